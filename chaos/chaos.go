@@ -0,0 +1,135 @@
+// Package chaos provides config-gated fault injection for staging
+// environments: random database errors, added database latency and webhook
+// delivery failures. It lets integration tests exercise retries, idempotency
+// and circuit breakers end to end. It is never enabled in production.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInjected is returned by operations failed on purpose
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Settings are the fault rates. Rates are probabilities between 0 and 1.
+type Settings struct {
+	DBErrorRate        float64 `json:"db_error_rate" example:"0.05"`
+	DBLatencyMs        int     `json:"db_latency_ms" example:"200"`
+	DBLatencyRate      float64 `json:"db_latency_rate" example:"0.2"`
+	WebhookFailureRate float64 `json:"webhook_failure_rate" example:"0.3"`
+}
+
+// Validate checks that rates are probabilities and latency is not negative
+func (s Settings) Validate() error {
+	for _, rate := range []float64{s.DBErrorRate, s.DBLatencyRate, s.WebhookFailureRate} {
+		if rate < 0 || rate > 1 {
+			return errors.New("rates must be between 0 and 1")
+		}
+	}
+	if s.DBLatencyMs < 0 || s.DBLatencyMs > 60000 {
+		return errors.New("db_latency_ms must be between 0 and 60000")
+	}
+	return nil
+}
+
+// Stats is a snapshot of the current settings and how many faults were injected
+type Stats struct {
+	Settings
+	DBErrorsInjected     int64 `json:"db_errors_injected" example:"12"`
+	DBDelaysInjected     int64 `json:"db_delays_injected" example:"48"`
+	WebhookFailsInjected int64 `json:"webhook_failures_injected" example:"3"`
+}
+
+// Injector decides when to inject faults. A nil *Injector never injects, so
+// callers can hold one unconditionally.
+type Injector struct {
+	mu       sync.RWMutex
+	settings Settings
+
+	dbErrors     atomic.Int64
+	dbDelays     atomic.Int64
+	webhookFails atomic.Int64
+}
+
+// NewInjector creates an injector with the given settings
+func NewInjector(settings Settings) (*Injector, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return &Injector{settings: settings}, nil
+}
+
+// Settings returns the current fault settings
+func (i *Injector) Settings() Settings {
+	if i == nil {
+		return Settings{}
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.settings
+}
+
+// Update replaces the fault settings at runtime
+func (i *Injector) Update(settings Settings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	i.settings = settings
+	i.mu.Unlock()
+	return nil
+}
+
+// Stats returns the current settings with injected fault counters
+func (i *Injector) Stats() Stats {
+	return Stats{
+		Settings:             i.Settings(),
+		DBErrorsInjected:     i.dbErrors.Load(),
+		DBDelaysInjected:     i.dbDelays.Load(),
+		WebhookFailsInjected: i.webhookFails.Load(),
+	}
+}
+
+// DB is called before a database operation. It may sleep for the configured
+// latency (returning early if ctx is cancelled) and may return ErrInjected.
+func (i *Injector) DB(ctx context.Context) error {
+	if i == nil {
+		return nil
+	}
+	s := i.Settings()
+
+	if s.DBLatencyMs > 0 && rand.Float64() < s.DBLatencyRate {
+		i.dbDelays.Add(1)
+		timer := time.NewTimer(time.Duration(s.DBLatencyMs) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if rand.Float64() < s.DBErrorRate {
+		i.dbErrors.Add(1)
+		return ErrInjected
+	}
+	return nil
+}
+
+// Webhook is called before a webhook delivery and returns ErrInjected when
+// the delivery should be treated as failed without being sent
+func (i *Injector) Webhook() error {
+	if i == nil {
+		return nil
+	}
+	if rand.Float64() < i.Settings().WebhookFailureRate {
+		i.webhookFails.Add(1)
+		return ErrInjected
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"retail-core-api/chaos"
+	"retail-core-api/models"
+	"strconv"
+)
+
+// ListUsers returns all users
+func (c *Client) ListUsers(ctx context.Context, opts ...RequestOption) ([]models.User, error) {
+	var users []models.User
+	err := c.do(ctx, http.MethodGet, "/v1/users", nil, nil, &users, opts...)
+	return users, err
+}
+
+// GetUser returns a user by ID
+func (c *Client) GetUser(ctx context.Context, id int, opts ...RequestOption) (*models.User, error) {
+	var user models.User
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/users/%d", id), nil, nil, &user, opts...); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUser replaces a user
+func (c *Client) UpdateUser(ctx context.Context, id int, input models.UserInput, opts ...RequestOption) (*models.User, error) {
+	var user models.User
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/users/%d", id), nil, input, &user, opts...); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// DeleteUser deletes a user
+func (c *Client) DeleteUser(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/users/%d", id), nil, nil, nil, opts...)
+}
+
+// ListAuditLogs returns a page of the audit log, newest first
+func (c *Client) ListAuditLogs(ctx context.Context, params models.AuditLogParams, opts ...RequestOption) (*models.PaginatedAuditLogs, error) {
+	q := url.Values{}
+	setString(q, "entity_type", params.EntityType)
+	setIntPtr(q, "entity_id", params.EntityID)
+	setIntPtr(q, "actor_id", params.ActorID)
+	setString(q, "action", params.Action)
+	setString(q, "start_date", params.StartDate)
+	setString(q, "end_date", params.EndDate)
+	setInt(q, "page", params.Page)
+	setInt(q, "limit", params.Limit)
+
+	var page models.PaginatedAuditLogs
+	meta, err := c.doPage(ctx, http.MethodGet, "/v1/audit-logs", q, nil, &page.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	page.Page, page.Limit, page.Total, page.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &page, nil
+}
+
+// GetChaosSettings returns the fault injection settings and counters. The
+// endpoint only exists when the server runs with CHAOS_ENABLED.
+func (c *Client) GetChaosSettings(ctx context.Context, opts ...RequestOption) (*chaos.Stats, error) {
+	var stats chaos.Stats
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/chaos", nil, nil, &stats, opts...); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// UpdateChaosSettings replaces the fault injection settings
+func (c *Client) UpdateChaosSettings(ctx context.Context, settings chaos.Settings, opts ...RequestOption) (*chaos.Stats, error) {
+	var stats chaos.Stats
+	if err := c.do(ctx, http.MethodPut, "/v1/admin/chaos", nil, settings, &stats, opts...); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetSchemaVersion returns the latest schema migration applied to the
+// server's database; resync cached data when its version changes
+func (c *Client) GetSchemaVersion(ctx context.Context, opts ...RequestOption) (*models.SchemaVersion, error) {
+	var version models.SchemaVersion
+	if err := c.do(ctx, http.MethodGet, "/v1/meta/schema-version", nil, nil, &version, opts...); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// ListMigrations returns the applied schema migrations, oldest first
+func (c *Client) ListMigrations(ctx context.Context, opts ...RequestOption) ([]models.SchemaMigration, error) {
+	var migrations []models.SchemaMigration
+	err := c.do(ctx, http.MethodGet, "/v1/meta/migrations", nil, nil, &migrations, opts...)
+	return migrations, err
+}
+
+// GetDataQualityReport returns how many products fail each catalog data
+// quality check; staleDays 0 uses the server default
+func (c *Client) GetDataQualityReport(ctx context.Context, staleDays int, opts ...RequestOption) (*models.DataQualityReport, error) {
+	q := url.Values{}
+	setInt(q, "stale_days", staleDays)
+
+	var report models.DataQualityReport
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/data-quality", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListDataQualityIssues returns a page of the products failing one data
+// quality check
+func (c *Client) ListDataQualityIssues(ctx context.Context, params models.DataQualityParams, opts ...RequestOption) (*models.PaginatedDataQualityIssues, error) {
+	q := url.Values{}
+	setInt(q, "stale_days", params.StaleDays)
+	setInt(q, "page", params.Page)
+	setInt(q, "limit", params.Limit)
+
+	var page models.PaginatedDataQualityIssues
+	meta, err := c.doPage(ctx, http.MethodGet, "/v1/admin/data-quality/"+url.PathEscape(params.Check), q, nil, &page.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	page.Page, page.Limit, page.Total, page.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &page, nil
+}
+
+// CheckTransactionTotals returns the transactions of a day (YYYY-MM-DD, empty
+// for today) whose recorded total differs from their lines
+func (c *Client) CheckTransactionTotals(ctx context.Context, date string, opts ...RequestOption) (*models.TransactionConsistencyReport, error) {
+	q := url.Values{}
+	setString(q, "date", date)
+
+	var report models.TransactionConsistencyReport
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/consistency/transactions", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RepairTransactionTotals sets the recorded total of the approved mismatched
+// transactions to the total of their lines
+func (c *Client) RepairTransactionTotals(ctx context.Context, input models.TransactionRepairInput, opts ...RequestOption) (*models.TransactionRepairResult, error) {
+	var result models.TransactionRepairResult
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/consistency/transactions/repair", nil, input, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CheckStock returns the products whose stock drifted from the stock
+// movement ledger
+func (c *Client) CheckStock(ctx context.Context, opts ...RequestOption) (*models.StockConsistencyReport, error) {
+	var report models.StockConsistencyReport
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/consistency/stock", nil, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RepairStock rebuilds the stock of the approved drifted products from the
+// ledger
+func (c *Client) RepairStock(ctx context.Context, input models.StockRepairInput, opts ...RequestOption) (*models.StockRepairResult, error) {
+	var result models.StockRepairResult
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/consistency/stock/repair", nil, input, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSyncStatus returns the state of replication to the upstream instance
+func (c *Client) GetSyncStatus(ctx context.Context, opts ...RequestOption) (*models.SyncStatus, error) {
+	var status models.SyncStatus
+	if err := c.do(ctx, http.MethodGet, "/v1/sync/status", nil, nil, &status, opts...); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RunSync replicates pending entities upstream now; a run that could not
+// reach upstream still succeeds, with the error in the run
+func (c *Client) RunSync(ctx context.Context, opts ...RequestOption) (*models.SyncRun, error) {
+	var run models.SyncRun
+	if err := c.do(ctx, http.MethodPost, "/v1/sync/run", nil, nil, &run, opts...); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// RetrySyncConflicts queues every sync conflict for the next run, returning
+// how many there were
+func (c *Client) RetrySyncConflicts(ctx context.Context, opts ...RequestOption) (int, error) {
+	var result struct {
+		Retried int `json:"retried"`
+	}
+	err := c.do(ctx, http.MethodPost, "/v1/sync/retry", nil, nil, &result, opts...)
+	return result.Retried, err
+}
+
+// ListWebhooks returns all webhooks (owner only); secrets are not included
+func (c *Client) ListWebhooks(ctx context.Context, opts ...RequestOption) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := c.do(ctx, http.MethodGet, "/v1/webhooks", nil, nil, &webhooks, opts...)
+	return webhooks, err
+}
+
+// GetWebhook returns a webhook by ID
+func (c *Client) GetWebhook(ctx context.Context, id int, opts ...RequestOption) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/webhooks/%d", id), nil, nil, &webhook, opts...); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// CreateWebhook registers a webhook; the returned webhook carries its
+// signing secret, which is not returned again
+func (c *Client) CreateWebhook(ctx context.Context, input models.WebhookInput, opts ...RequestOption) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := c.do(ctx, http.MethodPost, "/v1/webhooks", nil, input, &webhook, opts...); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// UpdateWebhook replaces a webhook; its secret is kept
+func (c *Client) UpdateWebhook(ctx context.Context, id int, input models.WebhookInput, opts ...RequestOption) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/webhooks/%d", id), nil, input, &webhook, opts...); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (c *Client) DeleteWebhook(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/webhooks/%d", id), nil, nil, nil, opts...)
+}
+
+// ListWebhookDeliveries returns a page of a webhook's delivery log, newest
+// first; status ("pending", "delivered" or "failed") is optional
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id int, status string, page, limit int, opts ...RequestOption) (*models.PaginatedWebhookDeliveries, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	setInt(q, "page", page)
+	setInt(q, "limit", limit)
+
+	var deliveries models.PaginatedWebhookDeliveries
+	meta, err := c.doPage(ctx, http.MethodGet, fmt.Sprintf("/v1/webhooks/%d/deliveries", id), q, nil, &deliveries.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	deliveries.Page, deliveries.Limit, deliveries.Total, deliveries.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &deliveries, nil
+}
+
+// GetWebhookDelivery returns a delivery of a webhook with every attempt made at it
+func (c *Client) GetWebhookDelivery(ctx context.Context, id, deliveryID int, opts ...RequestOption) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	path := fmt.Sprintf("/v1/webhooks/%d/deliveries/%d", id, deliveryID)
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &delivery, opts...); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// RedeliverWebhook queues a delivered or failed delivery of a webhook again
+func (c *Client) RedeliverWebhook(ctx context.Context, id, deliveryID int, opts ...RequestOption) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	path := fmt.Sprintf("/v1/webhooks/%d/deliveries/%d/redeliver", id, deliveryID)
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &delivery, opts...); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListTenants returns every tenant of a multi-tenant server; the client needs
+// WithPlatformKey
+func (c *Client) ListTenants(ctx context.Context, opts ...RequestOption) ([]models.Tenant, error) {
+	var tenants []models.Tenant
+	err := c.do(ctx, http.MethodGet, "/platform/tenants", nil, nil, &tenants, opts...)
+	return tenants, err
+}
+
+// CreateTenant creates a tenant with its first owner account and returns its
+// API key, which the server does not show again
+func (c *Client) CreateTenant(ctx context.Context, input models.TenantInput, opts ...RequestOption) (*models.TenantAPIKey, error) {
+	var key models.TenantAPIKey
+	if err := c.do(ctx, http.MethodPost, "/platform/tenants", nil, input, &key, opts...); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RotateTenantAPIKey issues a new API key for a tenant, revoking the old one
+func (c *Client) RotateTenantAPIKey(ctx context.Context, id int, opts ...RequestOption) (*models.TenantAPIKey, error) {
+	var key models.TenantAPIKey
+	if err := c.do(ctx, http.MethodPost, "/platform/tenants/"+strconv.Itoa(id)+"/api-key", nil, nil, &key, opts...); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
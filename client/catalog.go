@@ -0,0 +1,481 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"retail-core-api/models"
+	"strconv"
+)
+
+// ProductWriteResult is the outcome of creating or updating a product. When
+// catalog approval applies to the caller, the change is queued and
+// PendingApproval is set instead of Product.
+type ProductWriteResult struct {
+	Product         *models.Product
+	PendingApproval *models.ProductChangeRequest
+}
+
+// ListCategories returns all categories
+func (c *Client) ListCategories(ctx context.Context, opts ...RequestOption) ([]models.Category, error) {
+	var categories []models.Category
+	err := c.do(ctx, http.MethodGet, "/v1/categories", nil, nil, &categories, opts...)
+	return categories, err
+}
+
+// GetCategoryTree returns the categories nested under their parents
+func (c *Client) GetCategoryTree(ctx context.Context, opts ...RequestOption) ([]models.CategoryTreeNode, error) {
+	var tree []models.CategoryTreeNode
+	err := c.do(ctx, http.MethodGet, "/v1/categories/tree", nil, nil, &tree, opts...)
+	return tree, err
+}
+
+// GetCategory returns a category by ID
+func (c *Client) GetCategory(ctx context.Context, id int, opts ...RequestOption) (*models.Category, error) {
+	var category models.Category
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/categories/%d", id), nil, nil, &category, opts...); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetCategoryBySlug returns a category by its slug
+func (c *Client) GetCategoryBySlug(ctx context.Context, slug string, opts ...RequestOption) (*models.Category, error) {
+	var category models.Category
+	if err := c.do(ctx, http.MethodGet, "/v1/categories/slug/"+url.PathEscape(slug), nil, nil, &category, opts...); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// ListCategoryProducts returns the products of a category, optionally
+// including those of all its subcategories
+func (c *Client) ListCategoryProducts(ctx context.Context, id int, includeDescendants bool, opts ...RequestOption) ([]models.Product, error) {
+	q := url.Values{}
+	if includeDescendants {
+		q.Set("include_descendants", strconv.FormatBool(true))
+	}
+	var products []models.Product
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/categories/%d/products", id), q, nil, &products, opts...)
+	return products, err
+}
+
+// CreateCategory creates a category
+func (c *Client) CreateCategory(ctx context.Context, input models.CategoryInput, opts ...RequestOption) (*models.Category, error) {
+	var category models.Category
+	if err := c.do(ctx, http.MethodPost, "/v1/categories", nil, input, &category, opts...); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// UpdateCategory replaces a category
+func (c *Client) UpdateCategory(ctx context.Context, id int, input models.CategoryInput, opts ...RequestOption) (*models.Category, error) {
+	var category models.Category
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/categories/%d", id), nil, input, &category, opts...); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory deletes a category
+func (c *Client) DeleteCategory(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/categories/%d", id), nil, nil, nil, opts...)
+}
+
+// ListProducts returns a page of products
+func (c *Client) ListProducts(ctx context.Context, params models.ProductListParams, opts ...RequestOption) (*models.PaginatedProducts, error) {
+	q := url.Values{}
+	setString(q, "search", params.Search)
+	setIntPtr(q, "category_id", params.CategoryID)
+	setInt(q, "page", params.Page)
+	setInt(q, "limit", params.Limit)
+
+	var page models.PaginatedProducts
+	meta, err := c.doPage(ctx, http.MethodGet, "/v1/products", q, nil, &page.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	page.Page, page.Limit, page.Total, page.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &page, nil
+}
+
+// GetProduct returns a product by ID
+func (c *Client) GetProduct(ctx context.Context, id int, opts ...RequestOption) (*models.Product, error) {
+	var product models.Product
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/products/%d", id), nil, nil, &product, opts...); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProductBySlug returns a product by its slug
+func (c *Client) GetProductBySlug(ctx context.Context, slug string, opts ...RequestOption) (*models.Product, error) {
+	var product models.Product
+	if err := c.do(ctx, http.MethodGet, "/v1/products/slug/"+url.PathEscape(slug), nil, nil, &product, opts...); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// CreateProduct creates a product, or submits it for approval
+func (c *Client) CreateProduct(ctx context.Context, input models.ProductInput, opts ...RequestOption) (*ProductWriteResult, error) {
+	return c.writeProduct(ctx, http.MethodPost, "/v1/products", input, opts)
+}
+
+// UpdateProduct replaces a product, or submits a price change for approval
+func (c *Client) UpdateProduct(ctx context.Context, id int, input models.ProductInput, opts ...RequestOption) (*ProductWriteResult, error) {
+	return c.writeProduct(ctx, http.MethodPut, fmt.Sprintf("/v1/products/%d", id), input, opts)
+}
+
+// writeProduct sends a product write and tells a product from a queued change
+// request apart by the payload: only change requests carry a status
+func (c *Client) writeProduct(ctx context.Context, method, path string, input models.ProductInput, opts []RequestOption) (*ProductWriteResult, error) {
+	var probe struct {
+		Status string `json:"status"`
+	}
+	var raw rawJSON
+	if err := c.do(ctx, method, path, nil, input, &raw, opts...); err != nil {
+		return nil, err
+	}
+	if err := raw.decode(&probe); err != nil {
+		return nil, err
+	}
+
+	if probe.Status != "" {
+		var req models.ProductChangeRequest
+		if err := raw.decode(&req); err != nil {
+			return nil, err
+		}
+		return &ProductWriteResult{PendingApproval: &req}, nil
+	}
+	var product models.Product
+	if err := raw.decode(&product); err != nil {
+		return nil, err
+	}
+	return &ProductWriteResult{Product: &product}, nil
+}
+
+// DeleteProduct deletes a product
+func (c *Client) DeleteProduct(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/products/%d", id), nil, nil, nil, opts...)
+}
+
+// ImportProducts creates or updates products from a CSV with a header row of
+// name, sku, price, stock and category (owner only). With dryRun the server
+// only validates the rows and reports what the import would do.
+func (c *Client) ImportProducts(ctx context.Context, csv []byte, dryRun bool, opts ...RequestOption) (*models.ProductImportResult, error) {
+	q := url.Values{}
+	if dryRun {
+		q.Set("dry_run", "true")
+	}
+
+	var result models.ProductImportResult
+	if err := c.do(ctx, http.MethodPost, "/v1/products/import", q, csvBody(csv), &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportProducts downloads every product as "csv" or "xlsx"
+func (c *Client) ExportProducts(ctx context.Context, format string, opts ...RequestOption) ([]byte, error) {
+	q := url.Values{}
+	setString(q, "format", format)
+	return c.send(ctx, http.MethodGet, "/v1/products/export", q, nil, opts)
+}
+
+// ExportCategories downloads every category as "csv" or "xlsx"
+func (c *Client) ExportCategories(ctx context.Context, format string, opts ...RequestOption) ([]byte, error) {
+	q := url.Values{}
+	setString(q, "format", format)
+	return c.send(ctx, http.MethodGet, "/v1/categories/export", q, nil, opts)
+}
+
+// GetPriceHistory returns every selling price change of a product, newest first
+func (c *Client) GetPriceHistory(ctx context.Context, id int, opts ...RequestOption) ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/products/%d/price-history", id), nil, nil, &changes, opts...)
+	return changes, err
+}
+
+// ListScheduledPrices returns the scheduled price changes of a product,
+// optionally only those with the given status
+func (c *Client) ListScheduledPrices(ctx context.Context, id int, status string, opts ...RequestOption) ([]models.ScheduledPrice, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	var scheduled []models.ScheduledPrice
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/products/%d/scheduled-prices", id), q, nil, &scheduled, opts...)
+	return scheduled, err
+}
+
+// SchedulePrice sets a price that takes effect at input.EffectiveAt (owner only)
+func (c *Client) SchedulePrice(ctx context.Context, id int, input models.ScheduledPriceInput, opts ...RequestOption) (*models.ScheduledPrice, error) {
+	var scheduled models.ScheduledPrice
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/products/%d/scheduled-prices", id), nil, input, &scheduled, opts...); err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+// CancelScheduledPrice withdraws a pending price change (owner only)
+func (c *Client) CancelScheduledPrice(ctx context.Context, id, scheduleID int, opts ...RequestOption) (*models.ScheduledPrice, error) {
+	var scheduled models.ScheduledPrice
+	path := fmt.Sprintf("/v1/products/%d/scheduled-prices/%d", id, scheduleID)
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &scheduled, opts...); err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+// ListPriceTiers returns the price levels and quantity breaks of a product
+func (c *Client) ListPriceTiers(ctx context.Context, id int, opts ...RequestOption) ([]models.PriceTier, error) {
+	var tiers []models.PriceTier
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/products/%d/price-tiers", id), nil, nil, &tiers, opts...)
+	return tiers, err
+}
+
+// ReplacePriceTiers sets the complete list of price tiers of a product (owner only)
+func (c *Client) ReplacePriceTiers(ctx context.Context, id int, tiers []models.PriceTierInput, opts ...RequestOption) ([]models.PriceTier, error) {
+	var saved []models.PriceTier
+	input := models.PriceTiersInput{Tiers: tiers}
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/products/%d/price-tiers", id), nil, input, &saved, opts...)
+	return saved, err
+}
+
+// ListProductRelations returns the related products of a product, optionally
+// of one relation type
+func (c *Client) ListProductRelations(ctx context.Context, id int, relationType string, opts ...RequestOption) ([]models.ProductRelation, error) {
+	q := url.Values{}
+	setString(q, "type", relationType)
+	var relations []models.ProductRelation
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/products/%d/relations", id), q, nil, &relations, opts...)
+	return relations, err
+}
+
+// AddProductRelation links a related product
+func (c *Client) AddProductRelation(ctx context.Context, id int, input models.ProductRelationInput, opts ...RequestOption) (*models.ProductRelation, error) {
+	var relation models.ProductRelation
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/products/%d/relations", id), nil, input, &relation, opts...); err != nil {
+		return nil, err
+	}
+	return &relation, nil
+}
+
+// RemoveProductRelation unlinks a related product
+func (c *Client) RemoveProductRelation(ctx context.Context, id int, relationType string, relatedID int, opts ...RequestOption) error {
+	path := fmt.Sprintf("/v1/products/%d/relations/%s/%d", id, url.PathEscape(relationType), relatedID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil, opts...)
+}
+
+// ListProductTranslations returns the localized names of a product
+func (c *Client) ListProductTranslations(ctx context.Context, id int, opts ...RequestOption) ([]models.Translation, error) {
+	var translations []models.Translation
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/products/%d/translations", id), nil, nil, &translations, opts...)
+	return translations, err
+}
+
+// UpsertProductTranslation sets the localized name of a product for a locale
+func (c *Client) UpsertProductTranslation(ctx context.Context, id int, locale string, input models.TranslationInput, opts ...RequestOption) (*models.Translation, error) {
+	var translation models.Translation
+	path := fmt.Sprintf("/v1/products/%d/translations/%s", id, url.PathEscape(locale))
+	if err := c.do(ctx, http.MethodPut, path, nil, input, &translation, opts...); err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// DeleteProductTranslation removes the localized name of a product for a locale
+func (c *Client) DeleteProductTranslation(ctx context.Context, id int, locale string, opts ...RequestOption) error {
+	path := fmt.Sprintf("/v1/products/%d/translations/%s", id, url.PathEscape(locale))
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil, opts...)
+}
+
+// ListCategoryTranslations returns the localized names of a category
+func (c *Client) ListCategoryTranslations(ctx context.Context, id int, opts ...RequestOption) ([]models.Translation, error) {
+	var translations []models.Translation
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/categories/%d/translations", id), nil, nil, &translations, opts...)
+	return translations, err
+}
+
+// UpsertCategoryTranslation sets the localized name of a category for a locale
+func (c *Client) UpsertCategoryTranslation(ctx context.Context, id int, locale string, input models.TranslationInput, opts ...RequestOption) (*models.Translation, error) {
+	var translation models.Translation
+	path := fmt.Sprintf("/v1/categories/%d/translations/%s", id, url.PathEscape(locale))
+	if err := c.do(ctx, http.MethodPut, path, nil, input, &translation, opts...); err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// DeleteCategoryTranslation removes the localized name of a category for a locale
+func (c *Client) DeleteCategoryTranslation(ctx context.Context, id int, locale string, opts ...RequestOption) error {
+	path := fmt.Sprintf("/v1/categories/%d/translations/%s", id, url.PathEscape(locale))
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil, opts...)
+}
+
+// ListChangeRequests returns catalog change requests, optionally by status
+func (c *Client) ListChangeRequests(ctx context.Context, status string, opts ...RequestOption) ([]models.ProductChangeRequest, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	var requests []models.ProductChangeRequest
+	err := c.do(ctx, http.MethodGet, "/v1/catalog/approvals", q, nil, &requests, opts...)
+	return requests, err
+}
+
+// GetChangeRequest returns a catalog change request by ID
+func (c *Client) GetChangeRequest(ctx context.Context, id int, opts ...RequestOption) (*models.ProductChangeRequest, error) {
+	var req models.ProductChangeRequest
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/catalog/approvals/%d", id), nil, nil, &req, opts...); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ApproveChangeRequest approves and applies a catalog change request
+func (c *Client) ApproveChangeRequest(ctx context.Context, id int, input models.ReviewInput, opts ...RequestOption) (*models.ProductChangeRequest, error) {
+	return c.reviewChangeRequest(ctx, id, "approve", input, opts)
+}
+
+// RejectChangeRequest rejects a catalog change request
+func (c *Client) RejectChangeRequest(ctx context.Context, id int, input models.ReviewInput, opts ...RequestOption) (*models.ProductChangeRequest, error) {
+	return c.reviewChangeRequest(ctx, id, "reject", input, opts)
+}
+
+func (c *Client) reviewChangeRequest(ctx context.Context, id int, action string, input models.ReviewInput, opts []RequestOption) (*models.ProductChangeRequest, error) {
+	var req models.ProductChangeRequest
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/catalog/approvals/%d/%s", id, action), nil, input, &req, opts...); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ListChangesets returns catalog changesets, optionally by status
+func (c *Client) ListChangesets(ctx context.Context, status string, opts ...RequestOption) ([]models.CatalogChangeset, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	var changesets []models.CatalogChangeset
+	err := c.do(ctx, http.MethodGet, "/v1/catalog/changesets", q, nil, &changesets, opts...)
+	return changesets, err
+}
+
+// GetChangeset returns a catalog changeset with its items
+func (c *Client) GetChangeset(ctx context.Context, id int, opts ...RequestOption) (*models.CatalogChangeset, error) {
+	var changeset models.CatalogChangeset
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/catalog/changesets/%d", id), nil, nil, &changeset, opts...); err != nil {
+		return nil, err
+	}
+	return &changeset, nil
+}
+
+// CreateChangeset creates a draft catalog changeset
+func (c *Client) CreateChangeset(ctx context.Context, input models.ChangesetInput, opts ...RequestOption) (*models.CatalogChangeset, error) {
+	var changeset models.CatalogChangeset
+	if err := c.do(ctx, http.MethodPost, "/v1/catalog/changesets", nil, input, &changeset, opts...); err != nil {
+		return nil, err
+	}
+	return &changeset, nil
+}
+
+// AddChangesetItem adds a draft product change to a changeset
+func (c *Client) AddChangesetItem(ctx context.Context, id int, input models.ChangesetItemInput, opts ...RequestOption) (*models.CatalogChangesetItem, error) {
+	var item models.CatalogChangesetItem
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/catalog/changesets/%d/items", id), nil, input, &item, opts...); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// RemoveChangesetItem removes a draft product change from a changeset
+func (c *Client) RemoveChangesetItem(ctx context.Context, id, itemID int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/catalog/changesets/%d/items/%d", id, itemID), nil, nil, nil, opts...)
+}
+
+// ScheduleChangeset schedules a changeset to publish at a given time
+func (c *Client) ScheduleChangeset(ctx context.Context, id int, input models.ChangesetScheduleInput, opts ...RequestOption) (*models.CatalogChangeset, error) {
+	return c.changesetAction(ctx, id, "schedule", input, opts)
+}
+
+// UnscheduleChangeset returns a scheduled changeset to draft
+func (c *Client) UnscheduleChangeset(ctx context.Context, id int, opts ...RequestOption) (*models.CatalogChangeset, error) {
+	return c.changesetAction(ctx, id, "unschedule", nil, opts)
+}
+
+// CancelChangeset cancels a changeset
+func (c *Client) CancelChangeset(ctx context.Context, id int, opts ...RequestOption) (*models.CatalogChangeset, error) {
+	return c.changesetAction(ctx, id, "cancel", nil, opts)
+}
+
+// PublishChangeset publishes a changeset immediately
+func (c *Client) PublishChangeset(ctx context.Context, id int, opts ...RequestOption) (*models.CatalogChangeset, error) {
+	return c.changesetAction(ctx, id, "publish", nil, opts)
+}
+
+func (c *Client) changesetAction(ctx context.Context, id int, action string, body interface{}, opts []RequestOption) (*models.CatalogChangeset, error) {
+	var changeset models.CatalogChangeset
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/catalog/changesets/%d/%s", id, action), nil, body, &changeset, opts...); err != nil {
+		return nil, err
+	}
+	return &changeset, nil
+}
+
+// ListCategoryRules returns the keyword rules used for category suggestions (owner only)
+func (c *Client) ListCategoryRules(ctx context.Context, opts ...RequestOption) ([]models.CategoryRule, error) {
+	var rules []models.CategoryRule
+	err := c.do(ctx, http.MethodGet, "/v1/category-rules", nil, nil, &rules, opts...)
+	return rules, err
+}
+
+// CreateCategoryRule saves a keyword rule (owner only)
+func (c *Client) CreateCategoryRule(ctx context.Context, input models.CategoryRuleInput, opts ...RequestOption) (*models.CategoryRule, error) {
+	var rule models.CategoryRule
+	if err := c.do(ctx, http.MethodPost, "/v1/category-rules", nil, input, &rule, opts...); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// DeleteCategoryRule removes a keyword rule (owner only)
+func (c *Client) DeleteCategoryRule(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/category-rules/%d", id), nil, nil, nil, opts...)
+}
+
+// ListCategorySuggestions returns the category suggestion review queue
+// (pending when status is empty, "all" for every status; owner only)
+func (c *Client) ListCategorySuggestions(ctx context.Context, status string, opts ...RequestOption) ([]models.CategorySuggestion, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	var suggestions []models.CategorySuggestion
+	err := c.do(ctx, http.MethodGet, "/v1/category-suggestions", q, nil, &suggestions, opts...)
+	return suggestions, err
+}
+
+// GenerateCategorySuggestions suggests categories for uncategorized products
+// that have no suggestion yet (owner only)
+func (c *Client) GenerateCategorySuggestions(ctx context.Context, opts ...RequestOption) (*models.CategorySuggestionRun, error) {
+	var run models.CategorySuggestionRun
+	if err := c.do(ctx, http.MethodPost, "/v1/category-suggestions/generate", nil, nil, &run, opts...); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// AcceptCategorySuggestion assigns the suggested category, or categoryID
+// when given, to the product (owner only)
+func (c *Client) AcceptCategorySuggestion(ctx context.Context, id int, categoryID *int, opts ...RequestOption) (*models.CategorySuggestion, error) {
+	var suggestion models.CategorySuggestion
+	input := models.CategorySuggestionAcceptInput{CategoryID: categoryID}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/category-suggestions/%d/accept", id), nil, input, &suggestion, opts...); err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+// RejectCategorySuggestion dismisses a suggestion (owner only)
+func (c *Client) RejectCategorySuggestion(ctx context.Context, id int, opts ...RequestOption) (*models.CategorySuggestion, error) {
+	var suggestion models.CategorySuggestion
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/category-suggestions/%d/reject", id), nil, nil, &suggestion, opts...); err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
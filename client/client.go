@@ -0,0 +1,485 @@
+// Package client is a Go client for the Retail Core API.
+//
+// Every endpoint is wrapped by a typed method on Client that takes and returns
+// the API's own models:
+//
+//	c := client.New("https://api.example.com")
+//	if err := c.Login(ctx, "admin@retail.com", "secret"); err != nil {
+//		return err
+//	}
+//	products, err := c.ListProducts(ctx, models.ProductListParams{Search: "indomie"})
+//
+// Requests that fail with a network error, 429, 502, 503 or 504 are retried
+// with exponential backoff. POST and PATCH requests are sent with an
+// Idempotency-Key header (generated per call unless WithIdempotencyKey is
+// given), so the server executes a retried checkout or receipt only once.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"retail-core-api/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Retry defaults
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 200 * time.Millisecond
+	DefaultMaxBackoff   = 5 * time.Second
+	DefaultTimeout      = 30 * time.Second
+)
+
+// Client calls the Retail Core API. It is safe for concurrent use.
+type Client struct {
+	baseURL      string
+	http         *http.Client
+	userAgent    string
+	maxRetries   int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+
+	apiKey      string
+	platformKey string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken authenticates requests with a JWT instead of calling Login
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithAPIKey sends a tenant API key with every request. On a multi-tenant
+// server it selects the tenant for login, registration and the public
+// endpoints; later calls are routed by the JWT.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) { c.apiKey = apiKey }
+}
+
+// WithPlatformKey sends the platform administration key needed by the
+// tenant management methods (ListTenants, CreateTenant, RotateTenantAPIKey)
+func WithPlatformKey(key string) Option {
+	return func(c *Client) { c.platformKey = key }
+}
+
+// WithHTTPClient replaces the default HTTP client (30s timeout)
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) { c.http = h }
+}
+
+// WithRetries sets how many times a failed request is retried and the initial
+// backoff, which doubles on every attempt up to DefaultMaxBackoff. Use 0
+// retries to disable retrying.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// New creates a client for the API at baseURL, e.g. "https://api.example.com"
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		http:         &http.Client{Timeout: DefaultTimeout},
+		userAgent:    "retail-core-api-go-client",
+		maxRetries:   DefaultMaxRetries,
+		retryBackoff: DefaultRetryBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns the JWT the client currently authenticates with
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// SetToken replaces the JWT the client authenticates with
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+}
+
+// RequestOption configures a single call
+type RequestOption func(*requestConfig)
+
+// requestConfig holds per-call settings
+type requestConfig struct {
+	idempotencyKey string
+	locale         string
+	storeID        int
+	filters        url.Values
+}
+
+// WithIdempotencyKey sends key as the Idempotency-Key of a POST or PATCH.
+// Reuse the same key when repeating an operation whose outcome is unknown
+// (e.g. after a crash) to have the server replay the original response.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *requestConfig) { r.idempotencyKey = key }
+}
+
+// WithLocale sets the Accept-Language header, e.g. "id-ID,en;q=0.8"
+func WithLocale(locale string) RequestOption {
+	return func(r *requestConfig) { r.locale = locale }
+}
+
+// WithStore scopes a dashboard or report call to one store. Without it the
+// figures are consolidated over every store.
+func WithStore(storeID int) RequestOption {
+	return func(r *requestConfig) { r.storeID = storeID }
+}
+
+// WithReportFilter narrows a sales report call to the sales rung up by a
+// cashier, of a category or paid by a payment method; zero values are not
+// sent. Its dates and store are ignored, use the call's arguments and
+// WithStore for those.
+func WithReportFilter(filter models.ReportFilter) RequestOption {
+	return func(r *requestConfig) {
+		r.filters = url.Values{}
+		if filter.CashierID > 0 {
+			r.filters.Set("cashier_id", strconv.Itoa(filter.CashierID))
+		}
+		if filter.CategoryID > 0 {
+			r.filters.Set("category_id", strconv.Itoa(filter.CategoryID))
+		}
+		if filter.PaymentMethod != "" {
+			r.filters.Set("payment_method", filter.PaymentMethod)
+		}
+	}
+}
+
+// Error is a non-2xx response from the API. Code identifies the error (see
+// helpers.Code*); for errors with a stable code, Message is in the language
+// asked for with WithLocale, English by default. Violations lists the invalid
+// fields of a request body that failed validation.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Detail     string
+	Violations []Violation
+}
+
+// Violation is one invalid field of a request body
+type Violation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// problem is an error response body (RFC 9457 problem details)
+type problem struct {
+	Title      string      `json:"title"`
+	Detail     string      `json:"detail"`
+	Code       string      `json:"code"`
+	Violations []Violation `json:"violations"`
+}
+
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("retail api: HTTP %d: %s: %s", e.StatusCode, e.Message, e.Detail)
+	}
+	return fmt.Sprintf("retail api: HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is a 404 from the API
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// pageMeta is the meta field of a paginated response
+type pageMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// envelope is the standard response body
+type envelope struct {
+	Status  bool            `json:"status"`
+	Message string          `json:"message"`
+	Code    string          `json:"code"`
+	Error   string          `json:"error"`
+	Data    json.RawMessage `json:"data"`
+	Meta    *pageMeta       `json:"meta"`
+}
+
+// Login exchanges email and password for a JWT used by later calls
+func (c *Client) Login(ctx context.Context, email, password string) error {
+	var result models.LoginResponse
+	err := c.do(ctx, http.MethodPost, "/v1/auth/login", nil, models.LoginInput{Email: email, Password: password}, &result)
+	if err != nil {
+		return err
+	}
+	c.SetToken(result.Token)
+	return nil
+}
+
+// Register creates a user account
+func (c *Client) Register(ctx context.Context, input models.UserInput) (*models.User, error) {
+	var user models.User
+	if err := c.do(ctx, http.MethodPost, "/v1/auth/register", nil, input, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// do sends a request and decodes the data field of the response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...RequestOption) error {
+	_, err := c.doPage(ctx, method, path, query, body, out, opts...)
+	return err
+}
+
+// doPage is do for paginated endpoints; it also returns the page metadata
+func (c *Client) doPage(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...RequestOption) (*pageMeta, error) {
+	raw, err := c.send(ctx, method, path, query, body, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, fmt.Errorf("retail api: decoding %s %s: %w", method, path, err)
+	}
+	if out != nil && len(env.Data) > 0 && string(env.Data) != "null" {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return nil, fmt.Errorf("retail api: decoding %s %s: %w", method, path, err)
+		}
+	}
+	if env.Meta == nil {
+		env.Meta = &pageMeta{}
+	}
+	return env.Meta, nil
+}
+
+// send performs a request with retries and returns the body of a 2xx response
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}, opts []RequestOption) ([]byte, error) {
+	var cfg requestConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var payload []byte
+	contentType := "application/json"
+	if csv, ok := body.(csvBody); ok {
+		payload, contentType = csv, "text/csv"
+	} else if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	// POST and PATCH are only safe to retry when the server can deduplicate them
+	if (method == http.MethodPost || method == http.MethodPatch) && cfg.idempotencyKey == "" {
+		cfg.idempotencyKey = newIdempotencyKey()
+	}
+
+	if cfg.storeID > 0 || len(cfg.filters) > 0 {
+		scoped := url.Values{}
+		for name, values := range query {
+			scoped[name] = values
+		}
+		for name, values := range cfg.filters {
+			scoped[name] = values
+		}
+		if cfg.storeID > 0 {
+			scoped.Set("store_id", strconv.Itoa(cfg.storeID))
+		}
+		query = scoped
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(ctx, method, target, payload, contentType, cfg)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.maxRetries {
+				return nil, err
+			}
+			if err := c.wait(ctx, attempt, ""); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		raw, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 300 {
+			return raw, nil
+		}
+		if retryableStatus(resp.StatusCode) && attempt < c.maxRetries {
+			if err := c.wait(ctx, attempt, resp.Header.Get("Retry-After")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return nil, apiError(resp.StatusCode, resp.Header.Get("Content-Type"), raw)
+	}
+}
+
+// attempt sends one HTTP request
+func (c *Client) attempt(ctx context.Context, method, target string, payload []byte, contentType string, cfg requestConfig) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(models.APIKeyHeader, c.apiKey)
+	}
+	if c.platformKey != "" {
+		req.Header.Set(models.PlatformKeyHeader, c.platformKey)
+	}
+	if cfg.idempotencyKey != "" {
+		req.Header.Set(models.IdempotencyKeyHeader, cfg.idempotencyKey)
+	}
+	if cfg.locale != "" {
+		req.Header.Set("Accept-Language", cfg.locale)
+	}
+	return c.http.Do(req)
+}
+
+// wait sleeps before the next attempt, honouring Retry-After when given
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	delay := time.Duration(float64(c.retryBackoff) * math.Pow(2, float64(attempt)))
+	if delay > c.maxBackoff {
+		delay = c.maxBackoff
+	}
+	// Full jitter spreads out clients retrying after the same outage
+	delay = time.Duration(mathrand.Int64N(int64(delay) + 1))
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// apiError builds an Error from an error response body: problem details, or
+// the envelope of servers from before /v1
+func apiError(status int, contentType string, raw []byte) error {
+	if strings.HasPrefix(contentType, "application/problem+json") {
+		var p problem
+		if err := json.Unmarshal(raw, &p); err == nil {
+			message := p.Detail
+			if message == "" {
+				message = p.Title
+			}
+			return &Error{StatusCode: status, Code: p.Code, Message: message, Violations: p.Violations}
+		}
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil || env.Message == "" {
+		return &Error{StatusCode: status, Message: http.StatusText(status)}
+	}
+	return &Error{StatusCode: status, Code: env.Code, Message: env.Message, Detail: env.Error}
+}
+
+// newIdempotencyKey returns a random 128-bit key
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// setInt adds a positive integer query parameter
+func setInt(q url.Values, name string, value int) {
+	if value > 0 {
+		q.Set(name, strconv.Itoa(value))
+	}
+}
+
+// setIntPtr adds an optional integer query parameter
+func setIntPtr(q url.Values, name string, value *int) {
+	if value != nil {
+		q.Set(name, strconv.Itoa(*value))
+	}
+}
+
+// setString adds a non-empty string query parameter
+func setString(q url.Values, name, value string) {
+	if value != "" {
+		q.Set(name, value)
+	}
+}
+
+// csvBody is a request body sent as text/csv instead of being JSON encoded
+type csvBody []byte
+
+// rawJSON keeps a data field undecoded when its type depends on the response
+type rawJSON []byte
+
+func (r *rawJSON) UnmarshalJSON(b []byte) error {
+	*r = append((*r)[:0], b...)
+	return nil
+}
+
+func (r rawJSON) decode(out interface{}) error {
+	return json.Unmarshal(r, out)
+}
@@ -0,0 +1,353 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"retail-core-api/models"
+)
+
+// ListStockMovements returns a page of the stock ledger of a product
+func (c *Client) ListStockMovements(ctx context.Context, params models.StockMovementParams, opts ...RequestOption) (*models.PaginatedStockMovements, error) {
+	q := url.Values{}
+	setString(q, "reason", params.Reason)
+	setInt(q, "store_id", params.StoreID)
+	setString(q, "start_date", params.StartDate)
+	setString(q, "end_date", params.EndDate)
+	setInt(q, "page", params.Page)
+	setInt(q, "limit", params.Limit)
+
+	var page models.PaginatedStockMovements
+	path := fmt.Sprintf("/v1/products/%d/stock-movements", params.ProductID)
+	meta, err := c.doPage(ctx, http.MethodGet, path, q, nil, &page.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	page.Page, page.Limit, page.Total, page.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &page, nil
+}
+
+// AdjustStock applies a manual stock change with a reason code
+func (c *Client) AdjustStock(ctx context.Context, productID int, input models.StockAdjustmentInput, opts ...RequestOption) (*models.StockMovement, error) {
+	var movement models.StockMovement
+	path := fmt.Sprintf("/v1/products/%d/stock-adjustments", productID)
+	if err := c.do(ctx, http.MethodPost, path, nil, input, &movement, opts...); err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
+
+// GetSpotCheckSample opens a spot-check count session over a random sample
+// of size products (0 uses the server default)
+func (c *Client) GetSpotCheckSample(ctx context.Context, size int, opts ...RequestOption) (*models.CountSession, error) {
+	q := url.Values{}
+	setInt(q, "size", size)
+	var session models.CountSession
+	if err := c.do(ctx, http.MethodGet, "/v1/inventory/spot-check-sample", q, nil, &session, opts...); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// CreateStocktake opens a stocktake count session at a store
+func (c *Client) CreateStocktake(ctx context.Context, input models.StocktakeInput, opts ...RequestOption) (*models.CountSession, error) {
+	var session models.CountSession
+	if err := c.do(ctx, http.MethodPost, "/v1/inventory/count-sessions", nil, input, &session, opts...); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListCountSessions returns count sessions, optionally filtered by status and
+// assigned user
+func (c *Client) ListCountSessions(ctx context.Context, status string, assignedTo *int, opts ...RequestOption) ([]models.CountSession, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	setIntPtr(q, "assigned_to", assignedTo)
+	var sessions []models.CountSession
+	err := c.do(ctx, http.MethodGet, "/v1/inventory/count-sessions", q, nil, &sessions, opts...)
+	return sessions, err
+}
+
+// GetCountSession returns a count session with its items
+func (c *Client) GetCountSession(ctx context.Context, id int, opts ...RequestOption) (*models.CountSession, error) {
+	var session models.CountSession
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/inventory/count-sessions/%d", id), nil, nil, &session, opts...); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RecordCount records the counted quantity of a product in a count session
+func (c *Client) RecordCount(ctx context.Context, sessionID, productID, countedQty int, opts ...RequestOption) (*models.CountSessionItem, error) {
+	var item models.CountSessionItem
+	path := fmt.Sprintf("/v1/inventory/count-sessions/%d/items/%d", sessionID, productID)
+	if err := c.do(ctx, http.MethodPut, path, nil, models.CountInput{CountedQty: &countedQty}, &item, opts...); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetCountVarianceReport returns the shrinkage and overage of a count session
+func (c *Client) GetCountVarianceReport(ctx context.Context, id int, opts ...RequestOption) (*models.CountVarianceReport, error) {
+	var report models.CountVarianceReport
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/inventory/count-sessions/%d/variance", id), nil, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// CompleteCountSession completes a count session and posts its variances
+func (c *Client) CompleteCountSession(ctx context.Context, id int, opts ...RequestOption) (*models.CountSession, error) {
+	return c.countSessionAction(ctx, id, "complete", opts)
+}
+
+// CancelCountSession cancels a count session
+func (c *Client) CancelCountSession(ctx context.Context, id int, opts ...RequestOption) (*models.CountSession, error) {
+	return c.countSessionAction(ctx, id, "cancel", opts)
+}
+
+func (c *Client) countSessionAction(ctx context.Context, id int, action string, opts []RequestOption) (*models.CountSession, error) {
+	var session models.CountSession
+	path := fmt.Sprintf("/v1/inventory/count-sessions/%d/%s", id, action)
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &session, opts...); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListCycleCountSchedules returns all cycle count schedules
+func (c *Client) ListCycleCountSchedules(ctx context.Context, opts ...RequestOption) ([]models.CycleCountSchedule, error) {
+	var schedules []models.CycleCountSchedule
+	err := c.do(ctx, http.MethodGet, "/v1/inventory/cycle-count-schedules", nil, nil, &schedules, opts...)
+	return schedules, err
+}
+
+// GetCycleCountSchedule returns a cycle count schedule by ID
+func (c *Client) GetCycleCountSchedule(ctx context.Context, id int, opts ...RequestOption) (*models.CycleCountSchedule, error) {
+	var schedule models.CycleCountSchedule
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/inventory/cycle-count-schedules/%d", id), nil, nil, &schedule, opts...); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// CreateCycleCountSchedule creates a cycle count schedule
+func (c *Client) CreateCycleCountSchedule(ctx context.Context, input models.CycleCountScheduleInput, opts ...RequestOption) (*models.CycleCountSchedule, error) {
+	var schedule models.CycleCountSchedule
+	if err := c.do(ctx, http.MethodPost, "/v1/inventory/cycle-count-schedules", nil, input, &schedule, opts...); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// UpdateCycleCountSchedule replaces a cycle count schedule
+func (c *Client) UpdateCycleCountSchedule(ctx context.Context, id int, input models.CycleCountScheduleInput, opts ...RequestOption) (*models.CycleCountSchedule, error) {
+	var schedule models.CycleCountSchedule
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/inventory/cycle-count-schedules/%d", id), nil, input, &schedule, opts...); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// DeleteCycleCountSchedule deletes a cycle count schedule
+func (c *Client) DeleteCycleCountSchedule(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/inventory/cycle-count-schedules/%d", id), nil, nil, nil, opts...)
+}
+
+// RunCycleCountSchedule opens a count session for a schedule now
+func (c *Client) RunCycleCountSchedule(ctx context.Context, id int, opts ...RequestOption) (*models.CountSession, error) {
+	var session models.CountSession
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/inventory/cycle-count-schedules/%d/run", id), nil, nil, &session, opts...); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetCycleCountCompliance returns on-time completion per schedule for a
+// date range (empty dates use the server default)
+func (c *Client) GetCycleCountCompliance(ctx context.Context, startDate, endDate string, opts ...RequestOption) ([]models.CycleCountCompliance, error) {
+	q := url.Values{}
+	setString(q, "start_date", startDate)
+	setString(q, "end_date", endDate)
+	var compliance []models.CycleCountCompliance
+	err := c.do(ctx, http.MethodGet, "/v1/inventory/cycle-count-compliance", q, nil, &compliance, opts...)
+	return compliance, err
+}
+
+// ListSuppliers returns all suppliers
+func (c *Client) ListSuppliers(ctx context.Context, opts ...RequestOption) ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	err := c.do(ctx, http.MethodGet, "/v1/suppliers", nil, nil, &suppliers, opts...)
+	return suppliers, err
+}
+
+// GetSupplier returns a supplier by ID
+func (c *Client) GetSupplier(ctx context.Context, id int, opts ...RequestOption) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/suppliers/%d", id), nil, nil, &supplier, opts...); err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// CreateSupplier creates a supplier
+func (c *Client) CreateSupplier(ctx context.Context, input models.SupplierInput, opts ...RequestOption) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := c.do(ctx, http.MethodPost, "/v1/suppliers", nil, input, &supplier, opts...); err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// UpdateSupplier replaces a supplier
+func (c *Client) UpdateSupplier(ctx context.Context, id int, input models.SupplierInput, opts ...RequestOption) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/suppliers/%d", id), nil, input, &supplier, opts...); err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// DeleteSupplier deletes a supplier
+func (c *Client) DeleteSupplier(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/suppliers/%d", id), nil, nil, nil, opts...)
+}
+
+// ListPurchaseOrders returns purchase orders, optionally filtered by status
+// and supplier
+func (c *Client) ListPurchaseOrders(ctx context.Context, status string, supplierID *int, opts ...RequestOption) ([]models.PurchaseOrder, error) {
+	q := url.Values{}
+	setString(q, "status", status)
+	setIntPtr(q, "supplier_id", supplierID)
+	var orders []models.PurchaseOrder
+	err := c.do(ctx, http.MethodGet, "/v1/purchase-orders", q, nil, &orders, opts...)
+	return orders, err
+}
+
+// GetPurchaseOrder returns a purchase order with its items and receipts
+func (c *Client) GetPurchaseOrder(ctx context.Context, id int, opts ...RequestOption) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/purchase-orders/%d", id), nil, nil, &order, opts...); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CreatePurchaseOrder creates a purchase order
+func (c *Client) CreatePurchaseOrder(ctx context.Context, input models.PurchaseOrderInput, opts ...RequestOption) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := c.do(ctx, http.MethodPost, "/v1/purchase-orders", nil, input, &order, opts...); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ReceivePurchaseOrder records a full or partial delivery against a purchase
+// order. Retries are deduplicated by the Idempotency-Key, so stock is never
+// received twice.
+func (c *Client) ReceivePurchaseOrder(ctx context.Context, id int, input models.ReceiveInput, opts ...RequestOption) (*models.GoodsReceipt, error) {
+	var receipt models.GoodsReceipt
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/purchase-orders/%d/receive", id), nil, input, &receipt, opts...); err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// CancelPurchaseOrder cancels a purchase order
+func (c *Client) CancelPurchaseOrder(ctx context.Context, id int, opts ...RequestOption) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/purchase-orders/%d/cancel", id), nil, nil, &order, opts...); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListStores returns every store location, the default store first
+func (c *Client) ListStores(ctx context.Context, opts ...RequestOption) ([]models.Store, error) {
+	var stores []models.Store
+	err := c.do(ctx, http.MethodGet, "/v1/stores", nil, nil, &stores, opts...)
+	return stores, err
+}
+
+// GetStore returns a store by ID
+func (c *Client) GetStore(ctx context.Context, id int, opts ...RequestOption) (*models.Store, error) {
+	var store models.Store
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/stores/%d", id), nil, nil, &store, opts...); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// CreateStore adds a store location (owner only)
+func (c *Client) CreateStore(ctx context.Context, input models.StoreInput, opts ...RequestOption) (*models.Store, error) {
+	var store models.Store
+	if err := c.do(ctx, http.MethodPost, "/v1/stores", nil, input, &store, opts...); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// UpdateStore updates a store location (owner only)
+func (c *Client) UpdateStore(ctx context.Context, id int, input models.StoreInput, opts ...RequestOption) (*models.Store, error) {
+	var store models.Store
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/stores/%d", id), nil, input, &store, opts...); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// GetStoreStock returns the stock of every product at a store
+func (c *Client) GetStoreStock(ctx context.Context, id int, opts ...RequestOption) ([]models.StoreStock, error) {
+	var stock []models.StoreStock
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/stores/%d/stock", id), nil, nil, &stock, opts...)
+	return stock, err
+}
+
+// ListStockTransfers returns stock transfers, newest first, optionally
+// filtered by status and by a store on either side
+func (c *Client) ListStockTransfers(ctx context.Context, params models.StockTransferParams, opts ...RequestOption) ([]models.StockTransfer, error) {
+	q := url.Values{}
+	setString(q, "status", params.Status)
+	setInt(q, "store_id", params.StoreID)
+	var transfers []models.StockTransfer
+	err := c.do(ctx, http.MethodGet, "/v1/stock-transfers", q, nil, &transfers, opts...)
+	return transfers, err
+}
+
+// GetStockTransfer returns a stock transfer with its lines
+func (c *Client) GetStockTransfer(ctx context.Context, id int, opts ...RequestOption) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/stock-transfers/%d", id), nil, nil, &transfer, opts...); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// CreateStockTransfer sends stock from one store to another
+func (c *Client) CreateStockTransfer(ctx context.Context, input models.StockTransferInput, opts ...RequestOption) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	if err := c.do(ctx, http.MethodPost, "/v1/stock-transfers", nil, input, &transfer, opts...); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ReceiveStockTransfer confirms that a transfer arrived at the destination store
+func (c *Client) ReceiveStockTransfer(ctx context.Context, id int, opts ...RequestOption) (*models.StockTransfer, error) {
+	return c.stockTransferAction(ctx, id, "receive", opts)
+}
+
+// CancelStockTransfer returns an in-transit transfer to the source store (owner only)
+func (c *Client) CancelStockTransfer(ctx context.Context, id int, opts ...RequestOption) (*models.StockTransfer, error) {
+	return c.stockTransferAction(ctx, id, "cancel", opts)
+}
+
+func (c *Client) stockTransferAction(ctx context.Context, id int, action string, opts []RequestOption) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/stock-transfers/%d/%s", id, action), nil, nil, &transfer, opts...); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
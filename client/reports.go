@@ -0,0 +1,236 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"retail-core-api/models"
+)
+
+// GetDashboard returns the dashboard statistics
+func (c *Client) GetDashboard(ctx context.Context, opts ...RequestOption) (*models.DashboardStats, error) {
+	var stats models.DashboardStats
+	if err := c.do(ctx, http.MethodGet, "/v1/dashboard", nil, nil, &stats, opts...); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetTodayReport returns today's sales report
+func (c *Client) GetTodayReport(ctx context.Context, opts ...RequestOption) (*models.SalesReport, error) {
+	var report models.SalesReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/today", nil, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetSalesReport returns the sales report for a date range (YYYY-MM-DD)
+func (c *Client) GetSalesReport(ctx context.Context, startDate, endDate string, opts ...RequestOption) (*models.SalesReport, error) {
+	var report models.SalesReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report", dateRange(startDate, endDate), nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetReportSummary returns the report summary for a date range (YYYY-MM-DD)
+func (c *Client) GetReportSummary(ctx context.Context, startDate, endDate string, opts ...RequestOption) (*models.ReportSummary, error) {
+	var summary models.ReportSummary
+	if err := c.do(ctx, http.MethodGet, "/v1/report/summary", dateRange(startDate, endDate), nil, &summary, opts...); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// GetProfitReport returns gross profit per product and category for a date
+// range (YYYY-MM-DD)
+func (c *Client) GetProfitReport(ctx context.Context, startDate, endDate string, opts ...RequestOption) (*models.ProfitReport, error) {
+	var report models.ProfitReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/profit", dateRange(startDate, endDate), nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetBestSellersReport returns the top limit products by quantity sold in a
+// date range (YYYY-MM-DD); limit 0 uses the server default
+func (c *Client) GetBestSellersReport(ctx context.Context, startDate, endDate string, limit int, opts ...RequestOption) (*models.BestSellersReport, error) {
+	q := dateRange(startDate, endDate)
+	setInt(q, "limit", limit)
+
+	var report models.BestSellersReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/best-sellers", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetHourlySalesReport returns transactions and revenue per hour of a day
+// (YYYY-MM-DD); an empty date is today
+func (c *Client) GetHourlySalesReport(ctx context.Context, date string, opts ...RequestOption) (*models.HourlySalesReport, error) {
+	q := url.Values{}
+	if date != "" {
+		q.Set("date", date)
+	}
+
+	var report models.HourlySalesReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/hourly", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetSalesTimeSeries returns revenue, transactions and items sold per day,
+// week or month (granularity, empty for day) of a date range (YYYY-MM-DD)
+func (c *Client) GetSalesTimeSeries(ctx context.Context, granularity, startDate, endDate string, opts ...RequestOption) ([]models.SalesBucket, error) {
+	q := dateRange(startDate, endDate)
+	setString(q, "granularity", granularity)
+
+	var buckets []models.SalesBucket
+	err := c.do(ctx, http.MethodGet, "/v1/report/timeseries", q, nil, &buckets, opts...)
+	return buckets, err
+}
+
+// GetLowStockReport returns products at or below their minimum stock
+func (c *Client) GetLowStockReport(ctx context.Context, categoryID *int, opts ...RequestOption) ([]models.LowStockProduct, error) {
+	q := url.Values{}
+	setIntPtr(q, "category_id", categoryID)
+	var products []models.LowStockProduct
+	err := c.do(ctx, http.MethodGet, "/v1/report/low-stock", q, nil, &products, opts...)
+	return products, err
+}
+
+// GetReorderSuggestions returns the products to reorder now; zero parameters
+// use the server defaults
+func (c *Client) GetReorderSuggestions(ctx context.Context, params models.ReorderSuggestionParams, opts ...RequestOption) (*models.ReorderSuggestionReport, error) {
+	q := url.Values{}
+	setInt(q, "days", params.VelocityDays)
+	setInt(q, "lead_time_days", params.LeadTimeDays)
+	setInt(q, "cover_days", params.CoverDays)
+	setIntPtr(q, "category_id", params.CategoryID)
+	var report models.ReorderSuggestionReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/reorder-suggestions", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetInventoryValuation returns the FIFO value of the stock on hand
+func (c *Client) GetInventoryValuation(ctx context.Context, categoryID *int, opts ...RequestOption) (*models.InventoryValuation, error) {
+	q := url.Values{}
+	setIntPtr(q, "category_id", categoryID)
+	var valuation models.InventoryValuation
+	if err := c.do(ctx, http.MethodGet, "/v1/report/inventory-valuation", q, nil, &valuation, opts...); err != nil {
+		return nil, err
+	}
+	return &valuation, nil
+}
+
+// GetConsignmentReport returns the consignment settlement per supplier for a
+// date range (empty dates use the current month)
+func (c *Client) GetConsignmentReport(ctx context.Context, startDate, endDate string, supplierID *int, opts ...RequestOption) (*models.ConsignmentSettlementReport, error) {
+	q := dateRange(startDate, endDate)
+	setIntPtr(q, "supplier_id", supplierID)
+	var report models.ConsignmentSettlementReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/consignment", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// dateRange builds start_date/end_date query parameters
+func dateRange(startDate, endDate string) url.Values {
+	q := url.Values{}
+	setString(q, "start_date", startDate)
+	setString(q, "end_date", endDate)
+	return q
+}
+
+// GetStoreSalesReport returns revenue consolidated over every store with a
+// breakdown per store for a date range (YYYY-MM-DD, owner only)
+func (c *Client) GetStoreSalesReport(ctx context.Context, startDate, endDate string, opts ...RequestOption) (*models.StoreSalesReport, error) {
+	var report models.StoreSalesReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/stores", dateRange(startDate, endDate), nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetPromotionPerformance returns how every promotion performed over its
+// validity window, optionally clipped to a date range (YYYY-MM-DD) and one
+// store (owner only)
+func (c *Client) GetPromotionPerformance(ctx context.Context, startDate, endDate string, storeID int, opts ...RequestOption) (*models.PromotionPerformanceReport, error) {
+	q := dateRange(startDate, endDate)
+	setInt(q, "store_id", storeID)
+	var report models.PromotionPerformanceReport
+	if err := c.do(ctx, http.MethodGet, "/v1/report/promotions", q, nil, &report, opts...); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListReportSchedules returns all report schedules (owner only)
+func (c *Client) ListReportSchedules(ctx context.Context, opts ...RequestOption) ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := c.do(ctx, http.MethodGet, "/v1/report-schedules", nil, nil, &schedules, opts...)
+	return schedules, err
+}
+
+// GetReportSchedule returns a report schedule by ID
+func (c *Client) GetReportSchedule(ctx context.Context, id int, opts ...RequestOption) (*models.ReportSchedule, error) {
+	var schedule models.ReportSchedule
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/report-schedules/%d", id), nil, nil, &schedule, opts...); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// CreateReportSchedule creates a report schedule
+func (c *Client) CreateReportSchedule(ctx context.Context, input models.ReportScheduleInput, opts ...RequestOption) (*models.ReportSchedule, error) {
+	var schedule models.ReportSchedule
+	if err := c.do(ctx, http.MethodPost, "/v1/report-schedules", nil, input, &schedule, opts...); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// UpdateReportSchedule replaces a report schedule
+func (c *Client) UpdateReportSchedule(ctx context.Context, id int, input models.ReportScheduleInput, opts ...RequestOption) (*models.ReportSchedule, error) {
+	var schedule models.ReportSchedule
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/report-schedules/%d", id), nil, input, &schedule, opts...); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// DeleteReportSchedule deletes a report schedule and its run history
+func (c *Client) DeleteReportSchedule(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/report-schedules/%d", id), nil, nil, nil, opts...)
+}
+
+// RunReportSchedule renders and uploads a schedule's report now. A failed
+// upload is returned as a run with status failed, not as an error.
+func (c *Client) RunReportSchedule(ctx context.Context, id int, opts ...RequestOption) (*models.ReportRun, error) {
+	var run models.ReportRun
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/report-schedules/%d/run", id), nil, nil, &run, opts...); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListReportRuns returns a page of a schedule's run history, newest first
+func (c *Client) ListReportRuns(ctx context.Context, id, page, limit int, opts ...RequestOption) (*models.PaginatedReportRuns, error) {
+	q := url.Values{}
+	setInt(q, "page", page)
+	setInt(q, "limit", limit)
+
+	var runs models.PaginatedReportRuns
+	meta, err := c.doPage(ctx, http.MethodGet, fmt.Sprintf("/v1/report-schedules/%d/runs", id), q, nil, &runs.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	runs.Page, runs.Limit, runs.Total, runs.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &runs, nil
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"retail-core-api/models"
+)
+
+// Checkout creates a transaction and deducts stock. Retries are deduplicated
+// by the Idempotency-Key, so a checkout is never charged twice.
+func (c *Client) Checkout(ctx context.Context, req models.CheckoutRequest, opts ...RequestOption) (*models.Transaction, error) {
+	var transaction models.Transaction
+	if err := c.do(ctx, http.MethodPost, "/v1/checkout", nil, req, &transaction, opts...); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// ListTransactions returns a page of transactions, newest first
+func (c *Client) ListTransactions(ctx context.Context, params models.TransactionListParams, opts ...RequestOption) (*models.PaginatedTransactions, error) {
+	q := url.Values{}
+	setString(q, "start_date", params.StartDate)
+	setString(q, "end_date", params.EndDate)
+	setString(q, "receipt_no", params.ReceiptNo)
+	setInt(q, "store_id", params.StoreID)
+	setInt(q, "page", params.Page)
+	setInt(q, "limit", params.Limit)
+
+	var page models.PaginatedTransactions
+	meta, err := c.doPage(ctx, http.MethodGet, "/v1/transactions", q, nil, &page.Data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	page.Page, page.Limit, page.Total, page.TotalPages = meta.Page, meta.Limit, meta.Total, meta.TotalPages
+	return &page, nil
+}
+
+// GetTransaction returns a transaction with its line items
+func (c *Client) GetTransaction(ctx context.Context, id int, opts ...RequestOption) (*models.Transaction, error) {
+	var transaction models.Transaction
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/transactions/%d", id), nil, nil, &transaction, opts...); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// VoidTransaction voids a transaction and restores its stock
+func (c *Client) VoidTransaction(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/v1/transactions/%d/void", id), nil, nil, nil, opts...)
+}
+
+// ShareReceipt creates a short-lived public link to a transaction receipt
+func (c *Client) ShareReceipt(ctx context.Context, id int, opts ...RequestOption) (*models.ReceiptShareLink, error) {
+	var link models.ReceiptShareLink
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/transactions/%d/share", id), nil, nil, &link, opts...); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetReceiptPDF returns the PDF receipt of a transaction
+func (c *Client) GetReceiptPDF(ctx context.Context, id int, opts ...RequestOption) ([]byte, error) {
+	return c.send(ctx, http.MethodGet, fmt.Sprintf("/v1/transactions/%d/receipt.pdf", id), nil, nil, opts)
+}
+
+// GetQueueStatus returns today's pickup queue
+func (c *Client) GetQueueStatus(ctx context.Context, opts ...RequestOption) (*models.QueueStatus, error) {
+	var status models.QueueStatus
+	if err := c.do(ctx, http.MethodGet, "/v1/queue", nil, nil, &status, opts...); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// CallNextQueueNumber advances the pickup queue by one
+func (c *Client) CallNextQueueNumber(ctx context.Context, opts ...RequestOption) (*models.QueueStatus, error) {
+	var status models.QueueStatus
+	if err := c.do(ctx, http.MethodPost, "/v1/queue/next", nil, nil, &status, opts...); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// CallQueueNumber calls a specific pickup queue number
+func (c *Client) CallQueueNumber(ctx context.Context, queueNo int, opts ...RequestOption) (*models.QueueStatus, error) {
+	var status models.QueueStatus
+	if err := c.do(ctx, http.MethodPost, "/v1/queue/call", nil, models.QueueCallInput{QueueNo: queueNo}, &status, opts...); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ListPromotions returns all promotions
+func (c *Client) ListPromotions(ctx context.Context, opts ...RequestOption) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := c.do(ctx, http.MethodGet, "/v1/promotions", nil, nil, &promotions, opts...)
+	return promotions, err
+}
+
+// GetPromotion returns a promotion by ID
+func (c *Client) GetPromotion(ctx context.Context, id int, opts ...RequestOption) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/promotions/%d", id), nil, nil, &promotion, opts...); err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// CreatePromotion creates a promotion rule
+func (c *Client) CreatePromotion(ctx context.Context, input models.PromotionInput, opts ...RequestOption) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := c.do(ctx, http.MethodPost, "/v1/promotions", nil, input, &promotion, opts...); err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// UpdatePromotion replaces a promotion rule
+func (c *Client) UpdatePromotion(ctx context.Context, id int, input models.PromotionInput, opts ...RequestOption) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/promotions/%d", id), nil, input, &promotion, opts...); err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// DeletePromotion deletes a promotion rule
+func (c *Client) DeletePromotion(ctx context.Context, id int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/promotions/%d", id), nil, nil, nil, opts...)
+}
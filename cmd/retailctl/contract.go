@@ -0,0 +1,333 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"retail-core-api/helpers"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Contract check results
+const (
+	contractPass = "PASS"
+	contractFail = "FAIL"
+	contractSkip = "SKIP"
+)
+
+// contractCheck is one GET endpoint whose payload is validated against a
+// published schema
+type contractCheck struct {
+	path      string
+	schema    string // schema of the data field, or of each element when list is set
+	list      bool
+	paginated bool
+	capture   string // remember the first element's id under this key for later paths
+}
+
+// contractChecks are read-only so the command is safe against any environment.
+// Paths may reference ids captured by earlier checks, or the report date
+// range, as {key}.
+var contractChecks = []contractCheck{
+	{path: "/v1/categories", schema: "models.Category", list: true, capture: "category"},
+	{path: "/v1/categories/tree", schema: "models.CategoryTreeNode", list: true},
+	{path: "/v1/categories/{category}", schema: "models.Category"},
+	{path: "/v1/category-rules", schema: "models.CategoryRule", list: true},
+	{path: "/v1/category-suggestions?status=all", schema: "models.CategorySuggestion", list: true},
+	{path: "/v1/products?limit=20", schema: "models.Product", list: true, paginated: true, capture: "product"},
+	{path: "/v1/products/{product}", schema: "models.Product"},
+	{path: "/v1/products/{product}/price-history", schema: "models.PriceChange", list: true},
+	{path: "/v1/products/{product}/scheduled-prices", schema: "models.ScheduledPrice", list: true},
+	{path: "/v1/products/{product}/price-tiers", schema: "models.PriceTier", list: true},
+	{path: "/v1/products/{product}/stock-movements", schema: "models.StockMovement", list: true, paginated: true},
+	{path: "/v1/products/{product}/relations", schema: "models.ProductRelation", list: true},
+	{path: "/v1/transactions?limit=20", schema: "models.TransactionListItem", list: true, paginated: true, capture: "transaction"},
+	{path: "/v1/transactions/{transaction}", schema: "models.Transaction"},
+	{path: "/v1/promotions", schema: "models.Promotion", list: true},
+	{path: "/v1/suppliers", schema: "models.Supplier", list: true},
+	{path: "/v1/stores", schema: "models.Store", list: true, capture: "store"},
+	{path: "/v1/stores/{store}", schema: "models.Store"},
+	{path: "/v1/stores/{store}/stock", schema: "models.StoreStock", list: true},
+	{path: "/v1/stock-transfers", schema: "models.StockTransfer", list: true, capture: "stock_transfer"},
+	{path: "/v1/stock-transfers/{stock_transfer}", schema: "models.StockTransfer"},
+	{path: "/v1/purchase-orders", schema: "models.PurchaseOrder", list: true, capture: "purchase_order"},
+	{path: "/v1/purchase-orders/{purchase_order}", schema: "models.PurchaseOrder"},
+	{path: "/v1/inventory/count-sessions", schema: "models.CountSession", list: true, capture: "count_session"},
+	{path: "/v1/inventory/count-sessions/{count_session}/variance", schema: "models.CountVarianceReport"},
+	{path: "/v1/queue", schema: "models.QueueStatus"},
+	{path: "/v1/dashboard", schema: "models.DashboardStats"},
+	{path: "/v1/report/today", schema: "models.SalesReport"},
+	{path: "/v1/report/summary?start_date={start_date}&end_date={end_date}", schema: "models.ReportSummary"},
+	{path: "/v1/report/by-category?start_date={start_date}&end_date={end_date}", schema: "models.CategorySalesReport"},
+	{path: "/v1/report/hourly", schema: "models.HourlySalesReport"},
+	{path: "/v1/report/timeseries?granularity=week&start_date={start_date}&end_date={end_date}", schema: "models.SalesBucket", list: true},
+	{path: "/v1/report/best-sellers?start_date={start_date}&end_date={end_date}", schema: "models.BestSellersReport"},
+	{path: "/v1/report/low-stock", schema: "models.LowStockProduct", list: true},
+	{path: "/v1/report/reorder-suggestions", schema: "models.ReorderSuggestionReport"},
+	{path: "/v1/report/consignment", schema: "models.ConsignmentSettlementReport"},
+	{path: "/v1/report/inventory-valuation", schema: "models.InventoryValuation"},
+	{path: "/v1/report/profit?start_date={start_date}&end_date={end_date}", schema: "models.ProfitReport"},
+	{path: "/v1/report/stores?start_date={start_date}&end_date={end_date}", schema: "models.StoreSalesReport"},
+	{path: "/v1/report/promotions?start_date={start_date}&end_date={end_date}", schema: "models.PromotionPerformanceReport"},
+	{path: "/v1/report-schedules", schema: "models.ReportSchedule", list: true, capture: "report_schedule"},
+	{path: "/v1/report-schedules/{report_schedule}", schema: "models.ReportSchedule"},
+	{path: "/v1/report-schedules/{report_schedule}/runs?limit=20", schema: "models.ReportRun", list: true, paginated: true},
+	{path: "/v1/audit-logs?limit=20", schema: "models.AuditLog", list: true, paginated: true},
+	{path: "/v1/users", schema: "models.User", list: true},
+	{path: "/v1/admin/data-quality", schema: "models.DataQualityReport"},
+	{path: "/v1/admin/data-quality/missing_barcode?limit=20", schema: "models.DataQualityIssue", list: true, paginated: true},
+	{path: "/v1/admin/consistency/transactions", schema: "models.TransactionConsistencyReport"},
+	{path: "/v1/admin/consistency/stock", schema: "models.StockConsistencyReport"},
+	{path: "/v1/meta/schema-version", schema: "models.SchemaVersion"},
+	{path: "/v1/meta/migrations", schema: "models.SchemaMigration", list: true},
+	{path: "/v1/sync/status", schema: "models.SyncStatus"},
+	{path: "/v1/webhooks", schema: "models.Webhook", list: true},
+}
+
+// contractResult is the outcome of one check
+type contractResult struct {
+	path       string
+	status     string
+	violations []string
+}
+
+// runContract validates live responses of a running API against the schemas
+// it publishes under /docs/schemas/ and exits non-zero on any violation, so
+// CI can run it against a freshly started server to fail the build
+func runContract(args []string) error {
+	var (
+		target, token, email, password string
+		timeout                        time.Duration
+	)
+	fs := flag.NewFlagSet("contract", flag.ContinueOnError)
+	fs.StringVar(&target, "target", "http://localhost:8080", "base URL of the API")
+	fs.StringVar(&token, "token", os.Getenv("RETAILCTL_TOKEN"), "JWT bearer token (or RETAILCTL_TOKEN); alternatively use -email/-password")
+	fs.StringVar(&email, "email", "", "log in with this email to obtain a token")
+	fs.StringVar(&password, "password", os.Getenv("RETAILCTL_PASSWORD"), "password for -email (or RETAILCTL_PASSWORD)")
+	fs.DurationVar(&timeout, "timeout", 10*time.Second, "per-request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(target, token, email, password, timeout)
+	if err != nil {
+		return err
+	}
+	schemas, err := loadSchemas(client)
+	if err != nil {
+		return fmt.Errorf("loading schemas: %w", err)
+	}
+
+	// Date-ranged reports cover the last 30 days
+	now := time.Now()
+	ids := map[string]string{
+		"start_date": now.AddDate(0, 0, -30).Format("2006-01-02"),
+		"end_date":   now.Format("2006-01-02"),
+	}
+	results := make([]contractResult, 0, len(contractChecks))
+	for _, check := range contractChecks {
+		results = append(results, runContractCheck(client, schemas, check, ids))
+	}
+
+	failed := printContractReport(results)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d endpoints violate the published schemas", failed, len(results))
+	}
+	return nil
+}
+
+// loadSchemas fetches the schema index and every schema it lists
+func loadSchemas(client *apiClient) (map[string]map[string]interface{}, error) {
+	var index struct {
+		Schemas []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"schemas"`
+	}
+	if err := getJSON(client, "/docs/schemas/", &index); err != nil {
+		return nil, err
+	}
+
+	schemas := make(map[string]map[string]interface{}, len(index.Schemas))
+	for _, entry := range index.Schemas {
+		var schema map[string]interface{}
+		if err := getJSON(client, entry.URL, &schema); err != nil {
+			return nil, err
+		}
+		schemas[entry.Name] = schema
+	}
+	return schemas, nil
+}
+
+// getJSON fetches path and decodes the raw body into out
+func getJSON(client *apiClient, path string, out interface{}) error {
+	status, body, err := client.get(path)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("GET %s: HTTP %d", path, status)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// runContractCheck fetches one endpoint and validates the envelope and data
+func runContractCheck(client *apiClient, schemas map[string]map[string]interface{}, check contractCheck, ids map[string]string) contractResult {
+	path, missing := expandContractPath(check.path, ids)
+	result := contractResult{path: path}
+	if missing != "" {
+		result.status = contractSkip
+		result.violations = []string{"no " + missing + " to look up"}
+		return result
+	}
+
+	status, body, err := client.get(path)
+	if err != nil {
+		result.status = contractFail
+		result.violations = []string{err.Error()}
+		return result
+	}
+	if status == http.StatusForbidden {
+		result.status = contractSkip
+		result.violations = []string{"forbidden for this role"}
+		return result
+	}
+	if status != http.StatusOK {
+		result.status = contractFail
+		result.violations = []string{"HTTP " + strconv.Itoa(status)}
+		return result
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		result.status = contractFail
+		result.violations = []string{"invalid JSON: " + err.Error()}
+		return result
+	}
+
+	envelope := "helpers.Response"
+	if check.paginated {
+		envelope = "helpers.PaginatedResponse"
+	}
+	result.violations = append(result.violations, validateAgainst(schemas, envelope, payload)...)
+
+	envelopeObj, _ := payload.(map[string]interface{})
+	data := envelopeObj["data"]
+	if check.list {
+		items, ok := data.([]interface{})
+		if !ok {
+			result.violations = append(result.violations, fmt.Sprintf("$.data: expected array, got %s", jsonKind(data)))
+		}
+		for i, item := range items {
+			for _, v := range validateAgainst(schemas, check.schema, item) {
+				result.violations = append(result.violations, fmt.Sprintf("$.data[%d]%s", i, strings.TrimPrefix(v, "$")))
+			}
+		}
+		if check.capture != "" && len(items) > 0 {
+			captureID(ids, check.capture, items[0])
+		}
+	} else {
+		for _, v := range validateAgainst(schemas, check.schema, data) {
+			result.violations = append(result.violations, "$.data"+strings.TrimPrefix(v, "$"))
+		}
+		if check.capture != "" {
+			captureID(ids, check.capture, data)
+		}
+	}
+
+	result.status = contractPass
+	if len(result.violations) > 0 {
+		result.status = contractFail
+	}
+	return result
+}
+
+// validateAgainst validates value against the named published schema
+func validateAgainst(schemas map[string]map[string]interface{}, name string, value interface{}) []string {
+	schema, ok := schemas[name]
+	if !ok {
+		return []string{"$: schema " + name + " is not published"}
+	}
+	return helpers.ValidateSchema(schema, value)
+}
+
+// expandContractPath replaces {key} placeholders with captured ids and
+// returns the first key that has not been captured
+func expandContractPath(path string, ids map[string]string) (string, string) {
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			return path, ""
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			return path, ""
+		}
+		key := path[start+1 : start+end]
+		id, ok := ids[key]
+		if !ok {
+			return path, key
+		}
+		path = path[:start] + id + path[start+end+1:]
+	}
+}
+
+// captureID remembers the id of a decoded object
+func captureID(ids map[string]string, key string, value interface{}) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if id, ok := obj["id"].(float64); ok {
+		ids[key] = strconv.Itoa(int(id))
+	}
+}
+
+// jsonKind names the JSON type of a decoded value for error messages
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	return "number"
+}
+
+// printContractReport writes one line per check plus its violations and
+// returns the number of failed checks
+func printContractReport(results []contractResult) int {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	failed := 0
+	for _, r := range results {
+		note := ""
+		if r.status == contractSkip && len(r.violations) > 0 {
+			note = r.violations[0]
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.status, r.path, note)
+		if r.status != contractFail {
+			continue
+		}
+		failed++
+		for _, v := range r.violations {
+			fmt.Fprintf(w, "\t  %s\t\n", v)
+		}
+	}
+	w.Flush()
+
+	if failed == 0 {
+		fmt.Println("All checked endpoints match the published schemas")
+	}
+	return failed
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"retail-core-api/models"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Load generator operations
+const (
+	opProductList = "product_list"
+	opProductGet  = "product_get"
+	opCheckout    = "checkout"
+)
+
+// loadgenConfig holds the loadgen command flags
+type loadgenConfig struct {
+	target        string
+	token         string
+	email         string
+	password      string
+	duration      time.Duration
+	concurrency   int
+	rate          float64
+	checkoutRatio float64
+	maxItems      int
+	timeout       time.Duration
+}
+
+// sample is the outcome of one request
+type sample struct {
+	op      string
+	latency time.Duration
+	status  int
+	err     bool
+}
+
+// loadgen drives traffic against one API target
+type loadgen struct {
+	cfg      loadgenConfig
+	client   *apiClient
+	products []models.Product
+}
+
+// runLoadgen parses the loadgen flags, runs the load and prints a latency report
+func runLoadgen(args []string) error {
+	var cfg loadgenConfig
+	fs := flag.NewFlagSet("loadgen", flag.ContinueOnError)
+	fs.StringVar(&cfg.target, "target", "http://localhost:8080", "base URL of the API")
+	fs.StringVar(&cfg.token, "token", os.Getenv("RETAILCTL_TOKEN"), "JWT bearer token (or RETAILCTL_TOKEN); alternatively use -email/-password")
+	fs.StringVar(&cfg.email, "email", "", "log in with this email to obtain a token")
+	fs.StringVar(&cfg.password, "password", os.Getenv("RETAILCTL_PASSWORD"), "password for -email (or RETAILCTL_PASSWORD)")
+	fs.DurationVar(&cfg.duration, "duration", 30*time.Second, "how long to generate load")
+	fs.IntVar(&cfg.concurrency, "concurrency", 10, "number of concurrent virtual clients")
+	fs.Float64Var(&cfg.rate, "rate", 0, "target requests per second across all clients (0 = as fast as possible)")
+	fs.Float64Var(&cfg.checkoutRatio, "checkout-ratio", 0.1, "fraction of requests that are checkouts (0 = read-only); checkouts create real transactions and deduct stock")
+	fs.IntVar(&cfg.maxItems, "max-items", 3, "maximum distinct products per checkout")
+	fs.DurationVar(&cfg.timeout, "timeout", 10*time.Second, "per-request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cfg.concurrency < 1 {
+		return errors.New("-concurrency must be at least 1")
+	}
+	if cfg.duration <= 0 {
+		return errors.New("-duration must be positive")
+	}
+	if cfg.checkoutRatio < 0 || cfg.checkoutRatio > 1 {
+		return errors.New("-checkout-ratio must be between 0 and 1")
+	}
+	if cfg.maxItems < 1 {
+		return errors.New("-max-items must be at least 1")
+	}
+
+	client, err := newAPIClient(cfg.target, cfg.token, cfg.email, cfg.password, cfg.timeout)
+	if err != nil {
+		return err
+	}
+	lg := &loadgen{cfg: cfg, client: client}
+	if err := lg.loadProducts(); err != nil {
+		return fmt.Errorf("loading products: %w", err)
+	}
+
+	fmt.Printf("Generating load against %s for %s with %d clients", cfg.target, cfg.duration, cfg.concurrency)
+	if cfg.rate > 0 {
+		fmt.Printf(" at %.0f req/s", cfg.rate)
+	}
+	fmt.Printf(" (%.0f%% checkouts, %d products)\n", cfg.checkoutRatio*100, len(lg.products))
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.duration)
+	defer cancel()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	started := time.Now()
+	samples := lg.run(ctx)
+	printReport(os.Stdout, samples, time.Since(started))
+	return nil
+}
+
+// loadProducts fetches the active products used for reads and checkouts
+func (lg *loadgen) loadProducts() error {
+	var products []models.Product
+	if _, err := lg.client.do(http.MethodGet, "/v1/products?limit=100", nil, &products); err != nil {
+		return err
+	}
+	for _, p := range products {
+		if p.IsActive {
+			lg.products = append(lg.products, p)
+		}
+	}
+	if len(lg.products) == 0 {
+		return errors.New("the target has no active products")
+	}
+	return nil
+}
+
+// run starts the virtual clients and collects samples until ctx is done
+func (lg *loadgen) run(ctx context.Context) []sample {
+	var tokens <-chan time.Time
+	if lg.cfg.rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / lg.cfg.rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	results := make(chan sample, lg.cfg.concurrency*4)
+	var wg sync.WaitGroup
+	for i := 0; i < lg.cfg.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tokens:
+					}
+				} else if ctx.Err() != nil {
+					return
+				}
+				results <- lg.step()
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	samples := make([]sample, 0, 1024)
+	for s := range results {
+		samples = append(samples, s)
+	}
+	return samples
+}
+
+// step performs one randomly chosen operation
+func (lg *loadgen) step() sample {
+	if rand.Float64() < lg.cfg.checkoutRatio {
+		return lg.timed(opCheckout, http.MethodPost, "/v1/checkout", lg.checkoutBody())
+	}
+
+	if rand.IntN(2) == 0 {
+		return lg.timed(opProductList, http.MethodGet, fmt.Sprintf("/v1/products?page=%d&limit=20", rand.IntN(5)+1), nil)
+	}
+	p := lg.products[rand.IntN(len(lg.products))]
+	return lg.timed(opProductGet, http.MethodGet, fmt.Sprintf("/v1/products/%d", p.ID), nil)
+}
+
+// checkoutBody builds a checkout of 1..max-items distinct products, one unit each
+func (lg *loadgen) checkoutBody() []byte {
+	n := min(rand.IntN(lg.cfg.maxItems)+1, len(lg.products))
+	req := models.CheckoutRequest{PaymentMethod: "cash", Notes: "retailctl loadgen"}
+	for _, i := range rand.Perm(len(lg.products))[:n] {
+		req.Items = append(req.Items, models.CheckoutItem{ProductID: lg.products[i].ID, Quantity: 1})
+	}
+	body, _ := json.Marshal(req)
+	return body
+}
+
+// timed runs one request and measures it
+func (lg *loadgen) timed(op, method, path string, body []byte) sample {
+	started := time.Now()
+	status, err := lg.client.do(method, path, body, nil)
+	return sample{op: op, latency: time.Since(started), status: status, err: err != nil}
+}
+
+// printReport writes per-operation throughput, error counts and latency percentiles
+func printReport(w io.Writer, samples []sample, elapsed time.Duration) {
+	byOp := make(map[string][]sample)
+	for _, s := range samples {
+		byOp[s.op] = append(byOp[s.op], s)
+		byOp["total"] = append(byOp["total"], s)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\trequests\terrors\t503s\treq/s\tp50\tp90\tp95\tp99\tmax\t")
+	for _, op := range []string{opProductList, opProductGet, opCheckout, "total"} {
+		ops := byOp[op]
+		if len(ops) == 0 {
+			continue
+		}
+
+		latencies := make([]time.Duration, len(ops))
+		errs, shed := 0, 0
+		for i, s := range ops {
+			latencies[i] = s.latency
+			if s.err {
+				errs++
+			}
+			if s.status == http.StatusServiceUnavailable {
+				shed++
+			}
+		}
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t\n",
+			op, len(ops), errs, shed, float64(len(ops))/elapsed.Seconds(),
+			percentile(latencies, 50), percentile(latencies, 90), percentile(latencies, 95),
+			percentile(latencies, 99), latencies[len(latencies)-1].Round(time.Microsecond),
+		)
+	}
+	tw.Flush()
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1].Round(time.Microsecond)
+}
@@ -0,0 +1,69 @@
+// Command retailctl is an operator tool for the Retail Core API.
+//
+// Usage:
+//
+//	retailctl <command> [flags]
+//
+// Commands:
+//
+//	loadgen   simulate checkout and product-read traffic against a running API
+//	          and report latency percentiles
+//	contract  validate live responses against the JSON Schemas published
+//	          under /docs/schemas/
+//	tsclient  generate the TypeScript client from docs/swagger.json
+//	migrate   apply, revert or list the versioned database migrations
+//	seed      fill an empty database with demo categories, products,
+//	          cashiers and a month of sales
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a retailctl subcommand; run receives the arguments after its name
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{name: "loadgen", summary: "simulate checkout/product-read traffic and report latency percentiles", run: runLoadgen},
+	{name: "contract", summary: "validate live responses against the published JSON Schemas", run: runContract},
+	{name: "tsclient", summary: "generate the TypeScript client from the Swagger spec", run: runTSClient},
+	{name: "migrate", summary: "apply (up), revert (down) or list (status) the database migrations", run: runMigrate},
+	{name: "seed", summary: "fill an empty database with demo products, cashiers and a month of sales", run: runSeed},
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "retailctl %s: %v\n", cmd.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "retailctl: unknown command %q\n\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: retailctl <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'retailctl <command> -h' for command flags.")
+}
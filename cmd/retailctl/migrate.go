@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"retail-core-api/config"
+	"retail-core-api/database"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate applies, reverts or lists the versioned schema migrations of
+// the database in DB_CONN (or .env), the same migrations the API applies at
+// startup
+func runMigrate(args []string) error {
+	var dsn string
+	var steps int
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.StringVar(&dsn, "db", "", "PostgreSQL connection string (default DB_CONN from the environment or .env)")
+	fs.IntVar(&steps, "steps", 1, "number of migrations down reverts")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: retailctl migrate [flags] up|down|status")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "  up      apply every pending migration")
+		fmt.Fprintln(fs.Output(), "  down    revert the latest -steps applied migrations; refuses, reverting")
+		fmt.Fprintln(fs.Output(), "          none, when they reach one without a down file (the baseline)")
+		fmt.Fprintln(fs.Output(), "  status  list the migrations and when each was applied")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected one of up, down or status")
+	}
+	if steps < 1 {
+		return errors.New("-steps must be at least 1")
+	}
+
+	dsn, err := databaseConn(dsn)
+	if err != nil {
+		return err
+	}
+	db, err := database.OpenMigrationDB(dsn, nil)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	switch fs.Arg(0) {
+	case "up":
+		applied, err := database.MigrateUp(db)
+		for _, m := range applied {
+			fmt.Printf("applied  %d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+	case "down":
+		reverted, err := database.MigrateDown(db, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+		return err
+	case "status":
+		states, err := database.MigrationStatus(db)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(states)
+		return nil
+	}
+	fs.Usage()
+	return fmt.Errorf("unknown migrate command %q", fs.Arg(0))
+}
+
+// printMigrationStatus writes one line per migration: its version and name,
+// when it was applied (or pending) and whether down can revert it
+func printMigrationStatus(states []database.MigrationState) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tDOWN\tDESCRIPTION")
+	for _, s := range states {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Format(time.DateTime)
+		}
+		down := "no"
+		if s.Reversible() {
+			down = "yes"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", s.Version, s.Name, applied, down, s.Description)
+	}
+	w.Flush()
+}
+
+// databaseConn returns dsn, or DB_CONN from the environment or .env when it
+// is empty
+func databaseConn(dsn string) (string, error) {
+	if dsn != "" {
+		return dsn, nil
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if cfg.DBConn == "" {
+		return "", errors.New("no database: set DB_CONN or pass -db")
+	}
+	return cfg.DBConn, nil
+}
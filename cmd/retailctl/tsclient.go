@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"retail-core-api/helpers"
+)
+
+// runTSClient generates the TypeScript client from a Swagger spec file, the
+// same client the API serves at /docs/client.ts
+func runTSClient(args []string) error {
+	var specPath, output string
+	fs := flag.NewFlagSet("tsclient", flag.ContinueOnError)
+	fs.StringVar(&specPath, "spec", "docs/swagger.json", "Swagger 2.0 spec generated by swag init")
+	fs.StringVar(&output, "o", "", "write the client to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	client, err := helpers.TypeScriptClient(spec)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(client)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, client, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s (%d bytes)\n", output, len(client))
+	return nil
+}
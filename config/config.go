@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -10,12 +12,163 @@ import (
 // Config holds all application configuration
 type Config struct {
 	Port      string `mapstructure:"PORT"`
+	GRPCPort  string `mapstructure:"GRPC_PORT"`
 	DBConn    string `mapstructure:"DB_CONN"`
+	Storage   string `mapstructure:"STORAGE"`
 	AppEnv    string `mapstructure:"APP_ENV"`
 	AppURL    string `mapstructure:"APP_URL"`
 	JWTSecret string `mapstructure:"JWT_SECRET"`
+	StoreName string  `mapstructure:"STORE_NAME"`
+	TaxRate   float64 `mapstructure:"TAX_RATE"`
+
+	CatalogApproval bool `mapstructure:"CATALOG_APPROVAL"`
+	// Pending change requests are logged; when set they are also POSTed here
+	ApprovalWebhookURL string `mapstructure:"APPROVAL_WEBHOOK_URL"`
+
+	// Defaults of feature flags for every tenant, from "name=on,name=off";
+	// tenants override them for themselves or per store
+	FeatureFlags map[string]bool `mapstructure:"FEATURE_FLAGS"`
+
+	// Read-only replica of DB_CONN that sales reports and the transaction
+	// list are read from; everything runs on DB_CONN when empty
+	DBReplicaConn string `mapstructure:"DB_REPLICA_CONN"`
+
+	// Connection pools: the main and replica pools, and the smaller pool of
+	// each tenant in multi-tenant mode. Zero lifetimes and timeout mean no limit.
+	DBMaxOpenConns            int `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns            int `mapstructure:"DB_MAX_IDLE_CONNS"`
+	DBTenantMaxOpenConns      int `mapstructure:"DB_TENANT_MAX_OPEN_CONNS"`
+	DBTenantMaxIdleConns      int `mapstructure:"DB_TENANT_MAX_IDLE_CONNS"`
+	DBConnMaxLifetimeSeconds  int `mapstructure:"DB_CONN_MAX_LIFETIME_SECONDS"`
+	DBConnMaxIdleTimeSeconds  int `mapstructure:"DB_CONN_MAX_IDLE_TIME_SECONDS"`
+	DBStatementTimeoutSeconds int `mapstructure:"DB_STATEMENT_TIMEOUT_SECONDS"`
+
+	// Minimum level of the JSON logs, see LogLevel
+	LogLevelName string `mapstructure:"LOG_LEVEL"`
+
+	// OTLP/HTTP collector traces are exported to; tracing is off when empty
+	OTelEndpoint    string `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTelServiceName string `mapstructure:"OTEL_SERVICE_NAME"`
+
+	// Browser apps allowed to call the API from another origin, with the
+	// methods and request headers they may use; see LoadConfig for defaults
+	CORSAllowedOrigins []string `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods []string `mapstructure:"CORS_ALLOWED_METHODS"`
+	CORSAllowedHeaders []string `mapstructure:"CORS_ALLOWED_HEADERS"`
+
+	SwaggerHosts []string `mapstructure:"SWAGGER_HOSTS"`
+	DocsMode     string   `mapstructure:"DOCS_MODE"`
+
+	ShedDBLatencyMs int     `mapstructure:"SHED_DB_LATENCY_MS"`
+	ShedPoolUsage   float64 `mapstructure:"SHED_POOL_USAGE"`
+
+	// Limits that keep a single huge or slow request from tying up the server.
+	// Streams (SSE, exports) are exempt from the write timeout.
+	MaxBodyBytes        int64 `mapstructure:"MAX_BODY_BYTES"`
+	ReadTimeoutSeconds  int   `mapstructure:"READ_TIMEOUT_SECONDS"`
+	WriteTimeoutSeconds int   `mapstructure:"WRITE_TIMEOUT_SECONDS"`
+	IdleTimeoutSeconds  int   `mapstructure:"IDLE_TIMEOUT_SECONDS"`
+
+	// HTTPS served by the API itself, with the certificate and key in
+	// TLSCertFile and TLSKeyFile or one from Let's Encrypt for
+	// TLSAutocertDomains; plain HTTP (behind a terminating proxy) when neither
+	// is set. TLSRedirectPort serves plain HTTP redirecting to HTTPS.
+	TLSCertFile         string   `mapstructure:"TLS_CERT_FILE"`
+	TLSKeyFile          string   `mapstructure:"TLS_KEY_FILE"`
+	TLSAutocertDomains  []string `mapstructure:"TLS_AUTOCERT_DOMAINS"`
+	TLSAutocertEmail    string   `mapstructure:"TLS_AUTOCERT_EMAIL"`
+	TLSAutocertCacheDir string   `mapstructure:"TLS_AUTOCERT_CACHE_DIR"`
+	TLSRedirectPort     string   `mapstructure:"TLS_REDIRECT_PORT"`
+
+	// Time in-flight requests get to finish after SIGTERM before the server stops
+	ShutdownTimeoutSeconds int `mapstructure:"SHUTDOWN_TIMEOUT_SECONDS"`
+
+	// Profiling (net/http/pprof) on an internal address, off when PprofAddr
+	// is empty; requests need PprofPassword as their basic auth password
+	PprofAddr     string `mapstructure:"PPROF_ADDR"`
+	PprofPassword string `mapstructure:"PPROF_PASSWORD"`
+
+	// Fault injection for staging; always off in production
+	ChaosEnabled            bool    `mapstructure:"CHAOS_ENABLED"`
+	ChaosDBErrorRate        float64 `mapstructure:"CHAOS_DB_ERROR_RATE"`
+	ChaosDBLatencyMs        int     `mapstructure:"CHAOS_DB_LATENCY_MS"`
+	ChaosDBLatencyRate      float64 `mapstructure:"CHAOS_DB_LATENCY_RATE"`
+	ChaosWebhookFailureRate float64 `mapstructure:"CHAOS_WEBHOOK_FAILURE_RATE"`
+
+	// Cold storage for old transactions; archiving is off when ArchiveAfterDays is 0
+	ArchiveAfterDays  int    `mapstructure:"ARCHIVE_AFTER_DAYS"`
+	ArchiveDir        string `mapstructure:"ARCHIVE_DIR"`
+	ArchiveS3Endpoint string `mapstructure:"ARCHIVE_S3_ENDPOINT"`
+	ArchiveS3Region   string `mapstructure:"ARCHIVE_S3_REGION"`
+	ArchiveS3Bucket   string `mapstructure:"ARCHIVE_S3_BUCKET"`
+	ArchiveS3Key      string `mapstructure:"ARCHIVE_S3_ACCESS_KEY"`
+	ArchiveS3Secret   string `mapstructure:"ARCHIVE_S3_SECRET_KEY"`
+
+	// Background comparison of stock with the movement ledger; off when 0
+	StockCheckIntervalMinutes int `mapstructure:"STOCK_CHECK_INTERVAL_MINUTES"`
+
+	// Attempts at a webhook delivery before it is marked failed
+	WebhookMaxAttempts int `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+
+	// Domain events published to NATS; off when NATSURL is empty
+	NATSURL           string `mapstructure:"NATS_URL"`
+	NATSSubjectPrefix string `mapstructure:"NATS_SUBJECT_PREFIX"`
+
+	// Redis cache of product and category reads; off when RedisURL is empty
+	RedisURL        string `mapstructure:"REDIS_URL"`
+	CacheTTLSeconds int    `mapstructure:"CACHE_TTL_SECONDS"`
+
+	// Category suggestions for uncategorized products; the classifier is optional
+	CategoryClassifierURL   string  `mapstructure:"CATEGORY_CLASSIFIER_URL"`
+	CategorySuggestMinScore float64 `mapstructure:"CATEGORY_SUGGEST_MIN_CONFIDENCE"`
+
+	// Upload targets for scheduled reports; local is always available, s3 and
+	// sftp only when their bucket or address is set
+	ReportDir               string `mapstructure:"REPORT_DIR"`
+	ReportS3Endpoint        string `mapstructure:"REPORT_S3_ENDPOINT"`
+	ReportS3Region          string `mapstructure:"REPORT_S3_REGION"`
+	ReportS3Bucket          string `mapstructure:"REPORT_S3_BUCKET"`
+	ReportS3Key             string `mapstructure:"REPORT_S3_ACCESS_KEY"`
+	ReportS3Secret          string `mapstructure:"REPORT_S3_SECRET_KEY"`
+	ReportSFTPAddr          string `mapstructure:"REPORT_SFTP_ADDR"`
+	ReportSFTPUser          string `mapstructure:"REPORT_SFTP_USER"`
+	ReportSFTPPassword      string `mapstructure:"REPORT_SFTP_PASSWORD"`
+	ReportSFTPPrivateKey    string `mapstructure:"REPORT_SFTP_PRIVATE_KEY"`
+	ReportSFTPHostKey       string `mapstructure:"REPORT_SFTP_HOST_KEY"`
+	ReportSFTPDir           string `mapstructure:"REPORT_SFTP_DIR"`
+	ReportFailureWebhookURL string `mapstructure:"REPORT_FAILURE_WEBHOOK_URL"`
+
+	// Opened cycle counts are logged; when set they are also POSTed here
+	CycleCountWebhookURL string `mapstructure:"CYCLE_COUNT_WEBHOOK_URL"`
+
+	// Multi-tenancy; the platform tenant endpoints exist only when the admin key is set
+	MultiTenant      bool   `mapstructure:"MULTI_TENANT"`
+	PlatformAdminKey string `mapstructure:"PLATFORM_ADMIN_KEY"`
+
+	// Replication of sales, voids and stock adjustments to an upstream
+	// instance; off when SyncUpstreamURL is empty
+	SyncUpstreamURL      string `mapstructure:"SYNC_UPSTREAM_URL"`
+	SyncUpstreamAPIKey   string `mapstructure:"SYNC_UPSTREAM_API_KEY"`
+	SyncUpstreamEmail    string `mapstructure:"SYNC_UPSTREAM_EMAIL"`
+	SyncUpstreamPassword string `mapstructure:"SYNC_UPSTREAM_PASSWORD"`
+	SyncUpstreamStoreID  int    `mapstructure:"SYNC_UPSTREAM_STORE_ID"`
+	SyncEdgeID           string `mapstructure:"SYNC_EDGE_ID"`
+	SyncIntervalSeconds  int    `mapstructure:"SYNC_INTERVAL_SECONDS"`
 }
 
+// Docs modes controlling access to /docs
+const (
+	DocsPublic = "public"
+	DocsAuth   = "auth"
+	DocsOff    = "off"
+)
+
+// Storage backends; memory serves a demo of the catalog and checkout
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
 // LoadConfig reads configuration from environment variables and optional .env file
 func LoadConfig() (*Config, error) {
 	viper.AutomaticEnv()
@@ -28,11 +181,120 @@ func LoadConfig() (*Config, error) {
 
 	cfg := &Config{
 		Port:      viper.GetString("PORT"),
+		GRPCPort:  viper.GetString("GRPC_PORT"),
 		DBConn:    viper.GetString("DB_CONN"),
+		Storage:   strings.ToLower(viper.GetString("STORAGE")),
 		AppEnv:    viper.GetString("APP_ENV"),
 		AppURL:    viper.GetString("APP_URL"),
 		JWTSecret: viper.GetString("JWT_SECRET"),
+		StoreName: viper.GetString("STORE_NAME"),
+		TaxRate:   viper.GetFloat64("TAX_RATE"),
+
+		CatalogApproval:    viper.GetBool("CATALOG_APPROVAL"),
+		ApprovalWebhookURL: viper.GetString("APPROVAL_WEBHOOK_URL"),
+
+		DBReplicaConn: viper.GetString("DB_REPLICA_CONN"),
+
+		DBMaxOpenConns:            viper.GetInt("DB_MAX_OPEN_CONNS"),
+		DBMaxIdleConns:            viper.GetInt("DB_MAX_IDLE_CONNS"),
+		DBTenantMaxOpenConns:      viper.GetInt("DB_TENANT_MAX_OPEN_CONNS"),
+		DBTenantMaxIdleConns:      viper.GetInt("DB_TENANT_MAX_IDLE_CONNS"),
+		DBConnMaxLifetimeSeconds:  viper.GetInt("DB_CONN_MAX_LIFETIME_SECONDS"),
+		DBConnMaxIdleTimeSeconds:  viper.GetInt("DB_CONN_MAX_IDLE_TIME_SECONDS"),
+		DBStatementTimeoutSeconds: viper.GetInt("DB_STATEMENT_TIMEOUT_SECONDS"),
+
+		LogLevelName: strings.ToLower(viper.GetString("LOG_LEVEL")),
+
+		OTelEndpoint:    viper.GetString("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		OTelServiceName: viper.GetString("OTEL_SERVICE_NAME"),
+
+		CORSAllowedOrigins: splitList(viper.GetString("CORS_ALLOWED_ORIGINS")),
+		CORSAllowedMethods: splitList(strings.ToUpper(viper.GetString("CORS_ALLOWED_METHODS"))),
+		CORSAllowedHeaders: splitList(viper.GetString("CORS_ALLOWED_HEADERS")),
+
+		SwaggerHosts: splitList(viper.GetString("SWAGGER_HOSTS")),
+		DocsMode:     strings.ToLower(viper.GetString("DOCS_MODE")),
+
+		ShedDBLatencyMs: viper.GetInt("SHED_DB_LATENCY_MS"),
+		ShedPoolUsage:   viper.GetFloat64("SHED_POOL_USAGE"),
+
+		MaxBodyBytes:        viper.GetInt64("MAX_BODY_BYTES"),
+		ReadTimeoutSeconds:  viper.GetInt("READ_TIMEOUT_SECONDS"),
+		WriteTimeoutSeconds: viper.GetInt("WRITE_TIMEOUT_SECONDS"),
+		IdleTimeoutSeconds:  viper.GetInt("IDLE_TIMEOUT_SECONDS"),
+
+		TLSCertFile:         viper.GetString("TLS_CERT_FILE"),
+		TLSKeyFile:          viper.GetString("TLS_KEY_FILE"),
+		TLSAutocertDomains:  splitList(viper.GetString("TLS_AUTOCERT_DOMAINS")),
+		TLSAutocertEmail:    viper.GetString("TLS_AUTOCERT_EMAIL"),
+		TLSAutocertCacheDir: viper.GetString("TLS_AUTOCERT_CACHE_DIR"),
+		TLSRedirectPort:     viper.GetString("TLS_REDIRECT_PORT"),
+
+		ShutdownTimeoutSeconds: viper.GetInt("SHUTDOWN_TIMEOUT_SECONDS"),
+
+		PprofAddr:     viper.GetString("PPROF_ADDR"),
+		PprofPassword: viper.GetString("PPROF_PASSWORD"),
+
+		ChaosEnabled:            viper.GetBool("CHAOS_ENABLED"),
+		ChaosDBErrorRate:        viper.GetFloat64("CHAOS_DB_ERROR_RATE"),
+		ChaosDBLatencyMs:        viper.GetInt("CHAOS_DB_LATENCY_MS"),
+		ChaosDBLatencyRate:      viper.GetFloat64("CHAOS_DB_LATENCY_RATE"),
+		ChaosWebhookFailureRate: viper.GetFloat64("CHAOS_WEBHOOK_FAILURE_RATE"),
+
+		ArchiveAfterDays:  viper.GetInt("ARCHIVE_AFTER_DAYS"),
+		ArchiveDir:        viper.GetString("ARCHIVE_DIR"),
+		ArchiveS3Endpoint: viper.GetString("ARCHIVE_S3_ENDPOINT"),
+		ArchiveS3Region:   viper.GetString("ARCHIVE_S3_REGION"),
+		ArchiveS3Bucket:   viper.GetString("ARCHIVE_S3_BUCKET"),
+		ArchiveS3Key:      viper.GetString("ARCHIVE_S3_ACCESS_KEY"),
+		ArchiveS3Secret:   viper.GetString("ARCHIVE_S3_SECRET_KEY"),
+
+		StockCheckIntervalMinutes: viper.GetInt("STOCK_CHECK_INTERVAL_MINUTES"),
+
+		WebhookMaxAttempts: viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+
+		NATSURL:           viper.GetString("NATS_URL"),
+		NATSSubjectPrefix: strings.Trim(viper.GetString("NATS_SUBJECT_PREFIX"), "."),
+
+		RedisURL:        viper.GetString("REDIS_URL"),
+		CacheTTLSeconds: viper.GetInt("CACHE_TTL_SECONDS"),
+
+		CategoryClassifierURL:   viper.GetString("CATEGORY_CLASSIFIER_URL"),
+		CategorySuggestMinScore: viper.GetFloat64("CATEGORY_SUGGEST_MIN_CONFIDENCE"),
+
+		ReportDir:               viper.GetString("REPORT_DIR"),
+		ReportS3Endpoint:        viper.GetString("REPORT_S3_ENDPOINT"),
+		ReportS3Region:          viper.GetString("REPORT_S3_REGION"),
+		ReportS3Bucket:          viper.GetString("REPORT_S3_BUCKET"),
+		ReportS3Key:             viper.GetString("REPORT_S3_ACCESS_KEY"),
+		ReportS3Secret:          viper.GetString("REPORT_S3_SECRET_KEY"),
+		ReportSFTPAddr:          viper.GetString("REPORT_SFTP_ADDR"),
+		ReportSFTPUser:          viper.GetString("REPORT_SFTP_USER"),
+		ReportSFTPPassword:      viper.GetString("REPORT_SFTP_PASSWORD"),
+		ReportSFTPPrivateKey:    viper.GetString("REPORT_SFTP_PRIVATE_KEY"),
+		ReportSFTPHostKey:       viper.GetString("REPORT_SFTP_HOST_KEY"),
+		ReportSFTPDir:           viper.GetString("REPORT_SFTP_DIR"),
+		ReportFailureWebhookURL: viper.GetString("REPORT_FAILURE_WEBHOOK_URL"),
+
+		CycleCountWebhookURL: viper.GetString("CYCLE_COUNT_WEBHOOK_URL"),
+
+		MultiTenant:      viper.GetBool("MULTI_TENANT"),
+		PlatformAdminKey: viper.GetString("PLATFORM_ADMIN_KEY"),
+
+		SyncUpstreamURL:      strings.TrimRight(viper.GetString("SYNC_UPSTREAM_URL"), "/"),
+		SyncUpstreamAPIKey:   viper.GetString("SYNC_UPSTREAM_API_KEY"),
+		SyncUpstreamEmail:    viper.GetString("SYNC_UPSTREAM_EMAIL"),
+		SyncUpstreamPassword: viper.GetString("SYNC_UPSTREAM_PASSWORD"),
+		SyncUpstreamStoreID:  viper.GetInt("SYNC_UPSTREAM_STORE_ID"),
+		SyncEdgeID:           viper.GetString("SYNC_EDGE_ID"),
+		SyncIntervalSeconds:  viper.GetInt("SYNC_INTERVAL_SECONDS"),
+	}
+
+	featureFlags, err := parseFeatureFlags(viper.GetString("FEATURE_FLAGS"))
+	if err != nil {
+		return nil, err
 	}
+	cfg.FeatureFlags = featureFlags
 
 	// Defaults
 	if cfg.Port == "" {
@@ -41,6 +303,134 @@ func LoadConfig() (*Config, error) {
 	if cfg.JWTSecret == "" {
 		cfg.JWTSecret = "change-me-in-production"
 	}
+	if cfg.StoreName == "" {
+		cfg.StoreName = "Retail Core"
+	}
+	if cfg.DBMaxOpenConns == 0 {
+		cfg.DBMaxOpenConns = 25
+	}
+	if cfg.DBMaxIdleConns == 0 {
+		cfg.DBMaxIdleConns = min(5, cfg.DBMaxOpenConns)
+	}
+	if cfg.DBTenantMaxOpenConns == 0 {
+		cfg.DBTenantMaxOpenConns = 10
+	}
+	if cfg.DBTenantMaxIdleConns == 0 {
+		cfg.DBTenantMaxIdleConns = min(2, cfg.DBTenantMaxOpenConns)
+	}
+	if err := validatePool("DB", cfg.DBMaxOpenConns, cfg.DBMaxIdleConns); err != nil {
+		return nil, err
+	}
+	if err := validatePool("DB_TENANT", cfg.DBTenantMaxOpenConns, cfg.DBTenantMaxIdleConns); err != nil {
+		return nil, err
+	}
+	if cfg.DBConnMaxLifetimeSeconds < 0 || cfg.DBConnMaxIdleTimeSeconds < 0 || cfg.DBStatementTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("DB_CONN_MAX_LIFETIME_SECONDS, DB_CONN_MAX_IDLE_TIME_SECONDS and DB_STATEMENT_TIMEOUT_SECONDS must not be negative (0 = no limit)")
+	}
+	if cfg.ShedDBLatencyMs <= 0 {
+		cfg.ShedDBLatencyMs = 500
+	}
+	if cfg.ShedPoolUsage <= 0 || cfg.ShedPoolUsage > 1 {
+		cfg.ShedPoolUsage = 0.9
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = 1 << 20
+	}
+	if cfg.ReadTimeoutSeconds <= 0 {
+		cfg.ReadTimeoutSeconds = 15
+	}
+	if cfg.WriteTimeoutSeconds <= 0 {
+		cfg.WriteTimeoutSeconds = 30
+	}
+	if cfg.IdleTimeoutSeconds <= 0 {
+		cfg.IdleTimeoutSeconds = 60
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.TLSCertFile != "" && len(cfg.TLSAutocertDomains) > 0 {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_AUTOCERT_DOMAINS cannot both be set")
+	}
+	if cfg.TLSRedirectPort != "" && !cfg.TLSEnabled() {
+		return nil, fmt.Errorf("TLS_REDIRECT_PORT needs TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS")
+	}
+	if cfg.TLSAutocertCacheDir == "" {
+		cfg.TLSAutocertCacheDir = "certs"
+	}
+	if cfg.ShutdownTimeoutSeconds <= 0 {
+		cfg.ShutdownTimeoutSeconds = 20
+	}
+	if cfg.OTelServiceName == "" {
+		cfg.OTelServiceName = "retail-core-api"
+	}
+	if cfg.ArchiveDir == "" {
+		cfg.ArchiveDir = "archive"
+	}
+	if cfg.ReportDir == "" {
+		cfg.ReportDir = "reports"
+	}
+	if cfg.SyncEdgeID == "" {
+		// Idempotency keys upstream are built from the edge ID, so each edge
+		// box needs its own; the host name is a sensible default
+		cfg.SyncEdgeID, _ = os.Hostname()
+	}
+	if cfg.SyncIntervalSeconds <= 0 {
+		cfg.SyncIntervalSeconds = 60
+	}
+	if cfg.WebhookMaxAttempts <= 0 {
+		cfg.WebhookMaxAttempts = 8
+	}
+	if cfg.NATSSubjectPrefix == "" {
+		cfg.NATSSubjectPrefix = "retail"
+	}
+	if cfg.CacheTTLSeconds <= 0 {
+		cfg.CacheTTLSeconds = 60
+	}
+	if cfg.ChaosEnabled && cfg.IsProduction() {
+		// Fault injection must never reach customers
+		cfg.ChaosEnabled = false
+	}
+	if len(cfg.CORSAllowedOrigins) == 0 && !cfg.IsProduction() {
+		// The dev servers of the web frontends (Vite, Next.js, Vite preview);
+		// production allows no other origin unless configured
+		cfg.CORSAllowedOrigins = []string{"http://localhost:5173", "http://localhost:3000", "http://localhost:4173"}
+	}
+	for i, origin := range cfg.CORSAllowedOrigins {
+		// Browsers send the origin without a trailing slash
+		origin = strings.TrimRight(origin, "/")
+		cfg.CORSAllowedOrigins[i] = origin
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			return nil, fmt.Errorf("CORS_ALLOWED_ORIGINS: %q is not an origin such as https://pos.example.com", origin)
+		}
+	}
+	if len(cfg.CORSAllowedMethods) == 0 {
+		cfg.CORSAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	}
+	if len(cfg.CORSAllowedHeaders) == 0 {
+		cfg.CORSAllowedHeaders = []string{"Content-Type", "Authorization", "Accept", "X-Requested-With", "Idempotency-Key", "X-API-Key", "X-Request-ID"}
+	}
+	switch cfg.Storage {
+	case "":
+		cfg.Storage = StoragePostgres
+	case StoragePostgres:
+	case StorageMemory:
+		// The demo has no users, so its routes are open to anyone
+		if cfg.IsProduction() {
+			return nil, fmt.Errorf("STORAGE: %s is a demo without authentication and cannot run in production", StorageMemory)
+		}
+	default:
+		return nil, fmt.Errorf("STORAGE: %q is not %s or %s", cfg.Storage, StoragePostgres, StorageMemory)
+	}
+	switch cfg.DocsMode {
+	case DocsPublic, DocsAuth, DocsOff:
+	default:
+		// Docs are public while developing and require login in production
+		if cfg.IsProduction() {
+			cfg.DocsMode = DocsAuth
+		} else {
+			cfg.DocsMode = DocsPublic
+		}
+	}
 
 	return cfg, nil
 }
@@ -50,18 +440,115 @@ func (c *Config) IsProduction() bool {
 	return c.AppEnv == "production"
 }
 
-// SwaggerHost returns the host for Swagger documentation
+// TLSEnabled returns true if the API serves HTTPS itself
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || len(c.TLSAutocertDomains) > 0
+}
+
+// LogLevel returns the minimum level of log records from LOG_LEVEL (debug,
+// info, warn or error); anything else means info
+func (c *Config) LogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevelName)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// splitList splits a comma-separated value, dropping empty entries
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseFeatureFlags parses FEATURE_FLAGS: comma-separated name=on or
+// name=off entries (true/false and 1/0 work too)
+func parseFeatureFlags(value string) (map[string]bool, error) {
+	flags := make(map[string]bool)
+	for _, entry := range splitList(value) {
+		name, state, _ := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		var enabled bool
+		switch strings.ToLower(strings.TrimSpace(state)) {
+		case "on", "true", "1":
+			enabled = true
+		case "off", "false", "0":
+		default:
+			name = ""
+		}
+		if name == "" {
+			return nil, fmt.Errorf("FEATURE_FLAGS: %q must be name=on or name=off", entry)
+		}
+		flags[name] = enabled
+	}
+	return flags, nil
+}
+
+// validatePool checks the size of a connection pool configured under
+// prefix_MAX_OPEN_CONNS and prefix_MAX_IDLE_CONNS
+func validatePool(prefix string, maxOpen, maxIdle int) error {
+	if maxOpen < 0 || maxIdle < 0 {
+		return fmt.Errorf("%s_MAX_OPEN_CONNS and %s_MAX_IDLE_CONNS must be positive", prefix, prefix)
+	}
+	if maxIdle > maxOpen {
+		return fmt.Errorf("%s_MAX_IDLE_CONNS (%d) must not exceed %s_MAX_OPEN_CONNS (%d)", prefix, maxIdle, prefix, maxOpen)
+	}
+	return nil
+}
+
+// SwaggerHost returns the default host for Swagger documentation: the first
+// SWAGGER_HOSTS entry, else the host part of APP_URL, else localhost
 func (c *Config) SwaggerHost() string {
+	return c.SwaggerServers()[0]
+}
+
+// SwaggerServers returns every host the API is served from (e.g. production
+// and staging). The docs advertise whichever one the request arrived on.
+func (c *Config) SwaggerServers() []string {
+	if len(c.SwaggerHosts) > 0 {
+		hosts := make([]string, 0, len(c.SwaggerHosts))
+		for _, h := range c.SwaggerHosts {
+			hosts = append(hosts, stripScheme(h))
+		}
+		return hosts
+	}
 	if c.AppURL != "" {
-		return c.AppURL
+		return []string{stripScheme(c.AppURL)}
 	}
-	return "localhost:" + c.Port
+	return []string{"localhost:" + c.Port}
 }
 
-// SwaggerSchemes returns the schemes for Swagger documentation
+// stripScheme removes the scheme and trailing slash from a URL, leaving host[:port]
+func stripScheme(url string) string {
+	url = strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	return strings.TrimRight(url, "/")
+}
+
+// SwaggerSchemes returns the schemes for Swagger documentation. A scheme in
+// APP_URL wins; otherwise production, and a server serving HTTPS itself, is
+// served over https.
 func (c *Config) SwaggerSchemes() []string {
-	if c.IsProduction() {
+	switch {
+	case strings.HasPrefix(c.AppURL, "https://"):
+		return []string{"https"}
+	case strings.HasPrefix(c.AppURL, "http://"):
+		return []string{"http"}
+	case c.IsProduction(), c.TLSEnabled():
 		return []string{"https"}
 	}
 	return []string{"http"}
 }
+
+// BaseURL returns the public base URL of the API (scheme + host), used to
+// build absolute links such as shared receipt URLs
+func (c *Config) BaseURL() string {
+	if strings.HasPrefix(c.AppURL, "http://") || strings.HasPrefix(c.AppURL, "https://") {
+		return strings.TrimRight(c.AppURL, "/")
+	}
+	return c.SwaggerSchemes()[0] + "://" + c.SwaggerHost()
+}
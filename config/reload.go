@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Reloader reloads the configuration while the server runs and hands it to
+// the settings that can change without a restart. The rest of the new
+// configuration is ignored until the next start.
+type Reloader struct {
+	mu       sync.Mutex
+	validate func(*Config) error
+	hooks    []func(*Config)
+}
+
+// NewReloader creates a reloader. validate checks a reloaded configuration
+// before any of it is applied; nil accepts whatever LoadConfig does.
+func NewReloader(validate func(*Config) error) *Reloader {
+	return &Reloader{validate: validate}
+}
+
+// OnReload registers fn to apply a reloaded configuration
+func (r *Reloader) OnReload(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, fn)
+}
+
+// Reload reads the configuration again and applies it. An invalid
+// configuration is applied nowhere, so the running settings stay as they are.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if r.validate != nil {
+		if err := r.validate(cfg); err != nil {
+			return err
+		}
+	}
+	for _, fn := range r.hooks {
+		fn(cfg)
+	}
+	return nil
+}
+
+// configChangeDelay is how long the .env file must stay unchanged before it
+// is read again, so an editor saving it in several writes reloads it once
+const configChangeDelay = 100 * time.Millisecond
+
+// Watch reloads the configuration on SIGHUP and, when it was read from a
+// .env file, whenever that file changes, until ctx is done. One goroutine
+// takes both triggers, so the configuration is never read by two at once:
+// viper is not safe for concurrent use.
+func (r *Reloader) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	// The directory is watched rather than the file, so a file an editor
+	// replaces by renaming a new one over it is still seen
+	var watcher *fsnotify.Watcher
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	var file string
+	if used := viper.ConfigFileUsed(); used != "" {
+		var err error
+		watcher, err = watchDir(used)
+		if err != nil {
+			slog.Error("failed to watch the configuration file, reloading on SIGHUP only", "component", "config", "file", used, "error", err)
+		} else {
+			file, _ = filepath.Abs(used)
+			events, watchErrors = watcher.Events, watcher.Errors
+		}
+	}
+
+	go func() {
+		defer signal.Stop(hup)
+		if watcher != nil {
+			defer watcher.Close()
+		}
+		changed := time.NewTimer(configChangeDelay)
+		changed.Stop()
+		for {
+			select {
+			case <-hup:
+				r.reloadOn("SIGHUP")
+			case e := <-events:
+				if filepath.Clean(e.Name) == file && e.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					changed.Reset(configChangeDelay)
+				}
+			case <-changed.C:
+				r.reloadOn("file")
+			case err := <-watchErrors:
+				slog.Error("failed to watch the configuration file", "component", "config", "file", file, "error", err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// watchDir watches the directory of file
+func watchDir(file string) (*fsnotify.Watcher, error) {
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// reloadOn reloads the configuration and logs what came of it
+func (r *Reloader) reloadOn(trigger string) {
+	if err := r.Reload(); err != nil {
+		slog.Error("failed to reload configuration, keeping the current one", "component", "config", "trigger", trigger, "error", err)
+		return
+	}
+	slog.Info("configuration reloaded", "component", "config", "trigger", trigger)
+}
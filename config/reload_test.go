@@ -0,0 +1,77 @@
+package config
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// writeEnv replaces .env in the working directory the way editors save
+// files: a new file renamed over the old one
+func writeEnv(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile(".env.tmp", []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(".env.tmp", ".env"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// nextReload waits for the log level of the next reloaded configuration
+func nextReload(t *testing.T, reloaded <-chan string) string {
+	t.Helper()
+	select {
+	case level := <-reloaded:
+		return level
+	case <-time.After(2 * time.Second):
+		t.Fatal("the configuration was not reloaded")
+		return ""
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeEnv(t, "LOG_LEVEL=info\n")
+	if _, err := LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := make(chan string, 10)
+	r := NewReloader(nil)
+	r.OnReload(func(cfg *Config) { reloaded <- cfg.LogLevelName })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.Watch(ctx)
+
+	// A file replaced by a rename is reloaded, once for a burst of saves
+	writeEnv(t, "LOG_LEVEL=warn\n")
+	writeEnv(t, "LOG_LEVEL=debug\n")
+	if level := nextReload(t, reloaded); level != "debug" {
+		t.Fatalf("reloaded LOG_LEVEL %q, want debug", level)
+	}
+	select {
+	case level := <-reloaded:
+		t.Fatalf("a burst of saves reloaded the configuration again (LOG_LEVEL %q)", level)
+	case <-time.After(3 * configChangeDelay):
+	}
+
+	// Other files in the directory are ignored
+	if err := os.WriteFile("other.txt", []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloaded:
+		t.Fatal("a change to another file reloaded the configuration")
+	case <-time.After(3 * configChangeDelay):
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	if level := nextReload(t, reloaded); level != "debug" {
+		t.Fatalf("reloaded LOG_LEVEL %q on SIGHUP, want debug", level)
+	}
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"retail-core-api/config"
+	"retail-core-api/database"
+	"retail-core-api/database/dbtest"
+	"retail-core-api/docs"
+	"retail-core-api/helpers"
+	"retail-core-api/middleware"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"retail-core-api/storage"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// contractCheck is one request whose response must match what docs/swagger.json
+// documents for its route and status. The path and body may reference IDs
+// captured from earlier responses, or the report date range, as {key}.
+type contractCheck struct {
+	method  string
+	path    string
+	body    string
+	capture string // remember the id of the response data under this key
+}
+
+// contractChecks run in order: the writes create what the reads look up
+var contractChecks = []contractCheck{
+	{method: http.MethodPost, path: "/v1/categories", body: `{"name": "Contract Drinks"}`, capture: "category"},
+	{method: http.MethodPut, path: "/v1/categories/{category}", body: `{"name": "Contract Beverages", "description": "Cold drinks"}`},
+	{method: http.MethodPost, path: "/v1/suppliers", body: `{"name": "Contract Supplier"}`, capture: "supplier"},
+	{method: http.MethodPut, path: "/v1/suppliers/{supplier}", body: `{"name": "Contract Supplier", "phone": "+62 812 0000 0000"}`},
+	{method: http.MethodPost, path: "/v1/products", body: `{"name": "Contract Tea", "sku": "CT-TEA", "price": 4000, "stock": 50, "category_id": {category}, "cost_price": 2500}`, capture: "product"},
+	{method: http.MethodPut, path: "/v1/products/{product}", body: `{"name": "Contract Tea", "sku": "CT-TEA", "price": 4500, "stock": 50, "category_id": {category}, "cost_price": 2500}`},
+	{method: http.MethodPut, path: "/v1/products/{product}/price-tiers", body: `{"tiers": [{"price_level": "wholesale", "min_quantity": 12, "price": 3800}]}`},
+	{method: http.MethodPost, path: "/v1/products/{product}/scheduled-prices", body: `{"price": 4800, "effective_at": "{tomorrow}"}`},
+	{method: http.MethodPost, path: "/v1/products/{product}/stock-adjustments", body: `{"quantity": 5, "reason_code": "received_goods", "store_id": {main_store}}`},
+	{method: http.MethodPost, path: "/v1/stores", body: `{"code": "CT1", "name": "Contract Branch"}`, capture: "store"},
+	{method: http.MethodPut, path: "/v1/stores/{store}", body: `{"code": "CT1", "name": "Contract Branch", "address": "Jl. Dago 55"}`},
+	{method: http.MethodPost, path: "/v1/promotions", body: `{"name": "Contract Week", "type": "category_discount", "category_id": {category}, "discount_percent": 10}`, capture: "promotion"},
+	{method: http.MethodPut, path: "/v1/promotions/{promotion}", body: `{"name": "Contract Week", "type": "category_discount", "category_id": {category}, "discount_percent": 15}`},
+	{method: http.MethodPost, path: "/v1/checkout", body: `{"items": [{"product_id": {product}, "quantity": 2}], "payment_method": "cash"}`, capture: "transaction"},
+	{method: http.MethodPost, path: "/v1/queue/next"},
+	{method: http.MethodPost, path: "/v1/transactions/{transaction}/share"},
+	{method: http.MethodPatch, path: "/v1/transactions/{transaction}/void"},
+	// A sale that stays, for the reads below
+	{method: http.MethodPost, path: "/v1/checkout", body: `{"items": [{"product_id": {product}, "quantity": 3}], "payment_method": "qris"}`, capture: "transaction"},
+	{method: http.MethodPost, path: "/v1/purchase-orders", body: `{"supplier_id": {supplier}, "items": [{"product_id": {product}, "quantity": 10, "unit_cost": 2500}]}`, capture: "purchase_order"},
+	{method: http.MethodPost, path: "/v1/purchase-orders/{purchase_order}/receive", body: `{"items": [{"product_id": {product}, "quantity": 10, "unit_cost": 2600}]}`},
+	{method: http.MethodPost, path: "/v1/stock-transfers", body: `{"from_store_id": {main_store}, "to_store_id": {store}, "items": [{"product_id": {product}, "quantity": 3}]}`, capture: "stock_transfer"},
+	{method: http.MethodPost, path: "/v1/stock-transfers/{stock_transfer}/receive"},
+	{method: http.MethodPost, path: "/v1/inventory/count-sessions", body: `{"store_id": {store}, "product_ids": [{product}]}`, capture: "count_session"},
+	{method: http.MethodPut, path: "/v1/inventory/count-sessions/{count_session}/items/{product}", body: `{"counted_qty": 3}`},
+	{method: http.MethodPost, path: "/v1/inventory/count-sessions/{count_session}/complete"},
+	{method: http.MethodPost, path: "/v1/report-schedules", body: `{"name": "Contract sales", "report": "sales_summary", "format": "csv", "cron": "0 2 * * *", "target": "local"}`, capture: "report_schedule"},
+	{method: http.MethodPut, path: "/v1/report-schedules/{report_schedule}", body: `{"name": "Contract sales", "report": "sales_summary", "format": "pdf", "cron": "0 3 * * *", "target": "local"}`},
+
+	{method: http.MethodGet, path: "/v1/categories"},
+	{method: http.MethodGet, path: "/v1/categories/tree"},
+	{method: http.MethodGet, path: "/v1/categories/{category}"},
+	{method: http.MethodGet, path: "/v1/category-rules"},
+	{method: http.MethodGet, path: "/v1/category-suggestions?status=all"},
+	{method: http.MethodGet, path: "/v1/products?limit=20"},
+	{method: http.MethodGet, path: "/v1/products/{product}"},
+	{method: http.MethodGet, path: "/v1/products/{product}/price-history"},
+	{method: http.MethodGet, path: "/v1/products/{product}/scheduled-prices"},
+	{method: http.MethodGet, path: "/v1/products/{product}/price-tiers"},
+	{method: http.MethodGet, path: "/v1/products/{product}/stock-movements"},
+	{method: http.MethodGet, path: "/v1/products/{product}/relations"},
+	{method: http.MethodGet, path: "/v1/transactions?limit=20"},
+	{method: http.MethodGet, path: "/v1/transactions/{transaction}"},
+	{method: http.MethodGet, path: "/v1/promotions"},
+	{method: http.MethodGet, path: "/v1/suppliers"},
+	{method: http.MethodGet, path: "/v1/stores"},
+	{method: http.MethodGet, path: "/v1/stores/{store}"},
+	{method: http.MethodGet, path: "/v1/stores/{store}/stock"},
+	{method: http.MethodGet, path: "/v1/stock-transfers"},
+	{method: http.MethodGet, path: "/v1/stock-transfers/{stock_transfer}"},
+	{method: http.MethodGet, path: "/v1/purchase-orders"},
+	{method: http.MethodGet, path: "/v1/purchase-orders/{purchase_order}"},
+	{method: http.MethodGet, path: "/v1/inventory/count-sessions"},
+	{method: http.MethodGet, path: "/v1/inventory/count-sessions/{count_session}/variance"},
+	{method: http.MethodGet, path: "/v1/queue"},
+	{method: http.MethodGet, path: "/v1/dashboard"},
+	{method: http.MethodGet, path: "/v1/report/today"},
+	{method: http.MethodGet, path: "/v1/report/summary?start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report/by-category?start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report/hourly"},
+	{method: http.MethodGet, path: "/v1/report/timeseries?granularity=week&start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report/best-sellers?start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report/low-stock"},
+	{method: http.MethodGet, path: "/v1/report/reorder-suggestions"},
+	{method: http.MethodGet, path: "/v1/report/consignment"},
+	{method: http.MethodGet, path: "/v1/report/inventory-valuation"},
+	{method: http.MethodGet, path: "/v1/report/profit?start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report/stores?start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report/promotions?start_date={start_date}&end_date={end_date}"},
+	{method: http.MethodGet, path: "/v1/report-schedules"},
+	{method: http.MethodGet, path: "/v1/report-schedules/{report_schedule}"},
+	{method: http.MethodGet, path: "/v1/report-schedules/{report_schedule}/runs?limit=20"},
+	{method: http.MethodGet, path: "/v1/audit-logs?limit=20"},
+	{method: http.MethodGet, path: "/v1/users"},
+	{method: http.MethodGet, path: "/v1/admin/data-quality"},
+	{method: http.MethodGet, path: "/v1/admin/data-quality/missing_barcode?limit=20"},
+	{method: http.MethodGet, path: "/v1/admin/consistency/transactions"},
+	{method: http.MethodGet, path: "/v1/admin/consistency/stock"},
+	{method: http.MethodGet, path: "/v1/meta/schema-version"},
+	{method: http.MethodGet, path: "/v1/meta/migrations"},
+	{method: http.MethodGet, path: "/v1/sync/status"},
+	{method: http.MethodGet, path: "/v1/webhooks"},
+}
+
+// TestContract drives the handlers of a tenant app holding demo data and
+// checks every response against the operation docs/swagger.json documents for
+// it: the status must be documented, and the body must match the published
+// JSON Schemas of the types documented for that status, which reject missing
+// and undeclared fields.
+func TestContract(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tenantID, db := dbtest.NewTenant(t, dbtest.Open(t))
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("contract-secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SeedTenant(db, "Contract Owner", "owner@contract.test", string(hash)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.SeedDemoData(db, database.DemoOptions{Days: 3, SalesPerDay: 4, Seed: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.DocsMode = config.DocsPublic
+	deps := appDeps{
+		cfg:           cfg,
+		reloader:      config.NewReloader(func(*config.Config) error { return nil }),
+		cors:          middleware.NewCORS(cfg.CORSAllowedOrigins, cfg.CORSAllowedMethods, cfg.CORSAllowedHeaders),
+		featureFlags:  services.NewFeatureFlagDefaults(cfg.FeatureFlags),
+		archiveStore:  storage.NewFileStore(t.TempDir()),
+		reportTargets: map[string]storage.Store{models.ReportTargetLocal: storage.NewFileStore(t.TempDir())},
+	}
+	app, _ := newTenantApp(deps, db, nil, models.Tenant{ID: tenantID, Slug: "contract", Name: "Contract"})
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &spec); err != nil {
+		t.Fatalf("decoding docs/swagger.json: %v", err)
+	}
+	schemas := publishedSchemas(t, app)
+
+	var login struct {
+		Data models.LoginResponse `json:"data"`
+	}
+	loginCheck := contractCheck{method: http.MethodPost, path: "/v1/auth/login", body: `{"email": "owner@contract.test", "password": "contract-secret"}`}
+	checkContract(t, app, spec, schemas, loginCheck, "", &login)
+	if login.Data.Token == "" {
+		t.Fatal("logging in returned no token")
+	}
+
+	now := time.Now()
+	ids := map[string]string{
+		"start_date": now.AddDate(0, 0, -30).Format("2006-01-02"),
+		"end_date":   now.Format("2006-01-02"),
+		"tomorrow":   now.Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	}
+	var mainStore int
+	if err := db.QueryRow(`SELECT id FROM stores WHERE is_default`).Scan(&mainStore); err != nil {
+		t.Fatal(err)
+	}
+	ids["main_store"] = strconv.Itoa(mainStore)
+
+	for _, check := range contractChecks {
+		check.path, check.body = expandContract(t, check.path, ids), expandContract(t, check.body, ids)
+		var response struct {
+			Data struct {
+				ID int `json:"id"`
+			} `json:"data"`
+		}
+		if !checkContract(t, app, spec, schemas, check, login.Data.Token, &response) {
+			continue
+		}
+		if check.capture != "" {
+			if response.Data.ID == 0 {
+				t.Fatalf("%s %s: no id to remember as %s", check.method, check.path, check.capture)
+			}
+			ids[check.capture] = strconv.Itoa(response.Data.ID)
+		}
+	}
+}
+
+// publishedSchemas fetches the schema index and every schema it lists
+func publishedSchemas(t *testing.T, app http.Handler) map[string]map[string]interface{} {
+	t.Helper()
+	var index struct {
+		Schemas []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"schemas"`
+	}
+	getDoc(t, app, "/docs/schemas/", &index)
+
+	schemas := make(map[string]map[string]interface{}, len(index.Schemas))
+	for _, entry := range index.Schemas {
+		var schema map[string]interface{}
+		getDoc(t, app, entry.URL, &schema)
+		schemas[entry.Name] = schema
+	}
+	return schemas
+}
+
+// getDoc fetches a document served under /docs and decodes it into out
+func getDoc(t *testing.T, app http.Handler, path string, out interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d", path, w.Code)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+}
+
+// checkContract sends one request and reports every way its response departs
+// from the spec. It decodes the body into out and returns whether the request
+// succeeded and its response matched.
+func checkContract(t *testing.T, app http.Handler, spec map[string]interface{}, schemas map[string]map[string]interface{}, check contractCheck, token string, out interface{}) bool {
+	t.Helper()
+	req := httptest.NewRequest(check.method, check.path, strings.NewReader(check.body))
+	if check.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	name := check.method + " " + check.path
+	if w.Code < 200 || w.Code > 299 {
+		t.Errorf("%s: status %d: %s", name, w.Code, w.Body.String())
+		return false
+	}
+
+	route, op := specOperation(spec, check.method, check.path)
+	if op == nil {
+		t.Errorf("%s: no operation in docs/swagger.json", name)
+		return false
+	}
+	responses, _ := op["responses"].(map[string]interface{})
+	documented, ok := responses[strconv.Itoa(w.Code)].(map[string]interface{})
+	if !ok {
+		t.Errorf("%s: status %d is not documented for %s", name, w.Code, route)
+		return false
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+		t.Errorf("%s: invalid JSON: %v", name, err)
+		return false
+	}
+	schema, _ := documented["schema"].(map[string]interface{})
+	violations := validateSpecSchema(schemas, schema, payload, "$")
+	for _, v := range violations {
+		t.Errorf("%s: %s", name, v)
+	}
+	if len(violations) > 0 {
+		return false
+	}
+	return json.Unmarshal(w.Body.Bytes(), out) == nil
+}
+
+// specOperation returns the documented route a request path is served by and
+// its operation for method. Literal segments win over parameters, so
+// /v1/categories/tree is not taken for /v1/categories/{id}.
+func specOperation(spec map[string]interface{}, method, path string) (string, map[string]interface{}) {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	paths, _ := spec["paths"].(map[string]interface{})
+
+	best, bestScore := "", -1
+	for route := range paths {
+		parts := strings.Split(route, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		score := 0
+		for i, part := range parts {
+			if part == segments[i] {
+				score++
+			} else if !strings.HasPrefix(part, "{") {
+				score = -1
+				break
+			}
+		}
+		if score > bestScore {
+			best, bestScore = route, score
+		}
+	}
+	if best == "" {
+		return "", nil
+	}
+	item, _ := paths[best].(map[string]interface{})
+	op, _ := item[strings.ToLower(method)].(map[string]interface{})
+	return best, op
+}
+
+// validateSpecSchema checks value against a response schema of the spec. The
+// definitions it references are checked with their published JSON Schemas;
+// the spec only composes them (allOf) and places them in objects and arrays.
+func validateSpecSchema(schemas map[string]map[string]interface{}, s map[string]interface{}, value interface{}, path string) []string {
+	if s == nil {
+		return []string{path + ": no schema is documented"}
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		schema, ok := schemas[name]
+		if !ok {
+			return []string{fmt.Sprintf("%s: schema %s is not published", path, name)}
+		}
+		var violations []string
+		for _, v := range helpers.ValidateSchema(schema, value) {
+			violations = append(violations, path+strings.TrimPrefix(v, "$"))
+		}
+		return violations
+	}
+
+	var violations []string
+	if parts, ok := s["allOf"].([]interface{}); ok {
+		for _, part := range parts {
+			sub, _ := part.(map[string]interface{})
+			violations = append(violations, validateSpecSchema(schemas, sub, value, path)...)
+		}
+		return violations
+	}
+
+	switch s["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{path + ": expected object"}
+		}
+		properties, _ := s["properties"].(map[string]interface{})
+		for name, prop := range properties {
+			sub, _ := prop.(map[string]interface{})
+			if v, ok := obj[name]; ok {
+				violations = append(violations, validateSpecSchema(schemas, sub, v, path+"."+name)...)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{path + ": expected array"}
+		}
+		sub, _ := s["items"].(map[string]interface{})
+		for i, item := range items {
+			violations = append(violations, validateSpecSchema(schemas, sub, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			violations = append(violations, path+": expected string")
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			violations = append(violations, path+": expected number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			violations = append(violations, path+": expected boolean")
+		}
+	}
+	return violations
+}
+
+// placeholder matches a {key} in the path or body of a contract check
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// expandContract replaces {key} placeholders with captured values
+func expandContract(t *testing.T, s string, ids map[string]string) string {
+	t.Helper()
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		id, ok := ids[m[1:len(m)-1]]
+		if !ok {
+			t.Fatalf("%s: nothing captured as %s", s, m)
+		}
+		return id
+	})
+}
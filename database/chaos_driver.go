@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"retail-core-api/chaos"
+	"sync"
+
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// chaosDriverName is the database/sql driver that wraps pgx with fault injection
+const chaosDriverName = "pgx-chaos"
+
+var (
+	chaosDriverOnce sync.Once
+	chaosInjector   *chaos.Injector
+)
+
+// registerChaosDriver registers the fault-injecting pgx driver once
+func registerChaosDriver(injector *chaos.Injector) {
+	chaosDriverOnce.Do(func() {
+		chaosInjector = injector
+		sql.Register(chaosDriverName, &chaosDriver{base: stdlib.GetDefaultDriver()})
+	})
+}
+
+// chaosDriver opens pgx connections that consult the injector before every
+// query, exec, prepare and transaction begin. Pings, session resets and
+// closes are passed through so health checks see real failures only.
+type chaosDriver struct {
+	base driver.Driver
+}
+
+func (d *chaosDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &chaosConn{Conn: conn}, nil
+}
+
+// chaosConn forwards every optional driver interface implemented by pgx
+type chaosConn struct {
+	driver.Conn
+}
+
+func (c *chaosConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := chaosInjector.DB(ctx); err != nil {
+		return nil, err
+	}
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *chaosConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := chaosInjector.DB(ctx); err != nil {
+		return nil, err
+	}
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *chaosConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := chaosInjector.DB(ctx); err != nil {
+		return nil, err
+	}
+	return e.ExecContext(ctx, query, args)
+}
+
+func (c *chaosConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := chaosInjector.DB(ctx); err != nil {
+		return nil, err
+	}
+	return q.QueryContext(ctx, query, args)
+}
+
+func (c *chaosConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *chaosConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *chaosConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *chaosConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,69 @@
+// Package dbtest gives tests a migrated PostgreSQL database. Tests that need
+// one are skipped unless TEST_DB_CONN is set. It must connect as a regular
+// role, not a superuser or BYPASSRLS one, since row-level security is part of
+// what the tests check, and to a database the tests may fill with rows of
+// their own (each run creates new tenants).
+package dbtest
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"retail-core-api/database"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+)
+
+// Conn returns TEST_DB_CONN, skipping the test when it is not set
+func Conn(t testing.TB) string {
+	t.Helper()
+	conn := os.Getenv("TEST_DB_CONN")
+	if conn == "" {
+		t.Skip("TEST_DB_CONN is not set")
+	}
+	return conn
+}
+
+// Open returns a pool on the test database, migrated to the latest version,
+// acting as the default tenant; it is closed when the test ends
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	conn := Conn(t)
+	migrateOnce.Do(func() {
+		migrateErr = database.MigrateDB(conn, nil)
+	})
+	if migrateErr != nil {
+		t.Fatalf("migrating the test database: %v", migrateErr)
+	}
+
+	db, err := database.InitDB(conn, database.PoolSettings{MaxOpenConns: 5, MaxIdleConns: 2}, nil)
+	if err != nil {
+		t.Fatalf("opening the test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// NewTenant creates a tenant with a unique slug and returns its ID and a
+// pool acting as it (database.OpenTenantDB), closed when the test ends
+func NewTenant(t testing.TB, db *sql.DB) (int, *sql.DB) {
+	t.Helper()
+	slug := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	var id int
+	if err := db.QueryRow(`INSERT INTO tenants (slug, name) VALUES ($1, $1) RETURNING id`, slug).Scan(&id); err != nil {
+		t.Fatalf("creating tenant: %v", err)
+	}
+
+	tenantDB, err := database.OpenTenantDB(Conn(t), id, database.PoolSettings{MaxOpenConns: 2, MaxIdleConns: 1}, nil)
+	if err != nil {
+		t.Fatalf("opening the pool of tenant %d: %v", id, err)
+	}
+	t.Cleanup(func() { tenantDB.Close() })
+	return id, tenantDB
+}
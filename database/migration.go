@@ -1,32 +1,110 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
-// RunMigrations creates necessary database tables if they don't exist
-func RunMigrations(db *sql.DB) error {
-	// Create users table
-	createUsersTable := `
-	CREATE TABLE IF NOT EXISTS users (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(255) NOT NULL,
-		email VARCHAR(255) UNIQUE NOT NULL,
-		password VARCHAR(255) NOT NULL,
-		role VARCHAR(50) NOT NULL DEFAULT 'cashier',
-		is_active BOOLEAN NOT NULL DEFAULT true,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	`
+// migrationFiles holds the versioned migrations: NNNN_name.up.sql applies a
+// change and NNNN_name.down.sql, when there is one, reverts it. The first
+// line of an up file, a "--" comment, describes the change.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the advisory lock held while migrating, so instances
+// starting together apply each migration once
+const migrationLockID = 7_120_040
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version     int
+	Name        string
+	Description string
+	up, down    string
+}
+
+// Reversible reports whether the migration has a down file
+func (m Migration) Reversible() bool {
+	return m.down != ""
+}
+
+// MigrationState is a migration with the time it was applied to this
+// database, nil while it is pending
+type MigrationState struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrations returns the embedded migrations in version order
+func Migrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		file := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), ".")
+		number, name, named := strings.Cut(base, "_")
+		version, err := strconv.Atoi(number)
+		if !ok || !named || err != nil || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("migration %s: name must be NNNN_name.up.sql or NNNN_name.down.sql", file)
+		}
+		data, err := migrationFiles.ReadFile(path.Join("migrations", file))
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.up = string(data)
+			firstLine, _, _ := strings.Cut(m.up, "\n")
+			m.Description = strings.TrimSpace(strings.TrimPrefix(firstLine, "--"))
+		} else {
+			m.down = string(data)
+		}
+	}
 
-	_, err := db.Exec(createUsersTable)
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// RunMigrations applies the pending migrations at startup and seeds the
+// default owner account of a database without users
+func RunMigrations(db *sql.DB) error {
+	applied, err := MigrateUp(db)
 	if err != nil {
 		return err
 	}
-	log.Println("Users table ready")
+	for _, m := range applied {
+		slog.Info("migration applied", "version", m.Version, "name", m.Name)
+	}
 
 	// Seed default owner account if no users exist
 	var userCount int
@@ -38,124 +116,192 @@ func RunMigrations(db *sql.DB) error {
 			"Admin", "admin@retail.com", string(hash), "owner",
 		)
 		if err != nil {
-			log.Println("Warning: failed to seed admin user:", err)
+			slog.Warn("failed to seed admin user", "error", err)
 		} else {
-			log.Println("Default admin user seeded (admin@retail.com / password123)")
+			slog.Info("default admin user seeded (admin@retail.com / password123)")
 		}
 	}
 
-	// Create categories table
-	createCategoriesTable := `
-	CREATE TABLE IF NOT EXISTS categories (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(255) NOT NULL,
-		description TEXT,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	`
-
-	_, err = db.Exec(createCategoriesTable)
-	if err != nil {
+	var version int
+	if err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
 		return err
 	}
-	log.Println("Categories table ready")
-
-	// Create products table with foreign key to categories
-	createProductsTable := `
-	CREATE TABLE IF NOT EXISTS products (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(255) NOT NULL,
-		price INTEGER NOT NULL DEFAULT 0,
-		stock INTEGER NOT NULL DEFAULT 0,
-		sku VARCHAR(100) DEFAULT '',
-		image_url TEXT DEFAULT '',
-		unit VARCHAR(50) DEFAULT 'pcs',
-		is_active BOOLEAN DEFAULT true,
-		category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	`
+	slog.Info("schema ready", "version", version)
+	return nil
+}
 
-	_, err = db.Exec(createProductsTable)
+// MigrateUp applies every pending migration in version order, each in a DB
+// transaction of its own, and returns those it applied. Tables a migration
+// creates are isolated by tenant in the same transaction.
+func MigrateUp(db *sql.DB) ([]Migration, error) {
+	var applied []Migration
+	err := withMigrationLock(db, func(conn *sql.Conn, states []MigrationState) error {
+		for _, s := range states {
+			if s.AppliedAt != nil {
+				continue
+			}
+			err := inMigrationTx(conn, func(tx *sql.Tx) error {
+				if _, err := tx.Exec(s.up); err != nil {
+					return err
+				}
+				if _, err := tx.Exec("SELECT isolate_tenant_tables()"); err != nil {
+					return err
+				}
+				_, err := tx.Exec(`
+					INSERT INTO schema_migrations (version, name, description)
+					VALUES ($1, $2, $3)
+				`, s.Version, s.Name, s.Description)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", s.Version, s.Name, err)
+			}
+			applied = append(applied, s.Migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// ErrIrreversibleMigration is returned by MigrateDown when the steps to
+// revert reach a migration without a down file, such as the baseline
+var ErrIrreversibleMigration = errors.New("irreversible migration")
+
+// MigrateDown reverts the latest steps applied migrations, newest first, and
+// returns those it reverted. It refuses, reverting nothing, when the steps
+// reach a migration without a down file: the baseline (version 40), which
+// holds the schema from before versioned migrations, cannot be reverted.
+func MigrateDown(db *sql.DB, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := withMigrationLock(db, func(conn *sql.Conn, states []MigrationState) error {
+		revert, err := migrationsToRevert(states, steps)
+		if err != nil {
+			return err
+		}
+		for _, s := range revert {
+			err := inMigrationTx(conn, func(tx *sql.Tx) error {
+				if _, err := tx.Exec(s.down); err != nil {
+					return err
+				}
+				_, err := tx.Exec("DELETE FROM schema_migrations WHERE version = $1", s.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", s.Version, s.Name, err)
+			}
+			reverted = append(reverted, s.Migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// migrationsToRevert returns the latest steps applied migrations, newest
+// first, or ErrIrreversibleMigration when one of them has no down file
+func migrationsToRevert(states []MigrationState, steps int) ([]MigrationState, error) {
+	var revert []MigrationState
+	for i := len(states) - 1; i >= 0 && len(revert) < steps; i-- {
+		s := states[i]
+		if s.AppliedAt == nil {
+			continue
+		}
+		if !s.Reversible() {
+			reason := "has no down file"
+			if i == 0 {
+				reason = "is the baseline of the schema from before versioned migrations"
+			}
+			return nil, fmt.Errorf("cannot revert %d steps: %w: %d_%s %s; at most %d can be reverted",
+				steps, ErrIrreversibleMigration, s.Version, s.Name, reason, len(revert))
+		}
+		revert = append(revert, s)
+	}
+	return revert, nil
+}
+
+// MigrationStatus returns every migration with the time it was applied, if
+// it was
+func MigrationStatus(db *sql.DB) ([]MigrationState, error) {
+	var states []MigrationState
+	err := withMigrationLock(db, func(_ *sql.Conn, s []MigrationState) error {
+		states = s
+		return nil
+	})
+	return states, err
+}
+
+// withMigrationLock runs fn on one connection holding the migration lock,
+// with the state of every migration. schema_migrations is created first
+// when missing.
+func withMigrationLock(db *sql.DB, fn func(conn *sql.Conn, states []MigrationState) error) error {
+	migrations, err := Migrations()
 	if err != nil {
 		return err
 	}
-	log.Println("Products table ready")
 
-	// Add new columns if they don't exist (for existing databases)
-	alterProducts := []string{
-		"ALTER TABLE products ADD COLUMN IF NOT EXISTS sku VARCHAR(100) DEFAULT ''",
-		"ALTER TABLE products ADD COLUMN IF NOT EXISTS image_url TEXT DEFAULT ''",
-		"ALTER TABLE products ADD COLUMN IF NOT EXISTS unit VARCHAR(50) DEFAULT 'pcs'",
-		"ALTER TABLE products ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true",
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
 	}
-	for _, q := range alterProducts {
-		_, _ = db.Exec(q)
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
 	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID)
 
-	// Create index on category_id for better JOIN performance
-	createIndexQuery := `
-	CREATE INDEX IF NOT EXISTS idx_products_category_id ON products(category_id);
+	// Applied migrations, and the changelog entries from before them
+	createSchemaMigrationsTable := `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
 	`
-
-	_, err = db.Exec(createIndexQuery)
-	if err != nil {
+	if _, err := conn.ExecContext(ctx, createSchemaMigrationsTable); err != nil {
 		return err
 	}
-	log.Println("Database indexes ready")
-
-	// Create transactions table
-	createTransactionsTable := `
-	CREATE TABLE IF NOT EXISTS transactions (
-		id SERIAL PRIMARY KEY,
-		total_amount INT NOT NULL,
-		payment_method VARCHAR(50) DEFAULT 'cash',
-		discount INT DEFAULT 0,
-		notes TEXT DEFAULT '',
-		status VARCHAR(20) DEFAULT 'active',
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	`
 
-	_, err = db.Exec(createTransactionsTable)
+	appliedAt := make(map[int]time.Time)
+	rows, err := conn.QueryContext(ctx, "SELECT version, COALESCE(applied_at, CURRENT_TIMESTAMP) FROM schema_migrations")
 	if err != nil {
 		return err
 	}
-	log.Println("Transactions table ready")
-
-	// Add new columns to transactions if they don't exist
-	alterTransactions := []string{
-		"ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payment_method VARCHAR(50) DEFAULT 'cash'",
-		"ALTER TABLE transactions ADD COLUMN IF NOT EXISTS discount INT DEFAULT 0",
-		"ALTER TABLE transactions ADD COLUMN IF NOT EXISTS notes TEXT DEFAULT ''",
-		"ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'active'",
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			rows.Close()
+			return err
+		}
+		appliedAt[version] = at
 	}
-	for _, q := range alterTransactions {
-		_, _ = db.Exec(q)
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
 	}
 
-	// Create transaction_details table
-	createTransactionDetailsTable := `
-	CREATE TABLE IF NOT EXISTS transaction_details (
-		id SERIAL PRIMARY KEY,
-		transaction_id INT REFERENCES transactions(id) ON DELETE CASCADE,
-		product_id INT REFERENCES products(id),
-		quantity INT NOT NULL,
-		unit_price INT NOT NULL DEFAULT 0,
-		subtotal INT NOT NULL
-	);
-	`
+	states := make([]MigrationState, len(migrations))
+	for i, m := range migrations {
+		states[i].Migration = m
+		if at, ok := appliedAt[m.Version]; ok {
+			states[i].AppliedAt = &at
+		}
+	}
+	return fn(conn, states)
+}
 
-	_, err = db.Exec(createTransactionDetailsTable)
+// inMigrationTx runs fn in a DB transaction on conn, committing when it
+// returns nil
+func inMigrationTx(conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(context.Background(), nil)
 	if err != nil {
 		return err
 	}
-	log.Println("Transaction details table ready")
+	defer tx.Rollback()
 
-	// Add unit_price column if it doesn't exist
-	_, _ = db.Exec("ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_price INT DEFAULT 0")
-
-	return nil
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// applied returns the embedded migrations as states, the first n applied
+// (all of them when n is negative)
+func applied(t *testing.T, n int) []MigrationState {
+	t.Helper()
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	states := make([]MigrationState, len(migrations))
+	for i, m := range migrations {
+		states[i] = MigrationState{Migration: m}
+		if n < 0 || i < n {
+			states[i].AppliedAt = &now
+		}
+	}
+	return states
+}
+
+// versions returns the versions of states
+func versions(states []MigrationState) []int {
+	out := make([]int, len(states))
+	for i, s := range states {
+		out[i] = s.Version
+	}
+	return out
+}
+
+func TestMigrationsToRevert(t *testing.T) {
+	all := applied(t, -1)
+	if len(all) < 2 || all[0].Reversible() || !all[len(all)-1].Reversible() {
+		t.Fatal("want an irreversible baseline followed by reversible migrations")
+	}
+	latest := all[len(all)-1].Version
+
+	revert, err := migrationsToRevert(all, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(revert); len(got) != 1 || got[0] != latest {
+		t.Fatalf("reverting one step picks %v, want [%d]", got, latest)
+	}
+
+	// Pending migrations are not counted as steps
+	revert, err = migrationsToRevert(applied(t, 1), 1)
+	if !errors.Is(err, ErrIrreversibleMigration) {
+		t.Fatalf("with only the baseline applied, reverting one step: got %v, %v", versions(revert), err)
+	}
+
+	_, err = migrationsToRevert(all, len(all))
+	if !errors.Is(err, ErrIrreversibleMigration) {
+		t.Fatalf("reverting every step: got %v, want ErrIrreversibleMigration", err)
+	}
+	for _, want := range []string{"baseline", fmt.Sprintf("at most %d can be reverted", len(all)-1)} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not say %q", err, want)
+		}
+	}
+
+	// A later migration without a down file is refused too
+	noDown := applied(t, -1)
+	noDown[len(noDown)-1].down = ""
+	_, err = migrationsToRevert(noDown, 1)
+	if !errors.Is(err, ErrIrreversibleMigration) || !strings.Contains(err.Error(), "no down file") {
+		t.Fatalf("reverting a migration without a down file: got %v", err)
+	}
+}
@@ -0,0 +1,955 @@
+-- Baseline of the schema of versions 1 to 39 as the first versioned migration
+--
+-- Until this migration the schema was created at every startup by
+-- idempotent statements. Each statement still checks what exists, so the
+-- baseline also brings a database of an earlier release up to date. It
+-- cannot be reverted.
+
+-- The changelog of the versions the baseline covers; clients compare
+-- versions to decide when to resync
+INSERT INTO schema_migrations (version, name, description) VALUES
+	(1, 'baseline', 'Users, categories, products, transactions and transaction_details'),
+	(2, 'promotions', 'Add promotions, transaction_detail_promotions and transaction_details.discount'),
+	(3, 'receipt_numbers', 'Add transactions.receipt_no and receipt_sequences'),
+	(4, 'translations', 'Add product_translations and category_translations'),
+	(5, 'product_relations', 'Add product_relations (substitute, accessory, upsell)'),
+	(6, 'catalog_approvals', 'Add product_change_requests'),
+	(7, 'category_hierarchy', 'Add categories.parent_id'),
+	(8, 'catalog_changesets', 'Add catalog_changesets and catalog_changeset_items'),
+	(9, 'slugs', 'Add categories.slug and products.slug'),
+	(10, 'audit_logs', 'Add audit_logs'),
+	(11, 'pickup_queue', 'Add transactions.queue_no and queue_sequences'),
+	(12, 'stock_movements', 'Add the stock_movements ledger'),
+	(13, 'stock_adjustments', 'Add stock_movements.reason_code and stock_movements.created_by'),
+	(14, 'stocktake', 'Add count_sessions and count_session_items'),
+	(15, 'cycle_counts', 'Add cycle_count_schedules and count_sessions.schedule_id, assigned_to and due_at'),
+	(16, 'min_stock', 'Add products.min_stock'),
+	(17, 'consignment', 'Add suppliers, consignment_payables and products.supplier_id, is_consignment and consignment_cost'),
+	(18, 'purchase_orders', 'Add purchase_orders, purchase_order_items, goods_receipts and goods_receipt_lines'),
+	(19, 'cost_layers', 'Add cost_layers, cost_layer_consumptions and transaction_details.cost_amount'),
+	(20, 'cost_price', 'Add products.cost_price'),
+	(21, 'idempotency_keys', 'Add idempotency_keys'),
+	(22, 'price_changes', 'Add the price_changes history'),
+	(23, 'scheduled_prices', 'Add scheduled_prices'),
+	(24, 'schema_migrations', 'Add schema_migrations'),
+	(25, 'archived_transactions', 'Add archived_transactions'),
+	(26, 'product_price_tiers', 'Add product_price_tiers and transactions.price_level'),
+	(27, 'stores', 'Add stores, store_stocks, stock_movements.store_id and transactions.store_id'),
+	(28, 'category_suggestions', 'Add category_rules and category_suggestions'),
+	(29, 'stock_transfers', 'Add stock_transfers and stock_transfer_items'),
+	(30, 'count_session_stores', 'Add count_sessions.store_id for store stocktakes'),
+	(31, 'report_schedules', 'Add report_schedules and report_runs'),
+	(32, 'tenants', 'Add tenants and tenant_id on every table; slugs, emails, store codes and receipt numbers are unique per tenant'),
+	(33, 'product_listings', 'Add the product_listings read model, kept in step with products and categories by triggers'),
+	(34, 'sync_records', 'Add sync_records tracking replication of sales, voids and stock adjustments to an upstream instance'),
+	(35, 'transaction_cashiers', 'Add transactions.cashier_id, the user who rang up the sale'),
+	(36, 'webhooks', 'Add webhooks and webhook_deliveries for outgoing event notifications'),
+	(37, 'webhook_delivery_attempts', 'Add webhook_delivery_attempts, the log of every try at a webhook delivery'),
+	(38, 'outbox_events', 'Add outbox_events for domain events, with StockChanged raised by every stock_movements row'),
+	(39, 'webhook_delivery_event_key', 'Make webhook_deliveries unique per webhook and event_id')
+ON CONFLICT (version) DO NOTHING;
+
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	role VARCHAR(50) NOT NULL DEFAULT 'cashier',
+	is_active BOOLEAN NOT NULL DEFAULT true,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create categories table
+CREATE TABLE IF NOT EXISTS categories (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	description TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add parent_id for hierarchical categories (subcategories become roots when the parent is deleted)
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES categories(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+
+-- Add URL-friendly slugs; existing rows get "<name>-<id>" so they are unique
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS slug VARCHAR(150);
+UPDATE categories SET slug = COALESCE(NULLIF(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), 'item') || '-' || id WHERE slug IS NULL;
+ALTER TABLE categories ALTER COLUMN slug SET NOT NULL;
+
+-- Create products table with foreign key to categories
+CREATE TABLE IF NOT EXISTS products (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	price INTEGER NOT NULL DEFAULT 0,
+	stock INTEGER NOT NULL DEFAULT 0,
+	sku VARCHAR(100) DEFAULT '',
+	image_url TEXT DEFAULT '',
+	unit VARCHAR(50) DEFAULT 'pcs',
+	is_active BOOLEAN DEFAULT true,
+	category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add new columns if they don't exist (for existing databases)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku VARCHAR(100) DEFAULT '';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_url TEXT DEFAULT '';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unit VARCHAR(50) DEFAULT 'pcs';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS slug VARCHAR(150);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS min_stock INT NOT NULL DEFAULT 10;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost_price INT NOT NULL DEFAULT 0;
+UPDATE products SET slug = COALESCE(NULLIF(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), 'item') || '-' || id WHERE slug IS NULL;
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+
+-- Create index on category_id for better JOIN performance
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products(category_id);
+
+-- Create translation tables (localized names/descriptions per locale)
+CREATE TABLE IF NOT EXISTS product_translations (
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	locale VARCHAR(35) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (product_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS category_translations (
+	category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+	locale VARCHAR(35) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (category_id, locale)
+);
+
+-- Create product_relations table (substitutes, accessories, upsells)
+CREATE TABLE IF NOT EXISTS product_relations (
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	related_product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	relation_type VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (product_id, related_product_id, relation_type),
+	CHECK (product_id <> related_product_id)
+);
+
+-- Create product_change_requests table (catalog approval workflow)
+CREATE TABLE IF NOT EXISTS product_change_requests (
+	id SERIAL PRIMARY KEY,
+	action VARCHAR(20) NOT NULL,
+	product_id INT REFERENCES products(id) ON DELETE CASCADE,
+	payload JSONB NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'pending',
+	requested_by INT REFERENCES users(id) ON DELETE SET NULL,
+	requested_by_name VARCHAR(255) NOT NULL DEFAULT '',
+	reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+	review_note TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_change_requests_status ON product_change_requests(status);
+
+-- Create catalog changeset tables (scheduled catalog publishing)
+CREATE TABLE IF NOT EXISTS catalog_changesets (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'draft',
+	publish_at TIMESTAMP,
+	created_by INT REFERENCES users(id) ON DELETE SET NULL,
+	published_at TIMESTAMP,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_changesets_due ON catalog_changesets(status, publish_at);
+
+CREATE TABLE IF NOT EXISTS catalog_changeset_items (
+	id SERIAL PRIMARY KEY,
+	changeset_id INT NOT NULL REFERENCES catalog_changesets(id) ON DELETE CASCADE,
+	action VARCHAR(20) NOT NULL,
+	product_id INT REFERENCES products(id) ON DELETE CASCADE,
+	payload JSONB NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create transactions table
+CREATE TABLE IF NOT EXISTS transactions (
+	id SERIAL PRIMARY KEY,
+	total_amount INT NOT NULL,
+	payment_method VARCHAR(50) DEFAULT 'cash',
+	discount INT DEFAULT 0,
+	notes TEXT DEFAULT '',
+	status VARCHAR(20) DEFAULT 'active',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add new columns to transactions if they don't exist
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payment_method VARCHAR(50) DEFAULT 'cash';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS discount INT DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS notes TEXT DEFAULT '';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'active';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS receipt_no VARCHAR(50);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS queue_no INT;
+
+-- Create receipt_sequences table (one counter row per day)
+CREATE TABLE IF NOT EXISTS receipt_sequences (
+	seq_date DATE PRIMARY KEY,
+	last_value INT NOT NULL DEFAULT 0
+);
+
+-- Create queue_sequences table (daily pickup queue: last number issued and now serving)
+CREATE TABLE IF NOT EXISTS queue_sequences (
+	seq_date DATE PRIMARY KEY,
+	last_issued INT NOT NULL DEFAULT 0,
+	now_serving INT NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create transaction_details table
+CREATE TABLE IF NOT EXISTS transaction_details (
+	id SERIAL PRIMARY KEY,
+	transaction_id INT REFERENCES transactions(id) ON DELETE CASCADE,
+	product_id INT REFERENCES products(id),
+	quantity INT NOT NULL,
+	unit_price INT NOT NULL DEFAULT 0,
+	subtotal INT NOT NULL
+);
+
+-- Add unit_price column if it doesn't exist
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_price INT DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS discount INT DEFAULT 0;
+
+-- Create promotions table
+CREATE TABLE IF NOT EXISTS promotions (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	type VARCHAR(50) NOT NULL,
+	product_id INT REFERENCES products(id) ON DELETE CASCADE,
+	category_id INT REFERENCES categories(id) ON DELETE CASCADE,
+	buy_qty INT NOT NULL DEFAULT 0,
+	get_qty INT NOT NULL DEFAULT 0,
+	bundle_qty INT NOT NULL DEFAULT 0,
+	bundle_price INT NOT NULL DEFAULT 0,
+	discount_percent INT NOT NULL DEFAULT 0,
+	starts_at TIMESTAMP,
+	ends_at TIMESTAMP,
+	is_active BOOLEAN NOT NULL DEFAULT true,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create transaction_detail_promotions table (promotions applied per detail line)
+CREATE TABLE IF NOT EXISTS transaction_detail_promotions (
+	id SERIAL PRIMARY KEY,
+	transaction_detail_id INT REFERENCES transaction_details(id) ON DELETE CASCADE,
+	promotion_id INT REFERENCES promotions(id) ON DELETE SET NULL,
+	name VARCHAR(255) NOT NULL,
+	type VARCHAR(50) NOT NULL,
+	discount INT NOT NULL DEFAULT 0
+);
+
+-- Create audit_logs table (who changed what, with before/after snapshots)
+CREATE TABLE IF NOT EXISTS audit_logs (
+	id BIGSERIAL PRIMARY KEY,
+	entity_type VARCHAR(50) NOT NULL,
+	entity_id INT NOT NULL,
+	action VARCHAR(20) NOT NULL,
+	actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+	actor_name VARCHAR(255) NOT NULL DEFAULT '',
+	before_data JSONB,
+	after_data JSONB,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- Create stock_movements table (append-only stock ledger). product_id has no
+-- foreign key so the history survives product deletion.
+CREATE TABLE IF NOT EXISTS stock_movements (
+	id BIGSERIAL PRIMARY KEY,
+	product_id INT NOT NULL,
+	quantity_delta INT NOT NULL,
+	balance_after INT NOT NULL,
+	reason VARCHAR(20) NOT NULL,
+	reference_type VARCHAR(20) NOT NULL DEFAULT '',
+	reference_id INT,
+	note TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product ON stock_movements(product_id, id);
+
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_stock_movements_append_only ON stock_movements;
+CREATE TRIGGER trg_stock_movements_append_only
+	BEFORE UPDATE OR DELETE ON stock_movements
+	FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- Add reason codes and the acting user to the stock ledger (manual adjustments)
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS reason_code VARCHAR(30) NOT NULL DEFAULT '';
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS created_by INT;
+
+-- Create stocktake tables (count sessions and the products counted in them)
+CREATE TABLE IF NOT EXISTS count_sessions (
+	id SERIAL PRIMARY KEY,
+	type VARCHAR(20) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'open',
+	note TEXT NOT NULL DEFAULT '',
+	created_by INT REFERENCES users(id) ON DELETE SET NULL,
+	completed_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_count_sessions_status ON count_sessions(status, type);
+
+CREATE TABLE IF NOT EXISTS count_session_items (
+	id SERIAL PRIMARY KEY,
+	session_id INT NOT NULL REFERENCES count_sessions(id) ON DELETE CASCADE,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	sample_weight DOUBLE PRECISION NOT NULL DEFAULT 0,
+	expected_qty INT NOT NULL,
+	counted_qty INT,
+	counted_by INT REFERENCES users(id) ON DELETE SET NULL,
+	counted_at TIMESTAMP,
+	UNIQUE (session_id, product_id)
+);
+
+-- Create cycle_count_schedules table (recurring counts per ABC class)
+CREATE TABLE IF NOT EXISTS cycle_count_schedules (
+	id SERIAL PRIMARY KEY,
+	abc_class CHAR(1) NOT NULL,
+	frequency VARCHAR(20) NOT NULL,
+	assigned_to INT REFERENCES users(id) ON DELETE SET NULL,
+	is_active BOOLEAN NOT NULL DEFAULT true,
+	next_run_at TIMESTAMP NOT NULL,
+	last_run_at TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cycle_count_schedules_due ON cycle_count_schedules(is_active, next_run_at);
+
+-- Link count sessions to the schedule that opened them, the assignee and a due time
+ALTER TABLE count_sessions ADD COLUMN IF NOT EXISTS schedule_id INT REFERENCES cycle_count_schedules(id) ON DELETE SET NULL;
+ALTER TABLE count_sessions ADD COLUMN IF NOT EXISTS assigned_to INT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE count_sessions ADD COLUMN IF NOT EXISTS due_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_count_sessions_schedule ON count_sessions(schedule_id);
+
+-- Create suppliers and consignment_payables tables. Consigned products stay
+-- owned by their supplier; each sale accrues a payable instead of COGS.
+CREATE TABLE IF NOT EXISTS suppliers (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	contact_name VARCHAR(255) NOT NULL DEFAULT '',
+	phone VARCHAR(50) NOT NULL DEFAULT '',
+	email VARCHAR(255) NOT NULL DEFAULT '',
+	address TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS supplier_id INT REFERENCES suppliers(id) ON DELETE SET NULL;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_consignment BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS consignment_cost INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS consignment_payables (
+	id BIGSERIAL PRIMARY KEY,
+	transaction_id INT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+	transaction_detail_id INT REFERENCES transaction_details(id) ON DELETE SET NULL,
+	supplier_id INT NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+	product_id INT REFERENCES products(id) ON DELETE SET NULL,
+	quantity INT NOT NULL,
+	unit_cost INT NOT NULL,
+	amount INT NOT NULL,
+	sales_amount INT NOT NULL,
+	entry_type VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_consignment_payables_supplier ON consignment_payables(supplier_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_consignment_payables_transaction ON consignment_payables(transaction_id);
+
+-- Create purchase order tables. Goods receipts keep the cost price of every
+-- received line.
+CREATE TABLE IF NOT EXISTS purchase_orders (
+	id SERIAL PRIMARY KEY,
+	supplier_id INT NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+	status VARCHAR(20) NOT NULL DEFAULT 'open',
+	note TEXT NOT NULL DEFAULT '',
+	created_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders(status, supplier_id);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+	id SERIAL PRIMARY KEY,
+	purchase_order_id INT NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+	quantity_ordered INT NOT NULL,
+	quantity_received INT NOT NULL DEFAULT 0,
+	unit_cost INT NOT NULL DEFAULT 0,
+	UNIQUE (purchase_order_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+	id SERIAL PRIMARY KEY,
+	purchase_order_id INT NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+	note TEXT NOT NULL DEFAULT '',
+	received_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+	id SERIAL PRIMARY KEY,
+	goods_receipt_id INT NOT NULL REFERENCES goods_receipts(id) ON DELETE CASCADE,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+	quantity INT NOT NULL,
+	unit_cost INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipt_lines_product ON goods_receipt_lines(product_id);
+
+-- Create FIFO cost layer tables. Goods receipts open layers, sales consume
+-- them and record the cost of goods sold on the transaction line.
+CREATE TABLE IF NOT EXISTS cost_layers (
+	id BIGSERIAL PRIMARY KEY,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	source_type VARCHAR(30) NOT NULL,
+	source_id INT NOT NULL,
+	quantity INT NOT NULL,
+	remaining INT NOT NULL,
+	unit_cost INT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cost_layers_open ON cost_layers(product_id, id) WHERE remaining > 0;
+
+CREATE TABLE IF NOT EXISTS cost_layer_consumptions (
+	id BIGSERIAL PRIMARY KEY,
+	transaction_detail_id INT NOT NULL REFERENCES transaction_details(id) ON DELETE CASCADE,
+	cost_layer_id BIGINT REFERENCES cost_layers(id) ON DELETE SET NULL,
+	quantity INT NOT NULL,
+	unit_cost INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_cost_layer_consumptions_detail ON cost_layer_consumptions(transaction_detail_id);
+
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS cost_amount INT NOT NULL DEFAULT 0;
+
+-- Create idempotency_keys table. A POST or PATCH sent with an
+-- Idempotency-Key header is executed once per user and key; retries
+-- replay the stored response.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	user_id INT NOT NULL,
+	idempotency_key VARCHAR(255) NOT NULL,
+	method VARCHAR(10) NOT NULL,
+	path TEXT NOT NULL,
+	request_hash VARCHAR(64) NOT NULL,
+	status_code INT NOT NULL DEFAULT 0,
+	response_body BYTEA,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+-- Create price_changes table (append-only price history). product_id has
+-- no foreign key so the history survives product deletion.
+CREATE TABLE IF NOT EXISTS price_changes (
+	id BIGSERIAL PRIMARY KEY,
+	product_id INT NOT NULL,
+	old_price INT,
+	new_price INT NOT NULL,
+	source VARCHAR(30) NOT NULL,
+	reference_id INT,
+	changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+	changed_by_name VARCHAR(255) NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_changes_product ON price_changes(product_id, created_at);
+
+-- Products that predate the history start with their current price
+INSERT INTO price_changes (product_id, new_price, source, created_at)
+SELECT p.id, p.price, 'product', p.created_at
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM price_changes pc WHERE pc.product_id = p.id);
+
+-- Create scheduled_prices table (future-dated price changes applied by
+-- the price scheduler)
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+	id SERIAL PRIMARY KEY,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	price INT NOT NULL,
+	effective_at TIMESTAMP NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'pending',
+	created_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_by_name VARCHAR(255) NOT NULL DEFAULT '',
+	applied_at TIMESTAMP,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_due ON scheduled_prices(status, effective_at);
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_product ON scheduled_prices(product_id);
+
+-- Create archived_transactions table (index of transactions moved to cold
+-- storage, keeping enough to find and list them without a download)
+CREATE TABLE IF NOT EXISTS archived_transactions (
+	id INT PRIMARY KEY,
+	receipt_no VARCHAR(50) NOT NULL DEFAULT '',
+	total_amount INT NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	object_key TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	archived_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_transactions_receipt_no ON archived_transactions(receipt_no);
+
+-- Create product_price_tiers table (price levels and quantity breaks) and
+-- record which price level each checkout was priced at
+CREATE TABLE IF NOT EXISTS product_price_tiers (
+	id SERIAL PRIMARY KEY,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	price_level VARCHAR(20) NOT NULL,
+	min_quantity INT NOT NULL DEFAULT 1,
+	price INT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (product_id, price_level, min_quantity)
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS price_level VARCHAR(20) NOT NULL DEFAULT 'retail';
+
+-- Create stores and store_stocks tables. products.stock stays the total
+-- over all stores; store_stocks holds each store's share and is kept in
+-- step by the stock ledger. Existing stock, movements and transactions are
+-- assigned to the default store created here.
+CREATE TABLE IF NOT EXISTS stores (
+	id SERIAL PRIMARY KEY,
+	code VARCHAR(20) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	address TEXT NOT NULL DEFAULT '',
+	is_default BOOLEAN NOT NULL DEFAULT FALSE,
+	is_active BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO stores (code, name, is_default)
+SELECT 'MAIN', 'Main Store', TRUE
+WHERE NOT EXISTS (SELECT 1 FROM stores);
+
+CREATE TABLE IF NOT EXISTS store_stocks (
+	store_id INT NOT NULL REFERENCES stores(id) ON DELETE RESTRICT,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	stock INT NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (store_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_store_stocks_product ON store_stocks(product_id);
+
+INSERT INTO store_stocks (store_id, product_id, stock)
+SELECT (SELECT id FROM stores WHERE is_default), p.id, p.stock
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM store_stocks ss WHERE ss.product_id = p.id);
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS store_id INT REFERENCES stores(id) ON DELETE RESTRICT;
+UPDATE stock_movements SET store_id = (SELECT id FROM stores WHERE is_default) WHERE store_id IS NULL;
+ALTER TABLE stock_movements ALTER COLUMN store_id SET NOT NULL;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS store_id INT REFERENCES stores(id) ON DELETE RESTRICT;
+UPDATE transactions SET store_id = (SELECT id FROM stores WHERE is_default) WHERE store_id IS NULL;
+ALTER TABLE transactions ALTER COLUMN store_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_store ON transactions(store_id, created_at);
+
+-- Create category suggestion tables: keyword rules and the review queue
+CREATE TABLE IF NOT EXISTS category_rules (
+	id SERIAL PRIMARY KEY,
+	category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+	keyword VARCHAR(100) NOT NULL,
+	weight INT NOT NULL DEFAULT 1 CHECK (weight > 0),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (category_id, keyword)
+);
+
+CREATE TABLE IF NOT EXISTS category_suggestions (
+	id SERIAL PRIMARY KEY,
+	product_id INT NOT NULL UNIQUE REFERENCES products(id) ON DELETE CASCADE,
+	category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+	confidence NUMERIC(4,3) NOT NULL,
+	source VARCHAR(20) NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	status VARCHAR(20) NOT NULL DEFAULT 'pending',
+	reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_suggestions_status ON category_suggestions(status);
+
+-- Create stock transfer tables (goods sent between stores)
+CREATE TABLE IF NOT EXISTS stock_transfers (
+	id SERIAL PRIMARY KEY,
+	from_store_id INT NOT NULL REFERENCES stores(id),
+	to_store_id INT NOT NULL REFERENCES stores(id),
+	status VARCHAR(20) NOT NULL DEFAULT 'in_transit',
+	note TEXT NOT NULL DEFAULT '',
+	created_by INT REFERENCES users(id) ON DELETE SET NULL,
+	received_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	received_at TIMESTAMP,
+	CHECK (from_store_id <> to_store_id)
+);
+
+CREATE TABLE IF NOT EXISTS stock_transfer_items (
+	id SERIAL PRIMARY KEY,
+	transfer_id INT NOT NULL REFERENCES stock_transfers(id) ON DELETE CASCADE,
+	product_id INT NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+	quantity INT NOT NULL CHECK (quantity > 0),
+	UNIQUE (transfer_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_status ON stock_transfers(status);
+
+-- Stocktake sessions count the stock of one store; spot-check and cycle
+-- sessions leave store_id NULL and count product totals
+ALTER TABLE count_sessions ADD COLUMN IF NOT EXISTS store_id INT REFERENCES stores(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_count_sessions_store ON count_sessions(store_id);
+
+-- Create report_schedules and report_runs tables (reports rendered on a
+-- cron and uploaded to object storage)
+CREATE TABLE IF NOT EXISTS report_schedules (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	report VARCHAR(50) NOT NULL,
+	format VARCHAR(10) NOT NULL,
+	period VARCHAR(20) NOT NULL,
+	store_id INT REFERENCES stores(id) ON DELETE CASCADE,
+	cron VARCHAR(100) NOT NULL,
+	timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+	target VARCHAR(20) NOT NULL,
+	path_prefix VARCHAR(255) NOT NULL DEFAULT '',
+	is_active BOOLEAN NOT NULL DEFAULT true,
+	next_run_at TIMESTAMP,
+	last_run_at TIMESTAMP,
+	created_by INT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_due ON report_schedules(is_active, next_run_at);
+
+CREATE TABLE IF NOT EXISTS report_runs (
+	id SERIAL PRIMARY KEY,
+	schedule_id INT NOT NULL REFERENCES report_schedules(id) ON DELETE CASCADE,
+	trigger_type VARCHAR(20) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'running',
+	period_start DATE,
+	period_end DATE,
+	object_key TEXT NOT NULL DEFAULT '',
+	size_bytes INT NOT NULL DEFAULT 0,
+	error TEXT NOT NULL DEFAULT '',
+	started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_runs_schedule ON report_runs(schedule_id, id DESC);
+
+-- Create the product_listings read model served by GET /v1/products: one
+-- row per product with its category name copied in, so listings need no
+-- join. Triggers keep it in step with products and category renames;
+-- products.stock is already the total over all stores. A listing is
+-- deleted with its product through the foreign key.
+CREATE TABLE IF NOT EXISTS product_listings (
+	product_id INT PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+	name VARCHAR(255) NOT NULL,
+	slug VARCHAR(150) NOT NULL,
+	price INT NOT NULL,
+	stock INT NOT NULL,
+	min_stock INT NOT NULL,
+	sku VARCHAR(100) NOT NULL DEFAULT '',
+	image_url TEXT NOT NULL DEFAULT '',
+	unit VARCHAR(50) NOT NULL DEFAULT '',
+	is_active BOOLEAN NOT NULL DEFAULT TRUE,
+	category_id INT,
+	category_name VARCHAR(255) NOT NULL DEFAULT '',
+	supplier_id INT,
+	is_consignment BOOLEAN NOT NULL DEFAULT FALSE,
+	consignment_cost INT NOT NULL DEFAULT 0,
+	cost_price INT NOT NULL DEFAULT 0,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_listings_category ON product_listings(category_id);
+
+CREATE OR REPLACE FUNCTION refresh_product_listing(pid INT) RETURNS void AS $$
+	INSERT INTO product_listings (product_id, name, slug, price, stock, min_stock, sku, image_url, unit,
+		is_active, category_id, category_name, supplier_id, is_consignment, consignment_cost, cost_price,
+		created_at, updated_at)
+	SELECT p.id, p.name, p.slug, p.price, p.stock, p.min_stock, COALESCE(p.sku, ''), COALESCE(p.image_url, ''),
+		COALESCE(p.unit, ''), COALESCE(p.is_active, TRUE), p.category_id, COALESCE(c.name, ''), p.supplier_id,
+		p.is_consignment, p.consignment_cost, p.cost_price, p.created_at, p.updated_at
+	FROM products p
+	LEFT JOIN categories c ON c.id = p.category_id
+	WHERE p.id = pid
+	ON CONFLICT (product_id) DO UPDATE SET
+		name = EXCLUDED.name, slug = EXCLUDED.slug, price = EXCLUDED.price, stock = EXCLUDED.stock,
+		min_stock = EXCLUDED.min_stock, sku = EXCLUDED.sku, image_url = EXCLUDED.image_url, unit = EXCLUDED.unit,
+		is_active = EXCLUDED.is_active, category_id = EXCLUDED.category_id, category_name = EXCLUDED.category_name,
+		supplier_id = EXCLUDED.supplier_id, is_consignment = EXCLUDED.is_consignment,
+		consignment_cost = EXCLUDED.consignment_cost, cost_price = EXCLUDED.cost_price,
+		created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION products_sync_listing() RETURNS trigger AS $$
+BEGIN
+	PERFORM refresh_product_listing(NEW.id);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_products_sync_listing ON products;
+CREATE TRIGGER trg_products_sync_listing
+	AFTER INSERT OR UPDATE ON products
+	FOR EACH ROW EXECUTE FUNCTION products_sync_listing();
+
+CREATE OR REPLACE FUNCTION categories_sync_listings() RETURNS trigger AS $$
+BEGIN
+	UPDATE product_listings SET category_name = NEW.name WHERE category_id = NEW.id;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_categories_sync_listings ON categories;
+CREATE TRIGGER trg_categories_sync_listings
+	AFTER UPDATE OF name ON categories
+	FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+	EXECUTE FUNCTION categories_sync_listings();
+
+-- Listings of the products that predate the table
+SELECT refresh_product_listing(p.id)
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM product_listings l WHERE l.product_id = p.id);
+
+-- Create sync_records: how each local sale, void and stock adjustment was
+-- replicated to the upstream instance. Rows without a record are pending;
+-- failed ones are retried, conflicts wait for POST /v1/sync/retry.
+CREATE TABLE IF NOT EXISTS sync_records (
+	id SERIAL PRIMARY KEY,
+	entity_type VARCHAR(30) NOT NULL,
+	entity_id INT NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	remote_id INT,
+	attempts INT NOT NULL DEFAULT 0,
+	retry_count INT NOT NULL DEFAULT 0,
+	message TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (entity_type, entity_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sync_records_status ON sync_records(status);
+
+-- Record the cashier who rang up each sale, for reports per cashier
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS cashier_id INT REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_cashier ON transactions(cashier_id);
+
+-- Create webhooks and webhook_deliveries: integrator URLs subscribed to
+-- events (comma-separated), and every event queued for each of them with
+-- the outcome of its latest attempt. Pending deliveries are retried at
+-- next_attempt_at.
+CREATE TABLE IF NOT EXISTS webhooks (
+	id SERIAL PRIMARY KEY,
+	url TEXT NOT NULL,
+	description VARCHAR(255) NOT NULL DEFAULT '',
+	events TEXT NOT NULL,
+	secret VARCHAR(100) NOT NULL,
+	is_active BOOLEAN NOT NULL DEFAULT true,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id SERIAL PRIMARY KEY,
+	webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+	event_id VARCHAR(50) NOT NULL,
+	event VARCHAR(50) NOT NULL,
+	payload JSONB NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'pending',
+	attempts INT NOT NULL DEFAULT 0,
+	response_status INT NOT NULL DEFAULT 0,
+	error TEXT NOT NULL DEFAULT '',
+	next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- Create webhook_delivery_attempts: every try at a delivery with the
+-- response status, the start of the response body and how long it took,
+-- so integrators can see why a delivery is being retried
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+	id SERIAL PRIMARY KEY,
+	delivery_id INT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+	attempt INT NOT NULL,
+	response_status INT NOT NULL DEFAULT 0,
+	response_body TEXT NOT NULL DEFAULT '',
+	error TEXT NOT NULL DEFAULT '',
+	duration_ms INT NOT NULL DEFAULT 0,
+	attempted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(webhook_id, status, id DESC);
+
+-- Create outbox_events: domain events written in the DB transaction of
+-- the change they describe and handed to consumers by a relay, so no
+-- committed change goes unannounced. Every stock ledger row raises
+-- StockChanged through a trigger; TransactionCreated is written by checkout.
+CREATE TABLE IF NOT EXISTS outbox_events (
+	id SERIAL PRIMARY KEY,
+	event_type VARCHAR(50) NOT NULL,
+	aggregate_type VARCHAR(50) NOT NULL,
+	aggregate_id INT NOT NULL,
+	payload JSONB NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION stock_movements_outbox() RETURNS trigger AS $$
+BEGIN
+	INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+	VALUES ('StockChanged', 'product', NEW.product_id, jsonb_build_object(
+		'movement_id', NEW.id,
+		'product_id', NEW.product_id,
+		'store_id', NEW.store_id,
+		'quantity_delta', NEW.quantity_delta,
+		'balance_after', NEW.balance_after,
+		'reason', NEW.reason,
+		'reference_type', NEW.reference_type,
+		'reference_id', NEW.reference_id,
+		'created_at', NEW.created_at
+	));
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_stock_movements_outbox ON stock_movements;
+CREATE TRIGGER trg_stock_movements_outbox
+	AFTER INSERT ON stock_movements
+	FOR EACH ROW EXECUTE FUNCTION stock_movements_outbox();
+
+-- An event is queued once per webhook, so relaying an outbox event again
+-- after another consumer failed does not deliver it twice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id);
+
+-- Create tenants and isolate every other table by tenant. Each table gets
+-- a tenant_id defaulting to the connection's tenant and a row-level
+-- security policy, so repositories only ever see and write their own
+-- tenant's rows without naming the tenant in their queries. Tenant
+-- connections set app.tenant_id when they connect (see OpenTenantDB);
+-- connections without it, including this migration, act as the default
+-- tenant. isolate_tenant_tables covers every table that lacks isolation,
+-- and runs again after every later migration, so new tables are covered
+-- automatically.
+CREATE TABLE IF NOT EXISTS tenants (
+	id SERIAL PRIMARY KEY,
+	slug VARCHAR(50) UNIQUE NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	api_key_hash VARCHAR(64) UNIQUE,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO tenants (id, slug, name) VALUES (1, 'default', 'Default')
+ON CONFLICT (id) DO NOTHING;
+SELECT setval('tenants_id_seq', (SELECT MAX(id) FROM tenants));
+
+CREATE OR REPLACE FUNCTION current_tenant_id() RETURNS INT AS $$
+	SELECT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '1')::int
+$$ LANGUAGE sql STABLE;
+
+-- Adds tenant_id and the tenant_isolation policy to every table that lacks
+-- them. FORCE makes the policy apply to the table owner too; superusers and
+-- BYPASSRLS roles still bypass it, which is why multi-tenant mode refuses to
+-- start with such a role (see CheckTenantIsolation).
+CREATE OR REPLACE FUNCTION isolate_tenant_tables() RETURNS void AS $$
+DECLARE
+	t RECORD;
+BEGIN
+	FOR t IN
+		SELECT c.relname AS name,
+			EXISTS (SELECT 1 FROM pg_attribute a WHERE a.attrelid = c.oid AND a.attname = 'tenant_id' AND NOT a.attisdropped) AS has_column,
+			c.relrowsecurity AND c.relforcerowsecurity AS forced,
+			EXISTS (SELECT 1 FROM pg_policy p WHERE p.polrelid = c.oid AND p.polname = 'tenant_isolation') AS has_rule
+		FROM pg_class c
+		WHERE c.relnamespace = current_schema()::regnamespace AND c.relkind = 'r'
+		  AND c.relname NOT IN ('tenants', 'schema_migrations')
+		ORDER BY c.relname
+	LOOP
+		IF NOT t.has_column THEN
+			EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id INT NOT NULL DEFAULT current_tenant_id() REFERENCES tenants(id) ON DELETE RESTRICT', t.name);
+			EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(tenant_id)', 'idx_' || t.name || '_tenant', t.name);
+		END IF;
+		IF NOT t.forced THEN
+			EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t.name);
+			EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t.name);
+		END IF;
+		IF NOT t.has_rule THEN
+			EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id())', t.name);
+		END IF;
+	END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT isolate_tenant_tables();
+
+-- Natural keys are unique per tenant rather than globally
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
+DROP INDEX IF EXISTS idx_categories_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_tenant_slug ON categories(tenant_id, slug);
+DROP INDEX IF EXISTS idx_products_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_tenant_slug ON products(tenant_id, slug);
+DROP INDEX IF EXISTS idx_transactions_receipt_no;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_tenant_receipt_no ON transactions(tenant_id, receipt_no);
+ALTER TABLE stores DROP CONSTRAINT IF EXISTS stores_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stores_tenant_code ON stores(tenant_id, code);
+DROP INDEX IF EXISTS idx_stores_default;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stores_tenant_default ON stores(tenant_id) WHERE is_default;
+DO $$ BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'receipt_sequences_tenant_pkey') THEN
+		ALTER TABLE receipt_sequences DROP CONSTRAINT IF EXISTS receipt_sequences_pkey;
+		ALTER TABLE receipt_sequences ADD CONSTRAINT receipt_sequences_tenant_pkey PRIMARY KEY (tenant_id, seq_date);
+	END IF;
+END $$;
+DO $$ BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'queue_sequences_tenant_pkey') THEN
+		ALTER TABLE queue_sequences DROP CONSTRAINT IF EXISTS queue_sequences_pkey;
+		ALTER TABLE queue_sequences ADD CONSTRAINT queue_sequences_tenant_pkey PRIMARY KEY (tenant_id, seq_date);
+	END IF;
+END $$;
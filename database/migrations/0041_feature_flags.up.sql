@@ -0,0 +1,14 @@
+-- Add feature_flags: features switched on or off per tenant, or per store
+CREATE TABLE feature_flags (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(50) NOT NULL,
+	-- NULL for the setting of the whole tenant
+	store_id INT REFERENCES stores(id) ON DELETE CASCADE,
+	enabled BOOLEAN NOT NULL,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Adds tenant_id now rather than after the migration, for the index below
+SELECT isolate_tenant_tables();
+
+CREATE UNIQUE INDEX idx_feature_flags_tenant_name_store ON feature_flags(tenant_id, name, COALESCE(store_id, 0));
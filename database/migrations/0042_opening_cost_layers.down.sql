@@ -0,0 +1,3 @@
+-- The previous release only layers goods receipts. Sales already costed from
+-- the removed layers keep their cost.
+DELETE FROM cost_layers WHERE source_type IN ('opening', 'stock_adjustment', 'count_session');
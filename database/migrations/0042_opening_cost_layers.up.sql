@@ -0,0 +1,10 @@
+-- Add opening cost layers: stock no cost layer covers is layered at the product's cost_price
+--
+-- Covers the default tenant; SeedTenant backfills the others at startup.
+INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost)
+SELECT p.id, 'opening', p.id, p.stock - c.covered, p.stock - c.covered, p.cost_price
+FROM products p
+CROSS JOIN LATERAL (
+	SELECT COALESCE(SUM(remaining), 0) AS covered FROM cost_layers WHERE product_id = p.id
+) c
+WHERE p.is_consignment = false AND p.cost_price > 0 AND p.stock > c.covered;
@@ -0,0 +1,2 @@
+DROP TABLE outbox_consumers;
+DROP TABLE outbox_deliveries;
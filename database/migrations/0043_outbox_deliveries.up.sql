@@ -0,0 +1,27 @@
+-- Add outbox_deliveries and outbox_consumers: each consumer of the outbox relays events on its own and parks an event it keeps failing
+CREATE TABLE outbox_deliveries (
+	event_id INT NOT NULL REFERENCES outbox_events(id) ON DELETE CASCADE,
+	consumer VARCHAR(50) NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	-- When a failed event is tried again
+	retry_at TIMESTAMP,
+	delivered_at TIMESTAMP,
+	-- Set when the consumer gave up on the event after too many attempts
+	parked_at TIMESTAMP,
+	PRIMARY KEY (event_id, consumer)
+);
+
+CREATE INDEX idx_outbox_deliveries_parked ON outbox_deliveries(consumer) WHERE parked_at IS NOT NULL;
+
+-- One relay at a time hands a consumer its events; it holds the lease while
+-- it does, outside any DB transaction
+CREATE TABLE outbox_consumers (
+	consumer VARCHAR(50) NOT NULL,
+	leased_until TIMESTAMP NOT NULL DEFAULT '-infinity'
+);
+
+-- Adds tenant_id now rather than after the migration, for the index below
+SELECT isolate_tenant_tables();
+
+CREATE UNIQUE INDEX idx_outbox_consumers_tenant_consumer ON outbox_consumers(tenant_id, consumer);
@@ -2,51 +2,163 @@ package database
 
 import (
 	"database/sql"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"retail-core-api/chaos"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DB holds the database connection
 var DB *sql.DB
 
-// InitDB establishes connection to PostgreSQL database
-func InitDB(connectionString string) (*sql.DB, error) {
-	log.Println("Connecting to database...")
+// PoolSettings sizes a connection pool and bounds its connections and
+// queries. Zero durations mean no limit.
+type PoolSettings struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout cancels a statement running longer, server-side
+	StatementTimeout time.Duration
+}
+
+// InitDB establishes connection to PostgreSQL database. When injector is
+// not nil, every query goes through it so faults can be injected (staging only).
+func InitDB(connectionString string, pool PoolSettings, injector *chaos.Injector) (*sql.DB, error) {
+	slog.Info("connecting to database")
+
+	db, err := openDB(connectionString, pool, injector)
+	if err != nil {
+		return nil, err
+	}
 
+	DB = db
+	slog.Info("database connected")
+	return db, nil
+}
+
+// OpenReplicaDB opens a connection pool on a read replica of the database,
+// for the report and listing queries that may lag behind the primary. The
+// schema is the primary's, so no migrations run on it.
+func OpenReplicaDB(connectionString string, pool PoolSettings, injector *chaos.Injector) (*sql.DB, error) {
+	slog.Info("connecting to read replica")
+	return openDB(connectionString, pool, injector)
+}
+
+// MigrateDB runs the pending migrations (see RunMigrations) on a
+// connection of their own
+func MigrateDB(connectionString string, injector *chaos.Injector) error {
+	db, err := OpenMigrationDB(connectionString, injector)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return RunMigrations(db)
+}
+
+// OpenMigrationDB opens a single connection for migrating, without the
+// statement timeout of the other pools: creating an index on a large table
+// may well take longer than any query should.
+func OpenMigrationDB(connectionString string, injector *chaos.Injector) (*sql.DB, error) {
+	return openDB(connectionString, PoolSettings{MaxOpenConns: 1, MaxIdleConns: 1}, injector)
+}
+
+// OpenTenantDB opens a connection pool whose connections all act as the
+// given tenant: app.tenant_id is set when each connection starts, so the
+// row-level security policies limit every query to that tenant's rows. The
+// setting is a startup parameter, which PgBouncer in transaction pooling mode
+// drops or shares between clients; tenant pools need a direct connection or
+// session pooling, and the pool is checked before it is returned.
+func OpenTenantDB(connectionString string, tenantID int, pool PoolSettings, injector *chaos.Injector) (*sql.DB, error) {
+	separator := "?"
+	if strings.Contains(connectionString, "?") {
+		separator = "&"
+	}
+	db, err := openDB(connectionString+separator+"options=-c%20app.tenant_id%3D"+fmt.Sprint(tenantID), pool, injector)
+	if err != nil {
+		return nil, err
+	}
+
+	var actual int
+	if err := db.QueryRow("SELECT current_tenant_id()").Scan(&actual); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if actual != tenantID {
+		db.Close()
+		return nil, fmt.Errorf("tenant %d connection acts as tenant %d: the app.tenant_id startup parameter was not applied (connection pooler?)", tenantID, actual)
+	}
+	return db, nil
+}
+
+// openDB opens and pings a pgx pool sized by pool, appending the settings
+// every pool needs
+func openDB(connectionString string, pool PoolSettings, injector *chaos.Injector) (*sql.DB, error) {
 	// Disable prepared statement cache for PgBouncer compatibility (Supabase)
 	if strings.Contains(connectionString, "?") {
 		connectionString += "&default_query_exec_mode=exec"
 	} else {
 		connectionString += "?default_query_exec_mode=exec"
 	}
+	// pgx sends parameters it does not know itself as run-time parameters
+	// when connecting, so the timeout holds for the whole session
+	if pool.StatementTimeout > 0 {
+		connectionString += "&statement_timeout=" + strconv.FormatInt(pool.StatementTimeout.Milliseconds(), 10)
+	}
 
 	// Open database with pgx driver
-	db, err := sql.Open("pgx", connectionString)
+	driverName := "pgx"
+	if injector != nil {
+		registerChaosDriver(injector)
+		driverName = chaosDriverName
+	}
+	// Every query, commit and rollback is a span when tracing is on
+	db, err := otelsql.Open(driverName, connectionString,
+		otelsql.WithAttributes(attribute.String("db.system", "postgresql")),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true}),
+	)
 	if err != nil {
 		return nil, err
 	}
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
 	// Test connection
-	err = db.Ping()
-	if err != nil {
+	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, err
 	}
-
-	// Set connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-
-	DB = db
-	log.Println("Database connected successfully")
 	return db, nil
 }
 
+// CheckTenantIsolation returns an error when the database role bypasses
+// row-level security (superuser or BYPASSRLS), in which case tenants would
+// see each other's rows
+func CheckTenantIsolation(db *sql.DB) error {
+	var bypass bool
+	err := db.QueryRow("SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user").Scan(&bypass)
+	if err != nil {
+		return err
+	}
+	if bypass {
+		return errors.New("the database role is a superuser or has BYPASSRLS, so row-level security cannot isolate tenants; connect with a regular role")
+	}
+	return nil
+}
+
 // CloseDB closes the database connection
 func CloseDB() {
 	if DB != nil {
 		DB.Close()
-		log.Println("Database connection closed")
+		slog.Info("database connection closed")
 	}
 }
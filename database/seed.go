@@ -0,0 +1,464 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"retail-core-api/models"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrCatalogNotEmpty is returned by SeedDemoData for a database that already
+// has products, so demo data never mixes with real data
+var ErrCatalogNotEmpty = errors.New("the catalog is not empty: demo data is only seeded into a database without products")
+
+// DemoOptions shapes the demo data: how many days of sales lead up to now,
+// the average sales per day (weekends sell more) and the random seed, so the
+// same seed yields the same data
+type DemoOptions struct {
+	Days        int
+	SalesPerDay int
+	Seed        int64
+}
+
+// DemoSummary counts what SeedDemoData created
+type DemoSummary struct {
+	Categories   int
+	Products     int
+	Cashiers     int
+	Transactions int
+	Revenue      int
+}
+
+type demoProduct struct {
+	name, sku, unit string
+	price, cost     int
+	// popularity weighs how often the product is sold
+	popularity int
+}
+
+type demoCategory struct {
+	name, description string
+	products          []demoProduct
+}
+
+// demoCatalog is a small convenience store, priced in whole currency units
+var demoCatalog = []demoCategory{
+	{"Beverages", "Bottled drinks, coffee and tea", []demoProduct{
+		{"Mineral Water 600ml", "BEV-001", "bottle", 4000, 2800, 10},
+		{"Iced Tea 350ml", "BEV-002", "bottle", 5500, 3900, 7},
+		{"Cola 390ml", "BEV-003", "can", 8000, 6000, 6},
+		{"Orange Juice 1L", "BEV-004", "carton", 22000, 16500, 3},
+		{"Instant Coffee 10x20g", "BEV-005", "pack", 16500, 12800, 4},
+		{"Green Tea Bags 25s", "BEV-006", "box", 12000, 8700, 2},
+	}},
+	{"Snacks", "Chips, biscuits and sweets", []demoProduct{
+		{"Potato Chips 68g", "SNK-001", "pack", 11500, 8200, 6},
+		{"Chocolate Wafer 130g", "SNK-002", "pack", 9000, 6400, 5},
+		{"Salted Peanuts 250g", "SNK-003", "pack", 14000, 10100, 3},
+		{"Butter Cookies 200g", "SNK-004", "tin", 32000, 24000, 2},
+		{"Mint Candy 100g", "SNK-005", "pack", 6500, 4300, 4},
+	}},
+	{"Dairy", "Milk, yoghurt and cheese", []demoProduct{
+		{"Fresh Milk 1L", "DRY-001", "carton", 21000, 16800, 6},
+		{"Strawberry Yoghurt 200g", "DRY-002", "cup", 9500, 7000, 4},
+		{"Cheddar Slices 10s", "DRY-003", "pack", 24500, 19000, 2},
+		{"Sweetened Condensed Milk", "DRY-004", "can", 12500, 9800, 3},
+	}},
+	{"Bakery", "Bread and pastries", []demoProduct{
+		{"White Bread Loaf", "BKR-001", "loaf", 16000, 11000, 6},
+		{"Chocolate Bun", "BKR-002", "pcs", 6000, 3800, 5},
+		{"Butter Croissant", "BKR-003", "pcs", 12000, 7500, 3},
+	}},
+	{"Instant Food", "Noodles, canned and frozen food", []demoProduct{
+		{"Fried Instant Noodles", "INS-001", "pack", 3500, 2700, 12},
+		{"Chicken Soup Noodles", "INS-002", "pack", 3200, 2450, 9},
+		{"Canned Sardines 155g", "INS-003", "can", 11000, 8400, 3},
+		{"Frozen Chicken Nuggets 500g", "INS-004", "pack", 42000, 33500, 2},
+	}},
+	{"Household", "Cleaning and kitchen supplies", []demoProduct{
+		{"Dish Soap 800ml", "HSH-001", "pouch", 15500, 11600, 3},
+		{"Laundry Detergent 1kg", "HSH-002", "pack", 27000, 21000, 2},
+		{"Tissue Box 250s", "HSH-003", "box", 13000, 9400, 3},
+		{"Trash Bags 30s", "HSH-004", "roll", 10500, 7300, 2},
+	}},
+	{"Personal Care", "Toiletries", []demoProduct{
+		{"Toothpaste 190g", "PRC-001", "tube", 14500, 10900, 3},
+		{"Shampoo 170ml", "PRC-002", "bottle", 23000, 17200, 2},
+		{"Bath Soap 85g", "PRC-003", "bar", 4500, 3100, 4},
+		{"Hand Sanitizer 100ml", "PRC-004", "bottle", 18000, 12500, 1},
+	}},
+}
+
+// demoCashiers sign in with the admin's demo password
+var demoCashiers = []struct{ name, email string }{
+	{"Siti Rahma", "siti@retail.com"},
+	{"Budi Santoso", "budi@retail.com"},
+	{"Rina Wijaya", "rina@retail.com"},
+}
+
+// demoHourWeights weighs the opening hours, 08:00 to 21:00, by how busy they
+// are: a lunch and an after-work peak
+var demoHourWeights = []int{2, 3, 4, 5, 8, 7, 4, 3, 4, 6, 9, 8, 5, 3}
+
+type demoLine struct {
+	product, quantity int
+}
+
+type demoSale struct {
+	at            time.Time
+	lines         []demoLine
+	paymentMethod string
+	discount      int
+}
+
+// SeedDemoData fills an empty database (or the tenant of a tenant pool) with
+// a demo store: categories, products, cashier accounts and opts.Days days of
+// sales up to now, with their receipt numbers, stock ledger and cost of goods
+// sold. Opening stock is chosen so no sale runs a product out. Everything is
+// written in one DB transaction. The outbox events of the backdated ledger
+// rows are marked published, so webhooks are not sent a month of history.
+func SeedDemoData(db *sql.DB, opts DemoOptions) (*DemoSummary, error) {
+	if opts.Days < 1 || opts.SalesPerDay < 1 {
+		return nil, errors.New("demo data needs at least one day and one sale per day")
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var hasProducts bool
+	if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM products)").Scan(&hasProducts); err != nil {
+		return nil, err
+	}
+	if hasProducts {
+		return nil, ErrCatalogNotEmpty
+	}
+
+	// Timestamps are written the way the database writes them, in its
+	// session time zone
+	var now time.Time
+	var storeID, outboxBefore int
+	err = tx.QueryRow(`
+		SELECT LOCALTIMESTAMP, (SELECT id FROM stores WHERE is_default), (SELECT COALESCE(MAX(id), 0) FROM outbox_events)
+	`).Scan(&now, &storeID, &outboxBefore)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.UTC)
+	openedAt := today.AddDate(0, 0, -opts.Days).Add(7 * time.Hour)
+
+	summary := &DemoSummary{}
+
+	cashierIDs, err := seedDemoCashiers(tx, openedAt)
+	if err != nil {
+		return nil, err
+	}
+	summary.Cashiers = len(demoCashiers)
+
+	var products []demoProduct
+	for _, c := range demoCatalog {
+		products = append(products, c.products...)
+	}
+
+	// Simulate the sales first: the opening stock of each product is what it
+	// sold plus what is left today
+	sales := simulateDemoSales(rng, products, today, now, opts)
+	sold := make([]int, len(products))
+	for _, s := range sales {
+		for _, l := range s.lines {
+			sold[l.product] += l.quantity
+		}
+	}
+	balances := make([]int, len(products))
+	productIDs := make([]int, len(products))
+
+	i := 0
+	for _, c := range demoCatalog {
+		var categoryID int
+		err := tx.QueryRow(`
+			INSERT INTO categories (name, description, slug, created_at, updated_at)
+			VALUES ($1, $2, COALESCE(NULLIF(trim(both '-' from regexp_replace(lower($1), '[^a-z0-9]+', '-', 'g')), ''), 'item'), $3, $3)
+			RETURNING id
+		`, c.name, c.description, openedAt).Scan(&categoryID)
+		if err != nil {
+			return nil, err
+		}
+		summary.Categories++
+
+		for _, p := range c.products {
+			left := 5 + rng.Intn(80)
+			opening := sold[i] + left
+			err := tx.QueryRow(`
+				INSERT INTO products (name, slug, price, stock, sku, unit, category_id, cost_price, created_at, updated_at)
+				VALUES ($1, COALESCE(NULLIF(trim(both '-' from regexp_replace(lower($1), '[^a-z0-9]+', '-', 'g')), ''), 'item'),
+				        $2, $3, $4, $5, $6, $7, $8, $8)
+				RETURNING id
+			`, p.name, p.price, left, p.sku, p.unit, categoryID, p.cost, openedAt).Scan(&productIDs[i])
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = tx.Exec(`
+				INSERT INTO stock_movements (product_id, store_id, quantity_delta, balance_after, reason, reference_type, reference_id, created_at)
+				VALUES ($1, $2, $3, $3, $4, $5, $1, $6)
+			`, productIDs[i], storeID, opening, models.StockReasonInitial, models.StockRefProduct, openedAt)
+			if err != nil {
+				return nil, err
+			}
+			_, err = tx.Exec(`INSERT INTO store_stocks (store_id, product_id, stock) VALUES ($1, $2, $3)`, storeID, productIDs[i], left)
+			if err != nil {
+				return nil, err
+			}
+			_, err = tx.Exec(`
+				INSERT INTO price_changes (product_id, new_price, source, created_at) VALUES ($1, $2, $3, $4)
+			`, productIDs[i], p.price, models.PriceSourceProduct, openedAt)
+			if err != nil {
+				return nil, err
+			}
+			// The opening stock is one cost layer at the cost price, of which
+			// what the sales took is gone
+			_, err = tx.Exec(`
+				INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost, created_at)
+				VALUES ($1, $2, $1, $3, $4, $5, $6)
+			`, productIDs[i], models.CostSourceOpening, opening, left, p.cost, openedAt)
+			if err != nil {
+				return nil, err
+			}
+
+			balances[i] = opening
+			summary.Products++
+			i++
+		}
+	}
+
+	// Receipt and queue numbers restart every day, as at checkout
+	issued := make(map[time.Time]int)
+	var days []time.Time
+	for _, s := range sales {
+		day := time.Date(s.at.Year(), s.at.Month(), s.at.Day(), 0, 0, 0, 0, time.UTC)
+		if issued[day] == 0 {
+			days = append(days, day)
+		}
+		issued[day]++
+		number := issued[day]
+
+		total := 0
+		for _, l := range s.lines {
+			total += products[l.product].price * l.quantity
+		}
+		total -= s.discount
+
+		var transactionID int
+		err := tx.QueryRow(`
+			INSERT INTO transactions (store_id, receipt_no, queue_no, total_amount, payment_method, price_level, discount, cashier_id, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'active', $9)
+			RETURNING id
+		`, storeID, fmt.Sprintf("INV-%s-%04d", day.Format("20060102"), number), number, total, s.paymentMethod,
+			models.PriceLevelRetail, s.discount, cashierIDs[rng.Intn(len(cashierIDs))], s.at,
+		).Scan(&transactionID)
+		if err != nil {
+			return nil, err
+		}
+
+		details := make([]interface{}, 0, len(s.lines)*6)
+		movements := make([]interface{}, 0, len(s.lines)*8)
+		for _, l := range s.lines {
+			p := products[l.product]
+			balances[l.product] -= l.quantity
+			details = append(details, transactionID, productIDs[l.product], l.quantity, p.price, p.price*l.quantity, p.cost*l.quantity)
+			movements = append(movements, productIDs[l.product], storeID, -l.quantity, balances[l.product],
+				models.StockReasonSale, models.StockRefTransaction, transactionID, s.at)
+		}
+		_, err = tx.Exec(`
+			INSERT INTO transaction_details (transaction_id, product_id, quantity, unit_price, subtotal, cost_amount)
+			VALUES `+seedValues(len(s.lines), 6), details...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO stock_movements (product_id, store_id, quantity_delta, balance_after, reason, reference_type, reference_id, created_at)
+			VALUES `+seedValues(len(s.lines), 8), movements...)
+		if err != nil {
+			return nil, err
+		}
+
+		summary.Transactions++
+		summary.Revenue += total
+	}
+
+	for _, day := range days {
+		_, err := tx.Exec(`
+			INSERT INTO receipt_sequences (seq_date, last_value) VALUES ($1, $2)
+			ON CONFLICT (tenant_id, seq_date) DO UPDATE SET last_value = GREATEST(receipt_sequences.last_value, EXCLUDED.last_value)
+		`, day, issued[day])
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO queue_sequences (seq_date, last_issued, now_serving) VALUES ($1, $2, $2)
+			ON CONFLICT (tenant_id, seq_date) DO UPDATE SET last_issued = GREATEST(queue_sequences.last_issued, EXCLUDED.last_issued)
+		`, day, issued[day])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE outbox_events SET published_at = NOW() WHERE id > $1 AND published_at IS NULL", outboxBefore); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// seedDemoCashiers adds the demo cashier accounts that do not exist yet and
+// returns the IDs of every cashier and owner, who ring up the demo sales
+func seedDemoCashiers(tx *sql.Tx, createdAt time.Time) ([]int, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range demoCashiers {
+		_, err := tx.Exec(`
+			INSERT INTO users (name, email, password, role, created_at)
+			SELECT $1, $2, $3, 'cashier', $4
+			WHERE NOT EXISTS (SELECT 1 FROM users WHERE email = $2)
+		`, c.name, c.email, string(hash), createdAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Query("SELECT id FROM users WHERE role IN ('cashier', 'owner') AND is_active ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// simulateDemoSales draws the sales of every day from opts.Days days ago up
+// to now, in time order
+func simulateDemoSales(rng *rand.Rand, products []demoProduct, today, now time.Time, opts DemoOptions) []demoSale {
+	popularity := 0
+	for _, p := range products {
+		popularity += p.popularity
+	}
+	pick := func() int {
+		n := rng.Intn(popularity)
+		for i, p := range products {
+			if n < p.popularity {
+				return i
+			}
+			n -= p.popularity
+		}
+		return len(products) - 1
+	}
+	hours := 0
+	for _, w := range demoHourWeights {
+		hours += w
+	}
+	pickHour := func() int {
+		n := rng.Intn(hours)
+		for i, w := range demoHourWeights {
+			if n < w {
+				return 8 + i
+			}
+			n -= w
+		}
+		return 8
+	}
+
+	var sales []demoSale
+	for d := opts.Days; d >= 0; d-- {
+		day := today.AddDate(0, 0, -d)
+		count := float64(opts.SalesPerDay) * (0.75 + rng.Float64()/2)
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			count *= 1.4
+		}
+
+		var daySales []demoSale
+		for n := 0; n < int(count+0.5); n++ {
+			at := day.Add(time.Duration(pickHour())*time.Hour + time.Duration(rng.Intn(3600))*time.Second)
+			if at.After(now) {
+				continue
+			}
+
+			var lines []demoLine
+			seen := make(map[int]bool)
+			for l := 1 + rng.Intn(2) + rng.Intn(3); l > 0; l-- {
+				p := pick()
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
+				quantity := 1
+				if rng.Intn(4) == 0 {
+					quantity += 1 + rng.Intn(3)
+				}
+				lines = append(lines, demoLine{product: p, quantity: quantity})
+			}
+
+			sale := demoSale{at: at, lines: lines}
+			switch r := rng.Intn(100); {
+			case r < 55:
+				sale.paymentMethod = "cash"
+			case r < 85:
+				sale.paymentMethod = "qris"
+			default:
+				sale.paymentMethod = "debit"
+			}
+			total := 0
+			for _, l := range lines {
+				total += products[l.product].price * l.quantity
+			}
+			if total >= 100000 && rng.Intn(3) == 0 {
+				sale.discount = 5000
+			}
+			daySales = append(daySales, sale)
+		}
+		sort.Slice(daySales, func(i, j int) bool { return daySales[i].at.Before(daySales[j].at) })
+		sales = append(sales, daySales...)
+	}
+	return sales
+}
+
+// seedValues returns the placeholders of rows rows of columns values each:
+// ($1, $2), ($3, $4), ...
+func seedValues(rows, columns int) string {
+	var b strings.Builder
+	for r := 0; r < rows; r++ {
+		if r > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for c := 0; c < columns; c++ {
+			if c > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", r*columns+c+1)
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
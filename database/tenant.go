@@ -0,0 +1,59 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// SeedTenant creates the rows a new tenant needs before it can trade: its
+// default store and, when ownerEmail is set and the tenant has no users yet,
+// its first owner account. It also backfills the tenant's read models and
+// opening cost layers, which the migrations only fill for the default
+// tenant. db must be the tenant's own pool (OpenTenantDB).
+func SeedTenant(db *sql.DB, ownerName, ownerEmail, ownerPasswordHash string) error {
+	if _, err := db.Exec(backfillProductListings); err != nil {
+		return err
+	}
+	if _, err := db.Exec(backfillOpeningCostLayers); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`
+		INSERT INTO stores (code, name, is_default)
+		SELECT 'MAIN', 'Main Store', TRUE
+		WHERE NOT EXISTS (SELECT 1 FROM stores)
+	`)
+	if err != nil {
+		return err
+	}
+
+	if ownerEmail == "" {
+		return nil
+	}
+	_, err = db.Exec(`
+		INSERT INTO users (name, email, password, role)
+		SELECT $1, $2, $3, 'owner'
+		WHERE NOT EXISTS (SELECT 1 FROM users)
+	`, ownerName, ownerEmail, ownerPasswordHash)
+	return err
+}
+
+// backfillProductListings adds the listing rows of products that have none,
+// e.g. products created before product_listings existed. Row-level security
+// limits it to the connection's tenant, so it runs for every tenant at startup.
+const backfillProductListings = `
+	SELECT refresh_product_listing(p.id)
+	FROM products p
+	WHERE NOT EXISTS (SELECT 1 FROM product_listings l WHERE l.product_id = p.id)
+`
+
+// backfillOpeningCostLayers layers the stock of products that no cost layer
+// covers at their cost price, as migration 42 does for the default tenant
+const backfillOpeningCostLayers = `
+	INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost)
+	SELECT p.id, 'opening', p.id, p.stock - c.covered, p.stock - c.covered, p.cost_price
+	FROM products p
+	CROSS JOIN LATERAL (
+		SELECT COALESCE(SUM(remaining), 0) AS covered FROM cost_layers WHERE product_id = p.id
+	) c
+	WHERE p.is_consignment = false AND p.cost_price > 0 AND p.stock > c.covered
+`
@@ -0,0 +1,113 @@
+package database_test
+
+import (
+	"database/sql"
+	"fmt"
+	"retail-core-api/database"
+	"retail-core-api/database/dbtest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// categoryNames returns the names of the categories a pool can see among names
+func categoryNames(t *testing.T, db *sql.DB, names ...string) []string {
+	t.Helper()
+	rows, err := db.Query(`SELECT name FROM categories WHERE name = ANY($1) ORDER BY name`, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	seen := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		seen = append(seen, name)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return seen
+}
+
+// createCategory inserts a category as the tenant a pool acts as
+func createCategory(t *testing.T, db *sql.DB, name string) {
+	t.Helper()
+	if _, err := db.Exec(`INSERT INTO categories (name, slug) VALUES ($1, $1)`, name); err != nil {
+		t.Fatalf("creating category %s: %v", name, err)
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	db := dbtest.Open(t)
+	if err := database.CheckTenantIsolation(db); err != nil {
+		t.Fatalf("TEST_DB_CONN must connect as a regular role: %v", err)
+	}
+
+	idA, tenantA := dbtest.NewTenant(t, db)
+	idB, tenantB := dbtest.NewTenant(t, db)
+
+	suffix := time.Now().UnixNano()
+	nameA := fmt.Sprintf("tenant-a-%d", suffix)
+	nameB := fmt.Sprintf("tenant-b-%d", suffix)
+	nameDefault := fmt.Sprintf("tenant-default-%d", suffix)
+	createCategory(t, tenantA, nameA)
+	createCategory(t, tenantB, nameB)
+	createCategory(t, db, nameDefault)
+	all := []string{nameA, nameB, nameDefault}
+
+	t.Run("a tenant sees only its own rows", func(t *testing.T) {
+		if seen := categoryNames(t, tenantA, all...); !slices.Equal(seen, []string{nameA}) {
+			t.Fatalf("tenant %d sees %v, want only %s", idA, seen, nameA)
+		}
+		if seen := categoryNames(t, tenantB, all...); !slices.Equal(seen, []string{nameB}) {
+			t.Fatalf("tenant %d sees %v, want only %s", idB, seen, nameB)
+		}
+	})
+
+	t.Run("a connection without app.tenant_id sees no other tenant's rows", func(t *testing.T) {
+		// Without the setting a connection acts as the default tenant, which
+		// single-tenant deployments rely on
+		if seen := categoryNames(t, db, all...); !slices.Equal(seen, []string{nameDefault}) {
+			t.Fatalf("an unscoped connection sees %v, want only the default tenant's %s", seen, nameDefault)
+		}
+	})
+
+	t.Run("a connection as an unknown tenant sees nothing", func(t *testing.T) {
+		var unknown int
+		if err := db.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1000 FROM tenants`).Scan(&unknown); err != nil {
+			t.Fatal(err)
+		}
+		ghost, err := database.OpenTenantDB(dbtest.Conn(t), unknown, database.PoolSettings{MaxOpenConns: 1, MaxIdleConns: 1}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ghost.Close()
+
+		var count int
+		if err := ghost.QueryRow(`SELECT COUNT(*) FROM categories`).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("tenant %d, which does not exist, sees %d categories", unknown, count)
+		}
+	})
+
+	t.Run("a tenant cannot write another tenant's rows", func(t *testing.T) {
+		_, err := tenantA.Exec(`INSERT INTO categories (name, slug, tenant_id) VALUES ($1, $1, $2)`, fmt.Sprintf("forged-%d", suffix), idB)
+		if err == nil {
+			t.Fatalf("tenant %d inserted a row for tenant %d", idA, idB)
+		}
+
+		result, err := tenantA.Exec(`UPDATE categories SET description = 'changed' WHERE name = $1`, nameB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n, _ := result.RowsAffected(); n != 0 {
+			t.Fatalf("tenant %d updated %d rows of tenant %d", idA, n, idB)
+		}
+	})
+}
@@ -22,33 +22,39 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/checkout": {
-            "post": {
-                "description": "Process a checkout with multiple items. Validates product availability, deducts stock, and creates a transaction.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/health/live": {
+            "get": {
+                "description": "Reports that the process is up and serving requests. Dependencies are not checked, so an orchestrator restarts the instance only when it hangs, not when the database is down.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Health"
                 ],
-                "summary": "Process checkout",
-                "parameters": [
-                    {
-                        "description": "Checkout request with items and quantities",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Server is alive",
                         "schema": {
-                            "$ref": "#/definitions/models.CheckoutRequest"
+                            "$ref": "#/definitions/helpers.Response"
                         }
                     }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Checks the database, the schema version, and the read replica and cache when configured, with the status of each. Answers 503 when the database is down or behind on migrations; a down replica or cache only degrades the status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
                 ],
+                "summary": "Readiness probe",
                 "responses": {
-                    "201": {
-                        "description": "Checkout successful",
+                    "200": {
+                        "description": "Server is ready",
                         "schema": {
                             "allOf": [
                                 {
@@ -58,41 +64,52 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Transaction"
+                                            "$ref": "#/definitions/models.HealthReport"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body or validation error",
-                        "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Server error or insufficient stock",
+                    "503": {
+                        "description": "Server is not ready",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthReport"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/dashboard": {
+        "/platform/tenants": {
             "get": {
-                "description": "Retrieve summary statistics for the POS dashboard",
+                "security": [
+                    {
+                        "PlatformKey": []
+                    }
+                ],
+                "description": "Retrieve every tenant of a multi-tenant deployment (platform key required)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Platform"
                 ],
-                "summary": "Get dashboard statistics",
+                "summary": "Get all tenants",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved dashboard data",
+                        "description": "Successfully retrieved tenants",
                         "schema": {
                             "allOf": [
                                 {
@@ -102,45 +119,55 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DashboardStats"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Tenant"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "401": {
+                        "description": "Invalid platform key",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/report": {
-            "get": {
-                "description": "Retrieve the sales summary for a specific date range",
+            },
+            "post": {
+                "security": [
+                    {
+                        "PlatformKey": []
+                    }
+                ],
+                "description": "Create a tenant with its default store and first owner account (platform key required). The response carries the tenant's API key, which is not shown again.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Platform"
                 ],
-                "summary": "Get sales report by date range",
+                "summary": "Create a tenant",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Tenant",
+                        "name": "tenant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TenantInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved report",
+                    "201": {
+                        "description": "Tenant created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -150,7 +177,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.SalesReport"
+                                            "$ref": "#/definitions/models.TenantAPIKey"
                                         }
                                     }
                                 }
@@ -158,27 +185,53 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Missing start_date or end_date",
+                        "description": "Invalid request body or invalid slug",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid platform key",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug in use",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/report/today": {
-            "get": {
-                "description": "Retrieve the sales summary for today including revenue, transaction count, and best seller",
+        "/platform/tenants/{id}/api-key": {
+            "post": {
+                "security": [
+                    {
+                        "PlatformKey": []
+                    }
+                ],
+                "description": "Issue a new API key for a tenant (platform key required). The previous key stops working immediately.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Platform"
+                ],
+                "summary": "Rotate a tenant's API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get today's sales report",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved today's report",
+                        "description": "API key rotated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -188,43 +241,107 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.SalesReport"
+                                            "$ref": "#/definitions/models.TenantAPIKey"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Invalid tenant ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid platform key",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/api/transactions": {
+        "/queue/display": {
             "get": {
-                "description": "Retrieve a paginated list of all transactions",
+                "description": "Server-Sent Events stream for pickup displays. Sends the current queue status as a \"status\" event on connect and whenever a number is called, plus a \"ping\" event every 30 seconds.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Queue"
                 ],
-                "summary": "Get all transactions",
+                "summary": "Stream \"now serving\" updates",
+                "responses": {
+                    "200": {
+                        "description": "Stream of status events",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/{token}": {
+            "get": {
+                "description": "Render a shared receipt as an HTML page (public, no authentication; the token expires)",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Receipts"
+                ],
+                "summary": "View a shared receipt",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Receipt share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt HTML page",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "404": {
+                        "description": "Receipt link is invalid or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/chaos": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Items per page (default: 20, max: 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Current fault injection rates and how many faults were injected. Only available when CHAOS_ENABLED is set outside production (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get fault injection settings",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved transactions",
+                        "description": "Fault injection settings retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -234,7 +351,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.PaginatedTransactions"
+                                            "$ref": "#/definitions/chaos.Stats"
                                         }
                                     }
                                 }
@@ -242,30 +359,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/transactions/{id}": {
-            "get": {
-                "description": "Retrieve details of a specific transaction including its items",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change fault injection rates at runtime, e.g. to switch DB errors on for one test and off again (owner only). Set every rate to 0 to stop injecting.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Admin"
                 ],
-                "summary": "Get a transaction by ID",
+                "summary": "Update fault injection settings",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Fault injection settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/chaos.Settings"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction retrieved successfully",
+                        "description": "Fault injection settings updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -275,7 +400,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Transaction"
+                                            "$ref": "#/definitions/chaos.Stats"
                                         }
                                     }
                                 }
@@ -283,33 +408,32 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid transaction ID",
-                        "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Transaction not found",
+                        "description": "Invalid settings",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
             }
         },
-        "/categories": {
+        "/v1/admin/consistency/stock": {
             "get": {
-                "description": "Retrieve a list of all categories",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare every product's total and per-store stock with the stock movement ledger (the opening balance before its first entry plus every quantity change since) and list the products that drifted, e.g. after a subsystem changed stock without a ledger entry (owner only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Get all categories",
+                "summary": "Verify stock against the ledger",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved all categories",
+                        "description": "Stock verified against the ledger",
                         "schema": {
                             "allOf": [
                                 {
@@ -319,10 +443,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Category"
-                                            }
+                                            "$ref": "#/definitions/models.StockConsistencyReport"
                                         }
                                     }
                                 }
@@ -330,9 +451,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/admin/consistency/stock/repair": {
             "post": {
-                "description": "Add a new category to the database",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve setting the total and per-store stock of drifted products (all of them, or product_ids) to their ledger figures. Each product is re-checked under a row lock first; products without ledger entries or with a negative ledger balance are skipped. No ledger entries are written; every repair is recorded in the audit log (owner only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -340,23 +468,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Create a new category",
+                "summary": "Rebuild stock from the ledger",
                 "parameters": [
                     {
-                        "description": "Category object that needs to be added",
-                        "name": "category",
+                        "description": "Products to repair (default: every drifted product)",
+                        "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CategoryInput"
+                            "$ref": "#/definitions/models.StockRepairInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Category created successfully",
+                    "200": {
+                        "description": "Stock rebuilt from the ledger",
                         "schema": {
                             "allOf": [
                                 {
@@ -366,7 +493,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Category"
+                                            "$ref": "#/definitions/models.StockRepairResult"
                                         }
                                     }
                                 }
@@ -374,36 +501,40 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
             }
         },
-        "/categories/{id}": {
+        "/v1/admin/consistency/transactions": {
             "get": {
-                "description": "Retrieve details of a specific category by its ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recompute the total of every transaction of a day from its detail lines (sum of line subtotals less the transaction discount, in whole currency units) and list those whose recorded total differs or whose lines are inconsistent themselves, which points at data corruption or a bug (owner only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Get a category by ID",
+                "summary": "Verify transaction totals",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Day (YYYY-MM-DD, default today)",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category retrieved successfully",
+                        "description": "Transaction totals verified",
                         "schema": {
                             "allOf": [
                                 {
@@ -413,7 +544,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Category"
+                                            "$ref": "#/definitions/models.TransactionConsistencyReport"
                                         }
                                     }
                                 }
@@ -421,52 +552,46 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID",
-                        "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Category not found",
+                        "description": "Invalid date",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing category by its ID",
-                "consumes": [
+            }
+        },
+        "/v1/admin/consistency/transactions/repair": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve setting the recorded total of a day's mismatched transactions (all of them, or transaction_ids) to the total of their lines. Each transaction is re-checked first; transactions with inconsistent lines are skipped for manual review. Every repair is recorded in the audit log (owner only).",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Update a category",
+                "summary": "Repair transaction totals",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated category object",
-                        "name": "category",
+                        "description": "Day and optional transactions to repair",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CategoryInput"
+                            "$ref": "#/definitions/models.TransactionRepairInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category updated successfully",
+                        "description": "Transaction totals repaired",
                         "schema": {
                             "allOf": [
                                 {
@@ -476,7 +601,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Category"
+                                            "$ref": "#/definitions/models.TransactionRepairResult"
                                         }
                                     }
                                 }
@@ -484,85 +609,121 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
-                        "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Category not found",
+                        "description": "Missing or invalid date",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a category by its ID",
+            }
+        },
+        "/v1/admin/data-quality": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count products missing a SKU/barcode, priced at zero, without a category, sharing a name with another product, or stale (active but neither sold nor edited within stale_days). Each check links to its paginated drill-down (owner only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Delete a category",
+                "summary": "Catalog data quality report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Days without a sale or edit before an active product is stale (default 180)",
+                        "name": "stale_days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category deleted successfully",
+                        "description": "Data quality report retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/helpers.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DataQualityReport"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID",
-                        "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Category not found",
+                        "description": "Invalid stale_days",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
             }
         },
-        "/categories/{id}/products": {
+        "/v1/admin/data-quality/{check}": {
             "get": {
-                "description": "Retrieve all products belonging to a specific category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated list of the products failing one check. Duplicate names are grouped together; stale products are listed longest idle first (owner only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Get products by category",
+                "summary": "Products failing a data quality check",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
+                        "enum": [
+                            "missing_barcode",
+                            "zero_price",
+                            "uncategorized",
+                            "duplicate_name",
+                            "stale"
+                        ],
+                        "type": "string",
+                        "description": "Check",
+                        "name": "check",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days without a sale or edit before an active product is stale (default 180)",
+                        "name": "stale_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products retrieved successfully",
+                        "description": "Data quality issues retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/helpers.Response"
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
@@ -570,7 +731,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Product"
+                                                "$ref": "#/definitions/models.DataQualityIssue"
                                             }
                                         }
                                     }
@@ -579,35 +740,38 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID",
+                        "description": "Invalid stale_days",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown check",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
             }
         },
-        "/products": {
+        "/v1/admin/feature-flags": {
             "get": {
-                "description": "Retrieve a list of all products with their category names. Supports optional search by name.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every feature flag with its default, the tenant's setting and the stores that override it (owner only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Get all products",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search product by name (case-insensitive partial match)",
-                        "name": "name",
-                        "in": "query"
-                    }
+                    "Feature Flags"
                 ],
+                "summary": "Get all feature flags",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved all products",
+                        "description": "Feature flags retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -619,7 +783,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Product"
+                                                "$ref": "#/definitions/models.FeatureFlag"
                                             }
                                         }
                                     }
@@ -628,9 +792,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Add a new product to the database",
+            }
+        },
+        "/v1/admin/feature-flags/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Switch a feature on or off for the whole tenant, or for one store when store_id is set. A store's setting wins over the tenant's, which wins over the default. Takes effect on the next request (owner only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -638,23 +809,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Feature Flags"
                 ],
-                "summary": "Create a new product",
+                "summary": "Switch a feature flag on or off",
                 "parameters": [
                     {
-                        "description": "Product object that needs to be added",
-                        "name": "product",
+                        "type": "string",
+                        "example": "promotions",
+                        "description": "Flag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Flag state",
+                        "name": "flag",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductInput"
+                            "$ref": "#/definitions/models.SetFeatureFlagRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Product created successfully",
+                    "200": {
+                        "description": "Feature flag updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -664,7 +843,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Product"
+                                            "$ref": "#/definitions/models.FeatureFlag"
                                         }
                                     }
                                 }
@@ -672,36 +851,52 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Feature flag or store not found",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieve details of a specific product by its ID with category name",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the tenant's setting of a flag so the default applies again, or a store's override (store_id) so the tenant's setting applies (owner only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Feature Flags"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Clear a feature flag setting",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "type": "string",
+                        "example": "promotions",
+                        "description": "Flag name",
+                        "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store whose override to remove",
+                        "name": "store_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product retrieved successfully",
+                        "description": "Feature flag setting cleared successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -711,7 +906,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Product"
+                                            "$ref": "#/definitions/models.FeatureFlag"
                                         }
                                     }
                                 }
@@ -719,62 +914,115 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid store ID",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Feature flag not found or not set",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing product by its ID",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieve recorded create/update/delete changes, newest first, with before/after snapshots (owner only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Audit Logs"
                 ],
-                "summary": "Update a product",
+                "summary": "List audit logs",
                 "parameters": [
+                    {
+                        "enum": [
+                            "category",
+                            "product",
+                            "promotion",
+                            "store",
+                            "supplier",
+                            "transaction",
+                            "user"
+                        ],
+                        "type": "string",
+                        "description": "Filter by entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by entity ID",
+                        "name": "entity_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated product object",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProductInput"
-                        }
+                        "type": "integer",
+                        "description": "Filter by the user who made the change",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date filter (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date filter (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product updated successfully",
+                        "description": "Audit logs retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/helpers.Response"
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Product"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AuditLog"
+                                            }
                                         }
                                     }
                                 }
@@ -782,400 +1030,13606 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
-                        "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Product not found",
+                        "description": "Invalid filter",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a product by its ID",
+            }
+        },
+        "/v1/auth/login": {
+            "post": {
+                "description": "Authenticate user and return JWT token",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Auth"
                 ],
-                "summary": "Delete a product",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of error messages (en, id; default: en)",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/helpers.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid product ID",
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/register": {
+            "post": {
+                "description": "Create a new user account (owner-only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/approvals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve product creations and price changes submitted for approval (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Approvals"
+                ],
+                "summary": "List catalog change requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change requests retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProductChangeRequest"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/approvals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single change request with its proposed product payload (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Approvals"
+                ],
+                "summary": "Get a catalog change request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Change request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change request retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Change request not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/approvals/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a pending product creation or price change to the live catalog (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Approvals"
+                ],
+                "summary": "Approve a catalog change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Change request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional review note",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change request approved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Change request not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/approvals/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending product creation or price change without applying it (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Approvals"
+                ],
+                "summary": "Reject a catalog change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Change request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional review note",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change request rejected",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Change request not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ErrorResponse"
+                            "$ref": "#/definitions/helpers.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "helpers.ErrorResponse": {
+        },
+        "/v1/catalog/changesets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve draft, scheduled, published and cancelled catalog changesets (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "List catalog changesets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "draft",
+                            "scheduled",
+                            "published",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changesets retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CatalogChangeset"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start an empty draft changeset (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Create a catalog changeset",
+                "parameters": [
+                    {
+                        "description": "Changeset name",
+                        "name": "changeset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangesetInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Changeset created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangeset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a changeset with its draft product changes (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Get a catalog changeset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changeset retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangeset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Discard a changeset that has not been published (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Cancel a changeset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changeset cancelled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangeset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Changeset not editable",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a draft product state to a changeset. Omit product_id to create a new product on publish (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Add a draft product change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Draft product change",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangesetItemInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Item added successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangesetItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset or product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Changeset not editable",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}/items/{item_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from a changeset that has not been published (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Remove a draft product change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Changeset item ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Changeset not editable",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply all draft product changes to the live catalog in a single transaction (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Publish a changeset now",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changeset published",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangeset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Changeset empty or already published",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}/schedule": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish the changeset automatically at publish_at (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Schedule a changeset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Publish time",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangesetScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changeset scheduled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangeset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid publish time",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Changeset not editable",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/changesets/{id}/unschedule": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return a scheduled changeset to draft (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog Changesets"
+                ],
+                "summary": "Unschedule a changeset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changeset returned to draft",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CatalogChangeset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Changeset not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Changeset not editable",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories": {
+            "get": {
+                "description": "Retrieve a list of all categories, localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get all categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved all categories",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Category"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new category to the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Create a new category",
+                "parameters": [
+                    {
+                        "description": "Category object that needs to be added",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Category created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every category with its parent's name as CSV or XLSX",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Export categories",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category export",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories/slug/{slug}": {
+            "get": {
+                "description": "Retrieve details of a specific category by its URL-friendly slug, localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get a category by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories/tree": {
+            "get": {
+                "description": "Retrieve all categories nested under their parent categories, localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get the category tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved category tree",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CategoryTreeNode"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    }
+                }
+            }
+        },
+        "/v1/categories/{id}": {
+            "get": {
+                "description": "Retrieve details of a specific category by its ID, localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get a category by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing category by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Update a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated category object",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a category by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Delete a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories/{id}/products": {
+            "get": {
+                "description": "Retrieve all products belonging to a specific category (optionally including its subcategories), localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get products by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include products from all subcategories",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Product"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories/{id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all localized names and descriptions of a category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "List category translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Translation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/categories/{id}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the localized name and description of a category for one locale (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Create or update a category translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale (e.g. id, en-us)",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Translation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid locale or request body",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the localized name and description of a category for one locale (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Delete a category translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale (e.g. id, en-us)",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the keyword rules used to suggest categories for uncategorized products (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "List category rules",
+                "responses": {
+                    "200": {
+                        "description": "Category rules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CategoryRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Map a keyword in product names or SKUs to a category. Keywords are matched case-insensitively as whole words; saving an existing keyword for the same category replaces its weight (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "Create a category rule",
+                "parameters": [
+                    {
+                        "description": "Category rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryRuleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Category rule saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CategoryRule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, keyword or category",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-rules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a keyword rule; suggestions already queued are kept (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "Delete a category rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category rule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Category rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the review queue of suggested categories for uncategorized products, most confident first (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "List category suggestions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "rejected",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status (default pending)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category suggestions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CategorySuggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-suggestions/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scan uncategorized products without a suggestion (up to 1000 per call, e.g. after a bulk import) and queue a suggested category for each one the rules, heuristics or classifier are confident about (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "Generate category suggestions",
+                "responses": {
+                    "200": {
+                        "description": "Category suggestions generated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CategorySuggestionRun"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-suggestions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single category suggestion (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "Get a category suggestion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category suggestion retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CategorySuggestion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-suggestions/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign the suggested category to the product, or the category_id given in the body instead (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "Accept a category suggestion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional category overriding the suggestion",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CategorySuggestionAcceptInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category suggestion accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CategorySuggestion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/category-suggestions/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dismiss a suggestion; the product stays uncategorized and is not suggested again (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category Suggestions"
+                ],
+                "summary": "Reject a category suggestion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category suggestion rejected",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CategorySuggestion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/checkout": {
+            "post": {
+                "description": "Process a checkout with items, payment method, optional discount and notes at a store (default store when store_id is omitted). Stock is taken from that store.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Process checkout",
+                "parameters": [
+                    {
+                        "description": "Checkout request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckoutRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of error messages (en, id; default: en)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checkout successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Transaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, validation error or insufficient stock, with an error code",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/dashboard": {
+            "get": {
+                "description": "Retrieve summary statistics for the POS dashboard, for one store when store_id is given (low stock then counts that store's stock)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Get dashboard statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved dashboard data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DashboardStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/count-sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve stocktake count sessions, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "List count sessions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by assigned user ID",
+                        "name": "assigned_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count sessions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CountSession"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a stocktake count session at a store (default store when store_id is omitted) for every active product, narrowed to a category and/or a product list. Expected quantities are the store's stock; completing the session posts the variances to that store. Only one stocktake can be open per store (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Open a stocktake session",
+                "parameters": [
+                    {
+                        "description": "Stocktake scope",
+                        "name": "stocktake",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StocktakeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stocktake session created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid store or product, or no products to count",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A stocktake is already open",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/count-sessions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a count session with its items, expected and counted quantities",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Get a count session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Count session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count session retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Count session not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/count-sessions/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close an open count session without adjusting stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Cancel a count session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Count session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count session cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Count session not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Session not open",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/count-sessions/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close a fully counted session and post each variance to stock as a count_correction ledger entry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Complete a count session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Count session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count session completed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Count session not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Session not open or items not counted",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/count-sessions/{id}/items/{product_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Store the counted shelf quantity of a product in an open count session. Counting again overwrites the previous count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Record a product count",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Count session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counted quantity",
+                        "name": "count",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CountInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSessionItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quantity",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Count session or product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Session not open",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/count-sessions/{id}/variance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Shrinkage and overage of a count session valued at current cost price, with every counted line that differs from the expected quantity (largest value first). For an open session this previews what completing it would post.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Count session variance report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Count session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Variance report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountVarianceReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Count session not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/cycle-count-compliance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Per schedule, count the sessions opened in a date range that were completed on time, completed late, are overdue, in progress or cancelled. Defaults to the last 90 days (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "Cycle count compliance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cycle count compliance retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CycleCountCompliance"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/cycle-count-schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the cycle counting program (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "List cycle count schedules",
+                "responses": {
+                    "200": {
+                        "description": "Cycle count schedules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CycleCountSchedule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count every product of an ABC class on a recurring basis, e.g. A weekly, B monthly, C quarterly. Classes are ranked by 90-day sales value (A: top 80%, B: next 15%, C: rest). Each run opens a cycle count session due by the next run and notifies the assignee (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "Create a cycle count schedule",
+                "parameters": [
+                    {
+                        "description": "Cycle count schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CycleCountScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cycle count schedule created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CycleCountSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/cycle-count-schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a cycle count schedule by its ID (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "Get a cycle count schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cycle count schedule retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CycleCountSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Cycle count schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the class, frequency, assignee, next run or active flag of a schedule (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "Update a cycle count schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated cycle count schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CycleCountScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cycle count schedule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CycleCountSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cycle count schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a schedule; count sessions it already opened are kept (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "Delete a cycle count schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cycle count schedule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Cycle count schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/cycle-count-schedules/{id}/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a cycle count session for the schedule immediately, due one period from now. The regular next run is unchanged (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cycle Counts"
+                ],
+                "summary": "Run a cycle count schedule now",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cycle count session opened successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "No products in the class",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cycle count schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/inventory/spot-check-sample": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Draw a random sample of active products weighted by stock value and 30-day sales velocity, and open it as a spot_check count session. While today's spot-check session is open it is returned instead of drawing a new sample.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktake"
+                ],
+                "summary": "Get today's spot-check sample",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of products to sample (1-100)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spot-check sample retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid size or no products to sample",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meta/migrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the data schema changelog: every applied migration with its version, description and the time it was applied, oldest first. Migrations that predate the changelog share the time it was first recorded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meta"
+                ],
+                "summary": "List applied schema migrations",
+                "responses": {
+                    "200": {
+                        "description": "Schema migrations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SchemaMigration"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meta/schema-version": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the latest schema migration applied to the database. Offline clients and integrations store the version and resync when it changes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Meta"
+                ],
+                "summary": "Get the data schema version",
+                "responses": {
+                    "200": {
+                        "description": "Schema version retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SchemaVersion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products": {
+            "get": {
+                "description": "Retrieve a paginated list of products. Supports search by name and filter by category_id. Names are localized according to Accept-Language.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products (paginated)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search product by name (case-insensitive partial match)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Product"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new product to the database. When catalog approval is enabled, products created by non-owners are queued for approval instead (202). A product created without a category gets a category suggestion in the review queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product object that needs to be added",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Product submitted for approval",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every product with its category name as CSV or XLSX, streamed row by row. The first columns (name, sku, price, stock, category) are those of the CSV import, so an edited export can be imported again. The XLSX workbook has a second sheet with the categories.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Export products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product export",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update products from a CSV with a header row of name, sku, price, stock and category (owner only; name and price are required columns). A row whose sku matches an existing product updates it and leaves empty cells unchanged; other rows create products. category is a category name or slug. Invalid rows are skipped and listed in errors. With dry_run=true every row is validated but nothing is written. Upload the file as the multipart field \"file\" or send it as a text/csv body (up to 5 MB, 5000 rows).",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Import products from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, write nothing",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import finished",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file, unreadable CSV or unknown columns",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Owner role required",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "File larger than 5 MB",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/slug/{slug}": {
+            "get": {
+                "description": "Retrieve details of a specific product by its URL-friendly slug, localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}": {
+            "get": {
+                "description": "Retrieve details of a specific product by its ID with category name, localized according to Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Preferred locales (e.g. id-ID,en;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response, for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified: the client's copy is current"
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing product by its ID. When catalog approval is enabled, price changes by non-owners are queued for approval instead (202).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated product object",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Price change submitted for approval",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a product by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/price-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every selling price change of a product with the old and new price, who made it and when, newest first. The first entry (old_price null) is the price the product was created with.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get the price history of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price history retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PriceChange"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/price-tiers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the wholesale, member and quantity-break prices of a product. At checkout a customer pays the lowest of the product price and every tier of their price level, or of retail, whose min_quantity they buy.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List price tiers of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price tiers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PriceTier"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace every price tier of a product, e.g. a wholesale price from 1 unit and a cheaper one from 12 (owner only). An empty list removes all tiers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Set the price tiers of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Complete list of price tiers",
+                        "name": "tiers",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceTiersInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price tiers updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PriceTier"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or tiers",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/relations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve substitutes, accessories and upsells of a product (e.g. to suggest an alternative when it is out of stock)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get related products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "substitute",
+                            "accessory",
+                            "upsell"
+                        ],
+                        "type": "string",
+                        "description": "Filter by relation type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Related products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProductRelation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or relation type",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Link a product to a substitute, accessory or upsell product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Add a related product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relation to add",
+                        "name": "relation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductRelationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product relation added successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProductRelation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/relations/{type}/{related_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlink a related product for the given relation type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Remove a related product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "substitute",
+                            "accessory",
+                            "upsell"
+                        ],
+                        "type": "string",
+                        "description": "Relation type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Related product ID",
+                        "name": "related_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product relation removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or relation type",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product relation not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/scheduled-prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the future price changes of a product in the order they take effect, including applied and cancelled ones unless filtered by status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List scheduled prices of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "applied",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scheduled prices retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduledPrice"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or status",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set a new selling price that takes effect at effective_at (e.g. a promo starting Friday 00:00). The price scheduler applies it within a minute of that time and logs it to the price history (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Schedule a price change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New price and effective time",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledPriceInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Price change scheduled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduledPrice"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid price or effective time",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/scheduled-prices/{schedule_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Withdraw a pending price change before it takes effect (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Cancel a scheduled price change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Scheduled price ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scheduled price cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduledPrice"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Scheduled price not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Price change no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/stock-adjustments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add or remove stock at a store (the default store unless store_id is given) with a reason code (damage, count_correction, received_goods). The change is written to the stock ledger.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "Adjust product stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signed quantity and reason code",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockAdjustmentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock adjusted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StockMovement"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quantity, reason code, store or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/stock-movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the immutable stock ledger of a product (sales, refunds, restocks, adjustments), newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "List stock movements of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by store ID",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "initial",
+                            "sale",
+                            "refund",
+                            "restock",
+                            "adjustment",
+                            "transfer_out",
+                            "transfer_in"
+                        ],
+                        "type": "string",
+                        "description": "Filter by reason",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date filter (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date filter (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock movements retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StockMovement"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or filter",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all localized names of a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "List product translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Translation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the localized name of a product for one locale (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Create or update a product translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale (e.g. id, en-us)",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Translation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid locale or request body",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the localized name of a product for one locale (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Delete a product translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale (e.g. id, en-us)",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all promotion rules",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Get all promotions",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved promotions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Promotion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new promotion rule (owner only). bogo and bundle_price require product_id; category_discount requires category_id and discount_percent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Create a promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion rule",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Promotion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/promotions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve details of a specific promotion rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Get a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Promotion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing promotion rule by its ID (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated promotion rule",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Promotion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a promotion rule by its ID (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Delete a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List purchase orders, newest first (items and receipts are not included)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchase Orders"
+                ],
+                "summary": "List purchase orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (open, partially_received, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PurchaseOrder"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or supplier ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Order products from a supplier at a unit cost price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchase Orders"
+                ],
+                "summary": "Create a purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Purchase order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PurchaseOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier or product not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a purchase order with its items and goods receipts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchase Orders"
+                ],
+                "summary": "Get a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PurchaseOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an open or partially received purchase order; stock already received is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchase Orders"
+                ],
+                "summary": "Cancel a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PurchaseOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order already closed",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book a full or partial delivery: increments product stock, records the cost price of each line (defaulting to the ordered cost) and writes received_goods stock movements. Quantities may not exceed what is still outstanding.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchase Orders"
+                ],
+                "summary": "Receive goods against a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received quantities",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReceiveInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Goods received successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GoodsReceipt"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or quantity exceeds outstanding",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order already closed",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve today's queue: the number now being served and the last number issued at checkout",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get the pickup queue",
+                "responses": {
+                    "200": {
+                        "description": "Queue status retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue/call": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set \"now serving\" to a queue number issued today (e.g. to recall a customer) and push it to displays",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Call a specific queue number",
+                "parameters": [
+                    {
+                        "description": "Queue number to call",
+                        "name": "call",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueCallInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queue number called",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Queue number has not been issued today",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/queue/next": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Advance \"now serving\" to the next waiting queue number and push it to displays",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Call the next queue number",
+                "responses": {
+                    "200": {
+                        "description": "Next queue number called",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueueStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "No queue numbers are waiting",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report": {
+            "get": {
+                "description": "Retrieve the sales summary for a specific date range, consolidated over all stores unless store_id is given. With format=xlsx the report is downloaded as a workbook with Summary, Products and Days sheets.",
+                "produces": [
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get sales report by date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; product and category figures cover only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SalesReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing start_date or end_date, or invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every report schedule with its next run and the status of its latest run (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Get all report schedules",
+                "responses": {
+                    "200": {
+                        "description": "Report schedules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ReportSchedule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule a report to be rendered as CSV or PDF on a cron expression and uploaded to a configured target (local, s3 or sftp). Upload credentials come from the server configuration (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Create a report schedule",
+                "parameters": [
+                    {
+                        "description": "Report schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReportScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Report schedule created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReportSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, cron expression, time zone or unconfigured target",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific report schedule (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Get a report schedule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report schedule retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReportSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Report schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a report schedule; its next run is recomputed from now (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Update a report schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated report schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReportScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report schedule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReportSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, cron expression, time zone or unconfigured target",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Report schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a report schedule and its run history. Uploaded files are kept (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Delete a report schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report schedule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Report schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-schedules/{id}/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render and upload the schedule's report immediately without moving its next scheduled run. A failed upload is returned as a run with status failed and its error (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Run a report schedule now",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report run finished",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReportRun"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Report schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-schedules/{id}/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated run history of a schedule, newest first (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report Schedules"
+                ],
+                "summary": "Run history of a report schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report runs retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ReportRun"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Report schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/best-sellers": {
+            "get": {
+                "description": "The products sold most in a date range, ranked by quantity and then revenue, consolidated over all stores unless store_id is given. Revenue is net of transaction discounts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Best sellers report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of products (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; product and category figures cover only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved best sellers report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BestSellersReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid start_date, end_date or limit",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/by-category": {
+            "get": {
+                "description": "Quantity sold, revenue and share of revenue per category for a date range, consolidated over all stores unless store_id is given. Transaction discounts are spread over the lines; products count under their current category.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales report by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; product and category figures cover only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved category sales report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CategorySalesReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid start_date or end_date",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/consignment": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Amount owed to each supplier for consigned products sold in a period, net of voided sales. Defaults to the current month.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Consignment settlement report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consignment settlement report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ConsignmentSettlementReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range or supplier ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/hourly": {
+            "get": {
+                "description": "Transaction count and revenue per hour of a day, for planning staff around peak times, consolidated over all stores unless store_id is given. All 24 hours are listed; hours are in the server's time zone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales report by hour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Day (YYYY-MM-DD, default today)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; product and category figures cover only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved hourly sales report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HourlySalesReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/inventory-valuation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Current cost value of owned stock using FIFO cost layers from goods receipts. Stock without a cost layer is valued at the product's cost price, else its last received cost. Consigned products are excluded (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Inventory valuation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inventory valuation retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.InventoryValuation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/low-stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List active products whose stock is at or below their min_stock threshold, largest shortfall first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Low-stock report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Low-stock report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LowStockProduct"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/profit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revenue, cost of goods sold and gross margin per product and per category for a date range (owner only). Transaction discounts are spread over the lines; consigned products are costed at the supplier payable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Gross profit report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; product and category figures cover only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved profit report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProfitReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid start_date or end_date",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Usage count, discount given, promoted units, incremental units and attached revenue of every promotion over its validity window (starts_at or creation to ends_at or now), clipped to start_date and end_date when given. Incremental units compare the units of the targeted product or category sold in the window with the same length of time just before it. Attached revenue is the revenue of the sales the promotion applied to; a sale using several promotions counts toward each (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Promotion performance report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved promotion performance",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PromotionPerformanceReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid start_date, end_date or store ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/reorder-suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suggest reorder quantities from recent sales velocity. A product is listed when its stock is at or below its reorder point (velocity × lead time + min_stock); the suggested quantity covers the lead time plus cover_days on top of min_stock.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Reorder suggestions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sales velocity window in days (default 30, max 365)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Supplier lead time in days (default 7)",
+                        "name": "lead_time_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of demand to cover after delivery (default 14)",
+                        "name": "cover_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reorder suggestions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReorderSuggestionReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/stores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total revenue and transactions over every store for a date range with a breakdown per store, highest revenue first (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Consolidated sales report across stores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved store report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StoreSalesReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid start_date or end_date",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/summary": {
+            "get": {
+                "description": "Retrieve aggregated report summary with category breakdown for a date range, consolidated over all stores unless store_id is given",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get aggregated report summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; product and category figures cover only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved report summary",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReportSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing start_date or end_date",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/timeseries": {
+            "get": {
+                "description": "Revenue, transactions and items sold per day, week (Monday to Sunday) or month of a date range, oldest first with zeros for periods without sales, ready for a dashboard chart. The first and last buckets are cut to the range. Consolidated over all stores unless store_id is given.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Revenue time series",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Bucket size (default day)",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales rung up by this user",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sales of this category; items sold counts only its lines",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way, e.g. cash",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved sales time series",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SalesBucket"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid start_date, end_date or granularity",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/today": {
+            "get": {
+                "description": "Retrieve the sales summary for today including revenue, transaction count, and best seller, consolidated over all stores unless store_id is given",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get today's sales report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved today's report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SalesReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock-transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List stock transfers between stores, newest first (items are not included)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Transfers"
+                ],
+                "summary": "List stock transfers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "in_transit",
+                            "received",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only transfers from or to this store",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StockTransfer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or store ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dispatch products from one store to another. The quantities leave the source store immediately (transfer_out ledger entries) and stay in transit, counted in neither store, until the destination confirms receipt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Transfers"
+                ],
+                "summary": "Send stock to another store",
+                "parameters": [
+                    {
+                        "description": "Stock transfer",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockTransferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock transfer created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StockTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, store, product or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock-transfers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a stock transfer with its lines",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Transfers"
+                ],
+                "summary": "Get a stock transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StockTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock-transfers/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an in-transit transfer; the quantities return to the source store (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Transfers"
+                ],
+                "summary": "Cancel a stock transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer cancelled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StockTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer is no longer in transit",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock-transfers/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm that an in-transit transfer arrived; the quantities are added to the destination store (transfer_in ledger entries)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Transfers"
+                ],
+                "summary": "Confirm receipt of a stock transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer received",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StockTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer is no longer in transit",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all store locations, the default store first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get all stores",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved stores",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Store"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new store location (owner only). It starts without stock; receive or transfer goods into it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Create a store",
+                "parameters": [
+                    {
+                        "description": "Store",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Store created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Store"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Store code in use",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stores/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve details of a specific store",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get a store by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Store"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid store ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing store by its ID (owner only). Inactive stores cannot check out; the default store cannot be deactivated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Update a store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated store",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Store"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Store code in use",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stores/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every product a store holds or has held with its stock at that store",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get the stock of a store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store stock retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StoreStock"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid store ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of stock changes, so POS terminals keep stock in sync without polling. Each change is a \"stock\" event whose id is the stock movement ID, plus a \"ping\" event every 30 seconds. A client reconnecting with the Last-Event-ID header is sent the changes it missed; when more than 1000 were missed it receives a \"reset\" event and should reload stock from /v1/products.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Stream stock changes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only changes at this store",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last stock event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of stock events",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockUpdate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid store ID or Last-Event-ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all suppliers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Get all suppliers",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved suppliers",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Supplier"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new supplier (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Create a supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Supplier created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve details of a specific supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Get a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing supplier by its ID (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Update a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated supplier",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a supplier by its ID (owner only). Suppliers that still own consignment products cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Delete a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier still owns consignment products",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sync/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue every conflict (e.g. a sale whose product was missing upstream) for the next run, once the cause has been fixed upstream (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "Retry sync conflicts",
+                "responses": {
+                    "200": {
+                        "description": "Conflicts queued for retry",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "integer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sync/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replicate pending sales, voids and stock adjustments now instead of waiting for the next background run (owner only). A run that could not reach upstream returns 200 with the error in the run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "Run sync now",
+                "responses": {
+                    "200": {
+                        "description": "Sync run finished",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SyncRun"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Sync is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A sync run is already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sync/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replication of local sales, voids and stock adjustments to the upstream instance: whether it is configured, the latest run, pending/synced/skipped/conflict/failed counts per entity type and the latest conflicts and failures (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "Get sync status",
+                "responses": {
+                    "200": {
+                        "description": "Sync status retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SyncStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions": {
+            "get": {
+                "description": "Retrieve a paginated list of all transactions with optional date range filter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get all transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date filter (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date filter (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by receipt number (e.g. INV-20260208-0001, partial match)",
+                        "name": "receipt_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this store (default: all stores)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved transactions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TransactionListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}": {
+            "get": {
+                "description": "Retrieve details of a specific transaction including its items. Transactions archived to cold storage are retrieved from there with \"archived\": true, which takes longer.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get a transaction by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Transaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/receipt.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render a transaction receipt (store header, line items, totals, tax) as a PDF sized for 80mm receipt printers",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Download a printable receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt PDF",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/share": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a short-lived public URL rendering the transaction receipt, e.g. to show as a QR code on the customer display",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Create a receipt sharing link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Receipt link created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReceiptShareLink"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/void": {
+            "patch": {
+                "description": "Void a transaction and restore product stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Void a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of error messages (en, id; default: en)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction voided successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found, with an error code",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction already voided, with an error code",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all users (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single user by ID (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user details (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a user (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every registered webhook with its subscribed events; secrets are not returned (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Webhooks retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Webhook"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a URL that receives the subscribed events (product.updated, transaction.created, stock.low) as POST requests. Each request carries X-Webhook-Event, X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature: \"sha256=\" + hex HMAC-SHA256 of \"{timestamp}.{body}\" under the secret. The secret is only returned in this response (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, URL or events",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific webhook; its secret is not returned (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a webhook's URL, description, events and state; its secret is kept. Deliveries of an inactive webhook stay queued until it is activated again (owner only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, URL or events",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook and its delivery log; queued deliveries are dropped (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paginated deliveries of a webhook, newest first, with the payload sent and the status, response code and error of the latest attempt. Failed attempts are retried with exponential backoff (30s doubling up to 1h); a delivery fails after WEBHOOK_MAX_ATTEMPTS attempts, 8 by default (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delivery log of a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deliveries retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WebhookDelivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID or status",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries/{delivery_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a delivery of a webhook with its payload and attempt_history: every attempt with its response status, the first 512 bytes of the response body, the error and how long it took (owner only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook delivery retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook or delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a delivered or failed delivery again with a fresh set of attempts, e.g. after the receiver was fixed. The same payload and event id are sent (owner only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery queued",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook or delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found or already pending",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "chaos.Settings": {
+            "type": "object",
+            "properties": {
+                "db_error_rate": {
+                    "type": "number",
+                    "example": 0.05
+                },
+                "db_latency_ms": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "db_latency_rate": {
+                    "type": "number",
+                    "example": 0.2
+                },
+                "webhook_failure_rate": {
+                    "type": "number",
+                    "example": 0.3
+                }
+            }
+        },
+        "chaos.Stats": {
+            "type": "object",
+            "properties": {
+                "db_delays_injected": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "db_error_rate": {
+                    "type": "number",
+                    "example": 0.05
+                },
+                "db_errors_injected": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "db_latency_ms": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "db_latency_rate": {
+                    "type": "number",
+                    "example": 0.2
+                },
+                "webhook_failure_rate": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "webhook_failures_injected": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "helpers.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "meta": {
+                    "$ref": "#/definitions/helpers.PaginationMeta"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "helpers.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "helpers.Problem": {
+            "description": "Error response (RFC 9457 problem details, application/problem+json). code is stable and safe to branch on; detail is human-readable and localized for coded errors.",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "validation_failed"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/v1/products"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:retail-core-api:problem:validation_failed"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helpers.Violation"
+                    }
+                }
+            }
+        },
+        "helpers.Response": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "helpers.Violation": {
+            "description": "Invalid field of a request body: its JSON path, the rule it broke and why",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "items[0].quantity"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "items[0].quantity is required"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "models.AppliedPromotion": {
+            "description": "Promotion applied to a single checkout line with the discount it produced",
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Beverages Week"
+                },
+                "promotion_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "category_discount"
+                }
+            }
+        },
+        "models.AuditLog": {
+            "description": "Who changed what and when, with the entity state before and after the change",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "update"
+                },
+                "actor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "actor_name": {
+                    "type": "string",
+                    "example": "Store Owner"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-10T09:30:00Z"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "category",
+                        "product",
+                        "promotion",
+                        "store",
+                        "supplier",
+                        "transaction",
+                        "user"
+                    ],
+                    "example": "product"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.BestSeller": {
+            "description": "A product ranked by quantity sold; revenue is net of transaction discounts",
+            "type": "object",
+            "properties": {
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "qty_sold": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 360000
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 85
+                }
+            }
+        },
+        "models.BestSellersReport": {
+            "description": "The products sold most in a date range, best first",
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-02-28"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BestSeller"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                }
+            }
+        },
+        "models.BestSellingProduct": {
+            "description": "Best selling product information",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "qty_sold": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.CatalogChangeset": {
+            "description": "Draft catalog changes that go live atomically, either on demand or at a scheduled time",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-20T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogChangesetItem"
+                    }
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Rainy season catalog"
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2026-03-01T00:00:00Z"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2026-03-01T00:00:05Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published",
+                        "cancelled"
+                    ],
+                    "example": "scheduled"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-20T12:00:00Z"
+                }
+            }
+        },
+        "models.CatalogChangesetItem": {
+            "description": "Draft product state applied when the changeset is published",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update"
+                    ],
+                    "example": "update"
+                },
+                "changeset_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-20T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payload": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.Category": {
+            "description": "Category information with ID, name, description and optional parent category",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-30T12:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Electronic devices and gadgets"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Electronics"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-30T12:00:00Z"
+                }
+            }
+        },
+        "models.CategoryInput": {
+            "description": "Input model for creating or updating a category (ID is auto-generated)",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Electronic devices and gadgets"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Electronics"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.CategoryProfit": {
+            "description": "Gross profit of a category (uncategorized products have a null category_id)",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "cogs": {
+                    "type": "integer",
+                    "example": 1100000
+                },
+                "gross_profit": {
+                    "type": "integer",
+                    "example": 340000
+                },
+                "margin_percent": {
+                    "type": "number",
+                    "example": 23.61
+                },
+                "qty_sold": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 1440000
+                }
+            }
+        },
+        "models.CategoryRevenue": {
+            "description": "Revenue breakdown per category",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Electronics"
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 5000000
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "models.CategoryRule": {
+            "description": "Keyword rule used to suggest a category for uncategorized products",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Beverages"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-03-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "keyword": {
+                    "type": "string",
+                    "example": "coffee"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.CategoryRuleInput": {
+            "description": "Keyword rule; multi-word keywords match as a phrase and a higher weight wins over other matching rules",
+            "type": "object",
+            "required": [
+                "category_id",
+                "keyword"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "keyword": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "coffee"
+                },
+                "weight": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "models.CategorySales": {
+            "description": "Sales of a category (uncategorized products have a null category_id)",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "qty_sold": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 1440000
+                },
+                "share_percent": {
+                    "type": "number",
+                    "example": 48.5
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 130
+                }
+            }
+        },
+        "models.CategorySalesReport": {
+            "description": "Quantity and revenue per category for a date range; revenue is net of transaction discounts",
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategorySales"
+                    }
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-02-28"
+                },
+                "qty_sold": {
+                    "type": "integer",
+                    "example": 990
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 2970000
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 260
+                }
+            }
+        },
+        "models.CategorySuggestion": {
+            "description": "Suggested category for an uncategorized product with the engine that produced it and its confidence",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Beverages"
+                },
+                "confidence": {
+                    "type": "number",
+                    "example": 0.85
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-03-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Kapal Api Kopi Bubuk 165g"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "keyword \"kopi\""
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2026-03-01T13:00:00Z"
+                },
+                "reviewed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reviewed_by_name": {
+                    "type": "string",
+                    "example": "Owner"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "KPA-165"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "rule",
+                        "heuristic",
+                        "classifier"
+                    ],
+                    "example": "rule"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "accepted",
+                        "rejected"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "models.CategorySuggestionAcceptInput": {
+            "description": "Optional category overriding the suggested one when accepting",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.CategorySuggestionRun": {
+            "description": "Result of generating category suggestions for uncategorized products",
+            "type": "object",
+            "properties": {
+                "scanned": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 33
+                },
+                "suggested": {
+                    "type": "integer",
+                    "example": 87
+                }
+            }
+        },
+        "models.CategoryTreeNode": {
+            "description": "Category with nested child categories",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryTreeNode"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-30T12:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Electronic devices and gadgets"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Electronics"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-30T12:00:00Z"
+                }
+            }
+        },
+        "models.ChangesetInput": {
+            "description": "Input model for creating a catalog changeset",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Rainy season catalog"
+                }
+            }
+        },
+        "models.ChangesetItemInput": {
+            "description": "Draft product state; omit product_id to create a new product on publish",
+            "type": "object",
+            "required": [
+                "product"
+            ],
+            "properties": {
+                "product": {
+                    "$ref": "#/definitions/models.ProductInput"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.ChangesetScheduleInput": {
+            "description": "Time at which the changeset is published automatically",
+            "type": "object",
+            "required": [
+                "publish_at"
+            ],
+            "properties": {
+                "publish_at": {
+                    "type": "string",
+                    "example": "2026-03-01T00:00:00Z"
+                }
+            }
+        },
+        "models.CheckoutItem": {
+            "description": "Single item to be checked out",
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.CheckoutRequest": {
+            "description": "Request body for processing a checkout at store_id (default store when omitted). price_level is the customer group whose price tiers apply (default retail).",
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.CheckoutItem"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": ""
+                },
+                "payment_method": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "cash"
+                },
+                "price_level": {
+                    "type": "string",
+                    "enum": [
+                        "retail",
+                        "wholesale",
+                        "member"
+                    ],
+                    "example": "retail"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "models.ConsignmentSettlement": {
+            "description": "Per-supplier consignment settlement: what was sold and what the store owes",
+            "type": "object",
+            "properties": {
+                "payable_amount": {
+                    "type": "integer",
+                    "example": 320000
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConsignmentSettlementLine"
+                    }
+                },
+                "sales_amount": {
+                    "type": "integer",
+                    "example": 400000
+                },
+                "store_margin": {
+                    "type": "integer",
+                    "example": 80000
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "supplier_name": {
+                    "type": "string",
+                    "example": "PT Sumber Makmur"
+                },
+                "units_sold": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "models.ConsignmentSettlementLine": {
+            "description": "Units sold, sales and payable of a consigned product in the period (net of voids)",
+            "type": "object",
+            "properties": {
+                "payable_amount": {
+                    "type": "integer",
+                    "example": 320000
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Keripik Singkong"
+                },
+                "sales_amount": {
+                    "type": "integer",
+                    "example": 400000
+                },
+                "units_sold": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "models.ConsignmentSettlementReport": {
+            "description": "Consignment settlement report for a date range",
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-02-28"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConsignmentSettlement"
+                    }
+                },
+                "total_payable": {
+                    "type": "integer",
+                    "example": 320000
+                }
+            }
+        },
+        "models.CountInput": {
+            "description": "Counted quantity on the shelf",
+            "type": "object",
+            "required": [
+                "counted_qty"
+            ],
+            "properties": {
+                "counted_qty": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 46
+                }
+            }
+        },
+        "models.CountSession": {
+            "description": "Stocktake session; completing it posts count corrections for every variance to the stock ledger",
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2026-02-20T09:15:00Z"
+                },
+                "completed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "counted_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-20T08:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2026-02-27T08:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "item_count": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountSessionItem"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Spot check sample of 20 products"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed",
+                        "cancelled"
+                    ],
+                    "example": "open"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "spot_check",
+                        "cycle",
+                        "stocktake"
+                    ],
+                    "example": "spot_check"
+                }
+            }
+        },
+        "models.CountSessionItem": {
+            "description": "Expected quantity is the system stock (of the session's store, when it has one) when the item was last counted (or when the session opened)",
+            "type": "object",
+            "properties": {
+                "counted_at": {
+                    "type": "string",
+                    "example": "2026-02-20T08:40:00Z"
+                },
+                "counted_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "counted_qty": {
+                    "type": "integer",
+                    "example": 46
+                },
+                "expected_qty": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "sample_weight": {
+                    "type": "number",
+                    "example": 0.084
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "variance": {
+                    "type": "integer",
+                    "example": -2
+                }
+            }
+        },
+        "models.CountVarianceLine": {
+            "description": "Counted product with a variance, valued at the product's current cost price",
+            "type": "object",
+            "properties": {
+                "counted_qty": {
+                    "type": "integer",
+                    "example": 46
+                },
+                "expected_qty": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "unit_cost": {
+                    "type": "integer",
+                    "example": 2500
+                },
+                "variance": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "variance_value": {
+                    "type": "integer",
+                    "example": -5000
+                }
+            }
+        },
+        "models.CountVarianceReport": {
+            "description": "Shrinkage (units short) and overage (units over) of a count session at cost, with the lines that differ, largest value first. Uncounted items are excluded.",
+            "type": "object",
+            "properties": {
+                "accuracy_percent": {
+                    "type": "number",
+                    "example": 95.2
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2026-02-28T18:30:00Z"
+                },
+                "counted_count": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "item_count": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountVarianceLine"
+                    }
+                },
+                "matched_count": {
+                    "type": "integer",
+                    "example": 238
+                },
+                "net_variance_value": {
+                    "type": "integer",
+                    "example": -51500
+                },
+                "overage_qty": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "overage_value": {
+                    "type": "integer",
+                    "example": 9500
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "shrinkage_qty": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "shrinkage_value": {
+                    "type": "integer",
+                    "example": 61000
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed",
+                        "cancelled"
+                    ],
+                    "example": "completed"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "spot_check",
+                        "cycle",
+                        "stocktake"
+                    ],
+                    "example": "stocktake"
+                },
+                "variance_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.CycleCountCompliance": {
+            "description": "Compliance rate is the share of due sessions (completed, overdue or cancelled) completed by their due time",
+            "type": "object",
+            "properties": {
+                "abc_class": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "assigned_to": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "cancelled": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "completed_late": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed_on_time": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "compliance_rate": {
+                    "type": "number",
+                    "example": 90.91
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "weekly"
+                },
+                "in_progress": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "overdue": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.CycleCountSchedule": {
+            "description": "Opens a cycle count session for every product in the class each period and assigns it to a staff member",
+            "type": "object",
+            "properties": {
+                "abc_class": {
+                    "type": "string",
+                    "enum": [
+                        "A",
+                        "B",
+                        "C"
+                    ],
+                    "example": "A"
+                },
+                "assigned_to": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "assignee_name": {
+                    "type": "string",
+                    "example": "Jane Cashier"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "weekly",
+                        "monthly",
+                        "quarterly"
+                    ],
+                    "example": "weekly"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_run_at": {
+                    "type": "string",
+                    "example": "2026-02-16T07:00:00Z"
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2026-02-23T07:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                }
+            }
+        },
+        "models.CycleCountScheduleInput": {
+            "description": "Input model for a cycle count schedule; next_run_at defaults to now",
+            "type": "object",
+            "required": [
+                "abc_class",
+                "frequency"
+            ],
+            "properties": {
+                "abc_class": {
+                    "type": "string",
+                    "enum": [
+                        "A",
+                        "B",
+                        "C"
+                    ],
+                    "example": "A"
+                },
+                "assigned_to": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "weekly",
+                        "monthly",
+                        "quarterly"
+                    ],
+                    "example": "weekly"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2026-02-23T07:00:00Z"
+                }
+            }
+        },
+        "models.DashboardStats": {
+            "description": "Dashboard summary statistics",
+            "type": "object",
+            "properties": {
+                "best_selling_today": {
+                    "$ref": "#/definitions/models.BestSellingProduct"
+                },
+                "low_stock_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "total_categories": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "total_products": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total_revenue_today": {
+                    "type": "integer",
+                    "example": 450000
+                },
+                "transactions_today": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.DataQualityCheck": {
+            "description": "One catalog hygiene check with the number of products failing it",
+            "type": "object",
+            "properties": {
+                "check": {
+                    "type": "string",
+                    "enum": [
+                        "missing_barcode",
+                        "zero_price",
+                        "uncategorized",
+                        "duplicate_name",
+                        "stale"
+                    ],
+                    "example": "missing_barcode"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Products without a SKU/barcode to scan at checkout"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/v1/admin/data-quality/missing_barcode"
+                }
+            }
+        },
+        "models.DataQualityIssue": {
+            "description": "Product failing a data quality check",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "duplicate_count": {
+                    "description": "DuplicateCount is set by the duplicate_name check: how many products share the name",
+                    "type": "integer",
+                    "example": 2
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_sold_at": {
+                    "type": "string",
+                    "example": "2025-07-15T09:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 3500
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sku": {
+                    "type": "string",
+                    "example": ""
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-06-01T12:00:00Z"
+                }
+            }
+        },
+        "models.DataQualityReport": {
+            "description": "Catalog hygiene summary; follow a check's url for the products failing it",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DataQualityCheck"
+                    }
+                },
+                "stale_days": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "total_products": {
+                    "type": "integer",
+                    "example": 850
+                }
+            }
+        },
+        "models.DependencyHealth": {
+            "description": "Status of one dependency; critical ones make the instance unready when down",
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "schema at version 40"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "dial tcp 10.0.0.5:5432: connect: connection refused"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "models.FeatureFlag": {
+            "description": "Feature flag. default is the built-in or FEATURE_FLAGS default; enabled is the state of stores without an override, which is the tenant setting when there is one and default otherwise.",
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Apply active promotions to checkouts"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "promotions"
+                },
+                "store_overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeatureFlagSetting"
+                    }
+                },
+                "tenant_setting": {
+                    "$ref": "#/definitions/models.FeatureFlagSetting"
+                }
+            }
+        },
+        "models.FeatureFlagSetting": {
+            "description": "Flag state set for the whole tenant (no store_id) or for one store",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "promotions"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-03-01T09:30:00Z"
+                }
+            }
+        },
+        "models.GoodsReceipt": {
+            "description": "Goods receipt with the quantity and cost price of every received line",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-22T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GoodsReceiptLine"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "First delivery"
+                },
+                "purchase_order_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "received_by": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.GoodsReceiptLine": {
+            "description": "Received quantity and the unit cost price it was received at",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "unit_cost": {
+                    "type": "integer",
+                    "example": 2500
+                }
+            }
+        },
+        "models.HealthReport": {
+            "description": "Overall status with the status of every dependency checked, keyed by name (database, migrations, replica, cache)",
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "degraded",
+                        "down"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "models.HourlySales": {
+            "description": "Sales in one hour of the day; hour 13 covers 13:00-13:59",
+            "type": "object",
+            "properties": {
+                "hour": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 720000
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 24
+                }
+            }
+        },
+        "models.HourlySalesReport": {
+            "description": "Transactions and revenue per hour of a day, all 24 hours included; peak_hour is the hour with the most transactions (null without sales)",
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-08"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HourlySales"
+                    }
+                },
+                "peak_hour": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 5400000
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 180
+                }
+            }
+        },
+        "models.InventoryValuation": {
+            "description": "Inventory valuation report",
+            "type": "object",
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "example": "fifo"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductValuation"
+                    }
+                },
+                "total_units": {
+                    "type": "integer",
+                    "example": 1250
+                },
+                "total_value": {
+                    "type": "integer",
+                    "example": 3400000
+                }
+            }
+        },
+        "models.LoginInput": {
+            "description": "Login credentials",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin@retail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "description": "Login response with JWT token and user info",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.LowStockProduct": {
+            "description": "Product that needs reordering, with how far it is below its minimum stock",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "min_stock": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "shortfall": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "pcs"
+                }
+            }
+        },
+        "models.PriceChange": {
+            "description": "Old and new selling price of a product with who changed it and when. old_price is null for the price a product was created with.",
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "changed_by_name": {
+                    "type": "string",
+                    "example": "Store Owner"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-10T09:30:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "new_price": {
+                    "type": "integer",
+                    "example": 4000
+                },
+                "old_price": {
+                    "type": "integer",
+                    "example": 3500
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reference_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "product",
+                        "changeset",
+                        "schedule"
+                    ],
+                    "example": "product"
+                }
+            }
+        },
+        "models.PriceTier": {
+            "description": "Unit price a customer of price_level pays when buying at least min_quantity of the product",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_quantity": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 2700
+                },
+                "price_level": {
+                    "type": "string",
+                    "enum": [
+                        "retail",
+                        "wholesale",
+                        "member"
+                    ],
+                    "example": "wholesale"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.PriceTierInput": {
+            "description": "Unit price for a customer group from a minimum quantity",
+            "type": "object",
+            "required": [
+                "min_quantity",
+                "price_level"
+            ],
+            "properties": {
+                "min_quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 12
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2700
+                },
+                "price_level": {
+                    "type": "string",
+                    "enum": [
+                        "retail",
+                        "wholesale",
+                        "member"
+                    ],
+                    "example": "wholesale"
+                }
+            }
+        },
+        "models.PriceTiersInput": {
+            "description": "Replaces every price tier of a product; an empty list removes them all",
+            "type": "object",
+            "properties": {
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceTierInput"
+                    }
+                }
+            }
+        },
+        "models.Product": {
+            "description": "Product information with ID, name, price, stock, and category relationship",
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "stock"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Electronics"
+                },
+                "consignment_cost": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "cost_price": {
+                    "type": "integer",
+                    "example": 12000000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-30T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/img.jpg"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_consignment": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "margin_percent": {
+                    "description": "MarginPercent is only included for owners, and only when the unit cost is known",
+                    "type": "number",
+                    "example": 20
+                },
+                "min_stock": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15 Pro"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 15000000
+                },
+                "relations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductRelation"
+                    }
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IP15PRO-001"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "iphone-15-pro"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "pcs"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-30T12:00:00Z"
+                }
+            }
+        },
+        "models.ProductChangeRequest": {
+            "description": "Product creation or price change submitted by a non-owner user, pending owner approval",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update"
+                    ],
+                    "example": "update"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payload": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "requested_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "requested_by_name": {
+                    "type": "string",
+                    "example": "Cashier One"
+                },
+                "review_note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2026-02-08T13:00:00Z"
+                },
+                "reviewed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "models.ProductImportError": {
+            "description": "Validation error of a CSV import row; the row is skipped",
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string",
+                    "example": "price"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "price must be a whole number"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.ProductImportResult": {
+            "description": "Outcome of a CSV product import. In a dry run nothing is written and the counts show what the import would do.",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductImportError"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductImportRow"
+                    }
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "models.ProductImportRow": {
+            "description": "Product created or updated by a CSV import row",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update"
+                    ],
+                    "example": "update"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                }
+            }
+        },
+        "models.ProductInput": {
+            "description": "Input model for creating or updating a product (ID is auto-generated)",
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "stock"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "consignment_cost": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "cost_price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 12000000
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/img.jpg"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_consignment": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "min_stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 15 Pro"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 15000000
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IP15PRO-001"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "supplier_id": {
+                    "description": "Consignment products are owned by the supplier; each unit sold accrues\nconsignment_cost as a payable to the supplier",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "pcs"
+                }
+            }
+        },
+        "models.ProductProfit": {
+            "description": "Gross profit of a product; cost is FIFO COGS, or the supplier payable for consigned products",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "cogs": {
+                    "type": "integer",
+                    "example": 300000
+                },
+                "gross_profit": {
+                    "type": "integer",
+                    "example": 60000
+                },
+                "margin_percent": {
+                    "type": "number",
+                    "example": 16.67
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "qty_sold": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 360000
+                }
+            }
+        },
+        "models.ProductRelation": {
+            "description": "Related product (substitute, accessory or upsell) with its current price and stock",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Soto"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 3000
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "related_product_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "substitute",
+                        "accessory",
+                        "upsell"
+                    ],
+                    "example": "substitute"
+                }
+            }
+        },
+        "models.ProductRelationInput": {
+            "description": "Input model for adding a product relation",
+            "type": "object",
+            "required": [
+                "related_product_id",
+                "type"
+            ],
+            "properties": {
+                "related_product_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "substitute",
+                        "accessory",
+                        "upsell"
+                    ],
+                    "example": "substitute"
+                }
+            }
+        },
+        "models.ProductValuation": {
+            "description": "Stock value of a product; uncosted units (stock without a cost layer) are valued at the product's cost price, else its last received cost",
+            "type": "object",
+            "properties": {
+                "average_cost": {
+                    "type": "integer",
+                    "example": 2517
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "costed_qty": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "last_cost": {
+                    "type": "integer",
+                    "example": 2600
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "uncosted_qty": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "value": {
+                    "type": "integer",
+                    "example": 120800
+                }
+            }
+        },
+        "models.ProfitReport": {
+            "description": "Revenue, COGS and margin per product and per category for a date range",
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryProfit"
+                    }
+                },
+                "cogs": {
+                    "type": "integer",
+                    "example": 11000000
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-02-28"
+                },
+                "gross_profit": {
+                    "type": "integer",
+                    "example": 4000000
+                },
+                "margin_percent": {
+                    "type": "number",
+                    "example": 26.67
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductProfit"
+                    }
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 15000000
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                }
+            }
+        },
+        "models.Promotion": {
+            "description": "Promotion rule (buy-one-get-one, bundle price or category discount)",
+            "type": "object",
+            "properties": {
+                "bundle_price": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "bundle_qty": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "buy_qty": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-02-08T23:59:59Z"
+                },
+                "get_qty": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Beverages Week"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-02-01T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "bogo",
+                        "bundle_price",
+                        "category_discount"
+                    ],
+                    "example": "category_discount"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                }
+            }
+        },
+        "models.PromotionInput": {
+            "description": "Input model for creating or updating a promotion rule",
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "bundle_price": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "bundle_qty": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "buy_qty": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-02-08T23:59:59Z"
+                },
+                "get_qty": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Beverages Week"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-02-01T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "bogo",
+                        "bundle_price",
+                        "category_discount"
+                    ],
+                    "example": "category_discount"
+                }
+            }
+        },
+        "models.PromotionPerformance": {
+            "description": "Usage, discount and sales of one promotion over its validity window (starts_at or creation to ends_at or now), clipped to the requested dates. incremental_units compares units of the targeted product or category sold in the window with the same length of time just before it.",
+            "type": "object",
+            "properties": {
+                "attached_revenue": {
+                    "type": "integer",
+                    "example": 2100000
+                },
+                "baseline_units": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "discount_given": {
+                    "type": "integer",
+                    "example": 63000
+                },
+                "incremental_units": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Beverages Week"
+                },
+                "promoted_units": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "promotion_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "target_units": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "bogo",
+                        "bundle_price",
+                        "category_discount"
+                    ],
+                    "example": "category_discount"
+                },
+                "usage_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "window_end": {
+                    "type": "string",
+                    "example": "2026-02-08T23:59:59Z"
+                },
+                "window_start": {
+                    "type": "string",
+                    "example": "2026-02-01T00:00:00Z"
+                }
+            }
+        },
+        "models.PromotionPerformanceReport": {
+            "description": "Performance of every promotion whose validity window overlaps the requested dates, most used first, with totals",
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-02-28"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromotionPerformance"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_attached_revenue": {
+                    "type": "integer",
+                    "example": 2100000
+                },
+                "total_discount_given": {
+                    "type": "integer",
+                    "example": 63000
+                },
+                "total_usage": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.PurchaseOrder": {
+            "description": "Purchase order; goods are received against it in one or more receipts",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-20T08:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderItem"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Weekly restock"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GoodsReceipt"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "partially_received",
+                        "received",
+                        "cancelled"
+                    ],
+                    "example": "open"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "supplier_name": {
+                    "type": "string",
+                    "example": "PT Sumber Makmur"
+                },
+                "total_cost": {
+                    "type": "integer",
+                    "example": 1500000
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-20T08:00:00Z"
+                }
+            }
+        },
+        "models.PurchaseOrderInput": {
+            "description": "Input model for creating a purchase order",
+            "type": "object",
+            "required": [
+                "items",
+                "supplier_id"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderItemInput"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Weekly restock"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.PurchaseOrderItem": {
+            "description": "Ordered product with the quantity received so far",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "purchase_order_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity_ordered": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "quantity_received": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "unit_cost": {
+                    "type": "integer",
+                    "example": 2500
+                }
+            }
+        },
+        "models.PurchaseOrderItemInput": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "unit_cost": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2500
+                }
+            }
+        },
+        "models.QueueCallInput": {
+            "description": "Queue number to announce as now serving",
+            "type": "object",
+            "required": [
+                "queue_no"
+            ],
+            "properties": {
+                "queue_no": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 15
+                }
+            }
+        },
+        "models.QueueStatus": {
+            "description": "Today's pickup queue: the number being served and the last number issued",
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-08"
+                },
+                "last_issued": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "now_serving": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "waiting": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.ReceiptShareLink": {
+            "description": "Public receipt URL (e.g. for a QR code on the customer display) with its expiry",
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-02-09T12:00:00Z"
+                },
+                "receipt_no": {
+                    "type": "string",
+                    "example": "INV-20260208-0001"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://retail-core-api.zeabur.app/receipts/eyJhbGciOiJIUzI1NiIs..."
+                }
+            }
+        },
+        "models.ReceiveInput": {
+            "description": "Received quantities; lines may cover only part of what was ordered",
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.ReceiveItemInput"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "First delivery"
+                }
+            }
+        },
+        "models.ReceiveItemInput": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 60
+                },
+                "unit_cost": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2500
+                }
+            }
+        },
+        "models.ReorderSuggestion": {
+            "description": "Reorder suggestion based on recent sales velocity, supplier lead time and days of cover",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Food"
+                },
+                "daily_velocity": {
+                    "type": "number",
+                    "example": 3
+                },
+                "min_stock": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "suggested_qty": {
+                    "type": "integer",
+                    "example": 61
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "pcs"
+                },
+                "units_sold": {
+                    "type": "integer",
+                    "example": 90
+                }
+            }
+        },
+        "models.ReorderSuggestionReport": {
+            "description": "Reorder suggestions together with the parameters used to compute them",
+            "type": "object",
+            "properties": {
+                "cover_days": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "lead_time_days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReorderSuggestion"
+                    }
+                },
+                "velocity_days": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "models.ReportRun": {
+            "description": "Run history entry; error is set when the run failed",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2026-02-20T02:00:06+07:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "object_key": {
+                    "type": "string",
+                    "example": "bi/sales/sales_summary_2026-02-19_2026-02-19_20260220T0200.csv"
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2026-02-19"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2026-02-19"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 2048
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-02-20T02:00:04+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ],
+                    "example": "succeeded"
+                },
+                "trigger": {
+                    "type": "string",
+                    "enum": [
+                        "schedule",
+                        "manual"
+                    ],
+                    "example": "schedule"
+                }
+            }
+        },
+        "models.ReportSchedule": {
+            "description": "Report rendered as CSV or PDF on a cron schedule and uploaded to object storage. Files are named {path_prefix}/{report}_{period start}_{period end}_{run time}.{format}.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "cron": {
+                    "type": "string",
+                    "example": "0 2 * * *"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "pdf"
+                    ],
+                    "example": "csv"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_run_at": {
+                    "type": "string",
+                    "example": "2026-02-20T02:00:00+07:00"
+                },
+                "last_status": {
+                    "type": "string",
+                    "enum": [
+                        "",
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ],
+                    "example": "succeeded"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Daily sales for BI"
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2026-02-21T02:00:00+07:00"
+                },
+                "path_prefix": {
+                    "type": "string",
+                    "example": "bi/sales"
+                },
+                "period": {
+                    "type": "string",
+                    "enum": [
+                        "yesterday",
+                        "today",
+                        "last_7_days",
+                        "last_month",
+                        "month_to_date"
+                    ],
+                    "example": "yesterday"
+                },
+                "report": {
+                    "type": "string",
+                    "enum": [
+                        "sales_summary",
+                        "profit",
+                        "store_sales",
+                        "consignment",
+                        "low_stock",
+                        "inventory_valuation"
+                    ],
+                    "example": "sales_summary"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "target": {
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "s3",
+                        "sftp"
+                    ],
+                    "example": "s3"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                }
+            }
+        },
+        "models.ReportScheduleInput": {
+            "description": "Input model for a report schedule. cron has five fields (minute hour day month weekday) or @hourly/@daily/@weekly/@monthly; timezone defaults to UTC, period to yesterday and is_active to true. store_id scopes sales reports to one store.",
+            "type": "object",
+            "required": [
+                "cron",
+                "format",
+                "name",
+                "report",
+                "target"
+            ],
+            "properties": {
+                "cron": {
+                    "type": "string",
+                    "example": "0 2 * * *"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "pdf"
+                    ],
+                    "example": "csv"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Daily sales for BI"
+                },
+                "path_prefix": {
+                    "type": "string",
+                    "example": "bi/sales"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "yesterday"
+                },
+                "report": {
+                    "type": "string",
+                    "example": "sales_summary"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "target": {
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "s3",
+                        "sftp"
+                    ],
+                    "example": "s3"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                }
+            }
+        },
+        "models.ReportSummary": {
+            "description": "Aggregated report summary with category breakdown",
+            "type": "object",
+            "properties": {
+                "best_selling_product": {
+                    "$ref": "#/definitions/models.BestSellingProduct"
+                },
+                "category_breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryRevenue"
+                    }
+                },
+                "total_revenue": {
+                    "type": "integer",
+                    "example": 15000000
+                },
+                "total_transactions": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "models.ReviewInput": {
+            "description": "Optional note recorded with an approval decision",
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Price confirmed with supplier"
+                }
+            }
+        },
+        "models.SalesBucket": {
+            "description": "Sales of one day, week (Monday to Sunday) or month; the first and last buckets are cut to the requested range, and buckets without sales have zeros",
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "2026-02-08"
+                },
+                "items_sold": {
+                    "type": "integer",
+                    "example": 280
+                },
+                "revenue": {
+                    "type": "integer",
+                    "example": 3150000
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2026-02-02"
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 84
+                }
+            }
+        },
+        "models.SalesReport": {
+            "description": "Sales summary report with revenue, cost of sales, gross profit, transaction count, and best seller",
+            "type": "object",
+            "properties": {
+                "best_selling_product": {
+                    "$ref": "#/definitions/models.BestSellingProduct"
+                },
+                "consignment_payable": {
+                    "type": "integer",
+                    "example": 4000
+                },
+                "gross_profit": {
+                    "type": "integer",
+                    "example": 11000
+                },
+                "total_cogs": {
+                    "type": "integer",
+                    "example": 30000
+                },
+                "total_revenue": {
+                    "type": "integer",
+                    "example": 45000
+                },
+                "total_transactions": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.ScheduledPrice": {
+            "description": "Future selling price of a product, applied by the price scheduler at effective_at and then logged to the price history",
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string",
+                    "example": "2026-03-06T00:00:12Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-03-01T09:30:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_by_name": {
+                    "type": "string",
+                    "example": "Store Owner"
+                },
+                "effective_at": {
+                    "type": "string",
+                    "example": "2026-03-06T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 3000
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "applied",
+                        "cancelled"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "models.ScheduledPriceInput": {
+            "description": "New selling price and the future time it takes effect",
+            "type": "object",
+            "required": [
+                "effective_at",
+                "price"
+            ],
+            "properties": {
+                "effective_at": {
+                    "type": "string",
+                    "example": "2026-03-06T00:00:00Z"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 3000
+                }
+            }
+        },
+        "models.SchemaMigration": {
+            "description": "One entry of the data schema changelog with the time it was applied to this database",
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string",
+                    "example": "2026-03-01T09:30:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Add scheduled_prices for future-dated price changes"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "scheduled_prices"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 26
+                }
+            }
+        },
+        "models.SchemaVersion": {
+            "description": "Latest applied schema migration; clients resync when version changes",
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string",
+                    "example": "2026-03-01T09:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "scheduled_prices"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 26
+                }
+            }
+        },
+        "models.SetFeatureFlagRequest": {
+            "description": "Switches a flag on or off for the whole tenant, or for one store when store_id is set",
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "store_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "models.StockAdjustmentInput": {
+            "description": "Signed quantity change with a reason code (damage must be negative, received_goods positive) at a store (default store when store_id is omitted)",
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason_code"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Dropped during shelving"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason_code": {
+                    "type": "string",
+                    "enum": [
+                        "damage",
+                        "count_correction",
+                        "received_goods"
+                    ],
+                    "example": "damage"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.StockConsistencyReport": {
+            "description": "Products whose total or per-store stock differs from the stock movement ledger",
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer",
+                    "example": 850
+                },
+                "checked_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockDrift"
+                    }
+                }
+            }
+        },
+        "models.StockDrift": {
+            "description": "Stock of a product against its ledger: the opening balance before the first entry plus every quantity_delta since. drift is stock minus ledger_stock; stores lists the stores whose stock drifted.",
+            "type": "object",
+            "properties": {
+                "drift": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "ledger_entries": {
+                    "type": "integer",
+                    "example": 27
+                },
+                "ledger_stock": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "8886001001103"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "stores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StoreStockDrift"
+                    }
+                }
+            }
+        },
+        "models.StockMovement": {
+            "description": "Immutable record of a single stock change at a store with its reason and reference. balance_after is the product's total stock over all stores.",
+            "type": "object",
+            "properties": {
+                "balance_after": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity_delta": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "initial",
+                        "sale",
+                        "refund",
+                        "restock",
+                        "adjustment",
+                        "transfer_out",
+                        "transfer_in"
+                    ],
+                    "example": "sale"
+                },
+                "reason_code": {
+                    "type": "string",
+                    "enum": [
+                        "damage",
+                        "count_correction",
+                        "received_goods"
+                    ],
+                    "example": "damage"
+                },
+                "reference_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reference_type": {
+                    "type": "string",
+                    "enum": [
+                        "transaction",
+                        "product",
+                        "changeset",
+                        "adjustment",
+                        "count_session",
+                        "purchase_order",
+                        "stock_transfer"
+                    ],
+                    "example": "transaction"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.StockRepairInput": {
+            "description": "Owner approval to set drifted products' total and per-store stock to their ledger figures. product_ids limits the repair to those products (default: every drifted product).",
+            "type": "object",
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        7
+                    ]
+                }
+            }
+        },
+        "models.StockRepairResult": {
+            "description": "Products whose stock was rebuilt from the ledger (figures before the repair) and those skipped",
+            "type": "object",
+            "properties": {
+                "repaired": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockDrift"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockRepairSkip"
+                    }
+                }
+            }
+        },
+        "models.StockRepairSkip": {
+            "description": "Product not repaired, with the reason",
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "no ledger entries to rebuild stock from"
+                }
+            }
+        },
+        "models.StockTransfer": {
+            "description": "Stock transfer between two stores with its lines",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-03-05T08:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "from_store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "from_store_name": {
+                    "type": "string",
+                    "example": "Main Store"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockTransferItem"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Weekend restock"
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2026-03-05T14:00:00Z"
+                },
+                "received_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "in_transit",
+                        "received",
+                        "cancelled"
+                    ],
+                    "example": "in_transit"
+                },
+                "to_store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "to_store_name": {
+                    "type": "string",
+                    "example": "Branch Kemang"
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-03-05T08:00:00Z"
+                }
+            }
+        },
+        "models.StockTransferInput": {
+            "description": "Input model for sending stock from one store to another",
+            "type": "object",
+            "required": [
+                "from_store_id",
+                "items",
+                "to_store_id"
+            ],
+            "properties": {
+                "from_store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.StockTransferItemInput"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Weekend restock"
+                },
+                "to_store_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.StockTransferItem": {
+            "description": "Transferred product and quantity",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.StockTransferItemInput": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 30
+                }
+            }
+        },
+        "models.StockUpdate": {
+            "description": "Stock change sent on the stock stream. stock is the product's total over all stores after the change, store_stock the store's current stock.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "movement_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity_delta": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "sale"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "store_stock": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "models.StocktakeInput": {
+            "description": "Products to count at a store: every active product, narrowed to a category and/or an explicit product list. store_id defaults to the default store.",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "February month-end count"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        7,
+                        12
+                    ]
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Store": {
+            "description": "Store location. Exactly one store is the default, used for checkouts and stock changes that do not name a store.",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Merdeka 10, Bandung"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Main Store"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                }
+            }
+        },
+        "models.StoreInput": {
+            "description": "Input model for creating or updating a store; is_active defaults to true",
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Dago 55, Bandung"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "BDG2"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dago Branch"
+                }
+            }
+        },
+        "models.StoreSales": {
+            "description": "Revenue, transaction count and units sold at one store",
+            "type": "object",
+            "properties": {
+                "items_sold": {
+                    "type": "integer",
+                    "example": 310
+                },
+                "store_code": {
+                    "type": "string",
+                    "example": "BDG2"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "store_name": {
+                    "type": "string",
+                    "example": "Dago Branch"
+                },
+                "total_revenue": {
+                    "type": "integer",
+                    "example": 4500000
+                },
+                "total_transactions": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "models.StoreSalesReport": {
+            "description": "Consolidated sales for a date range with a breakdown per store",
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-02-28"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "stores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StoreSales"
+                    }
+                },
+                "total_revenue": {
+                    "type": "integer",
+                    "example": 12500000
+                },
+                "total_transactions": {
+                    "type": "integer",
+                    "example": 340
+                }
+            }
+        },
+        "models.StoreStock": {
+            "description": "Units of a product on hand at a store. A product's stock is the sum over all stores.",
+            "type": "object",
+            "properties": {
+                "min_stock": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Indomie Goreng"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "IDM-GRG-001"
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                }
+            }
+        },
+        "models.StoreStockDrift": {
+            "description": "Stock of a product at one store against the sum of its ledger entries there",
+            "type": "object",
+            "properties": {
+                "drift": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "ledger_stock": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "store_name": {
+                    "type": "string",
+                    "example": "Main Store"
+                }
+            }
+        },
+        "models.Supplier": {
+            "description": "Supplier information; consignment products are owned by their supplier until sold",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Merdeka 10, Bandung"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "sales@sumbermakmur.co.id"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PT Sumber Makmur"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+62 812 3456 7890"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-01T12:00:00Z"
+                }
+            }
+        },
+        "models.SupplierInput": {
+            "description": "Input model for creating or updating a supplier",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Merdeka 10, Bandung"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "sales@sumbermakmur.co.id"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PT Sumber Makmur"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+62 812 3456 7890"
+                }
+            }
+        },
+        "models.SyncEntityStatus": {
+            "description": "Replication counts of one entity type",
             "type": "object",
             "properties": {
-                "error": {
+                "conflict": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "transaction"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "synced": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "models.SyncRecord": {
+            "description": "Replication outcome of a local sale, void or stock adjustment. remote_id is the upstream transaction or stock movement ID; retry_count counts retries of conflicts.",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "entity_type": {
                     "type": "string",
-                    "example": "validation detail"
+                    "enum": [
+                        "transaction",
+                        "void",
+                        "stock_adjustment"
+                    ],
+                    "example": "transaction"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "message": {
                     "type": "string",
-                    "example": "Error occurred"
+                    "example": "product 'Indomie Goreng' (sku IDM-GRG-001) does not exist upstream"
+                },
+                "remote_id": {
+                    "type": "integer",
+                    "example": 1042
+                },
+                "retry_count": {
+                    "type": "integer",
+                    "example": 0
                 },
                 "status": {
-                    "type": "boolean",
-                    "example": false
+                    "type": "string",
+                    "enum": [
+                        "synced",
+                        "skipped",
+                        "conflict",
+                        "failed"
+                    ],
+                    "example": "conflict"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
                 }
             }
         },
-        "helpers.Response": {
+        "models.SyncRun": {
+            "description": "Outcome of one replication run; error is set when the run could not reach the upstream instance",
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object"
+                "conflicts": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "message": {
+                "error": {
                     "type": "string",
-                    "example": "Success"
+                    "example": ""
                 },
-                "status": {
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:03Z"
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "synced": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.SyncStatus": {
+            "description": "Replication state: configuration, the latest run, counts per entity type and the latest conflicts and failures",
+            "type": "object",
+            "properties": {
+                "edge_id": {
+                    "type": "string",
+                    "example": "store-bandung"
+                },
+                "enabled": {
                     "type": "boolean",
                     "example": true
+                },
+                "entities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncEntityStatus"
+                    }
+                },
+                "last_run": {
+                    "$ref": "#/definitions/models.SyncRun"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncRecord"
+                    }
+                },
+                "upstream": {
+                    "type": "string",
+                    "example": "https://pos.example.com"
+                },
+                "upstream_store_id": {
+                    "type": "integer",
+                    "example": 4
                 }
             }
         },
-        "models.BestSellingProduct": {
-            "description": "Best selling product information",
+        "models.Tenant": {
+            "description": "Merchant hosted on a multi-tenant deployment",
             "type": "object",
             "properties": {
-                "name": {
+                "created_at": {
                     "type": "string",
-                    "example": "Indomie Goreng"
+                    "example": "2026-02-01T12:00:00Z"
                 },
-                "qty_sold": {
+                "has_api_key": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
                     "type": "integer",
-                    "example": 12
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Toko Sinar"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "toko-sinar"
                 }
             }
         },
-        "models.Category": {
-            "description": "Category information with ID, name and description",
+        "models.TenantAPIKey": {
+            "description": "API key identifying the tenant on login, registration and public endpoints. It is shown only once; store it safely.",
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string",
+                    "example": "rk_3f7c9a1e5b2d4f608a9c1e3b5d7f9a1c"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/models.Tenant"
+                }
+            }
+        },
+        "models.TenantInput": {
+            "description": "Input model for creating a tenant with its first owner account. The slug is lowercase letters, digits and dashes.",
             "type": "object",
             "required": [
-                "name"
+                "name",
+                "owner_email",
+                "owner_name",
+                "owner_password",
+                "slug"
             ],
             "properties": {
-                "created_at": {
+                "name": {
                     "type": "string",
-                    "example": "2024-01-30T12:00:00Z"
+                    "maxLength": 255,
+                    "example": "Toko Sinar"
                 },
-                "description": {
+                "owner_email": {
                     "type": "string",
-                    "example": "Electronic devices and gadgets"
+                    "example": "owner@tokosinar.id"
+                },
+                "owner_name": {
+                    "type": "string",
+                    "example": "Sinta"
+                },
+                "owner_password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "secret123"
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "toko-sinar"
+                }
+            }
+        },
+        "models.Transaction": {
+            "description": "Transaction information with details of purchased items. archived is true when the transaction was retrieved from cold storage.",
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransactionDetail"
+                    }
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 0
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "name": {
+                "notes": {
                     "type": "string",
-                    "example": "Electronics"
+                    "example": ""
                 },
-                "updated_at": {
+                "payment_method": {
                     "type": "string",
-                    "example": "2024-01-30T12:00:00Z"
+                    "example": "cash"
+                },
+                "price_level": {
+                    "type": "string",
+                    "enum": [
+                        "retail",
+                        "wholesale",
+                        "member"
+                    ],
+                    "example": "retail"
+                },
+                "queue_no": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "receipt_no": {
+                    "type": "string",
+                    "example": "INV-20260208-0001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_amount": {
+                    "type": "integer",
+                    "example": 45000
                 }
             }
         },
-        "models.CategoryInput": {
-            "description": "Input model for creating or updating a category (ID is auto-generated)",
+        "models.TransactionConsistencyReport": {
+            "description": "Transactions of a day whose recorded total differs from their lines, or whose lines are inconsistent themselves",
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "checked": {
+                    "type": "integer",
+                    "example": 312
+                },
+                "date": {
                     "type": "string",
-                    "example": "Electronic devices and gadgets"
+                    "example": "2026-02-08"
                 },
-                "name": {
+                "mismatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransactionTotalCheck"
+                    }
+                }
+            }
+        },
+        "models.TransactionDetail": {
+            "description": "Detail of a single item within a transaction",
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 3000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
                     "type": "string",
-                    "example": "Electronics"
+                    "example": "Indomie Goreng"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppliedPromotion"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 12000
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "unit_price": {
+                    "type": "integer",
+                    "example": 3000
+                }
+            }
+        },
+        "models.TransactionListItem": {
+            "description": "Transaction summary for list display",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "item_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "cash"
+                },
+                "queue_no": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "receipt_no": {
+                    "type": "string",
+                    "example": "INV-20260208-0001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "store_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_amount": {
+                    "type": "integer",
+                    "example": 45000
                 }
             }
         },
-        "models.CheckoutItem": {
-            "description": "Single item to be checked out",
+        "models.TransactionRepairInput": {
+            "description": "Owner approval to set the recorded total of mismatched transactions on date to their expected total. transaction_ids limits the repair to those transactions (default: every mismatch of the day).",
             "type": "object",
             "properties": {
-                "product_id": {
-                    "type": "integer",
-                    "example": 3
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-08"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "example": 5
+                "transaction_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        42,
+                        57
+                    ]
                 }
             }
         },
-        "models.CheckoutRequest": {
-            "description": "Request body for processing a checkout",
+        "models.TransactionRepairResult": {
+            "description": "Transactions whose total was repaired (figures before the repair) and those skipped",
             "type": "object",
             "properties": {
-                "items": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-08"
+                },
+                "repaired": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CheckoutItem"
+                        "$ref": "#/definitions/models.TransactionTotalCheck"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransactionRepairSkip"
                     }
                 }
             }
         },
-        "models.DashboardStats": {
-            "description": "Dashboard summary statistics",
+        "models.TransactionRepairSkip": {
+            "description": "Transaction not repaired, with the reason",
             "type": "object",
             "properties": {
-                "best_selling_today": {
-                    "$ref": "#/definitions/models.BestSellingProduct"
+                "reason": {
+                    "type": "string",
+                    "example": "line subtotals are inconsistent; review the lines manually"
                 },
-                "low_stock_count": {
+                "transaction_id": {
                     "type": "integer",
-                    "example": 3
+                    "example": 57
+                }
+            }
+        },
+        "models.TransactionTotalCheck": {
+            "description": "Recorded and recomputed total of a transaction. expected_total is the sum of the line subtotals less the transaction discount; line_mismatches counts lines whose subtotal is not unit_price × quantity − discount.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
                 },
-                "total_categories": {
+                "difference": {
                     "type": "integer",
-                    "example": 8
+                    "example": 5000
                 },
-                "total_products": {
+                "discount": {
                     "type": "integer",
-                    "example": 50
+                    "example": 5000
                 },
-                "total_revenue_today": {
+                "expected_total": {
                     "type": "integer",
-                    "example": 450000
+                    "example": 40000
                 },
-                "transactions_today": {
+                "line_mismatches": {
                     "type": "integer",
-                    "example": 10
-                }
-            }
-        },
-        "models.PaginatedTransactions": {
-            "description": "Paginated list of transactions",
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.TransactionListItem"
-                    }
+                    "example": 0
                 },
-                "limit": {
+                "lines": {
                     "type": "integer",
-                    "example": 10
+                    "example": 3
                 },
-                "page": {
+                "lines_subtotal": {
                     "type": "integer",
-                    "example": 1
+                    "example": 45000
                 },
-                "total": {
+                "receipt_no": {
+                    "type": "string",
+                    "example": "INV-20260208-0001"
+                },
+                "recorded_total": {
                     "type": "integer",
-                    "example": 100
+                    "example": 45000
                 },
-                "total_pages": {
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "store_id": {
                     "type": "integer",
-                    "example": 10
+                    "example": 1
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
-        "models.Product": {
-            "description": "Product information with ID, name, price, stock, and category relationship",
+        "models.Translation": {
+            "description": "Localized name and description for a single locale",
             "type": "object",
-            "required": [
-                "name",
-                "price",
-                "stock"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "category_name": {
+                "description": {
                     "type": "string",
-                    "example": "Electronics"
+                    "example": "Minuman dingin dan panas"
                 },
-                "created_at": {
+                "locale": {
                     "type": "string",
-                    "example": "2024-01-30T12:00:00Z"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "id"
                 },
                 "name": {
                     "type": "string",
-                    "example": "iPhone 15 Pro"
-                },
-                "price": {
-                    "type": "integer",
-                    "example": 15000000
-                },
-                "stock": {
-                    "type": "integer",
-                    "example": 50
+                    "example": "Minuman"
                 },
                 "updated_at": {
                     "type": "string",
-                    "example": "2024-01-30T12:00:00Z"
+                    "example": "2026-02-08T12:00:00Z"
                 }
             }
         },
-        "models.ProductInput": {
-            "description": "Input model for creating or updating a product (ID is auto-generated)",
+        "models.TranslationInput": {
+            "description": "Input model for creating or updating a translation (locale comes from the URL)",
             "type": "object",
             "required": [
-                "name",
-                "price",
-                "stock"
+                "name"
             ],
             "properties": {
-                "category_id": {
-                    "type": "integer",
-                    "example": 1
+                "description": {
+                    "type": "string",
+                    "example": "Minuman dingin dan panas"
                 },
                 "name": {
                     "type": "string",
-                    "example": "iPhone 15 Pro"
+                    "example": "Minuman"
+                }
+            }
+        },
+        "models.User": {
+            "description": "User information with identity and role",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-30T12:00:00Z"
                 },
-                "price": {
-                    "type": "integer",
-                    "example": 15000000
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
                 },
-                "stock": {
+                "id": {
                     "type": "integer",
-                    "example": 50
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "cashier"
+                    ],
+                    "example": "owner"
                 }
             }
         },
-        "models.SalesReport": {
-            "description": "Sales summary report with revenue, transaction count, and best seller",
+        "models.UserInput": {
+            "description": "Input model for creating or updating a user",
             "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "role"
+            ],
             "properties": {
-                "best_selling_product": {
-                    "$ref": "#/definitions/models.BestSellingProduct"
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
                 },
-                "total_revenue": {
-                    "type": "integer",
-                    "example": 45000
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
                 },
-                "total_transactions": {
-                    "type": "integer",
-                    "example": 5
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "secret123"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "cashier"
+                    ],
+                    "example": "cashier"
                 }
             }
         },
-        "models.Transaction": {
-            "description": "Transaction information with details of purchased items",
+        "models.Webhook": {
+            "description": "URL that receives the subscribed events as signed POST requests. secret is only returned when the webhook is created.",
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string",
                     "example": "2026-02-08T12:00:00Z"
                 },
-                "details": {
+                "description": {
+                    "type": "string",
+                    "example": "ERP stock sync"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TransactionDetail"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "transaction.created",
+                        "stock.low"
+                    ]
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "total_amount": {
-                    "type": "integer",
-                    "example": 45000
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3f9a1c0e5b7d4a2e8c6f1b9d0a7e5c3b"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/pos"
                 }
             }
         },
-        "models.TransactionDetail": {
-            "description": "Detail of a single item within a transaction",
+        "models.WebhookAttempt": {
+            "description": "Outcome of one delivery attempt: the HTTP status and the start of the response body, or the error when no response arrived",
             "type": "object",
             "properties": {
-                "id": {
+                "attempt": {
                     "type": "integer",
                     "example": 1
                 },
-                "product_id": {
-                    "type": "integer",
-                    "example": 3
-                },
-                "product_name": {
+                "attempted_at": {
                     "type": "string",
-                    "example": "Indomie Goreng"
+                    "example": "2026-02-08T12:00:00Z"
                 },
-                "quantity": {
+                "delivery_id": {
                     "type": "integer",
-                    "example": 5
+                    "example": 1
                 },
-                "subtotal": {
+                "duration_ms": {
                     "type": "integer",
-                    "example": 15000
+                    "example": 120
                 },
-                "transaction_id": {
+                "error": {
+                    "type": "string",
+                    "example": "503 Service Unavailable: upstream busy"
+                },
+                "id": {
                     "type": "integer",
                     "example": 1
+                },
+                "response_body": {
+                    "type": "string",
+                    "example": "upstream busy"
+                },
+                "response_status": {
+                    "type": "integer",
+                    "example": 503
                 }
             }
         },
-        "models.TransactionListItem": {
-            "description": "Transaction summary for list display",
+        "models.WebhookDelivery": {
+            "description": "Delivery of an event to a webhook with the outcome of its latest attempt. Pending deliveries are retried at next_attempt_at. attempt_history lists every attempt and is only returned for a single delivery.",
             "type": "object",
             "properties": {
+                "attempt_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookAttempt"
+                    }
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2026-02-08T12:00:00Z"
                 },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:01Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "event": {
+                    "type": "string",
+                    "example": "transaction.created"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "evt_9b2f4c1d7e3a5f60"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "item_count": {
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "2026-02-08T12:00:30Z"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_status": {
                     "type": "integer",
-                    "example": 3
+                    "example": 200
                 },
-                "total_amount": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ],
+                    "example": "delivered"
+                },
+                "webhook_id": {
                     "type": "integer",
-                    "example": 45000
+                    "example": 1
+                }
+            }
+        },
+        "models.WebhookInput": {
+            "description": "Input model for a webhook. events lists product.updated, transaction.created and/or stock.low; is_active defaults to true.",
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "ERP stock sync"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "transaction.created",
+                        "stock.low"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://erp.example.com/hooks/pos"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "PlatformKey": {
+            "type": "apiKey",
+            "name": "X-Platform-Key",
+            "in": "header"
+        }
     }
 }`
 
@@ -1186,7 +14640,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{"http", "https"},
 	Title:            "Retail Core API",
-	Description:      "RESTful API for managing categories, products, transactions, and POS operations\n\n## Features:\n- Category Management (CRUD)\n- Product Management (CRUD with category relationship)\n- Product Search by Name (case-insensitive partial match)\n- Transaction / Checkout (multi-item checkout with stock deduction)\n- Sales Reports (daily summary, date range, best selling product)\n- Dashboard Statistics",
+	Description:      "RESTful API for managing categories, products, transactions, and POS operations\n\n## Features:\n- Authentication (JWT login/register)\n- User Management (owner-only)\n- Category Management (CRUD)\n- Product Management (CRUD with category, search, pagination)\n- CSV product import (create or update by SKU) with a dry run reporting per-row errors\n- CSV/XLSX export of products (with category names) and categories\n- Multi-language product/category names (Accept-Language with locale fallback)\n- Scheduled catalog publishing (draft changesets applied atomically)\n- Category suggestions for uncategorized products (keyword rules, heuristics, optional classifier) with a review queue\n- Stock movement ledger (sales, refunds, restocks, adjustments)\n- Real-time stock changes for POS terminals (SSE, resumable with Last-Event-ID)\n- Stocktake count sessions (store-wide counts, weighted random spot checks) with variance reports\n- Cycle counting program (ABC classes on weekly/monthly/quarterly schedules) with compliance tracking\n- Suppliers and consignment stock (supplier payables accrued per sale, settlement report)\n- Purchase orders with full or partial goods receiving (stock, cost prices, ledger entries)\n- FIFO inventory valuation and cost of goods sold per transaction line\n- Transaction / Checkout (multi-item with payment method, discount, notes)\n- Daily pickup queue numbers with a \"now serving\" display stream (SSE)\n- Promotion Rules (BOGO, bundle price, category discount) applied at checkout\n- Audit Log (who changed what, with before/after snapshots)\n- Void Transactions\n- Sales Reports (daily, date range, summary with category breakdown, quantity and revenue per category, transactions and revenue per hour, top-N best sellers, revenue time series by day/week/month), date range also as an XLSX workbook\n- Dashboard Statistics\n- Multiple store locations with per-store stock and store-scoped reports\n- Stock transfers between stores (in transit until received, ledger entries on both sides)\n- Scheduled reports rendered as CSV/PDF and uploaded to a local directory, S3 or SFTP, with run history\n- Catalog data quality report (missing barcodes, zero prices, uncategorized, duplicate names, stale products)\n- Transaction totals verification against their lines, with owner-approved repair\n- Stock verification against the movement ledger, with a background drift check and owner-approved rebuild\n- Cashier-facing error codes with messages localized from Accept-Language (en, id)\n- Multi-tenancy: tenants resolved from the X-API-Key header or the JWT, rows isolated by PostgreSQL row-level security\n- Promotion performance report (usage, discount given, incremental units, attached revenue)\n- Outgoing webhooks (product.updated, transaction.created, stock.low) with HMAC signatures, retries and a delivery log\n- Transactional outbox: TransactionCreated and StockChanged domain events committed with the change and relayed in order\n- Optional NATS publishing of domain events for internal services (NATS_URL)\n- Optional gRPC API for internal services: products, categories and transactions (GRPC_PORT)\n- Edge sync: sales, voids and stock adjustments replicated to a central instance, products matched by SKU\n- Versioned API under /v1; the old /api and /auth paths are still served with a Deprecation header",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
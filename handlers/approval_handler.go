@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalHandler handles the catalog approval workflow endpoints
+type ApprovalHandler struct {
+	service services.ApprovalService
+}
+
+// NewApprovalHandler creates a new approval handler instance
+func NewApprovalHandler(service services.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{service: service}
+}
+
+// List godoc
+// @Summary List catalog change requests
+// @Description Retrieve product creations and price changes submitted for approval (owner only)
+// @Tags Catalog Approvals
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(pending, approved, rejected)
+// @Success 200 {object} helpers.Response{data=[]models.ProductChangeRequest} "Change requests retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid status"
+// @Router /v1/catalog/approvals [get]
+func (h *ApprovalHandler) List(c *gin.Context) {
+	requests, err := h.service.GetChangeRequests(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve change requests")
+		return
+	}
+	helpers.OK(c, "Change requests retrieved successfully", requests)
+}
+
+// GetByID godoc
+// @Summary Get a catalog change request
+// @Description Retrieve a single change request with its proposed product payload (owner only)
+// @Tags Catalog Approvals
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Change request ID"
+// @Success 200 {object} helpers.Response{data=models.ProductChangeRequest} "Change request retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Change request not found"
+// @Router /v1/catalog/approvals/{id} [get]
+func (h *ApprovalHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid change request ID")
+		return
+	}
+
+	req, err := h.service.GetChangeRequestByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve change request")
+		return
+	}
+	if req == nil {
+		helpers.NotFound(c, "Change request not found")
+		return
+	}
+	helpers.OK(c, "Change request retrieved successfully", req)
+}
+
+// review runs an approve/reject decision and maps service errors to responses
+func (h *ApprovalHandler) review(c *gin.Context, decide func(ctx context.Context, id int, reviewer models.Actor, note string) (*models.ProductChangeRequest, error), message string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid change request ID")
+		return
+	}
+
+	var input models.ReviewInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			helpers.InvalidBody(c, err)
+			return
+		}
+	}
+
+	req, err := decide(c.Request.Context(), id, currentActor(c), input.Note)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to review change request")
+		return
+	}
+	helpers.OK(c, message, req)
+}
+
+// Approve godoc
+// @Summary Approve a catalog change
+// @Description Apply a pending product creation or price change to the live catalog (owner only)
+// @Tags Catalog Approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Change request ID"
+// @Param review body models.ReviewInput false "Optional review note"
+// @Success 200 {object} helpers.Response{data=models.ProductChangeRequest} "Change request approved"
+// @Failure 400 {object} helpers.Problem "Validation error"
+// @Failure 404 {object} helpers.Problem "Change request not found"
+// @Failure 409 {object} helpers.Problem "Already reviewed"
+// @Router /v1/catalog/approvals/{id}/approve [post]
+func (h *ApprovalHandler) Approve(c *gin.Context) {
+	h.review(c, h.service.Approve, "Change request approved")
+}
+
+// Reject godoc
+// @Summary Reject a catalog change
+// @Description Reject a pending product creation or price change without applying it (owner only)
+// @Tags Catalog Approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Change request ID"
+// @Param review body models.ReviewInput false "Optional review note"
+// @Success 200 {object} helpers.Response{data=models.ProductChangeRequest} "Change request rejected"
+// @Failure 404 {object} helpers.Problem "Change request not found"
+// @Failure 409 {object} helpers.Problem "Already reviewed"
+// @Router /v1/catalog/approvals/{id}/reject [post]
+func (h *ApprovalHandler) Reject(c *gin.Context) {
+	h.review(c, h.service.Reject, "Change request rejected")
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler handles audit log endpoints
+type AuditHandler struct {
+	service services.AuditService
+}
+
+// NewAuditHandler creates a new audit handler instance
+func NewAuditHandler(service services.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// List godoc
+// @Summary List audit logs
+// @Description Retrieve recorded create/update/delete changes, newest first, with before/after snapshots (owner only)
+// @Tags Audit Logs
+// @Produce json
+// @Security BearerAuth
+// @Param entity_type query string false "Filter by entity type" Enums(category, product, promotion, store, supplier, transaction, user)
+// @Param entity_id query int false "Filter by entity ID"
+// @Param actor_id query int false "Filter by the user who made the change"
+// @Param action query string false "Filter by action" Enums(create, update, delete)
+// @Param start_date query string false "Start date filter (YYYY-MM-DD)"
+// @Param end_date query string false "End date filter (YYYY-MM-DD)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.AuditLog} "Audit logs retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid filter"
+// @Router /v1/audit-logs [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	page, limit := helpers.ParsePagination(c)
+	params := models.AuditLogParams{
+		EntityType: strings.TrimSpace(c.Query("entity_type")),
+		Action:     strings.TrimSpace(c.Query("action")),
+		StartDate:  strings.TrimSpace(c.Query("start_date")),
+		EndDate:    strings.TrimSpace(c.Query("end_date")),
+		Page:       page,
+		Limit:      limit,
+	}
+
+	if v := c.Query("entity_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			helpers.BadRequest(c, "Invalid entity_id")
+			return
+		}
+		params.EntityID = &id
+	}
+	if v := c.Query("actor_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			helpers.BadRequest(c, "Invalid actor_id")
+			return
+		}
+		params.ActorID = &id
+	}
+
+	result, err := h.service.GetAuditLogs(c.Request.Context(), params)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve audit logs")
+		return
+	}
+	helpers.Paginated(c, "Audit logs retrieved successfully", result.Data, helpers.PaginationMeta{
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"net/http"
 	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/services"
@@ -25,14 +26,15 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 // @Accept json
 // @Produce json
 // @Param body body models.LoginInput true "Login credentials"
-// @Success 200 {object} helpers.Response
+// @Param Accept-Language header string false "Language of error messages (en, id; default: en)"
+// @Success 200 {object} helpers.Response{data=models.LoginResponse}
 // @Failure 400 {object} helpers.Response
-// @Failure 401 {object} helpers.Response
-// @Router /auth/login [post]
+// @Failure 401 {object} helpers.Problem
+// @Router /v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var input models.LoginInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
@@ -41,9 +43,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.Login(input.Email, input.Password)
+	result, err := h.authService.Login(c.Request.Context(), input.Email, input.Password)
 	if err != nil {
-		helpers.Unauthorized(c, err.Error())
+		helpers.ErrorFrom(c, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -57,14 +59,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param body body models.UserInput true "User registration data"
-// @Success 201 {object} helpers.Response
+// @Success 201 {object} helpers.Response{data=models.User}
 // @Failure 400 {object} helpers.Response
 // @Failure 409 {object} helpers.Response
-// @Router /auth/register [post]
+// @Router /v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var input models.UserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
@@ -78,13 +80,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		role = "cashier"
 	}
 
-	user, err := h.authService.Register(input.Name, input.Email, input.Password, role)
+	user, err := h.authService.Register(c.Request.Context(), input.Name, input.Email, input.Password, role)
 	if err != nil {
-		if err.Error() == "email already registered" {
-			helpers.Error(c, 409, err.Error())
-			return
-		}
-		helpers.BadRequest(c, err.Error())
+		helpers.ServiceError(c, err, "Failed to register user")
 		return
 	}
 
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogExportHandler handles spreadsheet exports of products and categories
+type CatalogExportHandler struct {
+	service services.CatalogExportService
+}
+
+// NewCatalogExportHandler creates a new catalog export handler instance
+func NewCatalogExportHandler(service services.CatalogExportService) *CatalogExportHandler {
+	return &CatalogExportHandler{service: service}
+}
+
+// Products godoc
+// @Summary Export products
+// @Description Download every product with its category name as CSV or XLSX, streamed row by row. The first columns (name, sku, price, stock, category) are those of the CSV import, so an edited export can be imported again. The XLSX workbook has a second sheet with the categories.
+// @Tags Products
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "File format (default csv)" Enums(csv, xlsx)
+// @Success 200 {file} file "Product export"
+// @Failure 400 {object} helpers.Problem "Invalid format"
+// @Router /v1/products/export [get]
+func (h *CatalogExportHandler) Products(c *gin.Context) {
+	h.export(c, "products", h.service.ExportProducts)
+}
+
+// Categories godoc
+// @Summary Export categories
+// @Description Download every category with its parent's name as CSV or XLSX
+// @Tags Categories
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "File format (default csv)" Enums(csv, xlsx)
+// @Success 200 {file} file "Category export"
+// @Failure 400 {object} helpers.Problem "Invalid format"
+// @Router /v1/categories/export [get]
+func (h *CatalogExportHandler) Categories(c *gin.Context) {
+	h.export(c, "categories", h.service.ExportCategories)
+}
+
+// export validates the format and streams an export as an attachment. Once
+// the first bytes are sent the status cannot change, so later failures are
+// only logged and leave a truncated file.
+func (h *CatalogExportHandler) export(c *gin.Context, name string, write func(ctx context.Context, w io.Writer, format string) error) {
+	format := strings.ToLower(c.DefaultQuery("format", models.ExportFormatCSV))
+	if err := h.service.ValidateFormat(format); err != nil {
+		helpers.BadRequest(c, err.Error())
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == models.ExportFormatXLSX {
+		contentType = helpers.XLSXContentType
+	}
+	filename := fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102"), format)
+	clearWriteDeadline(c)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := write(c.Request.Context(), c.Writer, format); err != nil {
+		slog.ErrorContext(c.Request.Context(), "export failed", "component", "export", "export", name, "error", err)
+		if !c.Writer.Written() {
+			helpers.InternalError(c, "Failed to export "+name, err.Error())
+		}
+	}
+}
@@ -12,26 +12,36 @@ import (
 
 // CategoryHandler handles HTTP requests for categories
 type CategoryHandler struct {
-	service        services.CategoryService
-	productService services.ProductService
+	service            services.CategoryService
+	productService     services.ProductService
+	translationService services.TranslationService
+	auditService       services.AuditService
 }
 
 // NewCategoryHandler creates a new category handler instance
-func NewCategoryHandler(service services.CategoryService, productService services.ProductService) *CategoryHandler {
-	return &CategoryHandler{service: service, productService: productService}
+func NewCategoryHandler(service services.CategoryService, productService services.ProductService, translationService services.TranslationService, auditService services.AuditService) *CategoryHandler {
+	return &CategoryHandler{service: service, productService: productService, translationService: translationService, auditService: auditService}
 }
 
 // List godoc
 // @Summary Get all categories
-// @Description Retrieve a list of all categories
+// @Description Retrieve a list of all categories, localized according to Accept-Language
 // @Tags Categories
 // @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
+// @Param If-None-Match header string false "ETag of the copy the client has"
 // @Success 200 {object} helpers.Response{data=[]models.Category} "Successfully retrieved all categories"
-// @Router /categories [get]
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Router /v1/categories [get]
 func (h *CategoryHandler) List(c *gin.Context) {
-	categories, err := h.service.GetAllCategories()
+	categories, err := h.service.GetAllCategories(c.Request.Context())
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve categories", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve categories")
+		return
+	}
+	if err := h.translationService.LocalizeCategories(c.Request.Context(), categories, helpers.RequestLocales(c)); err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve categories")
 		return
 	}
 	helpers.OK(c, "Successfully retrieved all categories", categories)
@@ -39,14 +49,18 @@ func (h *CategoryHandler) List(c *gin.Context) {
 
 // GetByID godoc
 // @Summary Get a category by ID
-// @Description Retrieve details of a specific category by its ID
+// @Description Retrieve details of a specific category by its ID, localized according to Accept-Language
 // @Tags Categories
 // @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
 // @Param id path int true "Category ID"
+// @Param If-None-Match header string false "ETag of the copy the client has"
 // @Success 200 {object} helpers.Response{data=models.Category} "Category retrieved successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid category ID"
-// @Failure 404 {object} helpers.ErrorResponse "Category not found"
-// @Router /categories/{id} [get]
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Failure 400 {object} helpers.Problem "Invalid category ID"
+// @Failure 404 {object} helpers.Problem "Category not found"
+// @Router /v1/categories/{id} [get]
 func (h *CategoryHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -54,16 +68,44 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	category, err := h.service.GetCategoryByID(id)
+	category, err := h.service.GetCategoryByID(c.Request.Context(), id)
+	h.respondCategory(c, category, err)
+}
+
+// GetBySlug godoc
+// @Summary Get a category by slug
+// @Description Retrieve details of a specific category by its URL-friendly slug, localized according to Accept-Language
+// @Tags Categories
+// @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
+// @Param slug path string true "Category slug"
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Success 200 {object} helpers.Response{data=models.Category} "Category retrieved successfully"
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Failure 404 {object} helpers.Problem "Category not found"
+// @Router /v1/categories/slug/{slug} [get]
+func (h *CategoryHandler) GetBySlug(c *gin.Context) {
+	category, err := h.service.GetCategoryBySlug(c.Request.Context(), c.Param("slug"))
+	h.respondCategory(c, category, err)
+}
+
+// respondCategory writes a single category lookup result, localized for the request
+func (h *CategoryHandler) respondCategory(c *gin.Context, category *models.Category, err error) {
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve category", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve category")
 		return
 	}
 	if category == nil {
 		helpers.NotFound(c, "Category not found")
 		return
 	}
-	helpers.OK(c, "Category retrieved successfully", category)
+	localized := []models.Category{*category}
+	if err := h.translationService.LocalizeCategories(c.Request.Context(), localized, helpers.RequestLocales(c)); err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category")
+		return
+	}
+	helpers.OK(c, "Category retrieved successfully", localized[0])
 }
 
 // Create godoc
@@ -74,25 +116,27 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 // @Produce json
 // @Param category body models.CategoryInput true "Category object that needs to be added"
 // @Success 201 {object} helpers.Response{data=models.Category} "Category created successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid request body or validation error"
-// @Router /categories [post]
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Router /v1/categories [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
 	var input models.CategoryInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
 	category := models.Category{
 		Name:        input.Name,
 		Description: input.Description,
+		ParentID:    input.ParentID,
 	}
 
-	created, err := h.service.CreateCategory(category)
+	created, err := h.service.CreateCategory(c.Request.Context(), category)
 	if err != nil {
-		helpers.BadRequest(c, err.Error())
+		helpers.ServiceError(c, err, "Failed to create category")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionCreate, models.AuditEntityCategory, created.ID, nil, created)
 	helpers.Created(c, "Category created successfully", created)
 }
 
@@ -105,9 +149,9 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 // @Param id path int true "Category ID"
 // @Param category body models.CategoryInput true "Updated category object"
 // @Success 200 {object} helpers.Response{data=models.Category} "Category updated successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid request body or validation error"
-// @Failure 404 {object} helpers.ErrorResponse "Category not found"
-// @Router /categories/{id} [put]
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Category not found"
+// @Router /v1/categories/{id} [put]
 func (h *CategoryHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -117,24 +161,24 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 
 	var input models.CategoryInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
 	category := models.Category{
 		Name:        input.Name,
 		Description: input.Description,
+		ParentID:    input.ParentID,
 	}
 
-	updated, err := h.service.UpdateCategory(id, category)
+	before, _ := h.service.GetCategoryByID(c.Request.Context(), id)
+
+	updated, err := h.service.UpdateCategory(c.Request.Context(), id, category)
 	if err != nil {
-		if helpers.IsNotFound(err) || err.Error() == "category not found" {
-			helpers.NotFound(c, "Category not found")
-		} else {
-			helpers.BadRequest(c, err.Error())
-		}
+		helpers.ServiceError(c, err, "Failed to update category")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionUpdate, models.AuditEntityCategory, id, before, updated)
 	helpers.OK(c, "Category updated successfully", updated)
 }
 
@@ -145,9 +189,9 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Category ID"
 // @Success 200 {object} helpers.Response "Category deleted successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid category ID"
-// @Failure 404 {object} helpers.ErrorResponse "Category not found"
-// @Router /categories/{id} [delete]
+// @Failure 400 {object} helpers.Problem "Invalid category ID"
+// @Failure 404 {object} helpers.Problem "Category not found"
+// @Router /v1/categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -155,27 +199,35 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.service.DeleteCategory(id)
+	before, _ := h.service.GetCategoryByID(c.Request.Context(), id)
+
+	err = h.service.DeleteCategory(c.Request.Context(), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			helpers.NotFound(c, "Category not found")
 			return
 		}
-		helpers.InternalError(c, "Failed to delete category", err.Error())
+		helpers.ServiceError(c, err, "Failed to delete category")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionDelete, models.AuditEntityCategory, id, before, nil)
 	helpers.OK(c, "Category deleted successfully", nil)
 }
 
 // GetProducts godoc
 // @Summary Get products by category
-// @Description Retrieve all products belonging to a specific category
+// @Description Retrieve all products belonging to a specific category (optionally including its subcategories), localized according to Accept-Language
 // @Tags Categories
 // @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
 // @Param id path int true "Category ID"
+// @Param include_descendants query bool false "Include products from all subcategories"
+// @Param If-None-Match header string false "ETag of the copy the client has"
 // @Success 200 {object} helpers.Response{data=[]models.Product} "Products retrieved successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid category ID"
-// @Router /categories/{id}/products [get]
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Failure 400 {object} helpers.Problem "Invalid category ID"
+// @Router /v1/categories/{id}/products [get]
 func (h *CategoryHandler) GetProducts(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -183,10 +235,41 @@ func (h *CategoryHandler) GetProducts(c *gin.Context) {
 		return
 	}
 
-	products, err := h.productService.GetProductsByCategoryID(id)
+	includeDescendants, _ := strconv.ParseBool(c.Query("include_descendants"))
+
+	products, err := h.productService.GetProductsByCategoryID(c.Request.Context(), id, includeDescendants)
 	if err != nil {
-		helpers.InternalError(c, "Failed to get products", err.Error())
+		helpers.ServiceError(c, err, "Failed to get products")
+		return
+	}
+	if err := h.translationService.LocalizeProducts(c.Request.Context(), products, helpers.RequestLocales(c)); err != nil {
+		helpers.ServiceError(c, err, "Failed to get products")
 		return
 	}
+	withMargins(c, products)
 	helpers.OK(c, "Products retrieved successfully", products)
 }
+
+// Tree godoc
+// @Summary Get the category tree
+// @Description Retrieve all categories nested under their parent categories, localized according to Accept-Language
+// @Tags Categories
+// @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Success 200 {object} helpers.Response{data=[]models.CategoryTreeNode} "Successfully retrieved category tree"
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Router /v1/categories/tree [get]
+func (h *CategoryHandler) Tree(c *gin.Context) {
+	categories, err := h.service.GetAllCategories(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category tree")
+		return
+	}
+	if err := h.translationService.LocalizeCategories(c.Request.Context(), categories, helpers.RequestLocales(c)); err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category tree")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved category tree", services.BuildCategoryTree(categories))
+}
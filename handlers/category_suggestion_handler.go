@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategorySuggestionHandler handles category rules and the suggestion review queue
+type CategorySuggestionHandler struct {
+	service services.CategorySuggestionService
+}
+
+// NewCategorySuggestionHandler creates a new category suggestion handler instance
+func NewCategorySuggestionHandler(service services.CategorySuggestionService) *CategorySuggestionHandler {
+	return &CategorySuggestionHandler{service: service}
+}
+
+// ListRules godoc
+// @Summary List category rules
+// @Description Retrieve the keyword rules used to suggest categories for uncategorized products (owner only)
+// @Tags Category Suggestions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.CategoryRule} "Category rules retrieved successfully"
+// @Router /v1/category-rules [get]
+func (h *CategorySuggestionHandler) ListRules(c *gin.Context) {
+	rules, err := h.service.GetRules(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category rules")
+		return
+	}
+	helpers.OK(c, "Category rules retrieved successfully", rules)
+}
+
+// CreateRule godoc
+// @Summary Create a category rule
+// @Description Map a keyword in product names or SKUs to a category. Keywords are matched case-insensitively as whole words; saving an existing keyword for the same category replaces its weight (owner only).
+// @Tags Category Suggestions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body models.CategoryRuleInput true "Category rule"
+// @Success 201 {object} helpers.Response{data=models.CategoryRule} "Category rule saved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body, keyword or category"
+// @Router /v1/category-rules [post]
+func (h *CategorySuggestionHandler) CreateRule(c *gin.Context) {
+	var input models.CategoryRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	rule, err := h.service.CreateRule(c.Request.Context(), input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to save category rule")
+		return
+	}
+	helpers.Created(c, "Category rule saved successfully", rule)
+}
+
+// DeleteRule godoc
+// @Summary Delete a category rule
+// @Description Remove a keyword rule; suggestions already queued are kept (owner only)
+// @Tags Category Suggestions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category rule ID"
+// @Success 200 {object} helpers.Response "Category rule deleted successfully"
+// @Failure 404 {object} helpers.Problem "Category rule not found"
+// @Router /v1/category-rules/{id} [delete]
+func (h *CategorySuggestionHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid category rule ID")
+		return
+	}
+
+	if err := h.service.DeleteRule(c.Request.Context(), id); err != nil {
+		helpers.ServiceError(c, err, "Failed to delete category rule")
+		return
+	}
+	helpers.OK(c, "Category rule deleted successfully", nil)
+}
+
+// List godoc
+// @Summary List category suggestions
+// @Description Retrieve the review queue of suggested categories for uncategorized products, most confident first (owner only)
+// @Tags Category Suggestions
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (default pending)" Enums(pending, accepted, rejected, all)
+// @Success 200 {object} helpers.Response{data=[]models.CategorySuggestion} "Category suggestions retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid status"
+// @Router /v1/category-suggestions [get]
+func (h *CategorySuggestionHandler) List(c *gin.Context) {
+	suggestions, err := h.service.GetSuggestions(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category suggestions")
+		return
+	}
+	helpers.OK(c, "Category suggestions retrieved successfully", suggestions)
+}
+
+// GetByID godoc
+// @Summary Get a category suggestion
+// @Description Retrieve a single category suggestion (owner only)
+// @Tags Category Suggestions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Suggestion ID"
+// @Success 200 {object} helpers.Response{data=models.CategorySuggestion} "Category suggestion retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Suggestion not found"
+// @Router /v1/category-suggestions/{id} [get]
+func (h *CategorySuggestionHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid suggestion ID")
+		return
+	}
+
+	suggestion, err := h.service.GetSuggestionByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category suggestion")
+		return
+	}
+	if suggestion == nil {
+		helpers.NotFound(c, "Suggestion not found")
+		return
+	}
+	helpers.OK(c, "Category suggestion retrieved successfully", suggestion)
+}
+
+// Generate godoc
+// @Summary Generate category suggestions
+// @Description Scan uncategorized products without a suggestion (up to 1000 per call, e.g. after a bulk import) and queue a suggested category for each one the rules, heuristics or classifier are confident about (owner only)
+// @Tags Category Suggestions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.CategorySuggestionRun} "Category suggestions generated"
+// @Router /v1/category-suggestions/generate [post]
+func (h *CategorySuggestionHandler) Generate(c *gin.Context) {
+	run, err := h.service.GenerateSuggestions(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to generate category suggestions")
+		return
+	}
+	helpers.OK(c, "Category suggestions generated", run)
+}
+
+// Accept godoc
+// @Summary Accept a category suggestion
+// @Description Assign the suggested category to the product, or the category_id given in the body instead (owner only)
+// @Tags Category Suggestions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Suggestion ID"
+// @Param review body models.CategorySuggestionAcceptInput false "Optional category overriding the suggestion"
+// @Success 200 {object} helpers.Response{data=models.CategorySuggestion} "Category suggestion accepted"
+// @Failure 400 {object} helpers.Problem "Invalid category"
+// @Failure 404 {object} helpers.Problem "Suggestion not found"
+// @Failure 409 {object} helpers.Problem "Already reviewed"
+// @Router /v1/category-suggestions/{id}/accept [post]
+func (h *CategorySuggestionHandler) Accept(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid suggestion ID")
+		return
+	}
+
+	var input models.CategorySuggestionAcceptInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			helpers.InvalidBody(c, err)
+			return
+		}
+	}
+
+	suggestion, err := h.service.Accept(c.Request.Context(), id, input.CategoryID, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to review category suggestion")
+		return
+	}
+	helpers.OK(c, "Category suggestion accepted", suggestion)
+}
+
+// Reject godoc
+// @Summary Reject a category suggestion
+// @Description Dismiss a suggestion; the product stays uncategorized and is not suggested again (owner only)
+// @Tags Category Suggestions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Suggestion ID"
+// @Success 200 {object} helpers.Response{data=models.CategorySuggestion} "Category suggestion rejected"
+// @Failure 404 {object} helpers.Problem "Suggestion not found"
+// @Failure 409 {object} helpers.Problem "Already reviewed"
+// @Router /v1/category-suggestions/{id}/reject [post]
+func (h *CategorySuggestionHandler) Reject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid suggestion ID")
+		return
+	}
+
+	suggestion, err := h.service.Reject(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to review category suggestion")
+		return
+	}
+	helpers.OK(c, "Category suggestion rejected", suggestion)
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"context"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangesetHandler handles scheduled catalog publishing endpoints
+type ChangesetHandler struct {
+	service services.ChangesetService
+}
+
+// NewChangesetHandler creates a new changeset handler instance
+func NewChangesetHandler(service services.ChangesetService) *ChangesetHandler {
+	return &ChangesetHandler{service: service}
+}
+
+// List godoc
+// @Summary List catalog changesets
+// @Description Retrieve draft, scheduled, published and cancelled catalog changesets (owner only)
+// @Tags Catalog Changesets
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(draft, scheduled, published, cancelled)
+// @Success 200 {object} helpers.Response{data=[]models.CatalogChangeset} "Changesets retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid status"
+// @Router /v1/catalog/changesets [get]
+func (h *ChangesetHandler) List(c *gin.Context) {
+	changesets, err := h.service.GetChangesets(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve changesets")
+		return
+	}
+	helpers.OK(c, "Changesets retrieved successfully", changesets)
+}
+
+// GetByID godoc
+// @Summary Get a catalog changeset
+// @Description Retrieve a changeset with its draft product changes (owner only)
+// @Tags Catalog Changesets
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Success 200 {object} helpers.Response{data=models.CatalogChangeset} "Changeset retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Changeset not found"
+// @Router /v1/catalog/changesets/{id} [get]
+func (h *ChangesetHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid changeset ID")
+		return
+	}
+
+	changeset, err := h.service.GetChangesetByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve changeset")
+		return
+	}
+	if changeset == nil {
+		helpers.NotFound(c, "Changeset not found")
+		return
+	}
+	helpers.OK(c, "Changeset retrieved successfully", changeset)
+}
+
+// Create godoc
+// @Summary Create a catalog changeset
+// @Description Start an empty draft changeset (owner only)
+// @Tags Catalog Changesets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param changeset body models.ChangesetInput true "Changeset name"
+// @Success 201 {object} helpers.Response{data=models.CatalogChangeset} "Changeset created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body"
+// @Router /v1/catalog/changesets [post]
+func (h *ChangesetHandler) Create(c *gin.Context) {
+	var input models.ChangesetInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	changeset, err := h.service.CreateChangeset(c.Request.Context(), input.Name, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create changeset")
+		return
+	}
+	helpers.Created(c, "Changeset created successfully", changeset)
+}
+
+// AddItem godoc
+// @Summary Add a draft product change
+// @Description Add a draft product state to a changeset. Omit product_id to create a new product on publish (owner only).
+// @Tags Catalog Changesets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Param item body models.ChangesetItemInput true "Draft product change"
+// @Success 201 {object} helpers.Response{data=models.CatalogChangesetItem} "Item added successfully"
+// @Failure 400 {object} helpers.Problem "Validation error"
+// @Failure 404 {object} helpers.Problem "Changeset or product not found"
+// @Failure 409 {object} helpers.Problem "Changeset not editable"
+// @Router /v1/catalog/changesets/{id}/items [post]
+func (h *ChangesetHandler) AddItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid changeset ID")
+		return
+	}
+
+	var input models.ChangesetItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	item, err := h.service.AddItem(c.Request.Context(), id, input.ProductID, productFromInput(input.Product))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to add changeset item")
+		return
+	}
+	helpers.Created(c, "Item added successfully", item)
+}
+
+// RemoveItem godoc
+// @Summary Remove a draft product change
+// @Description Remove an item from a changeset that has not been published (owner only)
+// @Tags Catalog Changesets
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Param item_id path int true "Changeset item ID"
+// @Success 200 {object} helpers.Response "Item removed successfully"
+// @Failure 404 {object} helpers.Problem "Changeset or item not found"
+// @Failure 409 {object} helpers.Problem "Changeset not editable"
+// @Router /v1/catalog/changesets/{id}/items/{item_id} [delete]
+func (h *ChangesetHandler) RemoveItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid changeset ID")
+		return
+	}
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil || itemID <= 0 {
+		helpers.BadRequest(c, "Invalid changeset item ID")
+		return
+	}
+
+	if err := h.service.RemoveItem(c.Request.Context(), id, itemID); err != nil {
+		helpers.ServiceError(c, err, "Failed to remove changeset item")
+		return
+	}
+	helpers.OK(c, "Item removed successfully", nil)
+}
+
+// Schedule godoc
+// @Summary Schedule a changeset
+// @Description Publish the changeset automatically at publish_at (owner only)
+// @Tags Catalog Changesets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Param schedule body models.ChangesetScheduleInput true "Publish time"
+// @Success 200 {object} helpers.Response{data=models.CatalogChangeset} "Changeset scheduled successfully"
+// @Failure 400 {object} helpers.Problem "Invalid publish time"
+// @Failure 404 {object} helpers.Problem "Changeset not found"
+// @Failure 409 {object} helpers.Problem "Changeset not editable"
+// @Router /v1/catalog/changesets/{id}/schedule [post]
+func (h *ChangesetHandler) Schedule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid changeset ID")
+		return
+	}
+
+	var input models.ChangesetScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	changeset, err := h.service.Schedule(c.Request.Context(), id, input.PublishAt)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to schedule changeset")
+		return
+	}
+	helpers.OK(c, "Changeset scheduled successfully", changeset)
+}
+
+// transition runs a status change that takes only the changeset ID
+func (h *ChangesetHandler) transition(c *gin.Context, apply func(ctx context.Context, id int) (*models.CatalogChangeset, error), message string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid changeset ID")
+		return
+	}
+
+	changeset, err := apply(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update changeset")
+		return
+	}
+	helpers.OK(c, message, changeset)
+}
+
+// Unschedule godoc
+// @Summary Unschedule a changeset
+// @Description Return a scheduled changeset to draft (owner only)
+// @Tags Catalog Changesets
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Success 200 {object} helpers.Response{data=models.CatalogChangeset} "Changeset returned to draft"
+// @Failure 404 {object} helpers.Problem "Changeset not found"
+// @Failure 409 {object} helpers.Problem "Changeset not editable"
+// @Router /v1/catalog/changesets/{id}/unschedule [post]
+func (h *ChangesetHandler) Unschedule(c *gin.Context) {
+	h.transition(c, h.service.Unschedule, "Changeset returned to draft")
+}
+
+// Cancel godoc
+// @Summary Cancel a changeset
+// @Description Discard a changeset that has not been published (owner only)
+// @Tags Catalog Changesets
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Success 200 {object} helpers.Response{data=models.CatalogChangeset} "Changeset cancelled"
+// @Failure 404 {object} helpers.Problem "Changeset not found"
+// @Failure 409 {object} helpers.Problem "Changeset not editable"
+// @Router /v1/catalog/changesets/{id}/cancel [post]
+func (h *ChangesetHandler) Cancel(c *gin.Context) {
+	h.transition(c, h.service.Cancel, "Changeset cancelled")
+}
+
+// Publish godoc
+// @Summary Publish a changeset now
+// @Description Apply all draft product changes to the live catalog in a single transaction (owner only)
+// @Tags Catalog Changesets
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Changeset ID"
+// @Success 200 {object} helpers.Response{data=models.CatalogChangeset} "Changeset published"
+// @Failure 400 {object} helpers.Problem "Invalid item"
+// @Failure 404 {object} helpers.Problem "Changeset not found"
+// @Failure 409 {object} helpers.Problem "Changeset empty or already published"
+// @Router /v1/catalog/changesets/{id}/publish [post]
+func (h *ChangesetHandler) Publish(c *gin.Context) {
+	actor := currentActor(c)
+	h.transition(c, func(ctx context.Context, id int) (*models.CatalogChangeset, error) {
+		return h.service.Publish(ctx, id, actor)
+	}, "Changeset published")
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"retail-core-api/chaos"
+	"retail-core-api/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosHandler exposes the fault injection settings of a staging environment
+type ChaosHandler struct {
+	injector *chaos.Injector
+}
+
+// NewChaosHandler creates a new chaos handler instance
+func NewChaosHandler(injector *chaos.Injector) *ChaosHandler {
+	return &ChaosHandler{injector: injector}
+}
+
+// Get godoc
+// @Summary Get fault injection settings
+// @Description Current fault injection rates and how many faults were injected. Only available when CHAOS_ENABLED is set outside production (owner only).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=chaos.Stats} "Fault injection settings retrieved successfully"
+// @Router /v1/admin/chaos [get]
+func (h *ChaosHandler) Get(c *gin.Context) {
+	helpers.OK(c, "Fault injection settings retrieved successfully", h.injector.Stats())
+}
+
+// Update godoc
+// @Summary Update fault injection settings
+// @Description Change fault injection rates at runtime, e.g. to switch DB errors on for one test and off again (owner only). Set every rate to 0 to stop injecting.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param settings body chaos.Settings true "Fault injection settings"
+// @Success 200 {object} helpers.Response{data=chaos.Stats} "Fault injection settings updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid settings"
+// @Router /v1/admin/chaos [put]
+func (h *ChaosHandler) Update(c *gin.Context) {
+	var settings chaos.Settings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	if err := h.injector.Update(settings); err != nil {
+		helpers.BadRequest(c, err.Error())
+		return
+	}
+	helpers.OK(c, "Fault injection settings updated successfully", h.injector.Stats())
+}
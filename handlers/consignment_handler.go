@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsignmentHandler handles HTTP requests for consignment settlements
+type ConsignmentHandler struct {
+	service services.ConsignmentService
+}
+
+// NewConsignmentHandler creates a new consignment handler instance
+func NewConsignmentHandler(service services.ConsignmentService) *ConsignmentHandler {
+	return &ConsignmentHandler{service: service}
+}
+
+// SettlementReport godoc
+// @Summary Consignment settlement report
+// @Description Amount owed to each supplier for consigned products sold in a period, net of voided sales. Defaults to the current month.
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param supplier_id query int false "Filter by supplier ID"
+// @Success 200 {object} helpers.Response{data=models.ConsignmentSettlementReport} "Consignment settlement report retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid date range or supplier ID"
+// @Router /v1/report/consignment [get]
+func (h *ConsignmentHandler) SettlementReport(c *gin.Context) {
+	var supplierID *int
+	if raw := c.Query("supplier_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			helpers.BadRequest(c, "Invalid supplier ID")
+			return
+		}
+		supplierID = &id
+	}
+
+	report, err := h.service.GetSettlementReport(c.Request.Context(), c.Query("start_date"), c.Query("end_date"), supplierID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve consignment settlement report")
+		return
+	}
+	helpers.OK(c, "Consignment settlement report retrieved successfully", report)
+}
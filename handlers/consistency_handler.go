@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsistencyHandler handles HTTP requests for data consistency checks
+type ConsistencyHandler struct {
+	service services.ConsistencyService
+}
+
+// NewConsistencyHandler creates a new consistency handler instance
+func NewConsistencyHandler(service services.ConsistencyService) *ConsistencyHandler {
+	return &ConsistencyHandler{service: service}
+}
+
+// TransactionTotals godoc
+// @Summary Verify transaction totals
+// @Description Recompute the total of every transaction of a day from its detail lines (sum of line subtotals less the transaction discount, in whole currency units) and list those whose recorded total differs or whose lines are inconsistent themselves, which points at data corruption or a bug (owner only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Day (YYYY-MM-DD, default today)"
+// @Success 200 {object} helpers.Response{data=models.TransactionConsistencyReport} "Transaction totals verified"
+// @Failure 400 {object} helpers.Problem "Invalid date"
+// @Router /v1/admin/consistency/transactions [get]
+func (h *ConsistencyHandler) TransactionTotals(c *gin.Context) {
+	report, err := h.service.CheckTransactionTotals(c.Request.Context(), strings.TrimSpace(c.Query("date")))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to verify transaction totals")
+		return
+	}
+	helpers.OK(c, "Transaction totals verified", report)
+}
+
+// RepairTransactionTotals godoc
+// @Summary Repair transaction totals
+// @Description Approve setting the recorded total of a day's mismatched transactions (all of them, or transaction_ids) to the total of their lines. Each transaction is re-checked first; transactions with inconsistent lines are skipped for manual review. Every repair is recorded in the audit log (owner only).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body models.TransactionRepairInput true "Day and optional transactions to repair"
+// @Success 200 {object} helpers.Response{data=models.TransactionRepairResult} "Transaction totals repaired"
+// @Failure 400 {object} helpers.Problem "Missing or invalid date"
+// @Router /v1/admin/consistency/transactions/repair [post]
+func (h *ConsistencyHandler) RepairTransactionTotals(c *gin.Context) {
+	var input models.TransactionRepairInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+	input.Date = strings.TrimSpace(input.Date)
+
+	result, err := h.service.RepairTransactionTotals(c.Request.Context(), input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to repair transaction totals")
+		return
+	}
+	helpers.OK(c, "Transaction totals repaired", result)
+}
+
+// Stock godoc
+// @Summary Verify stock against the ledger
+// @Description Compare every product's total and per-store stock with the stock movement ledger (the opening balance before its first entry plus every quantity change since) and list the products that drifted, e.g. after a subsystem changed stock without a ledger entry (owner only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.StockConsistencyReport} "Stock verified against the ledger"
+// @Router /v1/admin/consistency/stock [get]
+func (h *ConsistencyHandler) Stock(c *gin.Context) {
+	report, err := h.service.CheckStock(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to verify stock")
+		return
+	}
+	helpers.OK(c, "Stock verified against the ledger", report)
+}
+
+// RepairStock godoc
+// @Summary Rebuild stock from the ledger
+// @Description Approve setting the total and per-store stock of drifted products (all of them, or product_ids) to their ledger figures. Each product is re-checked under a row lock first; products without ledger entries or with a negative ledger balance are skipped. No ledger entries are written; every repair is recorded in the audit log (owner only).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body models.StockRepairInput false "Products to repair (default: every drifted product)"
+// @Success 200 {object} helpers.Response{data=models.StockRepairResult} "Stock rebuilt from the ledger"
+// @Failure 400 {object} helpers.Problem "Invalid request body"
+// @Router /v1/admin/consistency/stock/repair [post]
+func (h *ConsistencyHandler) RepairStock(c *gin.Context) {
+	var input models.StockRepairInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			helpers.InvalidBody(c, err)
+			return
+		}
+	}
+
+	result, err := h.service.RepairStock(c.Request.Context(), input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to rebuild stock")
+		return
+	}
+	helpers.OK(c, "Stock rebuilt from the ledger", result)
+}
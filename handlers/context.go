@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentActor returns the authenticated user set in the context by middleware.Auth
+func currentActor(c *gin.Context) models.Actor {
+	return models.Actor{
+		UserID: c.GetInt("user_id"),
+		Name:   c.GetString("user_name"),
+		Role:   c.GetString("user_role"),
+	}
+}
+
+// withMargin adds the margin percentage to a product when the caller manages
+// the store; cashiers never see margins
+func withMargin(c *gin.Context, product *models.Product) {
+	if currentActor(c).Role == "owner" {
+		services.ApplyMargin(product)
+	}
+}
+
+// withMargins is withMargin for a list of products
+func withMargins(c *gin.Context, products []models.Product) {
+	for i := range products {
+		withMargin(c, &products[i])
+	}
+}
+
+// clearWriteDeadline lifts the server's write timeout for a response that
+// streams for longer than it: SSE and file exports
+func clearWriteDeadline(c *gin.Context) {
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+}
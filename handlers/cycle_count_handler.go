@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"database/sql"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CycleCountHandler handles cycle count schedule and compliance endpoints
+type CycleCountHandler struct {
+	service services.CycleCountService
+}
+
+// NewCycleCountHandler creates a new cycle count handler instance
+func NewCycleCountHandler(service services.CycleCountService) *CycleCountHandler {
+	return &CycleCountHandler{service: service}
+}
+
+// parseScheduleID reads the cycle count schedule ID path parameter
+func parseScheduleID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid cycle count schedule ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// ListSchedules godoc
+// @Summary List cycle count schedules
+// @Description Retrieve the cycle counting program (owner only)
+// @Tags Cycle Counts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.CycleCountSchedule} "Cycle count schedules retrieved successfully"
+// @Router /v1/inventory/cycle-count-schedules [get]
+func (h *CycleCountHandler) ListSchedules(c *gin.Context) {
+	schedules, err := h.service.GetSchedules(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve cycle count schedules")
+		return
+	}
+	helpers.OK(c, "Cycle count schedules retrieved successfully", schedules)
+}
+
+// GetSchedule godoc
+// @Summary Get a cycle count schedule
+// @Description Retrieve a cycle count schedule by its ID (owner only)
+// @Tags Cycle Counts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} helpers.Response{data=models.CycleCountSchedule} "Cycle count schedule retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Cycle count schedule not found"
+// @Router /v1/inventory/cycle-count-schedules/{id} [get]
+func (h *CycleCountHandler) GetSchedule(c *gin.Context) {
+	id, ok := parseScheduleID(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.GetScheduleByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve cycle count schedule")
+		return
+	}
+	if schedule == nil {
+		helpers.NotFound(c, "Cycle count schedule not found")
+		return
+	}
+	helpers.OK(c, "Cycle count schedule retrieved successfully", schedule)
+}
+
+// CreateSchedule godoc
+// @Summary Create a cycle count schedule
+// @Description Count every product of an ABC class on a recurring basis, e.g. A weekly, B monthly, C quarterly. Classes are ranked by 90-day sales value (A: top 80%, B: next 15%, C: rest). Each run opens a cycle count session due by the next run and notifies the assignee (owner only).
+// @Tags Cycle Counts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule body models.CycleCountScheduleInput true "Cycle count schedule"
+// @Success 201 {object} helpers.Response{data=models.CycleCountSchedule} "Cycle count schedule created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Router /v1/inventory/cycle-count-schedules [post]
+func (h *CycleCountHandler) CreateSchedule(c *gin.Context) {
+	var input models.CycleCountScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(c.Request.Context(), input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create cycle count schedule")
+		return
+	}
+	helpers.Created(c, "Cycle count schedule created successfully", schedule)
+}
+
+// UpdateSchedule godoc
+// @Summary Update a cycle count schedule
+// @Description Change the class, frequency, assignee, next run or active flag of a schedule (owner only)
+// @Tags Cycle Counts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Schedule ID"
+// @Param schedule body models.CycleCountScheduleInput true "Updated cycle count schedule"
+// @Success 200 {object} helpers.Response{data=models.CycleCountSchedule} "Cycle count schedule updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Cycle count schedule not found"
+// @Router /v1/inventory/cycle-count-schedules/{id} [put]
+func (h *CycleCountHandler) UpdateSchedule(c *gin.Context) {
+	id, ok := parseScheduleID(c)
+	if !ok {
+		return
+	}
+
+	var input models.CycleCountScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(c.Request.Context(), id, input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update cycle count schedule")
+		return
+	}
+	helpers.OK(c, "Cycle count schedule updated successfully", schedule)
+}
+
+// DeleteSchedule godoc
+// @Summary Delete a cycle count schedule
+// @Description Delete a schedule; count sessions it already opened are kept (owner only)
+// @Tags Cycle Counts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} helpers.Response "Cycle count schedule deleted successfully"
+// @Failure 404 {object} helpers.Problem "Cycle count schedule not found"
+// @Router /v1/inventory/cycle-count-schedules/{id} [delete]
+func (h *CycleCountHandler) DeleteSchedule(c *gin.Context) {
+	id, ok := parseScheduleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSchedule(c.Request.Context(), id); err != nil {
+		if err == sql.ErrNoRows {
+			helpers.NotFound(c, "Cycle count schedule not found")
+			return
+		}
+		helpers.ServiceError(c, err, "Failed to delete cycle count schedule")
+		return
+	}
+	helpers.OK(c, "Cycle count schedule deleted successfully", nil)
+}
+
+// RunSchedule godoc
+// @Summary Run a cycle count schedule now
+// @Description Open a cycle count session for the schedule immediately, due one period from now. The regular next run is unchanged (owner only).
+// @Tags Cycle Counts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Schedule ID"
+// @Success 201 {object} helpers.Response{data=models.CountSession} "Cycle count session opened successfully"
+// @Failure 400 {object} helpers.Problem "No products in the class"
+// @Failure 404 {object} helpers.Problem "Cycle count schedule not found"
+// @Router /v1/inventory/cycle-count-schedules/{id}/run [post]
+func (h *CycleCountHandler) RunSchedule(c *gin.Context) {
+	id, ok := parseScheduleID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.RunSchedule(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to open cycle count session")
+		return
+	}
+	helpers.Created(c, "Cycle count session opened successfully", session)
+}
+
+// Compliance godoc
+// @Summary Cycle count compliance
+// @Description Per schedule, count the sessions opened in a date range that were completed on time, completed late, are overdue, in progress or cancelled. Defaults to the last 90 days (owner only).
+// @Tags Cycle Counts
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} helpers.Response{data=[]models.CycleCountCompliance} "Cycle count compliance retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid date range"
+// @Router /v1/inventory/cycle-count-compliance [get]
+func (h *CycleCountHandler) Compliance(c *gin.Context) {
+	report, err := h.service.GetCompliance(c.Request.Context(),
+		strings.TrimSpace(c.Query("start_date")), strings.TrimSpace(c.Query("end_date")),
+	)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve cycle count compliance")
+		return
+	}
+	helpers.OK(c, "Cycle count compliance retrieved successfully", report)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DataQualityHandler handles the catalog data quality report
+type DataQualityHandler struct {
+	service services.DataQualityService
+}
+
+// NewDataQualityHandler creates a new data quality handler instance
+func NewDataQualityHandler(service services.DataQualityService) *DataQualityHandler {
+	return &DataQualityHandler{service: service}
+}
+
+// staleDaysQuery parses the optional stale_days parameter (0 when absent)
+func staleDaysQuery(c *gin.Context) (int, bool) {
+	raw := c.Query("stale_days")
+	if raw == "" {
+		return 0, true
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil {
+		helpers.BadRequest(c, "Invalid stale_days")
+		return 0, false
+	}
+	return days, true
+}
+
+// Report godoc
+// @Summary Catalog data quality report
+// @Description Count products missing a SKU/barcode, priced at zero, without a category, sharing a name with another product, or stale (active but neither sold nor edited within stale_days). Each check links to its paginated drill-down (owner only).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param stale_days query int false "Days without a sale or edit before an active product is stale (default 180)"
+// @Success 200 {object} helpers.Response{data=models.DataQualityReport} "Data quality report retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid stale_days"
+// @Router /v1/admin/data-quality [get]
+func (h *DataQualityHandler) Report(c *gin.Context) {
+	staleDays, ok := staleDaysQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetReport(c.Request.Context(), staleDays)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve data quality report")
+		return
+	}
+	helpers.OK(c, "Data quality report retrieved successfully", report)
+}
+
+// Issues godoc
+// @Summary Products failing a data quality check
+// @Description Paginated list of the products failing one check. Duplicate names are grouped together; stale products are listed longest idle first (owner only).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param check path string true "Check" Enums(missing_barcode, zero_price, uncategorized, duplicate_name, stale)
+// @Param stale_days query int false "Days without a sale or edit before an active product is stale (default 180)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.DataQualityIssue} "Data quality issues retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid stale_days"
+// @Failure 404 {object} helpers.Problem "Unknown check"
+// @Router /v1/admin/data-quality/{check} [get]
+func (h *DataQualityHandler) Issues(c *gin.Context) {
+	staleDays, ok := staleDaysQuery(c)
+	if !ok {
+		return
+	}
+	page, limit := helpers.ParsePagination(c)
+
+	result, err := h.service.GetIssues(c.Request.Context(), models.DataQualityParams{
+		Check:     c.Param("check"),
+		StaleDays: staleDays,
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve data quality issues")
+		return
+	}
+	helpers.Paginated(c, "Data quality issues retrieved successfully", result.Data, helpers.PaginationMeta{
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/middleware"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+)
+
+// schemasPath is where the JSON Schemas of request and response bodies are served
+const schemasPath = "/docs/schemas/"
+
+// tsClientPath is where the generated TypeScript client is served
+const tsClientPath = "/client.ts"
+
+// DocsHandler serves the Swagger UI, a spec whose host matches the server
+// the docs were opened on, the JSON Schemas of the API bodies, a TypeScript
+// client generated from the spec and, under /docs/{role}/, the UI and spec
+// limited to the endpoints a role may call
+type DocsHandler struct {
+	spec        *swag.Spec
+	servers     []string
+	ui          gin.HandlerFunc
+	perms       middleware.Permissions
+	schemas     map[string][]byte
+	schemaIndex []byte
+	tsClient    []byte
+	tsClientErr error
+}
+
+// NewDocsHandler creates a new docs handler. servers lists the hosts the API
+// is reachable on; the first one is used when the request host is not listed.
+// perms is the role mapping enforced on the API, which scopes the role views.
+func NewDocsHandler(spec *swag.Spec, servers []string, ui gin.HandlerFunc, perms middleware.Permissions) *DocsHandler {
+	schemas, index := buildSchemas()
+	h := &DocsHandler{spec: spec, servers: servers, ui: ui, perms: perms, schemas: schemas, schemaIndex: index}
+	if h.tsClient, h.tsClientErr = helpers.TypeScriptClient([]byte(spec.ReadDoc())); h.tsClientErr != nil {
+		slog.Error("failed to generate the TypeScript client", "component", "docs", "error", h.tsClientErr)
+	}
+	return h
+}
+
+// Serve handles /docs/*any, rendering doc.json per request, serving the
+// schemas under /docs/schemas/, the TypeScript client at /docs/client.ts and
+// the role views under /docs/{role}/, and delegating everything else to the
+// Swagger UI
+func (h *DocsHandler) Serve(c *gin.Context) {
+	if role, rest, ok := h.roleView(c.Param("any")); ok {
+		h.serveRoleView(c, role, rest)
+		return
+	}
+	if name, ok := strings.CutPrefix(c.Param("any"), "/schemas"); ok {
+		h.serveSchema(c, strings.TrimPrefix(name, "/"))
+		return
+	}
+	if c.Param("any") == tsClientPath {
+		h.serveTSClient(c)
+		return
+	}
+	if c.Param("any") != "/doc.json" {
+		h.ui(c)
+		return
+	}
+
+	doc, err := h.specFor(c)
+	if err == nil {
+		var body []byte
+		if body, err = json.Marshal(doc); err == nil {
+			c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+			return
+		}
+	}
+	helpers.ServiceError(c, err, "Failed to render API documentation")
+}
+
+// specFor renders the spec for the host the request was made on, with the
+// legacy paths added
+func (h *DocsHandler) specFor(c *gin.Context) (map[string]interface{}, error) {
+	spec := *h.spec
+	spec.Host = h.hostFor(c)
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(spec.ReadDoc()), &doc); err != nil {
+		return nil, err
+	}
+	addLegacyPaths(doc)
+	return doc, nil
+}
+
+// legacyTag groups the legacy paths in the Swagger UI
+const legacyTag = "Legacy (deprecated)"
+
+// addLegacyPaths documents the unversioned path of every /v1 operation, which
+// middleware.LegacyPaths still serves, as a deprecated copy of the operation
+func addLegacyPaths(doc map[string]interface{}) {
+	paths, _ := doc["paths"].(map[string]interface{})
+	legacyPaths := make(map[string]interface{})
+	for path, item := range paths {
+		legacy, ok := middleware.LegacyPath(path)
+		operations, isMap := item.(map[string]interface{})
+		if !ok || !isMap {
+			continue
+		}
+		aliases := make(map[string]interface{}, len(operations))
+		for method, op := range operations {
+			operation, ok := op.(map[string]interface{})
+			if !ok || !isOperation(method) {
+				aliases[method] = op
+				continue
+			}
+			alias := make(map[string]interface{}, len(operation)+2)
+			for k, v := range operation {
+				alias[k] = v
+			}
+			alias["deprecated"] = true
+			alias["tags"] = []string{legacyTag}
+			alias["description"] = fmt.Sprintf("Deprecated: use %s %s. Responses carry a Deprecation header, and errors keep the shape they had before /v1.", strings.ToUpper(method), path)
+			aliases[method] = alias
+		}
+		legacyPaths[legacy] = aliases
+	}
+	for path, item := range legacyPaths {
+		paths[path] = item
+	}
+}
+
+// roleView splits /{role}/{file} when role is one of the user roles
+func (h *DocsHandler) roleView(path string) (role, rest string, ok bool) {
+	role, rest, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !slices.Contains(middleware.Roles, role) {
+		return "", "", false
+	}
+	return role, "/" + rest, true
+}
+
+// serveRoleView serves the docs of one role: doc.json without the operations
+// the role may not call, and the Swagger UI assets, which are shared, for
+// everything else. /docs/{role} redirects to its index.html.
+func (h *DocsHandler) serveRoleView(c *gin.Context, role, rest string) {
+	switch rest {
+	case "/":
+		c.Redirect(http.StatusMovedPermanently, "/docs/"+role+"/index.html")
+	case "/doc.json":
+		doc, err := h.specFor(c)
+		var body []byte
+		if err == nil {
+			body, err = scopeSpec(doc, role, h.perms)
+		}
+		if err != nil {
+			helpers.ServiceError(c, err, "Failed to render API documentation")
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	default:
+		// The UI serves its assets by file name relative to the first path
+		// it was requested under, so hand it the shared path
+		c.Request.URL.Path = "/docs" + rest
+		c.Request.RequestURI = c.Request.URL.RequestURI()
+		h.ui(c)
+	}
+}
+
+// scopeSpec removes the operations role may not call from a Swagger spec,
+// and paths left without operations, and names the role in the title. A
+// legacy path is allowed as its /v1 path is, since that is how it is served.
+func scopeSpec(spec map[string]interface{}, role string, perms middleware.Permissions) ([]byte, error) {
+	paths, _ := spec["paths"].(map[string]interface{})
+	for path, item := range paths {
+		operations, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		remaining := 0
+		for method := range operations {
+			if !isOperation(method) {
+				continue
+			}
+			route := path
+			if versioned, ok := middleware.VersionedPath(path); ok {
+				route = versioned
+			}
+			if perms.Allows(role, strings.ToUpper(method), route) {
+				remaining++
+			} else {
+				delete(operations, method)
+			}
+		}
+		if remaining == 0 {
+			delete(paths, path)
+		}
+	}
+	if info, ok := spec["info"].(map[string]interface{}); ok {
+		info["title"] = fmt.Sprintf("%v (%s)", info["title"], role)
+	}
+
+	return json.Marshal(spec)
+}
+
+// isOperation reports whether a key of a Swagger path item is an operation
+// rather than shared parameters or an extension
+func isOperation(key string) bool {
+	switch key {
+	case "get", "put", "post", "delete", "options", "head", "patch":
+		return true
+	}
+	return false
+}
+
+// hostFor returns the configured server matching the request host
+func (h *DocsHandler) hostFor(c *gin.Context) string {
+	host := c.GetHeader("X-Forwarded-Host")
+	if host == "" {
+		host = c.Request.Host
+	}
+	for _, server := range h.servers {
+		if strings.EqualFold(server, host) {
+			return server
+		}
+	}
+	if len(h.servers) > 0 {
+		return h.servers[0]
+	}
+	return h.spec.Host
+}
+
+// serveSchema serves the schema index at /docs/schemas/ and each schema at
+// /docs/schemas/{name}.json
+func (h *DocsHandler) serveSchema(c *gin.Context, name string) {
+	if name == "" {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", h.schemaIndex)
+		return
+	}
+
+	body, ok := h.schemas[strings.TrimSuffix(name, ".json")]
+	if !ok || !strings.HasSuffix(name, ".json") {
+		helpers.NotFound(c, "Schema not found")
+		return
+	}
+	c.Data(http.StatusOK, "application/schema+json", body)
+}
+
+// serveTSClient serves the TypeScript client generated from the spec at startup
+func (h *DocsHandler) serveTSClient(c *gin.Context) {
+	if h.tsClientErr != nil {
+		helpers.InternalError(c, "Failed to generate TypeScript client", h.tsClientErr.Error())
+		return
+	}
+	c.Header("Content-Disposition", `inline; filename="retail-api.ts"`)
+	c.Data(http.StatusOK, "application/typescript; charset=utf-8", h.tsClient)
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"retail-core-api/docs"
+	"retail-core-api/middleware"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newDocsRouter serves the docs of the committed spec on servers
+func newDocsRouter(servers []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ui := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	h := NewDocsHandler(docs.SwaggerInfo, servers, ui, middleware.RoutePermissions)
+	r := gin.New()
+	r.GET("/docs/*any", h.Serve)
+	return r
+}
+
+// getSpec fetches and decodes a doc.json from the docs router
+func getSpec(t *testing.T, r http.Handler, path, host string) map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Host = host
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d: %s", path, w.Code, w.Body.String())
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	return spec
+}
+
+// operation returns an operation of a decoded spec, nil when it is missing
+func operation(spec map[string]interface{}, method, path string) map[string]interface{} {
+	paths, _ := spec["paths"].(map[string]interface{})
+	item, _ := paths[path].(map[string]interface{})
+	op, _ := item[method].(map[string]interface{})
+	return op
+}
+
+func TestDocsLegacyPathsDeprecated(t *testing.T) {
+	spec := getSpec(t, newDocsRouter(nil), "/docs/doc.json", "localhost:8080")
+
+	current := operation(spec, "post", "/v1/checkout")
+	if current == nil {
+		t.Fatal("POST /v1/checkout is not documented")
+	}
+	if current["deprecated"] == true {
+		t.Fatal("POST /v1/checkout is marked deprecated")
+	}
+
+	for _, tc := range []struct{ method, path string }{
+		{"post", "/api/checkout"},
+		{"post", "/auth/login"},
+	} {
+		op := operation(spec, tc.method, tc.path)
+		if op == nil {
+			t.Fatalf("legacy %s %s is not documented", tc.method, tc.path)
+		}
+		if op["deprecated"] != true {
+			t.Fatalf("legacy %s %s is not marked deprecated", tc.method, tc.path)
+		}
+	}
+	if operation(spec, "post", "/api/auth/login") != nil {
+		t.Fatal("/v1/auth/login is documented as /api/auth/login instead of /auth/login")
+	}
+}
+
+func TestDocsRoleViewScopesLegacyPaths(t *testing.T) {
+	spec := getSpec(t, newDocsRouter(nil), "/docs/cashier/doc.json", "localhost:8080")
+
+	if operation(spec, "get", "/v1/admin/feature-flags") != nil {
+		t.Fatal("the cashier view lists an owner-only route")
+	}
+	if operation(spec, "get", "/api/admin/feature-flags") != nil {
+		t.Fatal("the cashier view lists the legacy path of an owner-only route")
+	}
+	if operation(spec, "post", "/api/checkout") == nil {
+		t.Fatal("the cashier view is missing the legacy checkout path")
+	}
+}
+
+func TestDocsServersDescribeTheRoutes(t *testing.T) {
+	r := newDocsRouter([]string{"api.example.com", "staging.example.com"})
+
+	for _, tc := range []struct{ host, want string }{
+		{"staging.example.com", "staging.example.com"},
+		{"API.example.com", "api.example.com"},
+		{"10.0.0.5:8080", "api.example.com"},
+	} {
+		spec := getSpec(t, r, "/docs/doc.json", tc.host)
+		if spec["host"] != tc.want {
+			t.Fatalf("opened on %s: host = %v, want %s", tc.host, spec["host"], tc.want)
+		}
+		// The servers are only useful if the spec describes what they serve
+		for _, route := range []struct{ method, path string }{
+			{"get", "/v1/products"},
+			{"post", "/v1/checkout"},
+			{"get", "/health/ready"},
+		} {
+			if operation(spec, route.method, route.path) == nil {
+				t.Fatalf("opened on %s: %s %s is not documented", tc.host, route.method, route.path)
+			}
+		}
+	}
+}
+
+func TestDocsTSClientCallsV1(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/docs/client.ts", nil)
+	w := httptest.NewRecorder()
+	newDocsRouter(nil).ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+
+	client := w.Body.String()
+	if !strings.Contains(client, "`/v1/checkout`") {
+		t.Fatal("the TypeScript client has no method for POST /v1/checkout")
+	}
+	// The client calls the current paths only, not the deprecated aliases
+	if strings.Contains(client, "`/api/") || strings.Contains(client, "`/auth/") {
+		t.Fatal("the TypeScript client calls legacy paths")
+	}
+}
+
+// routerAnnotation matches the @Router line of a godoc block
+var routerAnnotation = regexp.MustCompile(`^//\s*@Router\s+(\S+)\s+\[(\w+)\]`)
+
+// specOperations returns "METHOD path" of every operation in a spec
+func specOperations(t *testing.T, doc []byte) map[string]bool {
+	t.Helper()
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		t.Fatal(err)
+	}
+	ops := make(map[string]bool)
+	for path, item := range spec.Paths {
+		for method := range item {
+			if isOperation(method) {
+				ops[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+	return ops
+}
+
+// missing returns the keys of want that are not in got, sorted
+func missing(want, got map[string]bool) []string {
+	var out []string
+	for k := range want {
+		if !got[k] {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// TestDocsMatchAnnotations fails when docs/ was not regenerated (swag init)
+// after an @Router annotation was added, changed or removed
+func TestDocsMatchAnnotations(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotated := make(map[string]bool)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(string(src), "\n") {
+			if m := routerAnnotation.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				annotated[strings.ToUpper(m[2])+" "+m[1]] = true
+			}
+		}
+	}
+
+	committed, err := os.ReadFile(filepath.Join("..", "docs", "swagger.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, doc := range map[string][]byte{
+		"docs/swagger.json": committed,
+		"docs/docs.go":      []byte(docs.SwaggerInfo.ReadDoc()),
+	} {
+		documented := specOperations(t, doc)
+		if m := missing(annotated, documented); len(m) > 0 {
+			t.Errorf("%s is missing annotated operations, run swag init: %v", name, m)
+		}
+		if m := missing(documented, annotated); len(m) > 0 {
+			t.Errorf("%s documents operations no handler annotates, run swag init: %v", name, m)
+		}
+	}
+}
+
+// TestRoutePermissionsAreDocumented fails when a permission rule matches no
+// documented operation, so the role-scoped docs would not show its effect
+func TestRoutePermissionsAreDocumented(t *testing.T) {
+	documented := specOperations(t, []byte(docs.SwaggerInfo.ReadDoc()))
+	for _, perm := range middleware.RoutePermissions {
+		matched := false
+		for op := range documented {
+			method, path, _ := strings.Cut(op, " ")
+			if (middleware.Permissions{perm}).RolesFor(method, path) != nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			t.Errorf("permission %s %s matches no documented operation", perm.Method, perm.Path)
+		}
+	}
+}
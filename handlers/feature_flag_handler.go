@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagHandler handles HTTP requests for feature flags
+type FeatureFlagHandler struct {
+	service services.FeatureFlagService
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler instance
+func NewFeatureFlagHandler(service services.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{service: service}
+}
+
+// List godoc
+// @Summary Get all feature flags
+// @Description Retrieve every feature flag with its default, the tenant's setting and the stores that override it (owner only)
+// @Tags Feature Flags
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.FeatureFlag} "Feature flags retrieved successfully"
+// @Router /v1/admin/feature-flags [get]
+func (h *FeatureFlagHandler) List(c *gin.Context) {
+	flags, err := h.service.GetFlags(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve feature flags")
+		return
+	}
+	helpers.OK(c, "Feature flags retrieved successfully", flags)
+}
+
+// Set godoc
+// @Summary Switch a feature flag on or off
+// @Description Switch a feature on or off for the whole tenant, or for one store when store_id is set. A store's setting wins over the tenant's, which wins over the default. Takes effect on the next request (owner only).
+// @Tags Feature Flags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Flag name" example(promotions)
+// @Param flag body models.SetFeatureFlagRequest true "Flag state"
+// @Success 200 {object} helpers.Response{data=models.FeatureFlag} "Feature flag updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body"
+// @Failure 404 {object} helpers.Problem "Feature flag or store not found"
+// @Router /v1/admin/feature-flags/{name} [put]
+func (h *FeatureFlagHandler) Set(c *gin.Context) {
+	var req models.SetFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	flag, err := h.service.SetFlag(c.Request.Context(), c.Param("name"), req)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update feature flag")
+		return
+	}
+	helpers.OK(c, "Feature flag updated successfully", flag)
+}
+
+// Clear godoc
+// @Summary Clear a feature flag setting
+// @Description Remove the tenant's setting of a flag so the default applies again, or a store's override (store_id) so the tenant's setting applies (owner only)
+// @Tags Feature Flags
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Flag name" example(promotions)
+// @Param store_id query int false "Store whose override to remove"
+// @Success 200 {object} helpers.Response{data=models.FeatureFlag} "Feature flag setting cleared successfully"
+// @Failure 400 {object} helpers.Problem "Invalid store ID"
+// @Failure 404 {object} helpers.Problem "Feature flag not found or not set"
+// @Router /v1/admin/feature-flags/{name} [delete]
+func (h *FeatureFlagHandler) Clear(c *gin.Context) {
+	var storeID *int
+	if value := c.Query("store_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			helpers.BadRequest(c, "Invalid store ID")
+			return
+		}
+		storeID = &id
+	}
+
+	flag, err := h.service.ClearFlag(c.Request.Context(), c.Param("name"), storeID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to clear feature flag setting")
+		return
+	}
+	helpers.OK(c, "Feature flag setting cleared successfully", flag)
+}
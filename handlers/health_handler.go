@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler handles the liveness and readiness probes
+type HealthHandler struct {
+	service services.HealthService
+}
+
+// NewHealthHandler creates a new health handler instance
+func NewHealthHandler(service services.HealthService) *HealthHandler {
+	return &HealthHandler{service: service}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Reports that the process is up and serving requests. Dependencies are not checked, so an orchestrator restarts the instance only when it hangs, not when the database is down.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} helpers.Response "Server is alive"
+// @Router /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	helpers.OK(c, "Server is alive", gin.H{"status": models.HealthUp})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Checks the database, the schema version, and the read replica and cache when configured, with the status of each. Answers 503 when the database is down or behind on migrations; a down replica or cache only degrades the status.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} helpers.Response{data=models.HealthReport} "Server is ready"
+// @Failure 503 {object} helpers.Response{data=models.HealthReport} "Server is not ready"
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.service.Ready(c.Request.Context())
+	if report.Status == models.HealthDown {
+		helpers.Unavailable(c, "Server is not ready", report)
+		return
+	}
+	helpers.OK(c, "Server is ready", report)
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InventoryHandler handles stock ledger and adjustment endpoints
+type InventoryHandler struct {
+	service services.InventoryService
+}
+
+// NewInventoryHandler creates a new inventory handler instance
+func NewInventoryHandler(service services.InventoryService) *InventoryHandler {
+	return &InventoryHandler{service: service}
+}
+
+// ListMovements godoc
+// @Summary List stock movements of a product
+// @Description Retrieve the immutable stock ledger of a product (sales, refunds, restocks, adjustments), newest first
+// @Tags Stock
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param store_id query int false "Filter by store ID"
+// @Param reason query string false "Filter by reason" Enums(initial, sale, refund, restock, adjustment, transfer_out, transfer_in)
+// @Param start_date query string false "Start date filter (YYYY-MM-DD)"
+// @Param end_date query string false "End date filter (YYYY-MM-DD)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.StockMovement} "Stock movements retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID or filter"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/stock-movements [get]
+func (h *InventoryHandler) ListMovements(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
+	page, limit := helpers.ParsePagination(c)
+	params := models.StockMovementParams{
+		ProductID: id,
+		StoreID:   storeID,
+		Reason:    strings.TrimSpace(c.Query("reason")),
+		StartDate: strings.TrimSpace(c.Query("start_date")),
+		EndDate:   strings.TrimSpace(c.Query("end_date")),
+		Page:      page,
+		Limit:     limit,
+	}
+
+	result, err := h.service.GetStockMovements(c.Request.Context(), params)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve stock movements")
+		return
+	}
+	helpers.Paginated(c, "Stock movements retrieved successfully", result.Data, helpers.PaginationMeta{
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// AdjustStock godoc
+// @Summary Adjust product stock
+// @Description Add or remove stock at a store (the default store unless store_id is given) with a reason code (damage, count_correction, received_goods). The change is written to the stock ledger.
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param adjustment body models.StockAdjustmentInput true "Signed quantity and reason code"
+// @Success 201 {object} helpers.Response{data=models.StockMovement} "Stock adjusted successfully"
+// @Failure 400 {object} helpers.Problem "Invalid quantity, reason code, store or insufficient stock"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/stock-adjustments [post]
+func (h *InventoryHandler) AdjustStock(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	var input models.StockAdjustmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	movement, err := h.service.AdjustStock(c.Request.Context(), id, input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to adjust stock")
+		return
+	}
+	helpers.Created(c, "Stock adjusted successfully", movement)
+}
+
+// LowStockReport godoc
+// @Summary Low-stock report
+// @Description List active products whose stock is at or below their min_stock threshold, largest shortfall first
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param category_id query int false "Filter by category ID"
+// @Success 200 {object} helpers.Response{data=[]models.LowStockProduct} "Low-stock report retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid category ID"
+// @Router /v1/report/low-stock [get]
+func (h *InventoryHandler) LowStockReport(c *gin.Context) {
+	var categoryID *int
+	if raw := c.Query("category_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			helpers.BadRequest(c, "Invalid category ID")
+			return
+		}
+		categoryID = &id
+	}
+
+	products, err := h.service.GetLowStockReport(c.Request.Context(), categoryID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve low-stock report")
+		return
+	}
+	helpers.OK(c, "Low-stock report retrieved successfully", products)
+}
+
+// ReorderSuggestions godoc
+// @Summary Reorder suggestions
+// @Description Suggest reorder quantities from recent sales velocity. A product is listed when its stock is at or below its reorder point (velocity × lead time + min_stock); the suggested quantity covers the lead time plus cover_days on top of min_stock.
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Sales velocity window in days (default 30, max 365)"
+// @Param lead_time_days query int false "Supplier lead time in days (default 7)"
+// @Param cover_days query int false "Days of demand to cover after delivery (default 14)"
+// @Param category_id query int false "Filter by category ID"
+// @Success 200 {object} helpers.Response{data=models.ReorderSuggestionReport} "Reorder suggestions retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid query parameter"
+// @Router /v1/report/reorder-suggestions [get]
+func (h *InventoryHandler) ReorderSuggestions(c *gin.Context) {
+	var params models.ReorderSuggestionParams
+	dayParams := []struct {
+		name string
+		dest *int
+	}{
+		{"days", &params.VelocityDays},
+		{"lead_time_days", &params.LeadTimeDays},
+		{"cover_days", &params.CoverDays},
+	}
+	for _, p := range dayParams {
+		if raw := c.Query(p.name); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 {
+				helpers.BadRequest(c, "Invalid "+p.name)
+				return
+			}
+			*p.dest = n
+		}
+	}
+	if raw := c.Query("category_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			helpers.BadRequest(c, "Invalid category ID")
+			return
+		}
+		params.CategoryID = &id
+	}
+
+	report, err := h.service.GetReorderSuggestions(c.Request.Context(), params)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve reorder suggestions")
+		return
+	}
+	helpers.OK(c, "Reorder suggestions retrieved successfully", report)
+}
+
+// InventoryValuation godoc
+// @Summary Inventory valuation
+// @Description Current cost value of owned stock using FIFO cost layers from goods receipts. Stock without a cost layer is valued at the product's cost price, else its last received cost. Consigned products are excluded (owner only).
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param category_id query int false "Filter by category ID"
+// @Success 200 {object} helpers.Response{data=models.InventoryValuation} "Inventory valuation retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid category ID"
+// @Router /v1/report/inventory-valuation [get]
+func (h *InventoryHandler) InventoryValuation(c *gin.Context) {
+	var categoryID *int
+	if raw := c.Query("category_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			helpers.BadRequest(c, "Invalid category ID")
+			return
+		}
+		categoryID = &id
+	}
+
+	valuation, err := h.service.GetInventoryValuation(c.Request.Context(), categoryID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve inventory valuation")
+		return
+	}
+	helpers.OK(c, "Inventory valuation retrieved successfully", valuation)
+}
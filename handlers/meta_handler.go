@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetaHandler handles API metadata endpoints
+type MetaHandler struct {
+	service services.MetaService
+}
+
+// NewMetaHandler creates a new meta handler instance
+func NewMetaHandler(service services.MetaService) *MetaHandler {
+	return &MetaHandler{service: service}
+}
+
+// SchemaVersion godoc
+// @Summary Get the data schema version
+// @Description Retrieve the latest schema migration applied to the database. Offline clients and integrations store the version and resync when it changes.
+// @Tags Meta
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.SchemaVersion} "Schema version retrieved successfully"
+// @Router /v1/meta/schema-version [get]
+func (h *MetaHandler) SchemaVersion(c *gin.Context) {
+	version, err := h.service.GetSchemaVersion(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve schema version")
+		return
+	}
+	helpers.OK(c, "Schema version retrieved successfully", version)
+}
+
+// Migrations godoc
+// @Summary List applied schema migrations
+// @Description Retrieve the data schema changelog: every applied migration with its version, description and the time it was applied, oldest first. Migrations that predate the changelog share the time it was first recorded.
+// @Tags Meta
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.SchemaMigration} "Schema migrations retrieved successfully"
+// @Router /v1/meta/migrations [get]
+func (h *MetaHandler) Migrations(c *gin.Context) {
+	migrations, err := h.service.GetMigrations(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve schema migrations")
+		return
+	}
+	helpers.OK(c, "Schema migrations retrieved successfully", migrations)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriceScheduleHandler handles future-dated price change endpoints
+type PriceScheduleHandler struct {
+	service services.PriceScheduleService
+}
+
+// NewPriceScheduleHandler creates a new price schedule handler instance
+func NewPriceScheduleHandler(service services.PriceScheduleService) *PriceScheduleHandler {
+	return &PriceScheduleHandler{service: service}
+}
+
+// List godoc
+// @Summary List scheduled prices of a product
+// @Description Retrieve the future price changes of a product in the order they take effect, including applied and cancelled ones unless filtered by status
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param status query string false "Filter by status" Enums(pending, applied, cancelled)
+// @Success 200 {object} helpers.Response{data=[]models.ScheduledPrice} "Scheduled prices retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID or status"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/scheduled-prices [get]
+func (h *PriceScheduleHandler) List(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	scheduled, err := h.service.GetScheduledPrices(c.Request.Context(), id, strings.TrimSpace(c.Query("status")))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve scheduled prices")
+		return
+	}
+	helpers.OK(c, "Scheduled prices retrieved successfully", scheduled)
+}
+
+// Create godoc
+// @Summary Schedule a price change
+// @Description Set a new selling price that takes effect at effective_at (e.g. a promo starting Friday 00:00). The price scheduler applies it within a minute of that time and logs it to the price history (owner only).
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param schedule body models.ScheduledPriceInput true "New price and effective time"
+// @Success 201 {object} helpers.Response{data=models.ScheduledPrice} "Price change scheduled successfully"
+// @Failure 400 {object} helpers.Problem "Invalid price or effective time"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/scheduled-prices [post]
+func (h *PriceScheduleHandler) Create(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	var input models.ScheduledPriceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	scheduled, err := h.service.SchedulePrice(c.Request.Context(), id, input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to schedule price change")
+		return
+	}
+	helpers.Created(c, "Price change scheduled successfully", scheduled)
+}
+
+// Cancel godoc
+// @Summary Cancel a scheduled price change
+// @Description Withdraw a pending price change before it takes effect (owner only)
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param schedule_id path int true "Scheduled price ID"
+// @Success 200 {object} helpers.Response{data=models.ScheduledPrice} "Scheduled price cancelled successfully"
+// @Failure 400 {object} helpers.Problem "Invalid ID"
+// @Failure 404 {object} helpers.Problem "Scheduled price not found"
+// @Failure 409 {object} helpers.Problem "Price change no longer pending"
+// @Router /v1/products/{id}/scheduled-prices/{schedule_id} [delete]
+func (h *PriceScheduleHandler) Cancel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+	scheduleID, err := strconv.Atoi(c.Param("schedule_id"))
+	if err != nil || scheduleID <= 0 {
+		helpers.BadRequest(c, "Invalid scheduled price ID")
+		return
+	}
+
+	cancelled, err := h.service.CancelScheduledPrice(c.Request.Context(), id, scheduleID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to cancel scheduled price")
+		return
+	}
+	helpers.OK(c, "Scheduled price cancelled successfully", cancelled)
+}
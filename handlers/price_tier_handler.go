@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriceTierHandler handles price level and quantity break endpoints
+type PriceTierHandler struct {
+	service services.PriceTierService
+}
+
+// NewPriceTierHandler creates a new price tier handler instance
+func NewPriceTierHandler(service services.PriceTierService) *PriceTierHandler {
+	return &PriceTierHandler{service: service}
+}
+
+// List godoc
+// @Summary List price tiers of a product
+// @Description Retrieve the wholesale, member and quantity-break prices of a product. At checkout a customer pays the lowest of the product price and every tier of their price level, or of retail, whose min_quantity they buy.
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} helpers.Response{data=[]models.PriceTier} "Price tiers retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/price-tiers [get]
+func (h *PriceTierHandler) List(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	tiers, err := h.service.GetPriceTiers(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve price tiers")
+		return
+	}
+	helpers.OK(c, "Price tiers retrieved successfully", tiers)
+}
+
+// Replace godoc
+// @Summary Set the price tiers of a product
+// @Description Replace every price tier of a product, e.g. a wholesale price from 1 unit and a cheaper one from 12 (owner only). An empty list removes all tiers.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param tiers body models.PriceTiersInput true "Complete list of price tiers"
+// @Success 200 {object} helpers.Response{data=[]models.PriceTier} "Price tiers updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID or tiers"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/price-tiers [put]
+func (h *PriceTierHandler) Replace(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	var input models.PriceTiersInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	tiers, err := h.service.ReplacePriceTiers(c.Request.Context(), id, input.Tiers)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update price tiers")
+		return
+	}
+	helpers.OK(c, "Price tiers updated successfully", tiers)
+}
@@ -1,35 +1,78 @@
 package handlers
 
 import (
+	"database/sql"
+	"log/slog"
+	"net/http"
 	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/services"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ProductHandler handles HTTP requests for products
 type ProductHandler struct {
-	service services.ProductService
+	service            services.ProductService
+	translationService services.TranslationService
+	approvalService    services.ApprovalService
+	auditService       services.AuditService
+	suggestionService  services.CategorySuggestionService
+	importService      services.ProductImportService
 }
 
 // NewProductHandler creates a new product handler instance
-func NewProductHandler(service services.ProductService) *ProductHandler {
-	return &ProductHandler{service: service}
+func NewProductHandler(service services.ProductService, translationService services.TranslationService, approvalService services.ApprovalService, auditService services.AuditService, suggestionService services.CategorySuggestionService, importService services.ProductImportService) *ProductHandler {
+	return &ProductHandler{service: service, translationService: translationService, approvalService: approvalService, auditService: auditService, suggestionService: suggestionService, importService: importService}
+}
+
+// productFromInput maps a ProductInput to a Product (active and with the
+// default low-stock threshold unless set)
+func productFromInput(input models.ProductInput) models.Product {
+	isActive := true
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+	minStock := models.DefaultMinStock
+	if input.MinStock != nil {
+		minStock = *input.MinStock
+	}
+
+	return models.Product{
+		Name:       input.Name,
+		Price:      input.Price,
+		Stock:      input.Stock,
+		MinStock:   minStock,
+		SKU:        input.SKU,
+		ImageURL:   input.ImageURL,
+		Unit:       input.Unit,
+		IsActive:   isActive,
+		CategoryID: input.CategoryID,
+
+		SupplierID:      input.SupplierID,
+		IsConsignment:   input.IsConsignment,
+		ConsignmentCost: input.ConsignmentCost,
+		CostPrice:       input.CostPrice,
+	}
 }
 
 // List godoc
 // @Summary Get all products (paginated)
-// @Description Retrieve a paginated list of products. Supports search by name and filter by category_id.
+// @Description Retrieve a paginated list of products. Supports search by name and filter by category_id. Names are localized according to Accept-Language.
 // @Tags Products
 // @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
 // @Param search query string false "Search product by name (case-insensitive partial match)"
 // @Param category_id query int false "Filter by category ID"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 20)"
-// @Success 200 {object} helpers.PaginatedResponse
-// @Router /products [get]
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.Product} "Products retrieved successfully"
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Router /v1/products [get]
 func (h *ProductHandler) List(c *gin.Context) {
 	params := models.ProductListParams{
 		Search: c.Query("search"),
@@ -64,11 +107,16 @@ func (h *ProductHandler) List(c *gin.Context) {
 		params.Limit = 20
 	}
 
-	result, err := h.service.GetAllProducts(params)
+	result, err := h.service.GetAllProducts(c.Request.Context(), params)
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve products", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve products")
 		return
 	}
+	if err := h.translationService.LocalizeProducts(c.Request.Context(), result.Data, helpers.RequestLocales(c)); err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve products")
+		return
+	}
+	withMargins(c, result.Data)
 
 	helpers.Paginated(c, "Successfully retrieved products", result.Data, helpers.PaginationMeta{
 		Page:       result.Page,
@@ -80,14 +128,18 @@ func (h *ProductHandler) List(c *gin.Context) {
 
 // GetByID godoc
 // @Summary Get a product by ID
-// @Description Retrieve details of a specific product by its ID with category name
+// @Description Retrieve details of a specific product by its ID with category name, localized according to Accept-Language
 // @Tags Products
 // @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
 // @Param id path int true "Product ID"
+// @Param If-None-Match header string false "ETag of the copy the client has"
 // @Success 200 {object} helpers.Response{data=models.Product} "Product retrieved successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid product ID"
-// @Failure 404 {object} helpers.ErrorResponse "Product not found"
-// @Router /products/{id} [get]
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Failure 400 {object} helpers.Problem "Invalid product ID"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id} [get]
 func (h *ProductHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -95,71 +147,103 @@ func (h *ProductHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	product, err := h.service.GetProductByID(id)
+	product, err := h.service.GetProductByID(c.Request.Context(), id)
+	h.respondProduct(c, product, err)
+}
+
+// GetBySlug godoc
+// @Summary Get a product by slug
+// @Description Retrieve details of a specific product by its URL-friendly slug, localized according to Accept-Language
+// @Tags Products
+// @Produce json
+// @Param Accept-Language header string false "Preferred locales (e.g. id-ID,en;q=0.8)"
+// @Param slug path string true "Product slug"
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Success 200 {object} helpers.Response{data=models.Product} "Product retrieved successfully"
+// @Header 200 {string} ETag "Tag of the response, for If-None-Match"
+// @Success 304 "Not modified: the client's copy is current"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/slug/{slug} [get]
+func (h *ProductHandler) GetBySlug(c *gin.Context) {
+	product, err := h.service.GetProductBySlug(c.Request.Context(), c.Param("slug"))
+	h.respondProduct(c, product, err)
+}
+
+// respondProduct writes a single product lookup result, localized for the request
+func (h *ProductHandler) respondProduct(c *gin.Context, product *models.Product, err error) {
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve product", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve product")
 		return
 	}
 	if product == nil {
 		helpers.NotFound(c, "Product not found")
 		return
 	}
-	helpers.OK(c, "Product retrieved successfully", product)
+	localized := []models.Product{*product}
+	if err := h.translationService.LocalizeProducts(c.Request.Context(), localized, helpers.RequestLocales(c)); err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve product")
+		return
+	}
+	withMargins(c, localized)
+	helpers.OK(c, "Product retrieved successfully", localized[0])
 }
 
 // Create godoc
 // @Summary Create a new product
-// @Description Add a new product to the database
+// @Description Add a new product to the database. When catalog approval is enabled, products created by non-owners are queued for approval instead (202). A product created without a category gets a category suggestion in the review queue.
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Param product body models.ProductInput true "Product object that needs to be added"
 // @Success 201 {object} helpers.Response{data=models.Product} "Product created successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid request body or validation error"
-// @Router /products [post]
+// @Success 202 {object} helpers.Response{data=models.ProductChangeRequest} "Product submitted for approval"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Router /v1/products [post]
 func (h *ProductHandler) Create(c *gin.Context) {
 	var input models.ProductInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
-	isActive := true
-	if input.IsActive != nil {
-		isActive = *input.IsActive
-	}
+	product := productFromInput(input)
 
-	product := models.Product{
-		Name:       input.Name,
-		Price:      input.Price,
-		Stock:      input.Stock,
-		SKU:        input.SKU,
-		ImageURL:   input.ImageURL,
-		Unit:       input.Unit,
-		IsActive:   isActive,
-		CategoryID: input.CategoryID,
+	if actor := currentActor(c); h.approvalService.NeedsApproval(actor) {
+		req, err := h.approvalService.SubmitCreate(c.Request.Context(), product, actor)
+		if err != nil {
+			helpers.ServiceError(c, err, "Failed to submit product")
+			return
+		}
+		helpers.Success(c, http.StatusAccepted, "Product submitted for approval", req)
+		return
 	}
 
-	created, err := h.service.CreateProduct(product)
+	created, err := h.service.CreateProduct(c.Request.Context(), product, currentActor(c))
 	if err != nil {
-		helpers.BadRequest(c, err.Error())
+		helpers.ServiceError(c, err, "Failed to create product")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionCreate, models.AuditEntityProduct, created.ID, nil, created)
+	if _, err := h.suggestionService.SuggestFor(c.Request.Context(), *created); err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to suggest a category", "component", "category-suggest", "product_id", created.ID, "error", err)
+	}
+	withMargin(c, created)
 	helpers.Created(c, "Product created successfully", created)
 }
 
 // Update godoc
 // @Summary Update a product
-// @Description Update an existing product by its ID
+// @Description Update an existing product by its ID. When catalog approval is enabled, price changes by non-owners are queued for approval instead (202).
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
 // @Param product body models.ProductInput true "Updated product object"
 // @Success 200 {object} helpers.Response{data=models.Product} "Product updated successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid request body or validation error"
-// @Failure 404 {object} helpers.ErrorResponse "Product not found"
-// @Router /products/{id} [put]
+// @Success 202 {object} helpers.Response{data=models.ProductChangeRequest} "Price change submitted for approval"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id} [put]
 func (h *ProductHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -169,35 +253,33 @@ func (h *ProductHandler) Update(c *gin.Context) {
 
 	var input models.ProductInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
-	product := models.Product{
-		Name:       input.Name,
-		Price:      input.Price,
-		Stock:      input.Stock,
-		SKU:        input.SKU,
-		ImageURL:   input.ImageURL,
-		Unit:       input.Unit,
-		CategoryID: input.CategoryID,
-	}
+	product := productFromInput(input)
 
-	if input.IsActive != nil {
-		product.IsActive = *input.IsActive
-	} else {
-		product.IsActive = true
+	if actor := currentActor(c); h.approvalService.NeedsApproval(actor) {
+		req, err := h.approvalService.SubmitUpdate(c.Request.Context(), id, product, actor)
+		if err != nil {
+			helpers.ServiceError(c, err, "Failed to submit product update")
+			return
+		}
+		if req != nil {
+			helpers.Success(c, http.StatusAccepted, "Price change submitted for approval", req)
+			return
+		}
 	}
 
-	updated, err := h.service.UpdateProduct(id, product)
+	before, _ := h.service.GetProductByID(c.Request.Context(), id)
+
+	updated, err := h.service.UpdateProduct(c.Request.Context(), id, product, currentActor(c))
 	if err != nil {
-		if helpers.IsNotFound(err) || err.Error() == "product not found" {
-			helpers.NotFound(c, "Product not found")
-		} else {
-			helpers.BadRequest(c, err.Error())
-		}
+		helpers.ServiceError(c, err, "Failed to update product")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionUpdate, models.AuditEntityProduct, id, before, updated)
+	withMargin(c, updated)
 	helpers.OK(c, "Product updated successfully", updated)
 }
 
@@ -208,9 +290,9 @@ func (h *ProductHandler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Product ID"
 // @Success 200 {object} helpers.Response "Product deleted successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid product ID"
-// @Failure 404 {object} helpers.ErrorResponse "Product not found"
-// @Router /products/{id} [delete]
+// @Failure 400 {object} helpers.Problem "Invalid product ID"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id} [delete]
 func (h *ProductHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -218,14 +300,184 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.service.DeleteProduct(id)
+	before, _ := h.service.GetProductByID(c.Request.Context(), id)
+
+	err = h.service.DeleteProduct(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to delete product")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionDelete, models.AuditEntityProduct, id, before, nil)
+	helpers.OK(c, "Product deleted successfully", nil)
+}
+
+// Import godoc
+// @Summary Import products from CSV
+// @Description Create or update products from a CSV with a header row of name, sku, price, stock and category (owner only; name and price are required columns). A row whose sku matches an existing product updates it and leaves empty cells unchanged; other rows create products. category is a category name or slug. Invalid rows are skipped and listed in errors. With dry_run=true every row is validated but nothing is written. Upload the file as the multipart field "file" or send it as a text/csv body (up to 5 MB, 5000 rows).
+// @Tags Products
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file false "CSV file"
+// @Param dry_run query bool false "Validate only, write nothing"
+// @Success 200 {object} helpers.Response{data=models.ProductImportResult} "Import finished"
+// @Failure 400 {object} helpers.Problem "Missing file, unreadable CSV or unknown columns"
+// @Failure 403 {object} helpers.Problem "Owner role required"
+// @Failure 413 {object} helpers.Problem "File larger than 5 MB"
+// @Router /v1/products/import [post]
+func (h *ProductHandler) Import(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	body := c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			helpers.BadRequest(c, "CSV file is required", err.Error())
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			helpers.BadRequest(c, "Failed to read CSV file", err.Error())
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	result, err := h.importService.Import(c.Request.Context(), body, dryRun, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to import products")
+		return
+	}
+
+	message := "Products imported"
+	if dryRun {
+		message = "Dry run: nothing was written"
+	}
+	helpers.OK(c, message, result)
+}
+
+// PriceHistory godoc
+// @Summary Get the price history of a product
+// @Description Retrieve every selling price change of a product with the old and new price, who made it and when, newest first. The first entry (old_price null) is the price the product was created with.
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} helpers.Response{data=[]models.PriceChange} "Price history retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/price-history [get]
+func (h *ProductHandler) PriceHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	changes, err := h.service.GetPriceHistory(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve price history")
+		return
+	}
+	helpers.OK(c, "Price history retrieved successfully", changes)
+}
+
+// ListRelations godoc
+// @Summary Get related products
+// @Description Retrieve substitutes, accessories and upsells of a product (e.g. to suggest an alternative when it is out of stock)
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param type query string false "Filter by relation type" Enums(substitute, accessory, upsell)
+// @Success 200 {object} helpers.Response{data=[]models.ProductRelation} "Related products retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID or relation type"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/relations [get]
+func (h *ProductHandler) ListRelations(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	relations, err := h.service.GetProductRelations(c.Request.Context(), id, c.Query("type"))
 	if err != nil {
-		if helpers.IsNotFound(err) || err.Error() == "product not found" {
-			helpers.NotFound(c, "Product not found")
+		helpers.ServiceError(c, err, "Failed to retrieve related products")
+		return
+	}
+	helpers.OK(c, "Related products retrieved successfully", relations)
+}
+
+// AddRelation godoc
+// @Summary Add a related product
+// @Description Link a product to a substitute, accessory or upsell product
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param relation body models.ProductRelationInput true "Relation to add"
+// @Success 201 {object} helpers.Response{data=models.ProductRelation} "Product relation added successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/relations [post]
+func (h *ProductHandler) AddRelation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	var input models.ProductRelationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	relation, err := h.service.AddProductRelation(c.Request.Context(), id, input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to add product relation")
+		return
+	}
+	helpers.Created(c, "Product relation added successfully", relation)
+}
+
+// RemoveRelation godoc
+// @Summary Remove a related product
+// @Description Unlink a related product for the given relation type
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param type path string true "Relation type" Enums(substitute, accessory, upsell)
+// @Param related_id path int true "Related product ID"
+// @Success 200 {object} helpers.Response "Product relation removed successfully"
+// @Failure 400 {object} helpers.Problem "Invalid product ID or relation type"
+// @Failure 404 {object} helpers.Problem "Product relation not found"
+// @Router /v1/products/{id}/relations/{type}/{related_id} [delete]
+func (h *ProductHandler) RemoveRelation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+	relatedID, err := strconv.Atoi(c.Param("related_id"))
+	if err != nil || relatedID <= 0 {
+		helpers.BadRequest(c, "Invalid related product ID")
+		return
+	}
+
+	err = h.service.RemoveProductRelation(c.Request.Context(), id, relatedID, c.Param("type"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			helpers.NotFound(c, "Product relation not found")
 			return
 		}
-		helpers.InternalError(c, "Failed to delete product", err.Error())
+		helpers.ServiceError(c, err, "Failed to remove product relation")
 		return
 	}
-	helpers.OK(c, "Product deleted successfully", nil)
+	helpers.OK(c, "Product relation removed successfully", nil)
 }
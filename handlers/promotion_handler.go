@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"database/sql"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PromotionHandler handles HTTP requests for promotion rules
+type PromotionHandler struct {
+	service      services.PromotionService
+	auditService services.AuditService
+}
+
+// NewPromotionHandler creates a new promotion handler instance
+func NewPromotionHandler(service services.PromotionService, auditService services.AuditService) *PromotionHandler {
+	return &PromotionHandler{service: service, auditService: auditService}
+}
+
+// promotionFromInput maps a PromotionInput to a Promotion (active by default)
+func promotionFromInput(input models.PromotionInput) models.Promotion {
+	isActive := true
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+
+	return models.Promotion{
+		Name:            input.Name,
+		Type:            input.Type,
+		ProductID:       input.ProductID,
+		CategoryID:      input.CategoryID,
+		BuyQty:          input.BuyQty,
+		GetQty:          input.GetQty,
+		BundleQty:       input.BundleQty,
+		BundlePrice:     input.BundlePrice,
+		DiscountPercent: input.DiscountPercent,
+		StartsAt:        input.StartsAt,
+		EndsAt:          input.EndsAt,
+		IsActive:        isActive,
+	}
+}
+
+// List godoc
+// @Summary Get all promotions
+// @Description Retrieve all promotion rules
+// @Tags Promotions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.Promotion} "Successfully retrieved promotions"
+// @Router /v1/promotions [get]
+func (h *PromotionHandler) List(c *gin.Context) {
+	promotions, err := h.service.GetAllPromotions(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve promotions")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved promotions", promotions)
+}
+
+// GetByID godoc
+// @Summary Get a promotion by ID
+// @Description Retrieve details of a specific promotion rule
+// @Tags Promotions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} helpers.Response{data=models.Promotion} "Promotion retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid promotion ID"
+// @Failure 404 {object} helpers.Problem "Promotion not found"
+// @Router /v1/promotions/{id} [get]
+func (h *PromotionHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid promotion ID")
+		return
+	}
+
+	promotion, err := h.service.GetPromotionByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve promotion")
+		return
+	}
+	if promotion == nil {
+		helpers.NotFound(c, "Promotion not found")
+		return
+	}
+	helpers.OK(c, "Promotion retrieved successfully", promotion)
+}
+
+// Create godoc
+// @Summary Create a promotion
+// @Description Add a new promotion rule (owner only). bogo and bundle_price require product_id; category_discount requires category_id and discount_percent.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param promotion body models.PromotionInput true "Promotion rule"
+// @Success 201 {object} helpers.Response{data=models.Promotion} "Promotion created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Router /v1/promotions [post]
+func (h *PromotionHandler) Create(c *gin.Context) {
+	var input models.PromotionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	created, err := h.service.CreatePromotion(c.Request.Context(), promotionFromInput(input))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create promotion")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionCreate, models.AuditEntityPromotion, created.ID, nil, created)
+	helpers.Created(c, "Promotion created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a promotion
+// @Description Update an existing promotion rule by its ID (owner only)
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Promotion ID"
+// @Param promotion body models.PromotionInput true "Updated promotion rule"
+// @Success 200 {object} helpers.Response{data=models.Promotion} "Promotion updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Promotion not found"
+// @Router /v1/promotions/{id} [put]
+func (h *PromotionHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid promotion ID")
+		return
+	}
+
+	var input models.PromotionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	before, _ := h.service.GetPromotionByID(c.Request.Context(), id)
+
+	updated, err := h.service.UpdatePromotion(c.Request.Context(), id, promotionFromInput(input))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update promotion")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionUpdate, models.AuditEntityPromotion, id, before, updated)
+	helpers.OK(c, "Promotion updated successfully", updated)
+}
+
+// Delete godoc
+// @Summary Delete a promotion
+// @Description Delete a promotion rule by its ID (owner only)
+// @Tags Promotions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} helpers.Response "Promotion deleted successfully"
+// @Failure 400 {object} helpers.Problem "Invalid promotion ID"
+// @Failure 404 {object} helpers.Problem "Promotion not found"
+// @Router /v1/promotions/{id} [delete]
+func (h *PromotionHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid promotion ID")
+		return
+	}
+
+	before, _ := h.service.GetPromotionByID(c.Request.Context(), id)
+
+	err = h.service.DeletePromotion(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			helpers.NotFound(c, "Promotion not found")
+			return
+		}
+		helpers.ServiceError(c, err, "Failed to delete promotion")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionDelete, models.AuditEntityPromotion, id, before, nil)
+	helpers.OK(c, "Promotion deleted successfully", nil)
+}
+
+// PerformanceReport godoc
+// @Summary Promotion performance report
+// @Description Usage count, discount given, promoted units, incremental units and attached revenue of every promotion over its validity window (starts_at or creation to ends_at or now), clipped to start_date and end_date when given. Incremental units compare the units of the targeted product or category sold in the window with the same length of time just before it. Attached revenue is the revenue of the sales the promotion applied to; a sale using several promotions counts toward each (owner only).
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Success 200 {object} helpers.Response{data=models.PromotionPerformanceReport} "Successfully retrieved promotion performance"
+// @Failure 400 {object} helpers.Problem "Invalid start_date, end_date or store ID"
+// @Router /v1/report/promotions [get]
+func (h *PromotionHandler) PerformanceReport(c *gin.Context) {
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetPerformanceReport(c.Request.Context(), strings.TrimSpace(c.Query("start_date")), strings.TrimSpace(c.Query("end_date")), storeID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve promotion performance")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved promotion performance", report)
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PurchaseOrderHandler handles purchase order and goods receiving endpoints
+type PurchaseOrderHandler struct {
+	service services.PurchaseOrderService
+}
+
+// NewPurchaseOrderHandler creates a new purchase order handler instance
+func NewPurchaseOrderHandler(service services.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{service: service}
+}
+
+// parsePurchaseOrderID reads the purchase order ID path parameter
+func parsePurchaseOrderID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid purchase order ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List godoc
+// @Summary List purchase orders
+// @Description List purchase orders, newest first (items and receipts are not included)
+// @Tags Purchase Orders
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (open, partially_received, received, cancelled)"
+// @Param supplier_id query int false "Filter by supplier ID"
+// @Success 200 {object} helpers.Response{data=[]models.PurchaseOrder} "Purchase orders retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid status or supplier ID"
+// @Router /v1/purchase-orders [get]
+func (h *PurchaseOrderHandler) List(c *gin.Context) {
+	supplierID := 0
+	if raw := c.Query("supplier_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			helpers.BadRequest(c, "Invalid supplier ID")
+			return
+		}
+		supplierID = parsed
+	}
+
+	orders, err := h.service.GetPurchaseOrders(c.Request.Context(), c.Query("status"), supplierID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve purchase orders")
+		return
+	}
+	helpers.OK(c, "Purchase orders retrieved successfully", orders)
+}
+
+// GetByID godoc
+// @Summary Get a purchase order
+// @Description Get a purchase order with its items and goods receipts
+// @Tags Purchase Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} helpers.Response{data=models.PurchaseOrder} "Purchase order retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid purchase order ID"
+// @Failure 404 {object} helpers.Problem "Purchase order not found"
+// @Router /v1/purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetByID(c *gin.Context) {
+	id, ok := parsePurchaseOrderID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.service.GetPurchaseOrderByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve purchase order")
+		return
+	}
+	if order == nil {
+		helpers.NotFound(c, "Purchase order not found")
+		return
+	}
+	helpers.OK(c, "Purchase order retrieved successfully", order)
+}
+
+// Create godoc
+// @Summary Create a purchase order
+// @Description Order products from a supplier at a unit cost price
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order body models.PurchaseOrderInput true "Purchase order"
+// @Success 201 {object} helpers.Response{data=models.PurchaseOrder} "Purchase order created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Supplier or product not found"
+// @Router /v1/purchase-orders [post]
+func (h *PurchaseOrderHandler) Create(c *gin.Context) {
+	var input models.PurchaseOrderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	order, err := h.service.CreatePurchaseOrder(c.Request.Context(), input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create purchase order")
+		return
+	}
+	helpers.Created(c, "Purchase order created successfully", order)
+}
+
+// Receive godoc
+// @Summary Receive goods against a purchase order
+// @Description Book a full or partial delivery: increments product stock, records the cost price of each line (defaulting to the ordered cost) and writes received_goods stock movements. Quantities may not exceed what is still outstanding.
+// @Tags Purchase Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Param receipt body models.ReceiveInput true "Received quantities"
+// @Success 201 {object} helpers.Response{data=models.GoodsReceipt} "Goods received successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request or quantity exceeds outstanding"
+// @Failure 404 {object} helpers.Problem "Purchase order not found"
+// @Failure 409 {object} helpers.Problem "Purchase order already closed"
+// @Router /v1/purchase-orders/{id}/receive [post]
+func (h *PurchaseOrderHandler) Receive(c *gin.Context) {
+	id, ok := parsePurchaseOrderID(c)
+	if !ok {
+		return
+	}
+
+	var input models.ReceiveInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	receipt, err := h.service.ReceiveGoods(c.Request.Context(), id, input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to receive goods")
+		return
+	}
+	helpers.Created(c, "Goods received successfully", receipt)
+}
+
+// Cancel godoc
+// @Summary Cancel a purchase order
+// @Description Cancel an open or partially received purchase order; stock already received is kept
+// @Tags Purchase Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} helpers.Response{data=models.PurchaseOrder} "Purchase order cancelled successfully"
+// @Failure 404 {object} helpers.Problem "Purchase order not found"
+// @Failure 409 {object} helpers.Problem "Purchase order already closed"
+// @Router /v1/purchase-orders/{id}/cancel [post]
+func (h *PurchaseOrderHandler) Cancel(c *gin.Context) {
+	id, ok := parsePurchaseOrderID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.service.CancelPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to cancel purchase order")
+		return
+	}
+	helpers.OK(c, "Purchase order cancelled successfully", order)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"io"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queueKeepAlive is how often an idle display stream receives a ping event
+const queueKeepAlive = 30 * time.Second
+
+// QueueHandler handles the pickup queue and its "now serving" display
+type QueueHandler struct {
+	service services.QueueService
+}
+
+// NewQueueHandler creates a new queue handler instance
+func NewQueueHandler(service services.QueueService) *QueueHandler {
+	return &QueueHandler{service: service}
+}
+
+// Status godoc
+// @Summary Get the pickup queue
+// @Description Retrieve today's queue: the number now being served and the last number issued at checkout
+// @Tags Queue
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.QueueStatus} "Queue status retrieved successfully"
+// @Router /v1/queue [get]
+func (h *QueueHandler) Status(c *gin.Context) {
+	status, err := h.service.GetStatus(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve queue status")
+		return
+	}
+	helpers.OK(c, "Queue status retrieved successfully", status)
+}
+
+// CallNext godoc
+// @Summary Call the next queue number
+// @Description Advance "now serving" to the next waiting queue number and push it to displays
+// @Tags Queue
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.QueueStatus} "Next queue number called"
+// @Failure 409 {object} helpers.Problem "No queue numbers are waiting"
+// @Router /v1/queue/next [post]
+func (h *QueueHandler) CallNext(c *gin.Context) {
+	status, err := h.service.CallNext(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update queue")
+		return
+	}
+	helpers.OK(c, "Next queue number called", status)
+}
+
+// Call godoc
+// @Summary Call a specific queue number
+// @Description Set "now serving" to a queue number issued today (e.g. to recall a customer) and push it to displays
+// @Tags Queue
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param call body models.QueueCallInput true "Queue number to call"
+// @Success 200 {object} helpers.Response{data=models.QueueStatus} "Queue number called"
+// @Failure 400 {object} helpers.Problem "Queue number has not been issued today"
+// @Router /v1/queue/call [post]
+func (h *QueueHandler) Call(c *gin.Context) {
+	var input models.QueueCallInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	status, err := h.service.Call(c.Request.Context(), input.QueueNo)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update queue")
+		return
+	}
+	helpers.OK(c, "Queue number called", status)
+}
+
+// Display godoc
+// @Summary Stream "now serving" updates
+// @Description Server-Sent Events stream for pickup displays. Sends the current queue status as a "status" event on connect and whenever a number is called, plus a "ping" event every 30 seconds.
+// @Tags Queue
+// @Produce text/event-stream
+// @Success 200 {object} models.QueueStatus "Stream of status events"
+// @Router /queue/display [get]
+func (h *QueueHandler) Display(c *gin.Context) {
+	status, err := h.service.GetStatus(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve queue status")
+		return
+	}
+
+	updates, unsubscribe := h.service.Subscribe()
+	defer unsubscribe()
+
+	keepAlive := time.NewTicker(queueKeepAlive)
+	defer keepAlive.Stop()
+
+	clearWriteDeadline(c)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("status", status)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case update := <-updates:
+			c.SSEvent("status", update)
+			return true
+		case <-keepAlive.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		case <-helpers.ShuttingDown(c.Request.Context()):
+			return false
+		}
+	})
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReceiptHandler handles customer-facing receipt endpoints
+type ReceiptHandler struct {
+	service services.ReceiptService
+}
+
+// NewReceiptHandler creates a new receipt handler instance
+func NewReceiptHandler(service services.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{service: service}
+}
+
+// formatAmount renders an integer amount with thousand separators (e.g. 15.000)
+func formatAmount(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.Itoa(amount)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String()
+}
+
+// receiptTemplate is the HTML page served for shared receipt links
+var receiptTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"amount": formatAmount,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Receipt {{.Transaction.ReceiptNo}}</title>
+<style>
+body { font-family: monospace; max-width: 360px; margin: 24px auto; padding: 0 12px; }
+h1 { font-size: 18px; text-align: center; margin-bottom: 4px; }
+.meta { text-align: center; color: #555; margin-bottom: 16px; }
+table { width: 100%; border-collapse: collapse; }
+td { padding: 2px 0; vertical-align: top; }
+td.num { text-align: right; }
+.promo { color: #2a7; font-size: 12px; }
+.total td { border-top: 1px dashed #000; font-weight: bold; padding-top: 6px; }
+.void { color: #c00; text-align: center; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.StoreName}}</h1>
+<div class="meta">{{.Transaction.ReceiptNo}}<br>{{.Transaction.CreatedAt.Format "02 Jan 2006 15:04"}}</div>
+{{if eq .Transaction.Status "void"}}<p class="void">VOID</p>{{end}}
+<table>
+{{range .Transaction.Details}}
+<tr><td colspan="2">{{.ProductName}}</td></tr>
+<tr><td>{{.Quantity}} x {{amount .UnitPrice}}</td><td class="num">{{amount .Subtotal}}</td></tr>
+{{range .Promotions}}<tr class="promo"><td>{{.Name}}</td><td class="num">-{{amount .Discount}}</td></tr>{{end}}
+{{end}}
+<tr class="total"><td>Subtotal</td><td class="num">{{amount .Subtotal}}</td></tr>
+{{if gt .Transaction.Discount 0}}<tr><td>Discount</td><td class="num">-{{amount .Transaction.Discount}}</td></tr>{{end}}
+<tr class="total"><td>Total</td><td class="num">{{amount .Transaction.TotalAmount}}</td></tr>
+{{if gt .Tax 0}}<tr><td>Incl. tax ({{.TaxRate}}%)</td><td class="num">{{amount .Tax}}</td></tr>{{end}}
+<tr><td>Payment</td><td class="num">{{.Transaction.PaymentMethod}}</td></tr>
+</table>
+<p class="meta">Thank you for shopping with us!</p>
+</body>
+</html>
+`))
+
+// Share godoc
+// @Summary Create a receipt sharing link
+// @Description Generate a short-lived public URL rendering the transaction receipt, e.g. to show as a QR code on the customer display
+// @Tags Transactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transaction ID"
+// @Success 201 {object} helpers.Response{data=models.ReceiptShareLink} "Receipt link created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid transaction ID"
+// @Failure 404 {object} helpers.Problem "Transaction not found"
+// @Router /v1/transactions/{id}/share [post]
+func (h *ReceiptHandler) Share(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid transaction ID")
+		return
+	}
+
+	link, err := h.service.CreateShareLink(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create receipt link")
+		return
+	}
+	helpers.Created(c, "Receipt link created successfully", link)
+}
+
+// View godoc
+// @Summary View a shared receipt
+// @Description Render a shared receipt as an HTML page (public, no authentication; the token expires)
+// @Tags Receipts
+// @Produce html
+// @Param token path string true "Receipt share token"
+// @Success 200 {string} string "Receipt HTML page"
+// @Failure 404 {string} string "Receipt link is invalid or expired"
+// @Router /receipts/{token} [get]
+func (h *ReceiptHandler) View(c *gin.Context) {
+	receipt, err := h.service.GetSharedReceipt(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.String(http.StatusNotFound, "Receipt link is invalid or expired")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := receiptTemplate.Execute(&buf, receipt); err != nil {
+		c.String(http.StatusInternalServerError, "Failed to render receipt")
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// receiptRow formats a left/right aligned receipt row within the PDF width
+func receiptRow(left, right string) string {
+	width := helpers.PDFColumns
+	if len(left)+len(right)+1 > width {
+		left = left[:max(0, width-len(right)-1)]
+	}
+	return left + strings.Repeat(" ", width-len(left)-len(right)) + right
+}
+
+// receiptCenter centers text within the PDF width
+func receiptCenter(text string) string {
+	pad := (helpers.PDFColumns - len(text)) / 2
+	if pad <= 0 {
+		return text
+	}
+	return strings.Repeat(" ", pad) + text
+}
+
+// receiptLines lays out a receipt as fixed-width text lines for PDF output
+func receiptLines(receipt *models.Receipt) []string {
+	t := receipt.Transaction
+	rule := strings.Repeat("-", helpers.PDFColumns)
+
+	lines := []string{
+		receiptCenter(receipt.StoreName),
+		receiptCenter(t.ReceiptNo),
+		receiptCenter(t.CreatedAt.Format("02 Jan 2006 15:04")),
+	}
+	if t.Status == "void" {
+		lines = append(lines, receiptCenter("*** VOID ***"))
+	}
+	lines = append(lines, rule)
+
+	for _, d := range t.Details {
+		lines = append(lines, d.ProductName)
+		lines = append(lines, receiptRow(fmt.Sprintf("  %d x %s", d.Quantity, formatAmount(d.UnitPrice)), formatAmount(d.Subtotal+d.Discount)))
+		for _, p := range d.Promotions {
+			lines = append(lines, receiptRow("  "+p.Name, "-"+formatAmount(p.Discount)))
+		}
+	}
+
+	lines = append(lines, rule, receiptRow("Subtotal", formatAmount(receipt.Subtotal)))
+	if t.Discount > 0 {
+		lines = append(lines, receiptRow("Discount", "-"+formatAmount(t.Discount)))
+	}
+	lines = append(lines, receiptRow("TOTAL", formatAmount(t.TotalAmount)))
+	if receipt.Tax > 0 {
+		lines = append(lines, receiptRow(fmt.Sprintf("Incl. tax (%g%%)", receipt.TaxRate), formatAmount(receipt.Tax)))
+	}
+	lines = append(lines,
+		receiptRow("Payment", t.PaymentMethod),
+		rule,
+		receiptCenter("Thank you for shopping with us!"),
+	)
+
+	return lines
+}
+
+// PDF godoc
+// @Summary Download a printable receipt
+// @Description Render a transaction receipt (store header, line items, totals, tax) as a PDF sized for 80mm receipt printers
+// @Tags Transactions
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Transaction ID"
+// @Success 200 {file} file "Receipt PDF"
+// @Failure 400 {object} helpers.Problem "Invalid transaction ID"
+// @Failure 404 {object} helpers.Problem "Transaction not found"
+// @Router /v1/transactions/{id}/receipt.pdf [get]
+func (h *ReceiptHandler) PDF(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid transaction ID")
+		return
+	}
+
+	receipt, err := h.service.GetReceipt(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to generate receipt")
+		return
+	}
+
+	filename := fmt.Sprintf("receipt-%d.pdf", id)
+	if receipt.Transaction.ReceiptNo != "" {
+		filename = receipt.Transaction.ReceiptNo + ".pdf"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", helpers.TextPDF(receiptLines(receipt)))
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportScheduleHandler handles HTTP requests for scheduled reports
+type ReportScheduleHandler struct {
+	service services.ReportScheduleService
+}
+
+// NewReportScheduleHandler creates a new report schedule handler instance
+func NewReportScheduleHandler(service services.ReportScheduleService) *ReportScheduleHandler {
+	return &ReportScheduleHandler{service: service}
+}
+
+// List godoc
+// @Summary Get all report schedules
+// @Description Retrieve every report schedule with its next run and the status of its latest run (owner only)
+// @Tags Report Schedules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.ReportSchedule} "Report schedules retrieved successfully"
+// @Router /v1/report-schedules [get]
+func (h *ReportScheduleHandler) List(c *gin.Context) {
+	schedules, err := h.service.GetSchedules(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve report schedules")
+		return
+	}
+	helpers.OK(c, "Report schedules retrieved successfully", schedules)
+}
+
+// GetByID godoc
+// @Summary Get a report schedule by ID
+// @Description Retrieve a specific report schedule (owner only)
+// @Tags Report Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Report schedule ID"
+// @Success 200 {object} helpers.Response{data=models.ReportSchedule} "Report schedule retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid report schedule ID"
+// @Failure 404 {object} helpers.Problem "Report schedule not found"
+// @Router /v1/report-schedules/{id} [get]
+func (h *ReportScheduleHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid report schedule ID")
+		return
+	}
+
+	schedule, err := h.service.GetScheduleByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve report schedule")
+		return
+	}
+	if schedule == nil {
+		helpers.NotFound(c, "Report schedule not found")
+		return
+	}
+	helpers.OK(c, "Report schedule retrieved successfully", schedule)
+}
+
+// Create godoc
+// @Summary Create a report schedule
+// @Description Schedule a report to be rendered as CSV or PDF on a cron expression and uploaded to a configured target (local, s3 or sftp). Upload credentials come from the server configuration (owner only).
+// @Tags Report Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule body models.ReportScheduleInput true "Report schedule"
+// @Success 201 {object} helpers.Response{data=models.ReportSchedule} "Report schedule created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body, cron expression, time zone or unconfigured target"
+// @Router /v1/report-schedules [post]
+func (h *ReportScheduleHandler) Create(c *gin.Context) {
+	var input models.ReportScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	created, err := h.service.CreateSchedule(c.Request.Context(), input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create report schedule")
+		return
+	}
+	helpers.Created(c, "Report schedule created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a report schedule
+// @Description Replace a report schedule; its next run is recomputed from now (owner only)
+// @Tags Report Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Report schedule ID"
+// @Param schedule body models.ReportScheduleInput true "Updated report schedule"
+// @Success 200 {object} helpers.Response{data=models.ReportSchedule} "Report schedule updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body, cron expression, time zone or unconfigured target"
+// @Failure 404 {object} helpers.Problem "Report schedule not found"
+// @Router /v1/report-schedules/{id} [put]
+func (h *ReportScheduleHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid report schedule ID")
+		return
+	}
+
+	var input models.ReportScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	updated, err := h.service.UpdateSchedule(c.Request.Context(), id, input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update report schedule")
+		return
+	}
+	helpers.OK(c, "Report schedule updated successfully", updated)
+}
+
+// Delete godoc
+// @Summary Delete a report schedule
+// @Description Delete a report schedule and its run history. Uploaded files are kept (owner only).
+// @Tags Report Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Report schedule ID"
+// @Success 200 {object} helpers.Response "Report schedule deleted successfully"
+// @Failure 400 {object} helpers.Problem "Invalid report schedule ID"
+// @Failure 404 {object} helpers.Problem "Report schedule not found"
+// @Router /v1/report-schedules/{id} [delete]
+func (h *ReportScheduleHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid report schedule ID")
+		return
+	}
+
+	if err := h.service.DeleteSchedule(c.Request.Context(), id); err != nil {
+		helpers.ServiceError(c, err, "Failed to delete report schedule")
+		return
+	}
+	helpers.OK(c, "Report schedule deleted successfully", nil)
+}
+
+// Run godoc
+// @Summary Run a report schedule now
+// @Description Render and upload the schedule's report immediately without moving its next scheduled run. A failed upload is returned as a run with status failed and its error (owner only).
+// @Tags Report Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Report schedule ID"
+// @Success 200 {object} helpers.Response{data=models.ReportRun} "Report run finished"
+// @Failure 400 {object} helpers.Problem "Invalid report schedule ID"
+// @Failure 404 {object} helpers.Problem "Report schedule not found"
+// @Router /v1/report-schedules/{id}/run [post]
+func (h *ReportScheduleHandler) Run(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid report schedule ID")
+		return
+	}
+
+	run, err := h.service.RunSchedule(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to run report schedule")
+		return
+	}
+	if run.Status == models.ReportRunFailed {
+		helpers.OK(c, "Report run failed", run)
+		return
+	}
+	helpers.OK(c, "Report run succeeded", run)
+}
+
+// Runs godoc
+// @Summary Run history of a report schedule
+// @Description Paginated run history of a schedule, newest first (owner only)
+// @Tags Report Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Report schedule ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.ReportRun} "Report runs retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid report schedule ID"
+// @Failure 404 {object} helpers.Problem "Report schedule not found"
+// @Router /v1/report-schedules/{id}/runs [get]
+func (h *ReportScheduleHandler) Runs(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid report schedule ID")
+		return
+	}
+
+	page, limit := helpers.ParsePagination(c)
+	result, err := h.service.GetRuns(c.Request.Context(), id, page, limit)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve report runs")
+		return
+	}
+	helpers.Paginated(c, "Report runs retrieved successfully", result.Data, helpers.PaginationMeta{
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	})
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"retail-core-api/chaos"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"sort"
+)
+
+// publishedSchema is a request or response body published under /docs/schemas/
+type publishedSchema struct {
+	model     interface{}
+	direction string
+}
+
+// publishedSchemas lists every body the API accepts or emits. A handler that
+// starts accepting or returning a new type must add it here so clients and the
+// contract check can see it.
+var publishedSchemas = []publishedSchema{
+	// Envelopes
+	{helpers.Response{}, helpers.SchemaResponse},
+	{helpers.PaginatedResponse{}, helpers.SchemaResponse},
+	{helpers.ErrorResponse{}, helpers.SchemaResponse},
+	{helpers.Problem{}, helpers.SchemaResponse},
+
+	// Request bodies
+	{models.CategoryInput{}, helpers.SchemaRequest},
+	{models.CategoryRuleInput{}, helpers.SchemaRequest},
+	{models.CategorySuggestionAcceptInput{}, helpers.SchemaRequest},
+	{models.ChangesetInput{}, helpers.SchemaRequest},
+	{models.ChangesetItemInput{}, helpers.SchemaRequest},
+	{models.ChangesetScheduleInput{}, helpers.SchemaRequest},
+	{models.CheckoutRequest{}, helpers.SchemaRequest},
+	{models.CountInput{}, helpers.SchemaRequest},
+	{models.CycleCountScheduleInput{}, helpers.SchemaRequest},
+	{models.LoginInput{}, helpers.SchemaRequest},
+	{models.PriceTiersInput{}, helpers.SchemaRequest},
+	{models.ProductInput{}, helpers.SchemaRequest},
+	{models.ProductRelationInput{}, helpers.SchemaRequest},
+	{models.PromotionInput{}, helpers.SchemaRequest},
+	{models.PurchaseOrderInput{}, helpers.SchemaRequest},
+	{models.QueueCallInput{}, helpers.SchemaRequest},
+	{models.ReceiveInput{}, helpers.SchemaRequest},
+	{models.ReportScheduleInput{}, helpers.SchemaRequest},
+	{models.ReviewInput{}, helpers.SchemaRequest},
+	{models.ScheduledPriceInput{}, helpers.SchemaRequest},
+	{models.StockAdjustmentInput{}, helpers.SchemaRequest},
+	{models.StockRepairInput{}, helpers.SchemaRequest},
+	{models.StockTransferInput{}, helpers.SchemaRequest},
+	{models.StocktakeInput{}, helpers.SchemaRequest},
+	{models.StoreInput{}, helpers.SchemaRequest},
+	{models.SupplierInput{}, helpers.SchemaRequest},
+	{models.TenantInput{}, helpers.SchemaRequest},
+	{models.TransactionRepairInput{}, helpers.SchemaRequest},
+	{models.TranslationInput{}, helpers.SchemaRequest},
+	{models.UserInput{}, helpers.SchemaRequest},
+	{models.WebhookInput{}, helpers.SchemaRequest},
+	{chaos.Settings{}, helpers.SchemaRequest},
+
+	// Response payloads (the data field of the envelope)
+	{models.AuditLog{}, helpers.SchemaResponse},
+	{models.BestSellersReport{}, helpers.SchemaResponse},
+	{models.CatalogChangeset{}, helpers.SchemaResponse},
+	{models.CatalogChangesetItem{}, helpers.SchemaResponse},
+	{models.Category{}, helpers.SchemaResponse},
+	{models.CategoryRule{}, helpers.SchemaResponse},
+	{models.CategorySalesReport{}, helpers.SchemaResponse},
+	{models.CategorySuggestion{}, helpers.SchemaResponse},
+	{models.CategorySuggestionRun{}, helpers.SchemaResponse},
+	{models.CategoryTreeNode{}, helpers.SchemaResponse},
+	{models.ConsignmentSettlementReport{}, helpers.SchemaResponse},
+	{models.CountSession{}, helpers.SchemaResponse},
+	{models.CountSessionItem{}, helpers.SchemaResponse},
+	{models.CountVarianceReport{}, helpers.SchemaResponse},
+	{models.CycleCountCompliance{}, helpers.SchemaResponse},
+	{models.CycleCountSchedule{}, helpers.SchemaResponse},
+	{models.DashboardStats{}, helpers.SchemaResponse},
+	{models.DataQualityIssue{}, helpers.SchemaResponse},
+	{models.DataQualityReport{}, helpers.SchemaResponse},
+	{models.GoodsReceipt{}, helpers.SchemaResponse},
+	{models.HourlySalesReport{}, helpers.SchemaResponse},
+	{models.InventoryValuation{}, helpers.SchemaResponse},
+	{models.LoginResponse{}, helpers.SchemaResponse},
+	{models.LowStockProduct{}, helpers.SchemaResponse},
+	{models.PriceChange{}, helpers.SchemaResponse},
+	{models.PriceTier{}, helpers.SchemaResponse},
+	{models.Product{}, helpers.SchemaResponse},
+	{models.ProductChangeRequest{}, helpers.SchemaResponse},
+	{models.ProductImportResult{}, helpers.SchemaResponse},
+	{models.ProductRelation{}, helpers.SchemaResponse},
+	{models.ProfitReport{}, helpers.SchemaResponse},
+	{models.Promotion{}, helpers.SchemaResponse},
+	{models.PromotionPerformanceReport{}, helpers.SchemaResponse},
+	{models.PurchaseOrder{}, helpers.SchemaResponse},
+	{models.QueueStatus{}, helpers.SchemaResponse},
+	{models.ReceiptShareLink{}, helpers.SchemaResponse},
+	{models.ReorderSuggestionReport{}, helpers.SchemaResponse},
+	{models.ReportRun{}, helpers.SchemaResponse},
+	{models.ReportSchedule{}, helpers.SchemaResponse},
+	{models.ReportSummary{}, helpers.SchemaResponse},
+	{models.SalesBucket{}, helpers.SchemaResponse},
+	{models.SalesReport{}, helpers.SchemaResponse},
+	{models.ScheduledPrice{}, helpers.SchemaResponse},
+	{models.SchemaMigration{}, helpers.SchemaResponse},
+	{models.SchemaVersion{}, helpers.SchemaResponse},
+	{models.StockConsistencyReport{}, helpers.SchemaResponse},
+	{models.StockMovement{}, helpers.SchemaResponse},
+	{models.StockRepairResult{}, helpers.SchemaResponse},
+	{models.StockTransfer{}, helpers.SchemaResponse},
+	{models.Store{}, helpers.SchemaResponse},
+	{models.StoreSalesReport{}, helpers.SchemaResponse},
+	{models.StoreStock{}, helpers.SchemaResponse},
+	{models.Supplier{}, helpers.SchemaResponse},
+	{models.SyncRun{}, helpers.SchemaResponse},
+	{models.SyncStatus{}, helpers.SchemaResponse},
+	{models.Tenant{}, helpers.SchemaResponse},
+	{models.TenantAPIKey{}, helpers.SchemaResponse},
+	{models.Transaction{}, helpers.SchemaResponse},
+	{models.TransactionConsistencyReport{}, helpers.SchemaResponse},
+	{models.TransactionListItem{}, helpers.SchemaResponse},
+	{models.TransactionRepairResult{}, helpers.SchemaResponse},
+	{models.Translation{}, helpers.SchemaResponse},
+	{models.User{}, helpers.SchemaResponse},
+	{models.Webhook{}, helpers.SchemaResponse},
+	{models.WebhookDelivery{}, helpers.SchemaResponse},
+	{chaos.Stats{}, helpers.SchemaResponse},
+}
+
+// SchemaIndexEntry describes one published schema
+type SchemaIndexEntry struct {
+	Name      string `json:"name" example:"models.Product"`
+	Direction string `json:"direction" example:"response" enums:"request,response"`
+	URL       string `json:"url" example:"/docs/schemas/models.Product.json"`
+}
+
+// SchemaIndex lists the published schemas
+type SchemaIndex struct {
+	Schemas []SchemaIndexEntry `json:"schemas"`
+}
+
+// buildSchemas renders every published schema, keyed by name
+func buildSchemas() (map[string][]byte, []byte) {
+	schemas := make(map[string][]byte, len(publishedSchemas))
+	index := SchemaIndex{Schemas: make([]SchemaIndexEntry, 0, len(publishedSchemas))}
+	for _, p := range publishedSchemas {
+		name := helpers.SchemaName(p.model)
+		doc := helpers.JSONSchema(p.model, p.direction)
+		doc["$id"] = schemasPath + name + ".json"
+		doc["title"] = name
+
+		body, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			panic("rendering schema " + name + ": " + err.Error())
+		}
+		schemas[name] = body
+		index.Schemas = append(index.Schemas, SchemaIndexEntry{
+			Name:      name,
+			Direction: p.direction,
+			URL:       schemasPath + name + ".json",
+		})
+	}
+	sort.Slice(index.Schemas, func(i, j int) bool { return index.Schemas[i].Name < index.Schemas[j].Name })
+
+	indexBody, _ := json.MarshalIndent(index, "", "  ")
+	return schemas, indexBody
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StockStreamHandler handles the real-time stock stream
+type StockStreamHandler struct {
+	service services.StockStreamService
+}
+
+// NewStockStreamHandler creates a new stock stream handler instance
+func NewStockStreamHandler(service services.StockStreamService) *StockStreamHandler {
+	return &StockStreamHandler{service: service}
+}
+
+// Stream godoc
+// @Summary Stream stock changes
+// @Description Server-Sent Events stream of stock changes, so POS terminals keep stock in sync without polling. Each change is a "stock" event whose id is the stock movement ID, plus a "ping" event every 30 seconds. A client reconnecting with the Last-Event-ID header is sent the changes it missed; when more than 1000 were missed it receives a "reset" event and should reload stock from /v1/products.
+// @Tags Inventory
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param store_id query int false "Only changes at this store"
+// @Param Last-Event-ID header int false "ID of the last stock event received"
+// @Success 200 {object} models.StockUpdate "Stream of stock events"
+// @Failure 400 {object} helpers.Problem "Invalid store ID or Last-Event-ID"
+// @Router /v1/stream/stock [get]
+func (h *StockStreamHandler) Stream(c *gin.Context) {
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
+	// Subscribe before replaying, so nothing committed in between is lost
+	updates, unsubscribe := h.service.Subscribe()
+	defer unsubscribe()
+
+	var missed []models.StockUpdate
+	lastID := 0
+	if raw := c.GetHeader("Last-Event-ID"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			helpers.BadRequest(c, "Invalid Last-Event-ID")
+			return
+		}
+		if missed, err = h.service.Since(c.Request.Context(), id); err != nil {
+			helpers.ServiceError(c, err, "Failed to retrieve stock changes")
+			return
+		}
+		lastID = id
+	}
+
+	keepAlive := time.NewTicker(queueKeepAlive)
+	defer keepAlive.Stop()
+
+	clearWriteDeadline(c)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(200)
+
+	replayed := make(map[int]bool, len(missed))
+	if len(missed) >= services.StockStreamReplayLimit {
+		c.SSEvent("reset", "too many missed changes, reload stock")
+	} else {
+		for _, u := range missed {
+			replayed[u.MovementID] = true
+			if storeID == 0 || u.StoreID == storeID {
+				writeStockEvent(c.Writer, u)
+			}
+		}
+	}
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				// Fell too far behind; the client reconnects and catches up
+				return false
+			}
+			if replayed[update.MovementID] || update.MovementID <= lastID {
+				return true
+			}
+			if storeID == 0 || update.StoreID == storeID {
+				writeStockEvent(w, update)
+			}
+			return true
+		case <-keepAlive.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		case <-helpers.ShuttingDown(c.Request.Context()):
+			return false
+		}
+	})
+}
+
+// writeStockEvent writes a stock change as a "stock" event with the movement
+// ID as event ID, so a reconnecting client can resume from it
+func writeStockEvent(w io.Writer, update models.StockUpdate) {
+	data, _ := json.Marshal(update)
+	fmt.Fprintf(w, "id: %d\nevent: stock\ndata: %s\n\n", update.MovementID, data)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StockTransferHandler handles stock transfers between stores
+type StockTransferHandler struct {
+	service services.StockTransferService
+}
+
+// NewStockTransferHandler creates a new stock transfer handler instance
+func NewStockTransferHandler(service services.StockTransferService) *StockTransferHandler {
+	return &StockTransferHandler{service: service}
+}
+
+// parseStockTransferID reads the stock transfer ID path parameter
+func parseStockTransferID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid stock transfer ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List godoc
+// @Summary List stock transfers
+// @Description List stock transfers between stores, newest first (items are not included)
+// @Tags Stock Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(in_transit, received, cancelled)
+// @Param store_id query int false "Only transfers from or to this store"
+// @Success 200 {object} helpers.Response{data=[]models.StockTransfer} "Stock transfers retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid status or store ID"
+// @Router /v1/stock-transfers [get]
+func (h *StockTransferHandler) List(c *gin.Context) {
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
+	transfers, err := h.service.GetTransfers(c.Request.Context(), models.StockTransferParams{
+		Status:  strings.TrimSpace(c.Query("status")),
+		StoreID: storeID,
+	})
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve stock transfers")
+		return
+	}
+	helpers.OK(c, "Stock transfers retrieved successfully", transfers)
+}
+
+// GetByID godoc
+// @Summary Get a stock transfer
+// @Description Retrieve a stock transfer with its lines
+// @Tags Stock Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock transfer ID"
+// @Success 200 {object} helpers.Response{data=models.StockTransfer} "Stock transfer retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Stock transfer not found"
+// @Router /v1/stock-transfers/{id} [get]
+func (h *StockTransferHandler) GetByID(c *gin.Context) {
+	id, ok := parseStockTransferID(c)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.service.GetTransferByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve stock transfer")
+		return
+	}
+	if transfer == nil {
+		helpers.NotFound(c, "Stock transfer not found")
+		return
+	}
+	helpers.OK(c, "Stock transfer retrieved successfully", transfer)
+}
+
+// Create godoc
+// @Summary Send stock to another store
+// @Description Dispatch products from one store to another. The quantities leave the source store immediately (transfer_out ledger entries) and stay in transit, counted in neither store, until the destination confirms receipt.
+// @Tags Stock Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param transfer body models.StockTransferInput true "Stock transfer"
+// @Success 201 {object} helpers.Response{data=models.StockTransfer} "Stock transfer created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body, store, product or insufficient stock"
+// @Router /v1/stock-transfers [post]
+func (h *StockTransferHandler) Create(c *gin.Context) {
+	var input models.StockTransferInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	transfer, err := h.service.CreateTransfer(c.Request.Context(), input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create stock transfer")
+		return
+	}
+	helpers.Created(c, "Stock transfer created successfully", transfer)
+}
+
+// Receive godoc
+// @Summary Confirm receipt of a stock transfer
+// @Description Confirm that an in-transit transfer arrived; the quantities are added to the destination store (transfer_in ledger entries)
+// @Tags Stock Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock transfer ID"
+// @Success 200 {object} helpers.Response{data=models.StockTransfer} "Stock transfer received"
+// @Failure 404 {object} helpers.Problem "Stock transfer not found"
+// @Failure 409 {object} helpers.Problem "Transfer is no longer in transit"
+// @Router /v1/stock-transfers/{id}/receive [post]
+func (h *StockTransferHandler) Receive(c *gin.Context) {
+	id, ok := parseStockTransferID(c)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.service.ReceiveTransfer(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to receive stock transfer")
+		return
+	}
+	helpers.OK(c, "Stock transfer received", transfer)
+}
+
+// Cancel godoc
+// @Summary Cancel a stock transfer
+// @Description Cancel an in-transit transfer; the quantities return to the source store (owner only)
+// @Tags Stock Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock transfer ID"
+// @Success 200 {object} helpers.Response{data=models.StockTransfer} "Stock transfer cancelled"
+// @Failure 404 {object} helpers.Problem "Stock transfer not found"
+// @Failure 409 {object} helpers.Problem "Transfer is no longer in transit"
+// @Router /v1/stock-transfers/{id}/cancel [post]
+func (h *StockTransferHandler) Cancel(c *gin.Context) {
+	id, ok := parseStockTransferID(c)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.service.CancelTransfer(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to cancel stock transfer")
+		return
+	}
+	helpers.OK(c, "Stock transfer cancelled", transfer)
+}
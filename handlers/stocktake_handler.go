@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StocktakeHandler handles spot-check sampling and count session endpoints
+type StocktakeHandler struct {
+	service services.StocktakeService
+}
+
+// NewStocktakeHandler creates a new stocktake handler instance
+func NewStocktakeHandler(service services.StocktakeService) *StocktakeHandler {
+	return &StocktakeHandler{service: service}
+}
+
+// parseSessionID reads the count session ID path parameter
+func parseSessionID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid count session ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// SpotCheckSample godoc
+// @Summary Get today's spot-check sample
+// @Description Draw a random sample of active products weighted by stock value and 30-day sales velocity, and open it as a spot_check count session. While today's spot-check session is open it is returned instead of drawing a new sample.
+// @Tags Stocktake
+// @Produce json
+// @Security BearerAuth
+// @Param size query int false "Number of products to sample (1-100)" default(20)
+// @Success 200 {object} helpers.Response{data=models.CountSession} "Spot-check sample retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid size or no products to sample"
+// @Router /v1/inventory/spot-check-sample [get]
+func (h *StocktakeHandler) SpotCheckSample(c *gin.Context) {
+	size := services.SpotCheckDefaultSize
+	if raw := c.Query("size"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			helpers.BadRequest(c, "Invalid size")
+			return
+		}
+		size = parsed
+	}
+
+	session, err := h.service.GetSpotCheckSample(c.Request.Context(), size, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to draw spot-check sample")
+		return
+	}
+	helpers.OK(c, "Spot-check sample retrieved successfully", session)
+}
+
+// CreateStocktake godoc
+// @Summary Open a stocktake session
+// @Description Open a stocktake count session at a store (default store when store_id is omitted) for every active product, narrowed to a category and/or a product list. Expected quantities are the store's stock; completing the session posts the variances to that store. Only one stocktake can be open per store (owner only).
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param stocktake body models.StocktakeInput true "Stocktake scope"
+// @Success 201 {object} helpers.Response{data=models.CountSession} "Stocktake session created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid store or product, or no products to count"
+// @Failure 409 {object} helpers.Problem "A stocktake is already open"
+// @Router /v1/inventory/count-sessions [post]
+func (h *StocktakeHandler) CreateStocktake(c *gin.Context) {
+	var input models.StocktakeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	session, err := h.service.CreateStocktake(c.Request.Context(), input, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create stocktake session")
+		return
+	}
+	helpers.Created(c, "Stocktake session created successfully", session)
+}
+
+// ListSessions godoc
+// @Summary List count sessions
+// @Description Retrieve stocktake count sessions, newest first
+// @Tags Stocktake
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(open, completed, cancelled)
+// @Param assigned_to query int false "Filter by assigned user ID"
+// @Success 200 {object} helpers.Response{data=[]models.CountSession} "Count sessions retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid status"
+// @Router /v1/inventory/count-sessions [get]
+func (h *StocktakeHandler) ListSessions(c *gin.Context) {
+	assignedTo := 0
+	if raw := c.Query("assigned_to"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			helpers.BadRequest(c, "Invalid assigned_to")
+			return
+		}
+		assignedTo = parsed
+	}
+
+	sessions, err := h.service.GetSessions(c.Request.Context(), c.Query("status"), assignedTo)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve count sessions")
+		return
+	}
+	helpers.OK(c, "Count sessions retrieved successfully", sessions)
+}
+
+// GetSession godoc
+// @Summary Get a count session
+// @Description Retrieve a count session with its items, expected and counted quantities
+// @Tags Stocktake
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Count session ID"
+// @Success 200 {object} helpers.Response{data=models.CountSession} "Count session retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Count session not found"
+// @Router /v1/inventory/count-sessions/{id} [get]
+func (h *StocktakeHandler) GetSession(c *gin.Context) {
+	id, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.GetSessionByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve count session")
+		return
+	}
+	if session == nil {
+		helpers.NotFound(c, "Count session not found")
+		return
+	}
+	helpers.OK(c, "Count session retrieved successfully", session)
+}
+
+// VarianceReport godoc
+// @Summary Count session variance report
+// @Description Shrinkage and overage of a count session valued at current cost price, with every counted line that differs from the expected quantity (largest value first). For an open session this previews what completing it would post.
+// @Tags Stocktake
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Count session ID"
+// @Success 200 {object} helpers.Response{data=models.CountVarianceReport} "Variance report retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Count session not found"
+// @Router /v1/inventory/count-sessions/{id}/variance [get]
+func (h *StocktakeHandler) VarianceReport(c *gin.Context) {
+	id, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetVarianceReport(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve variance report")
+		return
+	}
+	helpers.OK(c, "Variance report retrieved successfully", report)
+}
+
+// RecordCount godoc
+// @Summary Record a product count
+// @Description Store the counted shelf quantity of a product in an open count session. Counting again overwrites the previous count.
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Count session ID"
+// @Param product_id path int true "Product ID"
+// @Param count body models.CountInput true "Counted quantity"
+// @Success 200 {object} helpers.Response{data=models.CountSessionItem} "Count recorded successfully"
+// @Failure 400 {object} helpers.Problem "Invalid quantity"
+// @Failure 404 {object} helpers.Problem "Count session or product not found"
+// @Failure 409 {object} helpers.Problem "Session not open"
+// @Router /v1/inventory/count-sessions/{id}/items/{product_id} [put]
+func (h *StocktakeHandler) RecordCount(c *gin.Context) {
+	id, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+	productID, err := strconv.Atoi(c.Param("product_id"))
+	if err != nil || productID <= 0 {
+		helpers.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	var input models.CountInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	item, err := h.service.RecordCount(c.Request.Context(), id, productID, *input.CountedQty, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to record count")
+		return
+	}
+	helpers.OK(c, "Count recorded successfully", item)
+}
+
+// CompleteSession godoc
+// @Summary Complete a count session
+// @Description Close a fully counted session and post each variance to stock as a count_correction ledger entry
+// @Tags Stocktake
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Count session ID"
+// @Success 200 {object} helpers.Response{data=models.CountSession} "Count session completed successfully"
+// @Failure 404 {object} helpers.Problem "Count session not found"
+// @Failure 409 {object} helpers.Problem "Session not open or items not counted"
+// @Router /v1/inventory/count-sessions/{id}/complete [post]
+func (h *StocktakeHandler) CompleteSession(c *gin.Context) {
+	id, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.CompleteSession(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to complete count session")
+		return
+	}
+	helpers.OK(c, "Count session completed successfully", session)
+}
+
+// CancelSession godoc
+// @Summary Cancel a count session
+// @Description Close an open count session without adjusting stock
+// @Tags Stocktake
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Count session ID"
+// @Success 200 {object} helpers.Response{data=models.CountSession} "Count session cancelled successfully"
+// @Failure 404 {object} helpers.Problem "Count session not found"
+// @Failure 409 {object} helpers.Problem "Session not open"
+// @Router /v1/inventory/count-sessions/{id}/cancel [post]
+func (h *StocktakeHandler) CancelSession(c *gin.Context) {
+	id, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.CancelSession(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to cancel count session")
+		return
+	}
+	helpers.OK(c, "Count session cancelled successfully", session)
+}
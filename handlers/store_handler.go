@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StoreHandler handles HTTP requests for store locations
+type StoreHandler struct {
+	service      services.StoreService
+	auditService services.AuditService
+}
+
+// NewStoreHandler creates a new store handler instance
+func NewStoreHandler(service services.StoreService, auditService services.AuditService) *StoreHandler {
+	return &StoreHandler{service: service, auditService: auditService}
+}
+
+// storeIDQuery parses the optional store_id filter. It returns 0 when the
+// filter is absent and writes a 400 response when it is invalid.
+func storeIDQuery(c *gin.Context) (int, bool) {
+	raw := c.Query("store_id")
+	if raw == "" {
+		return 0, true
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid store ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List godoc
+// @Summary Get all stores
+// @Description Retrieve all store locations, the default store first
+// @Tags Stores
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.Store} "Successfully retrieved stores"
+// @Router /v1/stores [get]
+func (h *StoreHandler) List(c *gin.Context) {
+	stores, err := h.service.GetAllStores(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve stores")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved stores", stores)
+}
+
+// GetByID godoc
+// @Summary Get a store by ID
+// @Description Retrieve details of a specific store
+// @Tags Stores
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Store ID"
+// @Success 200 {object} helpers.Response{data=models.Store} "Store retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid store ID"
+// @Failure 404 {object} helpers.Problem "Store not found"
+// @Router /v1/stores/{id} [get]
+func (h *StoreHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid store ID")
+		return
+	}
+
+	store, err := h.service.GetStoreByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve store")
+		return
+	}
+	if store == nil {
+		helpers.NotFound(c, "Store not found")
+		return
+	}
+	helpers.OK(c, "Store retrieved successfully", store)
+}
+
+// Create godoc
+// @Summary Create a store
+// @Description Add a new store location (owner only). It starts without stock; receive or transfer goods into it.
+// @Tags Stores
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param store body models.StoreInput true "Store"
+// @Success 201 {object} helpers.Response{data=models.Store} "Store created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or missing fields"
+// @Failure 409 {object} helpers.Problem "Store code in use"
+// @Router /v1/stores [post]
+func (h *StoreHandler) Create(c *gin.Context) {
+	var input models.StoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	created, err := h.service.CreateStore(c.Request.Context(), input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create store")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionCreate, models.AuditEntityStore, created.ID, nil, created)
+	helpers.Created(c, "Store created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a store
+// @Description Update an existing store by its ID (owner only). Inactive stores cannot check out; the default store cannot be deactivated.
+// @Tags Stores
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Store ID"
+// @Param store body models.StoreInput true "Updated store"
+// @Success 200 {object} helpers.Response{data=models.Store} "Store updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or missing fields"
+// @Failure 404 {object} helpers.Problem "Store not found"
+// @Failure 409 {object} helpers.Problem "Store code in use"
+// @Router /v1/stores/{id} [put]
+func (h *StoreHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid store ID")
+		return
+	}
+
+	var input models.StoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	before, _ := h.service.GetStoreByID(c.Request.Context(), id)
+
+	updated, err := h.service.UpdateStore(c.Request.Context(), id, input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update store")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionUpdate, models.AuditEntityStore, id, before, updated)
+	helpers.OK(c, "Store updated successfully", updated)
+}
+
+// Stock godoc
+// @Summary Get the stock of a store
+// @Description Retrieve every product a store holds or has held with its stock at that store
+// @Tags Stores
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Store ID"
+// @Success 200 {object} helpers.Response{data=[]models.StoreStock} "Store stock retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid store ID"
+// @Failure 404 {object} helpers.Problem "Store not found"
+// @Router /v1/stores/{id}/stock [get]
+func (h *StoreHandler) Stock(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid store ID")
+		return
+	}
+
+	stock, err := h.service.GetStoreStock(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve store stock")
+		return
+	}
+	helpers.OK(c, "Store stock retrieved successfully", stock)
+}
+
+// SalesReport godoc
+// @Summary Consolidated sales report across stores
+// @Description Total revenue and transactions over every store for a date range with a breakdown per store, highest revenue first (owner only)
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} helpers.Response{data=models.StoreSalesReport} "Successfully retrieved store report"
+// @Failure 400 {object} helpers.Problem "Missing or invalid start_date or end_date"
+// @Router /v1/report/stores [get]
+func (h *StoreHandler) SalesReport(c *gin.Context) {
+	startDate := strings.TrimSpace(c.Query("start_date"))
+	endDate := strings.TrimSpace(c.Query("end_date"))
+
+	report, err := h.service.GetSalesReport(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve store report")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved store report", report)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"database/sql"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupplierHandler handles HTTP requests for suppliers
+type SupplierHandler struct {
+	service      services.SupplierService
+	auditService services.AuditService
+}
+
+// NewSupplierHandler creates a new supplier handler instance
+func NewSupplierHandler(service services.SupplierService, auditService services.AuditService) *SupplierHandler {
+	return &SupplierHandler{service: service, auditService: auditService}
+}
+
+// supplierFromInput maps a SupplierInput to a Supplier
+func supplierFromInput(input models.SupplierInput) models.Supplier {
+	return models.Supplier{
+		Name:        input.Name,
+		ContactName: input.ContactName,
+		Phone:       input.Phone,
+		Email:       input.Email,
+		Address:     input.Address,
+	}
+}
+
+// List godoc
+// @Summary Get all suppliers
+// @Description Retrieve all suppliers
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.Supplier} "Successfully retrieved suppliers"
+// @Router /v1/suppliers [get]
+func (h *SupplierHandler) List(c *gin.Context) {
+	suppliers, err := h.service.GetAllSuppliers(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve suppliers")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved suppliers", suppliers)
+}
+
+// GetByID godoc
+// @Summary Get a supplier by ID
+// @Description Retrieve details of a specific supplier
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} helpers.Response{data=models.Supplier} "Supplier retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid supplier ID"
+// @Failure 404 {object} helpers.Problem "Supplier not found"
+// @Router /v1/suppliers/{id} [get]
+func (h *SupplierHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid supplier ID")
+		return
+	}
+
+	supplier, err := h.service.GetSupplierByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve supplier")
+		return
+	}
+	if supplier == nil {
+		helpers.NotFound(c, "Supplier not found")
+		return
+	}
+	helpers.OK(c, "Supplier retrieved successfully", supplier)
+}
+
+// Create godoc
+// @Summary Create a supplier
+// @Description Add a new supplier (owner only)
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param supplier body models.SupplierInput true "Supplier"
+// @Success 201 {object} helpers.Response{data=models.Supplier} "Supplier created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Router /v1/suppliers [post]
+func (h *SupplierHandler) Create(c *gin.Context) {
+	var input models.SupplierInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	created, err := h.service.CreateSupplier(c.Request.Context(), supplierFromInput(input))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create supplier")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionCreate, models.AuditEntitySupplier, created.ID, nil, created)
+	helpers.Created(c, "Supplier created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a supplier
+// @Description Update an existing supplier by its ID (owner only)
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Supplier ID"
+// @Param supplier body models.SupplierInput true "Updated supplier"
+// @Success 200 {object} helpers.Response{data=models.Supplier} "Supplier updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or validation error"
+// @Failure 404 {object} helpers.Problem "Supplier not found"
+// @Router /v1/suppliers/{id} [put]
+func (h *SupplierHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid supplier ID")
+		return
+	}
+
+	var input models.SupplierInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	before, _ := h.service.GetSupplierByID(c.Request.Context(), id)
+
+	updated, err := h.service.UpdateSupplier(c.Request.Context(), id, supplierFromInput(input))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update supplier")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionUpdate, models.AuditEntitySupplier, id, before, updated)
+	helpers.OK(c, "Supplier updated successfully", updated)
+}
+
+// Delete godoc
+// @Summary Delete a supplier
+// @Description Delete a supplier by its ID (owner only). Suppliers that still own consignment products cannot be deleted.
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} helpers.Response "Supplier deleted successfully"
+// @Failure 400 {object} helpers.Problem "Invalid supplier ID"
+// @Failure 404 {object} helpers.Problem "Supplier not found"
+// @Failure 409 {object} helpers.Problem "Supplier still owns consignment products"
+// @Router /v1/suppliers/{id} [delete]
+func (h *SupplierHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid supplier ID")
+		return
+	}
+
+	before, _ := h.service.GetSupplierByID(c.Request.Context(), id)
+
+	err = h.service.DeleteSupplier(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			helpers.NotFound(c, "Supplier not found")
+			return
+		}
+		helpers.ServiceError(c, err, "Failed to delete supplier")
+		return
+	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionDelete, models.AuditEntitySupplier, id, before, nil)
+	helpers.OK(c, "Supplier deleted successfully", nil)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncHandler handles HTTP requests for replication to the upstream instance
+type SyncHandler struct {
+	service services.SyncService
+}
+
+// NewSyncHandler creates a new sync handler instance
+func NewSyncHandler(service services.SyncService) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// Status godoc
+// @Summary Get sync status
+// @Description Replication of local sales, voids and stock adjustments to the upstream instance: whether it is configured, the latest run, pending/synced/skipped/conflict/failed counts per entity type and the latest conflicts and failures (owner only)
+// @Tags Sync
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.SyncStatus} "Sync status retrieved successfully"
+// @Router /v1/sync/status [get]
+func (h *SyncHandler) Status(c *gin.Context) {
+	status, err := h.service.GetStatus(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve sync status")
+		return
+	}
+	helpers.OK(c, "Sync status retrieved successfully", status)
+}
+
+// Run godoc
+// @Summary Run sync now
+// @Description Replicate pending sales, voids and stock adjustments now instead of waiting for the next background run (owner only). A run that could not reach upstream returns 200 with the error in the run.
+// @Tags Sync
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=models.SyncRun} "Sync run finished"
+// @Failure 400 {object} helpers.Problem "Sync is not configured"
+// @Failure 409 {object} helpers.Problem "A sync run is already in progress"
+// @Router /v1/sync/run [post]
+func (h *SyncHandler) Run(c *gin.Context) {
+	run, err := h.service.Run()
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to run sync")
+		return
+	}
+	helpers.OK(c, "Sync run finished", run)
+}
+
+// Retry godoc
+// @Summary Retry sync conflicts
+// @Description Queue every conflict (e.g. a sale whose product was missing upstream) for the next run, once the cause has been fixed upstream (owner only)
+// @Tags Sync
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=map[string]int} "Conflicts queued for retry"
+// @Router /v1/sync/retry [post]
+func (h *SyncHandler) Retry(c *gin.Context) {
+	n, err := h.service.RetryConflicts(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retry conflicts")
+		return
+	}
+	helpers.OK(c, "Conflicts queued for retry", gin.H{"retried": n})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantHandler handles the platform endpoints managing tenants
+type TenantHandler struct {
+	service services.TenantService
+}
+
+// NewTenantHandler creates a new tenant handler instance
+func NewTenantHandler(service services.TenantService) *TenantHandler {
+	return &TenantHandler{service: service}
+}
+
+// List godoc
+// @Summary Get all tenants
+// @Description Retrieve every tenant of a multi-tenant deployment (platform key required)
+// @Tags Platform
+// @Produce json
+// @Security PlatformKey
+// @Success 200 {object} helpers.Response{data=[]models.Tenant} "Successfully retrieved tenants"
+// @Failure 401 {object} helpers.Problem "Invalid platform key"
+// @Router /platform/tenants [get]
+func (h *TenantHandler) List(c *gin.Context) {
+	tenants, err := h.service.GetTenants(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve tenants")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved tenants", tenants)
+}
+
+// Create godoc
+// @Summary Create a tenant
+// @Description Create a tenant with its default store and first owner account (platform key required). The response carries the tenant's API key, which is not shown again.
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Security PlatformKey
+// @Param tenant body models.TenantInput true "Tenant"
+// @Success 201 {object} helpers.Response{data=models.TenantAPIKey} "Tenant created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body or invalid slug"
+// @Failure 401 {object} helpers.Problem "Invalid platform key"
+// @Failure 409 {object} helpers.Problem "Slug in use"
+// @Router /platform/tenants [post]
+func (h *TenantHandler) Create(c *gin.Context) {
+	var input models.TenantInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	created, err := h.service.CreateTenant(c.Request.Context(), input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create tenant")
+		return
+	}
+	helpers.Created(c, "Tenant created successfully", created)
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate a tenant's API key
+// @Description Issue a new API key for a tenant (platform key required). The previous key stops working immediately.
+// @Tags Platform
+// @Produce json
+// @Security PlatformKey
+// @Param id path int true "Tenant ID"
+// @Success 200 {object} helpers.Response{data=models.TenantAPIKey} "API key rotated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid tenant ID"
+// @Failure 401 {object} helpers.Problem "Invalid platform key"
+// @Failure 404 {object} helpers.Problem "Tenant not found"
+// @Router /platform/tenants/{id}/api-key [post]
+func (h *TenantHandler) RotateAPIKey(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	key, err := h.service.RotateAPIKey(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to rotate API key")
+		return
+	}
+	helpers.OK(c, "API key rotated successfully", key)
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
+	"net/http"
 	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/services"
@@ -22,30 +25,27 @@ func NewTransactionHandler(service services.TransactionService) *TransactionHand
 
 // Checkout godoc
 // @Summary Process checkout
-// @Description Process a checkout with items, payment method, optional discount and notes
+// @Description Process a checkout with items, payment method, optional discount and notes at a store (default store when store_id is omitted). Stock is taken from that store.
 // @Tags Transactions
 // @Accept json
 // @Produce json
 // @Param request body models.CheckoutRequest true "Checkout request"
+// @Param Accept-Language header string false "Language of error messages (en, id; default: en)"
 // @Success 201 {object} helpers.Response{data=models.Transaction} "Checkout successful"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid request body or validation error"
-// @Failure 500 {object} helpers.ErrorResponse "Server error or insufficient stock"
-// @Router /api/checkout [post]
+// @Failure 400 {object} helpers.Problem "Invalid request body, validation error or insufficient stock, with an error code"
+// @Failure 500 {object} helpers.Problem "Server error"
+// @Router /v1/checkout [post]
 func (h *TransactionHandler) Checkout(c *gin.Context) {
 	var req models.CheckoutRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
+	req.CashierID = currentActor(c).UserID
 
-	transaction, err := h.service.Checkout(req)
+	transaction, err := h.service.Checkout(c.Request.Context(), req)
 	if err != nil {
-		errMsg := err.Error()
-		if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "insufficient stock") || strings.Contains(errMsg, "cannot be empty") || strings.Contains(errMsg, "invalid") {
-			helpers.BadRequest(c, errMsg)
-			return
-		}
-		helpers.InternalError(c, errMsg)
+		helpers.ServiceError(c, err, "Failed to complete checkout")
 		return
 	}
 	helpers.Created(c, "Checkout successful", transaction)
@@ -60,16 +60,29 @@ func (h *TransactionHandler) Checkout(c *gin.Context) {
 // @Param limit query int false "Items per page (default: 20, max: 100)"
 // @Param start_date query string false "Start date filter (YYYY-MM-DD)"
 // @Param end_date query string false "End date filter (YYYY-MM-DD)"
-// @Success 200 {object} helpers.Response{data=models.PaginatedTransactions} "Successfully retrieved transactions"
-// @Router /api/transactions [get]
+// @Param receipt_no query string false "Search by receipt number (e.g. INV-20260208-0001, partial match)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.TransactionListItem} "Successfully retrieved transactions"
+// @Router /v1/transactions [get]
 func (h *TransactionHandler) ListTransactions(c *gin.Context) {
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
 	page, limit := helpers.ParsePagination(c)
-	startDate := strings.TrimSpace(c.Query("start_date"))
-	endDate := strings.TrimSpace(c.Query("end_date"))
+	params := models.TransactionListParams{
+		StartDate: strings.TrimSpace(c.Query("start_date")),
+		EndDate:   strings.TrimSpace(c.Query("end_date")),
+		ReceiptNo: strings.TrimSpace(c.Query("receipt_no")),
+		StoreID:   storeID,
+		Page:      page,
+		Limit:     limit,
+	}
 
-	result, err := h.service.GetAllTransactions(page, limit, startDate, endDate)
+	result, err := h.service.GetAllTransactions(c.Request.Context(), params)
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve transactions", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve transactions")
 		return
 	}
 	helpers.Paginated(c, "Successfully retrieved transactions", result.Data, helpers.PaginationMeta{
@@ -82,14 +95,14 @@ func (h *TransactionHandler) ListTransactions(c *gin.Context) {
 
 // GetTransactionByID godoc
 // @Summary Get a transaction by ID
-// @Description Retrieve details of a specific transaction including its items
+// @Description Retrieve details of a specific transaction including its items. Transactions archived to cold storage are retrieved from there with "archived": true, which takes longer.
 // @Tags Transactions
 // @Produce json
 // @Param id path int true "Transaction ID"
 // @Success 200 {object} helpers.Response{data=models.Transaction} "Transaction retrieved successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid transaction ID"
-// @Failure 404 {object} helpers.ErrorResponse "Transaction not found"
-// @Router /api/transactions/{id} [get]
+// @Failure 400 {object} helpers.Problem "Invalid transaction ID"
+// @Failure 404 {object} helpers.Problem "Transaction not found"
+// @Router /v1/transactions/{id} [get]
 func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -97,13 +110,9 @@ func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 		return
 	}
 
-	transaction, err := h.service.GetTransactionByID(id)
+	transaction, err := h.service.GetTransactionByID(c.Request.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			helpers.NotFound(c, err.Error())
-			return
-		}
-		helpers.InternalError(c, "Failed to retrieve transaction", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve transaction")
 		return
 	}
 	helpers.OK(c, "Transaction retrieved successfully", transaction)
@@ -115,10 +124,13 @@ func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 // @Tags Transactions
 // @Produce json
 // @Param id path int true "Transaction ID"
+// @Param Accept-Language header string false "Language of error messages (en, id; default: en)"
 // @Success 200 {object} helpers.Response "Transaction voided successfully"
-// @Failure 400 {object} helpers.ErrorResponse "Invalid transaction ID or already voided"
-// @Failure 500 {object} helpers.ErrorResponse "Server error"
-// @Router /api/transactions/{id}/void [patch]
+// @Failure 400 {object} helpers.Problem "Invalid transaction ID"
+// @Failure 404 {object} helpers.Problem "Transaction not found, with an error code"
+// @Failure 409 {object} helpers.Problem "Transaction already voided, with an error code"
+// @Failure 500 {object} helpers.Problem "Server error"
+// @Router /v1/transactions/{id}/void [patch]
 func (h *TransactionHandler) VoidTransaction(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
@@ -126,14 +138,9 @@ func (h *TransactionHandler) VoidTransaction(c *gin.Context) {
 		return
 	}
 
-	err = h.service.VoidTransaction(id)
+	err = h.service.VoidTransaction(c.Request.Context(), id)
 	if err != nil {
-		errMsg := err.Error()
-		if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "already voided") {
-			helpers.BadRequest(c, errMsg)
-			return
-		}
-		helpers.InternalError(c, errMsg)
+		helpers.ServiceError(c, err, "Failed to void transaction")
 		return
 	}
 	helpers.OK(c, "Transaction voided successfully", nil)
@@ -141,85 +148,320 @@ func (h *TransactionHandler) VoidTransaction(c *gin.Context) {
 
 // DailyReport godoc
 // @Summary Get today's sales report
-// @Description Retrieve the sales summary for today including revenue, transaction count, and best seller
+// @Description Retrieve the sales summary for today including revenue, transaction count, and best seller, consolidated over all stores unless store_id is given
 // @Tags Reports
 // @Produce json
+// @Param store_id query int false "Only this store (default: all stores)"
 // @Success 200 {object} helpers.Response{data=models.SalesReport} "Successfully retrieved today's report"
-// @Router /api/report/today [get]
+// @Router /v1/report/today [get]
 func (h *TransactionHandler) DailyReport(c *gin.Context) {
-	report, err := h.service.GetDailySalesReport()
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetDailySalesReport(c.Request.Context(), storeID)
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve daily report", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve daily report")
 		return
 	}
 	helpers.OK(c, "Successfully retrieved today's report", report)
 }
 
+// reportFilterQuery parses the filters shared by the sales reports:
+// start_date and end_date, store_id, cashier_id, category_id and
+// payment_method. It writes a 400 response and returns false when an ID is
+// invalid.
+func reportFilterQuery(c *gin.Context) (models.ReportFilter, bool) {
+	filter := models.ReportFilter{
+		StartDate:     strings.TrimSpace(c.Query("start_date")),
+		EndDate:       strings.TrimSpace(c.Query("end_date")),
+		PaymentMethod: strings.TrimSpace(c.Query("payment_method")),
+	}
+
+	var ok bool
+	if filter.StoreID, ok = storeIDQuery(c); !ok {
+		return filter, false
+	}
+	for _, p := range []struct {
+		name string
+		id   *int
+	}{{"cashier_id", &filter.CashierID}, {"category_id", &filter.CategoryID}} {
+		raw := c.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			helpers.BadRequest(c, p.name+" must be a positive number")
+			return filter, false
+		}
+		*p.id = id
+	}
+	return filter, true
+}
+
 // ReportByRange godoc
 // @Summary Get sales report by date range
-// @Description Retrieve the sales summary for a specific date range
+// @Description Retrieve the sales summary for a specific date range, consolidated over all stores unless store_id is given. With format=xlsx the report is downloaded as a workbook with Summary, Products and Days sheets.
 // @Tags Reports
 // @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param start_date query string true "Start date (YYYY-MM-DD)"
 // @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; product and category figures cover only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
+// @Param format query string false "Response format (default json)" Enums(json, xlsx)
 // @Success 200 {object} helpers.Response{data=models.SalesReport} "Successfully retrieved report"
-// @Failure 400 {object} helpers.ErrorResponse "Missing start_date or end_date"
-// @Router /api/report [get]
+// @Failure 400 {object} helpers.Problem "Missing start_date or end_date, or invalid format"
+// @Router /v1/report [get]
 func (h *TransactionHandler) ReportByRange(c *gin.Context) {
-	startDate := strings.TrimSpace(c.Query("start_date"))
-	endDate := strings.TrimSpace(c.Query("end_date"))
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
 
-	if startDate == "" || endDate == "" {
+	if filter.StartDate == "" || filter.EndDate == "" {
 		helpers.BadRequest(c, "start_date and end_date are required")
 		return
 	}
 
-	report, err := h.service.GetSalesReportByDateRange(startDate, endDate)
+	switch format := strings.ToLower(c.DefaultQuery("format", "json")); format {
+	case "json":
+	case models.ExportFormatXLSX:
+		h.exportReport(c, filter)
+		return
+	default:
+		helpers.BadRequest(c, "format must be 'json' or 'xlsx'")
+		return
+	}
+
+	report, err := h.service.GetSalesReportByDateRange(c.Request.Context(), filter)
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve report", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve report")
 		return
 	}
 	helpers.OK(c, "Successfully retrieved report", report)
 }
 
+// exportReport sends the sales report matching filter as an XLSX download.
+// The workbook is built in memory, so a failure still gets a JSON error.
+func (h *TransactionHandler) exportReport(c *gin.Context, filter models.ReportFilter) {
+	var buf bytes.Buffer
+	if err := h.service.ExportSalesReport(c.Request.Context(), &buf, filter); err != nil {
+		helpers.ServiceError(c, err, "Failed to export report")
+		return
+	}
+
+	filename := fmt.Sprintf("sales_report_%s_%s.xlsx", filter.StartDate, filter.EndDate)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, helpers.XLSXContentType, buf.Bytes())
+}
+
 // ReportSummary godoc
 // @Summary Get aggregated report summary
-// @Description Retrieve aggregated report summary with category breakdown for a date range
+// @Description Retrieve aggregated report summary with category breakdown for a date range, consolidated over all stores unless store_id is given
 // @Tags Reports
 // @Produce json
 // @Param start_date query string true "Start date (YYYY-MM-DD)"
 // @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; product and category figures cover only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
 // @Success 200 {object} helpers.Response{data=models.ReportSummary} "Successfully retrieved report summary"
-// @Failure 400 {object} helpers.ErrorResponse "Missing start_date or end_date"
-// @Router /api/report/summary [get]
+// @Failure 400 {object} helpers.Problem "Missing start_date or end_date"
+// @Router /v1/report/summary [get]
 func (h *TransactionHandler) ReportSummary(c *gin.Context) {
-	startDate := strings.TrimSpace(c.Query("start_date"))
-	endDate := strings.TrimSpace(c.Query("end_date"))
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
 
-	if startDate == "" || endDate == "" {
+	if filter.StartDate == "" || filter.EndDate == "" {
 		helpers.BadRequest(c, "start_date and end_date are required")
 		return
 	}
 
-	summary, err := h.service.GetReportSummary(startDate, endDate)
+	summary, err := h.service.GetReportSummary(c.Request.Context(), filter)
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve report summary", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve report summary")
 		return
 	}
 	helpers.OK(c, "Successfully retrieved report summary", summary)
 }
 
+// BestSellersReport godoc
+// @Summary Best sellers report
+// @Description The products sold most in a date range, ranked by quantity and then revenue, consolidated over all stores unless store_id is given. Revenue is net of transaction discounts.
+// @Tags Reports
+// @Produce json
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param limit query int false "Number of products (default 10, max 100)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; product and category figures cover only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
+// @Success 200 {object} helpers.Response{data=models.BestSellersReport} "Successfully retrieved best sellers report"
+// @Failure 400 {object} helpers.Problem "Missing or invalid start_date, end_date or limit"
+// @Router /v1/report/best-sellers [get]
+func (h *TransactionHandler) BestSellersReport(c *gin.Context) {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			helpers.BadRequest(c, "limit must be a number")
+			return
+		}
+		limit = n
+	}
+
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetBestSellersReport(c.Request.Context(), filter, limit)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve best sellers report")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved best sellers report", report)
+}
+
+// HourlySalesReport godoc
+// @Summary Sales report by hour
+// @Description Transaction count and revenue per hour of a day, for planning staff around peak times, consolidated over all stores unless store_id is given. All 24 hours are listed; hours are in the server's time zone.
+// @Tags Reports
+// @Produce json
+// @Param date query string false "Day (YYYY-MM-DD, default today)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; product and category figures cover only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
+// @Success 200 {object} helpers.Response{data=models.HourlySalesReport} "Successfully retrieved hourly sales report"
+// @Failure 400 {object} helpers.Problem "Invalid date"
+// @Router /v1/report/hourly [get]
+func (h *TransactionHandler) HourlySalesReport(c *gin.Context) {
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetHourlySalesReport(c.Request.Context(), strings.TrimSpace(c.Query("date")), filter)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve hourly sales report")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved hourly sales report", report)
+}
+
+// SalesTimeSeries godoc
+// @Summary Revenue time series
+// @Description Revenue, transactions and items sold per day, week (Monday to Sunday) or month of a date range, oldest first with zeros for periods without sales, ready for a dashboard chart. The first and last buckets are cut to the range. Consolidated over all stores unless store_id is given.
+// @Tags Reports
+// @Produce json
+// @Param granularity query string false "Bucket size (default day)" Enums(day, week, month)
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; items sold counts only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
+// @Success 200 {object} helpers.Response{data=[]models.SalesBucket} "Successfully retrieved sales time series"
+// @Failure 400 {object} helpers.Problem "Missing or invalid start_date, end_date or granularity"
+// @Router /v1/report/timeseries [get]
+func (h *TransactionHandler) SalesTimeSeries(c *gin.Context) {
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	buckets, err := h.service.GetSalesTimeSeries(c.Request.Context(), filter, strings.ToLower(strings.TrimSpace(c.Query("granularity"))))
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve sales time series")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved sales time series", buckets)
+}
+
+// ProfitReport godoc
+// @Summary Gross profit report
+// @Description Revenue, cost of goods sold and gross margin per product and per category for a date range (owner only). Transaction discounts are spread over the lines; consigned products are costed at the supplier payable.
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; product and category figures cover only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
+// @Success 200 {object} helpers.Response{data=models.ProfitReport} "Successfully retrieved profit report"
+// @Failure 400 {object} helpers.Problem "Missing or invalid start_date or end_date"
+// @Router /v1/report/profit [get]
+func (h *TransactionHandler) ProfitReport(c *gin.Context) {
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetProfitReport(c.Request.Context(), filter)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve profit report")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved profit report", report)
+}
+
+// CategorySalesReport godoc
+// @Summary Sales report by category
+// @Description Quantity sold, revenue and share of revenue per category for a date range, consolidated over all stores unless store_id is given. Transaction discounts are spread over the lines; products count under their current category.
+// @Tags Reports
+// @Produce json
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param store_id query int false "Only this store (default: all stores)"
+// @Param cashier_id query int false "Only sales rung up by this user"
+// @Param category_id query int false "Only sales of this category; product and category figures cover only its lines"
+// @Param payment_method query string false "Only sales paid this way, e.g. cash"
+// @Success 200 {object} helpers.Response{data=models.CategorySalesReport} "Successfully retrieved category sales report"
+// @Failure 400 {object} helpers.Problem "Missing or invalid start_date or end_date"
+// @Router /v1/report/by-category [get]
+func (h *TransactionHandler) CategorySalesReport(c *gin.Context) {
+	filter, ok := reportFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetCategorySalesReport(c.Request.Context(), filter)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve category sales report")
+		return
+	}
+	helpers.OK(c, "Successfully retrieved category sales report", report)
+}
+
 // Dashboard godoc
 // @Summary Get dashboard statistics
-// @Description Retrieve summary statistics for the POS dashboard
+// @Description Retrieve summary statistics for the POS dashboard, for one store when store_id is given (low stock then counts that store's stock)
 // @Tags Dashboard
 // @Produce json
+// @Param store_id query int false "Only this store (default: all stores)"
 // @Success 200 {object} helpers.Response{data=models.DashboardStats} "Successfully retrieved dashboard data"
-// @Router /api/dashboard [get]
+// @Router /v1/dashboard [get]
 func (h *TransactionHandler) Dashboard(c *gin.Context) {
-	stats, err := h.service.GetDashboardStats()
+	storeID, ok := storeIDQuery(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.service.GetDashboardStats(c.Request.Context(), storeID)
 	if err != nil {
-		helpers.InternalError(c, "Failed to retrieve dashboard data", err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve dashboard data")
 		return
 	}
 	helpers.OK(c, "Successfully retrieved dashboard data", stats)
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"database/sql"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TranslationHandler handles translation management for products and categories
+type TranslationHandler struct {
+	service services.TranslationService
+}
+
+// NewTranslationHandler creates a new translation handler instance
+func NewTranslationHandler(service services.TranslationService) *TranslationHandler {
+	return &TranslationHandler{service: service}
+}
+
+// list returns all translations of an entity identified by the :id path param
+func (h *TranslationHandler) list(c *gin.Context, entity, label string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid "+label+" ID")
+		return
+	}
+
+	translations, err := h.service.GetTranslations(c.Request.Context(), entity, id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve translations")
+		return
+	}
+	helpers.OK(c, "Translations retrieved successfully", translations)
+}
+
+// upsert creates or replaces the translation for the :locale path param
+func (h *TranslationHandler) upsert(c *gin.Context, entity, label string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid "+label+" ID")
+		return
+	}
+
+	var input models.TranslationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	translation, err := h.service.UpsertTranslation(c.Request.Context(), entity, id, c.Param("locale"), input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to save translation")
+		return
+	}
+	helpers.OK(c, "Translation saved successfully", translation)
+}
+
+// remove deletes the translation for the :locale path param
+func (h *TranslationHandler) remove(c *gin.Context, entity, label string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid "+label+" ID")
+		return
+	}
+
+	err = h.service.DeleteTranslation(c.Request.Context(), entity, id, c.Param("locale"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			helpers.NotFound(c, "Translation not found")
+			return
+		}
+		helpers.ServiceError(c, err, "Failed to delete translation")
+		return
+	}
+	helpers.OK(c, "Translation deleted successfully", nil)
+}
+
+// ListProductTranslations godoc
+// @Summary List product translations
+// @Description Retrieve all localized names of a product
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} helpers.Response{data=[]models.Translation} "Translations retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/translations [get]
+func (h *TranslationHandler) ListProductTranslations(c *gin.Context) {
+	h.list(c, repositories.TranslationEntityProduct, "product")
+}
+
+// UpsertProductTranslation godoc
+// @Summary Create or update a product translation
+// @Description Set the localized name of a product for one locale (owner only)
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param locale path string true "Locale (e.g. id, en-us)"
+// @Param translation body models.TranslationInput true "Translation"
+// @Success 200 {object} helpers.Response{data=models.Translation} "Translation saved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid locale or request body"
+// @Failure 404 {object} helpers.Problem "Product not found"
+// @Router /v1/products/{id}/translations/{locale} [put]
+func (h *TranslationHandler) UpsertProductTranslation(c *gin.Context) {
+	h.upsert(c, repositories.TranslationEntityProduct, "product")
+}
+
+// DeleteProductTranslation godoc
+// @Summary Delete a product translation
+// @Description Remove the localized name of a product for one locale (owner only)
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param locale path string true "Locale (e.g. id, en-us)"
+// @Success 200 {object} helpers.Response "Translation deleted successfully"
+// @Failure 404 {object} helpers.Problem "Translation not found"
+// @Router /v1/products/{id}/translations/{locale} [delete]
+func (h *TranslationHandler) DeleteProductTranslation(c *gin.Context) {
+	h.remove(c, repositories.TranslationEntityProduct, "product")
+}
+
+// ListCategoryTranslations godoc
+// @Summary List category translations
+// @Description Retrieve all localized names and descriptions of a category
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} helpers.Response{data=[]models.Translation} "Translations retrieved successfully"
+// @Failure 404 {object} helpers.Problem "Category not found"
+// @Router /v1/categories/{id}/translations [get]
+func (h *TranslationHandler) ListCategoryTranslations(c *gin.Context) {
+	h.list(c, repositories.TranslationEntityCategory, "category")
+}
+
+// UpsertCategoryTranslation godoc
+// @Summary Create or update a category translation
+// @Description Set the localized name and description of a category for one locale (owner only)
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param locale path string true "Locale (e.g. id, en-us)"
+// @Param translation body models.TranslationInput true "Translation"
+// @Success 200 {object} helpers.Response{data=models.Translation} "Translation saved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid locale or request body"
+// @Failure 404 {object} helpers.Problem "Category not found"
+// @Router /v1/categories/{id}/translations/{locale} [put]
+func (h *TranslationHandler) UpsertCategoryTranslation(c *gin.Context) {
+	h.upsert(c, repositories.TranslationEntityCategory, "category")
+}
+
+// DeleteCategoryTranslation godoc
+// @Summary Delete a category translation
+// @Description Remove the localized name and description of a category for one locale (owner only)
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param locale path string true "Locale (e.g. id, en-us)"
+// @Success 200 {object} helpers.Response "Translation deleted successfully"
+// @Failure 404 {object} helpers.Problem "Translation not found"
+// @Router /v1/categories/{id}/translations/{locale} [delete]
+func (h *TranslationHandler) DeleteCategoryTranslation(c *gin.Context) {
+	h.remove(c, repositories.TranslationEntityCategory, "category")
+}
@@ -11,12 +11,13 @@ import (
 
 // UserHandler handles user management endpoints
 type UserHandler struct {
-	userService services.UserService
+	userService  services.UserService
+	auditService services.AuditService
 }
 
 // NewUserHandler creates a new user handler instance
-func NewUserHandler(userService services.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService services.UserService, auditService services.AuditService) *UserHandler {
+	return &UserHandler{userService: userService, auditService: auditService}
 }
 
 // GetAll godoc
@@ -25,12 +26,12 @@ func NewUserHandler(userService services.UserService) *UserHandler {
 // @Tags Users
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} helpers.Response
-// @Router /api/users [get]
+// @Success 200 {object} helpers.Response{data=[]models.User}
+// @Router /v1/users [get]
 func (h *UserHandler) GetAll(c *gin.Context) {
-	users, err := h.userService.GetAll()
+	users, err := h.userService.GetAll(c.Request.Context())
 	if err != nil {
-		helpers.InternalError(c, "Failed to fetch users", err.Error())
+		helpers.ServiceError(c, err, "Failed to fetch users")
 		return
 	}
 	helpers.OK(c, "Users retrieved successfully", users)
@@ -43,9 +44,9 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "User ID"
-// @Success 200 {object} helpers.Response
+// @Success 200 {object} helpers.Response{data=models.User}
 // @Failure 404 {object} helpers.Response
-// @Router /api/users/{id} [get]
+// @Router /v1/users/{id} [get]
 func (h *UserHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -53,9 +54,9 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.GetByID(id)
+	user, err := h.userService.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NotFound(c, err.Error())
+		helpers.ServiceError(c, err, "Failed to retrieve user")
 		return
 	}
 
@@ -71,10 +72,10 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "User ID"
 // @Param body body models.UserInput true "User data"
-// @Success 200 {object} helpers.Response
+// @Success 200 {object} helpers.Response{data=models.User}
 // @Failure 400 {object} helpers.Response
 // @Failure 404 {object} helpers.Response
-// @Router /api/users/{id} [put]
+// @Router /v1/users/{id} [put]
 func (h *UserHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -84,15 +85,18 @@ func (h *UserHandler) Update(c *gin.Context) {
 
 	var input models.UserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequest(c, "Invalid request body", err.Error())
+		helpers.InvalidBody(c, err)
 		return
 	}
 
-	user, err := h.userService.Update(id, input)
+	before, _ := h.userService.GetByID(c.Request.Context(), id)
+
+	user, err := h.userService.Update(c.Request.Context(), id, input)
 	if err != nil {
-		helpers.BadRequest(c, err.Error())
+		helpers.ServiceError(c, err, "Failed to update user")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionUpdate, models.AuditEntityUser, id, before, user)
 
 	helpers.OK(c, "User updated successfully", user)
 }
@@ -106,7 +110,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 // @Param id path int true "User ID"
 // @Success 200 {object} helpers.Response
 // @Failure 404 {object} helpers.Response
-// @Router /api/users/{id} [delete]
+// @Router /v1/users/{id} [delete]
 func (h *UserHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -114,10 +118,13 @@ func (h *UserHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.Delete(id); err != nil {
-		helpers.NotFound(c, err.Error())
+	before, _ := h.userService.GetByID(c.Request.Context(), id)
+
+	if err := h.userService.Delete(c.Request.Context(), id); err != nil {
+		helpers.ServiceError(c, err, "Failed to delete user")
 		return
 	}
+	h.auditService.Record(c.Request.Context(), currentActor(c), models.AuditActionDelete, models.AuditEntityUser, id, before, nil)
 
 	helpers.OK(c, "User deleted successfully", nil)
 }
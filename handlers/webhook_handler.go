@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles HTTP requests for outgoing webhooks
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// List godoc
+// @Summary Get all webhooks
+// @Description Retrieve every registered webhook with its subscribed events; secrets are not returned (owner only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=[]models.Webhook} "Webhooks retrieved successfully"
+// @Router /v1/webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	webhooks, err := h.service.GetWebhooks(c.Request.Context())
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve webhooks")
+		return
+	}
+	helpers.OK(c, "Webhooks retrieved successfully", webhooks)
+}
+
+// GetByID godoc
+// @Summary Get a webhook by ID
+// @Description Retrieve a specific webhook; its secret is not returned (owner only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} helpers.Response{data=models.Webhook} "Webhook retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid webhook ID"
+// @Failure 404 {object} helpers.Problem "Webhook not found"
+// @Router /v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	webhook, err := h.service.GetWebhookByID(c.Request.Context(), id)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve webhook")
+		return
+	}
+	if webhook == nil {
+		helpers.NotFound(c, "Webhook not found")
+		return
+	}
+	helpers.OK(c, "Webhook retrieved successfully", webhook)
+}
+
+// Create godoc
+// @Summary Register a webhook
+// @Description Register a URL that receives the subscribed events (product.updated, transaction.created, stock.low) as POST requests. Each request carries X-Webhook-Event, X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature: "sha256=" + hex HMAC-SHA256 of "{timestamp}.{body}" under the secret. The secret is only returned in this response (owner only).
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook body models.WebhookInput true "Webhook"
+// @Success 201 {object} helpers.Response{data=models.Webhook} "Webhook created successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body, URL or events"
+// @Router /v1/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var input models.WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	created, err := h.service.CreateWebhook(c.Request.Context(), input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to create webhook")
+		return
+	}
+	helpers.Created(c, "Webhook created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a webhook
+// @Description Replace a webhook's URL, description, events and state; its secret is kept. Deliveries of an inactive webhook stay queued until it is activated again (owner only).
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param webhook body models.WebhookInput true "Updated webhook"
+// @Success 200 {object} helpers.Response{data=models.Webhook} "Webhook updated successfully"
+// @Failure 400 {object} helpers.Problem "Invalid request body, URL or events"
+// @Failure 404 {object} helpers.Problem "Webhook not found"
+// @Router /v1/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	var input models.WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.InvalidBody(c, err)
+		return
+	}
+
+	updated, err := h.service.UpdateWebhook(c.Request.Context(), id, input)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to update webhook")
+		return
+	}
+	helpers.OK(c, "Webhook updated successfully", updated)
+}
+
+// Delete godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook and its delivery log; queued deliveries are dropped (owner only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} helpers.Response "Webhook deleted successfully"
+// @Failure 400 {object} helpers.Problem "Invalid webhook ID"
+// @Failure 404 {object} helpers.Problem "Webhook not found"
+// @Router /v1/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.service.DeleteWebhook(c.Request.Context(), id); err != nil {
+		helpers.ServiceError(c, err, "Failed to delete webhook")
+		return
+	}
+	helpers.OK(c, "Webhook deleted successfully", nil)
+}
+
+// Deliveries godoc
+// @Summary Delivery log of a webhook
+// @Description Paginated deliveries of a webhook, newest first, with the payload sent and the status, response code and error of the latest attempt. Failed attempts are retried with exponential backoff (30s doubling up to 1h); a delivery fails after WEBHOOK_MAX_ATTEMPTS attempts, 8 by default (owner only).
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Filter by status" Enums(pending, delivered, failed)
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} helpers.PaginatedResponse{data=[]models.WebhookDelivery} "Webhook deliveries retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid webhook ID or status"
+// @Failure 404 {object} helpers.Problem "Webhook not found"
+// @Router /v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	page, limit := helpers.ParsePagination(c)
+	result, err := h.service.GetDeliveries(c.Request.Context(), models.WebhookDeliveryParams{
+		WebhookID: id,
+		Status:    c.Query("status"),
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+	helpers.Paginated(c, "Webhook deliveries retrieved successfully", result.Data, helpers.PaginationMeta{
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// Delivery godoc
+// @Summary Get a webhook delivery
+// @Description Retrieve a delivery of a webhook with its payload and attempt_history: every attempt with its response status, the first 512 bytes of the response body, the error and how long it took (owner only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 200 {object} helpers.Response{data=models.WebhookDelivery} "Webhook delivery retrieved successfully"
+// @Failure 400 {object} helpers.Problem "Invalid webhook or delivery ID"
+// @Failure 404 {object} helpers.Problem "Delivery not found"
+// @Router /v1/webhooks/{id}/deliveries/{delivery_id} [get]
+func (h *WebhookHandler) Delivery(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil || deliveryID <= 0 {
+		helpers.BadRequest(c, "Invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.service.GetDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to retrieve webhook delivery")
+		return
+	}
+	helpers.OK(c, "Webhook delivery retrieved successfully", delivery)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queue a delivered or failed delivery again with a fresh set of attempts, e.g. after the receiver was fixed. The same payload and event id are sent (owner only).
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 200 {object} helpers.Response{data=models.WebhookDelivery} "Delivery queued"
+// @Failure 400 {object} helpers.Problem "Invalid webhook or delivery ID"
+// @Failure 404 {object} helpers.Problem "Delivery not found or already pending"
+// @Router /v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		helpers.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil || deliveryID <= 0 {
+		helpers.BadRequest(c, "Invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.service.Redeliver(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		helpers.ServiceError(c, err, "Failed to redeliver")
+		return
+	}
+	helpers.OK(c, "Delivery queued", delivery)
+}
@@ -0,0 +1,178 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes of the errors a POS client shows to its operator. Clients can
+// rely on the code; the message is translated per Accept-Language.
+const (
+	CodeCheckoutEmpty          = "checkout_empty"
+	CodeInvalidPriceLevel      = "invalid_price_level"
+	CodeInvalidProductID       = "invalid_product_id"
+	CodeInvalidQuantity        = "invalid_quantity"
+	CodeProductNotFound        = "product_not_found"
+	CodeInsufficientStock      = "insufficient_stock"
+	CodeInsufficientStoreStock = "insufficient_store_stock"
+	CodeDefaultStoreNotFound   = "default_store_not_found"
+	CodeStoreNotFound          = "store_not_found"
+	CodeStoreInactive          = "store_inactive"
+	CodeTransactionNotFound    = "transaction_not_found"
+	CodeTransactionVoided      = "transaction_already_voided"
+	CodeInvalidCredentials     = "invalid_credentials"
+	CodeAccountDeactivated     = "account_deactivated"
+)
+
+// DefaultErrorLocale is the language of error messages for requests that
+// accept none of the translated ones
+const DefaultErrorLocale = "en"
+
+// errorLocales are the locales error messages are translated to
+var errorLocales = map[string]bool{"en": true, "id": true}
+
+// errorMessages holds the message format of each error code per locale. Every
+// code has an English message; the arguments of a code are the same in every
+// locale.
+var errorMessages = map[string]map[string]string{
+	CodeCheckoutEmpty: {
+		"en": "checkout items cannot be empty",
+		"id": "item checkout tidak boleh kosong",
+	},
+	CodeInvalidPriceLevel: {
+		"en": "invalid price_level: must be 'retail', 'wholesale' or 'member'",
+		"id": "price_level tidak valid: harus 'retail', 'wholesale' atau 'member'",
+	},
+	CodeInvalidProductID: {
+		"en": "invalid product ID",
+		"id": "ID produk tidak valid",
+	},
+	CodeInvalidQuantity: {
+		"en": "quantity must be greater than 0",
+		"id": "jumlah harus lebih dari 0",
+	},
+	CodeProductNotFound: {
+		"en": "product id %d not found",
+		"id": "produk dengan id %d tidak ditemukan",
+	},
+	CodeInsufficientStock: {
+		"en": "insufficient stock for product '%s' (available: %d, requested: %d)",
+		"id": "stok produk '%s' tidak mencukupi (tersedia: %d, diminta: %d)",
+	},
+	CodeInsufficientStoreStock: {
+		"en": "insufficient stock for product '%s' at this store (available: %d, requested: %d)",
+		"id": "stok produk '%s' di toko ini tidak mencukupi (tersedia: %d, diminta: %d)",
+	},
+	CodeDefaultStoreNotFound: {
+		"en": "default store not found",
+		"id": "toko utama tidak ditemukan",
+	},
+	CodeStoreNotFound: {
+		"en": "invalid store_id %d: store not found",
+		"id": "store_id %d tidak valid: toko tidak ditemukan",
+	},
+	CodeStoreInactive: {
+		"en": "invalid store_id %d: store is inactive",
+		"id": "store_id %d tidak valid: toko tidak aktif",
+	},
+	CodeTransactionNotFound: {
+		"en": "transaction id %d not found",
+		"id": "transaksi dengan id %d tidak ditemukan",
+	},
+	CodeTransactionVoided: {
+		"en": "transaction is already voided",
+		"id": "transaksi sudah dibatalkan",
+	},
+	CodeInvalidCredentials: {
+		"en": "invalid email or password",
+		"id": "email atau kata sandi salah",
+	},
+	CodeAccountDeactivated: {
+		"en": "account is deactivated",
+		"id": "akun dinonaktifkan",
+	},
+}
+
+// codeErrors is the sentinel each error code wraps, which sets its HTTP
+// status (see StatusOf)
+var codeErrors = map[string]error{
+	CodeCheckoutEmpty:          ErrValidation,
+	CodeInvalidPriceLevel:      ErrValidation,
+	CodeInvalidProductID:       ErrValidation,
+	CodeInvalidQuantity:        ErrValidation,
+	CodeProductNotFound:        ErrValidation,
+	CodeInsufficientStock:      ErrValidation,
+	CodeInsufficientStoreStock: ErrValidation,
+	CodeDefaultStoreNotFound:   ErrValidation,
+	CodeStoreNotFound:          ErrValidation,
+	CodeStoreInactive:          ErrValidation,
+	CodeTransactionNotFound:    ErrNotFound,
+	CodeTransactionVoided:      ErrConflict,
+	CodeInvalidCredentials:     ErrUnauthorized,
+	CodeAccountDeactivated:     ErrUnauthorized,
+}
+
+// CodedError is an error identified by a code whose message is translated
+// per locale. Error returns the English message, so callers matching on the
+// error text keep working; it wraps the sentinel of its code.
+type CodedError struct {
+	Code string
+	Args []interface{}
+}
+
+// NewCodedError creates a CodedError with the arguments of its message
+func NewCodedError(code string, args ...interface{}) *CodedError {
+	return &CodedError{Code: code, Args: args}
+}
+
+func (e *CodedError) Error() string {
+	return e.Message(DefaultErrorLocale)
+}
+
+func (e *CodedError) Unwrap() error {
+	return codeErrors[e.Code]
+}
+
+// Message returns the error message in locale, or in English if the code has
+// no message in that locale
+func (e *CodedError) Message(locale string) string {
+	messages := errorMessages[e.Code]
+	format, ok := messages[locale]
+	if !ok {
+		format, ok = messages[DefaultErrorLocale]
+	}
+	if !ok {
+		return e.Code
+	}
+	return fmt.Sprintf(format, e.Args...)
+}
+
+// ErrorLocale returns the first locale of a request's Accept-Language header
+// that error messages are translated to, or DefaultErrorLocale
+func ErrorLocale(c *gin.Context) string {
+	for _, locale := range RequestLocales(c) {
+		if errorLocales[locale] {
+			return locale
+		}
+	}
+	return DefaultErrorLocale
+}
+
+// ErrorFrom sends an error response for err. A CodedError (anywhere in the
+// chain) is sent with its code and its message in the request's language,
+// FieldErrors with a violation per field; any other error is sent with its
+// text.
+func ErrorFrom(c *gin.Context, statusCode int, err error) {
+	var coded *CodedError
+	var fields FieldErrors
+	switch {
+	case errors.As(err, &coded):
+		apiError{status: statusCode, code: coded.Code, message: coded.Message(ErrorLocale(c))}.render(c)
+	case errors.As(err, &fields):
+		apiError{status: statusCode, code: CodeValidationFailed, message: "Validation failed", detail: fields.Error(), violations: fields}.render(c)
+	default:
+		Error(c, statusCode, err.Error())
+	}
+}
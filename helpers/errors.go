@@ -1,8 +1,16 @@
 package helpers
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+	"strings"
 
-// Sentinel errors for common application error conditions.
+	"github.com/gin-gonic/gin"
+)
+
+// Sentinel errors for common application error conditions. Services wrap
+// them (see AppError) and handlers send them with ServiceError, which maps
+// each to its HTTP status.
 var (
 	ErrNotFound     = errors.New("resource not found")
 	ErrValidation   = errors.New("validation error")
@@ -11,6 +19,18 @@ var (
 	ErrConflict     = errors.New("conflict")
 )
 
+// sentinelStatuses maps each sentinel error to the HTTP status it is sent with
+var sentinelStatuses = []struct {
+	err    error
+	status int
+}{
+	{ErrNotFound, http.StatusNotFound},
+	{ErrValidation, http.StatusBadRequest},
+	{ErrUnauthorized, http.StatusUnauthorized},
+	{ErrForbidden, http.StatusForbidden},
+	{ErrConflict, http.StatusConflict},
+}
+
 // AppError wraps an error with an application-specific message so callers can
 // provide user-facing context while preserving the underlying sentinel for
 // programmatic checks.
@@ -37,6 +57,41 @@ func NewValidationError(message string) *AppError {
 	return &AppError{Err: ErrValidation, Message: message}
 }
 
+// NewConflictError creates an AppError wrapping ErrConflict.
+func NewConflictError(message string) *AppError {
+	return &AppError{Err: ErrConflict, Message: message}
+}
+
+// FieldErrors is a validation failure listing every invalid field of an
+// input, so a client can fix them all at once. It wraps ErrValidation.
+type FieldErrors []Violation
+
+// Add records an invalid field; message is the whole sentence, e.g. "price
+// must be at least 0"
+func (e *FieldErrors) Add(field, rule, message string) {
+	*e = append(*e, Violation{Field: field, Rule: rule, Message: message})
+}
+
+// Err returns the violations as an error, or nil when there are none
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, v := range e {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e FieldErrors) Unwrap() error {
+	return ErrValidation
+}
+
 // IsNotFound reports whether err (or any error in its chain) is ErrNotFound.
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
@@ -46,3 +101,32 @@ func IsNotFound(err error) bool {
 func IsValidation(err error) bool {
 	return errors.Is(err, ErrValidation)
 }
+
+// IsConflict reports whether err (or any error in its chain) is ErrConflict.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// StatusOf returns the HTTP status of the sentinel in err's chain, or 500 for
+// an error that wraps none
+func StatusOf(err error) int {
+	for _, s := range sentinelStatuses {
+		if errors.Is(err, s.err) {
+			return s.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// ServiceError sends an error returned by a service with the status of its
+// sentinel (see StatusOf). Client errors are sent with their own message, in
+// the request's language for a CodedError; any other error is a server error,
+// sent as message with err logged.
+func ServiceError(c *gin.Context, err error, message string) {
+	status := StatusOf(err)
+	if status == http.StatusInternalServerError {
+		InternalError(c, message, err.Error())
+		return
+	}
+	ErrorFrom(c, status, err)
+}
@@ -0,0 +1,448 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema draft the generated schemas declare
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema directions. A response schema describes what the API emits: every
+// field without omitempty is required and unknown fields are rejected. A
+// request schema describes what the API accepts: only fields with
+// binding:"required" are required.
+const (
+	SchemaRequest  = "request"
+	SchemaResponse = "response"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// JSONSchema generates a JSON Schema document for the type of v. Named
+// structs are emitted once under $defs and referenced by $ref, so recursive
+// types such as a category tree are supported.
+func JSONSchema(v interface{}, direction string) map[string]interface{} {
+	g := &schemaGenerator{direction: direction, defs: make(map[string]interface{})}
+	root := g.schemaFor(reflect.TypeOf(v))
+
+	doc := map[string]interface{}{"$schema": JSONSchemaDialect}
+	for k, val := range root {
+		doc[k] = val
+	}
+	if len(g.defs) > 0 {
+		doc["$defs"] = g.defs
+	}
+	return doc
+}
+
+// SchemaName returns the published name of a type, e.g. "models.Product"
+func SchemaName(v interface{}) string {
+	return defName(reflect.TypeOf(v))
+}
+
+// schemaGenerator builds one schema document
+type schemaGenerator struct {
+	direction string
+	defs      map[string]interface{}
+}
+
+// schemaFor returns the schema of t, registering named structs in $defs
+func (g *schemaGenerator) schemaFor(t reflect.Type) map[string]interface{} {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var s map[string]interface{}
+	switch {
+	case t == timeType:
+		s = map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == rawType || t.Kind() == reflect.Interface:
+		return map[string]interface{}{}
+	case t.Kind() == reflect.Struct:
+		name := defName(t)
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // placeholder so recursive references terminate
+			g.defs[name] = g.structSchema(t)
+		}
+		ref := map[string]interface{}{"$ref": "#/$defs/" + name}
+		if nullable {
+			return map[string]interface{}{"anyOf": []interface{}{ref, map[string]interface{}{"type": "null"}}}
+		}
+		return ref
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			s = map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+			break
+		}
+		s = map[string]interface{}{"type": "array", "items": g.schemaFor(t.Elem())}
+		// A nil slice encodes as null
+		nullable = nullable || t.Kind() == reflect.Slice
+	case t.Kind() == reflect.Map:
+		s = map[string]interface{}{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+		nullable = true
+	case t.Kind() == reflect.Bool:
+		s = map[string]interface{}{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = map[string]interface{}{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = map[string]interface{}{"type": "number"}
+	case t.Kind() == reflect.String:
+		s = map[string]interface{}{"type": "string"}
+	default:
+		return map[string]interface{}{}
+	}
+
+	if nullable {
+		s["type"] = []interface{}{s["type"], "null"}
+	}
+	return s
+}
+
+// structSchema returns the object schema of a struct, flattening embedded
+// structs the way encoding/json does
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	g.collectFields(t, properties, &required)
+	sort.Strings(required)
+
+	s := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	if g.direction == SchemaResponse {
+		s["additionalProperties"] = false
+	}
+	return s
+}
+
+// collectFields adds the JSON fields of t to properties and required
+func (g *schemaGenerator) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			g.collectFields(f.Type, properties, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := g.schemaFor(f.Type)
+		applyFieldTags(prop, f)
+		properties[name] = prop
+
+		omitempty := strings.Contains(opts, "omitempty")
+		binding := strings.Split(f.Tag.Get("binding"), ",")
+		switch g.direction {
+		case SchemaResponse:
+			if !omitempty {
+				*required = append(*required, name)
+			}
+		case SchemaRequest:
+			if binding[0] == "required" {
+				*required = append(*required, name)
+			}
+		}
+	}
+}
+
+// applyFieldTags maps enums and binding rules onto a property schema
+func applyFieldTags(prop map[string]interface{}, f reflect.StructField) {
+	if _, isRef := prop["$ref"]; isRef {
+		return
+	}
+
+	var enum []string
+	if tag := f.Tag.Get("enums"); tag != "" {
+		enum = strings.Split(tag, ",")
+	}
+	for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "oneof":
+			enum = strings.Fields(value)
+		case "email":
+			prop["format"] = "email"
+		case "min":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			switch primaryType(prop) {
+			case "integer", "number":
+				prop["minimum"] = n
+			case "string":
+				prop["minLength"] = n
+			case "array":
+				prop["minItems"] = n
+			}
+		}
+	}
+	if len(enum) > 0 && primaryType(prop) == "string" {
+		values := make([]interface{}, len(enum))
+		for i, e := range enum {
+			values[i] = e
+		}
+		prop["enum"] = values
+	}
+}
+
+// primaryType returns the non-null type of a schema
+func primaryType(s map[string]interface{}) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		if len(t) > 0 {
+			name, _ := t[0].(string)
+			return name
+		}
+	}
+	return ""
+}
+
+// defName returns "package.Type" for a named type
+func defName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	if pkg == "" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
+
+// ValidateSchema checks a decoded JSON value against a schema document
+// produced by JSONSchema and returns one message per violation. Only the
+// keywords JSONSchema emits are enforced.
+func ValidateSchema(schema map[string]interface{}, value interface{}) []string {
+	v := &schemaValidator{root: schema}
+	v.validate(schema, value, "$")
+	return v.errors
+}
+
+// schemaValidator collects violations while walking a value
+type schemaValidator struct {
+	root   map[string]interface{}
+	errors []string
+}
+
+// validate checks value against s, reporting violations under path
+func (v *schemaValidator) validate(s map[string]interface{}, value interface{}, path string) {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			v.fail(path, err.Error())
+			return
+		}
+		v.validate(target, value, path)
+		return
+	}
+
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		for _, option := range anyOf {
+			sub := &schemaValidator{root: v.root}
+			sub.validate(asSchema(option), value, path)
+			if len(sub.errors) == 0 {
+				return
+			}
+		}
+		v.fail(path, "does not match any allowed schema")
+		return
+	}
+
+	if types := schemaTypes(s); len(types) > 0 && !matchesType(types, value) {
+		v.fail(path, fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), jsonType(value)))
+		return
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, val, path)
+	case []interface{}:
+		if n, ok := schemaInt(s["minItems"]); ok && len(val) < n {
+			v.fail(path, fmt.Sprintf("must have at least %d items", n))
+		}
+		if items, ok := s["items"]; ok {
+			for i, item := range val {
+				v.validate(asSchema(items), item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case string:
+		if n, ok := schemaInt(s["minLength"]); ok && len([]rune(val)) < n {
+			v.fail(path, fmt.Sprintf("must be at least %d characters", n))
+		}
+		if enum, ok := s["enum"].([]interface{}); ok && !containsValue(enum, val) {
+			v.fail(path, fmt.Sprintf("%q is not one of %v", val, enum))
+		}
+	case float64:
+		if n, ok := schemaInt(s["minimum"]); ok && val < float64(n) {
+			v.fail(path, fmt.Sprintf("must be at least %d", n))
+		}
+	}
+}
+
+// validateObject checks required, declared and undeclared properties
+func (v *schemaValidator) validateObject(s map[string]interface{}, obj map[string]interface{}, path string) {
+	properties, _ := s["properties"].(map[string]interface{})
+	for _, name := range schemaStrings(s["required"]) {
+		if _, ok := obj[name]; !ok {
+			v.fail(path, fmt.Sprintf("missing required property %q", name))
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if prop, ok := properties[k]; ok {
+			v.validate(asSchema(prop), obj[k], path+"."+k)
+			continue
+		}
+		switch extra := s["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				v.fail(path, fmt.Sprintf("unexpected property %q", k))
+			}
+		case map[string]interface{}:
+			v.validate(extra, obj[k], path+"."+k)
+		}
+	}
+}
+
+// resolve looks up a local "#/$defs/Name" reference
+func (v *schemaValidator) resolve(ref string) (map[string]interface{}, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+	defs, _ := v.root["$defs"].(map[string]interface{})
+	def, ok := defs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown reference %q", ref)
+	}
+	return asSchema(def), nil
+}
+
+func (v *schemaValidator) fail(path, message string) {
+	v.errors = append(v.errors, path+": "+message)
+}
+
+// asSchema converts a schema node to a map; schemas built in memory and
+// schemas decoded from JSON use different slice types, so both are accepted
+func asSchema(node interface{}) map[string]interface{} {
+	s, _ := node.(map[string]interface{})
+	return s
+}
+
+// schemaTypes returns the allowed types of a schema
+func schemaTypes(s map[string]interface{}) []string {
+	switch t := s["type"].(type) {
+	case string:
+		return []string{t}
+	default:
+		return schemaStrings(t)
+	}
+}
+
+// schemaStrings converts a []string or decoded []interface{} to []string
+func schemaStrings(node interface{}) []string {
+	switch list := node.(type) {
+	case []string:
+		return list
+	case []interface{}:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// schemaInt reads a numeric keyword from an in-memory or decoded schema
+func schemaInt(node interface{}) (int, bool) {
+	switch n := node.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// matchesType reports whether value is one of the JSON types
+func matchesType(types []string, value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON Schema type of a value decoded by encoding/json
+func jsonType(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if val == math.Trunc(val) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// containsValue reports whether list contains s
+func containsValue(list []interface{}, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
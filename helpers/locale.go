@@ -0,0 +1,77 @@
+package helpers
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// localePattern matches BCP 47 style tags such as "id", "en-us" or "zh-hant-tw"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale lowercases a locale tag and converts underscores to hyphens
+// (en_US -> en-us). It returns an empty string if the tag is not valid.
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if !localePattern.MatchString(locale) {
+		return ""
+	}
+	return locale
+}
+
+// ParseAcceptLanguage returns the locales from an Accept-Language header in
+// preference order, each followed by its base language as a fallback
+// ("id-ID,en;q=0.8" -> [id-id id en]). Wildcards and invalid tags are skipped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := NormalizeLocale(fields[0])
+		if locale == "" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{locale, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	seen := make(map[string]bool)
+	locales := make([]string, 0, len(tags)*2)
+	add := func(l string) {
+		if !seen[l] {
+			seen[l] = true
+			locales = append(locales, l)
+		}
+	}
+	for _, t := range tags {
+		add(t.locale)
+		if base, _, found := strings.Cut(t.locale, "-"); found {
+			add(base)
+		}
+	}
+	return locales
+}
+
+// RequestLocales returns the locale preferences of a request from its
+// Accept-Language header
+func RequestLocales(c *gin.Context) []string {
+	return ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+}
@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Layout of single-page monospace PDFs (sized for 80mm receipt paper)
+const (
+	PDFFontSize   = 8.0
+	PDFLineHeight = 10.0
+	PDFMargin     = 12.0
+	PDFPageWidth  = 226.0 // 80mm in points
+	// PDFColumns is how many Courier characters (0.6em wide) fit between the margins
+	PDFColumns = 42
+)
+
+// TextPDF renders lines of plain text as a single-page PDF using the built-in
+// Courier font, so no font embedding or external library is required. The
+// page height grows with the number of lines.
+func TextPDF(lines []string) []byte {
+	return textPDF(lines, PDFPageWidth)
+}
+
+// WideTextPDF renders lines like TextPDF on a page wide enough for columns
+// characters, for tabular reports that do not fit on receipt paper
+func WideTextPDF(lines []string, columns int) []byte {
+	if columns < PDFColumns {
+		columns = PDFColumns
+	}
+	return textPDF(lines, 2*PDFMargin+float64(columns)*0.6*PDFFontSize)
+}
+
+// textPDF renders lines on a single page of the given width
+func textPDF(lines []string, pageWidth float64) []byte {
+	pageHeight := 2*PDFMargin + float64(len(lines))*PDFLineHeight
+
+	var content bytes.Buffer
+	content.WriteString("BT\n")
+	fmt.Fprintf(&content, "/F1 %.1f Tf\n%.1f TL\n", PDFFontSize, PDFLineHeight)
+	// The ' operator advances one line before drawing, so start a line above the first baseline
+	fmt.Fprintf(&content, "%.1f %.1f Td\n", PDFMargin, pageHeight-PDFMargin)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) '\n", pdfEscape(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.1f %.1f] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+			pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return out.Bytes()
+}
+
+// pdfEscape escapes PDF string delimiters and replaces characters outside
+// Latin-1 so the text can be drawn with a standard Type1 font
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		case r > 126:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
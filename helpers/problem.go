@@ -0,0 +1,278 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProblemContentType is the media type of error responses under /v1
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix turns an error code into the problem type URI
+const problemTypePrefix = "urn:retail-core-api:problem:"
+
+// Codes of errors that have no code of their own (see error_codes.go)
+const (
+	CodeValidationFailed = "validation_failed"
+	CodeMalformedBody    = "malformed_body"
+)
+
+// statusCodes names the errors without a code after their HTTP status
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnprocessableEntity:   "unprocessable_entity",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal_error",
+	http.StatusNotImplemented:        "not_implemented",
+	http.StatusServiceUnavailable:    "service_unavailable",
+}
+
+// Problem is the error response under /v1, in the problem details format of
+// RFC 9457 (formerly RFC 7807). Code is the machine-readable error; Type is
+// the same code as a URI. Violations lists each invalid field of a request
+// body that failed validation.
+// @Description Error response (RFC 9457 problem details, application/problem+json). code is stable and safe to branch on; detail is human-readable and localized for coded errors.
+type Problem struct {
+	Type       string      `json:"type" example:"urn:retail-core-api:problem:validation_failed"`
+	Title      string      `json:"title" example:"Bad Request"`
+	Status     int         `json:"status" example:"400"`
+	Detail     string      `json:"detail,omitempty" example:"Invalid request body"`
+	Instance   string      `json:"instance,omitempty" example:"/v1/products"`
+	Code       string      `json:"code" example:"validation_failed"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Violation is one invalid field of a request body
+// @Description Invalid field of a request body: its JSON path, the rule it broke and why
+type Violation struct {
+	Field   string `json:"field" example:"items[0].quantity"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"items[0].quantity is required"`
+}
+
+// legacyKey marks a request made on a path from before /v1
+type legacyKey struct{}
+
+// MarkLegacy returns r marked as made on a path from before /v1. Errors to
+// such requests keep the ErrorResponse envelope.
+func MarkLegacy(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), legacyKey{}, true))
+}
+
+// IsLegacy reports whether r was made on a path from before /v1
+func IsLegacy(r *http.Request) bool {
+	legacy, _ := r.Context().Value(legacyKey{}).(bool)
+	return legacy
+}
+
+// apiError is an error response before it is rendered in the request's format
+type apiError struct {
+	status     int
+	code       string
+	message    string
+	detail     string
+	violations []Violation
+}
+
+// body returns the content type and body of the error for r: problem
+// details, or the ErrorResponse envelope for legacy paths. Problem details
+// leave out the detail of server errors.
+func (e apiError) body(r *http.Request) (string, interface{}) {
+	if IsLegacy(r) {
+		return "application/json; charset=utf-8", ErrorResponse{Status: false, Message: e.message, Code: e.code, Error: e.detail}
+	}
+
+	p := Problem{
+		Title:      http.StatusText(e.status),
+		Status:     e.status,
+		Detail:     e.message,
+		Instance:   r.URL.Path,
+		Code:       e.code,
+		Violations: e.violations,
+	}
+	if p.Code == "" {
+		p.Code = statusCodes[e.status]
+		if p.Code == "" {
+			p.Code = fmt.Sprintf("http_%d", e.status)
+		}
+	}
+	p.Type = problemTypePrefix + p.Code
+	if e.detail != "" && e.status < http.StatusInternalServerError && len(e.violations) == 0 {
+		p.Detail += ": " + e.detail
+	}
+	return ProblemContentType, p
+}
+
+// render sends the error on a gin context. The detail of a server error,
+// which problem details leave out, goes to the request log instead (see
+// middleware.Logger).
+func (e apiError) render(c *gin.Context) {
+	if e.detail != "" && e.status >= http.StatusInternalServerError {
+		_ = c.Error(errors.New(e.message + ": " + e.detail))
+	}
+	contentType, body := e.body(c.Request)
+	c.Header("Content-Type", contentType)
+	writeJSON(c, e.status, body)
+}
+
+// WriteError sends an error outside gin, in the format of Error
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	contentType, body := apiError{status: statusCode, message: message}.body(r)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// InvalidBody sends a 400 for a request body that failed to bind, with a
+// violation for each field that failed validation, or a 413 for a body over
+// the size limit
+func InvalidBody(c *gin.Context, err error) {
+	e := apiError{status: http.StatusBadRequest, code: CodeMalformedBody, message: "Invalid request body", detail: err.Error()}
+
+	var fieldErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var sizeErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &sizeErr):
+		e = apiError{status: http.StatusRequestEntityTooLarge, message: fmt.Sprintf("Request body is larger than %d bytes", sizeErr.Limit)}
+	case errors.As(err, &fieldErrs):
+		e.code = CodeValidationFailed
+		for _, fe := range fieldErrs {
+			e.violations = append(e.violations, violation(fe))
+		}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		e.code = CodeValidationFailed
+		field := jsonPath(typeErr.Field)
+		e.violations = []Violation{{
+			Field:   field,
+			Rule:    "type",
+			Message: field + " must be " + decodedFrom(typeErr.Type),
+		}}
+	}
+	e.render(c)
+}
+
+// jsonPath writes a decoding error's field path, e.g. "items.0.quantity", the
+// way violations name fields: "items[0].quantity"
+func jsonPath(field string) string {
+	segments := strings.Split(field, ".")
+	var b strings.Builder
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil && i > 0 {
+			b.WriteString("[" + segment + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// decodedFrom names the JSON type a Go type is decoded from
+func decodedFrom(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
+
+// violation describes a failed validation rule
+func violation(fe validator.FieldError) Violation {
+	// The namespace starts with the struct's name, e.g. "CheckoutRequest.items[0].quantity"
+	field := fe.Namespace()
+	if _, rest, ok := strings.Cut(field, "."); ok {
+		field = rest
+	}
+
+	var message string
+	switch fe.Tag() {
+	case "required":
+		message = "is required"
+	case "oneof":
+		message = "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "email":
+		message = "must be a valid email address"
+	case "url":
+		message = "must be a valid URL"
+	case "min":
+		message = "must be at least " + fe.Param() + lengthUnit(fe)
+	case "max":
+		message = "must be at most " + fe.Param() + lengthUnit(fe)
+	case "gt":
+		message = "must be greater than " + fe.Param()
+	case "gte":
+		message = "must be at least " + fe.Param()
+	case "lt":
+		message = "must be less than " + fe.Param()
+	case "lte":
+		message = "must be at most " + fe.Param()
+	case "len":
+		message = "must have length " + fe.Param()
+	default:
+		message = "failed the " + fe.Tag() + " rule"
+	}
+	return Violation{Field: field, Rule: fe.Tag(), Message: field + " " + message}
+}
+
+// lengthUnit names what min and max count for a field: characters of a
+// string, items of a list, nothing for a number
+func lengthUnit(fe validator.FieldError) string {
+	var unit string
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " character"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " item"
+	default:
+		return ""
+	}
+	if fe.Param() != "1" {
+		unit += "s"
+	}
+	return unit
+}
+
+// UseJSONFieldNames makes request validation name fields after their JSON
+// keys, so violations point at the field the client sent
+func UseJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+}
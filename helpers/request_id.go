@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID that correlates a request with its log
+// records. A client may send its own; otherwise the server assigns one.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey carries the request ID in a context
+type requestIDKey struct{}
+
+// WithRequestID returns ctx carrying the request ID id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the ID of the request ctx belongs to, or "" outside a
+// request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDHandler adds the request ID and trace of the context to every log
+// record made with one (slog.InfoContext and the like)
+type requestIDHandler struct {
+	slog.Handler
+}
+
+// LogHandler wraps h so that records logged with a request's context carry
+// its request_id, and its trace_id and span_id when the request is traced
+func LogHandler(h slog.Handler) slog.Handler {
+	return requestIDHandler{h}
+}
+
+// Handle adds request_id, trace_id and span_id to the record when its
+// context has them
+func (h requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs keeps the request ID on loggers built with slog.With
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the request ID on grouped loggers
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
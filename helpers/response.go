@@ -4,8 +4,12 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
 )
 
+// tracer records the serialization of responses (see writeJSON)
+var tracer = otel.Tracer("retail-core-api/helpers")
+
 // Response is the standard API response envelope
 type Response struct {
 	Status  bool        `json:"status" example:"true"`
@@ -21,10 +25,13 @@ type PaginatedResponse struct {
 	Meta    PaginationMeta `json:"meta"`
 }
 
-// ErrorResponse is the standard error response envelope
+// ErrorResponse is the error response envelope of the paths from before
+// /v1, which answer with Problem instead. Code is set for errors with a
+// stable code (see error_codes.go), whose message is localized.
 type ErrorResponse struct {
 	Status  bool   `json:"status" example:"false"`
 	Message string `json:"message" example:"Error occurred"`
+	Code    string `json:"code,omitempty" example:"insufficient_stock"`
 	Error   string `json:"error,omitempty" example:"validation detail"`
 }
 
@@ -38,23 +45,22 @@ type PaginationMeta struct {
 
 // Success sends a standard success response
 func Success(c *gin.Context, statusCode int, message string, data interface{}) {
-	c.JSON(statusCode, Response{
+	writeJSON(c, statusCode, Response{
 		Status:  true,
 		Message: message,
 		Data:    data,
 	})
 }
 
-// Error sends a standard error response
+// Error sends an error response: problem details, or the ErrorResponse
+// envelope on paths from before /v1. err adds detail to the message; for
+// server errors it is logged instead of sent.
 func Error(c *gin.Context, statusCode int, message string, err ...string) {
-	resp := ErrorResponse{
-		Status:  false,
-		Message: message,
+	e := apiError{status: statusCode, message: message}
+	if len(err) > 0 {
+		e.detail = err[0]
 	}
-	if len(err) > 0 && err[0] != "" {
-		resp.Error = err[0]
-	}
-	c.JSON(statusCode, resp)
+	e.render(c)
 }
 
 // Created sends a 201 success response
@@ -67,6 +73,16 @@ func OK(c *gin.Context, message string, data interface{}) {
 	Success(c, http.StatusOK, message, data)
 }
 
+// Unavailable sends a 503 response in the standard envelope, with data
+// saying what is unavailable for clients that read the body (probes)
+func Unavailable(c *gin.Context, message string, data interface{}) {
+	writeJSON(c, http.StatusServiceUnavailable, Response{
+		Status:  false,
+		Message: message,
+		Data:    data,
+	})
+}
+
 // BadRequest sends a 400 error response
 func BadRequest(c *gin.Context, message string, err ...string) {
 	Error(c, http.StatusBadRequest, message, err...)
@@ -94,10 +110,18 @@ func Forbidden(c *gin.Context, message string) {
 
 // Paginated sends a standard paginated response
 func Paginated(c *gin.Context, message string, data interface{}, meta PaginationMeta) {
-	c.JSON(http.StatusOK, PaginatedResponse{
+	writeJSON(c, http.StatusOK, PaginatedResponse{
 		Status:  true,
 		Message: message,
 		Data:    data,
 		Meta:    meta,
 	})
 }
+
+// writeJSON sends body as JSON in a span of its own, so a trace tells the
+// time spent encoding a response apart from the handler's queries
+func writeJSON(c *gin.Context, statusCode int, body interface{}) {
+	_, span := tracer.Start(c.Request.Context(), "serialize response")
+	defer span.End()
+	c.JSON(statusCode, body)
+}
@@ -0,0 +1,20 @@
+package helpers
+
+import "context"
+
+// shutdownKey carries the channel closed when the server starts shutting down
+type shutdownKey struct{}
+
+// WithShutdown returns ctx carrying done, a channel closed when the server
+// starts shutting down. The server uses it as the base of request contexts.
+func WithShutdown(ctx context.Context, done <-chan struct{}) context.Context {
+	return context.WithValue(ctx, shutdownKey{}, done)
+}
+
+// ShuttingDown returns the channel closed when the server serving ctx starts
+// shutting down. Long-lived responses such as SSE streams end on it so they
+// do not hold up the shutdown; outside a server it is never closed.
+func ShuttingDown(ctx context.Context) <-chan struct{} {
+	done, _ := ctx.Value(shutdownKey{}).(<-chan struct{})
+	return done
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import "strings"
+
+// maxSlugLength caps generated slugs so a numeric suffix still fits the column
+const maxSlugLength = 120
+
+// Slugify converts a name into a lowercase, URL-friendly slug
+// ("iPhone 15 Pro (256GB)" -> "iphone-15-pro-256gb"). Characters other than
+// ASCII letters and digits become single hyphens. It returns "item" when the
+// name has no usable characters.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.TrimRight(b.String(), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		return "item"
+	}
+	return slug
+}
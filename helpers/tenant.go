@@ -0,0 +1,16 @@
+package helpers
+
+import (
+	"retail-core-api/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ClaimTenantID returns the tenant a token was issued for. Tokens issued
+// before multi-tenancy carry no tenant_id and belong to the default tenant.
+func ClaimTenantID(claims jwt.MapClaims) int {
+	if id, ok := claims["tenant_id"].(float64); ok {
+		return int(id)
+	}
+	return models.DefaultTenantID
+}
@@ -0,0 +1,618 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// swaggerSpec is the part of a Swagger 2.0 document the TypeScript client is
+// generated from
+type swaggerSpec struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths       map[string]map[string]json.RawMessage `json:"paths"`
+	Definitions map[string]*swaggerSchema             `json:"definitions"`
+}
+
+// swaggerOperation is a single method on a path
+type swaggerOperation struct {
+	OperationID string             `json:"operationId"`
+	Summary     string             `json:"summary"`
+	Description string             `json:"description"`
+	Produces    []string           `json:"produces"`
+	Parameters  []swaggerParameter `json:"parameters"`
+	Responses   map[string]struct {
+		Schema *swaggerSchema `json:"schema"`
+	} `json:"responses"`
+}
+
+// swaggerParameter is a path, query, header, body or form parameter
+type swaggerParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description"`
+	Required    bool           `json:"required"`
+	Type        string         `json:"type"`
+	Enum        []interface{}  `json:"enum"`
+	Items       *swaggerSchema `json:"items"`
+	Schema      *swaggerSchema `json:"schema"`
+}
+
+// swaggerSchema is a Swagger 2.0 schema object
+type swaggerSchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 string                    `json:"type"`
+	Description          string                    `json:"description"`
+	Enum                 []interface{}             `json:"enum"`
+	Items                *swaggerSchema            `json:"items"`
+	Properties           map[string]*swaggerSchema `json:"properties"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"`
+	Required             []string                  `json:"required"`
+	AllOf                []*swaggerSchema          `json:"allOf"`
+}
+
+// swaggerMethods are the operations generated, in output order
+var swaggerMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// tsReservedNames are browser globals a generated interface must not shadow
+var tsReservedNames = map[string]bool{
+	"Response": true, "Request": true, "Headers": true, "Error": true, "Event": true, "File": true, "Blob": true,
+}
+
+var (
+	tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	pathParam    = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// TypeScriptClient generates a dependency-free TypeScript client from a
+// Swagger 2.0 document: an interface per definition and a method per
+// operation on RetailApiClient, built on fetch. Methods are named after the
+// operationId, or the HTTP method and path when there is none, e.g.
+// GET /v1/products/{id} becomes getProductsById. Streaming (SSE) operations
+// are left out.
+func TypeScriptClient(specJSON []byte) ([]byte, error) {
+	var spec swaggerSpec
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI spec: %w", err)
+	}
+
+	g := &tsGenerator{spec: &spec, names: tsTypeNames(spec.Definitions), inputs: make(map[string]bool)}
+
+	ops, err := g.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by retailctl tsclient from the %s OpenAPI spec (version %s). DO NOT EDIT.\n\n",
+		spec.Info.Title, spec.Info.Version)
+	g.writeDefinitions(&b)
+	b.WriteString(tsRuntime)
+	for _, op := range ops {
+		g.writeMethod(&b, op)
+	}
+	b.WriteString("}\n")
+	return []byte(b.String()), nil
+}
+
+// tsGenerator holds the state of one TypeScript client generation
+type tsGenerator struct {
+	spec   *swaggerSpec
+	names  map[string]string // definition name -> TypeScript type name
+	inputs map[string]bool   // definitions sent as request bodies
+}
+
+// tsOperation is an operation with its generated method name
+type tsOperation struct {
+	method string
+	path   string
+	name   string
+	op     swaggerOperation
+}
+
+// tsTypeNames maps definitions such as "models.Product" to TypeScript names,
+// dropping the package unless that causes a clash
+func tsTypeNames(defs map[string]*swaggerSchema) map[string]string {
+	short := make(map[string]int)
+	for name := range defs {
+		short[tsPascal(name[strings.LastIndex(name, ".")+1:])]++
+	}
+
+	names := make(map[string]string, len(defs))
+	for name := range defs {
+		n := tsPascal(name[strings.LastIndex(name, ".")+1:])
+		if short[n] > 1 || tsReservedNames[n] {
+			n = tsPascal(name)
+		}
+		names[name] = n
+	}
+	return names
+}
+
+// operations decodes every operation, names it and records which
+// definitions are request bodies
+func (g *tsGenerator) operations() ([]tsOperation, error) {
+	paths := make([]string, 0, len(g.spec.Paths))
+	for path := range g.spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var ops []tsOperation
+	used := make(map[string]int)
+	for _, path := range paths {
+		for _, method := range swaggerMethods {
+			raw, ok := g.spec.Paths[path][method]
+			if !ok {
+				continue
+			}
+			var op swaggerOperation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("parsing %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if produces(op, "text/event-stream") {
+				continue
+			}
+
+			name := op.OperationID
+			if name == "" || !tsIdentifier.MatchString(name) {
+				name = tsMethodName(method, path)
+			}
+			if used[name]++; used[name] > 1 {
+				name += strconv.Itoa(used[name])
+			}
+
+			for _, p := range op.Parameters {
+				if p.In == "body" && p.Schema != nil {
+					g.markInput(p.Schema)
+				}
+			}
+			ops = append(ops, tsOperation{method: method, path: path, name: name, op: op})
+		}
+	}
+	return ops, nil
+}
+
+// markInput records a request body definition and everything it references,
+// so their optional fields are generated as optional
+func (g *tsGenerator) markInput(s *swaggerSchema) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		if g.inputs[name] {
+			return
+		}
+		g.inputs[name] = true
+		s = g.spec.Definitions[name]
+		if s == nil {
+			return
+		}
+	}
+	g.markInput(s.Items)
+	for _, p := range s.Properties {
+		g.markInput(p)
+	}
+	for _, a := range s.AllOf {
+		g.markInput(a)
+	}
+}
+
+// writeDefinitions writes an interface per definition. Fields of response
+// types are always present; fields of request bodies are optional unless the
+// spec marks them required.
+func (g *tsGenerator) writeDefinitions(b *strings.Builder) {
+	defs := make([]string, 0, len(g.spec.Definitions))
+	for name := range g.spec.Definitions {
+		defs = append(defs, name)
+	}
+	sort.Slice(defs, func(i, j int) bool { return g.names[defs[i]] < g.names[defs[j]] })
+
+	for _, name := range defs {
+		def := g.spec.Definitions[name]
+		writeDoc(b, "", def.Description)
+		if def.Type != "object" && len(def.Properties) == 0 {
+			fmt.Fprintf(b, "export type %s = %s;\n\n", g.names[name], g.tsType(def))
+			continue
+		}
+		fmt.Fprintf(b, "export interface %s %s\n\n", g.names[name], g.tsObject(def, g.inputs[name]))
+	}
+}
+
+// tsType returns the TypeScript type of a schema
+func (g *tsGenerator) tsType(s *swaggerSchema) string {
+	if s == nil {
+		return "unknown"
+	}
+	if s.Ref != "" {
+		if name, ok := g.names[strings.TrimPrefix(s.Ref, "#/definitions/")]; ok {
+			return name
+		}
+		return "unknown"
+	}
+	if len(s.AllOf) > 0 {
+		return g.tsAllOf(s.AllOf)
+	}
+	if len(s.Enum) > 0 {
+		return tsEnum(s.Enum)
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		item := g.tsType(s.Items)
+		if strings.ContainsAny(item, " |&") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "file":
+		return "Blob"
+	}
+
+	if len(s.Properties) > 0 {
+		return g.tsInline(s)
+	}
+	if len(s.AdditionalProperties) > 0 && string(s.AdditionalProperties) != "false" && string(s.AdditionalProperties) != "true" {
+		var value swaggerSchema
+		if err := json.Unmarshal(s.AdditionalProperties, &value); err == nil {
+			return "Record<string, " + g.tsType(&value) + ">"
+		}
+	}
+	if s.Type == "object" {
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// tsAllOf renders swag's response envelopes, e.g.
+// allOf[helpers.Response, {data: models.Product}], as the first type with the
+// listed properties replaced
+func (g *tsGenerator) tsAllOf(parts []*swaggerSchema) string {
+	t := g.tsType(parts[0])
+	for _, part := range parts[1:] {
+		if len(part.Properties) == 0 {
+			t += " & " + g.tsType(part)
+			continue
+		}
+		keys := sortedKeys(part.Properties)
+		for i, k := range keys {
+			keys[i] = strconv.Quote(k)
+		}
+		t = fmt.Sprintf("Omit<%s, %s> & %s", t, strings.Join(keys, " | "), g.tsInline(part))
+	}
+	return t
+}
+
+// tsObject renders the body of an interface. optional makes fields the spec
+// does not mark required optional.
+func (g *tsGenerator) tsObject(s *swaggerSchema, optional bool) string {
+	if len(s.Properties) == 0 {
+		return "{}"
+	}
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		writeDoc(&b, "  ", prop.Description)
+		mark := ""
+		if optional && !required[name] {
+			mark = "?"
+		}
+		fmt.Fprintf(&b, "  %s%s: %s;\n", tsKey(name), mark, g.tsType(prop))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// tsInline renders an anonymous object type on one line
+func (g *tsGenerator) tsInline(s *swaggerSchema) string {
+	fields := make([]string, 0, len(s.Properties))
+	for _, name := range sortedKeys(s.Properties) {
+		fields = append(fields, fmt.Sprintf("%s: %s", tsKey(name), g.tsType(s.Properties[name])))
+	}
+	return "{ " + strings.Join(fields, "; ") + " }"
+}
+
+// writeMethod writes the client method of an operation. Path parameters come
+// first, then the body or form, then an object of query parameters, then
+// fetch options.
+func (g *tsGenerator) writeMethod(b *strings.Builder, op tsOperation) {
+	var args, query []string
+	var body, form string
+	queryRequired := false
+	for _, p := range op.op.Parameters {
+		switch p.In {
+		case "path":
+			args = append(args, fmt.Sprintf("%s: %s", tsParamName(p.Name), g.paramType(p)))
+		case "body":
+			body = "body"
+			args = append(args, fmt.Sprintf("body: %s", g.tsType(p.Schema)))
+		case "formData":
+			form = "form"
+		case "query":
+			mark := "?"
+			if p.Required {
+				mark = ""
+				queryRequired = true
+			}
+			query = append(query, fmt.Sprintf("%s%s: %s", tsKey(p.Name), mark, g.paramType(p)))
+		}
+	}
+	if form != "" {
+		args = append(args, "form: FormData")
+	}
+	if len(query) > 0 {
+		mark := "?"
+		if queryRequired {
+			mark = ""
+		}
+		args = append(args, fmt.Sprintf("query%s: { %s }", mark, strings.Join(query, "; ")))
+	}
+	args = append(args, "init?: RequestInit")
+
+	path := "`" + pathParam.ReplaceAllStringFunc(op.path, func(m string) string {
+		return "${encodeURIComponent(String(" + tsParamName(m[1:len(m)-1]) + "))}"
+	}) + "`"
+
+	payload := "undefined"
+	if body != "" {
+		payload = body
+	} else if form != "" {
+		payload = form
+	}
+	queryArg := "undefined"
+	if len(query) > 0 {
+		queryArg = "query"
+	}
+
+	doc := op.op.Summary
+	if op.op.Description != "" && op.op.Description != doc {
+		doc = strings.TrimSpace(doc + "\n\n" + op.op.Description)
+	}
+	writeDoc(b, "  ", fmt.Sprintf("%s\n\n%s %s", doc, strings.ToUpper(op.method), op.path))
+
+	if !produces(op.op, "application/json") && len(op.op.Produces) > 0 {
+		fmt.Fprintf(b, "  %s(%s): Promise<Blob> {\n", op.name, strings.Join(args, ", "))
+		fmt.Fprintf(b, "    return this.requestBlob(%q, %s, %s, %s, init);\n  }\n\n", strings.ToUpper(op.method), path, queryArg, payload)
+		return
+	}
+	fmt.Fprintf(b, "  %s(%s): Promise<%s> {\n", op.name, strings.Join(args, ", "), g.successType(op.op))
+	fmt.Fprintf(b, "    return this.request(%q, %s, %s, %s, init);\n  }\n\n", strings.ToUpper(op.method), path, queryArg, payload)
+}
+
+// paramType returns the TypeScript type of a non-body parameter
+func (g *tsGenerator) paramType(p swaggerParameter) string {
+	return g.tsType(&swaggerSchema{Type: p.Type, Enum: p.Enum, Items: p.Items})
+}
+
+// successType returns the type of the lowest 2xx response with a body
+func (g *tsGenerator) successType(op swaggerOperation) string {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	var types []string
+	for _, code := range codes {
+		if schema := op.Responses[code].Schema; schema != nil {
+			types = append(types, g.tsType(schema))
+		}
+	}
+	switch len(types) {
+	case 0:
+		return "unknown"
+	case 1:
+		return types[0]
+	}
+	// e.g. 201 created or 202 submitted for approval
+	for i, t := range types {
+		if strings.Contains(t, "&") {
+			types[i] = "(" + t + ")"
+		}
+	}
+	return strings.Join(types, " | ")
+}
+
+// produces reports whether an operation lists the given content type
+func produces(op swaggerOperation, contentType string) bool {
+	for _, p := range op.Produces {
+		if p == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// tsMethodName names an operation after its HTTP method and path
+func tsMethodName(method, path string) string {
+	name := method
+	for _, seg := range strings.Split(path, "/") {
+		switch {
+		case seg == "" || seg == "api" || seg == "v1":
+		case strings.HasPrefix(seg, "{"):
+			name += "By" + tsPascal(strings.Trim(seg, "{}"))
+		default:
+			name += tsPascal(seg)
+		}
+	}
+	return name
+}
+
+// tsPascal converts "price-history", "total_pages" or "models.Product" to PascalCase
+func tsPascal(s string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// tsParamName converts a path parameter such as "related_id" to camelCase
+func tsParamName(s string) string {
+	p := tsPascal(s)
+	if p == "" {
+		return "param"
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+// tsKey quotes property names that are not valid identifiers
+func tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// tsEnum renders enum values as a union of literals
+func tsEnum(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		parts = append(parts, string(data))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// writeDoc writes a JSDoc comment
+func writeDoc(b *strings.Builder, indent, text string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "*/", "*\\/"))
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, text)
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s *%s\n", indent, strings.TrimRight(" "+line, " "))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
+
+// sortedKeys returns the keys of a schema property map in order
+func sortedKeys(m map[string]*swaggerSchema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tsRuntime is the hand-written part of the generated client
+const tsRuntime = `export interface ClientOptions {
+  /** Server root, e.g. "https://api.example.com" */
+  baseUrl: string;
+  /** JWT sent as a Bearer token; can also be set later with client.token */
+  token?: string;
+  /** fetch implementation; defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+/** A non-2xx response. body is the decoded problem details (or error envelope) when there is one. */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: unknown;
+
+  constructor(status: number, message: string, body: unknown) {
+    super(message);
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+type QueryValue = string | number | boolean | null | undefined | QueryValue[];
+
+export class RetailApiClient {
+  private readonly baseUrl: string;
+  private readonly fetchFn: typeof fetch;
+  token?: string;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, "");
+    this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis);
+    this.token = options.token;
+  }
+
+  private async send(method: string, path: string, query: object | undefined, body: unknown, init?: RequestInit): Promise<Response> {
+    let url = this.baseUrl + path;
+    if (query) {
+      const params = new URLSearchParams();
+      for (const [key, value] of Object.entries(query as Record<string, QueryValue>)) {
+        for (const v of Array.isArray(value) ? value : [value]) {
+          if (v !== undefined && v !== null && v !== "") params.append(key, String(v));
+        }
+      }
+      const qs = params.toString();
+      if (qs) url += "?" + qs;
+    }
+
+    const headers = new Headers(init?.headers);
+    if (!headers.has("Accept")) headers.set("Accept", "application/json");
+    if (this.token) headers.set("Authorization", ` + "`Bearer ${this.token}`" + `);
+    let payload: BodyInit | undefined;
+    if (body instanceof FormData) {
+      payload = body;
+    } else if (body !== undefined) {
+      headers.set("Content-Type", "application/json");
+      payload = JSON.stringify(body);
+    }
+
+    const res = await this.fetchFn(url, { ...init, method, headers, body: payload });
+    if (!res.ok) {
+      const text = await res.text();
+      let data: unknown = text;
+      try {
+        data = JSON.parse(text);
+      } catch {
+        // not JSON; keep the text
+      }
+      // Problem details under /v1, the { status, message } envelope on older paths
+      const problem = data as { detail?: string; title?: string; message?: string } | null;
+      const message = problem?.detail ?? problem?.message ?? problem?.title ?? res.statusText;
+      throw new ApiError(res.status, message, data);
+    }
+    return res;
+  }
+
+  protected async request<T>(method: string, path: string, query: object | undefined, body: unknown, init?: RequestInit): Promise<T> {
+    const res = await this.send(method, path, query, body, init);
+    const text = await res.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  protected async requestBlob(method: string, path: string, query: object | undefined, body: unknown, init?: RequestInit): Promise<Blob> {
+    const res = await this.send(method, path, query, body, init);
+    return res.blob();
+  }
+
+`
@@ -0,0 +1,194 @@
+package helpers
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// XLSXWriter streams a workbook of plain sheets (a bold header row followed
+// by text and number cells) in the Office Open XML format read by Excel,
+// LibreOffice and Google Sheets, without an external library. Rows are
+// written to the underlying writer as they are added, so large tables never
+// sit in memory. Sheets are written one after another: Sheet finishes the
+// previous one.
+type XLSXWriter struct {
+	zip    *zip.Writer
+	sheet  io.Writer
+	sheets []string
+	row    int
+	closed bool
+}
+
+// XLSXContentType is the MIME type of an XLSX workbook
+const XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// NewXLSXWriter starts a workbook on w
+func NewXLSXWriter(w io.Writer) *XLSXWriter {
+	return &XLSXWriter{zip: zip.NewWriter(w)}
+}
+
+// Sheet starts a new sheet with a header row. Names are cut to Excel's limit
+// of 31 characters.
+func (x *XLSXWriter) Sheet(name string, headers ...string) error {
+	if x.closed {
+		return errors.New("xlsx: workbook is closed")
+	}
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+	x.sheets = append(x.sheets, name)
+
+	sheet, err := x.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(x.sheets)))
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	x.row = 0
+	if _, err := io.WriteString(sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><sheetData>`); err != nil {
+		return err
+	}
+	return x.writeRow(headers, 1)
+}
+
+// Row appends a row to the current sheet. Cells that are ints or floats are
+// written as numbers, everything else as text.
+func (x *XLSXWriter) Row(cells ...interface{}) error {
+	if x.sheet == nil {
+		return errors.New("xlsx: Row called before Sheet")
+	}
+	return x.writeRow(cells, 0)
+}
+
+// Close finishes the last sheet and writes the workbook parts
+func (x *XLSXWriter) Close() error {
+	if x.closed {
+		return nil
+	}
+	x.closed = true
+	if len(x.sheets) == 0 {
+		if err := x.Sheet("Sheet1"); err != nil {
+			return err
+		}
+	}
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	var types, workbook, rels strings.Builder
+	types.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	rels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, name := range x.sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	types.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`, len(x.sheets)+1)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", types.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+		// Style 1 is the bold header
+		{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`},
+	}
+	for _, part := range parts {
+		w, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.body); err != nil {
+			return err
+		}
+	}
+	return x.zip.Close()
+}
+
+// endSheet closes the current sheet's XML, if any
+func (x *XLSXWriter) endSheet() error {
+	if x.sheet == nil {
+		return nil
+	}
+	_, err := io.WriteString(x.sheet, `</sheetData></worksheet>`)
+	x.sheet = nil
+	return err
+}
+
+// writeRow writes one row of cells with the given style
+func (x *XLSXWriter) writeRow(cells interface{}, style int) error {
+	x.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.row)
+
+	write := func(col int, cell interface{}) {
+		ref := xlsxColumn(col) + strconv.Itoa(x.row)
+		var number string
+		switch v := cell.(type) {
+		case int:
+			number = strconv.Itoa(v)
+		case int64:
+			number = strconv.FormatInt(v, 10)
+		case float64:
+			number = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		switch {
+		case number != "":
+			fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, number)
+		default:
+			text := fmt.Sprint(cell)
+			if cell == nil || text == "" {
+				return
+			}
+			fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(text))
+		}
+	}
+	switch row := cells.(type) {
+	case []string:
+		for i, cell := range row {
+			write(i, cell)
+		}
+	case []interface{}:
+		for i, cell := range row {
+			write(i, cell)
+		}
+	}
+
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+// xlsxColumn returns the letters of a zero-based column index (0 is A, 26 is AA)
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xmlEscape escapes text for an XML element or attribute, dropping control
+// characters XML cannot carry
+func xmlEscape(s string) string {
+	var b strings.Builder
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
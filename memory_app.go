@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"retail-core-api/config"
+	"retail-core-api/docs"
+	"retail-core-api/handlers"
+	"retail-core-api/helpers"
+	"retail-core-api/middleware"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"retail-core-api/rpc"
+	"retail-core-api/services"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// newMemoryApp serves the demo of STORAGE=memory: the category, product and
+// transaction routes on the in-memory repositories, which share one
+// MemoryStore. Everything else the routes touch only exists on PostgreSQL, so
+// the stand-ins below take its place: one default store, no price tiers,
+// promotions, translations, relations, suppliers or archive, and an audit log
+// that is not kept. There are no users either, so every request acts as the
+// owner. Nothing survives a restart.
+func newMemoryApp(deps appDeps) (http.Handler, http.Handler) {
+	cfg := deps.cfg
+	store := repositories.NewMemoryStore()
+	categoryRepo := repositories.NewMemoryCategoryRepository(store)
+	productRepo := repositories.NewMemoryProductRepository(store)
+	transactionRepo := repositories.NewMemoryTransactionRepository(store)
+
+	auditService := services.NewAuditService(discardAuditLog{})
+	featureFlagService := services.NewFeatureFlagService(noFlagSettings{}, demoStore{}, deps.featureFlags)
+	categoryService := services.NewCategoryService(categoryRepo)
+	productService := services.NewProductService(productRepo, categoryRepo, noRelations{}, noSuppliers{}, nil, noEvents{})
+	transactionService := services.NewTransactionService(transactionRepo, productRepo, noPromotions{}, noPriceTiers{}, demoStore{}, noArchive{}, featureFlagService)
+	translationService := services.NewTranslationService(noTranslations{}, productRepo, categoryRepo)
+	// The demo owner's changes never need approval
+	approvalService := services.NewApprovalService(nil, productRepo, productService, auditService, nil, false)
+
+	categoryHandler := handlers.NewCategoryHandler(categoryService, productService, translationService, auditService)
+	productHandler := handlers.NewProductHandler(productService, translationService, approvalService, auditService, noSuggestions{}, nil)
+	transactionHandler := handlers.NewTransactionHandler(transactionService)
+
+	etag := middleware.ETag()
+
+	r := gin.New()
+	r.Use(middleware.Logger())
+	r.Use(gin.Recovery())
+	r.Use(deps.cors.Handler())
+	r.Use(middleware.BodyLimit(cfg.MaxBodyBytes, middleware.RouteBodyLimits))
+	r.Use(middleware.Compress())
+
+	r.GET("/health", func(c *gin.Context) {
+		helpers.OK(c, "Server is running successfully", gin.H{"status": "OK"})
+	})
+	r.GET("/", func(c *gin.Context) {
+		helpers.OK(c, "Retail Core API", gin.H{
+			"name":    "Retail Core API",
+			"version": "1.0",
+			"api":     middleware.APIVersion,
+			"status":  "running",
+			"storage": config.StorageMemory,
+		})
+	})
+
+	// The docs describe every route, including those the demo does not serve
+	if cfg.DocsMode == config.DocsPublic {
+		docsHandler := handlers.NewDocsHandler(docs.SwaggerInfo, cfg.SwaggerServers(), ginSwagger.WrapHandler(swaggerFiles.Handler), middleware.RoutePermissions)
+		r.GET("/docs/*any", docsHandler.Serve)
+	}
+
+	api := r.Group(middleware.APIVersion)
+	api.Use(demoOwner)
+	{
+		// Categories
+		api.GET("/categories", etag, categoryHandler.List)
+		api.GET("/categories/tree", etag, categoryHandler.Tree)
+		api.GET("/categories/slug/:slug", etag, categoryHandler.GetBySlug)
+		api.GET("/categories/:id", etag, categoryHandler.GetByID)
+		api.GET("/categories/:id/products", etag, categoryHandler.GetProducts)
+		api.POST("/categories", categoryHandler.Create)
+		api.PUT("/categories/:id", categoryHandler.Update)
+		api.DELETE("/categories/:id", categoryHandler.Delete)
+
+		// Products
+		api.GET("/products", etag, productHandler.List)
+		api.GET("/products/slug/:slug", etag, productHandler.GetBySlug)
+		api.GET("/products/:id", etag, productHandler.GetByID)
+		api.POST("/products", productHandler.Create)
+		api.PUT("/products/:id", productHandler.Update)
+		api.DELETE("/products/:id", productHandler.Delete)
+
+		// Transactions / Checkout
+		api.POST("/checkout", transactionHandler.Checkout)
+		api.GET("/transactions", transactionHandler.ListTransactions)
+		api.GET("/transactions/:id", transactionHandler.GetTransactionByID)
+		api.PATCH("/transactions/:id/void", transactionHandler.VoidTransaction)
+
+		// Dashboard and sales reports
+		api.GET("/dashboard", transactionHandler.Dashboard)
+		api.GET("/report/today", transactionHandler.DailyReport)
+		api.GET("/report", transactionHandler.ReportByRange)
+		api.GET("/report/summary", transactionHandler.ReportSummary)
+		api.GET("/report/by-category", transactionHandler.CategorySalesReport)
+		api.GET("/report/hourly", transactionHandler.HourlySalesReport)
+		api.GET("/report/best-sellers", transactionHandler.BestSellersReport)
+		api.GET("/report/timeseries", transactionHandler.SalesTimeSeries)
+		api.GET("/report/profit", transactionHandler.ProfitReport)
+	}
+
+	grpcServer := rpc.NewServer(cfg.JWTSecret, models.DefaultTenantID)
+	rpc.RegisterCategoryService(grpcServer, categoryService)
+	rpc.RegisterProductService(grpcServer, productService)
+	rpc.RegisterTransactionService(grpcServer, transactionService)
+
+	return r, grpcServer
+}
+
+// demoOwner makes the requests of the demo act as the owner, the way
+// middleware.Auth does for a signed-in user
+func demoOwner(c *gin.Context) {
+	c.Set("user_name", "Demo")
+	c.Set("user_role", "owner")
+	c.Next()
+}
+
+// demoStore is the only store of the demo
+type demoStore struct{ repositories.StoreRepository }
+
+func (demoStore) GetDefault(ctx context.Context) (*models.Store, error) {
+	return &models.Store{ID: 1, Code: "MAIN", Name: "Main store", IsDefault: true, IsActive: true}, nil
+}
+
+func (s demoStore) GetByID(ctx context.Context, id int) (*models.Store, error) {
+	if id != 1 {
+		return nil, nil
+	}
+	return s.GetDefault(ctx)
+}
+
+type noPriceTiers struct {
+	repositories.PriceTierRepository
+}
+
+func (noPriceTiers) GetByProductID(ctx context.Context, productID int) ([]models.PriceTier, error) {
+	return nil, nil
+}
+
+func (noPriceTiers) GetByProductIDs(ctx context.Context, productIDs []int) (map[int][]models.PriceTier, error) {
+	return nil, nil
+}
+
+type noPromotions struct {
+	repositories.PromotionRepository
+}
+
+func (noPromotions) GetActive(ctx context.Context) ([]models.Promotion, error) {
+	return nil, nil
+}
+
+// noFlagSettings leaves every feature flag at its default
+type noFlagSettings struct {
+	repositories.FeatureFlagRepository
+}
+
+func (noFlagSettings) GetAll(ctx context.Context) ([]models.FeatureFlagSetting, error) {
+	return nil, nil
+}
+
+type noRelations struct {
+	repositories.ProductRelationRepository
+}
+
+func (noRelations) GetByProductID(ctx context.Context, productID int, relationType string) ([]models.ProductRelation, error) {
+	return nil, nil
+}
+
+// noSuppliers rejects the supplier_id of a product as not matching a supplier
+type noSuppliers struct {
+	repositories.SupplierRepository
+}
+
+func (noSuppliers) GetByID(ctx context.Context, id int) (*models.Supplier, error) {
+	return nil, nil
+}
+
+type noTranslations struct {
+	repositories.TranslationRepository
+}
+
+func (noTranslations) FindBest(ctx context.Context, entity string, entityIDs []int, locales []string) (map[int]models.Translation, error) {
+	return nil, nil
+}
+
+type discardAuditLog struct{ repositories.AuditRepository }
+
+func (discardAuditLog) Create(ctx context.Context, entry models.AuditLog) error {
+	return nil
+}
+
+type noArchive struct {
+	services.TransactionArchiveService
+}
+
+func (noArchive) GetArchived(ctx context.Context, id int) (*models.Transaction, error) {
+	return nil, nil
+}
+
+type noSuggestions struct {
+	services.CategorySuggestionService
+}
+
+func (noSuggestions) SuggestFor(ctx context.Context, product models.Product) (bool, error) {
+	return false, nil
+}
+
+type noEvents struct{}
+
+func (noEvents) Publish(ctx context.Context, event string, data interface{}) {}
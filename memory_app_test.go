@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"retail-core-api/config"
+	"retail-core-api/middleware"
+	"retail-core-api/services"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMemoryApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Storage: config.StorageMemory, DocsMode: config.DocsOff, MaxBodyBytes: 1 << 20}
+	app, _ := newMemoryApp(appDeps{
+		cfg:          cfg,
+		cors:         middleware.NewCORS(nil, nil, nil),
+		featureFlags: services.NewFeatureFlagDefaults(nil),
+	})
+
+	// call sends a request and decodes the data of its response
+	call := func(method, path, body string, wantStatus int, data interface{}) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if w.Code != wantStatus {
+			t.Fatalf("%s %s: status %d, want %d: %s", method, path, w.Code, wantStatus, w.Body.String())
+		}
+		if data != nil {
+			envelope := struct {
+				Data interface{} `json:"data"`
+			}{Data: data}
+			if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+		}
+	}
+
+	var category struct{ ID int }
+	call(http.MethodPost, "/v1/categories", `{"name": "Drinks"}`, http.StatusCreated, &category)
+	var product struct {
+		ID    int
+		Stock int
+	}
+	call(http.MethodPost, "/v1/products",
+		`{"name": "Green Tea", "price": 4000, "stock": 10, "cost_price": 2500, "category_id": `+strconv.Itoa(category.ID)+`}`,
+		http.StatusCreated, &product)
+	call(http.MethodPost, "/v1/products", `{"name": "Consigned", "price": 4000, "stock": 1, "supplier_id": 1}`, http.StatusBadRequest, nil)
+
+	var sale struct {
+		ID          int
+		TotalAmount int `json:"total_amount"`
+	}
+	call(http.MethodPost, "/v1/checkout",
+		`{"items": [{"product_id": `+strconv.Itoa(product.ID)+`, "quantity": 3}], "payment_method": "cash"}`,
+		http.StatusCreated, &sale)
+	if sale.TotalAmount != 12000 {
+		t.Fatalf("sale total %d, want 12000", sale.TotalAmount)
+	}
+	call(http.MethodGet, "/v1/products/"+strconv.Itoa(product.ID), "", http.StatusOK, &product)
+	if product.Stock != 7 {
+		t.Fatalf("stock %d after selling 3 of 10, want 7", product.Stock)
+	}
+
+	var report struct {
+		TotalRevenue int `json:"total_revenue"`
+	}
+	call(http.MethodGet, "/v1/report/today", "", http.StatusOK, &report)
+	if report.TotalRevenue != 12000 {
+		t.Fatalf("revenue today %d, want 12000", report.TotalRevenue)
+	}
+
+	call(http.MethodPatch, "/v1/transactions/"+strconv.Itoa(sale.ID)+"/void", "", http.StatusOK, nil)
+	call(http.MethodGet, "/v1/products/"+strconv.Itoa(product.ID), "", http.StatusOK, &product)
+	if product.Stock != 10 {
+		t.Fatalf("stock %d after voiding the sale, want 10", product.Stock)
+	}
+	call(http.MethodGet, "/v1/transactions/"+strconv.Itoa(sale.ID+1), "", http.StatusNotFound, nil)
+
+	// Routes needing what only exists on PostgreSQL are not served
+	call(http.MethodGet, "/v1/promotions", "", http.StatusNotFound, nil)
+}
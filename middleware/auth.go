@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"fmt"
-	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -11,7 +13,8 @@ import (
 
 // Auth validates the JWT token from the Authorization header or cookie
 // and sets user_id, user_email, user_role, user_name in the Gin context.
-func Auth(jwtSecret string) gin.HandlerFunc {
+// Tokens issued for another tenant are rejected.
+func Auth(jwtSecret string, tenantID int) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var tokenString string
 
@@ -20,10 +23,8 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 		if authHeader != "" {
 			parts := strings.SplitN(authHeader, " ", 2)
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-					"status":  false,
-					"message": "Invalid authorization format, expected: Bearer <token>",
-				})
+				helpers.Unauthorized(c, "Invalid authorization format, expected: Bearer <token>")
+				c.Abort()
 				return
 			}
 			tokenString = parts[1]
@@ -37,10 +38,8 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 		}
 
 		if tokenString == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Authorization required",
-			})
+			helpers.Unauthorized(c, "Authorization required")
+			c.Abort()
 			return
 		}
 
@@ -53,19 +52,20 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Invalid or expired token",
-			})
+			helpers.Unauthorized(c, "Invalid or expired token")
+			c.Abort()
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Invalid token claims",
-			})
+			helpers.Unauthorized(c, "Invalid token claims")
+			c.Abort()
+			return
+		}
+		if helpers.ClaimTenantID(claims) != tenantID {
+			helpers.Unauthorized(c, "Token belongs to another tenant")
+			c.Abort()
 			return
 		}
 
@@ -93,19 +93,15 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"status":  false,
-				"message": "Access denied",
-			})
+			helpers.Forbidden(c, "Access denied")
+			c.Abort()
 			return
 		}
 
 		role, ok := userRole.(string)
 		if !ok {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"status":  false,
-				"message": "Invalid user role",
-			})
+			helpers.Forbidden(c, "Invalid user role")
+			c.Abort()
 			return
 		}
 
@@ -116,9 +112,22 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 			}
 		}
 
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-			"status":  false,
-			"message": "Insufficient permissions",
-		})
+		helpers.Forbidden(c, "Insufficient permissions")
+		c.Abort()
+	}
+}
+
+// RequirePlatformKey returns middleware that only lets requests through
+// whose X-Platform-Key header matches key. It guards the tenant management
+// endpoints of a multi-tenant deployment, which sit above any one tenant.
+func RequirePlatformKey(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given := c.GetHeader(models.PlatformKeyHeader)
+		if given == "" || subtle.ConstantTimeCompare([]byte(given), []byte(key)) != 1 {
+			helpers.Unauthorized(c, "Invalid platform key")
+			c.Abort()
+			return
+		}
+		c.Next()
 	}
 }
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"retail-core-api/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteBodyLimits raises the body limit of routes that take uploads larger
+// than the default, keyed by "METHOD path" as registered on the router
+var RouteBodyLimits = map[string]int64{
+	"POST /v1/products/import": 5 << 20,
+}
+
+// BodyLimit caps request bodies at max bytes, or at the route's entry in
+// limits. A body declared larger is answered with 413 right away; one that
+// only turns out larger while streaming fails to read with
+// *http.MaxBytesError, which helpers.InvalidBody answers with 413.
+func BodyLimit(max int64, limits map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		limit := max
+		if l, ok := limits[c.Request.Method+" "+c.FullPath()]; ok {
+			limit = l
+		}
+		if c.Request.ContentLength > limit {
+			helpers.Error(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body is larger than %d bytes", limit))
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
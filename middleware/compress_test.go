@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// largeBody is a response body above compressMinSize
+var largeBody = strings.Repeat("retail core api ", 200)
+
+// newCompressRouter serves handler on /x, for every method, behind Compress
+func newCompressRouter(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress())
+	r.Any("/x", handler)
+	return r
+}
+
+// serve sends a request with an Accept-Encoding header to r
+func serve(r http.Handler, method, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/x", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// decode returns the body of a response, decoded by its Content-Encoding
+func decode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var r io.Reader = w.Body
+	var err error
+	switch w.Header().Get("Content-Encoding") {
+	case "gzip":
+		r, err = gzip.NewReader(w.Body)
+	case "deflate":
+		r, err = zlib.NewReader(w.Body)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestAcceptedEncoding(t *testing.T) {
+	tests := []struct{ header, want string }{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"br", ""},
+		{"deflate, gzip", "gzip"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"GZIP; q=0.8, deflate;q=0.9", "deflate"},
+		{"gzip;q=0", ""},
+		{"gzip;q=0, deflate;q=0", ""},
+		{"gzip;q=abc, deflate", "deflate"},
+		{"br, *", "gzip"},
+		{"*;q=0", ""},
+	}
+	for _, tt := range tests {
+		if got := acceptedEncoding(tt.header); got != tt.want {
+			t.Errorf("acceptedEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCompressNegotiation(t *testing.T) {
+	r := newCompressRouter(func(c *gin.Context) {
+		c.String(http.StatusOK, largeBody)
+	})
+
+	for _, tt := range []struct{ acceptEncoding, want string }{
+		{"gzip, deflate", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip;q=0.1, deflate;q=0.5", "deflate"},
+		{"br", ""},
+		{"", ""},
+	} {
+		w := serve(r, http.MethodGet, tt.acceptEncoding)
+		if got := w.Header().Get("Content-Encoding"); got != tt.want {
+			t.Fatalf("Accept-Encoding %q: Content-Encoding = %q, want %q", tt.acceptEncoding, got, tt.want)
+		}
+		if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+			t.Fatalf("Accept-Encoding %q: Vary = %q", tt.acceptEncoding, got)
+		}
+		if body := decode(t, w); body != largeBody {
+			t.Fatalf("Accept-Encoding %q: the body does not survive the round trip", tt.acceptEncoding)
+		}
+	}
+}
+
+func TestCompressSkips(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+	}{
+		{"a body under the minimum size", func(c *gin.Context) {
+			c.String(http.StatusOK, "short")
+		}},
+		{"an already compressed content type", func(c *gin.Context) {
+			c.Data(http.StatusOK, "image/png", []byte(largeBody))
+		}},
+		{"an already encoded body", func(c *gin.Context) {
+			c.Header("Content-Encoding", "br")
+			c.Data(http.StatusOK, "text/plain", []byte(largeBody))
+		}},
+		{"a range of a body", func(c *gin.Context) {
+			c.Data(http.StatusPartialContent, "text/plain", []byte(largeBody))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(newCompressRouter(tt.handler), http.MethodGet, "gzip")
+			if got := w.Header().Get("Content-Encoding"); got == "gzip" {
+				t.Fatal("the response was compressed")
+			}
+			if w.Body.Len() == 0 {
+				t.Fatal("the response has no body")
+			}
+		})
+	}
+}
+
+func TestCompressHead(t *testing.T) {
+	r := newCompressRouter(func(c *gin.Context) {
+		c.Header("Content-Length", "3200")
+		c.Status(http.StatusOK)
+	})
+	w := serve(r, http.MethodHead, "gzip")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("a HEAD response has Content-Encoding %q", got)
+	}
+	if got := w.Header().Get("Content-Length"); got != "3200" {
+		t.Fatalf("Content-Length = %q, want the handler's 3200", got)
+	}
+}
+
+func TestCompressErrorResponses(t *testing.T) {
+	t.Run("an abort without a body", func(t *testing.T) {
+		r := newCompressRouter(func(c *gin.Context) {
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+		})
+		w := serve(r, http.MethodGet, "gzip")
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("status %d, want %d", w.Code, http.StatusServiceUnavailable)
+		}
+		if got := w.Header().Get("Content-Encoding"); got != "" {
+			t.Fatalf("an empty response has Content-Encoding %q", got)
+		}
+	})
+
+	t.Run("an error with a body", func(t *testing.T) {
+		message := strings.Repeat("x", 2*compressMinSize)
+		r := newCompressRouter(func(c *gin.Context) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+		})
+		w := serve(r, http.MethodGet, "gzip")
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("status %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+		if body := decode(t, w); !strings.Contains(body, message) {
+			t.Fatalf("the error body was lost: %.80s", body)
+		}
+	})
+
+	t.Run("a short error", func(t *testing.T) {
+		r := newCompressRouter(func(c *gin.Context) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		})
+		w := serve(r, http.MethodGet, "gzip")
+		if w.Code != http.StatusNotFound || w.Header().Get("Content-Encoding") != "" {
+			t.Fatalf("status %d, Content-Encoding %q", w.Code, w.Header().Get("Content-Encoding"))
+		}
+		if body := w.Body.String(); body != `{"error":"not found"}` {
+			t.Fatalf("body %q", body)
+		}
+	})
+}
+
+func TestCompressFlush(t *testing.T) {
+	t.Run("a compressed stream", func(t *testing.T) {
+		first := "first chunk, under the minimum size"
+		var w *httptest.ResponseRecorder
+		r := newCompressRouter(func(c *gin.Context) {
+			c.Header("Content-Type", "text/plain")
+			c.String(http.StatusOK, first)
+			c.Writer.Flush()
+
+			// What was flushed reaches the client before the handler returns
+			if !w.Flushed {
+				t.Fatal("Flush did not reach the client")
+			}
+			zr, err := gzip.NewReader(strings.NewReader(w.Body.String()))
+			if err != nil {
+				t.Fatalf("the flushed bytes do not start a gzip stream: %v", err)
+			}
+			got := make([]byte, len(first))
+			if _, err := io.ReadFull(zr, got); err != nil || string(got) != first {
+				t.Fatalf("decoded %q (%v) from the flushed bytes, want %q", got, err, first)
+			}
+
+			c.String(http.StatusOK, largeBody)
+		})
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		r.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+			t.Fatalf("Content-Encoding = %q, want gzip", got)
+		}
+		if body := decode(t, w); body != first+largeBody {
+			t.Fatal("the stream does not decode to what was written")
+		}
+	})
+
+	t.Run("server-sent events", func(t *testing.T) {
+		event := "data: stock changed\n\n"
+		var w *httptest.ResponseRecorder
+		r := newCompressRouter(func(c *gin.Context) {
+			c.Header("Content-Type", "text/event-stream")
+			c.String(http.StatusOK, event)
+			c.Writer.Flush()
+			if w.Body.String() != event {
+				t.Fatalf("flushed %q, want the event as it is", w.Body.String())
+			}
+		})
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		r.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Content-Encoding"); got != "" {
+			t.Fatalf("an event stream has Content-Encoding %q", got)
+		}
+	})
+}
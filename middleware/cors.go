@@ -1,19 +1,51 @@
 package middleware
 
 import (
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-// CORS returns a configured CORS middleware
-func CORS() gin.HandlerFunc {
-	return cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:5173", "http://localhost:3000", "http://localhost:4173"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowHeaders:     []string{"Content-Type", "Authorization", "Accept", "X-Requested-With"},
-		AllowCredentials: true,
-		MaxAge:           24 * time.Hour,
-	})
+// CORS lets browser apps on the allowed origins call the API. Its settings
+// can be replaced while the server runs (see Update).
+type CORS struct {
+	handler atomic.Pointer[gin.HandlerFunc]
+}
+
+// NewCORS creates CORS middleware that lets browser apps on origins call the
+// API with methods and the request headers in headers (see
+// config.LoadConfig for the defaults). Credentials are allowed unless
+// origins is "*", which browsers reject together with credentials. Without
+// origins, cross-origin requests get no CORS headers, so browsers block them.
+func NewCORS(origins, methods, headers []string) *CORS {
+	c := &CORS{}
+	c.Update(origins, methods, headers)
+	return c
+}
+
+// Update replaces the allowed origins, methods and headers from the next
+// request on
+func (c *CORS) Update(origins, methods, headers []string) {
+	var handler gin.HandlerFunc = func(ctx *gin.Context) { ctx.Next() }
+	if len(origins) > 0 {
+		handler = cors.New(cors.Config{
+			AllowOrigins:     origins,
+			AllowMethods:     methods,
+			AllowHeaders:     headers,
+			ExposeHeaders:    []string{"Idempotent-Replayed", "Deprecation", "Link", "X-Request-ID"},
+			AllowCredentials: !slices.Contains(origins, "*"),
+			MaxAge:           24 * time.Hour,
+		})
+	}
+	c.handler.Store(&handler)
+}
+
+// Handler returns the middleware, which applies the latest settings
+func (c *CORS) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		(*c.handler.Load())(ctx)
+	}
 }
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag returns middleware for GET routes that tags a successful response
+// with a hash of its body and answers 304 Not Modified, without the body,
+// when the client's If-None-Match already names it. POS terminals polling the
+// catalog then only download it again after it changed. The tag is weak
+// because compression (see Compress) changes the bytes but not the content.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &etagWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.status != http.StatusOK {
+			original.WriteHeader(w.status)
+			_, _ = original.Write(w.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(w.body.Bytes())
+		tag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+		original.Header().Set("ETag", tag)
+		// The catalog is per user and tenant, and clients must check it is current
+		original.Header().Set("Cache-Control", "private, no-cache")
+		if etagMatches(c.GetHeader("If-None-Match"), tag) {
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		original.WriteHeader(http.StatusOK)
+		_, _ = original.Write(w.body.Bytes())
+	}
+}
+
+// etagMatches reports whether an If-None-Match header names tag, comparing
+// weakly (W/"x" matches "x") as RFC 9110 requires for If-None-Match
+func etagMatches(header, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagWriter holds back the status and body of a response until its tag is
+// known
+type etagWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *etagWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+// WriteHeaderNow is deferred like the rest of the response
+func (w *etagWriter) WriteHeaderNow() {}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *etagWriter) Status() int {
+	return w.status
+}
+
+func (w *etagWriter) Written() bool {
+	return w.body.Len() > 0
+}
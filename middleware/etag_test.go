@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newETagRouter serves handler on /x, for every method, behind ETag and
+// optionally Compress in front of it, as the catalog routes are
+func newETagRouter(compress bool, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if compress {
+		r.Use(Compress())
+	}
+	r.Any("/x", ETag(), handler)
+	return r
+}
+
+// serveIfNoneMatch sends a request with an If-None-Match header to r
+func serveIfNoneMatch(r http.Handler, method, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/x", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func catalog(c *gin.Context) {
+	c.String(http.StatusOK, largeBody)
+}
+
+func TestETagTagsResponses(t *testing.T) {
+	w := serveIfNoneMatch(newETagRouter(false, catalog), http.MethodGet, "")
+	if w.Code != http.StatusOK || w.Body.String() != largeBody {
+		t.Fatalf("status %d, body of %d bytes", w.Code, w.Body.Len())
+	}
+	tag := w.Header().Get("ETag")
+	if !strings.HasPrefix(tag, `W/"`) {
+		t.Fatalf("ETag = %q, want a weak tag", tag)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "private, no-cache" {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+
+	again := serveIfNoneMatch(newETagRouter(false, catalog), http.MethodGet, "")
+	if got := again.Header().Get("ETag"); got != tag {
+		t.Fatalf("the same body is tagged %q, then %q", tag, got)
+	}
+	changed := serveIfNoneMatch(newETagRouter(false, func(c *gin.Context) {
+		c.String(http.StatusOK, largeBody+"!")
+	}), http.MethodGet, "")
+	if got := changed.Header().Get("ETag"); got == tag {
+		t.Fatal("a changed body keeps its tag")
+	}
+}
+
+func TestETagNotModified(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		r := newETagRouter(compress, catalog)
+		tag := serveIfNoneMatch(r, http.MethodGet, "").Header().Get("ETag")
+		strong := strings.TrimPrefix(tag, "W/")
+
+		for _, tt := range []struct {
+			ifNoneMatch string
+			want        int
+		}{
+			{tag, http.StatusNotModified},
+			{strong, http.StatusNotModified},
+			{`"other", ` + tag, http.StatusNotModified},
+			{"*", http.StatusNotModified},
+			{`W/"other"`, http.StatusOK},
+		} {
+			w := serveIfNoneMatch(r, http.MethodGet, tt.ifNoneMatch)
+			if w.Code != tt.want {
+				t.Fatalf("compress %v, If-None-Match %s: status %d, want %d", compress, tt.ifNoneMatch, w.Code, tt.want)
+			}
+			if w.Header().Get("ETag") != tag {
+				t.Fatalf("compress %v, If-None-Match %s: ETag = %q, want %q", compress, tt.ifNoneMatch, w.Header().Get("ETag"), tag)
+			}
+			if tt.want == http.StatusNotModified {
+				if w.Body.Len() != 0 || w.Header().Get("Content-Encoding") != "" {
+					t.Fatalf("compress %v: a 304 has a body of %d bytes, Content-Encoding %q", compress, w.Body.Len(), w.Header().Get("Content-Encoding"))
+				}
+				continue
+			}
+			if compress && w.Header().Get("Content-Encoding") != "gzip" {
+				t.Fatal("a tagged response was not compressed")
+			}
+			if body := decode(t, w); body != largeBody {
+				t.Fatalf("compress %v: the body of a tagged response was changed", compress)
+			}
+		}
+	}
+}
+
+func TestETagErrorResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		status  int
+		body    string
+	}{
+		{"an error with a body", func(c *gin.Context) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		}, http.StatusNotFound, `{"error":"not found"}`},
+		{"an abort without a body", func(c *gin.Context) {
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+		}, http.StatusServiceUnavailable, ""},
+		{"a success other than 200", func(c *gin.Context) {
+			c.String(http.StatusAccepted, "queued")
+		}, http.StatusAccepted, "queued"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A matching If-None-Match must not turn an error into a 304
+			w := serveIfNoneMatch(newETagRouter(false, tt.handler), http.MethodGet, "*")
+			if w.Code != tt.status || w.Body.String() != tt.body {
+				t.Fatalf("status %d, body %q, want %d, %q", w.Code, w.Body.String(), tt.status, tt.body)
+			}
+			if got := w.Header().Get("ETag"); got != "" {
+				t.Fatalf("the response is tagged %q", got)
+			}
+		})
+	}
+}
+
+func TestETagOnlyTagsGet(t *testing.T) {
+	for _, method := range []string{http.MethodHead, http.MethodPost} {
+		r := newETagRouter(false, func(c *gin.Context) {
+			c.Header("Content-Length", "3200")
+			c.Status(http.StatusOK)
+		})
+		w := serveIfNoneMatch(r, method, "*")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, want 200", method, w.Code)
+		}
+		if got := w.Header().Get("ETag"); got != "" {
+			t.Fatalf("%s: the response is tagged %q", method, got)
+		}
+		if got := w.Header().Get("Content-Length"); got != "3200" {
+			t.Fatalf("%s: Content-Length = %q, want the handler's 3200", method, got)
+		}
+	}
+}
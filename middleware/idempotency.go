@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes POST and PATCH requests that carry an Idempotency-Key
+// header safe to retry: the first request with a key runs normally and its
+// response is stored; later requests with the same key and body get the
+// stored response back instead of running again. Keys are scoped per user and
+// expire after ttl.
+type Idempotency struct {
+	repo repositories.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotency creates the idempotency middleware
+func NewIdempotency(repo repositories.IdempotencyRepository, ttl time.Duration) *Idempotency {
+	return &Idempotency{repo: repo, ttl: ttl}
+}
+
+// Start purges expired keys every interval in the background
+func (i *Idempotency) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := i.repo.DeleteExpired(context.Background(), time.Now().Add(-i.ttl))
+			if err != nil {
+				slog.Error("failed to purge expired keys", "component", "idempotency", "error", err)
+			} else if n > 0 {
+				slog.Info("purged expired keys", "component", "idempotency", "count", n)
+			}
+		}
+	}()
+}
+
+// Handler returns the middleware. It must run after Auth.
+func (i *Idempotency) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(models.IdempotencyKeyHeader)
+		if key == "" || (c.Request.Method != http.MethodPost && c.Request.Method != http.MethodPatch) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			helpers.BadRequest(c, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			helpers.InvalidBody(c, err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+		hash.Write(body)
+
+		record := models.IdempotencyRecord{
+			UserID:      c.GetInt("user_id"),
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: hex.EncodeToString(hash.Sum(nil)),
+		}
+		existing, err := i.repo.Reserve(c.Request.Context(), record, time.Now().Add(-i.ttl))
+		if err != nil {
+			helpers.InternalError(c, "Failed to check Idempotency-Key", err.Error())
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			i.replay(c, record, existing)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Server errors are not stored so the client can retry with the same
+		// key. The result is stored even if the client has gone away.
+		ctx := context.WithoutCancel(c.Request.Context())
+		if recorder.Status() >= http.StatusInternalServerError {
+			err = i.repo.Release(ctx, record.UserID, key)
+		} else {
+			err = i.repo.Complete(ctx, record.UserID, key, recorder.Status(), recorder.body.Bytes())
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to store result", "component", "idempotency", "key", key, "error", err)
+		}
+	}
+}
+
+// replay answers a repeated request from the stored record
+func (i *Idempotency) replay(c *gin.Context, record models.IdempotencyRecord, existing *models.IdempotencyRecord) {
+	defer c.Abort()
+
+	if existing.RequestHash != record.RequestHash {
+		helpers.Error(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+		return
+	}
+	if existing.StatusCode == 0 {
+		helpers.Error(c, http.StatusConflict, "A request with this Idempotency-Key is still in progress")
+		return
+	}
+
+	c.Header(models.IdempotencyReplayedHeader, "true")
+	contentType := "application/json; charset=utf-8"
+	if existing.StatusCode >= http.StatusBadRequest && !helpers.IsLegacy(c.Request) {
+		contentType = helpers.ProblemContentType
+	}
+	c.Data(existing.StatusCode, contentType, existing.ResponseBody)
+}
+
+// responseRecorder copies the response body while writing it through
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
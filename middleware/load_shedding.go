@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"retail-core-api/helpers"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoadShedder watches database health and rejects low-priority requests
+// while the database is slow or its connection pool is nearly exhausted,
+// keeping capacity for checkout and other critical traffic.
+type LoadShedder struct {
+	db *sql.DB
+
+	overloaded atomic.Bool
+	latency    atomic.Int64
+	poolUsage  atomic.Uint64 // percentage * 100
+
+	mu           sync.Mutex
+	maxLatency   time.Duration
+	maxPoolUsage float64
+	reason       string
+	shedTotal    int64
+	shedByRoute  map[string]int64
+}
+
+// LoadSheddingStats is a snapshot of the shedder state exposed as metrics
+type LoadSheddingStats struct {
+	Overloaded     bool             `json:"overloaded" example:"false"`
+	Reason         string           `json:"reason,omitempty" example:"db latency 812ms exceeds 500ms"`
+	DBLatencyMs    int64            `json:"db_latency_ms" example:"12"`
+	PoolUsage      float64          `json:"pool_usage" example:"0.24"`
+	MaxDBLatencyMs int64            `json:"max_db_latency_ms" example:"500"`
+	MaxPoolUsage   float64          `json:"max_pool_usage" example:"0.9"`
+	ShedTotal      int64            `json:"shed_total" example:"0"`
+	ShedByRoute    map[string]int64 `json:"shed_by_route"`
+}
+
+// NewLoadShedder creates a load shedder for db. Requests are shed when a
+// ping takes longer than maxLatency or when the share of open connections in
+// use reaches maxPoolUsage (0-1).
+func NewLoadShedder(db *sql.DB, maxLatency time.Duration, maxPoolUsage float64) *LoadShedder {
+	return &LoadShedder{
+		db:           db,
+		maxLatency:   maxLatency,
+		maxPoolUsage: maxPoolUsage,
+		shedByRoute:  make(map[string]int64),
+	}
+}
+
+// Start probes the database every interval in the background
+func (l *LoadShedder) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			l.probe()
+		}
+	}()
+}
+
+// SetLimits replaces the ping latency and pool usage (0-1) at which
+// requests are shed, from the next probe on
+func (l *LoadShedder) SetLimits(maxLatency time.Duration, maxPoolUsage float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxLatency = maxLatency
+	l.maxPoolUsage = maxPoolUsage
+}
+
+// probe measures ping latency and pool saturation and updates the overload flag
+func (l *LoadShedder) probe() {
+	l.mu.Lock()
+	maxLatency, maxPoolUsage := l.maxLatency, l.maxPoolUsage
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*maxLatency)
+	defer cancel()
+
+	start := time.Now()
+	err := l.db.PingContext(ctx)
+	latency := time.Since(start)
+
+	stats := l.db.Stats()
+	usage := 0.0
+	if stats.MaxOpenConnections > 0 {
+		usage = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+
+	reason := ""
+	switch {
+	case err != nil:
+		reason = "db ping failed: " + err.Error()
+	case latency > maxLatency:
+		reason = "db latency " + latency.Round(time.Millisecond).String() + " exceeds " + maxLatency.String()
+	case usage >= maxPoolUsage:
+		reason = "db pool saturated"
+	}
+
+	l.latency.Store(latency.Milliseconds())
+	l.poolUsage.Store(uint64(usage * 10000))
+
+	overloaded := reason != ""
+	if was := l.overloaded.Swap(overloaded); was != overloaded {
+		if overloaded {
+			slog.Warn("shedding low-priority traffic", "component", "load-shedding", "reason", reason)
+		} else {
+			slog.Info("database healthy again, no longer shedding", "component", "load-shedding")
+		}
+	}
+
+	l.mu.Lock()
+	l.reason = reason
+	l.mu.Unlock()
+}
+
+// Shed returns middleware for low-priority routes (reports, exports) that
+// responds 503 while the database is overloaded
+func (l *LoadShedder) Shed() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !l.overloaded.Load() {
+			c.Next()
+			return
+		}
+
+		l.mu.Lock()
+		l.shedTotal++
+		l.shedByRoute[c.Request.Method+" "+c.FullPath()]++
+		l.mu.Unlock()
+
+		c.Header("Retry-After", "5")
+		helpers.Error(c, http.StatusServiceUnavailable, "Server is busy, please retry shortly")
+		c.Abort()
+	}
+}
+
+// Stats returns the current shedder state and shed counters
+func (l *LoadShedder) Stats() LoadSheddingStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	byRoute := make(map[string]int64, len(l.shedByRoute))
+	for route, count := range l.shedByRoute {
+		byRoute[route] = count
+	}
+
+	return LoadSheddingStats{
+		Overloaded:     l.overloaded.Load(),
+		Reason:         l.reason,
+		DBLatencyMs:    l.latency.Load(),
+		PoolUsage:      float64(l.poolUsage.Load()) / 10000,
+		MaxDBLatencyMs: l.maxLatency.Milliseconds(),
+		MaxPoolUsage:   l.maxPoolUsage,
+		ShedTotal:      l.shedTotal,
+		ShedByRoute:    byRoute,
+	}
+}
@@ -1,33 +1,46 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Logger returns a custom request logging middleware
+// Logger returns a request logging middleware that writes one structured
+// record per request: method, path, status, latency and client IP, plus the
+// errors handlers attached to the context. Server errors are logged at error
+// level and client errors at warn.
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
 
 		// Process request
 		c.Next()
 
-		latency := time.Since(start)
 		status := c.Writer.Status()
-		method := c.Request.Method
-		clientIP := c.ClientIP()
-
-		if query != "" {
-			path = path + "?" + query
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency", time.Since(start),
+			"client_ip", c.ClientIP(),
+		}
+		if query := c.Request.URL.RawQuery; query != "" {
+			attrs = append(attrs, "query", query)
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "error", strings.Join(c.Errors.Errors(), "; "))
 		}
 
-		log.Printf("[%d] %s %s | %s | %v",
-			status, method, path, clientIP, latency,
-		)
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		slog.Log(c.Request.Context(), level, "request", attrs...)
 	}
 }
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Roles are the user roles, in the order the role-scoped docs list them
+var Roles = []string{"owner", "cashier"}
+
+// Permission restricts a route to roles. Method is empty for every method;
+// a Path ending in "/*" covers the path itself and everything below it.
+// Path parameters match any parameter, whatever their name.
+type Permission struct {
+	Method string
+	Path   string
+	Roles  []string
+}
+
+// Permissions is the role mapping of the API; routes it does not list are
+// open to every authenticated role. The same mapping is enforced by
+// Authorize and filters the role-scoped docs, so the two cannot drift.
+type Permissions []Permission
+
+// ownerOnly is shorthand for the many owner-only routes
+var ownerOnly = []string{"owner"}
+
+// RoutePermissions is the role mapping enforced on /v1
+var RoutePermissions = Permissions{
+	{Method: "PUT", Path: "/v1/categories/:id/translations/:locale", Roles: ownerOnly},
+	{Method: "DELETE", Path: "/v1/categories/:id/translations/:locale", Roles: ownerOnly},
+
+	{Method: "POST", Path: "/v1/products/import", Roles: ownerOnly},
+	{Method: "POST", Path: "/v1/products/:id/scheduled-prices", Roles: ownerOnly},
+	{Method: "DELETE", Path: "/v1/products/:id/scheduled-prices/:schedule_id", Roles: ownerOnly},
+	{Method: "PUT", Path: "/v1/products/:id/price-tiers", Roles: ownerOnly},
+	{Method: "PUT", Path: "/v1/products/:id/translations/:locale", Roles: ownerOnly},
+	{Method: "DELETE", Path: "/v1/products/:id/translations/:locale", Roles: ownerOnly},
+
+	// Promotions, suppliers and stores: writes are owner only
+	{Method: "POST", Path: "/v1/promotions", Roles: ownerOnly},
+	{Method: "PUT", Path: "/v1/promotions/:id", Roles: ownerOnly},
+	{Method: "DELETE", Path: "/v1/promotions/:id", Roles: ownerOnly},
+	{Method: "POST", Path: "/v1/suppliers", Roles: ownerOnly},
+	{Method: "PUT", Path: "/v1/suppliers/:id", Roles: ownerOnly},
+	{Method: "DELETE", Path: "/v1/suppliers/:id", Roles: ownerOnly},
+	{Method: "POST", Path: "/v1/stores", Roles: ownerOnly},
+	{Method: "PUT", Path: "/v1/stores/:id", Roles: ownerOnly},
+
+	{Method: "POST", Path: "/v1/stock-transfers/:id/cancel", Roles: ownerOnly},
+	{Method: "POST", Path: "/v1/purchase-orders", Roles: ownerOnly},
+	{Method: "POST", Path: "/v1/purchase-orders/:id/cancel", Roles: ownerOnly},
+
+	// Catalog governance
+	{Path: "/v1/catalog/approvals/*", Roles: ownerOnly},
+	{Path: "/v1/catalog/changesets/*", Roles: ownerOnly},
+	{Path: "/v1/category-rules/*", Roles: ownerOnly},
+	{Path: "/v1/category-suggestions/*", Roles: ownerOnly},
+
+	// Stocktake and cycle counting
+	{Method: "POST", Path: "/v1/inventory/count-sessions", Roles: ownerOnly},
+	{Path: "/v1/inventory/cycle-count-schedules/*", Roles: ownerOnly},
+	{Method: "GET", Path: "/v1/inventory/cycle-count-compliance", Roles: ownerOnly},
+
+	// Reports
+	{Method: "GET", Path: "/v1/report/profit", Roles: ownerOnly},
+	{Method: "GET", Path: "/v1/report/inventory-valuation", Roles: ownerOnly},
+	{Method: "GET", Path: "/v1/report/stores", Roles: ownerOnly},
+	{Method: "GET", Path: "/v1/report/promotions", Roles: ownerOnly},
+	{Path: "/v1/report-schedules/*", Roles: ownerOnly},
+
+	// Administration
+	{Method: "GET", Path: "/v1/audit-logs", Roles: ownerOnly},
+	{Path: "/v1/sync/*", Roles: ownerOnly},
+	{Path: "/v1/webhooks/*", Roles: ownerOnly},
+	{Path: "/v1/admin/*", Roles: ownerOnly},
+	{Path: "/v1/users/*", Roles: ownerOnly},
+}
+
+// RolesFor returns the roles allowed to call a route, nil when every
+// authenticated role may. path is either a gin route ("/v1/users/:id") or a
+// spec path ("/v1/users/{id}").
+func (p Permissions) RolesFor(method, path string) []string {
+	segments := routeSegments(path)
+	for _, perm := range p {
+		if perm.Method != "" && perm.Method != method {
+			continue
+		}
+		if routeMatches(routeSegments(perm.Path), segments) {
+			return perm.Roles
+		}
+	}
+	return nil
+}
+
+// Allows reports whether role may call a route
+func (p Permissions) Allows(role, method, path string) bool {
+	roles := p.RolesFor(method, path)
+	if roles == nil {
+		return true
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// routeSegments splits a route into segments, replacing path parameters
+// (":id", "*path" or "{id}") with ":"
+func routeSegments(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		isParam := strings.HasPrefix(s, ":") || (strings.HasPrefix(s, "*") && len(s) > 1) ||
+			(strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"))
+		if isParam {
+			segments[i] = ":"
+		}
+	}
+	return segments
+}
+
+// routeMatches reports whether a route matches a permission pattern; a
+// trailing "*" segment matches zero or more segments
+func routeMatches(pattern, route []string) bool {
+	if n := len(pattern); n > 0 && pattern[n-1] == "*" {
+		prefix := pattern[:n-1]
+		return len(route) >= len(prefix) && segmentsEqual(prefix, route[:len(prefix)])
+	}
+	return len(pattern) == len(route) && segmentsEqual(pattern, route)
+}
+
+// segmentsEqual compares two routes segment by segment
+func segmentsEqual(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Authorize returns middleware enforcing perms on the matched route. It runs
+// after Auth, which sets the user role.
+func Authorize(perms Permissions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles := perms.RolesFor(c.Request.Method, c.FullPath())
+		if roles == nil {
+			c.Next()
+			return
+		}
+		RequireRole(roles...)(c)
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"retail-core-api/helpers"
+	"strings"
+)
+
+// APIVersion is the path prefix of the current API version
+const APIVersion = "/v1"
+
+// legacyPrefixes are the unversioned prefixes the API was served under
+// before /v1, with the versioned prefix that replaces each
+var legacyPrefixes = []struct{ old, new string }{
+	{"/api", APIVersion},
+	{"/auth", APIVersion + "/auth"},
+}
+
+// LegacyPaths keeps the unversioned paths from before /v1 working: /api/...
+// is served as /v1/... and /auth/... as /v1/auth/..., so a request reaches
+// the same route, permissions and idempotency keys either way. Responses to
+// old paths carry a Deprecation header and a Link to their /v1 path, and the
+// request is marked (helpers.MarkLegacy) so its responses keep their old
+// shape where /v1 changed it, e.g. errors.
+func LegacyPaths(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, ok := VersionedPath(r.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+path+">; rel=\"successor-version\"")
+
+		u := *r.URL
+		u.Path, u.RawPath = path, ""
+		r = helpers.MarkLegacy(r)
+		r.URL = &u
+		next.ServeHTTP(w, r)
+	})
+}
+
+// VersionedPath returns the /v1 path of a legacy path
+func VersionedPath(path string) (string, bool) {
+	for _, p := range legacyPrefixes {
+		if rest, ok := strings.CutPrefix(path, p.old); ok && (rest == "" || rest[0] == '/') {
+			return p.new + rest, true
+		}
+	}
+	return "", false
+}
+
+// LegacyPath returns the unversioned path a /v1 path is also served under:
+// /v1/auth/... as /auth/... and any other /v1/... as /api/...
+func LegacyPath(path string) (string, bool) {
+	legacy, matched := "", ""
+	for _, p := range legacyPrefixes {
+		rest, ok := strings.CutPrefix(path, p.new)
+		// The longest matching prefix wins, so /v1/auth is not /api/auth
+		if ok && (rest == "" || rest[0] == '/') && len(p.new) > len(matched) {
+			legacy, matched = p.old+rest, p.new
+		}
+	}
+	return legacy, matched != ""
+}
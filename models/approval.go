@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Product change request statuses and actions
+const (
+	ChangeStatusPending  = "pending"
+	ChangeStatusApproved = "approved"
+	ChangeStatusRejected = "rejected"
+
+	ChangeActionCreate = "create"
+	ChangeActionUpdate = "update"
+)
+
+// Actor identifies the authenticated user performing an action
+type Actor struct {
+	UserID int
+	Name   string
+	Role   string
+}
+
+// ProductChangeRequest represents a product creation or price change awaiting approval
+// @Description Product creation or price change submitted by a non-owner user, pending owner approval
+type ProductChangeRequest struct {
+	ID              int        `json:"id" example:"1"`
+	Action          string     `json:"action" example:"update" enums:"create,update"`
+	ProductID       *int       `json:"product_id" example:"3"`
+	Payload         Product    `json:"payload"`
+	Status          string     `json:"status" example:"pending" enums:"pending,approved,rejected"`
+	RequestedBy     int        `json:"requested_by" example:"2"`
+	RequestedByName string     `json:"requested_by_name" example:"Cashier One"`
+	ReviewedBy      *int       `json:"reviewed_by" example:"1"`
+	ReviewNote      string     `json:"review_note" example:""`
+	CreatedAt       time.Time  `json:"created_at" example:"2026-02-08T12:00:00Z"`
+	ReviewedAt      *time.Time `json:"reviewed_at" example:"2026-02-08T13:00:00Z"`
+}
+
+// ReviewInput represents the body of an approve/reject request
+// @Description Optional note recorded with an approval decision
+type ReviewInput struct {
+	Note string `json:"note" example:"Price confirmed with supplier"`
+}
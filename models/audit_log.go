@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// Audited entity types
+const (
+	AuditEntityCategory    = "category"
+	AuditEntityProduct     = "product"
+	AuditEntityPromotion   = "promotion"
+	AuditEntityStore       = "store"
+	AuditEntitySupplier    = "supplier"
+	AuditEntityTransaction = "transaction"
+	AuditEntityUser        = "user"
+)
+
+// AuditLog represents a recorded change to a catalog or admin entity
+// @Description Who changed what and when, with the entity state before and after the change
+type AuditLog struct {
+	ID         int             `json:"id" example:"1"`
+	EntityType string          `json:"entity_type" example:"product" enums:"category,product,promotion,store,supplier,transaction,user"`
+	EntityID   int             `json:"entity_id" example:"3"`
+	Action     string          `json:"action" example:"update" enums:"create,update,delete"`
+	ActorID    *int            `json:"actor_id" example:"1"`
+	ActorName  string          `json:"actor_name" example:"Store Owner"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at" example:"2026-02-10T09:30:00Z"`
+}
+
+// AuditLogParams holds the query parameters for listing audit logs
+type AuditLogParams struct {
+	EntityType string
+	EntityID   *int
+	ActorID    *int
+	Action     string
+	StartDate  string
+	EndDate    string
+	Page       int
+	Limit      int
+}
+
+// PaginatedAuditLogs represents a paginated list of audit logs
+// @Description Paginated list of audit logs
+type PaginatedAuditLogs struct {
+	Data       []AuditLog `json:"data"`
+	Total      int        `json:"total" example:"100"`
+	Page       int        `json:"page" example:"1"`
+	Limit      int        `json:"limit" example:"20"`
+	TotalPages int        `json:"total_pages" example:"5"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Catalog export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ProductExportColumns are the columns of a product export. The first ones
+// are the ProductImportColumns, so an edited export can be imported again;
+// the import ignores the rest.
+var ProductExportColumns = []string{
+	"name", "sku", "price", "stock", "category",
+	"id", "slug", "unit", "min_stock", "cost_price", "is_active", "updated_at",
+}
+
+// CategoryExportColumns are the columns of a category export
+var CategoryExportColumns = []string{"id", "name", "slug", "parent_id", "parent", "description"}
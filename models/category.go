@@ -3,11 +3,13 @@ package models
 import "time"
 
 // Category represents a category entity
-// @Description Category information with ID, name and description
+// @Description Category information with ID, name, description and optional parent category
 type Category struct {
 	ID          int       `json:"id" example:"1"`
 	Name        string    `json:"name" example:"Electronics" binding:"required"`
+	Slug        string    `json:"slug" example:"electronics"`
 	Description string    `json:"description" example:"Electronic devices and gadgets"`
+	ParentID    *int      `json:"parent_id" example:"1"`
 	CreatedAt   time.Time `json:"created_at" example:"2024-01-30T12:00:00Z"`
 	UpdatedAt   time.Time `json:"updated_at" example:"2024-01-30T12:00:00Z"`
 }
@@ -17,4 +19,12 @@ type Category struct {
 type CategoryInput struct {
 	Name        string `json:"name" example:"Electronics" binding:"required"`
 	Description string `json:"description" example:"Electronic devices and gadgets"`
-}
\ No newline at end of file
+	ParentID    *int   `json:"parent_id" example:"1" binding:"omitempty,min=1"`
+}
+
+// CategoryTreeNode represents a category with its nested subcategories
+// @Description Category with nested child categories
+type CategoryTreeNode struct {
+	Category
+	Children []CategoryTreeNode `json:"children"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Category suggestion statuses and sources
+const (
+	SuggestionStatusPending  = "pending"
+	SuggestionStatusAccepted = "accepted"
+	SuggestionStatusRejected = "rejected"
+
+	SuggestionSourceRule       = "rule"
+	SuggestionSourceHeuristic  = "heuristic"
+	SuggestionSourceClassifier = "classifier"
+)
+
+// CategoryRule maps a keyword in product names or SKUs to a category
+// @Description Keyword rule used to suggest a category for uncategorized products
+type CategoryRule struct {
+	ID           int       `json:"id" example:"1"`
+	CategoryID   int       `json:"category_id" example:"2"`
+	CategoryName string    `json:"category_name" example:"Beverages"`
+	Keyword      string    `json:"keyword" example:"coffee"`
+	Weight       int       `json:"weight" example:"3"`
+	CreatedAt    time.Time `json:"created_at" example:"2026-03-01T12:00:00Z"`
+}
+
+// CategoryRuleInput represents the input for creating a category rule
+// @Description Keyword rule; multi-word keywords match as a phrase and a higher weight wins over other matching rules
+type CategoryRuleInput struct {
+	CategoryID int    `json:"category_id" example:"2" binding:"required,min=1"`
+	Keyword    string `json:"keyword" example:"coffee" binding:"required,max=100"`
+	Weight     int    `json:"weight" example:"3" binding:"omitempty,min=1,max=100"`
+}
+
+// CategorySuggestion is a suggested category for an uncategorized product,
+// waiting in the review queue
+// @Description Suggested category for an uncategorized product with the engine that produced it and its confidence
+type CategorySuggestion struct {
+	ID             int        `json:"id" example:"1"`
+	ProductID      int        `json:"product_id" example:"42"`
+	ProductName    string     `json:"product_name" example:"Kapal Api Kopi Bubuk 165g"`
+	SKU            string     `json:"sku" example:"KPA-165"`
+	CategoryID     int        `json:"category_id" example:"2"`
+	CategoryName   string     `json:"category_name" example:"Beverages"`
+	Confidence     float64    `json:"confidence" example:"0.85"`
+	Source         string     `json:"source" example:"rule" enums:"rule,heuristic,classifier"`
+	Reason         string     `json:"reason" example:"keyword \"kopi\""`
+	Status         string     `json:"status" example:"pending" enums:"pending,accepted,rejected"`
+	ReviewedBy     *int       `json:"reviewed_by" example:"1"`
+	ReviewedByName string     `json:"reviewed_by_name,omitempty" example:"Owner"`
+	CreatedAt      time.Time  `json:"created_at" example:"2026-03-01T12:00:00Z"`
+	ReviewedAt     *time.Time `json:"reviewed_at" example:"2026-03-01T13:00:00Z"`
+}
+
+// CategorySuggestionAcceptInput represents the body of an accept request
+// @Description Optional category overriding the suggested one when accepting
+type CategorySuggestionAcceptInput struct {
+	CategoryID *int `json:"category_id" example:"3"`
+}
+
+// CategorySuggestionRun summarizes a scan of uncategorized products
+// @Description Result of generating category suggestions for uncategorized products
+type CategorySuggestionRun struct {
+	Scanned   int `json:"scanned" example:"120"`
+	Suggested int `json:"suggested" example:"87"`
+	Skipped   int `json:"skipped" example:"33"`
+}
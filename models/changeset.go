@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Catalog changeset statuses
+const (
+	ChangesetStatusDraft     = "draft"
+	ChangesetStatusScheduled = "scheduled"
+	ChangesetStatusPublished = "published"
+	ChangesetStatusCancelled = "cancelled"
+)
+
+// CatalogChangeset represents a set of draft product changes published together
+// @Description Draft catalog changes that go live atomically, either on demand or at a scheduled time
+type CatalogChangeset struct {
+	ID          int                    `json:"id" example:"1"`
+	Name        string                 `json:"name" example:"Rainy season catalog"`
+	Status      string                 `json:"status" example:"scheduled" enums:"draft,scheduled,published,cancelled"`
+	PublishAt   *time.Time             `json:"publish_at" example:"2026-03-01T00:00:00Z"`
+	CreatedBy   int                    `json:"created_by" example:"1"`
+	PublishedAt *time.Time             `json:"published_at" example:"2026-03-01T00:00:05Z"`
+	LastError   string                 `json:"last_error" example:""`
+	CreatedAt   time.Time              `json:"created_at" example:"2026-02-20T12:00:00Z"`
+	UpdatedAt   time.Time              `json:"updated_at" example:"2026-02-20T12:00:00Z"`
+	Items       []CatalogChangesetItem `json:"items"`
+}
+
+// CatalogChangesetItem represents one draft product creation or update in a changeset
+// @Description Draft product state applied when the changeset is published
+type CatalogChangesetItem struct {
+	ID          int       `json:"id" example:"1"`
+	ChangesetID int       `json:"changeset_id" example:"1"`
+	Action      string    `json:"action" example:"update" enums:"create,update"`
+	ProductID   *int      `json:"product_id" example:"3"`
+	Payload     Product   `json:"payload"`
+	CreatedAt   time.Time `json:"created_at" example:"2026-02-20T12:00:00Z"`
+}
+
+// ChangesetInput represents the input for creating a changeset
+// @Description Input model for creating a catalog changeset
+type ChangesetInput struct {
+	Name string `json:"name" example:"Rainy season catalog" binding:"required"`
+}
+
+// ChangesetItemInput represents a draft product change added to a changeset
+// @Description Draft product state; omit product_id to create a new product on publish
+type ChangesetItemInput struct {
+	ProductID *int         `json:"product_id" example:"3"`
+	Product   ProductInput `json:"product" binding:"required"`
+}
+
+// ChangesetScheduleInput represents the body of a schedule request
+// @Description Time at which the changeset is published automatically
+type ChangesetScheduleInput struct {
+	PublishAt time.Time `json:"publish_at" example:"2026-03-01T00:00:00Z" binding:"required"`
+}
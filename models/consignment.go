@@ -0,0 +1,38 @@
+package models
+
+// Consignment payable entry types
+const (
+	ConsignmentEntrySale     = "sale"
+	ConsignmentEntryReversal = "reversal"
+)
+
+// ConsignmentSettlementLine is the amount owed to a supplier for one consigned product
+// @Description Units sold, sales and payable of a consigned product in the period (net of voids)
+type ConsignmentSettlementLine struct {
+	ProductID     int    `json:"product_id" example:"7"`
+	ProductName   string `json:"product_name" example:"Keripik Singkong"`
+	UnitsSold     int    `json:"units_sold" example:"40"`
+	SalesAmount   int    `json:"sales_amount" example:"400000"`
+	PayableAmount int    `json:"payable_amount" example:"320000"`
+}
+
+// ConsignmentSettlement is the amount owed to one supplier for a period
+// @Description Per-supplier consignment settlement: what was sold and what the store owes
+type ConsignmentSettlement struct {
+	SupplierID    int                         `json:"supplier_id" example:"1"`
+	SupplierName  string                      `json:"supplier_name" example:"PT Sumber Makmur"`
+	UnitsSold     int                         `json:"units_sold" example:"40"`
+	SalesAmount   int                         `json:"sales_amount" example:"400000"`
+	PayableAmount int                         `json:"payable_amount" example:"320000"`
+	StoreMargin   int                         `json:"store_margin" example:"80000"`
+	Products      []ConsignmentSettlementLine `json:"products"`
+}
+
+// ConsignmentSettlementReport lists consignment settlements per supplier for a period
+// @Description Consignment settlement report for a date range
+type ConsignmentSettlementReport struct {
+	StartDate    string                  `json:"start_date" example:"2026-02-01"`
+	EndDate      string                  `json:"end_date" example:"2026-02-28"`
+	TotalPayable int                     `json:"total_payable" example:"320000"`
+	Suppliers    []ConsignmentSettlement `json:"suppliers"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+// TransactionTotalCheck compares a transaction's recorded total with the
+// total recomputed from its detail lines. Amounts are whole currency units,
+// summed as 64-bit integers so no rounding is involved.
+// @Description Recorded and recomputed total of a transaction. expected_total is the sum of the line subtotals less the transaction discount; line_mismatches counts lines whose subtotal is not unit_price × quantity − discount.
+type TransactionTotalCheck struct {
+	TransactionID  int       `json:"transaction_id" example:"42"`
+	ReceiptNo      string    `json:"receipt_no" example:"INV-20260208-0001"`
+	StoreID        int       `json:"store_id" example:"1"`
+	Status         string    `json:"status" example:"active"`
+	CreatedAt      time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+	Lines          int       `json:"lines" example:"3"`
+	LinesSubtotal  int       `json:"lines_subtotal" example:"45000"`
+	Discount       int       `json:"discount" example:"5000"`
+	ExpectedTotal  int       `json:"expected_total" example:"40000"`
+	RecordedTotal  int       `json:"recorded_total" example:"45000"`
+	Difference     int       `json:"difference" example:"5000"`
+	LineMismatches int       `json:"line_mismatches" example:"0"`
+}
+
+// TransactionConsistencyReport lists the transactions of a day whose total
+// does not match their lines
+// @Description Transactions of a day whose recorded total differs from their lines, or whose lines are inconsistent themselves
+type TransactionConsistencyReport struct {
+	Date       string                  `json:"date" example:"2026-02-08"`
+	Checked    int                     `json:"checked" example:"312"`
+	Mismatches []TransactionTotalCheck `json:"mismatches"`
+}
+
+// TransactionRepairInput approves repairing the totals of a day's mismatched
+// transactions
+// @Description Owner approval to set the recorded total of mismatched transactions on date to their expected total. transaction_ids limits the repair to those transactions (default: every mismatch of the day).
+type TransactionRepairInput struct {
+	Date           string `json:"date" example:"2026-02-08"`
+	TransactionIDs []int  `json:"transaction_ids" example:"42,57"`
+}
+
+// TransactionRepairSkip is a transaction the repair left alone
+// @Description Transaction not repaired, with the reason
+type TransactionRepairSkip struct {
+	TransactionID int    `json:"transaction_id" example:"57"`
+	Reason        string `json:"reason" example:"line subtotals are inconsistent; review the lines manually"`
+}
+
+// TransactionRepairResult reports what a repair changed
+// @Description Transactions whose total was repaired (figures before the repair) and those skipped
+type TransactionRepairResult struct {
+	Date     string                  `json:"date" example:"2026-02-08"`
+	Repaired []TransactionTotalCheck `json:"repaired"`
+	Skipped  []TransactionRepairSkip `json:"skipped"`
+}
+
+// StoreStockDrift compares one store's stock of a product with its ledger
+// @Description Stock of a product at one store against the sum of its ledger entries there
+type StoreStockDrift struct {
+	StoreID     int    `json:"store_id" example:"1"`
+	StoreName   string `json:"store_name" example:"Main Store"`
+	Stock       int    `json:"stock" example:"48"`
+	LedgerStock int    `json:"ledger_stock" example:"45"`
+	Drift       int    `json:"drift" example:"3"`
+}
+
+// StockDrift compares a product's stock with the stock movement ledger
+// @Description Stock of a product against its ledger: the opening balance before the first entry plus every quantity_delta since. drift is stock minus ledger_stock; stores lists the stores whose stock drifted.
+type StockDrift struct {
+	ProductID     int               `json:"product_id" example:"3"`
+	ProductName   string            `json:"product_name" example:"Indomie Goreng"`
+	SKU           string            `json:"sku" example:"8886001001103"`
+	Stock         int               `json:"stock" example:"48"`
+	LedgerStock   int               `json:"ledger_stock" example:"45"`
+	Drift         int               `json:"drift" example:"3"`
+	LedgerEntries int               `json:"ledger_entries" example:"27"`
+	Stores        []StoreStockDrift `json:"stores"`
+}
+
+// StockConsistencyReport lists the products whose stock drifted from the ledger
+// @Description Products whose total or per-store stock differs from the stock movement ledger
+type StockConsistencyReport struct {
+	CheckedAt time.Time    `json:"checked_at" example:"2026-02-08T12:00:00Z"`
+	Checked   int          `json:"checked" example:"850"`
+	Drifts    []StockDrift `json:"drifts"`
+}
+
+// StockRepairInput approves rebuilding stock from the ledger
+// @Description Owner approval to set drifted products' total and per-store stock to their ledger figures. product_ids limits the repair to those products (default: every drifted product).
+type StockRepairInput struct {
+	ProductIDs []int `json:"product_ids" example:"3,7"`
+}
+
+// StockRepairSkip is a product the repair left alone
+// @Description Product not repaired, with the reason
+type StockRepairSkip struct {
+	ProductID int    `json:"product_id" example:"7"`
+	Reason    string `json:"reason" example:"no ledger entries to rebuild stock from"`
+}
+
+// StockRepairResult reports what a stock repair changed
+// @Description Products whose stock was rebuilt from the ledger (figures before the repair) and those skipped
+type StockRepairResult struct {
+	Repaired []StockDrift      `json:"repaired"`
+	Skipped  []StockRepairSkip `json:"skipped"`
+}
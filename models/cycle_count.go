@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// ABC inventory classes
+const (
+	ABCClassA = "A"
+	ABCClassB = "B"
+	ABCClassC = "C"
+)
+
+// Cycle count frequencies
+const (
+	CycleFrequencyWeekly    = "weekly"
+	CycleFrequencyMonthly   = "monthly"
+	CycleFrequencyQuarterly = "quarterly"
+)
+
+// CycleCountSchedule represents a recurring count of one ABC class
+// @Description Opens a cycle count session for every product in the class each period and assigns it to a staff member
+type CycleCountSchedule struct {
+	ID           int        `json:"id" example:"1"`
+	ABCClass     string     `json:"abc_class" example:"A" enums:"A,B,C"`
+	Frequency    string     `json:"frequency" example:"weekly" enums:"weekly,monthly,quarterly"`
+	AssignedTo   *int       `json:"assigned_to" example:"2"`
+	AssigneeName string     `json:"assignee_name" example:"Jane Cashier"`
+	IsActive     bool       `json:"is_active" example:"true"`
+	NextRunAt    time.Time  `json:"next_run_at" example:"2026-02-23T07:00:00Z"`
+	LastRunAt    *time.Time `json:"last_run_at" example:"2026-02-16T07:00:00Z"`
+	CreatedAt    time.Time  `json:"created_at" example:"2026-02-01T12:00:00Z"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2026-02-01T12:00:00Z"`
+}
+
+// CycleCountScheduleInput represents the input for creating/updating a cycle count schedule
+// @Description Input model for a cycle count schedule; next_run_at defaults to now
+type CycleCountScheduleInput struct {
+	ABCClass   string     `json:"abc_class" example:"A" binding:"required,oneof=A B C"`
+	Frequency  string     `json:"frequency" example:"weekly" binding:"required,oneof=weekly monthly quarterly"`
+	AssignedTo *int       `json:"assigned_to" example:"2"`
+	IsActive   *bool      `json:"is_active" example:"true"`
+	NextRunAt  *time.Time `json:"next_run_at" example:"2026-02-23T07:00:00Z"`
+}
+
+// CycleCountCompliance summarizes how a schedule's count sessions were completed
+// @Description Compliance rate is the share of due sessions (completed, overdue or cancelled) completed by their due time
+type CycleCountCompliance struct {
+	ScheduleID      int     `json:"schedule_id" example:"1"`
+	ABCClass        string  `json:"abc_class" example:"A"`
+	Frequency       string  `json:"frequency" example:"weekly"`
+	AssignedTo      *int    `json:"assigned_to" example:"2"`
+	Sessions        int     `json:"sessions" example:"12"`
+	CompletedOnTime int     `json:"completed_on_time" example:"10"`
+	CompletedLate   int     `json:"completed_late" example:"1"`
+	Overdue         int     `json:"overdue" example:"0"`
+	InProgress      int     `json:"in_progress" example:"1"`
+	Cancelled       int     `json:"cancelled" example:"0"`
+	ComplianceRate  float64 `json:"compliance_rate" example:"90.91"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Data quality checks run over the product catalog
+const (
+	DataQualityMissingBarcode = "missing_barcode"
+	DataQualityZeroPrice      = "zero_price"
+	DataQualityUncategorized  = "uncategorized"
+	DataQualityDuplicateName  = "duplicate_name"
+	DataQualityStale          = "stale"
+)
+
+// DefaultStaleDays is how long an active product may go without a sale or an
+// edit before it is reported as stale
+const DefaultStaleDays = 180
+
+// DataQualityCheck is the number of products failing one check
+// @Description One catalog hygiene check with the number of products failing it
+type DataQualityCheck struct {
+	Check       string `json:"check" example:"missing_barcode" enums:"missing_barcode,zero_price,uncategorized,duplicate_name,stale"`
+	Description string `json:"description" example:"Products without a SKU/barcode to scan at checkout"`
+	Count       int    `json:"count" example:"12"`
+	URL         string `json:"url" example:"/v1/admin/data-quality/missing_barcode"`
+}
+
+// DataQualityReport summarizes every check over the catalog
+// @Description Catalog hygiene summary; follow a check's url for the products failing it
+type DataQualityReport struct {
+	TotalProducts int                `json:"total_products" example:"850"`
+	StaleDays     int                `json:"stale_days" example:"180"`
+	Checks        []DataQualityCheck `json:"checks"`
+}
+
+// DataQualityIssue is a product failing a data quality check
+// @Description Product failing a data quality check
+type DataQualityIssue struct {
+	ProductID    int        `json:"product_id" example:"3"`
+	Name         string     `json:"name" example:"Indomie Goreng"`
+	SKU          string     `json:"sku" example:""`
+	Price        int        `json:"price" example:"3500"`
+	CategoryID   *int       `json:"category_id" example:"1"`
+	CategoryName string     `json:"category_name" example:"Food"`
+	IsActive     bool       `json:"is_active" example:"true"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2025-06-01T12:00:00Z"`
+	LastSoldAt   *time.Time `json:"last_sold_at" example:"2025-07-15T09:30:00Z"`
+	// DuplicateCount is set by the duplicate_name check: how many products share the name
+	DuplicateCount int `json:"duplicate_count,omitempty" example:"2"`
+}
+
+// DataQualityParams holds the query parameters for a data quality drill-down
+type DataQualityParams struct {
+	Check     string
+	StaleDays int
+	Page      int
+	Limit     int
+}
+
+// PaginatedDataQualityIssues represents a paginated list of data quality issues
+// @Description Paginated list of products failing a data quality check
+type PaginatedDataQualityIssues struct {
+	Data       []DataQualityIssue `json:"data"`
+	Total      int                `json:"total" example:"12"`
+	Page       int                `json:"page" example:"1"`
+	Limit      int                `json:"limit" example:"20"`
+	TotalPages int                `json:"total_pages" example:"1"`
+}
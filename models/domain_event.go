@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Domain event types
+const (
+	DomainEventTransactionCreated = "TransactionCreated"
+	DomainEventStockChanged       = "StockChanged"
+)
+
+// Domain event aggregate types
+const (
+	AggregateTransaction = "transaction"
+	AggregateProduct     = "product"
+)
+
+// DomainEvent is an outbox entry: an event written in the same database
+// transaction as the change it describes and relayed to consumers after
+// commit. Payload is a Transaction for TransactionCreated and a StockChanged
+// for StockChanged. Attempts and LastError are those of the consumer the
+// event is being relayed to; Deferred is set while its retry of a failed
+// attempt is not due yet.
+type DomainEvent struct {
+	ID            int
+	Type          string
+	AggregateType string
+	AggregateID   int
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     string
+	Deferred      bool
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+// StockChanged is the payload of a StockChanged event, raised for every
+// stock ledger row. BalanceAfter is the product's total stock over all stores.
+type StockChanged struct {
+	MovementID    int       `json:"movement_id"`
+	ProductID     int       `json:"product_id"`
+	StoreID       int       `json:"store_id"`
+	QuantityDelta int       `json:"quantity_delta"`
+	BalanceAfter  int       `json:"balance_after"`
+	Reason        string    `json:"reason"`
+	ReferenceType string    `json:"reference_type"`
+	ReferenceID   *int      `json:"reference_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
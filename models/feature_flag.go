@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Feature flags the services consult
+const (
+	FeatureFlagPromotions = "promotions"
+)
+
+// FeatureFlagDefinition is a feature that can be switched on or off
+type FeatureFlagDefinition struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// FeatureFlagDefinitions are the known feature flags with their built-in
+// defaults. FEATURE_FLAGS overrides the defaults for every tenant; a tenant
+// overrides them for itself, or for one of its stores.
+var FeatureFlagDefinitions = []FeatureFlagDefinition{
+	{Name: FeatureFlagPromotions, Description: "Apply active promotions to checkouts", Default: true},
+}
+
+// FeatureFlagSetting is a flag switched on or off for a tenant, or for one
+// of its stores
+// @Description Flag state set for the whole tenant (no store_id) or for one store
+type FeatureFlagSetting struct {
+	Name      string    `json:"name" example:"promotions"`
+	StoreID   *int      `json:"store_id,omitempty" example:"2"`
+	Enabled   bool      `json:"enabled" example:"false"`
+	UpdatedAt time.Time `json:"updated_at" example:"2026-03-01T09:30:00Z"`
+}
+
+// FeatureFlag represents a feature flag with its state for the tenant
+// @Description Feature flag. default is the built-in or FEATURE_FLAGS default; enabled is the state of stores without an override, which is the tenant setting when there is one and default otherwise.
+type FeatureFlag struct {
+	Name           string               `json:"name" example:"promotions"`
+	Description    string               `json:"description" example:"Apply active promotions to checkouts"`
+	Default        bool                 `json:"default" example:"true"`
+	Enabled        bool                 `json:"enabled" example:"true"`
+	TenantSetting  *FeatureFlagSetting  `json:"tenant_setting,omitempty"`
+	StoreOverrides []FeatureFlagSetting `json:"store_overrides"`
+}
+
+// SetFeatureFlagRequest represents the input for switching a flag on or off
+// @Description Switches a flag on or off for the whole tenant, or for one store when store_id is set
+type SetFeatureFlagRequest struct {
+	Enabled *bool `json:"enabled" example:"false" binding:"required"`
+	StoreID *int  `json:"store_id" example:"2" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Health statuses. A dependency is up or down; the service is degraded when
+// only optional dependencies are down, and down when a critical one is.
+const (
+	HealthUp       = "up"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+)
+
+// DependencyHealth represents the result of checking one dependency
+// @Description Status of one dependency; critical ones make the instance unready when down
+type DependencyHealth struct {
+	Status    string `json:"status" example:"up" enums:"up,down"`
+	Critical  bool   `json:"critical" example:"true"`
+	LatencyMs int64  `json:"latency_ms" example:"3"`
+	Detail    string `json:"detail,omitempty" example:"schema at version 40"`
+	Error     string `json:"error,omitempty" example:"dial tcp 10.0.0.5:5432: connect: connection refused"`
+}
+
+// HealthReport represents the readiness of the service
+// @Description Overall status with the status of every dependency checked, keyed by name (database, migrations, replica, cache)
+type HealthReport struct {
+	Status       string                      `json:"status" example:"up" enums:"up,degraded,down"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
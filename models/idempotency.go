@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// IdempotencyKeyHeader is the request header clients set to make a POST or
+// PATCH safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyReplayedHeader is set on responses replayed from an earlier
+// request with the same key
+const IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+// IdempotencyRecord is the stored outcome of a request made with an
+// Idempotency-Key. StatusCode is 0 while the first request is still running.
+type IdempotencyRecord struct {
+	UserID       int
+	Key          string
+	Method       string
+	Path         string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Price change sources
+const (
+	PriceSourceProduct   = "product"
+	PriceSourceChangeset = "changeset"
+	PriceSourceSchedule  = "schedule"
+)
+
+// Scheduled price statuses
+const (
+	ScheduledPriceStatusPending   = "pending"
+	ScheduledPriceStatusApplied   = "applied"
+	ScheduledPriceStatusCancelled = "cancelled"
+)
+
+// PriceChange represents an immutable record of a product's selling price changing
+// @Description Old and new selling price of a product with who changed it and when. old_price is null for the price a product was created with.
+type PriceChange struct {
+	ID            int       `json:"id" example:"1"`
+	ProductID     int       `json:"product_id" example:"3"`
+	OldPrice      *int      `json:"old_price" example:"3500"`
+	NewPrice      int       `json:"new_price" example:"4000"`
+	Source        string    `json:"source" example:"product" enums:"product,changeset,schedule"`
+	ReferenceID   *int      `json:"reference_id" example:"3"`
+	ChangedBy     *int      `json:"changed_by" example:"1"`
+	ChangedByName string    `json:"changed_by_name" example:"Store Owner"`
+	CreatedAt     time.Time `json:"created_at" example:"2026-02-10T09:30:00Z"`
+}
+
+// ScheduledPrice represents a price that takes effect at a future time
+// @Description Future selling price of a product, applied by the price scheduler at effective_at and then logged to the price history
+type ScheduledPrice struct {
+	ID            int        `json:"id" example:"1"`
+	ProductID     int        `json:"product_id" example:"3"`
+	Price         int        `json:"price" example:"3000"`
+	EffectiveAt   time.Time  `json:"effective_at" example:"2026-03-06T00:00:00Z"`
+	Status        string     `json:"status" example:"pending" enums:"pending,applied,cancelled"`
+	CreatedBy     *int       `json:"created_by" example:"1"`
+	CreatedByName string     `json:"created_by_name" example:"Store Owner"`
+	AppliedAt     *time.Time `json:"applied_at" example:"2026-03-06T00:00:12Z"`
+	LastError     string     `json:"last_error" example:""`
+	CreatedAt     time.Time  `json:"created_at" example:"2026-03-01T09:30:00Z"`
+}
+
+// ScheduledPriceInput represents the input for scheduling a price change
+// @Description New selling price and the future time it takes effect
+type ScheduledPriceInput struct {
+	Price       int       `json:"price" example:"3000" binding:"required"`
+	EffectiveAt time.Time `json:"effective_at" example:"2026-03-06T00:00:00Z" binding:"required"`
+}
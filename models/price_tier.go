@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Price levels (customer groups). Retail is the default for walk-in customers.
+const (
+	PriceLevelRetail    = "retail"
+	PriceLevelWholesale = "wholesale"
+	PriceLevelMember    = "member"
+)
+
+// PriceTier represents a unit price for a customer group from a minimum quantity
+// @Description Unit price a customer of price_level pays when buying at least min_quantity of the product
+type PriceTier struct {
+	ID          int       `json:"id" example:"1"`
+	ProductID   int       `json:"product_id" example:"3"`
+	PriceLevel  string    `json:"price_level" example:"wholesale" enums:"retail,wholesale,member"`
+	MinQuantity int       `json:"min_quantity" example:"12"`
+	Price       int       `json:"price" example:"2700"`
+	CreatedAt   time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// PriceTierInput represents a single tier in a price tier update
+// @Description Unit price for a customer group from a minimum quantity
+type PriceTierInput struct {
+	PriceLevel  string `json:"price_level" example:"wholesale" binding:"required,oneof=retail wholesale member"`
+	MinQuantity int    `json:"min_quantity" example:"12" binding:"required,min=1"`
+	Price       int    `json:"price" example:"2700" binding:"min=0"`
+}
+
+// PriceTiersInput represents the full set of price tiers of a product
+// @Description Replaces every price tier of a product; an empty list removes them all
+type PriceTiersInput struct {
+	Tiers []PriceTierInput `json:"tiers" binding:"dive"`
+}
@@ -5,31 +5,66 @@ import "time"
 // Product represents a product entity
 // @Description Product information with ID, name, price, stock, and category relationship
 type Product struct {
-	ID           int       `json:"id" example:"1"`
-	Name         string    `json:"name" example:"iPhone 15 Pro" binding:"required"`
-	Price        int       `json:"price" example:"15000000" binding:"required"`
-	Stock        int       `json:"stock" example:"50" binding:"required"`
-	SKU          string    `json:"sku" example:"IP15PRO-001"`
-	ImageURL     string    `json:"image_url" example:"https://example.com/img.jpg"`
-	Unit         string    `json:"unit" example:"pcs"`
-	IsActive     bool      `json:"is_active" example:"true"`
-	CategoryID   *int      `json:"category_id" example:"1"`
-	CategoryName string    `json:"category_name,omitempty" example:"Electronics"`
-	CreatedAt    time.Time `json:"created_at" example:"2024-01-30T12:00:00Z"`
-	UpdatedAt    time.Time `json:"updated_at" example:"2024-01-30T12:00:00Z"`
+	ID              int       `json:"id" example:"1"`
+	Name            string    `json:"name" example:"iPhone 15 Pro" binding:"required"`
+	Slug            string    `json:"slug" example:"iphone-15-pro"`
+	Price           int       `json:"price" example:"15000000" binding:"required"`
+	Stock           int       `json:"stock" example:"50" binding:"required"`
+	MinStock        int       `json:"min_stock" example:"10"`
+	SKU             string    `json:"sku" example:"IP15PRO-001"`
+	ImageURL        string    `json:"image_url" example:"https://example.com/img.jpg"`
+	Unit            string    `json:"unit" example:"pcs"`
+	IsActive        bool      `json:"is_active" example:"true"`
+	CategoryID      *int      `json:"category_id" example:"1"`
+	CategoryName    string    `json:"category_name,omitempty" example:"Electronics"`
+	SupplierID      *int      `json:"supplier_id" example:"1"`
+	IsConsignment   bool      `json:"is_consignment" example:"false"`
+	ConsignmentCost int       `json:"consignment_cost" example:"0"`
+	CostPrice       int       `json:"cost_price" example:"12000000"`
+	CreatedAt       time.Time `json:"created_at" example:"2024-01-30T12:00:00Z"`
+	UpdatedAt       time.Time `json:"updated_at" example:"2024-01-30T12:00:00Z"`
+
+	// MarginPercent is only included for owners, and only when the unit cost is known
+	MarginPercent *float64 `json:"margin_percent,omitempty" example:"20"`
+
+	Relations []ProductRelation `json:"relations,omitempty"`
 }
 
 // ProductInput represents the input for creating/updating a product
 // @Description Input model for creating or updating a product (ID is auto-generated)
 type ProductInput struct {
 	Name       string `json:"name" example:"iPhone 15 Pro" binding:"required"`
-	Price      int    `json:"price" example:"15000000" binding:"required"`
-	Stock      int    `json:"stock" example:"50" binding:"required"`
+	Price      int    `json:"price" example:"15000000" binding:"required,min=0"`
+	Stock      int    `json:"stock" example:"50" binding:"required,min=0"`
+	MinStock   *int   `json:"min_stock" example:"10" binding:"omitempty,min=0"`
 	SKU        string `json:"sku" example:"IP15PRO-001"`
-	ImageURL   string `json:"image_url" example:"https://example.com/img.jpg"`
+	ImageURL   string `json:"image_url" example:"https://example.com/img.jpg" binding:"omitempty,url"`
 	Unit       string `json:"unit" example:"pcs"`
 	IsActive   *bool  `json:"is_active" example:"true"`
-	CategoryID *int   `json:"category_id" example:"1"`
+	CategoryID *int   `json:"category_id" example:"1" binding:"omitempty,min=1"`
+	// Consignment products are owned by the supplier; each unit sold accrues
+	// consignment_cost as a payable to the supplier
+	SupplierID      *int `json:"supplier_id" example:"1" binding:"omitempty,min=1"`
+	IsConsignment   bool `json:"is_consignment" example:"false"`
+	ConsignmentCost int  `json:"consignment_cost" example:"0" binding:"min=0"`
+	CostPrice       int  `json:"cost_price" example:"12000000" binding:"min=0"`
+}
+
+// DefaultMinStock is the low-stock threshold used when a product does not set one
+const DefaultMinStock = 10
+
+// LowStockProduct represents a product at or below its low-stock threshold
+// @Description Product that needs reordering, with how far it is below its minimum stock
+type LowStockProduct struct {
+	ProductID    int    `json:"product_id" example:"3"`
+	Name         string `json:"name" example:"Indomie Goreng"`
+	SKU          string `json:"sku" example:"IDM-GRG-001"`
+	Unit         string `json:"unit" example:"pcs"`
+	CategoryID   *int   `json:"category_id" example:"1"`
+	CategoryName string `json:"category_name" example:"Food"`
+	Stock        int    `json:"stock" example:"4"`
+	MinStock     int    `json:"min_stock" example:"10"`
+	Shortfall    int    `json:"shortfall" example:"6"`
 }
 
 // ProductListParams holds the query parameters for listing products
@@ -43,9 +78,45 @@ type ProductListParams struct {
 // PaginatedProducts represents a paginated list of products
 // @Description Paginated list of products
 type PaginatedProducts struct {
-	Data       []Product      `json:"data"`
-	Total      int            `json:"total" example:"100"`
-	Page       int            `json:"page" example:"1"`
-	Limit      int            `json:"limit" example:"20"`
-	TotalPages int            `json:"total_pages" example:"5"`
+	Data       []Product `json:"data"`
+	Total      int       `json:"total" example:"100"`
+	Page       int       `json:"page" example:"1"`
+	Limit      int       `json:"limit" example:"20"`
+	TotalPages int       `json:"total_pages" example:"5"`
+}
+
+// ReorderSuggestion is a product whose projected demand will exhaust its stock
+// before a new delivery arrives
+// @Description Reorder suggestion based on recent sales velocity, supplier lead time and days of cover
+type ReorderSuggestion struct {
+	ProductID     int     `json:"product_id" example:"3"`
+	Name          string  `json:"name" example:"Indomie Goreng"`
+	SKU           string  `json:"sku" example:"IDM-GRG-001"`
+	Unit          string  `json:"unit" example:"pcs"`
+	CategoryID    *int    `json:"category_id" example:"1"`
+	CategoryName  string  `json:"category_name" example:"Food"`
+	SupplierID    *int    `json:"supplier_id" example:"1"`
+	Stock         int     `json:"stock" example:"12"`
+	MinStock      int     `json:"min_stock" example:"10"`
+	UnitsSold     int     `json:"units_sold" example:"90"`
+	DailyVelocity float64 `json:"daily_velocity" example:"3"`
+	ReorderPoint  int     `json:"reorder_point" example:"31"`
+	SuggestedQty  int     `json:"suggested_qty" example:"61"`
+}
+
+// ReorderSuggestionParams holds the query parameters for reorder suggestions
+type ReorderSuggestionParams struct {
+	VelocityDays int
+	LeadTimeDays int
+	CoverDays    int
+	CategoryID   *int
+}
+
+// ReorderSuggestionReport lists the products that should be reordered now
+// @Description Reorder suggestions together with the parameters used to compute them
+type ReorderSuggestionReport struct {
+	VelocityDays int                 `json:"velocity_days" example:"30"`
+	LeadTimeDays int                 `json:"lead_time_days" example:"7"`
+	CoverDays    int                 `json:"cover_days" example:"14"`
+	Suggestions  []ReorderSuggestion `json:"suggestions"`
 }
@@ -0,0 +1,44 @@
+package models
+
+// Product import actions
+const (
+	ProductImportCreate = "create"
+	ProductImportUpdate = "update"
+)
+
+// ProductImportColumns are the CSV columns a product import understands.
+// name and price are required; a row whose sku matches an existing product
+// updates it, any other row creates a product. The other columns of a
+// product export are accepted and ignored.
+var ProductImportColumns = []string{"name", "sku", "price", "stock", "category"}
+
+// ProductImportRow is a CSV row that was (or, in a dry run, would be) imported
+// @Description Product created or updated by a CSV import row
+type ProductImportRow struct {
+	Row       int    `json:"row" example:"2"`
+	Action    string `json:"action" example:"update" enums:"create,update"`
+	ProductID *int   `json:"product_id" example:"3"`
+	SKU       string `json:"sku" example:"IDM-GRG-001"`
+	Name      string `json:"name" example:"Indomie Goreng"`
+}
+
+// ProductImportError is a problem with one CSV row
+// @Description Validation error of a CSV import row; the row is skipped
+type ProductImportError struct {
+	Row     int    `json:"row" example:"5"`
+	Column  string `json:"column,omitempty" example:"price"`
+	Message string `json:"message" example:"price must be a whole number"`
+}
+
+// ProductImportResult summarizes a CSV product import. Row numbers are the
+// line numbers in the file, the header being line 1.
+// @Description Outcome of a CSV product import. In a dry run nothing is written and the counts show what the import would do.
+type ProductImportResult struct {
+	DryRun    bool                 `json:"dry_run" example:"true"`
+	TotalRows int                  `json:"total_rows" example:"120"`
+	Created   int                  `json:"created" example:"15"`
+	Updated   int                  `json:"updated" example:"100"`
+	Failed    int                  `json:"failed" example:"5"`
+	Rows      []ProductImportRow   `json:"rows"`
+	Errors    []ProductImportError `json:"errors"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Product relation types
+const (
+	RelationTypeSubstitute = "substitute"
+	RelationTypeAccessory  = "accessory"
+	RelationTypeUpsell     = "upsell"
+)
+
+// ProductRelation represents a typed link from a product to another product
+// @Description Related product (substitute, accessory or upsell) with its current price and stock
+type ProductRelation struct {
+	ProductID        int       `json:"product_id" example:"1"`
+	RelatedProductID int       `json:"related_product_id" example:"2"`
+	Type             string    `json:"type" example:"substitute" enums:"substitute,accessory,upsell"`
+	Name             string    `json:"name" example:"Indomie Soto"`
+	Price            int       `json:"price" example:"3000"`
+	Stock            int       `json:"stock" example:"40"`
+	IsActive         bool      `json:"is_active" example:"true"`
+	CreatedAt        time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// ProductRelationInput represents the input for linking two products
+// @Description Input model for adding a product relation
+type ProductRelationInput struct {
+	RelatedProductID int    `json:"related_product_id" example:"2" binding:"required"`
+	Type             string `json:"type" example:"substitute" binding:"required,oneof=substitute accessory upsell"`
+}
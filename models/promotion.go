@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// Promotion types supported by the checkout rules engine
+const (
+	PromotionTypeBOGO             = "bogo"
+	PromotionTypeBundlePrice      = "bundle_price"
+	PromotionTypeCategoryDiscount = "category_discount"
+)
+
+// Promotion represents a promotion rule evaluated during checkout
+// @Description Promotion rule (buy-one-get-one, bundle price or category discount)
+type Promotion struct {
+	ID              int        `json:"id" example:"1"`
+	Name            string     `json:"name" example:"Beverages Week"`
+	Type            string     `json:"type" example:"category_discount" enums:"bogo,bundle_price,category_discount"`
+	ProductID       *int       `json:"product_id" example:"3"`
+	CategoryID      *int       `json:"category_id" example:"2"`
+	BuyQty          int        `json:"buy_qty" example:"1"`
+	GetQty          int        `json:"get_qty" example:"1"`
+	BundleQty       int        `json:"bundle_qty" example:"3"`
+	BundlePrice     int        `json:"bundle_price" example:"10000"`
+	DiscountPercent int        `json:"discount_percent" example:"10"`
+	StartsAt        *time.Time `json:"starts_at" example:"2026-02-01T00:00:00Z"`
+	EndsAt          *time.Time `json:"ends_at" example:"2026-02-08T23:59:59Z"`
+	IsActive        bool       `json:"is_active" example:"true"`
+	CreatedAt       time.Time  `json:"created_at" example:"2026-02-01T12:00:00Z"`
+	UpdatedAt       time.Time  `json:"updated_at" example:"2026-02-01T12:00:00Z"`
+}
+
+// PromotionInput represents the input for creating/updating a promotion
+// @Description Input model for creating or updating a promotion rule
+type PromotionInput struct {
+	Name            string     `json:"name" example:"Beverages Week" binding:"required"`
+	Type            string     `json:"type" example:"category_discount" binding:"required,oneof=bogo bundle_price category_discount"`
+	ProductID       *int       `json:"product_id" example:"3"`
+	CategoryID      *int       `json:"category_id" example:"2"`
+	BuyQty          int        `json:"buy_qty" example:"1"`
+	GetQty          int        `json:"get_qty" example:"1"`
+	BundleQty       int        `json:"bundle_qty" example:"3"`
+	BundlePrice     int        `json:"bundle_price" example:"10000"`
+	DiscountPercent int        `json:"discount_percent" example:"10"`
+	StartsAt        *time.Time `json:"starts_at" example:"2026-02-01T00:00:00Z"`
+	EndsAt          *time.Time `json:"ends_at" example:"2026-02-08T23:59:59Z"`
+	IsActive        *bool      `json:"is_active" example:"true"`
+}
+
+// AppliedPromotion represents a promotion applied to a transaction detail line
+// @Description Promotion applied to a single checkout line with the discount it produced
+type AppliedPromotion struct {
+	PromotionID int    `json:"promotion_id" example:"1"`
+	Name        string `json:"name" example:"Beverages Week"`
+	Type        string `json:"type" example:"category_discount"`
+	Discount    int    `json:"discount" example:"1500"`
+}
+
+// PromotionPerformance represents how one promotion performed over its
+// validity window
+// @Description Usage, discount and sales of one promotion over its validity window (starts_at or creation to ends_at or now), clipped to the requested dates. incremental_units compares units of the targeted product or category sold in the window with the same length of time just before it.
+type PromotionPerformance struct {
+	PromotionID      int       `json:"promotion_id" example:"1"`
+	Name             string    `json:"name" example:"Beverages Week"`
+	Type             string    `json:"type" example:"category_discount" enums:"bogo,bundle_price,category_discount"`
+	IsActive         bool      `json:"is_active" example:"true"`
+	WindowStart      time.Time `json:"window_start" example:"2026-02-01T00:00:00Z"`
+	WindowEnd        time.Time `json:"window_end" example:"2026-02-08T23:59:59Z"`
+	UsageCount       int       `json:"usage_count" example:"42"`
+	DiscountGiven    int       `json:"discount_given" example:"63000"`
+	PromotedUnits    int       `json:"promoted_units" example:"120"`
+	TargetUnits      int       `json:"target_units" example:"150"`
+	BaselineUnits    int       `json:"baseline_units" example:"90"`
+	IncrementalUnits int       `json:"incremental_units" example:"60"`
+	AttachedRevenue  int       `json:"attached_revenue" example:"2100000"`
+}
+
+// PromotionPerformanceReport represents the performance of every promotion
+// @Description Performance of every promotion whose validity window overlaps the requested dates, most used first, with totals
+type PromotionPerformanceReport struct {
+	StartDate            string                 `json:"start_date,omitempty" example:"2026-02-01"`
+	EndDate              string                 `json:"end_date,omitempty" example:"2026-02-28"`
+	StoreID              int                    `json:"store_id,omitempty" example:"1"`
+	Promotions           []PromotionPerformance `json:"promotions"`
+	TotalUsage           int                    `json:"total_usage" example:"42"`
+	TotalDiscountGiven   int                    `json:"total_discount_given" example:"63000"`
+	TotalAttachedRevenue int                    `json:"total_attached_revenue" example:"2100000"`
+}
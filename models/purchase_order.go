@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+// Purchase order statuses
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrder represents an order of stock from a supplier
+// @Description Purchase order; goods are received against it in one or more receipts
+type PurchaseOrder struct {
+	ID           int                 `json:"id" example:"1"`
+	SupplierID   int                 `json:"supplier_id" example:"1"`
+	SupplierName string              `json:"supplier_name" example:"PT Sumber Makmur"`
+	Status       string              `json:"status" example:"open" enums:"open,partially_received,received,cancelled"`
+	Note         string              `json:"note" example:"Weekly restock"`
+	TotalCost    int                 `json:"total_cost" example:"1500000"`
+	CreatedBy    int                 `json:"created_by" example:"1"`
+	CreatedAt    time.Time           `json:"created_at" example:"2026-02-20T08:00:00Z"`
+	UpdatedAt    time.Time           `json:"updated_at" example:"2026-02-20T08:00:00Z"`
+	Items        []PurchaseOrderItem `json:"items,omitempty"`
+	Receipts     []GoodsReceipt      `json:"receipts,omitempty"`
+}
+
+// PurchaseOrderItem represents one ordered product line
+// @Description Ordered product with the quantity received so far
+type PurchaseOrderItem struct {
+	ID               int    `json:"id" example:"1"`
+	PurchaseOrderID  int    `json:"purchase_order_id" example:"1"`
+	ProductID        int    `json:"product_id" example:"3"`
+	ProductName      string `json:"product_name" example:"Indomie Goreng"`
+	SKU              string `json:"sku" example:"IDM-GRG-001"`
+	QuantityOrdered  int    `json:"quantity_ordered" example:"100"`
+	QuantityReceived int    `json:"quantity_received" example:"60"`
+	UnitCost         int    `json:"unit_cost" example:"2500"`
+}
+
+// GoodsReceipt records one delivery received against a purchase order
+// @Description Goods receipt with the quantity and cost price of every received line
+type GoodsReceipt struct {
+	ID              int                `json:"id" example:"1"`
+	PurchaseOrderID int                `json:"purchase_order_id" example:"1"`
+	Note            string             `json:"note" example:"First delivery"`
+	ReceivedBy      int                `json:"received_by" example:"2"`
+	CreatedAt       time.Time          `json:"created_at" example:"2026-02-22T10:00:00Z"`
+	Lines           []GoodsReceiptLine `json:"lines"`
+}
+
+// GoodsReceiptLine is one product received in a goods receipt
+// @Description Received quantity and the unit cost price it was received at
+type GoodsReceiptLine struct {
+	ID          int    `json:"id" example:"1"`
+	ProductID   int    `json:"product_id" example:"3"`
+	ProductName string `json:"product_name" example:"Indomie Goreng"`
+	Quantity    int    `json:"quantity" example:"60"`
+	UnitCost    int    `json:"unit_cost" example:"2500"`
+}
+
+// PurchaseOrderItemInput represents one product line of a new purchase order
+type PurchaseOrderItemInput struct {
+	ProductID int `json:"product_id" example:"3" binding:"required"`
+	Quantity  int `json:"quantity" example:"100" binding:"required,min=1"`
+	UnitCost  int `json:"unit_cost" example:"2500" binding:"min=0"`
+}
+
+// PurchaseOrderInput represents the input for creating a purchase order
+// @Description Input model for creating a purchase order
+type PurchaseOrderInput struct {
+	SupplierID int                      `json:"supplier_id" example:"1" binding:"required"`
+	Note       string                   `json:"note" example:"Weekly restock"`
+	Items      []PurchaseOrderItemInput `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReceiveItemInput represents the quantity received of one ordered product.
+// UnitCost overrides the ordered cost when the supplier invoiced a different price.
+type ReceiveItemInput struct {
+	ProductID int  `json:"product_id" example:"3" binding:"required"`
+	Quantity  int  `json:"quantity" example:"60" binding:"required,min=1"`
+	UnitCost  *int `json:"unit_cost" example:"2500" binding:"omitempty,min=0"`
+}
+
+// ReceiveInput represents a delivery received against a purchase order
+// @Description Received quantities; lines may cover only part of what was ordered
+type ReceiveInput struct {
+	Note  string             `json:"note" example:"First delivery"`
+	Items []ReceiveItemInput `json:"items" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// QueueStatus represents today's pickup queue as shown on the "now serving" display
+// @Description Today's pickup queue: the number being served and the last number issued
+type QueueStatus struct {
+	Date       string    `json:"date" example:"2026-02-08"`
+	NowServing int       `json:"now_serving" example:"15"`
+	LastIssued int       `json:"last_issued" example:"21"`
+	Waiting    int       `json:"waiting" example:"6"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// QueueCallInput represents a request to call a specific queue number
+// @Description Queue number to announce as now serving
+type QueueCallInput struct {
+	QueueNo int `json:"queue_no" example:"15" binding:"required,min=1"`
+}
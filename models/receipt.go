@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ReceiptShareLink represents a short-lived public link to a transaction receipt
+// @Description Public receipt URL (e.g. for a QR code on the customer display) with its expiry
+type ReceiptShareLink struct {
+	TransactionID int       `json:"transaction_id" example:"1"`
+	ReceiptNo     string    `json:"receipt_no" example:"INV-20260208-0001"`
+	Token         string    `json:"token" example:"eyJhbGciOiJIUzI1NiIs..."`
+	URL           string    `json:"url" example:"https://retail-core-api.zeabur.app/receipts/eyJhbGciOiJIUzI1NiIs..."`
+	ExpiresAt     time.Time `json:"expires_at" example:"2026-02-09T12:00:00Z"`
+}
+
+// Receipt is a transaction prepared for rendering as a customer receipt.
+// Prices are tax-inclusive; Tax is the portion of the total that is tax.
+type Receipt struct {
+	StoreName   string
+	Transaction Transaction
+	Subtotal    int
+	TaxRate     float64
+	Tax         int
+}
@@ -0,0 +1,108 @@
+package models
+
+import "time"
+
+// Reports that can be scheduled
+const (
+	ScheduledReportSalesSummary       = "sales_summary"
+	ScheduledReportProfit             = "profit"
+	ScheduledReportStoreSales         = "store_sales"
+	ScheduledReportConsignment        = "consignment"
+	ScheduledReportLowStock           = "low_stock"
+	ScheduledReportInventoryValuation = "inventory_valuation"
+)
+
+// Scheduled report file formats
+const (
+	ReportFormatCSV = "csv"
+	ReportFormatPDF = "pdf"
+)
+
+// Scheduled report periods, relative to the run time in the schedule's time zone
+const (
+	ReportPeriodYesterday   = "yesterday"
+	ReportPeriodToday       = "today"
+	ReportPeriodLast7Days   = "last_7_days"
+	ReportPeriodLastMonth   = "last_month"
+	ReportPeriodMonthToDate = "month_to_date"
+)
+
+// Scheduled report upload targets
+const (
+	ReportTargetLocal = "local"
+	ReportTargetS3    = "s3"
+	ReportTargetSFTP  = "sftp"
+)
+
+// Report run statuses and triggers
+const (
+	ReportRunRunning   = "running"
+	ReportRunSucceeded = "succeeded"
+	ReportRunFailed    = "failed"
+
+	ReportTriggerSchedule = "schedule"
+	ReportTriggerManual   = "manual"
+)
+
+// ReportSchedule renders a report on a cron schedule and uploads the file
+// @Description Report rendered as CSV or PDF on a cron schedule and uploaded to object storage. Files are named {path_prefix}/{report}_{period start}_{period end}_{run time}.{format}.
+type ReportSchedule struct {
+	ID         int        `json:"id" example:"1"`
+	Name       string     `json:"name" example:"Daily sales for BI"`
+	Report     string     `json:"report" example:"sales_summary" enums:"sales_summary,profit,store_sales,consignment,low_stock,inventory_valuation"`
+	Format     string     `json:"format" example:"csv" enums:"csv,pdf"`
+	Period     string     `json:"period" example:"yesterday" enums:"yesterday,today,last_7_days,last_month,month_to_date"`
+	StoreID    *int       `json:"store_id" example:"2"`
+	Cron       string     `json:"cron" example:"0 2 * * *"`
+	Timezone   string     `json:"timezone" example:"Asia/Jakarta"`
+	Target     string     `json:"target" example:"s3" enums:"local,s3,sftp"`
+	PathPrefix string     `json:"path_prefix" example:"bi/sales"`
+	IsActive   bool       `json:"is_active" example:"true"`
+	NextRunAt  *time.Time `json:"next_run_at" example:"2026-02-21T02:00:00+07:00"`
+	LastRunAt  *time.Time `json:"last_run_at" example:"2026-02-20T02:00:00+07:00"`
+	LastStatus string     `json:"last_status" example:"succeeded" enums:",running,succeeded,failed"`
+	CreatedBy  int        `json:"created_by" example:"1"`
+	CreatedAt  time.Time  `json:"created_at" example:"2026-02-01T12:00:00Z"`
+	UpdatedAt  time.Time  `json:"updated_at" example:"2026-02-01T12:00:00Z"`
+}
+
+// ReportScheduleInput represents the input for creating/updating a report schedule
+// @Description Input model for a report schedule. cron has five fields (minute hour day month weekday) or @hourly/@daily/@weekly/@monthly; timezone defaults to UTC, period to yesterday and is_active to true. store_id scopes sales reports to one store.
+type ReportScheduleInput struct {
+	Name       string `json:"name" example:"Daily sales for BI" binding:"required,max=255"`
+	Report     string `json:"report" example:"sales_summary" binding:"required"`
+	Format     string `json:"format" example:"csv" binding:"required,oneof=csv pdf"`
+	Period     string `json:"period" example:"yesterday"`
+	StoreID    *int   `json:"store_id" example:"2"`
+	Cron       string `json:"cron" example:"0 2 * * *" binding:"required"`
+	Timezone   string `json:"timezone" example:"Asia/Jakarta"`
+	Target     string `json:"target" example:"s3" binding:"required,oneof=local s3 sftp"`
+	PathPrefix string `json:"path_prefix" example:"bi/sales"`
+	IsActive   *bool  `json:"is_active" example:"true"`
+}
+
+// ReportRun is one rendering and upload of a scheduled report
+// @Description Run history entry; error is set when the run failed
+type ReportRun struct {
+	ID          int        `json:"id" example:"1"`
+	ScheduleID  int        `json:"schedule_id" example:"1"`
+	Trigger     string     `json:"trigger" example:"schedule" enums:"schedule,manual"`
+	Status      string     `json:"status" example:"succeeded" enums:"running,succeeded,failed"`
+	PeriodStart string     `json:"period_start" example:"2026-02-19"`
+	PeriodEnd   string     `json:"period_end" example:"2026-02-19"`
+	ObjectKey   string     `json:"object_key" example:"bi/sales/sales_summary_2026-02-19_2026-02-19_20260220T0200.csv"`
+	SizeBytes   int        `json:"size_bytes" example:"2048"`
+	Error       string     `json:"error" example:""`
+	StartedAt   time.Time  `json:"started_at" example:"2026-02-20T02:00:04+07:00"`
+	FinishedAt  *time.Time `json:"finished_at" example:"2026-02-20T02:00:06+07:00"`
+}
+
+// PaginatedReportRuns represents a paginated list of report runs
+// @Description Paginated run history of a report schedule
+type PaginatedReportRuns struct {
+	Data       []ReportRun `json:"data"`
+	Total      int         `json:"total" example:"30"`
+	Page       int         `json:"page" example:"1"`
+	Limit      int         `json:"limit" example:"20"`
+	TotalPages int         `json:"total_pages" example:"2"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// SchemaMigration represents a change to the database schema
+// @Description One entry of the data schema changelog with the time it was applied to this database
+type SchemaMigration struct {
+	Version     int       `json:"version" example:"26"`
+	Name        string    `json:"name" example:"scheduled_prices"`
+	Description string    `json:"description" example:"Add scheduled_prices for future-dated price changes"`
+	AppliedAt   time.Time `json:"applied_at" example:"2026-03-01T09:30:00Z"`
+}
+
+// SchemaVersion represents the current version of the database schema
+// @Description Latest applied schema migration; clients resync when version changes
+type SchemaVersion struct {
+	Version   int       `json:"version" example:"26"`
+	Name      string    `json:"name" example:"scheduled_prices"`
+	AppliedAt time.Time `json:"applied_at" example:"2026-03-01T09:30:00Z"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// Stock movement reasons
+const (
+	StockReasonInitial     = "initial"
+	StockReasonSale        = "sale"
+	StockReasonRefund      = "refund"
+	StockReasonRestock     = "restock"
+	StockReasonAdjustment  = "adjustment"
+	StockReasonTransferOut = "transfer_out"
+	StockReasonTransferIn  = "transfer_in"
+)
+
+// Reason codes for manual stock adjustments
+const (
+	AdjustmentDamage          = "damage"
+	AdjustmentCountCorrection = "count_correction"
+	AdjustmentReceivedGoods   = "received_goods"
+)
+
+// Stock movement reference types
+const (
+	StockRefTransaction   = "transaction"
+	StockRefProduct       = "product"
+	StockRefChangeset     = "changeset"
+	StockRefAdjustment    = "adjustment"
+	StockRefCountSession  = "count_session"
+	StockRefPurchaseOrder = "purchase_order"
+	StockRefStockTransfer = "stock_transfer"
+)
+
+// StockMovement represents an immutable ledger entry for a stock change
+// @Description Immutable record of a single stock change at a store with its reason and reference. balance_after is the product's total stock over all stores.
+type StockMovement struct {
+	ID            int       `json:"id" example:"1"`
+	ProductID     int       `json:"product_id" example:"3"`
+	StoreID       int       `json:"store_id" example:"1"`
+	QuantityDelta int       `json:"quantity_delta" example:"-2"`
+	BalanceAfter  int       `json:"balance_after" example:"48"`
+	Reason        string    `json:"reason" example:"sale" enums:"initial,sale,refund,restock,adjustment,transfer_out,transfer_in"`
+	ReasonCode    string    `json:"reason_code,omitempty" example:"damage" enums:"damage,count_correction,received_goods"`
+	ReferenceType string    `json:"reference_type" example:"transaction" enums:"transaction,product,changeset,adjustment,count_session,purchase_order,stock_transfer"`
+	ReferenceID   *int      `json:"reference_id" example:"12"`
+	Note          string    `json:"note" example:""`
+	CreatedBy     *int      `json:"created_by" example:"1"`
+	CreatedAt     time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// StockAdjustmentInput represents a manual stock adjustment
+// @Description Signed quantity change with a reason code (damage must be negative, received_goods positive) at a store (default store when store_id is omitted)
+type StockAdjustmentInput struct {
+	Quantity   int    `json:"quantity" example:"-2" binding:"required"`
+	ReasonCode string `json:"reason_code" example:"damage" binding:"required,oneof=damage count_correction received_goods"`
+	Note       string `json:"note" example:"Dropped during shelving"`
+	StoreID    int    `json:"store_id" example:"1"`
+}
+
+// StockMovementParams holds the query parameters for listing stock movements
+type StockMovementParams struct {
+	ProductID int
+	StoreID   int
+	Reason    string
+	StartDate string
+	EndDate   string
+	Page      int
+	Limit     int
+}
+
+// PaginatedStockMovements represents a paginated list of stock movements
+// @Description Paginated list of stock movements
+type PaginatedStockMovements struct {
+	Data       []StockMovement `json:"data"`
+	Total      int             `json:"total" example:"100"`
+	Page       int             `json:"page" example:"1"`
+	Limit      int             `json:"limit" example:"20"`
+	TotalPages int             `json:"total_pages" example:"5"`
+}
+
+// StockUpdate is a stock change pushed to stream subscribers
+// @Description Stock change sent on the stock stream. stock is the product's total over all stores after the change, store_stock the store's current stock.
+type StockUpdate struct {
+	MovementID    int       `json:"movement_id" example:"42"`
+	ProductID     int       `json:"product_id" example:"3"`
+	StoreID       int       `json:"store_id" example:"1"`
+	QuantityDelta int       `json:"quantity_delta" example:"-2"`
+	Stock         int       `json:"stock" example:"48"`
+	StoreStock    int       `json:"store_stock" example:"20"`
+	Reason        string    `json:"reason" example:"sale"`
+	CreatedAt     time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+}
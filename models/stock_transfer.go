@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Stock transfer statuses
+const (
+	StockTransferInTransit = "in_transit"
+	StockTransferReceived  = "received"
+	StockTransferCancelled = "cancelled"
+)
+
+// StockTransfer moves stock from one store to another. Goods leave the source
+// store when the transfer is created and arrive when the destination confirms
+// receipt; while in transit they count towards neither store.
+// @Description Stock transfer between two stores with its lines
+type StockTransfer struct {
+	ID            int                 `json:"id" example:"1"`
+	FromStoreID   int                 `json:"from_store_id" example:"1"`
+	FromStoreName string              `json:"from_store_name" example:"Main Store"`
+	ToStoreID     int                 `json:"to_store_id" example:"2"`
+	ToStoreName   string              `json:"to_store_name" example:"Branch Kemang"`
+	Status        string              `json:"status" example:"in_transit" enums:"in_transit,received,cancelled"`
+	Note          string              `json:"note" example:"Weekend restock"`
+	TotalQuantity int                 `json:"total_quantity" example:"30"`
+	CreatedBy     int                 `json:"created_by" example:"1"`
+	ReceivedBy    *int                `json:"received_by" example:"2"`
+	CreatedAt     time.Time           `json:"created_at" example:"2026-03-05T08:00:00Z"`
+	UpdatedAt     time.Time           `json:"updated_at" example:"2026-03-05T08:00:00Z"`
+	ReceivedAt    *time.Time          `json:"received_at" example:"2026-03-05T14:00:00Z"`
+	Items         []StockTransferItem `json:"items,omitempty"`
+}
+
+// StockTransferItem is one product moved by a stock transfer
+// @Description Transferred product and quantity
+type StockTransferItem struct {
+	ID          int    `json:"id" example:"1"`
+	TransferID  int    `json:"transfer_id" example:"1"`
+	ProductID   int    `json:"product_id" example:"3"`
+	ProductName string `json:"product_name" example:"Indomie Goreng"`
+	SKU         string `json:"sku" example:"IDM-GRG-001"`
+	Quantity    int    `json:"quantity" example:"30"`
+}
+
+// StockTransferItemInput represents one product line of a new stock transfer
+type StockTransferItemInput struct {
+	ProductID int `json:"product_id" example:"3" binding:"required"`
+	Quantity  int `json:"quantity" example:"30" binding:"required,min=1"`
+}
+
+// StockTransferInput represents the input for creating a stock transfer
+// @Description Input model for sending stock from one store to another
+type StockTransferInput struct {
+	FromStoreID int                      `json:"from_store_id" example:"1" binding:"required"`
+	ToStoreID   int                      `json:"to_store_id" example:"2" binding:"required"`
+	Note        string                   `json:"note" example:"Weekend restock"`
+	Items       []StockTransferItemInput `json:"items" binding:"required,min=1,dive"`
+}
+
+// StockTransferParams holds the query parameters for listing stock transfers
+type StockTransferParams struct {
+	Status  string
+	StoreID int
+}
@@ -0,0 +1,111 @@
+package models
+
+import "time"
+
+// Count session types
+const (
+	CountSessionSpotCheck = "spot_check"
+	CountSessionCycle     = "cycle"
+	CountSessionStocktake = "stocktake"
+)
+
+// Count session statuses
+const (
+	CountStatusOpen      = "open"
+	CountStatusCompleted = "completed"
+	CountStatusCancelled = "cancelled"
+)
+
+// CountSession represents a stocktake: a set of products to be physically counted
+// @Description Stocktake session; completing it posts count corrections for every variance to the stock ledger
+type CountSession struct {
+	ID           int                `json:"id" example:"1"`
+	Type         string             `json:"type" example:"spot_check" enums:"spot_check,cycle,stocktake"`
+	Status       string             `json:"status" example:"open" enums:"open,completed,cancelled"`
+	Note         string             `json:"note" example:"Spot check sample of 20 products"`
+	StoreID      *int               `json:"store_id" example:"2"`
+	ScheduleID   *int               `json:"schedule_id" example:"1"`
+	AssignedTo   *int               `json:"assigned_to" example:"2"`
+	DueAt        *time.Time         `json:"due_at" example:"2026-02-27T08:00:00Z"`
+	CreatedBy    int                `json:"created_by" example:"1"`
+	CompletedBy  *int               `json:"completed_by" example:"1"`
+	ItemCount    int                `json:"item_count" example:"20"`
+	CountedCount int                `json:"counted_count" example:"12"`
+	CreatedAt    time.Time          `json:"created_at" example:"2026-02-20T08:00:00Z"`
+	CompletedAt  *time.Time         `json:"completed_at" example:"2026-02-20T09:15:00Z"`
+	Items        []CountSessionItem `json:"items,omitempty"`
+}
+
+// CountSessionItem represents one product to count in a session
+// @Description Expected quantity is the system stock (of the session's store, when it has one) when the item was last counted (or when the session opened)
+type CountSessionItem struct {
+	ID           int        `json:"id" example:"1"`
+	SessionID    int        `json:"session_id" example:"1"`
+	ProductID    int        `json:"product_id" example:"3"`
+	ProductName  string     `json:"product_name" example:"Indomie Goreng"`
+	SKU          string     `json:"sku" example:"IDM-GRG-001"`
+	SampleWeight float64    `json:"sample_weight" example:"0.084"`
+	ExpectedQty  int        `json:"expected_qty" example:"48"`
+	CountedQty   *int       `json:"counted_qty" example:"46"`
+	Variance     *int       `json:"variance" example:"-2"`
+	CountedBy    *int       `json:"counted_by" example:"2"`
+	CountedAt    *time.Time `json:"counted_at" example:"2026-02-20T08:40:00Z"`
+}
+
+// CountInput represents a physical count of one product in a session
+// @Description Counted quantity on the shelf
+type CountInput struct {
+	CountedQty *int `json:"counted_qty" example:"46" binding:"required,min=0"`
+}
+
+// StocktakeInput represents the input for opening a stocktake session
+// @Description Products to count at a store: every active product, narrowed to a category and/or an explicit product list. store_id defaults to the default store.
+type StocktakeInput struct {
+	StoreID    int    `json:"store_id" example:"2"`
+	CategoryID *int   `json:"category_id" example:"1"`
+	ProductIDs []int  `json:"product_ids" example:"3,7,12"`
+	Note       string `json:"note" example:"February month-end count"`
+}
+
+// CountVarianceLine is a counted product whose quantity differs from stock
+// @Description Counted product with a variance, valued at the product's current cost price
+type CountVarianceLine struct {
+	ProductID     int    `json:"product_id" example:"3"`
+	ProductName   string `json:"product_name" example:"Indomie Goreng"`
+	SKU           string `json:"sku" example:"IDM-GRG-001"`
+	ExpectedQty   int    `json:"expected_qty" example:"48"`
+	CountedQty    int    `json:"counted_qty" example:"46"`
+	Variance      int    `json:"variance" example:"-2"`
+	UnitCost      int    `json:"unit_cost" example:"2500"`
+	VarianceValue int    `json:"variance_value" example:"-5000"`
+}
+
+// CountVarianceReport summarizes the variances of a count session
+// @Description Shrinkage (units short) and overage (units over) of a count session at cost, with the lines that differ, largest value first. Uncounted items are excluded.
+type CountVarianceReport struct {
+	SessionID        int                 `json:"session_id" example:"1"`
+	Type             string              `json:"type" example:"stocktake" enums:"spot_check,cycle,stocktake"`
+	Status           string              `json:"status" example:"completed" enums:"open,completed,cancelled"`
+	StoreID          *int                `json:"store_id" example:"2"`
+	ItemCount        int                 `json:"item_count" example:"250"`
+	CountedCount     int                 `json:"counted_count" example:"250"`
+	MatchedCount     int                 `json:"matched_count" example:"238"`
+	VarianceCount    int                 `json:"variance_count" example:"12"`
+	AccuracyPercent  float64             `json:"accuracy_percent" example:"95.2"`
+	ShrinkageQty     int                 `json:"shrinkage_qty" example:"18"`
+	ShrinkageValue   int                 `json:"shrinkage_value" example:"61000"`
+	OverageQty       int                 `json:"overage_qty" example:"4"`
+	OverageValue     int                 `json:"overage_value" example:"9500"`
+	NetVarianceValue int                 `json:"net_variance_value" example:"-51500"`
+	CompletedAt      *time.Time          `json:"completed_at" example:"2026-02-28T18:30:00Z"`
+	Lines            []CountVarianceLine `json:"lines"`
+}
+
+// CountCandidate holds the price, stock and recent sales of an active product,
+// used to weight spot-check samples and to rank products into ABC classes
+type CountCandidate struct {
+	ProductID int
+	Price     int
+	Stock     int
+	UnitsSold int
+}
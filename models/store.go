@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Store represents a shop location holding its own stock
+// @Description Store location. Exactly one store is the default, used for checkouts and stock changes that do not name a store.
+type Store struct {
+	ID        int       `json:"id" example:"1"`
+	Code      string    `json:"code" example:"MAIN"`
+	Name      string    `json:"name" example:"Main Store"`
+	Address   string    `json:"address" example:"Jl. Merdeka 10, Bandung"`
+	IsDefault bool      `json:"is_default" example:"true"`
+	IsActive  bool      `json:"is_active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2026-02-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2026-02-01T12:00:00Z"`
+}
+
+// StoreInput represents the input for creating/updating a store
+// @Description Input model for creating or updating a store; is_active defaults to true
+type StoreInput struct {
+	Code     string `json:"code" example:"BDG2" binding:"required,max=20"`
+	Name     string `json:"name" example:"Dago Branch" binding:"required"`
+	Address  string `json:"address" example:"Jl. Dago 55, Bandung"`
+	IsActive *bool  `json:"is_active" example:"true"`
+}
+
+// StoreStock represents the stock of a product at one store
+// @Description Units of a product on hand at a store. A product's stock is the sum over all stores.
+type StoreStock struct {
+	StoreID     int       `json:"store_id" example:"2"`
+	ProductID   int       `json:"product_id" example:"3"`
+	ProductName string    `json:"product_name" example:"Indomie Goreng"`
+	SKU         string    `json:"sku" example:"IDM-GRG-001"`
+	Stock       int       `json:"stock" example:"24"`
+	MinStock    int       `json:"min_stock" example:"10"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// StoreSales represents the sales of one store in a consolidated report
+// @Description Revenue, transaction count and units sold at one store
+type StoreSales struct {
+	StoreID           int    `json:"store_id" example:"2"`
+	StoreCode         string `json:"store_code" example:"BDG2"`
+	StoreName         string `json:"store_name" example:"Dago Branch"`
+	TotalRevenue      int    `json:"total_revenue" example:"4500000"`
+	TotalTransactions int    `json:"total_transactions" example:"120"`
+	ItemsSold         int    `json:"items_sold" example:"310"`
+}
+
+// StoreSalesReport represents sales across every store for a date range
+// @Description Consolidated sales for a date range with a breakdown per store
+type StoreSalesReport struct {
+	StartDate         string       `json:"start_date" example:"2026-02-01"`
+	EndDate           string       `json:"end_date" example:"2026-02-28"`
+	TotalRevenue      int          `json:"total_revenue" example:"12500000"`
+	TotalTransactions int          `json:"total_transactions" example:"340"`
+	Stores            []StoreSales `json:"stores"`
+}
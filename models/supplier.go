@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Supplier represents a vendor that supplies or consigns products
+// @Description Supplier information; consignment products are owned by their supplier until sold
+type Supplier struct {
+	ID          int       `json:"id" example:"1"`
+	Name        string    `json:"name" example:"PT Sumber Makmur"`
+	ContactName string    `json:"contact_name" example:"Budi Santoso"`
+	Phone       string    `json:"phone" example:"+62 812 3456 7890"`
+	Email       string    `json:"email" example:"sales@sumbermakmur.co.id"`
+	Address     string    `json:"address" example:"Jl. Merdeka 10, Bandung"`
+	CreatedAt   time.Time `json:"created_at" example:"2026-02-01T12:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2026-02-01T12:00:00Z"`
+}
+
+// SupplierInput represents the input for creating/updating a supplier
+// @Description Input model for creating or updating a supplier
+type SupplierInput struct {
+	Name        string `json:"name" example:"PT Sumber Makmur" binding:"required"`
+	ContactName string `json:"contact_name" example:"Budi Santoso"`
+	Phone       string `json:"phone" example:"+62 812 3456 7890"`
+	Email       string `json:"email" example:"sales@sumbermakmur.co.id"`
+	Address     string `json:"address" example:"Jl. Merdeka 10, Bandung"`
+}
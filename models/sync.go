@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Entities replicated to the upstream instance
+const (
+	SyncEntityTransaction = "transaction"
+	SyncEntityVoid        = "void"
+	SyncEntityAdjustment  = "stock_adjustment"
+)
+
+// Sync record statuses. Entities without a record are pending; failed ones
+// are retried on the next run, conflicts only after POST /v1/sync/retry.
+const (
+	SyncStatusPending  = "pending"
+	SyncStatusSynced   = "synced"
+	SyncStatusSkipped  = "skipped"
+	SyncStatusConflict = "conflict"
+	SyncStatusFailed   = "failed"
+)
+
+// SyncRecord is how one local entity was replicated upstream
+// @Description Replication outcome of a local sale, void or stock adjustment. remote_id is the upstream transaction or stock movement ID; retry_count counts retries of conflicts.
+type SyncRecord struct {
+	ID         int       `json:"id" example:"1"`
+	EntityType string    `json:"entity_type" example:"transaction" enums:"transaction,void,stock_adjustment"`
+	EntityID   int       `json:"entity_id" example:"42"`
+	Status     string    `json:"status" example:"conflict" enums:"synced,skipped,conflict,failed"`
+	RemoteID   *int      `json:"remote_id" example:"1042"`
+	Attempts   int       `json:"attempts" example:"1"`
+	RetryCount int       `json:"retry_count" example:"0"`
+	Message    string    `json:"message" example:"product 'Indomie Goreng' (sku IDM-GRG-001) does not exist upstream"`
+	CreatedAt  time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// SyncEntityStatus counts the records of one entity type by status
+// @Description Replication counts of one entity type
+type SyncEntityStatus struct {
+	EntityType string `json:"entity_type" example:"transaction"`
+	Pending    int    `json:"pending" example:"3"`
+	Synced     int    `json:"synced" example:"1200"`
+	Skipped    int    `json:"skipped" example:"4"`
+	Conflict   int    `json:"conflict" example:"1"`
+	Failed     int    `json:"failed" example:"0"`
+}
+
+// SyncRun summarizes one replication run
+// @Description Outcome of one replication run; error is set when the run could not reach the upstream instance
+type SyncRun struct {
+	StartedAt  time.Time `json:"started_at" example:"2026-02-08T12:00:00Z"`
+	FinishedAt time.Time `json:"finished_at" example:"2026-02-08T12:00:03Z"`
+	Synced     int       `json:"synced" example:"12"`
+	Skipped    int       `json:"skipped" example:"0"`
+	Conflicts  int       `json:"conflicts" example:"1"`
+	Failed     int       `json:"failed" example:"0"`
+	Error      string    `json:"error,omitempty" example:""`
+}
+
+// SyncStatus is the state of replication to the upstream instance
+// @Description Replication state: configuration, the latest run, counts per entity type and the latest conflicts and failures
+type SyncStatus struct {
+	Enabled         bool               `json:"enabled" example:"true"`
+	Upstream        string             `json:"upstream" example:"https://pos.example.com"`
+	EdgeID          string             `json:"edge_id" example:"store-bandung"`
+	UpstreamStoreID int                `json:"upstream_store_id" example:"4"`
+	LastRun         *SyncRun           `json:"last_run"`
+	Entities        []SyncEntityStatus `json:"entities"`
+	Problems        []SyncRecord       `json:"problems"`
+}
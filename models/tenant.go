@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// DefaultTenantID is the tenant that existing data belongs to and that
+// requests without an API key or tenant token are served as
+const DefaultTenantID = 1
+
+// APIKeyHeader is the request header carrying a tenant API key
+const APIKeyHeader = "X-API-Key"
+
+// PlatformKeyHeader is the request header carrying the platform
+// administration key that guards the tenant management endpoints
+const PlatformKeyHeader = "X-Platform-Key"
+
+// Tenant is a merchant hosted on the deployment. Every row of every other
+// table belongs to exactly one tenant.
+// @Description Merchant hosted on a multi-tenant deployment
+type Tenant struct {
+	ID        int       `json:"id" example:"2"`
+	Slug      string    `json:"slug" example:"toko-sinar"`
+	Name      string    `json:"name" example:"Toko Sinar"`
+	HasAPIKey bool      `json:"has_api_key" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2026-02-01T12:00:00Z"`
+}
+
+// TenantInput represents the input for creating a tenant
+// @Description Input model for creating a tenant with its first owner account. The slug is lowercase letters, digits and dashes.
+type TenantInput struct {
+	Slug          string `json:"slug" example:"toko-sinar" binding:"required,max=50"`
+	Name          string `json:"name" example:"Toko Sinar" binding:"required,max=255"`
+	OwnerName     string `json:"owner_name" example:"Sinta" binding:"required"`
+	OwnerEmail    string `json:"owner_email" example:"owner@tokosinar.id" binding:"required,email"`
+	OwnerPassword string `json:"owner_password" example:"secret123" binding:"required,min=6"`
+}
+
+// TenantAPIKey is a newly issued tenant API key
+// @Description API key identifying the tenant on login, registration and public endpoints. It is shown only once; store it safely.
+type TenantAPIKey struct {
+	Tenant Tenant `json:"tenant"`
+	APIKey string `json:"api_key" example:"rk_3f7c9a1e5b2d4f608a9c1e3b5d7f9a1c"`
+}
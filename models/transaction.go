@@ -3,50 +3,65 @@ package models
 import "time"
 
 // Transaction represents a completed transaction
-// @Description Transaction information with details of purchased items
+// @Description Transaction information with details of purchased items. archived is true when the transaction was retrieved from cold storage.
 type Transaction struct {
 	ID            int                 `json:"id" example:"1"`
+	StoreID       int                 `json:"store_id" example:"1"`
+	ReceiptNo     string              `json:"receipt_no" example:"INV-20260208-0001"`
+	QueueNo       int                 `json:"queue_no" example:"17"`
 	TotalAmount   int                 `json:"total_amount" example:"45000"`
 	PaymentMethod string              `json:"payment_method" example:"cash"`
+	PriceLevel    string              `json:"price_level" example:"retail" enums:"retail,wholesale,member"`
 	Discount      int                 `json:"discount" example:"0"`
 	Notes         string              `json:"notes" example:""`
 	Status        string              `json:"status" example:"active"`
 	CreatedAt     time.Time           `json:"created_at" example:"2026-02-08T12:00:00Z"`
 	Details       []TransactionDetail `json:"details"`
+	Archived      bool                `json:"archived,omitempty" example:"false"`
 }
 
 // TransactionDetail represents a single item in a transaction
 // @Description Detail of a single item within a transaction
 type TransactionDetail struct {
-	ID            int    `json:"id" example:"1"`
-	TransactionID int    `json:"transaction_id" example:"1"`
-	ProductID     int    `json:"product_id" example:"3"`
-	ProductName   string `json:"product_name,omitempty" example:"Indomie Goreng"`
-	Quantity      int    `json:"quantity" example:"5"`
-	UnitPrice     int    `json:"unit_price" example:"3000"`
-	Subtotal      int    `json:"subtotal" example:"15000"`
+	ID            int                `json:"id" example:"1"`
+	TransactionID int                `json:"transaction_id" example:"1"`
+	ProductID     int                `json:"product_id" example:"3"`
+	ProductName   string             `json:"product_name,omitempty" example:"Indomie Goreng"`
+	Quantity      int                `json:"quantity" example:"5"`
+	UnitPrice     int                `json:"unit_price" example:"3000"`
+	Discount      int                `json:"discount" example:"3000"`
+	Subtotal      int                `json:"subtotal" example:"12000"`
+	Promotions    []AppliedPromotion `json:"promotions"`
+	CategoryID    *int               `json:"-"`
+	CostAmount    int                `json:"-"`
 }
 
 // CheckoutItem represents a single item in a checkout request
 // @Description Single item to be checked out
 type CheckoutItem struct {
-	ProductID int `json:"product_id" example:"3"`
-	Quantity  int `json:"quantity" example:"5"`
+	ProductID int `json:"product_id" example:"3" binding:"gt=0"`
+	Quantity  int `json:"quantity" example:"5" binding:"gt=0"`
 }
 
 // CheckoutRequest represents the request body for checkout
-// @Description Request body for processing a checkout
+// @Description Request body for processing a checkout at store_id (default store when omitted). price_level is the customer group whose price tiers apply (default retail).
 type CheckoutRequest struct {
-	Items         []CheckoutItem `json:"items"`
-	PaymentMethod string         `json:"payment_method" example:"cash"`
-	Discount      int            `json:"discount" example:"0"`
+	Items         []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+	StoreID       int            `json:"store_id" example:"1" binding:"min=0"`
+	PaymentMethod string         `json:"payment_method" example:"cash" binding:"max=50"`
+	PriceLevel    string         `json:"price_level" example:"retail" enums:"retail,wholesale,member" binding:"omitempty,oneof=retail wholesale member"`
+	Discount      int            `json:"discount" example:"0" binding:"min=0"`
 	Notes         string         `json:"notes" example:""`
+	CashierID     int            `json:"-"` // set from the authenticated user
 }
 
 // SalesReport represents the sales summary response
-// @Description Sales summary report with revenue, transaction count, and best seller
+// @Description Sales summary report with revenue, cost of sales, gross profit, transaction count, and best seller
 type SalesReport struct {
 	TotalRevenue       int                 `json:"total_revenue" example:"45000"`
+	TotalCOGS          int                 `json:"total_cogs" example:"30000"`
+	ConsignmentPayable int                 `json:"consignment_payable" example:"4000"`
+	GrossProfit        int                 `json:"gross_profit" example:"11000"`
 	TotalTransactions  int                 `json:"total_transactions" example:"5"`
 	BestSellingProduct *BestSellingProduct `json:"best_selling_product"`
 }
@@ -73,6 +88,9 @@ type DashboardStats struct {
 // @Description Transaction summary for list display
 type TransactionListItem struct {
 	ID            int       `json:"id" example:"1"`
+	StoreID       int       `json:"store_id" example:"1"`
+	ReceiptNo     string    `json:"receipt_no" example:"INV-20260208-0001"`
+	QueueNo       int       `json:"queue_no" example:"17"`
 	TotalAmount   int       `json:"total_amount" example:"45000"`
 	PaymentMethod string    `json:"payment_method" example:"cash"`
 	Discount      int       `json:"discount" example:"0"`
@@ -81,6 +99,30 @@ type TransactionListItem struct {
 	CreatedAt     time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
 }
 
+// TransactionListParams holds the query parameters for listing transactions
+type TransactionListParams struct {
+	StartDate string
+	EndDate   string
+	ReceiptNo string
+	StoreID   int
+	Page      int
+	Limit     int
+}
+
+// ReportFilter narrows a sales report to a period (YYYY-MM-DD, inclusive)
+// and, when set, a store, the cashier who rang up the sale, a product
+// category and a payment method. Figures per transaction (totals, counts)
+// cover the transactions that sold the category; figures per line (products,
+// categories) cover only its lines.
+type ReportFilter struct {
+	StartDate     string
+	EndDate       string
+	StoreID       int
+	CashierID     int
+	CategoryID    int
+	PaymentMethod string
+}
+
 // PaginatedTransactions represents a paginated list of transactions
 // @Description Paginated list of transactions
 type PaginatedTransactions struct {
@@ -100,6 +142,32 @@ type CategoryRevenue struct {
 	Transactions int    `json:"transactions" example:"25"`
 }
 
+// DailySales is the sales total of one day in a report period
+// @Description Sales of one day; days without sales have zeros
+type DailySales struct {
+	Date         string `json:"date" example:"2026-02-08"`
+	Transactions int    `json:"transactions" example:"12"`
+	ItemsSold    int    `json:"items_sold" example:"40"`
+	Revenue      int    `json:"revenue" example:"450000"`
+}
+
+// Time series granularities
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// SalesBucket is the sales total of one period of a time series
+// @Description Sales of one day, week (Monday to Sunday) or month; the first and last buckets are cut to the requested range, and buckets without sales have zeros
+type SalesBucket struct {
+	Start        string `json:"start" example:"2026-02-02"`
+	End          string `json:"end" example:"2026-02-08"`
+	Revenue      int    `json:"revenue" example:"3150000"`
+	Transactions int    `json:"transactions" example:"84"`
+	ItemsSold    int    `json:"items_sold" example:"280"`
+}
+
 // ReportSummary represents the aggregated report summary
 // @Description Aggregated report summary with category breakdown
 type ReportSummary struct {
@@ -108,3 +176,103 @@ type ReportSummary struct {
 	BestSellingProduct *BestSellingProduct `json:"best_selling_product"`
 	CategoryBreakdown  []CategoryRevenue  `json:"category_breakdown"`
 }
+
+// BestSeller is one product of the best sellers ranking
+// @Description A product ranked by quantity sold; revenue is net of transaction discounts
+type BestSeller struct {
+	Rank         int    `json:"rank" example:"1"`
+	ProductID    int    `json:"product_id" example:"3"`
+	ProductName  string `json:"product_name" example:"Indomie Goreng"`
+	CategoryName string `json:"category_name" example:"Food"`
+	QtySold      int    `json:"qty_sold" example:"120"`
+	Revenue      int    `json:"revenue" example:"360000"`
+	Transactions int    `json:"transactions" example:"85"`
+}
+
+// BestSellersReport is the top selling products of a date range
+// @Description The products sold most in a date range, best first
+type BestSellersReport struct {
+	StartDate string       `json:"start_date" example:"2026-02-01"`
+	EndDate   string       `json:"end_date" example:"2026-02-28"`
+	Limit     int          `json:"limit" example:"10"`
+	Products  []BestSeller `json:"products"`
+}
+
+// ProductProfit is the revenue, cost and margin of one product in a period
+// @Description Gross profit of a product; cost is FIFO COGS, or the supplier payable for consigned products
+type ProductProfit struct {
+	ProductID     int     `json:"product_id" example:"3"`
+	ProductName   string  `json:"product_name" example:"Indomie Goreng"`
+	CategoryID    *int    `json:"category_id" example:"1"`
+	CategoryName  string  `json:"category_name" example:"Food"`
+	QtySold       int     `json:"qty_sold" example:"120"`
+	Revenue       int     `json:"revenue" example:"360000"`
+	COGS          int     `json:"cogs" example:"300000"`
+	GrossProfit   int     `json:"gross_profit" example:"60000"`
+	MarginPercent float64 `json:"margin_percent" example:"16.67"`
+}
+
+// CategoryProfit is the revenue, cost and margin of one category in a period
+// @Description Gross profit of a category (uncategorized products have a null category_id)
+type CategoryProfit struct {
+	CategoryID    *int    `json:"category_id" example:"1"`
+	CategoryName  string  `json:"category_name" example:"Food"`
+	QtySold       int     `json:"qty_sold" example:"480"`
+	Revenue       int     `json:"revenue" example:"1440000"`
+	COGS          int     `json:"cogs" example:"1100000"`
+	GrossProfit   int     `json:"gross_profit" example:"340000"`
+	MarginPercent float64 `json:"margin_percent" example:"23.61"`
+}
+
+// CategorySales is the quantity and revenue of one category in a period
+// @Description Sales of a category (uncategorized products have a null category_id)
+type CategorySales struct {
+	CategoryID   *int    `json:"category_id" example:"1"`
+	CategoryName string  `json:"category_name" example:"Food"`
+	QtySold      int     `json:"qty_sold" example:"480"`
+	Revenue      int     `json:"revenue" example:"1440000"`
+	Transactions int     `json:"transactions" example:"130"`
+	SharePercent float64 `json:"share_percent" example:"48.5"`
+}
+
+// CategorySalesReport is the sales report per category for a date range
+// @Description Quantity and revenue per category for a date range; revenue is net of transaction discounts
+type CategorySalesReport struct {
+	StartDate    string          `json:"start_date" example:"2026-02-01"`
+	EndDate      string          `json:"end_date" example:"2026-02-28"`
+	QtySold      int             `json:"qty_sold" example:"990"`
+	Revenue      int             `json:"revenue" example:"2970000"`
+	Transactions int             `json:"transactions" example:"260"`
+	Categories   []CategorySales `json:"categories"`
+}
+
+// HourlySales is the sales of one hour of the day
+// @Description Sales in one hour of the day; hour 13 covers 13:00-13:59
+type HourlySales struct {
+	Hour         int `json:"hour" example:"13"`
+	Transactions int `json:"transactions" example:"24"`
+	Revenue      int `json:"revenue" example:"720000"`
+}
+
+// HourlySalesReport is the sales per hour of one day
+// @Description Transactions and revenue per hour of a day, all 24 hours included; peak_hour is the hour with the most transactions (null without sales)
+type HourlySalesReport struct {
+	Date         string        `json:"date" example:"2026-02-08"`
+	Transactions int           `json:"transactions" example:"180"`
+	Revenue      int           `json:"revenue" example:"5400000"`
+	PeakHour     *int          `json:"peak_hour" example:"13"`
+	Hours        []HourlySales `json:"hours"`
+}
+
+// ProfitReport is the gross profit report for a date range
+// @Description Revenue, COGS and margin per product and per category for a date range
+type ProfitReport struct {
+	StartDate     string           `json:"start_date" example:"2026-02-01"`
+	EndDate       string           `json:"end_date" example:"2026-02-28"`
+	Revenue       int              `json:"revenue" example:"15000000"`
+	COGS          int              `json:"cogs" example:"11000000"`
+	GrossProfit   int              `json:"gross_profit" example:"4000000"`
+	MarginPercent float64          `json:"margin_percent" example:"26.67"`
+	Categories    []CategoryProfit `json:"categories"`
+	Products      []ProductProfit  `json:"products"`
+}
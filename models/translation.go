@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Translation represents a localized name/description for a product or category
+// @Description Localized name and description for a single locale
+type Translation struct {
+	Locale      string    `json:"locale" example:"id"`
+	Name        string    `json:"name" example:"Minuman"`
+	Description string    `json:"description" example:"Minuman dingin dan panas"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// TranslationInput represents the input for creating/updating a translation
+// @Description Input model for creating or updating a translation (locale comes from the URL)
+type TranslationInput struct {
+	Name        string `json:"name" example:"Minuman" binding:"required"`
+	Description string `json:"description" example:"Minuman dingin dan panas"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Costing method used to value stock and cost sales
+const CostingMethodFIFO = "fifo"
+
+// Cost layer source types. Stock that did not come from a goods receipt is
+// layered at the product's cost_price: opening stock when a product is
+// created or given a cost price (source is the product), and stock added by
+// an adjustment (source is the stock movement) or a stocktake (source is the
+// count session).
+const (
+	CostSourceGoodsReceipt = "goods_receipt"
+	CostSourceOpening      = "opening"
+	CostSourceAdjustment   = "stock_adjustment"
+	CostSourceCountSession = "count_session"
+)
+
+// CostLayer is a batch of received stock at one unit cost. Sales consume the
+// oldest layers first (FIFO); Remaining is what is left of the batch.
+type CostLayer struct {
+	ID         int       `json:"id" example:"1"`
+	ProductID  int       `json:"product_id" example:"3"`
+	SourceType string    `json:"source_type" example:"goods_receipt"`
+	SourceID   int       `json:"source_id" example:"4"`
+	Quantity   int       `json:"quantity" example:"100"`
+	Remaining  int       `json:"remaining" example:"40"`
+	UnitCost   int       `json:"unit_cost" example:"2500"`
+	CreatedAt  time.Time `json:"created_at" example:"2026-02-22T10:00:00Z"`
+}
+
+// ProductValuation is the cost value of a product's current stock
+// @Description Stock value of a product; uncosted units (stock without a cost layer) are valued at the product's cost price, else its last received cost
+type ProductValuation struct {
+	ProductID    int    `json:"product_id" example:"3"`
+	Name         string `json:"name" example:"Indomie Goreng"`
+	SKU          string `json:"sku" example:"IDM-GRG-001"`
+	CategoryID   *int   `json:"category_id" example:"1"`
+	CategoryName string `json:"category_name" example:"Food"`
+	Stock        int    `json:"stock" example:"48"`
+	CostedQty    int    `json:"costed_qty" example:"40"`
+	UncostedQty  int    `json:"uncosted_qty" example:"8"`
+	LastCost     int    `json:"last_cost" example:"2600"`
+	AverageCost  int    `json:"average_cost" example:"2517"`
+	Value        int    `json:"value" example:"120800"`
+}
+
+// InventoryValuation is the cost value of all owned stock (consigned stock excluded)
+// @Description Inventory valuation report
+type InventoryValuation struct {
+	Method     string             `json:"method" example:"fifo"`
+	TotalUnits int                `json:"total_units" example:"1250"`
+	TotalValue int                `json:"total_value" example:"3400000"`
+	Products   []ProductValuation `json:"products"`
+}
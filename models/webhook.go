@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook events
+const (
+	WebhookEventProductUpdated     = "product.updated"
+	WebhookEventTransactionCreated = "transaction.created"
+	WebhookEventStockLow           = "stock.low"
+)
+
+// Webhook delivery statuses. Pending deliveries are retried with backoff
+// until they are delivered or run out of attempts.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an integrator's URL that receives events
+// @Description URL that receives the subscribed events as signed POST requests. secret is only returned when the webhook is created.
+type Webhook struct {
+	ID          int       `json:"id" example:"1"`
+	URL         string    `json:"url" example:"https://erp.example.com/hooks/pos"`
+	Description string    `json:"description" example:"ERP stock sync"`
+	Events      []string  `json:"events" example:"transaction.created,stock.low"`
+	Secret      string    `json:"secret,omitempty" example:"whsec_3f9a1c0e5b7d4a2e8c6f1b9d0a7e5c3b"`
+	IsActive    bool      `json:"is_active" example:"true"`
+	CreatedAt   time.Time `json:"created_at" example:"2026-02-08T12:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// WebhookInput represents the input for creating/updating a webhook
+// @Description Input model for a webhook. events lists product.updated, transaction.created and/or stock.low; is_active defaults to true.
+type WebhookInput struct {
+	URL         string   `json:"url" example:"https://erp.example.com/hooks/pos" binding:"required,max=2000"`
+	Description string   `json:"description" example:"ERP stock sync" binding:"max=255"`
+	Events      []string `json:"events" example:"transaction.created,stock.low" binding:"required"`
+	IsActive    *bool    `json:"is_active" example:"true"`
+}
+
+// WebhookDelivery is one event sent to one webhook
+// @Description Delivery of an event to a webhook with the outcome of its latest attempt. Pending deliveries are retried at next_attempt_at. attempt_history lists every attempt and is only returned for a single delivery.
+type WebhookDelivery struct {
+	ID             int              `json:"id" example:"1"`
+	WebhookID      int              `json:"webhook_id" example:"1"`
+	EventID        string           `json:"event_id" example:"evt_9b2f4c1d7e3a5f60"`
+	Event          string           `json:"event" example:"transaction.created"`
+	Payload        json.RawMessage  `json:"payload" swaggertype:"object"`
+	Status         string           `json:"status" example:"delivered" enums:"pending,delivered,failed"`
+	Attempts       int              `json:"attempts" example:"1"`
+	ResponseStatus int              `json:"response_status" example:"200"`
+	Error          string           `json:"error" example:""`
+	NextAttemptAt  *time.Time       `json:"next_attempt_at" example:"2026-02-08T12:00:30Z"`
+	CreatedAt      time.Time        `json:"created_at" example:"2026-02-08T12:00:00Z"`
+	DeliveredAt    *time.Time       `json:"delivered_at" example:"2026-02-08T12:00:01Z"`
+	AttemptHistory []WebhookAttempt `json:"attempt_history,omitempty"`
+}
+
+// WebhookAttempt is one try at sending a delivery
+// @Description Outcome of one delivery attempt: the HTTP status and the start of the response body, or the error when no response arrived
+type WebhookAttempt struct {
+	ID             int       `json:"id" example:"1"`
+	DeliveryID     int       `json:"delivery_id" example:"1"`
+	Attempt        int       `json:"attempt" example:"1"`
+	ResponseStatus int       `json:"response_status" example:"503"`
+	ResponseBody   string    `json:"response_body" example:"upstream busy"`
+	Error          string    `json:"error" example:"503 Service Unavailable: upstream busy"`
+	DurationMs     int       `json:"duration_ms" example:"120"`
+	AttemptedAt    time.Time `json:"attempted_at" example:"2026-02-08T12:00:00Z"`
+}
+
+// WebhookDeliveryParams holds the query parameters for listing deliveries
+type WebhookDeliveryParams struct {
+	WebhookID int
+	Status    string
+	Page      int
+	Limit     int
+}
+
+// WebhookTarget is a pending delivery with the webhook it goes to
+type WebhookTarget struct {
+	Delivery WebhookDelivery
+	URL      string
+	Secret   string
+}
+
+// PaginatedWebhookDeliveries represents a paginated list of webhook deliveries
+// @Description Paginated delivery log of a webhook
+type PaginatedWebhookDeliveries struct {
+	Data       []WebhookDelivery `json:"data"`
+	Total      int               `json:"total" example:"120"`
+	Page       int               `json:"page" example:"1"`
+	Limit      int               `json:"limit" example:"20"`
+	TotalPages int               `json:"total_pages" example:"6"`
+}
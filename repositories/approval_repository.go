@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// ApprovalRepository defines the interface for product change request data access
+type ApprovalRepository interface {
+	GetAll(ctx context.Context, status string) ([]models.ProductChangeRequest, error)
+	GetByID(ctx context.Context, id int) (*models.ProductChangeRequest, error)
+	Create(ctx context.Context, req models.ProductChangeRequest) (*models.ProductChangeRequest, error)
+	SetStatus(ctx context.Context, id int, status string, reviewerID int, note string, productID *int) (*models.ProductChangeRequest, error)
+}
+
+// approvalRepository implements ApprovalRepository interface with PostgreSQL
+type approvalRepository struct {
+	db DBTX
+}
+
+// NewApprovalRepository creates a new approval repository instance
+func NewApprovalRepository(db DBTX) ApprovalRepository {
+	return &approvalRepository{db: db}
+}
+
+// changeRequestColumns is the standard set of columns selected for change request queries
+const changeRequestColumns = `
+	id, action, product_id, payload, status, COALESCE(requested_by, 0), requested_by_name,
+	reviewed_by, review_note, created_at, reviewed_at
+`
+
+// scanChangeRequest scans a row into a ProductChangeRequest, decoding the JSON payload
+func scanChangeRequest(scanner interface{ Scan(dest ...interface{}) error }) (*models.ProductChangeRequest, error) {
+	var req models.ProductChangeRequest
+	var payload []byte
+	err := scanner.Scan(
+		&req.ID, &req.Action, &req.ProductID, &payload, &req.Status, &req.RequestedBy, &req.RequestedByName,
+		&req.ReviewedBy, &req.ReviewNote, &req.CreatedAt, &req.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(payload, &req.Payload); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetAll returns change requests, newest first, optionally filtered by status
+func (r *approvalRepository) GetAll(ctx context.Context, status string) ([]models.ProductChangeRequest, error) {
+	where := ""
+	args := []interface{}{}
+	if status != "" {
+		where = "WHERE status = $1"
+		args = append(args, status)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s FROM product_change_requests %s ORDER BY id DESC`, changeRequestColumns, where,
+	), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]models.ProductChangeRequest, 0)
+	for rows.Next() {
+		req, err := scanChangeRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, *req)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetByID returns a change request by its ID
+func (r *approvalRepository) GetByID(ctx context.Context, id int) (*models.ProductChangeRequest, error) {
+	req, err := scanChangeRequest(r.db.QueryRowContext(ctx,
+		`SELECT `+changeRequestColumns+` FROM product_change_requests WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return req, nil
+}
+
+// Create stores a new pending change request
+func (r *approvalRepository) Create(ctx context.Context, req models.ProductChangeRequest) (*models.ProductChangeRequest, error) {
+	payload, err := json.Marshal(req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanChangeRequest(r.db.QueryRowContext(ctx, `
+		INSERT INTO product_change_requests (action, product_id, payload, status, requested_by, requested_by_name)
+		VALUES ($1, $2, $3, 'pending', $4, $5)
+		RETURNING `+changeRequestColumns,
+		req.Action, req.ProductID, payload, req.RequestedBy, req.RequestedByName,
+	))
+}
+
+// SetStatus records the review decision on a pending change request. The
+// product ID is updated so approved creations point at the new product.
+// Returns nil if the request does not exist or was already reviewed.
+func (r *approvalRepository) SetStatus(ctx context.Context, id int, status string, reviewerID int, note string, productID *int) (*models.ProductChangeRequest, error) {
+	req, err := scanChangeRequest(r.db.QueryRowContext(ctx, `
+		UPDATE product_change_requests
+		SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = CURRENT_TIMESTAMP,
+		    product_id = COALESCE($4, product_id)
+		WHERE id = $5 AND status = 'pending'
+		RETURNING `+changeRequestColumns,
+		status, reviewerID, note, productID, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return req, nil
+}
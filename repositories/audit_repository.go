@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+)
+
+// AuditRepository defines the interface for audit log data access
+type AuditRepository interface {
+	Create(ctx context.Context, entry models.AuditLog) error
+	GetAll(ctx context.Context, params models.AuditLogParams) (*models.PaginatedAuditLogs, error)
+}
+
+// auditRepository implements AuditRepository interface with PostgreSQL
+type auditRepository struct {
+	db DBTX
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db DBTX) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Create appends an entry to the audit log
+func (r *auditRepository) Create(ctx context.Context, entry models.AuditLog) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_logs (entity_type, entity_id, action, actor_id, actor_name, before_data, after_data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, entry.EntityType, entry.EntityID, entry.Action, entry.ActorID, entry.ActorName,
+		nullableJSON(entry.Before), nullableJSON(entry.After))
+	return err
+}
+
+// nullableJSON stores empty JSON as SQL NULL
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+// GetAll returns audit logs, newest first, with optional filters
+func (r *auditRepository) GetAll(ctx context.Context, params models.AuditLogParams) (*models.PaginatedAuditLogs, error) {
+	if params.Page <= 0 {
+		params.Page = helpers.DefaultPage
+	}
+	if params.Limit <= 0 {
+		params.Limit = helpers.DefaultLimit
+	}
+
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIdx := 1
+
+	if params.EntityType != "" {
+		where += fmt.Sprintf(" AND entity_type = $%d", argIdx)
+		args = append(args, params.EntityType)
+		argIdx++
+	}
+	if params.EntityID != nil {
+		where += fmt.Sprintf(" AND entity_id = $%d", argIdx)
+		args = append(args, *params.EntityID)
+		argIdx++
+	}
+	if params.ActorID != nil {
+		where += fmt.Sprintf(" AND actor_id = $%d", argIdx)
+		args = append(args, *params.ActorID)
+		argIdx++
+	}
+	if params.Action != "" {
+		where += fmt.Sprintf(" AND action = $%d", argIdx)
+		args = append(args, params.Action)
+		argIdx++
+	}
+	if params.StartDate != "" {
+		where += fmt.Sprintf(" AND created_at::date >= $%d::date", argIdx)
+		args = append(args, params.StartDate)
+		argIdx++
+	}
+	if params.EndDate != "" {
+		where += fmt.Sprintf(" AND created_at::date <= $%d::date", argIdx)
+		args = append(args, params.EndDate)
+		argIdx++
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_logs"+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := fmt.Sprintf(`
+		SELECT id, entity_type, entity_id, action, actor_id, actor_name, before_data, after_data, created_at
+		FROM audit_logs
+		%s
+		ORDER BY id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, argIdx, argIdx+1)
+	args = append(args, params.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := make([]models.AuditLog, 0)
+	for rows.Next() {
+		var entry models.AuditLog
+		var before, after []byte
+		err := rows.Scan(
+			&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action,
+			&entry.ActorID, &entry.ActorName, &before, &after, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.Before = before
+		entry.After = after
+		logs = append(logs, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedAuditLogs{
+		Data:       logs,
+		Total:      total,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalPages: helpers.CalcTotalPages(total, params.Limit),
+	}, nil
+}
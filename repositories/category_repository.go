@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"retail-core-api/models"
 	"time"
@@ -8,27 +9,42 @@ import (
 
 // CategoryRepository defines the interface for category data access
 type CategoryRepository interface {
-	GetAll() ([]models.Category, error)
-	GetByID(id int) (*models.Category, error)
-	Create(category models.Category) (*models.Category, error)
-	Update(id int, category models.Category) (*models.Category, error)
-	Delete(id int) error
+	GetAll(ctx context.Context) ([]models.Category, error)
+	GetByID(ctx context.Context, id int) (*models.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Category, error)
+	Create(ctx context.Context, category models.Category) (*models.Category, error)
+	Update(ctx context.Context, id int, category models.Category) (*models.Category, error)
+	Delete(ctx context.Context, id int) error
+	GetDescendantIDs(ctx context.Context, id int) ([]int, error)
 }
 
 // categoryRepository implements CategoryRepository interface with PostgreSQL
 type categoryRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewCategoryRepository creates a new category repository instance
-func NewCategoryRepository(db *sql.DB) CategoryRepository {
+func NewCategoryRepository(db DBTX) CategoryRepository {
 	return &categoryRepository{db: db}
 }
 
+// categoryColumns is the standard set of columns selected for category queries
+const categoryColumns = `id, name, slug, COALESCE(description, ''), parent_id, created_at, updated_at`
+
+// scanCategory scans a row into a Category struct
+func scanCategory(scanner interface{ Scan(dest ...interface{}) error }) (*models.Category, error) {
+	var cat models.Category
+	err := scanner.Scan(&cat.ID, &cat.Name, &cat.Slug, &cat.Description, &cat.ParentID, &cat.CreatedAt, &cat.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
 // GetAll returns all categories from database
-func (r *categoryRepository) GetAll() ([]models.Category, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM categories ORDER BY id`
-	rows, err := r.db.Query(query)
+func (r *categoryRepository) GetAll(ctx context.Context) ([]models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -36,12 +52,11 @@ func (r *categoryRepository) GetAll() ([]models.Category, error) {
 
 	var categories []models.Category
 	for rows.Next() {
-		var cat models.Category
-		err := rows.Scan(&cat.ID, &cat.Name, &cat.Description, &cat.CreatedAt, &cat.UpdatedAt)
+		cat, err := scanCategory(rows)
 		if err != nil {
 			return nil, err
 		}
-		categories = append(categories, cat)
+		categories = append(categories, *cat)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -52,52 +67,64 @@ func (r *categoryRepository) GetAll() ([]models.Category, error) {
 }
 
 // GetByID returns a category by its ID
-func (r *categoryRepository) GetByID(id int) (*models.Category, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM categories WHERE id = $1`
-	var cat models.Category
-	err := r.db.QueryRow(query, id).Scan(&cat.ID, &cat.Name, &cat.Description, &cat.CreatedAt, &cat.UpdatedAt)
+func (r *categoryRepository) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+	cat, err := scanCategory(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &cat, nil
+	return cat, nil
+}
+
+// GetBySlug returns a category by its slug
+func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE slug = $1`
+	cat, err := scanCategory(r.db.QueryRowContext(ctx, query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cat, nil
 }
 
 // Create adds a new category and returns it
-func (r *categoryRepository) Create(category models.Category) (*models.Category, error) {
-	query := `INSERT INTO categories (name, description) VALUES ($1, $2) RETURNING id, name, description, created_at, updated_at`
-	var cat models.Category
-	err := r.db.QueryRow(query, category.Name, category.Description).Scan(
-		&cat.ID, &cat.Name, &cat.Description, &cat.CreatedAt, &cat.UpdatedAt,
-	)
+func (r *categoryRepository) Create(ctx context.Context, category models.Category) (*models.Category, error) {
+	slug, err := uniqueSlug(ctx, r.db, "categories", category.Name, 0)
 	if err != nil {
 		return nil, err
 	}
-	return &cat, nil
+
+	query := `INSERT INTO categories (name, slug, description, parent_id) VALUES ($1, $2, $3, $4) RETURNING ` + categoryColumns
+	return scanCategory(r.db.QueryRowContext(ctx, query, category.Name, slug, category.Description, category.ParentID))
 }
 
 // Update modifies an existing category
-func (r *categoryRepository) Update(id int, category models.Category) (*models.Category, error) {
-	query := `UPDATE categories SET name = $1, description = $2, updated_at = $3 WHERE id = $4 RETURNING id, name, description, created_at, updated_at`
-	var cat models.Category
-	err := r.db.QueryRow(query, category.Name, category.Description, time.Now(), id).Scan(
-		&cat.ID, &cat.Name, &cat.Description, &cat.CreatedAt, &cat.UpdatedAt,
-	)
+func (r *categoryRepository) Update(ctx context.Context, id int, category models.Category) (*models.Category, error) {
+	slug, err := uniqueSlug(ctx, r.db, "categories", category.Name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE categories SET name = $1, slug = $2, description = $3, parent_id = $4, updated_at = $5 WHERE id = $6 RETURNING ` + categoryColumns
+	cat, err := scanCategory(r.db.QueryRowContext(ctx, query, category.Name, slug, category.Description, category.ParentID, time.Now(), id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &cat, nil
+	return cat, nil
 }
 
 // Delete removes a category by its ID
-func (r *categoryRepository) Delete(id int) error {
+func (r *categoryRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM categories WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -113,3 +140,36 @@ func (r *categoryRepository) Delete(id int) error {
 	
 	return nil
 }
+
+// GetDescendantIDs returns the ID of a category followed by the IDs of all
+// its subcategories at any depth
+func (r *categoryRepository) GetDescendantIDs(ctx context.Context, id int) ([]int, error) {
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth FROM categories WHERE id = $1
+			UNION
+			SELECT c.id, t.depth + 1 FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree ORDER BY depth, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var childID int
+		if err := rows.Scan(&childID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, childID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
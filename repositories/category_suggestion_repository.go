@@ -0,0 +1,294 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// CategorySuggestionRepository defines the interface for category rule and
+// suggestion data access
+type CategorySuggestionRepository interface {
+	GetRules(ctx context.Context) ([]models.CategoryRule, error)
+	CreateRule(ctx context.Context, rule models.CategoryRule) (*models.CategoryRule, error)
+	DeleteRule(ctx context.Context, id int) (bool, error)
+	GetUncategorizedProducts(ctx context.Context, limit int) ([]models.Product, error)
+	GetCategorizedNames(ctx context.Context, limit int) (map[int][]string, error)
+	GetAll(ctx context.Context, status string) ([]models.CategorySuggestion, error)
+	GetByID(ctx context.Context, id int) (*models.CategorySuggestion, error)
+	Create(ctx context.Context, suggestion models.CategorySuggestion) (bool, error)
+	Accept(ctx context.Context, id, categoryID, reviewerID int) (*models.CategorySuggestion, error)
+	Reject(ctx context.Context, id, reviewerID int) (*models.CategorySuggestion, error)
+}
+
+// categorySuggestionRepository implements CategorySuggestionRepository interface with PostgreSQL
+type categorySuggestionRepository struct {
+	db DBTX
+}
+
+// NewCategorySuggestionRepository creates a new category suggestion repository instance
+func NewCategorySuggestionRepository(db DBTX) CategorySuggestionRepository {
+	return &categorySuggestionRepository{db: db}
+}
+
+// categorySuggestionColumns is the standard set of columns selected for suggestion queries
+const categorySuggestionColumns = `
+	s.id, s.product_id, p.name, COALESCE(p.sku, ''), s.category_id, c.name, s.confidence::float8,
+	s.source, s.reason, s.status, s.reviewed_by, COALESCE(u.name, ''), s.created_at, s.reviewed_at
+`
+
+// categorySuggestionFrom joins a suggestion with its product, category and reviewer
+const categorySuggestionFrom = `
+	FROM category_suggestions s
+	JOIN products p ON p.id = s.product_id
+	JOIN categories c ON c.id = s.category_id
+	LEFT JOIN users u ON u.id = s.reviewed_by
+`
+
+// scanCategorySuggestion scans a row into a CategorySuggestion
+func scanCategorySuggestion(scanner interface{ Scan(dest ...interface{}) error }) (*models.CategorySuggestion, error) {
+	var s models.CategorySuggestion
+	err := scanner.Scan(
+		&s.ID, &s.ProductID, &s.ProductName, &s.SKU, &s.CategoryID, &s.CategoryName, &s.Confidence,
+		&s.Source, &s.Reason, &s.Status, &s.ReviewedBy, &s.ReviewedByName, &s.CreatedAt, &s.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetRules returns every keyword rule grouped by category
+func (r *categorySuggestionRepository) GetRules(ctx context.Context) ([]models.CategoryRule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT cr.id, cr.category_id, c.name, cr.keyword, cr.weight, cr.created_at
+		FROM category_rules cr
+		JOIN categories c ON c.id = cr.category_id
+		ORDER BY c.name, cr.keyword
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]models.CategoryRule, 0)
+	for rows.Next() {
+		var rule models.CategoryRule
+		if err := rows.Scan(&rule.ID, &rule.CategoryID, &rule.CategoryName, &rule.Keyword, &rule.Weight, &rule.CreatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// CreateRule stores a keyword rule. A rule for the same category and keyword
+// has its weight replaced.
+func (r *categorySuggestionRepository) CreateRule(ctx context.Context, rule models.CategoryRule) (*models.CategoryRule, error) {
+	err := r.db.QueryRowContext(ctx, `
+		WITH saved AS (
+			INSERT INTO category_rules (category_id, keyword, weight)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (category_id, keyword) DO UPDATE SET weight = EXCLUDED.weight
+			RETURNING id, category_id, keyword, weight, created_at
+		)
+		SELECT saved.id, saved.category_id, c.name, saved.keyword, saved.weight, saved.created_at
+		FROM saved JOIN categories c ON c.id = saved.category_id
+	`, rule.CategoryID, rule.Keyword, rule.Weight).Scan(
+		&rule.ID, &rule.CategoryID, &rule.CategoryName, &rule.Keyword, &rule.Weight, &rule.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// DeleteRule removes a keyword rule. Returns false if it does not exist.
+func (r *categorySuggestionRepository) DeleteRule(ctx context.Context, id int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM category_rules WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetUncategorizedProducts returns products without a category that have no
+// suggestion yet, oldest first
+func (r *categorySuggestionRepository) GetUncategorizedProducts(ctx context.Context, limit int) ([]models.Product, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.id, p.name, COALESCE(p.sku, '')
+		FROM products p
+		WHERE p.category_id IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM category_suggestions s WHERE s.product_id = p.id)
+		ORDER BY p.id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.Product, 0)
+	for rows.Next() {
+		var p models.Product
+		if err := rows.Scan(&p.ID, &p.Name, &p.SKU); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, rows.Err()
+}
+
+// GetCategorizedNames returns the names of the most recently updated
+// categorized products, keyed by category ID
+func (r *categorySuggestionRepository) GetCategorizedNames(ctx context.Context, limit int) (map[int][]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT category_id, name
+		FROM products
+		WHERE category_id IS NOT NULL
+		ORDER BY updated_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[int][]string)
+	for rows.Next() {
+		var categoryID int
+		var name string
+		if err := rows.Scan(&categoryID, &name); err != nil {
+			return nil, err
+		}
+		names[categoryID] = append(names[categoryID], name)
+	}
+	return names, rows.Err()
+}
+
+// GetAll returns suggestions, most confident first, optionally filtered by status
+func (r *categorySuggestionRepository) GetAll(ctx context.Context, status string) ([]models.CategorySuggestion, error) {
+	where := ""
+	args := []interface{}{}
+	if status != "" {
+		where = "WHERE s.status = $1"
+		args = append(args, status)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s %s %s ORDER BY s.confidence DESC, s.id`, categorySuggestionColumns, categorySuggestionFrom, where,
+	), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := make([]models.CategorySuggestion, 0)
+	for rows.Next() {
+		s, err := scanCategorySuggestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, *s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// GetByID returns a suggestion by its ID
+func (r *categorySuggestionRepository) GetByID(ctx context.Context, id int) (*models.CategorySuggestion, error) {
+	s, err := scanCategorySuggestion(r.db.QueryRowContext(ctx,
+		`SELECT `+categorySuggestionColumns+categorySuggestionFrom+`WHERE s.id = $1`, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create queues a pending suggestion. Returns false if the product already
+// has one.
+func (r *categorySuggestionRepository) Create(ctx context.Context, s models.CategorySuggestion) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO category_suggestions (product_id, category_id, confidence, source, reason, status)
+		VALUES ($1, $2, $3, $4, $5, 'pending')
+		ON CONFLICT (product_id) DO NOTHING
+	`, s.ProductID, s.CategoryID, s.Confidence, s.Source, s.Reason)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Accept assigns the category to the product and marks the suggestion
+// accepted in one transaction. Returns nil if the suggestion does not exist
+// or was already reviewed.
+func (r *categorySuggestionRepository) Accept(ctx context.Context, id, categoryID, reviewerID int) (*models.CategorySuggestion, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var productID int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE category_suggestions
+		SET status = 'accepted', category_id = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND status = 'pending'
+		RETURNING product_id
+	`, categoryID, reviewerID, id).Scan(&productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE products SET category_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2
+	`, categoryID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Reject marks a pending suggestion rejected so the product is not suggested
+// again. Returns nil if the suggestion does not exist or was already reviewed.
+func (r *categorySuggestionRepository) Reject(ctx context.Context, id, reviewerID int) (*models.CategorySuggestion, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE category_suggestions
+		SET status = 'rejected', reviewed_by = $1, reviewed_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = 'pending'
+	`, reviewerID, id)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+	return r.GetByID(ctx, id)
+}
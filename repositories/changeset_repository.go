@@ -0,0 +1,386 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"time"
+)
+
+// ChangesetRepository defines the interface for catalog changeset data access
+type ChangesetRepository interface {
+	GetAll(ctx context.Context, status string) ([]models.CatalogChangeset, error)
+	GetByID(ctx context.Context, id int) (*models.CatalogChangeset, error)
+	Create(ctx context.Context, changeset models.CatalogChangeset) (*models.CatalogChangeset, error)
+	AddItem(ctx context.Context, item models.CatalogChangesetItem) (*models.CatalogChangesetItem, error)
+	DeleteItem(ctx context.Context, changesetID, itemID int) error
+	SetStatus(ctx context.Context, id int, status string, publishAt *time.Time) (*models.CatalogChangeset, error)
+	SetError(ctx context.Context, id int, message string) error
+	GetDueIDs(ctx context.Context) ([]int, error)
+	Publish(ctx context.Context, id int, actor models.Actor) error
+}
+
+// changesetRepository implements ChangesetRepository interface with PostgreSQL
+type changesetRepository struct {
+	db DBTX
+}
+
+// NewChangesetRepository creates a new changeset repository instance
+func NewChangesetRepository(db DBTX) ChangesetRepository {
+	return &changesetRepository{db: db}
+}
+
+// changesetColumns is the standard set of columns selected for changeset queries
+const changesetColumns = `
+	id, name, status, publish_at, COALESCE(created_by, 0), published_at, last_error, created_at, updated_at
+`
+
+// scanChangeset scans a row into a CatalogChangeset struct
+func scanChangeset(scanner interface{ Scan(dest ...interface{}) error }) (*models.CatalogChangeset, error) {
+	var cs models.CatalogChangeset
+	err := scanner.Scan(
+		&cs.ID, &cs.Name, &cs.Status, &cs.PublishAt, &cs.CreatedBy,
+		&cs.PublishedAt, &cs.LastError, &cs.CreatedAt, &cs.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cs.Items = make([]models.CatalogChangesetItem, 0)
+	return &cs, nil
+}
+
+// GetAll returns changesets, newest first, optionally filtered by status (items are not loaded)
+func (r *changesetRepository) GetAll(ctx context.Context, status string) ([]models.CatalogChangeset, error) {
+	where := ""
+	args := []interface{}{}
+	if status != "" {
+		where = "WHERE status = $1"
+		args = append(args, status)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s FROM catalog_changesets %s ORDER BY id DESC`, changesetColumns, where,
+	), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changesets := make([]models.CatalogChangeset, 0)
+	for rows.Next() {
+		cs, err := scanChangeset(rows)
+		if err != nil {
+			return nil, err
+		}
+		changesets = append(changesets, *cs)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changesets, nil
+}
+
+// GetByID returns a changeset with its items
+func (r *changesetRepository) GetByID(ctx context.Context, id int) (*models.CatalogChangeset, error) {
+	cs, err := scanChangeset(r.db.QueryRowContext(ctx,
+		`SELECT `+changesetColumns+` FROM catalog_changesets WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	items, err := r.getItems(ctx, r.db, id)
+	if err != nil {
+		return nil, err
+	}
+	cs.Items = items
+
+	return cs, nil
+}
+
+// getItems loads the items of a changeset in insertion order
+func (r *changesetRepository) getItems(ctx context.Context, q rowsQueryer, changesetID int) ([]models.CatalogChangesetItem, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, changeset_id, action, product_id, payload, created_at
+		FROM catalog_changeset_items
+		WHERE changeset_id = $1
+		ORDER BY id
+	`, changesetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.CatalogChangesetItem, 0)
+	for rows.Next() {
+		var item models.CatalogChangesetItem
+		var payload []byte
+		if err := rows.Scan(&item.ID, &item.ChangesetID, &item.Action, &item.ProductID, &payload, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &item.Payload); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Create adds a new draft changeset and returns it
+func (r *changesetRepository) Create(ctx context.Context, changeset models.CatalogChangeset) (*models.CatalogChangeset, error) {
+	return scanChangeset(r.db.QueryRowContext(ctx, `
+		INSERT INTO catalog_changesets (name, status, created_by)
+		VALUES ($1, $2, NULLIF($3, 0))
+		RETURNING `+changesetColumns,
+		changeset.Name, models.ChangesetStatusDraft, changeset.CreatedBy,
+	))
+}
+
+// AddItem stores a draft product change in a changeset
+func (r *changesetRepository) AddItem(ctx context.Context, item models.CatalogChangesetItem) (*models.CatalogChangesetItem, error) {
+	payload, err := json.Marshal(item.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	created := item
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO catalog_changeset_items (changeset_id, action, product_id, payload)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, item.ChangesetID, item.Action, item.ProductID, payload).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// DeleteItem removes an item from a changeset
+func (r *changesetRepository) DeleteItem(ctx context.Context, changesetID, itemID int) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM catalog_changeset_items WHERE id = $1 AND changeset_id = $2`, itemID, changesetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetStatus moves a changeset that has not been published or cancelled to a
+// new status. It returns nil when no such changeset exists.
+func (r *changesetRepository) SetStatus(ctx context.Context, id int, status string, publishAt *time.Time) (*models.CatalogChangeset, error) {
+	cs, err := scanChangeset(r.db.QueryRowContext(ctx, `
+		UPDATE catalog_changesets
+		SET status = $1, publish_at = $2, last_error = '', updated_at = NOW()
+		WHERE id = $3 AND status IN ('draft', 'scheduled')
+		RETURNING `+changesetColumns,
+		status, publishAt, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cs, nil
+}
+
+// SetError records why the last publish attempt failed
+func (r *changesetRepository) SetError(ctx context.Context, id int, message string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE catalog_changesets SET last_error = $1, updated_at = NOW() WHERE id = $2`, message, id,
+	)
+	return err
+}
+
+// GetDueIDs returns scheduled changesets whose publish time has passed
+func (r *changesetRepository) GetDueIDs(ctx context.Context) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id FROM catalog_changesets
+		WHERE status = 'scheduled' AND publish_at <= NOW()
+		ORDER BY publish_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Publish applies every item of a changeset to the products table in a single
+// database transaction, so either all changes go live or none do. Price
+// changes are attributed to actor.
+func (r *changesetRepository) Publish(ctx context.Context, id int, actor models.Actor) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM catalog_changesets WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NewNotFoundError("changeset not found")
+		}
+		return err
+	}
+	if status != models.ChangesetStatusDraft && status != models.ChangesetStatusScheduled {
+		return helpers.NewConflictError(fmt.Sprintf("changeset is already %s", status))
+	}
+
+	items, err := r.getItems(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return helpers.NewConflictError("changeset has no items")
+	}
+
+	for _, item := range items {
+		p := item.Payload
+		switch item.Action {
+		case models.ChangeActionCreate:
+			slug, err := uniqueSlug(ctx, tx, "products", p.Name, 0)
+			if err != nil {
+				return err
+			}
+			var productID int
+			err = tx.QueryRowContext(ctx, `
+				INSERT INTO products (name, slug, price, stock, min_stock, sku, image_url, unit, is_active, category_id,
+				                      supplier_id, is_consignment, consignment_cost, cost_price)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+				RETURNING id
+			`, p.Name, slug, p.Price, p.Stock, p.MinStock, p.SKU, p.ImageURL, p.Unit, p.IsActive, p.CategoryID,
+				p.SupplierID, p.IsConsignment, p.ConsignmentCost, p.CostPrice).Scan(&productID)
+			if err != nil {
+				return err
+			}
+			if _, err = tx.ExecContext(ctx, `UPDATE catalog_changeset_items SET product_id = $1 WHERE id = $2`, productID, item.ID); err != nil {
+				return err
+			}
+			err = recordStockMovement(ctx, tx, models.StockMovement{
+				ProductID:     productID,
+				QuantityDelta: p.Stock,
+				BalanceAfter:  p.Stock,
+				Reason:        models.StockReasonInitial,
+				ReferenceType: models.StockRefChangeset,
+				ReferenceID:   &id,
+			})
+			if err != nil {
+				return err
+			}
+			err = recordPriceChange(ctx, tx, models.PriceChange{
+				ProductID:   productID,
+				NewPrice:    p.Price,
+				Source:      models.PriceSourceChangeset,
+				ReferenceID: &id,
+			}, actor)
+			if err != nil {
+				return err
+			}
+			if err = openCostLayer(ctx, tx, productID, models.CostSourceOpening, productID); err != nil {
+				return err
+			}
+		case models.ChangeActionUpdate:
+			if item.ProductID == nil {
+				return fmt.Errorf("changeset item %d has no product", item.ID)
+			}
+			var oldStock, oldPrice int
+			err = tx.QueryRowContext(ctx, `SELECT stock, price FROM products WHERE id = $1 FOR UPDATE`, *item.ProductID).Scan(&oldStock, &oldPrice)
+			if err == sql.ErrNoRows {
+				return helpers.NewValidationError(fmt.Sprintf("product id %d not found", *item.ProductID))
+			}
+			if err != nil {
+				return err
+			}
+			slug, err := uniqueSlug(ctx, tx, "products", p.Name, *item.ProductID)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, `
+				UPDATE products
+				SET name = $1, slug = $2, price = $3, stock = $4, min_stock = $5, sku = $6, image_url = $7,
+				    unit = $8, is_active = $9, category_id = $10, supplier_id = $11, is_consignment = $12,
+				    consignment_cost = $13, cost_price = $14, updated_at = $15
+				WHERE id = $16
+			`, p.Name, slug, p.Price, p.Stock, p.MinStock, p.SKU, p.ImageURL, p.Unit, p.IsActive, p.CategoryID,
+				p.SupplierID, p.IsConsignment, p.ConsignmentCost, p.CostPrice, time.Now(), *item.ProductID)
+			if err != nil {
+				return err
+			}
+			err = recordStockMovement(ctx, tx, models.StockMovement{
+				ProductID:     *item.ProductID,
+				QuantityDelta: p.Stock - oldStock,
+				BalanceAfter:  p.Stock,
+				Reason:        models.StockReasonAdjustment,
+				ReferenceType: models.StockRefChangeset,
+				ReferenceID:   &id,
+			})
+			if err != nil {
+				return err
+			}
+			err = recordPriceChange(ctx, tx, models.PriceChange{
+				ProductID:   *item.ProductID,
+				OldPrice:    &oldPrice,
+				NewPrice:    p.Price,
+				Source:      models.PriceSourceChangeset,
+				ReferenceID: &id,
+			}, actor)
+			if err != nil {
+				return err
+			}
+			if err = openCostLayer(ctx, tx, *item.ProductID, models.CostSourceOpening, *item.ProductID); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("changeset item %d has unknown action '%s'", item.ID, item.Action)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE catalog_changesets
+		SET status = $1, published_at = NOW(), last_error = '', updated_at = NOW()
+		WHERE id = $2
+	`, models.ChangesetStatusPublished, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
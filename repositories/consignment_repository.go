@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// ConsignmentRepository defines the interface for consignment payable data access
+type ConsignmentRepository interface {
+	GetSettlements(ctx context.Context, startDate, endDate string, supplierID *int) ([]models.ConsignmentSettlement, error)
+}
+
+// consignmentRepository implements ConsignmentRepository interface with PostgreSQL
+type consignmentRepository struct {
+	db DBTX
+}
+
+// NewConsignmentRepository creates a new consignment repository instance
+func NewConsignmentRepository(db DBTX) ConsignmentRepository {
+	return &consignmentRepository{db: db}
+}
+
+// accrueConsignmentPayables records what the store owes the supplier for the
+// lines of a sale whose products are consigned, in one statement. Lines of
+// other products are skipped. The cost is taken from the product at the time
+// of sale.
+func accrueConsignmentPayables(ctx context.Context, e execer, transactionID int, details []models.TransactionDetail) error {
+	args := make([]interface{}, 0, 2+len(details)*4)
+	args = append(args, transactionID, models.ConsignmentEntrySale)
+	for _, d := range details {
+		args = append(args, d.ID, d.ProductID, d.Quantity, d.Subtotal)
+	}
+	_, err := e.ExecContext(ctx, `
+		WITH lines (detail_id, product_id, quantity, sales_amount) AS (
+			VALUES `+valuesList(len(details), 4, 2, "int")+`
+		)
+		INSERT INTO consignment_payables
+			(transaction_id, transaction_detail_id, supplier_id, product_id, quantity, unit_cost, amount, sales_amount, entry_type)
+		SELECT $1, l.detail_id, p.supplier_id, p.id, l.quantity, p.consignment_cost, p.consignment_cost * l.quantity, l.sales_amount, $2
+		FROM lines l
+		JOIN products p ON p.id = l.product_id
+		WHERE p.is_consignment = true AND p.supplier_id IS NOT NULL
+	`, args...)
+	return err
+}
+
+// reverseConsignmentPayables books a negative entry for every payable accrued
+// by a transaction, dated now, so voids reduce the period they happen in
+func reverseConsignmentPayables(ctx context.Context, e execer, transactionID int) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO consignment_payables
+			(transaction_id, transaction_detail_id, supplier_id, product_id, quantity, unit_cost, amount, sales_amount, entry_type)
+		SELECT transaction_id, transaction_detail_id, supplier_id, product_id, -quantity, unit_cost, -amount, -sales_amount, $1
+		FROM consignment_payables
+		WHERE transaction_id = $2 AND entry_type = $3
+	`, models.ConsignmentEntryReversal, transactionID, models.ConsignmentEntrySale)
+	return err
+}
+
+// GetSettlements sums consignment payables per supplier and product for
+// entries booked between two dates (inclusive)
+func (r *consignmentRepository) GetSettlements(ctx context.Context, startDate, endDate string, supplierID *int) ([]models.ConsignmentSettlement, error) {
+	where := "WHERE cp.created_at::date >= $1::date AND cp.created_at::date <= $2::date"
+	args := []interface{}{startDate, endDate}
+	if supplierID != nil {
+		where += " AND cp.supplier_id = $3"
+		args = append(args, *supplierID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT cp.supplier_id, COALESCE(s.name, ''), cp.product_id, COALESCE(p.name, ''),
+		       SUM(cp.quantity), SUM(cp.sales_amount), SUM(cp.amount)
+		FROM consignment_payables cp
+		LEFT JOIN suppliers s ON s.id = cp.supplier_id
+		LEFT JOIN products p ON p.id = cp.product_id
+		%s
+		GROUP BY cp.supplier_id, s.name, cp.product_id, p.name
+		ORDER BY s.name, cp.supplier_id, p.name, cp.product_id
+	`, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settlements := make([]models.ConsignmentSettlement, 0)
+	for rows.Next() {
+		var supplierID int
+		var supplierName string
+		var line models.ConsignmentSettlementLine
+		err := rows.Scan(
+			&supplierID, &supplierName, &line.ProductID, &line.ProductName,
+			&line.UnitsSold, &line.SalesAmount, &line.PayableAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Rows are ordered by supplier, so a new supplier starts a new settlement
+		if n := len(settlements); n == 0 || settlements[n-1].SupplierID != supplierID {
+			settlements = append(settlements, models.ConsignmentSettlement{
+				SupplierID:   supplierID,
+				SupplierName: supplierName,
+				Products:     make([]models.ConsignmentSettlementLine, 0),
+			})
+		}
+		current := &settlements[len(settlements)-1]
+		current.UnitsSold += line.UnitsSold
+		current.SalesAmount += line.SalesAmount
+		current.PayableAmount += line.PayableAmount
+		current.StoreMargin = current.SalesAmount - current.PayableAmount
+		current.Products = append(current.Products, line)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return settlements, nil
+}
@@ -0,0 +1,279 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/models"
+	"strings"
+)
+
+// ConsistencyRepository defines the interface for data consistency checks
+type ConsistencyRepository interface {
+	CheckTransactionTotals(ctx context.Context, date string) (checked int, mismatches []models.TransactionTotalCheck, err error)
+	CheckTransactionTotal(ctx context.Context, id int) (*models.TransactionTotalCheck, error)
+	SetTransactionTotal(ctx context.Context, id, total int) error
+	CheckStock(ctx context.Context) (checked int, drifts []models.StockDrift, err error)
+	CheckProductStock(ctx context.Context, id int) (*models.StockDrift, error)
+	SetProductStock(ctx context.Context, drift models.StockDrift) error
+}
+
+// consistencyRepository implements ConsistencyRepository interface with PostgreSQL
+type consistencyRepository struct {
+	db DBTX
+}
+
+// NewConsistencyRepository creates a new consistency repository instance
+func NewConsistencyRepository(db DBTX) ConsistencyRepository {
+	return &consistencyRepository{db: db}
+}
+
+// transactionTotalsQuery recomputes the totals of the transactions matching
+// its WHERE condition from their lines. Sums are bigint, so they cannot
+// overflow. Lines without a unit price predate its column and are not
+// checked against it.
+const transactionTotalsQuery = `
+	WITH totals AS (
+		SELECT t.id, t.receipt_no, t.store_id, t.status, t.created_at, t.total_amount,
+		       COALESCE(t.discount, 0) AS discount,
+		       COUNT(td.id) AS lines,
+		       COALESCE(SUM(td.subtotal), 0) AS lines_subtotal,
+		       COUNT(td.id) FILTER (
+		           WHERE td.unit_price > 0
+		             AND td.subtotal <> td.unit_price::bigint * td.quantity - COALESCE(td.discount, 0)
+		       ) AS line_mismatches
+		FROM transactions t
+		LEFT JOIN transaction_details td ON td.transaction_id = t.id
+		WHERE %s
+		GROUP BY t.id
+	)
+	SELECT id, receipt_no, store_id, status, created_at, lines, lines_subtotal, discount,
+	       GREATEST(lines_subtotal - discount, 0) AS expected_total, total_amount,
+	       line_mismatches
+	FROM totals
+`
+
+// scanTransactionTotalCheck scans a row of transactionTotalsQuery
+func scanTransactionTotalCheck(row interface{ Scan(...interface{}) error }) (models.TransactionTotalCheck, error) {
+	var c models.TransactionTotalCheck
+	err := row.Scan(
+		&c.TransactionID, &c.ReceiptNo, &c.StoreID, &c.Status, &c.CreatedAt, &c.Lines,
+		&c.LinesSubtotal, &c.Discount, &c.ExpectedTotal, &c.RecordedTotal, &c.LineMismatches,
+	)
+	c.Difference = c.RecordedTotal - c.ExpectedTotal
+	return c, err
+}
+
+// CheckTransactionTotals recomputes the total of every transaction created on
+// date (YYYY-MM-DD) and returns how many were checked and those whose recorded
+// total or lines disagree, in ID order
+func (r *consistencyRepository) CheckTransactionTotals(ctx context.Context, date string) (int, []models.TransactionTotalCheck, error) {
+	var checked int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM transactions WHERE created_at::date = $1::date`, date).Scan(&checked)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(transactionTotalsQuery, "t.created_at::date = $1::date")+`
+		WHERE total_amount <> GREATEST(lines_subtotal - discount, 0) OR line_mismatches > 0
+		ORDER BY id
+	`, date)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	mismatches := make([]models.TransactionTotalCheck, 0)
+	for rows.Next() {
+		c, err := scanTransactionTotalCheck(rows)
+		if err != nil {
+			return 0, nil, err
+		}
+		mismatches = append(mismatches, c)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	return checked, mismatches, nil
+}
+
+// CheckTransactionTotal recomputes the total of one transaction, or returns
+// nil if it does not exist. Inside a DB transaction the header row is locked
+// until the end of it, so the figures hold for a following repair.
+func (r *consistencyRepository) CheckTransactionTotal(ctx context.Context, id int) (*models.TransactionTotalCheck, error) {
+	var locked int
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM transactions WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := scanTransactionTotalCheck(r.db.QueryRowContext(ctx, fmt.Sprintf(transactionTotalsQuery, "t.id = $1"), id))
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// SetTransactionTotal overwrites the recorded total of a transaction
+func (r *consistencyRepository) SetTransactionTotal(ctx context.Context, id, total int) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE transactions SET total_amount = $2 WHERE id = $1`, id, total)
+	return err
+}
+
+// stockDriftQuery compares the total and per-store stock of the products
+// matching its first verb, a condition on a product_id column, with the
+// stock movement ledger, returning a row per drifted store of each product
+// (one row without a store when only the total drifted). Its second verb
+// filters the products returned.
+//
+// Stock that predates the ledger shows up as an opening balance: the balance
+// before a product's first entry. It is counted at the default store, which
+// held all stock when stores were introduced.
+const stockDriftQuery = `
+	WITH opening AS (
+		SELECT DISTINCT ON (product_id) product_id, balance_after - quantity_delta AS opening
+		FROM stock_movements
+		WHERE product_id %[1]s
+		ORDER BY product_id, id
+	), ledger AS (
+		SELECT product_id, store_id, SUM(quantity_delta) AS delta, COUNT(*) AS entries
+		FROM stock_movements
+		WHERE product_id %[1]s
+		GROUP BY product_id, store_id
+	), pairs AS (
+		SELECT product_id, store_id FROM store_stocks WHERE product_id %[1]s
+		UNION
+		SELECT product_id, store_id FROM ledger
+		UNION
+		SELECT o.product_id, s.id FROM opening o JOIN stores s ON s.is_default
+	), figures AS (
+		SELECT pr.product_id, pr.store_id, st.name AS store_name, COALESCE(ss.stock, 0) AS stock,
+		       COALESCE(l.delta, 0) + CASE WHEN st.is_default THEN COALESCE(o.opening, 0) ELSE 0 END AS ledger_stock,
+		       COALESCE(l.entries, 0) AS entries
+		FROM pairs pr
+		JOIN stores st ON st.id = pr.store_id
+		LEFT JOIN store_stocks ss ON ss.product_id = pr.product_id AND ss.store_id = pr.store_id
+		LEFT JOIN ledger l ON l.product_id = pr.product_id AND l.store_id = pr.store_id
+		LEFT JOIN opening o ON o.product_id = pr.product_id
+	), totals AS (
+		SELECT product_id, SUM(ledger_stock)::bigint AS ledger_stock, SUM(entries)::bigint AS entries,
+		       bool_or(stock <> ledger_stock) AS store_drift
+		FROM figures
+		GROUP BY product_id
+	)
+	SELECT p.id, p.name, COALESCE(p.sku, ''), p.stock, COALESCE(tl.ledger_stock, 0), COALESCE(tl.entries, 0),
+	       f.store_id, COALESCE(f.store_name, ''), COALESCE(f.stock, 0), COALESCE(f.ledger_stock, 0)
+	FROM products p
+	LEFT JOIN totals tl ON tl.product_id = p.id
+	LEFT JOIN figures f ON f.product_id = p.id AND f.stock <> f.ledger_stock
+	WHERE p.id %[1]s AND %[2]s
+	ORDER BY p.id, f.store_id
+`
+
+// stockDrifted selects the products of stockDriftQuery whose stock drifted
+const stockDrifted = `(p.stock <> COALESCE(tl.ledger_stock, 0) OR COALESCE(tl.store_drift, FALSE))`
+
+// queryStockDrifts runs stockDriftQuery and groups its rows by product
+func (r *consistencyRepository) queryStockDrifts(ctx context.Context, query string, args ...interface{}) ([]models.StockDrift, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drifts := make([]models.StockDrift, 0)
+	for rows.Next() {
+		var d models.StockDrift
+		var storeID sql.NullInt64
+		var store models.StoreStockDrift
+		err := rows.Scan(
+			&d.ProductID, &d.ProductName, &d.SKU, &d.Stock, &d.LedgerStock, &d.LedgerEntries,
+			&storeID, &store.StoreName, &store.Stock, &store.LedgerStock,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(drifts); n == 0 || drifts[n-1].ProductID != d.ProductID {
+			d.Drift = d.Stock - d.LedgerStock
+			d.Stores = make([]models.StoreStockDrift, 0)
+			drifts = append(drifts, d)
+		}
+		if storeID.Valid {
+			store.StoreID = int(storeID.Int64)
+			store.Drift = store.Stock - store.LedgerStock
+			last := &drifts[len(drifts)-1]
+			last.Stores = append(last.Stores, store)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+// CheckStock compares every product's stock with the ledger and returns how
+// many products were checked and those that drifted, in ID order
+func (r *consistencyRepository) CheckStock(ctx context.Context) (int, []models.StockDrift, error) {
+	var checked int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM products`).Scan(&checked); err != nil {
+		return 0, nil, err
+	}
+
+	drifts, err := r.queryStockDrifts(ctx, fmt.Sprintf(stockDriftQuery, "IS NOT NULL", stockDrifted))
+	if err != nil {
+		return 0, nil, err
+	}
+	return checked, drifts, nil
+}
+
+// CheckProductStock compares one product's stock with the ledger, or returns
+// nil if the product does not exist. Inside a DB transaction the product row
+// is locked until the end of it, which holds off every stock change of the
+// product, so the figures hold for a following repair.
+func (r *consistencyRepository) CheckProductStock(ctx context.Context, id int) (*models.StockDrift, error) {
+	var locked int
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM products WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	drifts, err := r.queryStockDrifts(ctx, fmt.Sprintf(stockDriftQuery, "= $1", "TRUE"), id)
+	if err != nil || len(drifts) == 0 {
+		return nil, err
+	}
+	return &drifts[0], nil
+}
+
+// SetProductStock sets the stock of the drifted stores of a product and its
+// total to their ledger figures. No ledger entries are written: the ledger
+// is what the stock is rebuilt from.
+func (r *consistencyRepository) SetProductStock(ctx context.Context, drift models.StockDrift) error {
+	if len(drift.Stores) > 0 {
+		args := []interface{}{drift.ProductID}
+		values := make([]string, 0, len(drift.Stores))
+		for _, s := range drift.Stores {
+			values = append(values, fmt.Sprintf("($%d::int, $1::int, $%d::int)", len(args)+1, len(args)+2))
+			args = append(args, s.StoreID, s.LedgerStock)
+		}
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO store_stocks (store_id, product_id, stock)
+			VALUES `+strings.Join(values, ", ")+`
+			ON CONFLICT (store_id, product_id) DO UPDATE SET stock = EXCLUDED.stock, updated_at = NOW()
+		`, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := r.db.ExecContext(ctx, `UPDATE products SET stock = $2, updated_at = NOW() WHERE id = $1`, drift.ProductID, drift.LedgerStock)
+	return err
+}
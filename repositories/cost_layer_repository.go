@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// CostLayerRepository defines the interface for reading FIFO cost layers.
+// Layers are written and consumed by the repositories that receive and sell
+// stock, inside the same database transaction as the stock change.
+type CostLayerRepository interface {
+	GetValuationProducts(ctx context.Context, categoryID *int) ([]models.ProductValuation, error)
+	GetOpenLayers(ctx context.Context, categoryID *int) ([]models.CostLayer, error)
+}
+
+// costLayerRepository implements CostLayerRepository interface with PostgreSQL
+type costLayerRepository struct {
+	db DBTX
+}
+
+// NewCostLayerRepository creates a new cost layer repository instance
+func NewCostLayerRepository(db DBTX) CostLayerRepository {
+	return &costLayerRepository{db: db}
+}
+
+// addCostLayer opens a cost layer for stock received at unitCost. Consigned
+// products are skipped: the store does not own that stock.
+func addCostLayer(ctx context.Context, e execer, productID, quantity, unitCost int, sourceType string, sourceID int) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost)
+		SELECT id, $1, $2, $3, $3, $4 FROM products WHERE id = $5 AND is_consignment = false
+	`, sourceType, sourceID, quantity, unitCost, productID)
+	return err
+}
+
+// openCostLayer layers the stock of a product that its open cost layers do
+// not cover at the product's cost_price, so sales of stock that was not
+// received through a purchase order are costed too. Consigned products and
+// products without a cost price are skipped.
+func openCostLayer(ctx context.Context, e execer, productID int, sourceType string, sourceID int) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost)
+		SELECT p.id, $1, $2, p.stock - c.covered, p.stock - c.covered, p.cost_price
+		FROM products p
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(SUM(remaining), 0) AS covered FROM cost_layers WHERE product_id = p.id
+		) c
+		WHERE p.id = $3 AND p.is_consignment = false AND p.cost_price > 0 AND p.stock > c.covered
+	`, sourceType, sourceID, productID)
+	return err
+}
+
+// productCosting is what costing a product's sold lines needs: whether it is
+// consigned, the unit cost of units beyond its layers, and its open layers
+// oldest first
+type productCosting struct {
+	consigned bool
+	fallback  int
+	layers    []models.CostLayer
+}
+
+// consumeCostLayers costs the lines of a sale by taking each line's quantity
+// from its product's oldest open layers, in line order, and records what was
+// taken so a void can put it back. Units sold beyond the layered quantity are
+// costed at the product's cost_price, else at its last received unit cost, or
+// zero. Consigned products carry no cost of goods sold. The layers of all
+// lines are read and locked, drawn down, and the consumptions and line costs
+// written with one statement each, however many lines the sale has. It sets
+// the CostAmount of every line.
+func consumeCostLayers(ctx context.Context, tx DBTX, details []models.TransactionDetail) error {
+	productIDs := make([]int, 0, len(details))
+	costings := make(map[int]*productCosting, len(details))
+	for _, d := range details {
+		if _, ok := costings[d.ProductID]; !ok {
+			productIDs = append(productIDs, d.ProductID)
+			costings[d.ProductID] = nil
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT p.id, p.is_consignment,
+		       COALESCE(NULLIF(p.cost_price, 0), (SELECT l.unit_cost FROM cost_layers l WHERE l.product_id = p.id ORDER BY l.id DESC LIMIT 1), 0)
+		FROM products p WHERE p.id = ANY($1)
+	`, productIDs)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int
+		c := &productCosting{}
+		if err := rows.Scan(&id, &c.consigned, &c.fallback); err != nil {
+			rows.Close()
+			return err
+		}
+		costings[id] = c
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT id, product_id, remaining, unit_cost FROM cost_layers
+		WHERE product_id = ANY($1) AND remaining > 0
+		ORDER BY product_id, id
+		FOR UPDATE
+	`, productIDs)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var l models.CostLayer
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.Remaining, &l.UnitCost); err != nil {
+			rows.Close()
+			return err
+		}
+		if c := costings[l.ProductID]; c != nil {
+			c.layers = append(c.layers, l)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// Draw the lines from the layers in memory; lines of the same product
+	// take from the layers the earlier ones left
+	taken := make(map[int]int)
+	var layerIDs []int
+	var consumptionArgs, costArgs []interface{}
+	for i, d := range details {
+		cost := 0
+		c := costings[d.ProductID]
+		if c != nil && !c.consigned {
+			left := d.Quantity
+			for j := range c.layers {
+				l := &c.layers[j]
+				if left == 0 {
+					break
+				}
+				if l.Remaining == 0 {
+					continue
+				}
+				take := min(left, l.Remaining)
+				l.Remaining -= take
+				if _, ok := taken[l.ID]; !ok {
+					layerIDs = append(layerIDs, l.ID)
+				}
+				taken[l.ID] += take
+				consumptionArgs = append(consumptionArgs, d.ID, l.ID, take, l.UnitCost)
+				cost += take * l.UnitCost
+				left -= take
+			}
+			if left > 0 {
+				consumptionArgs = append(consumptionArgs, d.ID, nil, left, c.fallback)
+				cost += left * c.fallback
+			}
+		}
+		details[i].CostAmount = cost
+		costArgs = append(costArgs, d.ID, cost)
+	}
+
+	if len(layerIDs) > 0 {
+		takenArgs := make([]interface{}, 0, len(layerIDs)*2)
+		for _, id := range layerIDs {
+			takenArgs = append(takenArgs, id, taken[id])
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE cost_layers l SET remaining = l.remaining - t.quantity
+			FROM (VALUES `+valuesList(len(layerIDs), 2, 0, "int")+`) AS t (id, quantity)
+			WHERE l.id = t.id
+		`, takenArgs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(consumptionArgs) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO cost_layer_consumptions (transaction_detail_id, cost_layer_id, quantity, unit_cost)
+			VALUES `+valuesList(len(consumptionArgs)/4, 4, 0, "int"), consumptionArgs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE transaction_details td SET cost_amount = c.cost
+		FROM (VALUES `+valuesList(len(details), 2, 0, "int")+`) AS c (id, cost)
+		WHERE td.id = c.id
+	`, costArgs...)
+	return err
+}
+
+// restoreCostLayers returns the quantities a transaction consumed to the
+// layers they came from, so voided sales are available to cost later sales
+func restoreCostLayers(ctx context.Context, e execer, transactionID int) error {
+	_, err := e.ExecContext(ctx, `
+		UPDATE cost_layers l
+		SET remaining = l.remaining + c.quantity
+		FROM (
+			SELECT clc.cost_layer_id, SUM(clc.quantity) AS quantity
+			FROM cost_layer_consumptions clc
+			JOIN transaction_details td ON td.id = clc.transaction_detail_id
+			WHERE td.transaction_id = $1 AND clc.cost_layer_id IS NOT NULL
+			GROUP BY clc.cost_layer_id
+		) c
+		WHERE l.id = c.cost_layer_id
+	`, transactionID)
+	return err
+}
+
+// GetValuationProducts returns every active product the store owns with
+// stock on hand and the unit cost of its uncovered stock: the cost price,
+// else the unit cost of its most recent layer
+func (r *costLayerRepository) GetValuationProducts(ctx context.Context, categoryID *int) ([]models.ProductValuation, error) {
+	where := "WHERE p.is_active = true AND p.is_consignment = false AND p.stock > 0"
+	args := []interface{}{}
+	if categoryID != nil {
+		where += " AND p.category_id = $1"
+		args = append(args, *categoryID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT p.id, p.name, COALESCE(p.sku, ''), p.category_id, COALESCE(c.name, ''), p.stock,
+		       COALESCE(NULLIF(p.cost_price, 0), (SELECT l.unit_cost FROM cost_layers l WHERE l.product_id = p.id ORDER BY l.id DESC LIMIT 1), 0)
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		%s
+		ORDER BY p.name, p.id
+	`, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.ProductValuation, 0)
+	for rows.Next() {
+		var p models.ProductValuation
+		err := rows.Scan(&p.ProductID, &p.Name, &p.SKU, &p.CategoryID, &p.CategoryName, &p.Stock, &p.LastCost)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetOpenLayers returns the cost layers with quantity remaining, per product
+// newest first
+func (r *costLayerRepository) GetOpenLayers(ctx context.Context, categoryID *int) ([]models.CostLayer, error) {
+	where := "WHERE l.remaining > 0"
+	args := []interface{}{}
+	if categoryID != nil {
+		where += " AND p.category_id = $1"
+		args = append(args, *categoryID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT l.id, l.product_id, l.source_type, l.source_id, l.quantity, l.remaining, l.unit_cost, l.created_at
+		FROM cost_layers l
+		JOIN products p ON p.id = l.product_id
+		%s
+		ORDER BY l.product_id, l.id DESC
+	`, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	layers := make([]models.CostLayer, 0)
+	for rows.Next() {
+		var l models.CostLayer
+		err := rows.Scan(&l.ID, &l.ProductID, &l.SourceType, &l.SourceID, &l.Quantity, &l.Remaining, &l.UnitCost, &l.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return layers, nil
+}
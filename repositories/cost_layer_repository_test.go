@@ -0,0 +1,134 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/database/dbtest"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+)
+
+// stockAtStore sets the stock of a product at a store
+func stockAtStore(t *testing.T, db *sql.DB, storeID, productID, stock int) {
+	t.Helper()
+	_, err := db.Exec(`
+		INSERT INTO store_stocks (store_id, product_id, stock) VALUES ($1, $2, $3)
+		ON CONFLICT (store_id, product_id) DO UPDATE SET stock = EXCLUDED.stock
+	`, storeID, productID, stock)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sell checks out quantity of a product and returns the cost of the line
+func sell(t *testing.T, db *sql.DB, storeID, productID, quantity int) int {
+	t.Helper()
+	details := []models.TransactionDetail{{ProductID: productID, Quantity: quantity, UnitPrice: 4000, Subtotal: 4000 * quantity}}
+	req := models.CheckoutRequest{StoreID: storeID, PaymentMethod: "cash"}
+	transaction, err := repositories.NewTransactionRepository(db).CreateTransaction(context.Background(), req, details)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transaction.Details[0].CostAmount
+}
+
+// openLayers returns the quantity and value of a product's open cost layers
+func openLayers(t *testing.T, db *sql.DB, productID int) (remaining, value int) {
+	t.Helper()
+	err := db.QueryRow(`SELECT COALESCE(SUM(remaining), 0), COALESCE(SUM(remaining * unit_cost), 0) FROM cost_layers WHERE product_id = $1`,
+		productID).Scan(&remaining, &value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remaining, value
+}
+
+func TestCostPriceCostsStockWithoutReceipts(t *testing.T) {
+	ctx := context.Background()
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+	storeID := createStore(t, db)
+	products := repositories.NewProductRepository(db)
+
+	t.Run("opening stock is layered at the cost price", func(t *testing.T) {
+		tea, err := products.Create(ctx, models.Product{Name: "Tea", Price: 4000, Stock: 10, CostPrice: 2500, IsActive: true}, models.Actor{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stockAtStore(t, db, storeID, tea.ID, 10)
+		if remaining, value := openLayers(t, db, tea.ID); remaining != 10 || value != 25000 {
+			t.Fatalf("open layers hold %d units worth %d, want 10 worth 25000", remaining, value)
+		}
+		if cost := sell(t, db, storeID, tea.ID, 3); cost != 7500 {
+			t.Fatalf("3 teas cost %d, want 7500", cost)
+		}
+	})
+
+	t.Run("setting a cost price layers stock without a cost", func(t *testing.T) {
+		rice, err := products.Create(ctx, models.Product{Name: "Rice", Price: 12000, Stock: 5, IsActive: true}, models.Actor{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remaining, _ := openLayers(t, db, rice.ID); remaining != 0 {
+			t.Fatalf("stock without a cost price has %d layered units", remaining)
+		}
+		rice.CostPrice = 10000
+		if _, err := products.Update(ctx, rice.ID, *rice, models.Actor{}); err != nil {
+			t.Fatal(err)
+		}
+		if remaining, value := openLayers(t, db, rice.ID); remaining != 5 || value != 50000 {
+			t.Fatalf("open layers hold %d units worth %d, want 5 worth 50000", remaining, value)
+		}
+
+		// Updating it again does not layer the same stock twice
+		if _, err := products.Update(ctx, rice.ID, *rice, models.Actor{}); err != nil {
+			t.Fatal(err)
+		}
+		if remaining, _ := openLayers(t, db, rice.ID); remaining != 5 {
+			t.Fatalf("open layers hold %d units after a second update, want 5", remaining)
+		}
+	})
+
+	t.Run("stock without a layer is costed at the cost price", func(t *testing.T) {
+		var sugar int
+		err := db.QueryRow(`INSERT INTO products (name, slug, price, stock, cost_price) VALUES ('Sugar', 'sugar', 4000, 8, 1500) RETURNING id`).Scan(&sugar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stockAtStore(t, db, storeID, sugar, 8)
+		if cost := sell(t, db, storeID, sugar, 2); cost != 3000 {
+			t.Fatalf("2 sugars cost %d, want 3000", cost)
+		}
+	})
+
+	t.Run("a receipt sets the cost price", func(t *testing.T) {
+		coffee, err := products.Create(ctx, models.Product{Name: "Coffee", Price: 9000, CostPrice: 5000, IsActive: true}, models.Actor{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var supplierID int
+		if err := db.QueryRow(`INSERT INTO suppliers (name) VALUES ('Roaster') RETURNING id`).Scan(&supplierID); err != nil {
+			t.Fatal(err)
+		}
+		orders := repositories.NewPurchaseOrderRepository(db)
+		order, err := orders.Create(ctx, models.PurchaseOrder{SupplierID: supplierID},
+			[]models.PurchaseOrderItem{{ProductID: coffee.ID, QuantityOrdered: 4, UnitCost: 5600}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = orders.Receive(ctx, order.ID, models.GoodsReceipt{Lines: []models.GoodsReceiptLine{{ProductID: coffee.ID, Quantity: 4, UnitCost: 5600}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		received, err := products.GetByID(ctx, coffee.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received.CostPrice != 5600 {
+			t.Fatalf("cost price %d after a receipt at 5600", received.CostPrice)
+		}
+		if remaining, value := openLayers(t, db, coffee.ID); remaining != 4 || value != 4*5600 {
+			t.Fatalf("open layers hold %d units worth %d, want the 4 received at 5600", remaining, value)
+		}
+	})
+}
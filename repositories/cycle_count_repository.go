@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// CycleCountRepository defines the interface for cycle count schedule data access
+type CycleCountRepository interface {
+	GetAll(ctx context.Context) ([]models.CycleCountSchedule, error)
+	GetByID(ctx context.Context, id int) (*models.CycleCountSchedule, error)
+	Create(ctx context.Context, schedule models.CycleCountSchedule) (*models.CycleCountSchedule, error)
+	Update(ctx context.Context, id int, schedule models.CycleCountSchedule) (*models.CycleCountSchedule, error)
+	Delete(ctx context.Context, id int) error
+	GetDue(ctx context.Context) ([]models.CycleCountSchedule, error)
+	Advance(ctx context.Context, id int, nextRunAt time.Time) (bool, error)
+	GetCompliance(ctx context.Context, startDate, endDate string) ([]models.CycleCountCompliance, error)
+}
+
+// cycleCountRepository implements CycleCountRepository interface with PostgreSQL
+type cycleCountRepository struct {
+	db DBTX
+}
+
+// NewCycleCountRepository creates a new cycle count repository instance
+func NewCycleCountRepository(db DBTX) CycleCountRepository {
+	return &cycleCountRepository{db: db}
+}
+
+// cycleCountColumns is the standard set of columns selected for schedule queries
+const cycleCountColumns = `
+	id, abc_class, frequency, assigned_to,
+	COALESCE((SELECT u.name FROM users u WHERE u.id = assigned_to), ''),
+	is_active, next_run_at, last_run_at, created_at, updated_at
+`
+
+// scanCycleCountSchedule scans a row into a CycleCountSchedule struct
+func scanCycleCountSchedule(scanner interface{ Scan(dest ...interface{}) error }) (*models.CycleCountSchedule, error) {
+	var s models.CycleCountSchedule
+	err := scanner.Scan(
+		&s.ID, &s.ABCClass, &s.Frequency, &s.AssignedTo, &s.AssigneeName,
+		&s.IsActive, &s.NextRunAt, &s.LastRunAt, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// querySchedules runs a schedule query and scans every row
+func (r *cycleCountRepository) querySchedules(ctx context.Context, query string, args ...interface{}) ([]models.CycleCountSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]models.CycleCountSchedule, 0)
+	for rows.Next() {
+		s, err := scanCycleCountSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// GetAll returns every cycle count schedule ordered by class
+func (r *cycleCountRepository) GetAll(ctx context.Context) ([]models.CycleCountSchedule, error) {
+	return r.querySchedules(ctx, `SELECT `+cycleCountColumns+` FROM cycle_count_schedules ORDER BY abc_class, id`)
+}
+
+// GetByID returns a cycle count schedule by its ID
+func (r *cycleCountRepository) GetByID(ctx context.Context, id int) (*models.CycleCountSchedule, error) {
+	s, err := scanCycleCountSchedule(r.db.QueryRowContext(ctx,
+		`SELECT `+cycleCountColumns+` FROM cycle_count_schedules WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create inserts a new cycle count schedule
+func (r *cycleCountRepository) Create(ctx context.Context, schedule models.CycleCountSchedule) (*models.CycleCountSchedule, error) {
+	return scanCycleCountSchedule(r.db.QueryRowContext(ctx, `
+		INSERT INTO cycle_count_schedules (abc_class, frequency, assigned_to, is_active, next_run_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+cycleCountColumns,
+		schedule.ABCClass, schedule.Frequency, schedule.AssignedTo, schedule.IsActive, schedule.NextRunAt,
+	))
+}
+
+// Update modifies an existing cycle count schedule
+func (r *cycleCountRepository) Update(ctx context.Context, id int, schedule models.CycleCountSchedule) (*models.CycleCountSchedule, error) {
+	s, err := scanCycleCountSchedule(r.db.QueryRowContext(ctx, `
+		UPDATE cycle_count_schedules
+		SET abc_class = $1, frequency = $2, assigned_to = $3, is_active = $4, next_run_at = $5, updated_at = $6
+		WHERE id = $7
+		RETURNING `+cycleCountColumns,
+		schedule.ABCClass, schedule.Frequency, schedule.AssignedTo, schedule.IsActive, schedule.NextRunAt,
+		time.Now(), id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Delete removes a cycle count schedule; sessions it opened are kept
+func (r *cycleCountRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM cycle_count_schedules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetDue returns active schedules whose next run time has passed
+func (r *cycleCountRepository) GetDue(ctx context.Context) ([]models.CycleCountSchedule, error) {
+	return r.querySchedules(ctx, `
+		SELECT ` + cycleCountColumns + ` FROM cycle_count_schedules
+		WHERE is_active = true AND next_run_at <= NOW()
+		ORDER BY next_run_at, id
+	`)
+}
+
+// Advance moves a due schedule's next run forward. It returns false when the
+// schedule is no longer due (e.g. another instance advanced it first), so
+// each run opens exactly one session.
+func (r *cycleCountRepository) Advance(ctx context.Context, id int, nextRunAt time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE cycle_count_schedules
+		SET next_run_at = $1, last_run_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND is_active = true AND next_run_at <= NOW()
+	`, nextRunAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetCompliance counts each schedule's sessions opened in a date range by outcome
+func (r *cycleCountRepository) GetCompliance(ctx context.Context, startDate, endDate string) ([]models.CycleCountCompliance, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT sc.id, sc.abc_class, sc.frequency, sc.assigned_to,
+		       COUNT(s.id),
+		       COUNT(s.id) FILTER (WHERE s.status = 'completed' AND (s.due_at IS NULL OR s.completed_at <= s.due_at)),
+		       COUNT(s.id) FILTER (WHERE s.status = 'completed' AND s.completed_at > s.due_at),
+		       COUNT(s.id) FILTER (WHERE s.status = 'open' AND s.due_at < NOW()),
+		       COUNT(s.id) FILTER (WHERE s.status = 'open' AND (s.due_at IS NULL OR s.due_at >= NOW())),
+		       COUNT(s.id) FILTER (WHERE s.status = 'cancelled')
+		FROM cycle_count_schedules sc
+		LEFT JOIN count_sessions s ON s.schedule_id = sc.id
+		     AND s.created_at::date >= $1::date AND s.created_at::date <= $2::date
+		GROUP BY sc.id
+		ORDER BY sc.abc_class, sc.id
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := make([]models.CycleCountCompliance, 0)
+	for rows.Next() {
+		var c models.CycleCountCompliance
+		err := rows.Scan(
+			&c.ScheduleID, &c.ABCClass, &c.Frequency, &c.AssignedTo, &c.Sessions,
+			&c.CompletedOnTime, &c.CompletedLate, &c.Overdue, &c.InProgress, &c.Cancelled,
+		)
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
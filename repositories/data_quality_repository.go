@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"time"
+)
+
+// DataQualityRepository defines the interface for catalog hygiene queries
+type DataQualityRepository interface {
+	CountIssues(ctx context.Context, staleBefore time.Time) (total int, counts map[string]int, err error)
+	GetIssues(ctx context.Context, params models.DataQualityParams, staleBefore time.Time) (*models.PaginatedDataQualityIssues, error)
+}
+
+// dataQualityRepository implements DataQualityRepository interface with PostgreSQL
+type dataQualityRepository struct {
+	db DBTX
+}
+
+// NewDataQualityRepository creates a new data quality repository instance
+func NewDataQualityRepository(db DBTX) DataQualityRepository {
+	return &dataQualityRepository{db: db}
+}
+
+// dataQualityConditions is the WHERE condition of every check. $1 is the
+// stale cutoff; conditions may use the dup and sold joins of dataQualityFrom.
+var dataQualityConditions = map[string]string{
+	models.DataQualityMissingBarcode: `COALESCE(TRIM(p.sku), '') = ''`,
+	models.DataQualityZeroPrice:      `p.price = 0`,
+	models.DataQualityUncategorized:  `p.category_id IS NULL`,
+	models.DataQualityDuplicateName:  `dup.n IS NOT NULL`,
+	models.DataQualityStale:          `p.is_active AND p.updated_at < $1 AND (sold.last_sold_at IS NULL OR sold.last_sold_at < $1)`,
+}
+
+// dataQualityFrom joins products with their category, the number of products
+// sharing their name and their last active sale
+const dataQualityFrom = `
+	FROM products p
+	LEFT JOIN categories c ON c.id = p.category_id
+	LEFT JOIN (
+		SELECT LOWER(TRIM(name)) AS name_key, COUNT(*) AS n
+		FROM products
+		GROUP BY 1
+		HAVING COUNT(*) > 1
+	) dup ON dup.name_key = LOWER(TRIM(p.name))
+	LEFT JOIN LATERAL (
+		SELECT MAX(t.created_at) AS last_sold_at
+		FROM transaction_details d
+		JOIN transactions t ON t.id = d.transaction_id
+		WHERE d.product_id = p.id AND t.status = 'active'
+	) sold ON true
+`
+
+// CountIssues returns the number of products and how many fail each check
+func (r *dataQualityRepository) CountIssues(ctx context.Context, staleBefore time.Time) (int, map[string]int, error) {
+	checks := []string{
+		models.DataQualityMissingBarcode, models.DataQualityZeroPrice, models.DataQualityUncategorized,
+		models.DataQualityDuplicateName, models.DataQualityStale,
+	}
+	columns := "COUNT(*)"
+	for _, check := range checks {
+		columns += fmt.Sprintf(", COUNT(*) FILTER (WHERE %s)", dataQualityConditions[check])
+	}
+
+	values := make([]int, len(checks)+1)
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.db.QueryRowContext(ctx, `SELECT `+columns+dataQualityFrom, staleBefore).Scan(dest...); err != nil {
+		return 0, nil, err
+	}
+
+	counts := make(map[string]int, len(checks))
+	for i, check := range checks {
+		counts[check] = values[i+1]
+	}
+	return values[0], counts, nil
+}
+
+// GetIssues returns a page of the products failing a check. Duplicate names
+// are grouped together and stale products are listed longest idle first.
+func (r *dataQualityRepository) GetIssues(ctx context.Context, params models.DataQualityParams, staleBefore time.Time) (*models.PaginatedDataQualityIssues, error) {
+	condition, ok := dataQualityConditions[params.Check]
+	if !ok {
+		return nil, fmt.Errorf("unknown data quality check %q", params.Check)
+	}
+	// Every query passes the cutoff, so reference it in checks that do not use it
+	where := fmt.Sprintf("WHERE (%s) AND $1::timestamp IS NOT NULL", condition)
+
+	order := "p.id"
+	switch params.Check {
+	case models.DataQualityDuplicateName:
+		order = "LOWER(TRIM(p.name)), p.id"
+	case models.DataQualityStale:
+		order = "COALESCE(sold.last_sold_at, p.updated_at), p.id"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+dataQualityFrom+where, staleBefore).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT p.id, p.name, COALESCE(p.sku, ''), p.price, p.category_id, COALESCE(c.name, ''),
+		       COALESCE(p.is_active, true), p.updated_at, sold.last_sold_at, COALESCE(dup.n, 0)
+		%s
+		%s
+		ORDER BY %s
+		LIMIT $2 OFFSET $3
+	`, dataQualityFrom, where, order), staleBefore, params.Limit, (params.Page-1)*params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	issues := make([]models.DataQualityIssue, 0)
+	for rows.Next() {
+		var issue models.DataQualityIssue
+		err := rows.Scan(
+			&issue.ProductID, &issue.Name, &issue.SKU, &issue.Price, &issue.CategoryID, &issue.CategoryName,
+			&issue.IsActive, &issue.UpdatedAt, &issue.LastSoldAt, &issue.DuplicateCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedDataQualityIssues{
+		Data:       issues,
+		Total:      total,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalPages: helpers.CalcTotalPages(total, params.Limit),
+	}, nil
+}
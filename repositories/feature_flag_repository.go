@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+)
+
+// FeatureFlagRepository defines the interface for the feature flag settings
+// of a tenant
+type FeatureFlagRepository interface {
+	GetAll(ctx context.Context) ([]models.FeatureFlagSetting, error)
+	Set(ctx context.Context, setting models.FeatureFlagSetting) (*models.FeatureFlagSetting, error)
+	Delete(ctx context.Context, name string, storeID *int) error
+}
+
+// featureFlagRepository implements FeatureFlagRepository interface with PostgreSQL
+type featureFlagRepository struct {
+	db DBTX
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository instance
+func NewFeatureFlagRepository(db DBTX) FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+// featureFlagColumns is the standard set of columns selected for feature flag queries
+const featureFlagColumns = `name, store_id, enabled, updated_at`
+
+// scanFeatureFlagSetting scans a row into a FeatureFlagSetting struct
+func scanFeatureFlagSetting(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.FeatureFlagSetting, error) {
+	var s models.FeatureFlagSetting
+	if err := scanner.Scan(&s.Name, &s.StoreID, &s.Enabled, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// queryFeatureFlagSettings runs a query selecting featureFlagColumns
+func (r *featureFlagRepository) queryFeatureFlagSettings(ctx context.Context, query string, args ...interface{}) ([]models.FeatureFlagSetting, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := make([]models.FeatureFlagSetting, 0)
+	for rows.Next() {
+		s, err := scanFeatureFlagSetting(rows)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, *s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// GetAll returns every setting by flag name, the tenant's setting of a flag
+// before its store overrides
+func (r *featureFlagRepository) GetAll(ctx context.Context) ([]models.FeatureFlagSetting, error) {
+	return r.queryFeatureFlagSettings(ctx, `
+		SELECT `+featureFlagColumns+`
+		FROM feature_flags
+		ORDER BY name, store_id NULLS FIRST
+	`)
+}
+
+// Set creates or replaces the tenant's setting of a flag, or a store's
+// override when StoreID is set
+func (r *featureFlagRepository) Set(ctx context.Context, setting models.FeatureFlagSetting) (*models.FeatureFlagSetting, error) {
+	return scanFeatureFlagSetting(r.db.QueryRowContext(ctx, `
+		INSERT INTO feature_flags (name, store_id, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, name, COALESCE(store_id, 0))
+		DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = CURRENT_TIMESTAMP
+		RETURNING `+featureFlagColumns,
+		setting.Name, setting.StoreID, setting.Enabled,
+	))
+}
+
+// Delete removes the tenant's setting of a flag, or a store's override when
+// storeID is set. It returns sql.ErrNoRows when there is none.
+func (r *featureFlagRepository) Delete(ctx context.Context, name string, storeID *int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM feature_flags
+		WHERE name = $1 AND store_id IS NOT DISTINCT FROM $2
+	`, name, storeID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// IdempotencyRepository defines the interface for idempotency key storage
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, record models.IdempotencyRecord, expiredBefore time.Time) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, userID int, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, userID int, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// idempotencyRepository implements IdempotencyRepository interface with PostgreSQL
+type idempotencyRepository struct {
+	db DBTX
+}
+
+// NewIdempotencyRepository creates a new idempotency repository instance
+func NewIdempotencyRepository(db DBTX) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve claims a key for a new request. It returns nil when the key was
+// free (or its previous use has expired) and the existing record otherwise.
+func (r *idempotencyRepository) Reserve(ctx context.Context, record models.IdempotencyRecord, expiredBefore time.Time) (*models.IdempotencyRecord, error) {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND created_at < $3
+	`, record.UserID, record.Key, expiredBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (user_id, idempotency_key, method, path, request_hash)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, idempotency_key) DO NOTHING
+	`, record.UserID, record.Key, record.Method, record.Path, record.RequestHash)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := result.RowsAffected(); n == 1 {
+		return nil, nil
+	}
+
+	var existing models.IdempotencyRecord
+	err = r.db.QueryRowContext(ctx, `
+		SELECT user_id, idempotency_key, method, path, request_hash, status_code,
+		       COALESCE(response_body, ''::bytea), created_at
+		FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2
+	`, record.UserID, record.Key).Scan(
+		&existing.UserID, &existing.Key, &existing.Method, &existing.Path, &existing.RequestHash,
+		&existing.StatusCode, &existing.ResponseBody, &existing.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		// Released between the insert and the lookup; let the caller retry
+		return r.Reserve(ctx, record, expiredBefore)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// Complete stores the response of a reserved request
+func (r *idempotencyRepository) Complete(ctx context.Context, userID int, key string, statusCode int, body []byte) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE idempotency_keys SET status_code = $1, response_body = $2
+		WHERE user_id = $3 AND idempotency_key = $4
+	`, statusCode, body, userID, key)
+	return err
+}
+
+// Release frees a reserved key so the request can be retried
+func (r *idempotencyRepository) Release(ctx context.Context, userID int, key string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2`, userID, key)
+	return err
+}
+
+// DeleteExpired removes keys created before the given time
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"slices"
+	"sort"
+	"time"
+)
+
+// memoryCategoryRepository implements CategoryRepository on a MemoryStore
+type memoryCategoryRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryCategoryRepository creates a category repository keeping its rows
+// in store
+func NewMemoryCategoryRepository(store *MemoryStore) CategoryRepository {
+	return &memoryCategoryRepository{store: store}
+}
+
+// GetAll returns all categories in ID order
+func (r *memoryCategoryRepository) GetAll(ctx context.Context) ([]models.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var categories []models.Category
+	for _, c := range r.store.categories {
+		categories = append(categories, c)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+	return categories, nil
+}
+
+// GetByID returns a category by its ID, nil when there is none
+func (r *memoryCategoryRepository) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	c, ok := r.store.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+// GetBySlug returns a category by its slug, nil when there is none
+func (r *memoryCategoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, c := range r.store.categories {
+		if c.Slug == slug {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create adds a new category and returns it
+func (r *memoryCategoryRepository) Create(ctx context.Context, category models.Category) (*models.Category, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.lastCategoryID++
+	category.ID = r.store.lastCategoryID
+	category.Slug = r.slug(category.Name, 0)
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+	r.store.categories[category.ID] = category
+	return &category, nil
+}
+
+// Update modifies an existing category, returning nil when there is none
+func (r *memoryCategoryRepository) Update(ctx context.Context, id int, category models.Category) (*models.Category, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	old, ok := r.store.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	category.ID = id
+	category.Slug = r.slug(category.Name, id)
+	category.CreatedAt = old.CreatedAt
+	category.UpdatedAt = time.Now()
+	r.store.categories[id] = category
+	return &category, nil
+}
+
+// Delete removes a category by its ID. Its subcategories become roots and
+// its products uncategorized, as with the foreign keys of the SQL schema.
+func (r *memoryCategoryRepository) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.categories[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.store.categories, id)
+	for cid, c := range r.store.categories {
+		if c.ParentID != nil && *c.ParentID == id {
+			c.ParentID = nil
+			r.store.categories[cid] = c
+		}
+	}
+	for pid, p := range r.store.products {
+		if p.CategoryID != nil && *p.CategoryID == id {
+			p.CategoryID = nil
+			r.store.products[pid] = p
+		}
+	}
+	return nil
+}
+
+// GetDescendantIDs returns the ID of a category followed by the IDs of all
+// its subcategories at any depth, level by level
+func (r *memoryCategoryRepository) GetDescendantIDs(ctx context.Context, id int) ([]int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := make([]int, 0)
+	if _, ok := r.store.categories[id]; !ok {
+		return ids, nil
+	}
+	seen := map[int]bool{id: true}
+	level := []int{id}
+	for len(level) > 0 {
+		ids = append(ids, level...)
+		var next []int
+		for cid, c := range r.store.categories {
+			if c.ParentID != nil && !seen[cid] && slices.Contains(level, *c.ParentID) {
+				seen[cid] = true
+				next = append(next, cid)
+			}
+		}
+		sort.Ints(next)
+		level = next
+	}
+	return ids, nil
+}
+
+// slug returns a slug for name unused by any category but excludeID. Callers
+// hold the lock.
+func (r *memoryCategoryRepository) slug(name string, excludeID int) string {
+	return memorySlug(name, func(slug string) bool {
+		for id, c := range r.store.categories {
+			if id != excludeID && c.Slug == slug {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,291 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"retail-core-api/models"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// memoryProductRepository implements ProductRepository on a MemoryStore
+type memoryProductRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryProductRepository creates a product repository keeping its rows in
+// store
+func NewMemoryProductRepository(store *MemoryStore) ProductRepository {
+	return &memoryProductRepository{store: store}
+}
+
+// GetAll returns paginated products, newest first, with optional search and
+// category filter
+func (r *memoryProductRepository) GetAll(ctx context.Context, params models.ProductListParams) (*models.PaginatedProducts, error) {
+	// Defaults
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	r.store.mu.RLock()
+	search := strings.ToLower(params.Search)
+	products := r.store.sortedProducts(func(p models.Product) bool {
+		if search != "" && !strings.Contains(strings.ToLower(p.Name), search) {
+			return false
+		}
+		return inCategory(p, params.CategoryID)
+	})
+	r.store.mu.RUnlock()
+	slices.Reverse(products)
+
+	total := len(products)
+	start := min((params.Page-1)*params.Limit, total)
+	end := min(start+params.Limit, total)
+	var page []models.Product
+	if start < end {
+		page = products[start:end]
+	}
+
+	return &models.PaginatedProducts{
+		Data:       page,
+		Total:      total,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(params.Limit))),
+	}, nil
+}
+
+// EachListing calls fn with every product in ID order. It stops at the first
+// error fn returns.
+func (r *memoryProductRepository) EachListing(ctx context.Context, fn func(models.Product) error) error {
+	r.store.mu.RLock()
+	products := r.store.sortedProducts(func(models.Product) bool { return true })
+	r.store.mu.RUnlock()
+
+	for _, p := range products {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID returns a product by its ID with category name, nil when there is
+// none
+func (r *memoryProductRepository) GetByID(ctx context.Context, id int) (*models.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	p, ok := r.store.products[id]
+	if !ok {
+		return nil, nil
+	}
+	p.CategoryName = r.store.categoryName(p.CategoryID)
+	return &p, nil
+}
+
+// GetByIDs returns the products with any of the given IDs in ID order; IDs
+// without a product are left out
+func (r *memoryProductRepository) GetByIDs(ctx context.Context, ids []int) ([]models.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedProducts(func(p models.Product) bool {
+		return slices.Contains(ids, p.ID)
+	}), nil
+}
+
+// GetBySlug returns a product by its slug with category name, nil when there
+// is none
+func (r *memoryProductRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	products := r.store.sortedProducts(func(p models.Product) bool { return p.Slug == slug })
+	if len(products) == 0 {
+		return nil, nil
+	}
+	return &products[0], nil
+}
+
+// GetBySKU returns the products with a SKU in ID order
+func (r *memoryProductRepository) GetBySKU(ctx context.Context, sku string) ([]models.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	products := r.store.sortedProducts(func(p models.Product) bool { return p.SKU == sku })
+	if products == nil {
+		products = make([]models.Product, 0)
+	}
+	return products, nil
+}
+
+// GetByCategoryID returns all products belonging to a specific category
+func (r *memoryProductRepository) GetByCategoryID(ctx context.Context, categoryID int) ([]models.Product, error) {
+	return r.GetByCategoryIDs(ctx, []int{categoryID})
+}
+
+// GetByCategoryIDs returns all products belonging to any of the given categories
+func (r *memoryProductRepository) GetByCategoryIDs(ctx context.Context, categoryIDs []int) ([]models.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedProducts(func(p models.Product) bool {
+		return p.CategoryID != nil && slices.Contains(categoryIDs, *p.CategoryID)
+	}), nil
+}
+
+// Create adds a new product and returns it. The actor is not used: there is
+// no price history in memory.
+func (r *memoryProductRepository) Create(ctx context.Context, product models.Product, actor models.Actor) (*models.Product, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.lastProductID++
+	product.ID = r.store.lastProductID
+	product.Slug = r.slug(product.Name, 0)
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = product.CreatedAt
+	product.CategoryName = ""
+	product.MarginPercent = nil
+	product.Relations = nil
+	r.store.products[product.ID] = product
+
+	product.CategoryName = r.store.categoryName(product.CategoryID)
+	return &product, nil
+}
+
+// Update modifies an existing product, returning nil when there is none
+func (r *memoryProductRepository) Update(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.Product, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	old, ok := r.store.products[id]
+	if !ok {
+		return nil, nil
+	}
+	product.ID = id
+	product.Slug = r.slug(product.Name, id)
+	product.CreatedAt = old.CreatedAt
+	product.UpdatedAt = time.Now()
+	product.CategoryName = ""
+	product.MarginPercent = nil
+	product.Relations = nil
+	r.store.products[id] = product
+
+	product.CategoryName = r.store.categoryName(product.CategoryID)
+	return &product, nil
+}
+
+// Delete removes a product by its ID. Like the foreign key of the SQL schema,
+// it refuses to delete a product that has been sold.
+func (r *memoryProductRepository) Delete(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.products[id]; !ok {
+		return sql.ErrNoRows
+	}
+	for _, t := range r.store.transactions {
+		for _, d := range t.Details {
+			if d.ProductID == id {
+				return fmt.Errorf("product %d is referenced by transaction %d", id, t.ID)
+			}
+		}
+	}
+	delete(r.store.products, id)
+	return nil
+}
+
+// GetLowStock returns active products whose stock is at or below their
+// min_stock threshold, largest shortfall first
+func (r *memoryProductRepository) GetLowStock(ctx context.Context, categoryID *int) ([]models.LowStockProduct, error) {
+	r.store.mu.RLock()
+	products := r.store.sortedProducts(func(p models.Product) bool {
+		return p.IsActive && p.Stock <= p.MinStock && inCategory(p, categoryID)
+	})
+	r.store.mu.RUnlock()
+
+	low := make([]models.LowStockProduct, 0, len(products))
+	for _, p := range products {
+		low = append(low, models.LowStockProduct{
+			ProductID:    p.ID,
+			Name:         p.Name,
+			SKU:          p.SKU,
+			Unit:         p.Unit,
+			CategoryID:   p.CategoryID,
+			CategoryName: p.CategoryName,
+			Stock:        p.Stock,
+			MinStock:     p.MinStock,
+			Shortfall:    p.MinStock - p.Stock,
+		})
+	}
+	sort.SliceStable(low, func(i, j int) bool {
+		if low[i].Shortfall != low[j].Shortfall {
+			return low[i].Shortfall > low[j].Shortfall
+		}
+		return low[i].Name < low[j].Name
+	})
+	return low, nil
+}
+
+// GetSalesVelocity returns every active product with the units sold by
+// non-voided transactions in the last given number of days
+func (r *memoryProductRepository) GetSalesVelocity(ctx context.Context, days int, categoryID *int) ([]models.ReorderSuggestion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	since := time.Now().AddDate(0, 0, -days)
+	sold := make(map[int]int)
+	for _, t := range r.store.transactions {
+		if t.Status != "active" || t.CreatedAt.Before(since) {
+			continue
+		}
+		for _, d := range t.Details {
+			sold[d.ProductID] += d.Quantity
+		}
+	}
+
+	products := r.store.sortedProducts(func(p models.Product) bool { return p.IsActive && inCategory(p, categoryID) })
+	suggestions := make([]models.ReorderSuggestion, 0, len(products))
+	for _, p := range products {
+		suggestions = append(suggestions, models.ReorderSuggestion{
+			ProductID:    p.ID,
+			Name:         p.Name,
+			SKU:          p.SKU,
+			Unit:         p.Unit,
+			CategoryID:   p.CategoryID,
+			CategoryName: p.CategoryName,
+			SupplierID:   p.SupplierID,
+			Stock:        p.Stock,
+			MinStock:     p.MinStock,
+			UnitsSold:    sold[p.ID],
+		})
+	}
+	return suggestions, nil
+}
+
+// slug returns a slug for name unused by any product but excludeID. Callers
+// hold the lock.
+func (r *memoryProductRepository) slug(name string, excludeID int) string {
+	return memorySlug(name, func(slug string) bool {
+		for id, p := range r.store.products {
+			if id != excludeID && p.Slug == slug {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// inCategory reports whether p is in the category, always true for nil
+func inCategory(p models.Product, categoryID *int) bool {
+	return categoryID == nil || (p.CategoryID != nil && *p.CategoryID == *categoryID)
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"sort"
+	"sync"
+)
+
+// MemoryStore holds the rows of the in-memory category, product and
+// transaction repositories, which share one store the way the SQL
+// repositories share a database: sales deduct product stock and deleting a
+// category uncategorizes its products. It is meant for demos and for
+// exercising services without Postgres, so it leaves out what lives in other
+// tables: stock is not split by store, there is no stock ledger or outbox,
+// and lines are costed at the product's cost price (or its consignment cost)
+// instead of FIFO cost layers. Everything is lost when the process exits.
+type MemoryStore struct {
+	mu           sync.RWMutex
+	categories   map[int]models.Category
+	products     map[int]models.Product
+	transactions map[int]*memoryTransaction
+	// receiptSeqs and queueSeqs hold the last number issued per day
+	receiptSeqs map[string]int
+	queueSeqs   map[string]int
+
+	lastCategoryID, lastProductID, lastTransactionID, lastDetailID int
+}
+
+// memoryTransaction is a stored transaction with the columns the model does
+// not carry
+type memoryTransaction struct {
+	models.Transaction
+	cashierID int
+	// payables is the consignment payable of each line, in Details order
+	payables []int
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		categories:   make(map[int]models.Category),
+		products:     make(map[int]models.Product),
+		transactions: make(map[int]*memoryTransaction),
+		receiptSeqs:  make(map[string]int),
+		queueSeqs:    make(map[string]int),
+	}
+}
+
+// categoryName returns the name of a category, "" when it is nil or gone.
+// Callers hold the lock.
+func (s *MemoryStore) categoryName(id *int) string {
+	if id == nil {
+		return ""
+	}
+	return s.categories[*id].Name
+}
+
+// sortedProducts returns the products matching keep in ID order, with their
+// category names. Callers hold the lock.
+func (s *MemoryStore) sortedProducts(keep func(models.Product) bool) []models.Product {
+	var products []models.Product
+	for _, p := range s.products {
+		if keep(p) {
+			p.CategoryName = s.categoryName(p.CategoryID)
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	return products
+}
+
+// copyTransaction returns a copy of a stored transaction whose details the
+// caller may change
+func copyTransaction(t *memoryTransaction) models.Transaction {
+	c := t.Transaction
+	c.Details = make([]models.TransactionDetail, len(t.Details))
+	for i, d := range t.Details {
+		d.Promotions = append(make([]models.AppliedPromotion, 0, len(d.Promotions)), d.Promotions...)
+		c.Details[i] = d
+	}
+	return c
+}
+
+// memorySlug returns a slug for name that taken reports unused, appending
+// -2, -3, ... like uniqueSlug
+func memorySlug(name string, taken func(slug string) bool) string {
+	base := helpers.Slugify(name)
+	slug := base
+	for n := 2; taken(slug); n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug
+}
@@ -0,0 +1,679 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// memoryTransactionRepository implements TransactionRepository on a
+// MemoryStore. Reports group products under their current category, as the
+// SQL reports do.
+type memoryTransactionRepository struct {
+	store *MemoryStore
+}
+
+// NewMemoryTransactionRepository creates a transaction repository keeping its
+// rows in store
+func NewMemoryTransactionRepository(store *MemoryStore) TransactionRepository {
+	return &memoryTransactionRepository{store: store}
+}
+
+// memoryLine is a line of a sale selected by a report, with its revenue net
+// of the transaction discount and its cost
+type memoryLine struct {
+	transaction *memoryTransaction
+	detail      models.TransactionDetail
+	revenue     float64
+	cost        int
+}
+
+// CreateTransaction checks and deducts the stock of every line and stores the
+// sale with the next receipt and queue numbers of the day. Details arrive
+// already priced by the service layer.
+func (repo *memoryTransactionRepository) CreateTransaction(ctx context.Context, req models.CheckoutRequest, details []models.TransactionDetail) (*models.Transaction, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	quantities := make(map[int]int, len(details))
+	for _, d := range details {
+		quantities[d.ProductID] += d.Quantity
+	}
+	for _, d := range details {
+		p, ok := s.products[d.ProductID]
+		if !ok {
+			return nil, helpers.NewCodedError(helpers.CodeProductNotFound, d.ProductID)
+		}
+		if p.Stock < quantities[d.ProductID] {
+			return nil, helpers.NewCodedError(helpers.CodeInsufficientStock, d.ProductName, p.Stock, quantities[d.ProductID])
+		}
+	}
+
+	totalAmount := 0
+	for _, d := range details {
+		totalAmount += d.Subtotal
+	}
+	discount := min(req.Discount, totalAmount)
+	paymentMethod := req.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = "cash"
+	}
+
+	now := time.Now()
+	day := now.Format("2006-01-02")
+	s.receiptSeqs[day]++
+	s.queueSeqs[day]++
+	s.lastTransactionID++
+
+	t := &memoryTransaction{
+		Transaction: models.Transaction{
+			ID:            s.lastTransactionID,
+			StoreID:       req.StoreID,
+			ReceiptNo:     fmt.Sprintf("INV-%s-%04d", now.Format("20060102"), s.receiptSeqs[day]),
+			QueueNo:       s.queueSeqs[day],
+			TotalAmount:   totalAmount - discount,
+			PaymentMethod: paymentMethod,
+			PriceLevel:    req.PriceLevel,
+			Discount:      discount,
+			Notes:         req.Notes,
+			Status:        "active",
+			CreatedAt:     now,
+			Details:       make([]models.TransactionDetail, len(details)),
+		},
+		cashierID: req.CashierID,
+		payables:  make([]int, len(details)),
+	}
+	for i, d := range details {
+		p := s.products[d.ProductID]
+		p.Stock -= d.Quantity
+		s.products[d.ProductID] = p
+
+		s.lastDetailID++
+		d.ID = s.lastDetailID
+		d.TransactionID = t.ID
+		if p.IsConsignment {
+			d.CostAmount = 0
+			t.payables[i] = p.ConsignmentCost * d.Quantity
+		} else {
+			d.CostAmount = p.CostPrice * d.Quantity
+		}
+		t.Details[i] = d
+	}
+	s.transactions[t.ID] = t
+
+	transaction := copyTransaction(t)
+	return &transaction, nil
+}
+
+// GetAllTransactions returns a paginated list of transactions, newest first,
+// with optional date range, store and receipt number filtering
+func (repo *memoryTransactionRepository) GetAllTransactions(ctx context.Context, params models.TransactionListParams) (*models.PaginatedTransactions, error) {
+	page, limit := params.Page, params.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	repo.store.mu.RLock()
+	receiptNo := strings.ToLower(params.ReceiptNo)
+	items := make([]models.TransactionListItem, 0)
+	for _, t := range repo.store.transactions {
+		date := t.CreatedAt.Format("2006-01-02")
+		if (params.StartDate != "" && date < params.StartDate) || (params.EndDate != "" && date > params.EndDate) ||
+			(params.StoreID > 0 && t.StoreID != params.StoreID) ||
+			(receiptNo != "" && !strings.Contains(strings.ToLower(t.ReceiptNo), receiptNo)) {
+			continue
+		}
+		items = append(items, models.TransactionListItem{
+			ID:            t.ID,
+			StoreID:       t.StoreID,
+			ReceiptNo:     t.ReceiptNo,
+			QueueNo:       t.QueueNo,
+			TotalAmount:   t.TotalAmount,
+			PaymentMethod: t.PaymentMethod,
+			Discount:      t.Discount,
+			Status:        t.Status,
+			ItemCount:     len(t.Details),
+			CreatedAt:     t.CreatedAt,
+		})
+	}
+	repo.store.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.After(items[j].CreatedAt)
+		}
+		return items[i].ID > items[j].ID
+	})
+	total := len(items)
+	start := min((page-1)*limit, total)
+	end := min(start+limit, total)
+
+	return &models.PaginatedTransactions{
+		Data:       items[start:end],
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// GetTransactionByID returns a single transaction with all its details
+func (repo *memoryTransactionRepository) GetTransactionByID(ctx context.Context, id int) (*models.Transaction, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	t, ok := repo.store.transactions[id]
+	if !ok {
+		return nil, helpers.NewCodedError(helpers.CodeTransactionNotFound, id)
+	}
+	transaction := copyTransaction(t)
+	for i, d := range transaction.Details {
+		transaction.Details[i].ProductName = "Deleted Product"
+		if p, ok := repo.store.products[d.ProductID]; ok {
+			transaction.Details[i].ProductName = p.Name
+		}
+	}
+	return &transaction, nil
+}
+
+// VoidTransaction marks a transaction as void and restores the stock of the
+// products it sold that still exist
+func (repo *memoryTransactionRepository) VoidTransaction(ctx context.Context, id int) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.transactions[id]
+	if !ok {
+		return helpers.NewCodedError(helpers.CodeTransactionNotFound, id)
+	}
+	if t.Status == "void" {
+		return helpers.NewCodedError(helpers.CodeTransactionVoided)
+	}
+	for _, d := range t.Details {
+		if p, ok := s.products[d.ProductID]; ok {
+			p.Stock += d.Quantity
+			s.products[d.ProductID] = p
+		}
+	}
+	t.Status = "void"
+	return nil
+}
+
+// GetDashboardStats returns summary statistics for the admin dashboard for a
+// store, or across all stores when storeID is 0. Stock is not split by store
+// in memory, so the low stock count always covers the total stock.
+func (repo *memoryTransactionRepository) GetDashboardStats(ctx context.Context, storeID int) (*models.DashboardStats, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	today := time.Now().Format("2006-01-02")
+	filter := models.ReportFilter{StartDate: today, EndDate: today, StoreID: storeID}
+	stats := &models.DashboardStats{
+		TotalProducts:   len(repo.store.products),
+		TotalCategories: len(repo.store.categories),
+	}
+	for _, t := range repo.sales(filter) {
+		stats.TotalRevenueToday += t.TotalAmount
+		stats.TransactionsToday++
+	}
+	for _, p := range repo.store.products {
+		if p.IsActive && p.Stock <= p.MinStock {
+			stats.LowStockCount++
+		}
+	}
+	stats.BestSellingToday = repo.bestSellingProduct(filter)
+	return stats, nil
+}
+
+// GetDailySalesReport returns the sales summary for today at a store, or
+// across all stores when storeID is 0
+func (repo *memoryTransactionRepository) GetDailySalesReport(ctx context.Context, storeID int) (*models.SalesReport, error) {
+	today := time.Now().Format("2006-01-02")
+	return repo.GetSalesReportByDateRange(ctx, models.ReportFilter{StartDate: today, EndDate: today, StoreID: storeID})
+}
+
+// GetSalesReportByDateRange returns the sales summary for the transactions
+// matching filter
+func (repo *memoryTransactionRepository) GetSalesReportByDateRange(ctx context.Context, filter models.ReportFilter) (*models.SalesReport, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	report := &models.SalesReport{}
+	for _, t := range repo.sales(filter) {
+		report.TotalRevenue += t.TotalAmount
+		report.TotalTransactions++
+		for i, d := range t.Details {
+			report.TotalCOGS += d.CostAmount
+			report.ConsignmentPayable += t.payables[i]
+		}
+	}
+	report.GrossProfit = report.TotalRevenue - report.TotalCOGS - report.ConsignmentPayable
+	report.BestSellingProduct = repo.bestSellingProduct(filter)
+	return report, nil
+}
+
+// GetReportSummary returns an aggregated report with category breakdown for
+// the transactions matching filter
+func (repo *memoryTransactionRepository) GetReportSummary(ctx context.Context, filter models.ReportFilter) (*models.ReportSummary, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	summary := &models.ReportSummary{}
+	for _, t := range repo.sales(filter) {
+		summary.TotalRevenue += t.TotalAmount
+		summary.TotalTransactions++
+	}
+	summary.BestSellingProduct = repo.bestSellingProduct(filter)
+
+	byCategory := make(map[int]*models.CategoryRevenue)
+	transactions := make(map[int]map[int]bool)
+	for _, l := range repo.lines(filter) {
+		p, ok := repo.store.products[l.detail.ProductID]
+		if !ok {
+			continue
+		}
+		categoryID, name := 0, "Uncategorized"
+		if p.CategoryID != nil {
+			categoryID = *p.CategoryID
+			if c, ok := repo.store.categories[categoryID]; ok {
+				name = c.Name
+			}
+		}
+		cr := byCategory[categoryID]
+		if cr == nil {
+			cr = &models.CategoryRevenue{CategoryID: categoryID, CategoryName: name}
+			byCategory[categoryID] = cr
+			transactions[categoryID] = make(map[int]bool)
+		}
+		cr.Revenue += l.detail.Subtotal
+		transactions[categoryID][l.transaction.ID] = true
+	}
+
+	summary.CategoryBreakdown = make([]models.CategoryRevenue, 0, len(byCategory))
+	for id, cr := range byCategory {
+		cr.Transactions = len(transactions[id])
+		summary.CategoryBreakdown = append(summary.CategoryBreakdown, *cr)
+	}
+	sort.Slice(summary.CategoryBreakdown, func(i, j int) bool {
+		a, b := summary.CategoryBreakdown[i], summary.CategoryBreakdown[j]
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+		return a.CategoryID < b.CategoryID
+	})
+	return summary, nil
+}
+
+// GetProductProfits returns revenue and cost of goods sold per product for
+// the lines matching filter, highest gross profit first. Transaction
+// discounts are spread over the lines as in the SQL report.
+func (repo *memoryTransactionRepository) GetProductProfits(ctx context.Context, filter models.ReportFilter) ([]models.ProductProfit, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	byProduct := make(map[int]*models.ProductProfit)
+	revenue := make(map[int]float64)
+	for _, l := range repo.netLines(filter) {
+		pp := byProduct[l.detail.ProductID]
+		if pp == nil {
+			pp = &models.ProductProfit{ProductID: l.detail.ProductID}
+			if p, ok := repo.store.products[l.detail.ProductID]; ok {
+				pp.ProductName, pp.CategoryID, pp.CategoryName = p.Name, p.CategoryID, repo.store.categoryName(p.CategoryID)
+			}
+			byProduct[l.detail.ProductID] = pp
+		}
+		pp.QtySold += l.detail.Quantity
+		pp.COGS += l.cost
+		revenue[l.detail.ProductID] += l.revenue
+	}
+
+	profits := make([]models.ProductProfit, 0, len(byProduct))
+	for id, pp := range byProduct {
+		pp.Revenue = int(math.Round(revenue[id]))
+		profits = append(profits, *pp)
+	}
+	sort.Slice(profits, func(i, j int) bool {
+		a, b := profits[i], profits[j]
+		if a.Revenue-a.COGS != b.Revenue-b.COGS {
+			return a.Revenue-a.COGS > b.Revenue-b.COGS
+		}
+		return a.ProductID < b.ProductID
+	})
+	return profits, nil
+}
+
+// GetDailySales returns revenue, transactions and items sold per day for the
+// transactions matching filter, which must have a start and end date. Days
+// without sales are included with zeros.
+func (repo *memoryTransactionRepository) GetDailySales(ctx context.Context, filter models.ReportFilter) ([]models.DailySales, error) {
+	buckets, err := repo.buckets(filter, models.GranularityDay)
+	if err != nil {
+		return nil, err
+	}
+	days := make([]models.DailySales, len(buckets))
+	for i, b := range buckets {
+		days[i] = models.DailySales{Date: b.Start, Transactions: b.Transactions, ItemsSold: b.ItemsSold, Revenue: b.Revenue}
+	}
+	return days, nil
+}
+
+// GetCategorySales returns quantity sold, revenue and the number of
+// transactions per category for the lines matching filter, highest revenue
+// first
+func (repo *memoryTransactionRepository) GetCategorySales(ctx context.Context, filter models.ReportFilter) ([]models.CategorySales, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	byCategory := make(map[int]*models.CategorySales)
+	revenue := make(map[int]float64)
+	transactions := make(map[int]map[int]bool)
+	for _, l := range repo.netLines(filter) {
+		var categoryID *int
+		if p, ok := repo.store.products[l.detail.ProductID]; ok {
+			categoryID = p.CategoryID
+		}
+		key := 0
+		if categoryID != nil {
+			key = *categoryID
+		}
+		cs := byCategory[key]
+		if cs == nil {
+			cs = &models.CategorySales{CategoryID: categoryID, CategoryName: "Uncategorized"}
+			if c, ok := repo.store.categories[key]; ok {
+				cs.CategoryName = c.Name
+			}
+			byCategory[key] = cs
+			transactions[key] = make(map[int]bool)
+		}
+		cs.QtySold += l.detail.Quantity
+		revenue[key] += l.revenue
+		transactions[key][l.transaction.ID] = true
+	}
+
+	categories := make([]models.CategorySales, 0, len(byCategory))
+	for key, cs := range byCategory {
+		cs.Revenue = int(math.Round(revenue[key]))
+		cs.Transactions = len(transactions[key])
+		categories = append(categories, *cs)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+		// Uncategorized last, as NULLs sort in Postgres
+		if a.CategoryID == nil || b.CategoryID == nil {
+			return b.CategoryID == nil && a.CategoryID != nil
+		}
+		return *a.CategoryID < *b.CategoryID
+	})
+	return categories, nil
+}
+
+// GetBestSellers returns the products sold most in the lines matching filter,
+// by quantity and then revenue. Revenue is net of transaction discounts.
+func (repo *memoryTransactionRepository) GetBestSellers(ctx context.Context, filter models.ReportFilter, limit int) ([]models.BestSeller, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	byProduct := make(map[int]*models.BestSeller)
+	revenue := make(map[int]float64)
+	transactions := make(map[int]map[int]bool)
+	for _, l := range repo.netLines(filter) {
+		id := l.detail.ProductID
+		b := byProduct[id]
+		if b == nil {
+			b = &models.BestSeller{ProductID: id}
+			if p, ok := repo.store.products[id]; ok {
+				b.ProductName, b.CategoryName = p.Name, repo.store.categoryName(p.CategoryID)
+			}
+			byProduct[id] = b
+			transactions[id] = make(map[int]bool)
+		}
+		b.QtySold += l.detail.Quantity
+		revenue[id] += l.revenue
+		transactions[id][l.transaction.ID] = true
+	}
+
+	products := make([]models.BestSeller, 0, len(byProduct))
+	for id, b := range byProduct {
+		b.Revenue = int(math.Round(revenue[id]))
+		b.Transactions = len(transactions[id])
+		products = append(products, *b)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		a, b := products[i], products[j]
+		if a.QtySold != b.QtySold {
+			return a.QtySold > b.QtySold
+		}
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+		return a.ProductID < b.ProductID
+	})
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	for i := range products {
+		products[i].Rank = i + 1
+	}
+	return products, nil
+}
+
+// GetHourlySales returns transactions and revenue per hour of the day for the
+// transactions matching filter. All 24 hours are included, with zeros for
+// hours without sales.
+func (repo *memoryTransactionRepository) GetHourlySales(ctx context.Context, filter models.ReportFilter) ([]models.HourlySales, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	hours := make([]models.HourlySales, 24)
+	for h := range hours {
+		hours[h].Hour = h
+	}
+	for _, t := range repo.sales(filter) {
+		h := &hours[t.CreatedAt.Hour()]
+		h.Transactions++
+		h.Revenue += t.TotalAmount
+	}
+	return hours, nil
+}
+
+// GetSalesTimeSeries returns revenue, transactions and items sold per day,
+// week or month (granularity) for the transactions matching filter, which
+// must have a start and end date. Weeks start on Monday; the first and last
+// buckets are cut to the filter's dates, and buckets without sales are
+// included with zeros.
+func (repo *memoryTransactionRepository) GetSalesTimeSeries(ctx context.Context, filter models.ReportFilter, granularity string) ([]models.SalesBucket, error) {
+	return repo.buckets(filter, granularity)
+}
+
+// buckets totals the sales matching filter per day, week or month from the
+// filter's start to its end date
+func (repo *memoryTransactionRepository) buckets(filter models.ReportFilter, granularity string) ([]models.SalesBucket, error) {
+	start, err := time.Parse("2006-01-02", filter.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse("2006-01-02", filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	buckets := make([]models.SalesBucket, 0)
+	index := make(map[time.Time]int)
+	for b := truncateDate(start, granularity); !b.After(end); b = nextBucket(b, granularity) {
+		last := nextBucket(b, granularity).AddDate(0, 0, -1)
+		if last.After(end) {
+			last = end
+		}
+		first := b
+		if first.Before(start) {
+			first = start
+		}
+		index[b] = len(buckets)
+		buckets = append(buckets, models.SalesBucket{Start: first.Format("2006-01-02"), End: last.Format("2006-01-02")})
+	}
+
+	bucketOf := func(t *memoryTransaction) *models.SalesBucket {
+		y, m, d := t.CreatedAt.Date()
+		i, ok := index[truncateDate(time.Date(y, m, d, 0, 0, 0, 0, time.UTC), granularity)]
+		if !ok {
+			return nil
+		}
+		return &buckets[i]
+	}
+	for _, t := range repo.sales(filter) {
+		if b := bucketOf(t); b != nil {
+			b.Revenue += t.TotalAmount
+			b.Transactions++
+		}
+	}
+	for _, l := range repo.lines(filter) {
+		if b := bucketOf(l.transaction); b != nil {
+			b.ItemsSold += l.detail.Quantity
+		}
+	}
+	return buckets, nil
+}
+
+// truncateDate returns the first day of the day, week (Monday) or month of d
+func truncateDate(d time.Time, granularity string) time.Time {
+	switch granularity {
+	case models.GranularityWeek:
+		return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+	case models.GranularityMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return d
+}
+
+// nextBucket returns the first day of the day, week or month after bucket b
+func nextBucket(b time.Time, granularity string) time.Time {
+	switch granularity {
+	case models.GranularityWeek:
+		return b.AddDate(0, 0, 7)
+	case models.GranularityMonth:
+		return b.AddDate(0, 1, 0)
+	}
+	return b.AddDate(0, 0, 1)
+}
+
+// bestSellingProduct returns the existing product sold most by quantity in
+// the lines matching filter, or nil when nothing was sold. Callers hold the
+// lock.
+func (repo *memoryTransactionRepository) bestSellingProduct(filter models.ReportFilter) *models.BestSellingProduct {
+	sold := make(map[int]int)
+	for _, l := range repo.lines(filter) {
+		if _, ok := repo.store.products[l.detail.ProductID]; ok {
+			sold[l.detail.ProductID] += l.detail.Quantity
+		}
+	}
+	var best *models.BestSellingProduct
+	bestID := 0
+	for id, qty := range sold {
+		if best == nil || qty > best.QtySold || (qty == best.QtySold && id < bestID) {
+			best = &models.BestSellingProduct{Name: repo.store.products[id].Name, QtySold: qty}
+			bestID = id
+		}
+	}
+	return best
+}
+
+// sales returns the non-voided transactions matching filter in ID order.
+// With a category it returns those that sold a product of it. Callers hold
+// the lock.
+func (repo *memoryTransactionRepository) sales(filter models.ReportFilter) []*memoryTransaction {
+	var sales []*memoryTransaction
+	for _, t := range repo.store.transactions {
+		if !matchesSale(t, filter) {
+			continue
+		}
+		if filter.CategoryID > 0 {
+			found := false
+			for _, d := range t.Details {
+				found = found || repo.inCategory(d.ProductID, filter.CategoryID)
+			}
+			if !found {
+				continue
+			}
+		}
+		sales = append(sales, t)
+	}
+	sort.Slice(sales, func(i, j int) bool { return sales[i].ID < sales[j].ID })
+	return sales
+}
+
+// lines returns the lines of the non-voided transactions matching filter;
+// with a category only the lines of its products. Callers hold the lock.
+func (repo *memoryTransactionRepository) lines(filter models.ReportFilter) []memoryLine {
+	var lines []memoryLine
+	for _, t := range repo.sales(models.ReportFilter{
+		StartDate: filter.StartDate, EndDate: filter.EndDate, StoreID: filter.StoreID,
+		CashierID: filter.CashierID, PaymentMethod: filter.PaymentMethod,
+	}) {
+		for i, d := range t.Details {
+			if filter.CategoryID == 0 || repo.inCategory(d.ProductID, filter.CategoryID) {
+				lines = append(lines, memoryLine{transaction: t, detail: d, cost: d.CostAmount + t.payables[i]})
+			}
+		}
+	}
+	return lines
+}
+
+// netLines returns the lines of the sales matching filter with the
+// transaction discount spread over every line of a sale in proportion to its
+// subtotal (see lineRevenue); with a category only its lines, after the
+// spread. Callers hold the lock.
+func (repo *memoryTransactionRepository) netLines(filter models.ReportFilter) []memoryLine {
+	var lines []memoryLine
+	for _, t := range repo.sales(filter) {
+		subtotal := 0
+		for _, d := range t.Details {
+			subtotal += d.Subtotal
+		}
+		for i, d := range t.Details {
+			if filter.CategoryID > 0 && !repo.inCategory(d.ProductID, filter.CategoryID) {
+				continue
+			}
+			revenue := float64(d.Subtotal)
+			if subtotal != 0 {
+				revenue -= math.Round(float64(t.Discount) * float64(d.Subtotal) / float64(subtotal))
+			}
+			lines = append(lines, memoryLine{transaction: t, detail: d, revenue: revenue, cost: d.CostAmount + t.payables[i]})
+		}
+	}
+	return lines
+}
+
+// inCategory reports whether a product exists and is in a category. Callers
+// hold the lock.
+func (repo *memoryTransactionRepository) inCategory(productID, categoryID int) bool {
+	p, ok := repo.store.products[productID]
+	return ok && p.CategoryID != nil && *p.CategoryID == categoryID
+}
+
+// matchesSale reports whether t is a non-voided transaction matching the
+// period, store, cashier and payment method of filter
+func matchesSale(t *memoryTransaction, filter models.ReportFilter) bool {
+	date := t.CreatedAt.Format("2006-01-02")
+	return t.Status == "active" &&
+		(filter.StartDate == "" || date >= filter.StartDate) &&
+		(filter.EndDate == "" || date <= filter.EndDate) &&
+		(filter.StoreID == 0 || t.StoreID == filter.StoreID) &&
+		(filter.CashierID == 0 || t.cashierID == filter.CashierID) &&
+		(filter.PaymentMethod == "" || t.PaymentMethod == filter.PaymentMethod)
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"retail-core-api/models"
+	"time"
+)
+
+// OutboxRepository defines the interface for relaying domain events. Events
+// are written by the repositories that make the change, inside the same
+// database transaction as the change. Each consumer keeps its own record of
+// the events it was handed, so one consumer failing does not hold up the
+// others.
+type OutboxRepository interface {
+	Lease(ctx context.Context, consumer string, d time.Duration) (bool, error)
+	Release(ctx context.Context, consumer string) error
+	GetPending(ctx context.Context, consumer string, limit int) ([]models.DomainEvent, error)
+	MarkDelivered(ctx context.Context, consumer string, ids []int) error
+	RecordFailure(ctx context.Context, consumer string, id int, message string, retryIn time.Duration) error
+	Park(ctx context.Context, consumer string, id int, message string) error
+	MarkPublished(ctx context.Context, consumers []string) (int, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// outboxRepository implements OutboxRepository interface with PostgreSQL
+type outboxRepository struct {
+	db DBTX
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db DBTX) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// recordDomainEvent appends a domain event to the outbox. It must run in the
+// same database transaction as the change the event describes.
+func recordDomainEvent(ctx context.Context, e execer, eventType, aggregateType string, aggregateID int, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = e.ExecContext(ctx, `
+		INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+		VALUES ($1, $2, $3, $4::jsonb)
+	`, eventType, aggregateType, aggregateID, string(data))
+	return err
+}
+
+// Lease claims a consumer for d, so relays on other servers leave it alone
+// until it is released or the lease runs out. It reports false when another
+// relay holds it.
+func (r *outboxRepository) Lease(ctx context.Context, consumer string, d time.Duration) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO outbox_consumers (consumer, leased_until)
+		VALUES ($1, NOW() + $2 * INTERVAL '1 millisecond')
+		ON CONFLICT (tenant_id, consumer) DO UPDATE SET leased_until = EXCLUDED.leased_until
+		WHERE outbox_consumers.leased_until < NOW()
+	`, consumer, d.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	leased, err := result.RowsAffected()
+	return leased == 1, err
+}
+
+// Release ends the lease of a consumer
+func (r *outboxRepository) Release(ctx context.Context, consumer string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE outbox_consumers SET leased_until = '-infinity' WHERE consumer = $1`,
+		consumer,
+	)
+	return err
+}
+
+// GetPending returns the oldest unpublished events a consumer has neither
+// accepted nor parked, with its attempts at them
+func (r *outboxRepository) GetPending(ctx context.Context, consumer string, limit int) ([]models.DomainEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.id, e.event_type, e.aggregate_type, e.aggregate_id, e.payload,
+		       COALESCE(d.attempts, 0), COALESCE(d.last_error, ''), COALESCE(d.retry_at > NOW(), false),
+		       e.created_at, e.published_at
+		FROM outbox_events e
+		LEFT JOIN outbox_deliveries d ON d.event_id = e.id AND d.consumer = $1
+		WHERE e.published_at IS NULL AND d.delivered_at IS NULL AND d.parked_at IS NULL
+		ORDER BY e.id
+		LIMIT $2
+	`, consumer, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]models.DomainEvent, 0)
+	for rows.Next() {
+		var e models.DomainEvent
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Type, &e.AggregateType, &e.AggregateID, &payload,
+			&e.Attempts, &e.LastError, &e.Deferred, &e.CreatedAt, &e.PublishedAt); err != nil {
+			return nil, err
+		}
+		e.Payload = payload
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// MarkDelivered records that a consumer accepted events
+func (r *outboxRepository) MarkDelivered(ctx context.Context, consumer string, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, consumer)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO outbox_deliveries (event_id, consumer, attempts, delivered_at)
+		SELECT ids.id, $1, 1, NOW() FROM (VALUES `+valuesList(len(ids), 1, 1, "int")+`) AS ids (id)
+		ON CONFLICT (event_id, consumer) DO UPDATE
+		SET attempts = outbox_deliveries.attempts + 1, last_error = '', retry_at = NULL, delivered_at = NOW()
+	`, args...)
+	return err
+}
+
+// RecordFailure records a consumer's failed attempt at an event, which it is
+// handed again once retryIn has passed
+func (r *outboxRepository) RecordFailure(ctx context.Context, consumer string, id int, message string, retryIn time.Duration) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO outbox_deliveries (event_id, consumer, attempts, last_error, retry_at)
+		VALUES ($1, $2, 1, $3, NOW() + $4 * INTERVAL '1 millisecond')
+		ON CONFLICT (event_id, consumer) DO UPDATE
+		SET attempts = outbox_deliveries.attempts + 1, last_error = EXCLUDED.last_error, retry_at = EXCLUDED.retry_at
+	`, id, consumer, message, retryIn.Milliseconds())
+	return err
+}
+
+// Park records a consumer's last failed attempt at an event and gives up on
+// it, so the consumer moves on to later events
+func (r *outboxRepository) Park(ctx context.Context, consumer string, id int, message string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO outbox_deliveries (event_id, consumer, attempts, last_error, parked_at)
+		VALUES ($1, $2, 1, $3, NOW())
+		ON CONFLICT (event_id, consumer) DO UPDATE
+		SET attempts = outbox_deliveries.attempts + 1, last_error = EXCLUDED.last_error, retry_at = NULL, parked_at = NOW()
+	`, id, consumer, message)
+	return err
+}
+
+// MarkPublished marks the events every one of consumers has accepted or
+// parked as published and returns how many there were
+func (r *outboxRepository) MarkPublished(ctx context.Context, consumers []string) (int, error) {
+	args := make([]interface{}, len(consumers))
+	for i, c := range consumers {
+		args[i] = c
+	}
+	pending := "FALSE"
+	if len(consumers) > 0 {
+		pending = `EXISTS (
+			SELECT 1 FROM (VALUES ` + valuesList(len(consumers), 1, 0, "text") + `) AS c (consumer)
+			WHERE NOT EXISTS (
+				SELECT 1 FROM outbox_deliveries d
+				WHERE d.event_id = e.id AND d.consumer = c.consumer
+				  AND (d.delivered_at IS NOT NULL OR d.parked_at IS NOT NULL)
+			)
+		)`
+	}
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE outbox_events e SET published_at = NOW()
+		WHERE e.published_at IS NULL AND NOT `+pending, args...)
+	if err != nil {
+		return 0, err
+	}
+	published, err := result.RowsAffected()
+	return int(published), err
+}
+
+// DeletePublishedBefore removes events published before a time and returns
+// how many were removed
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox_events WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
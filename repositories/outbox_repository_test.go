@@ -0,0 +1,98 @@
+package repositories_test
+
+import (
+	"context"
+	"retail-core-api/database/dbtest"
+	"retail-core-api/repositories"
+	"testing"
+	"time"
+)
+
+func TestOutboxDeliveriesPerConsumer(t *testing.T) {
+	ctx := context.Background()
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+	outbox := repositories.NewOutboxRepository(db)
+
+	var first, second int
+	err := db.QueryRow(`
+		WITH e AS (
+			INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+			VALUES ('StockChanged', 'product', 1, '{}'), ('StockChanged', 'product', 2, '{}')
+			RETURNING id
+		)
+		SELECT MIN(id), MAX(id) FROM e
+	`).Scan(&first, &second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("a lease keeps other relays away", func(t *testing.T) {
+		if leased, err := outbox.Lease(ctx, "broker", time.Minute); err != nil || !leased {
+			t.Fatalf("lease: %v, %v", leased, err)
+		}
+		if leased, err := outbox.Lease(ctx, "broker", time.Minute); err != nil || leased {
+			t.Fatalf("a held consumer was leased again: %v, %v", leased, err)
+		}
+		if leased, err := outbox.Lease(ctx, "webhooks", time.Minute); err != nil || !leased {
+			t.Fatalf("another consumer could not be leased: %v, %v", leased, err)
+		}
+		if err := outbox.Release(ctx, "broker"); err != nil {
+			t.Fatal(err)
+		}
+		if leased, err := outbox.Lease(ctx, "broker", time.Minute); err != nil || !leased {
+			t.Fatalf("a released consumer could not be leased: %v, %v", leased, err)
+		}
+	})
+
+	pending := func(consumer string) []int {
+		t.Helper()
+		events, err := outbox.GetPending(ctx, consumer, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]int, len(events))
+		for i, e := range events {
+			ids[i] = e.ID
+		}
+		return ids
+	}
+
+	if err := outbox.MarkDelivered(ctx, "webhooks", []int{first, second}); err != nil {
+		t.Fatal(err)
+	}
+	if got := pending("webhooks"); len(got) != 0 {
+		t.Fatalf("webhooks still have %v pending after accepting both", got)
+	}
+	if got := pending("broker"); len(got) != 2 || got[0] != first || got[1] != second {
+		t.Fatalf("broker has %v pending, want %d and %d", got, first, second)
+	}
+
+	// A failed event is deferred until its retry is due
+	if err := outbox.RecordFailure(ctx, "broker", first, "timeout", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	events, err := outbox.GetPending(ctx, "broker", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := events[0]; e.ID != first || !e.Deferred || e.Attempts != 1 || e.LastError != "timeout" {
+		t.Fatalf("failed event: %+v", e)
+	}
+	if n, err := outbox.MarkPublished(ctx, []string{"webhooks", "broker"}); err != nil || n != 0 {
+		t.Fatalf("published %d events (%v) the broker has not accepted", n, err)
+	}
+
+	// A parked event no longer holds up publication
+	if err := outbox.Park(ctx, "broker", first, "timeout"); err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.MarkDelivered(ctx, "broker", []int{second}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := outbox.MarkPublished(ctx, []string{"webhooks", "broker"}); err != nil || n != 2 {
+		t.Fatalf("published %d events (%v), want 2", n, err)
+	}
+	if got := pending("catalog_cache"); len(got) != 0 {
+		t.Fatalf("published events are pending for a new consumer: %v", got)
+	}
+}
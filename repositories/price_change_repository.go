@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"retail-core-api/models"
+)
+
+// PriceChangeRepository defines the interface for reading the price history.
+// Changes are written by the repositories that change prices, inside the same
+// database transaction as the change.
+type PriceChangeRepository interface {
+	GetByProductID(ctx context.Context, productID int) ([]models.PriceChange, error)
+}
+
+// priceChangeRepository implements PriceChangeRepository interface with PostgreSQL
+type priceChangeRepository struct {
+	db DBTX
+}
+
+// NewPriceChangeRepository creates a new price change repository instance
+func NewPriceChangeRepository(db DBTX) PriceChangeRepository {
+	return &priceChangeRepository{db: db}
+}
+
+// recordPriceChange appends a price history row when the price actually
+// changed. It must run in the same database transaction as the change it
+// records.
+func recordPriceChange(ctx context.Context, e execer, change models.PriceChange, actor models.Actor) error {
+	if change.OldPrice != nil && *change.OldPrice == change.NewPrice {
+		return nil
+	}
+	var changedBy *int
+	if actor.UserID > 0 {
+		changedBy = &actor.UserID
+	}
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO price_changes (product_id, old_price, new_price, source, reference_id, changed_by, changed_by_name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, change.ProductID, change.OldPrice, change.NewPrice, change.Source, change.ReferenceID, changedBy, actor.Name)
+	return err
+}
+
+// GetByProductID returns the price history of a product, newest first
+func (r *priceChangeRepository) GetByProductID(ctx context.Context, productID int) ([]models.PriceChange, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, product_id, old_price, new_price, source, reference_id, changed_by, changed_by_name, created_at
+		FROM price_changes
+		WHERE product_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]models.PriceChange, 0)
+	for rows.Next() {
+		var pc models.PriceChange
+		err := rows.Scan(
+			&pc.ID, &pc.ProductID, &pc.OldPrice, &pc.NewPrice, &pc.Source,
+			&pc.ReferenceID, &pc.ChangedBy, &pc.ChangedByName, &pc.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, pc)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"retail-core-api/models"
+)
+
+// PriceTierRepository defines the interface for price tier data access
+type PriceTierRepository interface {
+	GetByProductID(ctx context.Context, productID int) ([]models.PriceTier, error)
+	GetByProductIDs(ctx context.Context, productIDs []int) (map[int][]models.PriceTier, error)
+	Replace(ctx context.Context, productID int, tiers []models.PriceTierInput) ([]models.PriceTier, error)
+}
+
+// priceTierRepository implements PriceTierRepository interface with PostgreSQL
+type priceTierRepository struct {
+	db DBTX
+}
+
+// NewPriceTierRepository creates a new price tier repository instance
+func NewPriceTierRepository(db DBTX) PriceTierRepository {
+	return &priceTierRepository{db: db}
+}
+
+// priceTierColumns is the standard set of columns selected for price tier queries
+const priceTierColumns = `id, product_id, price_level, min_quantity, price, created_at`
+
+// scanPriceTier scans a row into a PriceTier struct
+func scanPriceTier(scanner interface{ Scan(dest ...interface{}) error }) (*models.PriceTier, error) {
+	var t models.PriceTier
+	if err := scanner.Scan(&t.ID, &t.ProductID, &t.PriceLevel, &t.MinQuantity, &t.Price, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetByProductID returns the price tiers of a product grouped by price level,
+// smallest quantity first
+func (r *priceTierRepository) GetByProductID(ctx context.Context, productID int) ([]models.PriceTier, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+priceTierColumns+` FROM product_price_tiers
+		WHERE product_id = $1
+		ORDER BY CASE price_level WHEN 'retail' THEN 1 WHEN 'wholesale' THEN 2 ELSE 3 END, min_quantity
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := make([]models.PriceTier, 0)
+	for rows.Next() {
+		t, err := scanPriceTier(rows)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, *t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// GetByProductIDs returns the price tiers of several products keyed by
+// product ID, each ordered as GetByProductID orders them. Products without
+// tiers have no entry.
+func (r *priceTierRepository) GetByProductIDs(ctx context.Context, productIDs []int) (map[int][]models.PriceTier, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+priceTierColumns+` FROM product_price_tiers
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, CASE price_level WHEN 'retail' THEN 1 WHEN 'wholesale' THEN 2 ELSE 3 END, min_quantity
+	`, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := make(map[int][]models.PriceTier)
+	for rows.Next() {
+		t, err := scanPriceTier(rows)
+		if err != nil {
+			return nil, err
+		}
+		tiers[t.ProductID] = append(tiers[t.ProductID], *t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// Replace swaps every price tier of a product for the given set in a single
+// database transaction
+func (r *priceTierRepository) Replace(ctx context.Context, productID int, tiers []models.PriceTierInput) ([]models.PriceTier, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM product_price_tiers WHERE product_id = $1`, productID); err != nil {
+		return nil, err
+	}
+	for _, t := range tiers {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO product_price_tiers (product_id, price_level, min_quantity, price)
+			VALUES ($1, $2, $3, $4)
+		`, productID, t.PriceLevel, t.MinQuantity, t.Price)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByProductID(ctx, productID)
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// ProductRelationRepository defines the interface for product relation data access
+type ProductRelationRepository interface {
+	GetByProductID(ctx context.Context, productID int, relationType string) ([]models.ProductRelation, error)
+	Create(ctx context.Context, productID, relatedProductID int, relationType string) (*models.ProductRelation, error)
+	Delete(ctx context.Context, productID, relatedProductID int, relationType string) error
+}
+
+// productRelationRepository implements ProductRelationRepository interface with PostgreSQL
+type productRelationRepository struct {
+	db DBTX
+}
+
+// NewProductRelationRepository creates a new product relation repository instance
+func NewProductRelationRepository(db DBTX) ProductRelationRepository {
+	return &productRelationRepository{db: db}
+}
+
+// GetByProductID returns the relations of a product, optionally filtered by
+// type, joined with the related product's current name, price and stock
+func (r *productRelationRepository) GetByProductID(ctx context.Context, productID int, relationType string) ([]models.ProductRelation, error) {
+	where := "WHERE pr.product_id = $1"
+	args := []interface{}{productID}
+	if relationType != "" {
+		where += " AND pr.relation_type = $2"
+		args = append(args, relationType)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.product_id, pr.related_product_id, pr.relation_type,
+		       p.name, p.price, p.stock, p.is_active, pr.created_at
+		FROM product_relations pr
+		JOIN products p ON p.id = pr.related_product_id
+		%s
+		ORDER BY pr.relation_type, p.stock DESC, p.name
+	`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	relations := make([]models.ProductRelation, 0)
+	for rows.Next() {
+		var rel models.ProductRelation
+		err := rows.Scan(
+			&rel.ProductID, &rel.RelatedProductID, &rel.Type,
+			&rel.Name, &rel.Price, &rel.Stock, &rel.IsActive, &rel.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, rel)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return relations, nil
+}
+
+// Create links two products with the given relation type. Re-adding an
+// existing relation is a no-op that returns the current row.
+func (r *productRelationRepository) Create(ctx context.Context, productID, relatedProductID int, relationType string) (*models.ProductRelation, error) {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO product_relations (product_id, related_product_id, relation_type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id, related_product_id, relation_type) DO NOTHING
+	`, productID, relatedProductID, relationType)
+	if err != nil {
+		return nil, err
+	}
+
+	var rel models.ProductRelation
+	err = r.db.QueryRowContext(ctx, `
+		SELECT pr.product_id, pr.related_product_id, pr.relation_type,
+		       p.name, p.price, p.stock, p.is_active, pr.created_at
+		FROM product_relations pr
+		JOIN products p ON p.id = pr.related_product_id
+		WHERE pr.product_id = $1 AND pr.related_product_id = $2 AND pr.relation_type = $3
+	`, productID, relatedProductID, relationType).Scan(
+		&rel.ProductID, &rel.RelatedProductID, &rel.Type,
+		&rel.Name, &rel.Price, &rel.Stock, &rel.IsActive, &rel.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// Delete removes a product relation
+func (r *productRelationRepository) Delete(ctx context.Context, productID, relatedProductID int, relationType string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM product_relations
+		WHERE product_id = $1 AND related_product_id = $2 AND relation_type = $3
+	`, productID, relatedProductID, relationType)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
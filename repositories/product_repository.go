@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"math"
@@ -10,47 +11,71 @@ import (
 
 // ProductRepository defines the interface for product data access
 type ProductRepository interface {
-	GetAll(params models.ProductListParams) (*models.PaginatedProducts, error)
-	GetByID(id int) (*models.Product, error)
-	GetByCategoryID(categoryID int) ([]models.Product, error)
-	Create(product models.Product) (*models.Product, error)
-	Update(id int, product models.Product) (*models.Product, error)
-	Delete(id int) error
+	GetAll(ctx context.Context, params models.ProductListParams) (*models.PaginatedProducts, error)
+	GetByID(ctx context.Context, id int) (*models.Product, error)
+	GetByIDs(ctx context.Context, ids []int) ([]models.Product, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Product, error)
+	GetBySKU(ctx context.Context, sku string) ([]models.Product, error)
+	EachListing(ctx context.Context, fn func(models.Product) error) error
+	GetByCategoryID(ctx context.Context, categoryID int) ([]models.Product, error)
+	GetByCategoryIDs(ctx context.Context, categoryIDs []int) ([]models.Product, error)
+	Create(ctx context.Context, product models.Product, actor models.Actor) (*models.Product, error)
+	Update(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.Product, error)
+	Delete(ctx context.Context, id int) error
+	GetLowStock(ctx context.Context, categoryID *int) ([]models.LowStockProduct, error)
+	GetSalesVelocity(ctx context.Context, days int, categoryID *int) ([]models.ReorderSuggestion, error)
 }
 
 // productRepository implements ProductRepository interface with PostgreSQL
 type productRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewProductRepository creates a new product repository instance
-func NewProductRepository(db *sql.DB) ProductRepository {
+func NewProductRepository(db DBTX) ProductRepository {
 	return &productRepository{db: db}
 }
 
 // productColumns is the standard set of columns selected for product queries
 const productColumns = `
-	p.id, p.name, p.price, p.stock,
+	p.id, p.name, p.slug, p.price, p.stock, p.min_stock,
 	p.sku, p.image_url, p.unit, p.is_active,
 	p.category_id,
 	COALESCE(c.name, '') as category_name,
+	p.supplier_id, p.is_consignment, p.consignment_cost, p.cost_price,
 	p.created_at, p.updated_at
 `
 
+// productListingColumns selects the productColumns from the product_listings
+// read model, which carries the category name so listings need no join
+const productListingColumns = `
+	l.product_id, l.name, l.slug, l.price, l.stock, l.min_stock,
+	l.sku, l.image_url, l.unit, l.is_active,
+	l.category_id, l.category_name,
+	l.supplier_id, l.is_consignment, l.consignment_cost, l.cost_price,
+	l.created_at, l.updated_at
+`
+
 // scanProduct scans a row into a Product struct
 func scanProduct(scanner interface{ Scan(dest ...interface{}) error }) (*models.Product, error) {
 	var prod models.Product
 	err := scanner.Scan(
 		&prod.ID,
 		&prod.Name,
+		&prod.Slug,
 		&prod.Price,
 		&prod.Stock,
+		&prod.MinStock,
 		&prod.SKU,
 		&prod.ImageURL,
 		&prod.Unit,
 		&prod.IsActive,
 		&prod.CategoryID,
 		&prod.CategoryName,
+		&prod.SupplierID,
+		&prod.IsConsignment,
+		&prod.ConsignmentCost,
+		&prod.CostPrice,
 		&prod.CreatedAt,
 		&prod.UpdatedAt,
 	)
@@ -60,8 +85,9 @@ func scanProduct(scanner interface{ Scan(dest ...interface{}) error }) (*models.
 	return &prod, nil
 }
 
-// GetAll returns paginated products with optional search and category filter
-func (r *productRepository) GetAll(params models.ProductListParams) (*models.PaginatedProducts, error) {
+// GetAll returns paginated products with optional search and category
+// filter, read from the product_listings read model
+func (r *productRepository) GetAll(ctx context.Context, params models.ProductListParams) (*models.PaginatedProducts, error) {
 	// Defaults
 	if params.Page <= 0 {
 		params.Page = 1
@@ -76,21 +102,21 @@ func (r *productRepository) GetAll(params models.ProductListParams) (*models.Pag
 	argIdx := 1
 
 	if params.Search != "" {
-		where += fmt.Sprintf(" AND p.name ILIKE $%d", argIdx)
+		where += fmt.Sprintf(" AND l.name ILIKE $%d", argIdx)
 		args = append(args, "%"+params.Search+"%")
 		argIdx++
 	}
 
 	if params.CategoryID != nil {
-		where += fmt.Sprintf(" AND p.category_id = $%d", argIdx)
+		where += fmt.Sprintf(" AND l.category_id = $%d", argIdx)
 		args = append(args, *params.CategoryID)
 		argIdx++
 	}
 
 	// Count total
-	countQuery := "SELECT COUNT(*) FROM products p" + where
+	countQuery := "SELECT COUNT(*) FROM product_listings l" + where
 	var total int
-	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, err
 	}
 
@@ -98,15 +124,14 @@ func (r *productRepository) GetAll(params models.ProductListParams) (*models.Pag
 	offset := (params.Page - 1) * params.Limit
 	query := fmt.Sprintf(`
 		SELECT %s
-		FROM products p
-		LEFT JOIN categories c ON p.category_id = c.id
+		FROM product_listings l
 		%s
-		ORDER BY p.id DESC
+		ORDER BY l.product_id DESC
 		LIMIT $%d OFFSET $%d
-	`, productColumns, where, argIdx, argIdx+1)
+	`, productListingColumns, where, argIdx, argIdx+1)
 	args = append(args, params.Limit, offset)
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -135,8 +160,30 @@ func (r *productRepository) GetAll(params models.ProductListParams) (*models.Pag
 	}, nil
 }
 
+// EachListing calls fn with every product of the product_listings read model
+// in ID order, one row at a time, so exports of large catalogs are not held
+// in memory. It stops at the first error fn returns.
+func (r *productRepository) EachListing(ctx context.Context, fn func(models.Product) error) error {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+productListingColumns+` FROM product_listings l ORDER BY l.product_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		prod, err := scanProduct(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(*prod); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // GetByID returns a product by its ID with category name (LEFT JOIN)
-func (r *productRepository) GetByID(id int) (*models.Product, error) {
+func (r *productRepository) GetByID(ctx context.Context, id int) (*models.Product, error) {
 	query := fmt.Sprintf(`
 		SELECT %s
 		FROM products p
@@ -144,7 +191,59 @@ func (r *productRepository) GetByID(id int) (*models.Product, error) {
 		WHERE p.id = $1
 	`, productColumns)
 
-	prod, err := scanProduct(r.db.QueryRow(query, id))
+	prod, err := scanProduct(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return prod, nil
+}
+
+// GetByIDs returns the products with any of the given IDs in ID order; IDs
+// without a product are left out
+func (r *productRepository) GetByIDs(ctx context.Context, ids []int) ([]models.Product, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE p.id = ANY($1)
+		ORDER BY p.id
+	`, productColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []models.Product
+	for rows.Next() {
+		prod, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *prod)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetBySlug returns a product by its slug with category name (LEFT JOIN)
+func (r *productRepository) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE p.slug = $1
+	`, productColumns)
+
+	prod, err := scanProduct(r.db.QueryRowContext(ctx, query, slug))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -154,32 +253,109 @@ func (r *productRepository) GetByID(id int) (*models.Product, error) {
 	return prod, nil
 }
 
-// Create adds a new product and returns it
-func (r *productRepository) Create(product models.Product) (*models.Product, error) {
+// GetBySKU returns the products with a SKU. SKUs are not enforced unique,
+// so callers decide what several matches mean.
+func (r *productRepository) GetBySKU(ctx context.Context, sku string) ([]models.Product, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE p.sku = $1
+		ORDER BY p.id
+	`, productColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, sku)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.Product, 0)
+	for rows.Next() {
+		prod, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *prod)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// Create adds a new product and returns it, recording its opening stock in
+// the stock ledger and its opening price in the price history. Opening stock
+// is layered at the cost price.
+func (r *productRepository) Create(ctx context.Context, product models.Product, actor models.Actor) (*models.Product, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	slug, err := uniqueSlug(ctx, tx, "products", product.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO products (name, price, stock, sku, image_url, unit, is_active, category_id) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
-		RETURNING id, name, price, stock, sku, image_url, unit, is_active, category_id, created_at, updated_at
+		INSERT INTO products (name, slug, price, stock, min_stock, sku, image_url, unit, is_active, category_id,
+		                      supplier_id, is_consignment, consignment_cost, cost_price) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) 
+		RETURNING id, name, slug, price, stock, min_stock, sku, image_url, unit, is_active, category_id,
+		          supplier_id, is_consignment, consignment_cost, cost_price, created_at, updated_at
 	`
 	var prod models.Product
-	err := r.db.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		query,
-		product.Name, product.Price, product.Stock,
+		product.Name, slug, product.Price, product.Stock, product.MinStock,
 		product.SKU, product.ImageURL, product.Unit, product.IsActive,
-		product.CategoryID,
+		product.CategoryID, product.SupplierID, product.IsConsignment, product.ConsignmentCost, product.CostPrice,
 	).Scan(
-		&prod.ID, &prod.Name, &prod.Price, &prod.Stock,
+		&prod.ID, &prod.Name, &prod.Slug, &prod.Price, &prod.Stock, &prod.MinStock,
 		&prod.SKU, &prod.ImageURL, &prod.Unit, &prod.IsActive,
-		&prod.CategoryID, &prod.CreatedAt, &prod.UpdatedAt,
+		&prod.CategoryID, &prod.SupplierID, &prod.IsConsignment, &prod.ConsignmentCost, &prod.CostPrice,
+		&prod.CreatedAt, &prod.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = recordStockMovement(ctx, tx, models.StockMovement{
+		ProductID:     prod.ID,
+		QuantityDelta: prod.Stock,
+		BalanceAfter:  prod.Stock,
+		Reason:        models.StockReasonInitial,
+		ReferenceType: models.StockRefProduct,
+		ReferenceID:   &prod.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordPriceChange(ctx, tx, models.PriceChange{
+		ProductID: prod.ID,
+		NewPrice:  prod.Price,
+		Source:    models.PriceSourceProduct,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := openCostLayer(ctx, tx, prod.ID, models.CostSourceOpening, prod.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	// Fetch the category name
 	if prod.CategoryID != nil {
 		var categoryName string
-		err = r.db.QueryRow(`SELECT name FROM categories WHERE id = $1`, *prod.CategoryID).Scan(&categoryName)
+		err = r.db.QueryRowContext(ctx, `SELECT name FROM categories WHERE id = $1`, *prod.CategoryID).Scan(&categoryName)
 		if err == nil {
 			prod.CategoryName = categoryName
 		}
@@ -188,25 +364,51 @@ func (r *productRepository) Create(product models.Product) (*models.Product, err
 	return &prod, nil
 }
 
-// Update modifies an existing product
-func (r *productRepository) Update(id int, product models.Product) (*models.Product, error) {
+// Update modifies an existing product. A change to the stock level is
+// recorded in the stock ledger as an adjustment and a change to the price in
+// the price history.
+func (r *productRepository) Update(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.Product, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var oldStock, oldPrice int
+	err = tx.QueryRowContext(ctx, `SELECT stock, price FROM products WHERE id = $1 FOR UPDATE`, id).Scan(&oldStock, &oldPrice)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	slug, err := uniqueSlug(ctx, tx, "products", product.Name, id)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE products 
-		SET name = $1, price = $2, stock = $3, sku = $4, image_url = $5, 
-		    unit = $6, is_active = $7, category_id = $8, updated_at = $9
-		WHERE id = $10 
-		RETURNING id, name, price, stock, sku, image_url, unit, is_active, category_id, created_at, updated_at
+		SET name = $1, slug = $2, price = $3, stock = $4, min_stock = $5, sku = $6, image_url = $7, 
+		    unit = $8, is_active = $9, category_id = $10, supplier_id = $11, is_consignment = $12,
+		    consignment_cost = $13, cost_price = $14, updated_at = $15
+		WHERE id = $16 
+		RETURNING id, name, slug, price, stock, min_stock, sku, image_url, unit, is_active, category_id,
+		          supplier_id, is_consignment, consignment_cost, cost_price, created_at, updated_at
 	`
 	var prod models.Product
-	err := r.db.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		query,
-		product.Name, product.Price, product.Stock,
+		product.Name, slug, product.Price, product.Stock, product.MinStock,
 		product.SKU, product.ImageURL, product.Unit, product.IsActive,
-		product.CategoryID, time.Now(), id,
+		product.CategoryID, product.SupplierID, product.IsConsignment, product.ConsignmentCost, product.CostPrice,
+		time.Now(), id,
 	).Scan(
-		&prod.ID, &prod.Name, &prod.Price, &prod.Stock,
+		&prod.ID, &prod.Name, &prod.Slug, &prod.Price, &prod.Stock, &prod.MinStock,
 		&prod.SKU, &prod.ImageURL, &prod.Unit, &prod.IsActive,
-		&prod.CategoryID, &prod.CreatedAt, &prod.UpdatedAt,
+		&prod.CategoryID, &prod.SupplierID, &prod.IsConsignment, &prod.ConsignmentCost, &prod.CostPrice,
+		&prod.CreatedAt, &prod.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -215,10 +417,43 @@ func (r *productRepository) Update(id int, product models.Product) (*models.Prod
 		return nil, err
 	}
 
+	err = recordStockMovement(ctx, tx, models.StockMovement{
+		ProductID:     id,
+		QuantityDelta: prod.Stock - oldStock,
+		BalanceAfter:  prod.Stock,
+		Reason:        models.StockReasonAdjustment,
+		ReferenceType: models.StockRefProduct,
+		ReferenceID:   &id,
+		Note:          "product update",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordPriceChange(ctx, tx, models.PriceChange{
+		ProductID: id,
+		OldPrice:  &oldPrice,
+		NewPrice:  prod.Price,
+		Source:    models.PriceSourceProduct,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock the update added, or stock that had no cost until now, is
+	// layered at the cost price
+	if err := openCostLayer(ctx, tx, id, models.CostSourceOpening, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	// Fetch the category name
 	if prod.CategoryID != nil {
 		var categoryName string
-		err = r.db.QueryRow(`SELECT name FROM categories WHERE id = $1`, *prod.CategoryID).Scan(&categoryName)
+		err = r.db.QueryRowContext(ctx, `SELECT name FROM categories WHERE id = $1`, *prod.CategoryID).Scan(&categoryName)
 		if err == nil {
 			prod.CategoryName = categoryName
 		}
@@ -228,9 +463,9 @@ func (r *productRepository) Update(id int, product models.Product) (*models.Prod
 }
 
 // Delete removes a product by its ID
-func (r *productRepository) Delete(id int) error {
+func (r *productRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM products WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -248,7 +483,7 @@ func (r *productRepository) Delete(id int) error {
 }
 
 // GetByCategoryID returns all products belonging to a specific category
-func (r *productRepository) GetByCategoryID(categoryID int) ([]models.Product, error) {
+func (r *productRepository) GetByCategoryID(ctx context.Context, categoryID int) ([]models.Product, error) {
 	query := fmt.Sprintf(`
 		SELECT %s
 		FROM products p
@@ -257,7 +492,7 @@ func (r *productRepository) GetByCategoryID(categoryID int) ([]models.Product, e
 		ORDER BY p.id
 	`, productColumns)
 
-	rows, err := r.db.Query(query, categoryID)
+	rows, err := r.db.QueryContext(ctx, query, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -278,3 +513,129 @@ func (r *productRepository) GetByCategoryID(categoryID int) ([]models.Product, e
 
 	return products, nil
 }
+
+// GetByCategoryIDs returns all products belonging to any of the given categories
+func (r *productRepository) GetByCategoryIDs(ctx context.Context, categoryIDs []int) ([]models.Product, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE p.category_id = ANY($1)
+		ORDER BY p.id
+	`, productColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []models.Product
+	for rows.Next() {
+		prod, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *prod)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetLowStock returns active products whose stock is at or below their
+// min_stock threshold, largest shortfall first
+func (r *productRepository) GetLowStock(ctx context.Context, categoryID *int) ([]models.LowStockProduct, error) {
+	where := "WHERE p.is_active = true AND p.stock <= p.min_stock"
+	args := []interface{}{}
+	if categoryID != nil {
+		where += " AND p.category_id = $1"
+		args = append(args, *categoryID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT p.id, p.name, COALESCE(p.sku, ''), COALESCE(p.unit, ''), p.category_id,
+		       COALESCE(c.name, ''), p.stock, p.min_stock
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		%s
+		ORDER BY p.min_stock - p.stock DESC, p.name
+	`, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.LowStockProduct, 0)
+	for rows.Next() {
+		var p models.LowStockProduct
+		err := rows.Scan(
+			&p.ProductID, &p.Name, &p.SKU, &p.Unit, &p.CategoryID,
+			&p.CategoryName, &p.Stock, &p.MinStock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		p.Shortfall = p.MinStock - p.Stock
+		products = append(products, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetSalesVelocity returns every active product with the units sold by
+// non-voided transactions in the last given number of days
+func (r *productRepository) GetSalesVelocity(ctx context.Context, days int, categoryID *int) ([]models.ReorderSuggestion, error) {
+	where := "WHERE p.is_active = true"
+	args := []interface{}{days}
+	if categoryID != nil {
+		where += " AND p.category_id = $2"
+		args = append(args, *categoryID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT p.id, p.name, COALESCE(p.sku, ''), COALESCE(p.unit, ''), p.category_id,
+		       COALESCE(c.name, ''), p.supplier_id, p.stock, p.min_stock,
+		       COALESCE(sold.qty, 0)
+		FROM products p
+		LEFT JOIN categories c ON p.category_id = c.id
+		LEFT JOIN (
+			SELECT td.product_id, SUM(td.quantity) AS qty
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE t.status = 'active' AND t.created_at >= NOW() - make_interval(days => $1)
+			GROUP BY td.product_id
+		) sold ON sold.product_id = p.id
+		%s
+		ORDER BY p.id
+	`, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.ReorderSuggestion, 0)
+	for rows.Next() {
+		var p models.ReorderSuggestion
+		err := rows.Scan(
+			&p.ProductID, &p.Name, &p.SKU, &p.Unit, &p.CategoryID,
+			&p.CategoryName, &p.SupplierID, &p.Stock, &p.MinStock,
+			&p.UnitsSold,
+		)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// PromotionRepository defines the interface for promotion data access
+type PromotionRepository interface {
+	GetAll(ctx context.Context) ([]models.Promotion, error)
+	GetActive(ctx context.Context) ([]models.Promotion, error)
+	GetByID(ctx context.Context, id int) (*models.Promotion, error)
+	Create(ctx context.Context, promotion models.Promotion) (*models.Promotion, error)
+	Update(ctx context.Context, id int, promotion models.Promotion) (*models.Promotion, error)
+	Delete(ctx context.Context, id int) error
+	GetPerformance(ctx context.Context, startDate, endDate string, storeID int) ([]models.PromotionPerformance, error)
+}
+
+// promotionRepository implements PromotionRepository interface with PostgreSQL
+type promotionRepository struct {
+	db DBTX
+}
+
+// NewPromotionRepository creates a new promotion repository instance
+func NewPromotionRepository(db DBTX) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+// promotionColumns is the standard set of columns selected for promotion queries
+const promotionColumns = `
+	id, name, type, product_id, category_id,
+	buy_qty, get_qty, bundle_qty, bundle_price, discount_percent,
+	starts_at, ends_at, is_active, created_at, updated_at
+`
+
+// scanPromotion scans a row into a Promotion struct
+func scanPromotion(scanner interface{ Scan(dest ...interface{}) error }) (*models.Promotion, error) {
+	var promo models.Promotion
+	err := scanner.Scan(
+		&promo.ID, &promo.Name, &promo.Type, &promo.ProductID, &promo.CategoryID,
+		&promo.BuyQty, &promo.GetQty, &promo.BundleQty, &promo.BundlePrice, &promo.DiscountPercent,
+		&promo.StartsAt, &promo.EndsAt, &promo.IsActive, &promo.CreatedAt, &promo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+// queryPromotions runs a promotion SELECT and collects the rows
+func (r *promotionRepository) queryPromotions(ctx context.Context, query string, args ...interface{}) ([]models.Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := make([]models.Promotion, 0)
+	for rows.Next() {
+		promo, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, *promo)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// GetAll returns all promotions
+func (r *promotionRepository) GetAll(ctx context.Context) ([]models.Promotion, error) {
+	return r.queryPromotions(ctx, `SELECT `+promotionColumns+` FROM promotions ORDER BY id DESC`)
+}
+
+// GetActive returns promotions that are enabled and within their validity window
+func (r *promotionRepository) GetActive(ctx context.Context) ([]models.Promotion, error) {
+	return r.queryPromotions(ctx, `
+		SELECT ` + promotionColumns + `
+		FROM promotions
+		WHERE is_active = true
+		  AND (starts_at IS NULL OR starts_at <= NOW())
+		  AND (ends_at IS NULL OR ends_at >= NOW())
+		ORDER BY id
+	`)
+}
+
+// GetByID returns a promotion by its ID
+func (r *promotionRepository) GetByID(ctx context.Context, id int) (*models.Promotion, error) {
+	promo, err := scanPromotion(r.db.QueryRowContext(ctx, `SELECT `+promotionColumns+` FROM promotions WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return promo, nil
+}
+
+// Create adds a new promotion and returns it
+func (r *promotionRepository) Create(ctx context.Context, promotion models.Promotion) (*models.Promotion, error) {
+	query := `
+		INSERT INTO promotions (name, type, product_id, category_id, buy_qty, get_qty,
+		                        bundle_qty, bundle_price, discount_percent, starts_at, ends_at, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ` + promotionColumns
+	return scanPromotion(r.db.QueryRowContext(ctx,
+		query,
+		promotion.Name, promotion.Type, promotion.ProductID, promotion.CategoryID,
+		promotion.BuyQty, promotion.GetQty, promotion.BundleQty, promotion.BundlePrice,
+		promotion.DiscountPercent, promotion.StartsAt, promotion.EndsAt, promotion.IsActive,
+	))
+}
+
+// Update modifies an existing promotion
+func (r *promotionRepository) Update(ctx context.Context, id int, promotion models.Promotion) (*models.Promotion, error) {
+	query := `
+		UPDATE promotions
+		SET name = $1, type = $2, product_id = $3, category_id = $4, buy_qty = $5, get_qty = $6,
+		    bundle_qty = $7, bundle_price = $8, discount_percent = $9, starts_at = $10,
+		    ends_at = $11, is_active = $12, updated_at = $13
+		WHERE id = $14
+		RETURNING ` + promotionColumns
+	promo, err := scanPromotion(r.db.QueryRowContext(ctx,
+		query,
+		promotion.Name, promotion.Type, promotion.ProductID, promotion.CategoryID,
+		promotion.BuyQty, promotion.GetQty, promotion.BundleQty, promotion.BundlePrice,
+		promotion.DiscountPercent, promotion.StartsAt, promotion.EndsAt, promotion.IsActive,
+		time.Now(), id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return promo, nil
+}
+
+// Delete removes a promotion by its ID
+func (r *promotionRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM promotions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetPerformance returns the performance of every promotion over its
+// validity window (starts_at or creation to ends_at or now), clipped to the
+// dates when given, most used first. Promotions whose window does not overlap
+// the dates are left out. Baseline units are the units of the targeted
+// product or category sold in the same length of time just before the window.
+func (r *promotionRepository) GetPerformance(ctx context.Context, startDate, endDate string, storeID int) ([]models.PromotionPerformance, error) {
+	var start, end interface{}
+	if startDate != "" {
+		start = startDate
+	}
+	if endDate != "" {
+		end = endDate
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		WITH windows AS (
+			SELECT p.id, p.name, p.type, p.is_active, p.product_id, p.category_id,
+			       GREATEST(COALESCE(p.starts_at, p.created_at), COALESCE($1::date::timestamp, '-infinity'::timestamp)) AS win_start,
+			       LEAST(COALESCE(p.ends_at, LOCALTIMESTAMP), COALESCE(($2::date + 1)::timestamp, 'infinity'::timestamp), LOCALTIMESTAMP) AS win_end
+			FROM promotions p
+		), promo_lines AS (
+			SELECT w.id AS promotion_id, t.id AS transaction_id, t.total_amount, tdp.discount, td.quantity
+			FROM windows w
+			JOIN transaction_detail_promotions tdp ON tdp.promotion_id = w.id
+			JOIN transaction_details td ON td.id = tdp.transaction_detail_id
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE t.status = 'active' AND t.created_at >= w.win_start AND t.created_at < w.win_end
+			  AND ($3 = 0 OR t.store_id = $3)
+		), promo_usage AS (
+			SELECT promotion_id, COUNT(DISTINCT transaction_id) AS usage_count,
+			       SUM(discount) AS discount_given, SUM(quantity) AS promoted_units
+			FROM promo_lines
+			GROUP BY promotion_id
+		), promo_attached AS (
+			SELECT promotion_id, SUM(total_amount) AS revenue
+			FROM (SELECT DISTINCT promotion_id, transaction_id, total_amount FROM promo_lines) used
+			GROUP BY promotion_id
+		), promo_targets AS (
+			SELECT w.id AS promotion_id,
+			       SUM(td.quantity) FILTER (WHERE t.created_at >= w.win_start) AS target_units,
+			       SUM(td.quantity) FILTER (WHERE t.created_at < w.win_start) AS baseline_units
+			FROM windows w
+			JOIN transactions t ON t.status = 'active'
+			 AND t.created_at >= w.win_start - (w.win_end - w.win_start) AND t.created_at < w.win_end
+			 AND ($3 = 0 OR t.store_id = $3)
+			JOIN transaction_details td ON td.transaction_id = t.id
+			JOIN products pr ON pr.id = td.product_id
+			WHERE w.win_end > w.win_start AND (td.product_id = w.product_id OR pr.category_id = w.category_id)
+			GROUP BY w.id
+		)
+		SELECT w.id, w.name, w.type, w.is_active, w.win_start, w.win_end,
+		       COALESCE(u.usage_count, 0), COALESCE(u.discount_given, 0), COALESCE(u.promoted_units, 0),
+		       COALESCE(g.target_units, 0), COALESCE(g.baseline_units, 0), COALESCE(a.revenue, 0)
+		FROM windows w
+		LEFT JOIN promo_usage u ON u.promotion_id = w.id
+		LEFT JOIN promo_attached a ON a.promotion_id = w.id
+		LEFT JOIN promo_targets g ON g.promotion_id = w.id
+		WHERE w.win_end > w.win_start
+		ORDER BY COALESCE(u.usage_count, 0) DESC, w.id
+	`, start, end, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := make([]models.PromotionPerformance, 0)
+	for rows.Next() {
+		var p models.PromotionPerformance
+		if err := rows.Scan(&p.PromotionID, &p.Name, &p.Type, &p.IsActive, &p.WindowStart, &p.WindowEnd,
+			&p.UsageCount, &p.DiscountGiven, &p.PromotedUnits,
+			&p.TargetUnits, &p.BaselineUnits, &p.AttachedRevenue); err != nil {
+			return nil, err
+		}
+		p.IncrementalUnits = p.TargetUnits - p.BaselineUnits
+		promotions = append(promotions, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
@@ -0,0 +1,383 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+)
+
+// PurchaseOrderRepository defines the interface for purchase order data access
+type PurchaseOrderRepository interface {
+	GetAll(ctx context.Context, status string, supplierID int) ([]models.PurchaseOrder, error)
+	GetByID(ctx context.Context, id int) (*models.PurchaseOrder, error)
+	Create(ctx context.Context, order models.PurchaseOrder, items []models.PurchaseOrderItem) (*models.PurchaseOrder, error)
+	Receive(ctx context.Context, id int, receipt models.GoodsReceipt) (*models.GoodsReceipt, error)
+	Cancel(ctx context.Context, id int) (*models.PurchaseOrder, error)
+}
+
+// purchaseOrderRepository implements PurchaseOrderRepository interface with PostgreSQL
+type purchaseOrderRepository struct {
+	db DBTX
+}
+
+// NewPurchaseOrderRepository creates a new purchase order repository instance
+func NewPurchaseOrderRepository(db DBTX) PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db}
+}
+
+// purchaseOrderColumns is the standard set of columns selected for purchase order queries
+const purchaseOrderColumns = `
+	po.id, po.supplier_id, COALESCE(s.name, ''), po.status, po.note,
+	(SELECT COALESCE(SUM(i.quantity_ordered * i.unit_cost), 0) FROM purchase_order_items i WHERE i.purchase_order_id = po.id),
+	COALESCE(po.created_by, 0), po.created_at, po.updated_at
+`
+
+// scanPurchaseOrder scans a row into a PurchaseOrder struct
+func scanPurchaseOrder(scanner interface{ Scan(dest ...interface{}) error }) (*models.PurchaseOrder, error) {
+	var po models.PurchaseOrder
+	err := scanner.Scan(
+		&po.ID, &po.SupplierID, &po.SupplierName, &po.Status, &po.Note,
+		&po.TotalCost, &po.CreatedBy, &po.CreatedAt, &po.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &po, nil
+}
+
+// GetAll returns purchase orders, newest first, optionally filtered by status
+// and supplier (items and receipts are not loaded)
+func (r *purchaseOrderRepository) GetAll(ctx context.Context, status string, supplierID int) ([]models.PurchaseOrder, error) {
+	where := "WHERE 1=1"
+	args := []interface{}{}
+	if status != "" {
+		args = append(args, status)
+		where += fmt.Sprintf(" AND po.status = $%d", len(args))
+	}
+	if supplierID > 0 {
+		args = append(args, supplierID)
+		where += fmt.Sprintf(" AND po.supplier_id = $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM purchase_orders po
+		LEFT JOIN suppliers s ON s.id = po.supplier_id
+		%s
+		ORDER BY po.id DESC
+	`, purchaseOrderColumns, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]models.PurchaseOrder, 0)
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, *po)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// GetByID returns a purchase order with its items and goods receipts
+func (r *purchaseOrderRepository) GetByID(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	po, err := scanPurchaseOrder(r.db.QueryRowContext(ctx, `
+		SELECT `+purchaseOrderColumns+`
+		FROM purchase_orders po
+		LEFT JOIN suppliers s ON s.id = po.supplier_id
+		WHERE po.id = $1
+	`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if po.Items, err = r.getItems(ctx, id); err != nil {
+		return nil, err
+	}
+	if po.Receipts, err = r.getReceipts(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// getItems loads the items of a purchase order in insertion order
+func (r *purchaseOrderRepository) getItems(ctx context.Context, orderID int) ([]models.PurchaseOrderItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.id, i.purchase_order_id, i.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''),
+		       i.quantity_ordered, i.quantity_received, i.unit_cost
+		FROM purchase_order_items i
+		LEFT JOIN products p ON p.id = i.product_id
+		WHERE i.purchase_order_id = $1
+		ORDER BY i.id
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.PurchaseOrderItem, 0)
+	for rows.Next() {
+		var item models.PurchaseOrderItem
+		err := rows.Scan(
+			&item.ID, &item.PurchaseOrderID, &item.ProductID, &item.ProductName, &item.SKU,
+			&item.QuantityOrdered, &item.QuantityReceived, &item.UnitCost,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// getReceipts loads the goods receipts of a purchase order with their lines, oldest first
+func (r *purchaseOrderRepository) getReceipts(ctx context.Context, orderID int) ([]models.GoodsReceipt, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT gr.id, gr.purchase_order_id, gr.note, COALESCE(gr.received_by, 0), gr.created_at,
+		       l.id, l.product_id, COALESCE(p.name, ''), l.quantity, l.unit_cost
+		FROM goods_receipts gr
+		JOIN goods_receipt_lines l ON l.goods_receipt_id = gr.id
+		LEFT JOIN products p ON p.id = l.product_id
+		WHERE gr.purchase_order_id = $1
+		ORDER BY gr.id, l.id
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]models.GoodsReceipt, 0)
+	for rows.Next() {
+		var gr models.GoodsReceipt
+		var line models.GoodsReceiptLine
+		err := rows.Scan(
+			&gr.ID, &gr.PurchaseOrderID, &gr.Note, &gr.ReceivedBy, &gr.CreatedAt,
+			&line.ID, &line.ProductID, &line.ProductName, &line.Quantity, &line.UnitCost,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Rows are ordered by receipt, so a new receipt ID starts a new receipt
+		if n := len(receipts); n == 0 || receipts[n-1].ID != gr.ID {
+			gr.Lines = make([]models.GoodsReceiptLine, 0)
+			receipts = append(receipts, gr)
+		}
+		current := &receipts[len(receipts)-1]
+		current.Lines = append(current.Lines, line)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+// Create inserts a purchase order and its items in one database transaction
+func (r *purchaseOrderRepository) Create(ctx context.Context, order models.PurchaseOrder, items []models.PurchaseOrderItem) (*models.PurchaseOrder, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO purchase_orders (supplier_id, status, note, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, 0))
+		RETURNING id
+	`, order.SupplierID, models.PurchaseOrderOpen, order.Note, order.CreatedBy).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity_ordered, unit_cost)
+			VALUES ($1, $2, $3, $4)
+		`, id, item.ProductID, item.QuantityOrdered, item.UnitCost)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Receive books a delivery against a purchase order in one database
+// transaction: every line increments product stock, is written to the stock
+// ledger as a received_goods restock and is stored with its cost price,
+// which also opens a FIFO cost layer for the received quantity and becomes
+// the product's cost_price.
+// Receiving more than is still outstanding is rejected. The order becomes received once
+// every item is complete, partially_received otherwise.
+func (r *purchaseOrderRepository) Receive(ctx context.Context, id int, receipt models.GoodsReceipt) (*models.GoodsReceipt, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, helpers.NewNotFoundError("purchase order not found")
+		}
+		return nil, err
+	}
+	if status != models.PurchaseOrderOpen && status != models.PurchaseOrderPartiallyReceived {
+		return nil, helpers.NewConflictError(fmt.Sprintf("purchase order is already %s", status))
+	}
+
+	var receiptID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO goods_receipts (purchase_order_id, note, received_by)
+		VALUES ($1, $2, NULLIF($3, 0))
+		RETURNING id, created_at
+	`, id, receipt.Note, receipt.ReceivedBy).Scan(&receiptID, &receipt.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var actor *int
+	if receipt.ReceivedBy > 0 {
+		actor = &receipt.ReceivedBy
+	}
+
+	for i, line := range receipt.Lines {
+		var itemID, ordered, received int
+		err = tx.QueryRowContext(ctx, `
+			SELECT id, quantity_ordered, quantity_received
+			FROM purchase_order_items
+			WHERE purchase_order_id = $1 AND product_id = $2
+			FOR UPDATE
+		`, id, line.ProductID).Scan(&itemID, &ordered, &received)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, helpers.NewValidationError(fmt.Sprintf("product %d is not part of this purchase order", line.ProductID))
+			}
+			return nil, err
+		}
+		if received+line.Quantity > ordered {
+			return nil, helpers.NewValidationError(fmt.Sprintf("received quantity for product %d exceeds the outstanding %d", line.ProductID, ordered-received))
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE purchase_order_items SET quantity_received = quantity_received + $1 WHERE id = $2`,
+			line.Quantity, itemID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// The cost of the latest receipt becomes the product's cost price;
+		// consigned products keep theirs, their cost is the consignment cost
+		var newStock int
+		err = tx.QueryRowContext(ctx, `
+			UPDATE products
+			SET stock = stock + $1,
+			    cost_price = CASE WHEN is_consignment THEN cost_price ELSE $3 END,
+			    updated_at = NOW()
+			WHERE id = $2
+			RETURNING stock, name
+		`, line.Quantity, line.ProductID, line.UnitCost).Scan(&newStock, &line.ProductName)
+		if err != nil {
+			return nil, err
+		}
+
+		err = recordStockMovement(ctx, tx, models.StockMovement{
+			ProductID:     line.ProductID,
+			QuantityDelta: line.Quantity,
+			BalanceAfter:  newStock,
+			Reason:        models.StockReasonRestock,
+			ReasonCode:    models.AdjustmentReceivedGoods,
+			ReferenceType: models.StockRefPurchaseOrder,
+			ReferenceID:   &id,
+			CreatedBy:     actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO goods_receipt_lines (goods_receipt_id, product_id, quantity, unit_cost)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`, receiptID, line.ProductID, line.Quantity, line.UnitCost).Scan(&line.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = addCostLayer(ctx, tx, line.ProductID, line.Quantity, line.UnitCost, models.CostSourceGoodsReceipt, line.ID)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Lines[i] = line
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE purchase_orders
+		SET status = CASE
+		        WHEN EXISTS (
+		            SELECT 1 FROM purchase_order_items
+		            WHERE purchase_order_id = $1 AND quantity_received < quantity_ordered
+		        ) THEN $2 ELSE $3 END,
+		    updated_at = NOW()
+		WHERE id = $1
+	`, id, models.PurchaseOrderPartiallyReceived, models.PurchaseOrderReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	receipt.ID = receiptID
+	receipt.PurchaseOrderID = id
+	return &receipt, nil
+}
+
+// Cancel closes a purchase order that has not been received yet. Stock that
+// was already received stays. It returns nil when no open or partially
+// received order with that ID exists.
+func (r *purchaseOrderRepository) Cancel(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE purchase_orders SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status IN ($3, $4)
+	`, models.PurchaseOrderCancelled, id, models.PurchaseOrderOpen, models.PurchaseOrderPartiallyReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	return r.GetByID(ctx, id)
+}
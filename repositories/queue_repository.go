@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// QueueRepository defines the interface for pickup queue data access
+type QueueRepository interface {
+	GetStatus(ctx context.Context) (*models.QueueStatus, error)
+	CallNext(ctx context.Context) (*models.QueueStatus, error)
+	Call(ctx context.Context, queueNo int) (*models.QueueStatus, error)
+}
+
+// queueRepository implements QueueRepository interface with PostgreSQL
+type queueRepository struct {
+	db DBTX
+}
+
+// NewQueueRepository creates a new queue repository instance
+func NewQueueRepository(db DBTX) QueueRepository {
+	return &queueRepository{db: db}
+}
+
+// scanQueueStatus scans a queue_sequences row into a QueueStatus
+func scanQueueStatus(scanner interface{ Scan(dest ...interface{}) error }) (*models.QueueStatus, error) {
+	var status models.QueueStatus
+	var seqDate time.Time
+	if err := scanner.Scan(&seqDate, &status.NowServing, &status.LastIssued, &status.UpdatedAt); err != nil {
+		return nil, err
+	}
+	status.Date = seqDate.Format("2006-01-02")
+	status.Waiting = status.LastIssued - status.NowServing
+	if status.Waiting < 0 {
+		status.Waiting = 0
+	}
+	return &status, nil
+}
+
+// GetStatus returns today's queue, or an empty queue if nothing was issued yet
+func (r *queueRepository) GetStatus(ctx context.Context) (*models.QueueStatus, error) {
+	status, err := scanQueueStatus(r.db.QueryRowContext(ctx, `
+		SELECT seq_date, now_serving, last_issued, updated_at
+		FROM queue_sequences WHERE seq_date = CURRENT_DATE
+	`))
+	if err == sql.ErrNoRows {
+		return scanQueueStatus(r.db.QueryRowContext(ctx, `SELECT CURRENT_DATE, 0, 0, NOW()::timestamp`))
+	}
+	return status, err
+}
+
+// CallNext advances now serving by one, never past the last issued number.
+// It returns nil when there is nobody waiting.
+func (r *queueRepository) CallNext(ctx context.Context) (*models.QueueStatus, error) {
+	status, err := scanQueueStatus(r.db.QueryRowContext(ctx, `
+		UPDATE queue_sequences
+		SET now_serving = now_serving + 1, updated_at = NOW()
+		WHERE seq_date = CURRENT_DATE AND now_serving < last_issued
+		RETURNING seq_date, now_serving, last_issued, updated_at
+	`))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return status, err
+}
+
+// Call sets now serving to a number issued today (e.g. to recall a customer).
+// It returns nil when the number has not been issued.
+func (r *queueRepository) Call(ctx context.Context, queueNo int) (*models.QueueStatus, error) {
+	status, err := scanQueueStatus(r.db.QueryRowContext(ctx, `
+		UPDATE queue_sequences
+		SET now_serving = $1, updated_at = NOW()
+		WHERE seq_date = CURRENT_DATE AND $1 <= last_issued
+		RETURNING seq_date, now_serving, last_issued, updated_at
+	`, queueNo))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return status, err
+}
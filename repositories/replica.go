@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"retail-core-api/models"
+)
+
+// replicaTransactionRepository runs the sales reports, the dashboard and the
+// transaction list, which scan many rows but may lag a little, on a read
+// replica. Checkouts, voids and reads of a single transaction, which must see
+// what was just written, stay on the primary.
+type replicaTransactionRepository struct {
+	TransactionRepository
+	replica TransactionRepository
+}
+
+// NewReplicatedTransactionRepository creates a transaction repository that
+// writes to db and reads reports and lists from replica
+func NewReplicatedTransactionRepository(db, replica DBTX) TransactionRepository {
+	return &replicaTransactionRepository{
+		TransactionRepository: NewTransactionRepository(db),
+		replica:               NewTransactionRepository(replica),
+	}
+}
+
+// GetAllTransactions lists transactions on the replica
+func (repo *replicaTransactionRepository) GetAllTransactions(ctx context.Context, params models.TransactionListParams) (*models.PaginatedTransactions, error) {
+	return repo.replica.GetAllTransactions(ctx, params)
+}
+
+// GetDashboardStats computes the dashboard statistics on the replica
+func (repo *replicaTransactionRepository) GetDashboardStats(ctx context.Context, storeID int) (*models.DashboardStats, error) {
+	return repo.replica.GetDashboardStats(ctx, storeID)
+}
+
+// GetDailySalesReport reports today's sales from the replica
+func (repo *replicaTransactionRepository) GetDailySalesReport(ctx context.Context, storeID int) (*models.SalesReport, error) {
+	return repo.replica.GetDailySalesReport(ctx, storeID)
+}
+
+// GetSalesReportByDateRange reports the sales of a period from the replica
+func (repo *replicaTransactionRepository) GetSalesReportByDateRange(ctx context.Context, filter models.ReportFilter) (*models.SalesReport, error) {
+	return repo.replica.GetSalesReportByDateRange(ctx, filter)
+}
+
+// GetReportSummary summarizes the sales of a period on the replica
+func (repo *replicaTransactionRepository) GetReportSummary(ctx context.Context, filter models.ReportFilter) (*models.ReportSummary, error) {
+	return repo.replica.GetReportSummary(ctx, filter)
+}
+
+// GetProductProfits reports the profit per product from the replica
+func (repo *replicaTransactionRepository) GetProductProfits(ctx context.Context, filter models.ReportFilter) ([]models.ProductProfit, error) {
+	return repo.replica.GetProductProfits(ctx, filter)
+}
+
+// GetDailySales reports the sales per day from the replica
+func (repo *replicaTransactionRepository) GetDailySales(ctx context.Context, filter models.ReportFilter) ([]models.DailySales, error) {
+	return repo.replica.GetDailySales(ctx, filter)
+}
+
+// GetCategorySales reports the sales per category from the replica
+func (repo *replicaTransactionRepository) GetCategorySales(ctx context.Context, filter models.ReportFilter) ([]models.CategorySales, error) {
+	return repo.replica.GetCategorySales(ctx, filter)
+}
+
+// GetBestSellers reports the best-selling products from the replica
+func (repo *replicaTransactionRepository) GetBestSellers(ctx context.Context, filter models.ReportFilter, limit int) ([]models.BestSeller, error) {
+	return repo.replica.GetBestSellers(ctx, filter, limit)
+}
+
+// GetHourlySales reports the sales per hour from the replica
+func (repo *replicaTransactionRepository) GetHourlySales(ctx context.Context, filter models.ReportFilter) ([]models.HourlySales, error) {
+	return repo.replica.GetHourlySales(ctx, filter)
+}
+
+// GetSalesTimeSeries buckets the sales of a period on the replica
+func (repo *replicaTransactionRepository) GetSalesTimeSeries(ctx context.Context, filter models.ReportFilter, granularity string) ([]models.SalesBucket, error) {
+	return repo.replica.GetSalesTimeSeries(ctx, filter, granularity)
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"fmt"
+	"retail-core-api/models"
+	"strings"
+)
+
+// reportQuery collects the arguments of a sales report query while its
+// filter fragments are rendered. Each fragment numbers its placeholders after
+// the arguments added before it, so fragments compose in any order and a
+// query may use the same filter twice.
+type reportQuery struct {
+	args []interface{}
+}
+
+// arg adds a query argument and returns its placeholder
+func (q *reportQuery) arg(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// sales returns the conditions selecting the non-voided transactions, aliased
+// t, that match f. With a category it selects the transactions that sold a
+// product of it.
+func (q *reportQuery) sales(f models.ReportFilter) string {
+	conds := q.saleConds(f)
+	if f.CategoryID > 0 {
+		conds = append(conds, `EXISTS (
+			SELECT 1 FROM transaction_details ctd
+			JOIN products cp ON cp.id = ctd.product_id
+			WHERE ctd.transaction_id = t.id AND cp.category_id = `+q.arg(f.CategoryID)+`)`)
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// lines returns the conditions selecting the lines, aliased td, of the
+// non-voided transactions t that match f. With a category it selects only
+// the lines of its products.
+func (q *reportQuery) lines(f models.ReportFilter) string {
+	conds := q.saleConds(f)
+	if f.CategoryID > 0 {
+		conds = append(conds, q.category(f, "td.product_id"))
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// category returns the condition selecting the products in column of the
+// category of f, or TRUE without one. Queries spreading transaction discounts
+// with lineRevenue apply it after the spread, since it needs every line of
+// the transaction.
+func (q *reportQuery) category(f models.ReportFilter, column string) string {
+	if f.CategoryID == 0 {
+		return "TRUE"
+	}
+	return column + " IN (SELECT id FROM products WHERE category_id = " + q.arg(f.CategoryID) + ")"
+}
+
+// saleConds returns the conditions on the transaction t itself
+func (q *reportQuery) saleConds(f models.ReportFilter) []string {
+	conds := []string{"t.status = 'active'"}
+	if f.StartDate != "" {
+		conds = append(conds, "t.created_at::date >= "+q.arg(f.StartDate)+"::date")
+	}
+	if f.EndDate != "" {
+		conds = append(conds, "t.created_at::date <= "+q.arg(f.EndDate)+"::date")
+	}
+	if f.StoreID > 0 {
+		conds = append(conds, "t.store_id = "+q.arg(f.StoreID))
+	}
+	if f.CashierID > 0 {
+		conds = append(conds, "t.cashier_id = "+q.arg(f.CashierID))
+	}
+	if f.PaymentMethod != "" {
+		conds = append(conds, "t.payment_method = "+q.arg(f.PaymentMethod))
+	}
+	return conds
+}
+
+// lineRevenue is the revenue of a line td of transaction t net of the
+// transaction discount, which is spread over the lines in proportion to their
+// subtotal so line revenue adds up to the transaction totals. The window runs
+// over the lines the query selects, so they must be all lines of the
+// transaction.
+const lineRevenue = `td.subtotal - COALESCE(ROUND(
+	t.discount * td.subtotal::numeric / NULLIF(SUM(td.subtotal) OVER (PARTITION BY td.transaction_id), 0)
+), 0)`
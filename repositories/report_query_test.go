@@ -0,0 +1,208 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/database/dbtest"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+	"time"
+)
+
+// reportFixture is a tenant with two stores, two cashiers and two categories,
+// and a few sales between them
+type reportFixture struct {
+	transactions repositories.TransactionRepository
+	main, branch int
+	alice, bob   int
+	drinks, food int
+}
+
+// newReportFixture records the sales the report tests filter:
+//
+//	main store, alice, cash:  tea x1 (4000) + rice x2 (2000), 600 off -> 5400
+//	branch,     bob,   qris:  tea x2 (8000)                           -> 8000
+//	main store, bob,   cash:  rice x3 (3000), voided
+func newReportFixture(t *testing.T) reportFixture {
+	t.Helper()
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+	f := reportFixture{transactions: repositories.NewTransactionRepository(db)}
+
+	f.main = createStore(t, db)
+	if err := db.QueryRow(`INSERT INTO stores (code, name) VALUES ('T2', 'Branch') RETURNING id`).Scan(&f.branch); err != nil {
+		t.Fatal(err)
+	}
+	f.alice, f.bob = createCashier(t, db, "alice"), createCashier(t, db, "bob")
+	f.drinks, f.food = createCategory(t, db, "drinks"), createCategory(t, db, "food")
+
+	tea := createStockedProduct(t, db, f.main, "tea", 4000, 20, 100)
+	rice := createStockedProduct(t, db, f.main, "rice", 1000, 20, 300)
+	for product, category := range map[int]int{tea: f.drinks, rice: f.food} {
+		if _, err := db.Exec(`UPDATE products SET category_id = $1 WHERE id = $2`, category, product); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(`INSERT INTO store_stocks (store_id, product_id, stock) VALUES ($1, $2, 20)`, f.branch, product); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkout := func(req models.CheckoutRequest, details ...models.TransactionDetail) int {
+		t.Helper()
+		transaction, err := f.transactions.CreateTransaction(context.Background(), req, details)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return transaction.ID
+	}
+	teaLine := func(qty int) models.TransactionDetail {
+		return models.TransactionDetail{ProductID: tea, Quantity: qty, UnitPrice: 4000, Subtotal: 4000 * qty}
+	}
+	riceLine := func(qty int) models.TransactionDetail {
+		return models.TransactionDetail{ProductID: rice, Quantity: qty, UnitPrice: 1000, Subtotal: 1000 * qty}
+	}
+
+	checkout(models.CheckoutRequest{StoreID: f.main, CashierID: f.alice, PaymentMethod: "cash", Discount: 600}, teaLine(1), riceLine(2))
+	checkout(models.CheckoutRequest{StoreID: f.branch, CashierID: f.bob, PaymentMethod: "qris"}, teaLine(2))
+	voided := checkout(models.CheckoutRequest{StoreID: f.main, CashierID: f.bob, PaymentMethod: "cash"}, riceLine(3))
+	if err := f.transactions.VoidTransaction(context.Background(), voided); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// createCashier inserts a cashier of the tenant a pool acts as
+func createCashier(t *testing.T, db *sql.DB, name string) int {
+	t.Helper()
+	var id int
+	err := db.QueryRow(`INSERT INTO users (name, email, password, role) VALUES ($1, $1 || '@example.com', 'x', 'cashier') RETURNING id`,
+		name).Scan(&id)
+	if err != nil {
+		t.Fatalf("creating cashier %s: %v", name, err)
+	}
+	return id
+}
+
+// createCategory inserts a category of the tenant a pool acts as
+func createCategory(t *testing.T, db *sql.DB, name string) int {
+	t.Helper()
+	var id int
+	if err := db.QueryRow(`INSERT INTO categories (name, slug) VALUES ($1, $1) RETURNING id`, name).Scan(&id); err != nil {
+		t.Fatalf("creating category %s: %v", name, err)
+	}
+	return id
+}
+
+func TestReportFilters(t *testing.T) {
+	f := newReportFixture(t)
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	// Voided sales never count; a category selects the whole transactions
+	// that sold a product of it
+	tests := []struct {
+		name         string
+		filter       models.ReportFilter
+		revenue      int
+		transactions int
+	}{
+		{"no filter", models.ReportFilter{}, 13400, 2},
+		{"main store", models.ReportFilter{StoreID: f.main}, 5400, 1},
+		{"branch", models.ReportFilter{StoreID: f.branch}, 8000, 1},
+		{"alice", models.ReportFilter{CashierID: f.alice}, 5400, 1},
+		{"bob", models.ReportFilter{CashierID: f.bob}, 8000, 1},
+		{"qris", models.ReportFilter{PaymentMethod: "qris"}, 8000, 1},
+		{"cash at the branch", models.ReportFilter{StoreID: f.branch, PaymentMethod: "cash"}, 0, 0},
+		{"food", models.ReportFilter{CategoryID: f.food}, 5400, 1},
+		{"drinks by bob", models.ReportFilter{CategoryID: f.drinks, CashierID: f.bob}, 8000, 1},
+		{"from tomorrow", models.ReportFilter{StartDate: tomorrow}, 0, 0},
+		{"until tomorrow", models.ReportFilter{EndDate: tomorrow}, 13400, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := f.transactions.GetSalesReportByDateRange(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.TotalRevenue != tt.revenue || report.TotalTransactions != tt.transactions {
+				t.Fatalf("revenue %d over %d transactions, want %d over %d",
+					report.TotalRevenue, report.TotalTransactions, tt.revenue, tt.transactions)
+			}
+		})
+	}
+}
+
+func TestReportCategoryJoin(t *testing.T) {
+	f := newReportFixture(t)
+	ctx := context.Background()
+
+	// Lines are grouped under the category of their product; with a category
+	// filter only its lines remain
+	summary, err := f.transactions.GetReportSummary(ctx, models.ReportFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []models.CategoryRevenue{
+		{CategoryID: f.drinks, CategoryName: "drinks", Revenue: 12000, Transactions: 2},
+		{CategoryID: f.food, CategoryName: "food", Revenue: 2000, Transactions: 1},
+	}
+	if len(summary.CategoryBreakdown) != len(want) {
+		t.Fatalf("category breakdown %+v, want %+v", summary.CategoryBreakdown, want)
+	}
+	for i := range want {
+		if summary.CategoryBreakdown[i] != want[i] {
+			t.Fatalf("category breakdown %+v, want %+v", summary.CategoryBreakdown, want)
+		}
+	}
+
+	summary, err = f.transactions.GetReportSummary(ctx, models.ReportFilter{CategoryID: f.food})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.CategoryBreakdown) != 1 || summary.CategoryBreakdown[0].CategoryID != f.food {
+		t.Fatalf("food breakdown %+v, want only food", summary.CategoryBreakdown)
+	}
+	if best := summary.BestSellingProduct; best == nil || best.Name != "rice" || best.QtySold != 2 {
+		t.Fatalf("best seller in food %+v, want rice x2", best)
+	}
+}
+
+func TestReportSpreadsTransactionDiscount(t *testing.T) {
+	f := newReportFixture(t)
+	ctx := context.Background()
+
+	// The 600 off the first sale is spread 400/200 over its 4000 tea and
+	// 2000 rice, so line revenue adds up to the transaction totals
+	sales, err := f.transactions.GetCategorySales(ctx, models.ReportFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	revenue := make(map[int]int)
+	total := 0
+	for _, s := range sales {
+		if s.CategoryID == nil {
+			t.Fatalf("sales without a category: %+v", s)
+		}
+		revenue[*s.CategoryID] = s.Revenue
+		total += s.Revenue
+	}
+	if revenue[f.drinks] != 3600+8000 || revenue[f.food] != 1800 || total != 13400 {
+		t.Fatalf("revenue by category %v (total %d), want drinks 11600, food 1800 (13400)", revenue, total)
+	}
+
+	// The spread runs over every line of the transaction before the category
+	// filter, so rice still carries only its share of the discount
+	sales, err = f.transactions.GetCategorySales(ctx, models.ReportFilter{CategoryID: f.food})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sales) != 1 || sales[0].Revenue != 1800 {
+		t.Fatalf("food sales %+v, want revenue 1800", sales)
+	}
+	profits, err := f.transactions.GetProductProfits(ctx, models.ReportFilter{CategoryID: f.food})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profits) != 1 || profits[0].Revenue != 1800 || profits[0].COGS != 2*300 {
+		t.Fatalf("food profits %+v, want rice with revenue 1800 and COGS 600", profits)
+	}
+}
@@ -0,0 +1,260 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"time"
+)
+
+// ReportScheduleRepository defines the interface for report schedule and run history data access
+type ReportScheduleRepository interface {
+	GetAll(ctx context.Context) ([]models.ReportSchedule, error)
+	GetByID(ctx context.Context, id int) (*models.ReportSchedule, error)
+	Create(ctx context.Context, schedule models.ReportSchedule) (*models.ReportSchedule, error)
+	Update(ctx context.Context, id int, schedule models.ReportSchedule) (*models.ReportSchedule, error)
+	Delete(ctx context.Context, id int) error
+	GetDue(ctx context.Context) ([]models.ReportSchedule, error)
+	Advance(ctx context.Context, id int, nextRunAt *time.Time) (bool, error)
+	CreateRun(ctx context.Context, run models.ReportRun) (*models.ReportRun, error)
+	FinishRun(ctx context.Context, run models.ReportRun) (*models.ReportRun, error)
+	GetRuns(ctx context.Context, scheduleID, page, limit int) (*models.PaginatedReportRuns, error)
+}
+
+// reportScheduleRepository implements ReportScheduleRepository interface with PostgreSQL
+type reportScheduleRepository struct {
+	db DBTX
+}
+
+// NewReportScheduleRepository creates a new report schedule repository instance
+func NewReportScheduleRepository(db DBTX) ReportScheduleRepository {
+	return &reportScheduleRepository{db: db}
+}
+
+// reportScheduleColumns is the standard set of columns selected for report schedule queries
+const reportScheduleColumns = `
+	id, name, report, format, period, store_id, cron, timezone, target, path_prefix,
+	is_active, next_run_at, last_run_at,
+	COALESCE((SELECT r.status FROM report_runs r WHERE r.schedule_id = report_schedules.id ORDER BY r.id DESC LIMIT 1), ''),
+	COALESCE(created_by, 0), created_at, updated_at
+`
+
+// scanReportSchedule scans a row into a ReportSchedule struct
+func scanReportSchedule(scanner interface{ Scan(dest ...interface{}) error }) (*models.ReportSchedule, error) {
+	var s models.ReportSchedule
+	err := scanner.Scan(
+		&s.ID, &s.Name, &s.Report, &s.Format, &s.Period, &s.StoreID, &s.Cron, &s.Timezone, &s.Target, &s.PathPrefix,
+		&s.IsActive, &s.NextRunAt, &s.LastRunAt, &s.LastStatus,
+		&s.CreatedBy, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// reportRunColumns is the standard set of columns selected for report run queries
+const reportRunColumns = `
+	id, schedule_id, trigger_type, status,
+	COALESCE(TO_CHAR(period_start, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(period_end, 'YYYY-MM-DD'), ''),
+	object_key, size_bytes, error, started_at, finished_at
+`
+
+// scanReportRun scans a row into a ReportRun struct
+func scanReportRun(scanner interface{ Scan(dest ...interface{}) error }) (*models.ReportRun, error) {
+	var run models.ReportRun
+	err := scanner.Scan(
+		&run.ID, &run.ScheduleID, &run.Trigger, &run.Status,
+		&run.PeriodStart, &run.PeriodEnd,
+		&run.ObjectKey, &run.SizeBytes, &run.Error, &run.StartedAt, &run.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// querySchedules runs a schedule query and scans every row
+func (r *reportScheduleRepository) querySchedules(ctx context.Context, query string, args ...interface{}) ([]models.ReportSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]models.ReportSchedule, 0)
+	for rows.Next() {
+		s, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// GetAll returns every report schedule ordered by name
+func (r *reportScheduleRepository) GetAll(ctx context.Context) ([]models.ReportSchedule, error) {
+	return r.querySchedules(ctx, `SELECT `+reportScheduleColumns+` FROM report_schedules ORDER BY name, id`)
+}
+
+// GetByID returns a report schedule by its ID
+func (r *reportScheduleRepository) GetByID(ctx context.Context, id int) (*models.ReportSchedule, error) {
+	s, err := scanReportSchedule(r.db.QueryRowContext(ctx,
+		`SELECT `+reportScheduleColumns+` FROM report_schedules WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create inserts a new report schedule
+func (r *reportScheduleRepository) Create(ctx context.Context, schedule models.ReportSchedule) (*models.ReportSchedule, error) {
+	return scanReportSchedule(r.db.QueryRowContext(ctx, `
+		INSERT INTO report_schedules (name, report, format, period, store_id, cron, timezone, target, path_prefix, is_active, next_run_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, 0))
+		RETURNING `+reportScheduleColumns,
+		schedule.Name, schedule.Report, schedule.Format, schedule.Period, schedule.StoreID, schedule.Cron,
+		schedule.Timezone, schedule.Target, schedule.PathPrefix, schedule.IsActive, schedule.NextRunAt, schedule.CreatedBy,
+	))
+}
+
+// Update modifies an existing report schedule
+func (r *reportScheduleRepository) Update(ctx context.Context, id int, schedule models.ReportSchedule) (*models.ReportSchedule, error) {
+	s, err := scanReportSchedule(r.db.QueryRowContext(ctx, `
+		UPDATE report_schedules
+		SET name = $1, report = $2, format = $3, period = $4, store_id = $5, cron = $6, timezone = $7,
+		    target = $8, path_prefix = $9, is_active = $10, next_run_at = $11, updated_at = $12
+		WHERE id = $13
+		RETURNING `+reportScheduleColumns,
+		schedule.Name, schedule.Report, schedule.Format, schedule.Period, schedule.StoreID, schedule.Cron,
+		schedule.Timezone, schedule.Target, schedule.PathPrefix, schedule.IsActive, schedule.NextRunAt,
+		time.Now(), id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Delete removes a report schedule and its run history
+func (r *reportScheduleRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM report_schedules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetDue returns active schedules whose next run time has passed
+func (r *reportScheduleRepository) GetDue(ctx context.Context) ([]models.ReportSchedule, error) {
+	return r.querySchedules(ctx, `
+		SELECT ` + reportScheduleColumns + ` FROM report_schedules
+		WHERE is_active = true AND next_run_at <= NOW()
+		ORDER BY next_run_at, id
+	`)
+}
+
+// Advance moves a due schedule's next run forward. It returns false when the
+// schedule is no longer due (e.g. another instance advanced it first), so
+// each run renders exactly one file.
+func (r *reportScheduleRepository) Advance(ctx context.Context, id int, nextRunAt *time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE report_schedules
+		SET next_run_at = $1, last_run_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND is_active = true AND next_run_at <= NOW()
+	`, nextRunAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// CreateRun records the start of a report run
+func (r *reportScheduleRepository) CreateRun(ctx context.Context, run models.ReportRun) (*models.ReportRun, error) {
+	return scanReportRun(r.db.QueryRowContext(ctx, `
+		INSERT INTO report_runs (schedule_id, trigger_type, status, period_start, period_end)
+		VALUES ($1, $2, $3, NULLIF($4, '')::date, NULLIF($5, '')::date)
+		RETURNING `+reportRunColumns,
+		run.ScheduleID, run.Trigger, models.ReportRunRunning, run.PeriodStart, run.PeriodEnd,
+	))
+}
+
+// FinishRun records the outcome of a report run
+func (r *reportScheduleRepository) FinishRun(ctx context.Context, run models.ReportRun) (*models.ReportRun, error) {
+	return scanReportRun(r.db.QueryRowContext(ctx, `
+		UPDATE report_runs
+		SET status = $1, object_key = $2, size_bytes = $3, error = $4, finished_at = NOW()
+		WHERE id = $5
+		RETURNING `+reportRunColumns,
+		run.Status, run.ObjectKey, run.SizeBytes, run.Error, run.ID,
+	))
+}
+
+// GetRuns returns the run history of a schedule, newest first
+func (r *reportScheduleRepository) GetRuns(ctx context.Context, scheduleID, page, limit int) (*models.PaginatedReportRuns, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM report_runs WHERE schedule_id = $1`, scheduleID).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reportRunColumns+`
+		FROM report_runs
+		WHERE schedule_id = $1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3
+	`, scheduleID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := make([]models.ReportRun, 0)
+	for rows.Next() {
+		run, err := scanReportRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedReportRuns{
+		Data:       runs,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: helpers.CalcTotalPages(total, limit),
+	}, nil
+}
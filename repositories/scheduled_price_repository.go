@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+)
+
+// ScheduledPriceRepository defines the interface for future price changes
+type ScheduledPriceRepository interface {
+	GetByProductID(ctx context.Context, productID int, status string) ([]models.ScheduledPrice, error)
+	GetByID(ctx context.Context, id int) (*models.ScheduledPrice, error)
+	Create(ctx context.Context, scheduled models.ScheduledPrice) (*models.ScheduledPrice, error)
+	Cancel(ctx context.Context, id int) (*models.ScheduledPrice, error)
+	SetError(ctx context.Context, id int, message string) error
+	GetDueIDs(ctx context.Context) ([]int, error)
+	Apply(ctx context.Context, id int) error
+}
+
+// scheduledPriceRepository implements ScheduledPriceRepository interface with PostgreSQL
+type scheduledPriceRepository struct {
+	db DBTX
+}
+
+// NewScheduledPriceRepository creates a new scheduled price repository instance
+func NewScheduledPriceRepository(db DBTX) ScheduledPriceRepository {
+	return &scheduledPriceRepository{db: db}
+}
+
+// scheduledPriceColumns is the standard set of columns selected for scheduled price queries
+const scheduledPriceColumns = `
+	id, product_id, price, effective_at, status, created_by, created_by_name, applied_at, last_error, created_at
+`
+
+// scanScheduledPrice scans a row into a ScheduledPrice struct
+func scanScheduledPrice(scanner interface{ Scan(dest ...interface{}) error }) (*models.ScheduledPrice, error) {
+	var sp models.ScheduledPrice
+	err := scanner.Scan(
+		&sp.ID, &sp.ProductID, &sp.Price, &sp.EffectiveAt, &sp.Status,
+		&sp.CreatedBy, &sp.CreatedByName, &sp.AppliedAt, &sp.LastError, &sp.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sp, nil
+}
+
+// GetByProductID returns the scheduled prices of a product, optionally
+// filtered by status, in the order they take effect
+func (r *scheduledPriceRepository) GetByProductID(ctx context.Context, productID int, status string) ([]models.ScheduledPrice, error) {
+	query := `SELECT ` + scheduledPriceColumns + ` FROM scheduled_prices WHERE product_id = $1`
+	args := []interface{}{productID}
+	if status != "" {
+		query += ` AND status = $2`
+		args = append(args, status)
+	}
+	query += ` ORDER BY effective_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scheduled := make([]models.ScheduledPrice, 0)
+	for rows.Next() {
+		sp, err := scanScheduledPrice(rows)
+		if err != nil {
+			return nil, err
+		}
+		scheduled = append(scheduled, *sp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+// GetByID returns a scheduled price by its ID
+func (r *scheduledPriceRepository) GetByID(ctx context.Context, id int) (*models.ScheduledPrice, error) {
+	sp, err := scanScheduledPrice(r.db.QueryRowContext(ctx, `SELECT `+scheduledPriceColumns+` FROM scheduled_prices WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sp, nil
+}
+
+// Create stores a pending price change
+func (r *scheduledPriceRepository) Create(ctx context.Context, scheduled models.ScheduledPrice) (*models.ScheduledPrice, error) {
+	return scanScheduledPrice(r.db.QueryRowContext(ctx, `
+		INSERT INTO scheduled_prices (product_id, price, effective_at, created_by, created_by_name)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+scheduledPriceColumns,
+		scheduled.ProductID, scheduled.Price, scheduled.EffectiveAt, scheduled.CreatedBy, scheduled.CreatedByName,
+	))
+}
+
+// Cancel withdraws a pending price change. It returns nil when the price
+// change is no longer pending.
+func (r *scheduledPriceRepository) Cancel(ctx context.Context, id int) (*models.ScheduledPrice, error) {
+	sp, err := scanScheduledPrice(r.db.QueryRowContext(ctx, `
+		UPDATE scheduled_prices SET status = $1
+		WHERE id = $2 AND status = $3
+		RETURNING `+scheduledPriceColumns,
+		models.ScheduledPriceStatusCancelled, id, models.ScheduledPriceStatusPending,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sp, nil
+}
+
+// SetError records why the last attempt to apply a price change failed
+func (r *scheduledPriceRepository) SetError(ctx context.Context, id int, message string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE scheduled_prices SET last_error = $1 WHERE id = $2`, message, id)
+	return err
+}
+
+// GetDueIDs returns pending price changes whose effective time has passed,
+// in the order they take effect
+func (r *scheduledPriceRepository) GetDueIDs(ctx context.Context) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id FROM scheduled_prices
+		WHERE status = $1 AND effective_at <= NOW()
+		ORDER BY effective_at, id
+	`, models.ScheduledPriceStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Apply sets the product price of a due price change, records it in the price
+// history under the user who scheduled it and marks it applied, in a single
+// database transaction
+func (r *scheduledPriceRepository) Apply(ctx context.Context, id int) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	sp, err := scanScheduledPrice(tx.QueryRowContext(ctx, `SELECT `+scheduledPriceColumns+` FROM scheduled_prices WHERE id = $1 FOR UPDATE`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NewNotFoundError("scheduled price not found")
+		}
+		return err
+	}
+	if sp.Status != models.ScheduledPriceStatusPending {
+		return helpers.NewConflictError("scheduled price is no longer pending")
+	}
+
+	var oldPrice int
+	err = tx.QueryRowContext(ctx, `SELECT price FROM products WHERE id = $1 FOR UPDATE`, sp.ProductID).Scan(&oldPrice)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NewNotFoundError("product not found")
+		}
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE products SET price = $1, updated_at = NOW() WHERE id = $2`, sp.Price, sp.ProductID); err != nil {
+		return err
+	}
+
+	actor := models.Actor{Name: sp.CreatedByName}
+	if sp.CreatedBy != nil {
+		actor.UserID = *sp.CreatedBy
+	}
+	err = recordPriceChange(ctx, tx, models.PriceChange{
+		ProductID:   sp.ProductID,
+		OldPrice:    &oldPrice,
+		NewPrice:    sp.Price,
+		Source:      models.PriceSourceSchedule,
+		ReferenceID: &sp.ID,
+	}, actor)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE scheduled_prices SET status = $1, applied_at = NOW(), last_error = ''
+		WHERE id = $2
+	`, models.ScheduledPriceStatusApplied, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"retail-core-api/models"
+)
+
+// SchemaRepository defines the interface for reading the applied schema migrations
+type SchemaRepository interface {
+	GetMigrations(ctx context.Context) ([]models.SchemaMigration, error)
+}
+
+// schemaRepository implements SchemaRepository interface with PostgreSQL
+type schemaRepository struct {
+	db DBTX
+}
+
+// NewSchemaRepository creates a new schema repository instance
+func NewSchemaRepository(db DBTX) SchemaRepository {
+	return &schemaRepository{db: db}
+}
+
+// GetMigrations returns the applied schema migrations, oldest first
+func (r *schemaRepository) GetMigrations(ctx context.Context) ([]models.SchemaMigration, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT version, name, description, applied_at
+		FROM schema_migrations
+		ORDER BY version
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	migrations := make([]models.SchemaMigration, 0)
+	for rows.Next() {
+		var m models.SchemaMigration
+		if err := rows.Scan(&m.Version, &m.Name, &m.Description, &m.AppliedAt); err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return migrations, nil
+}
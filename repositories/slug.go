@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/helpers"
+)
+
+// rowsQueryer is satisfied by both *sql.DB and *sql.Tx
+type rowsQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// uniqueSlug returns a slug for name that is not used by any other row of
+// table, appending -2, -3, ... when needed. excludeID is the row being
+// updated (0 on create) so it keeps its own slug.
+func uniqueSlug(ctx context.Context, q rowsQueryer, table, name string, excludeID int) (string, error) {
+	base := helpers.Slugify(name)
+
+	rows, err := q.QueryContext(ctx,
+		fmt.Sprintf(`SELECT slug FROM %s WHERE (slug = $1 OR slug LIKE $1 || '-%%') AND id <> $2`, table),
+		base, excludeID,
+	)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		taken[slug] = true
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	slug := base
+	for n := 2; taken[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
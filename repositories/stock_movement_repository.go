@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+)
+
+// StockMovementRepository defines the interface for reading the stock ledger.
+// Movements are written by the repositories that change stock, inside the
+// same database transaction as the change.
+type StockMovementRepository interface {
+	GetByID(ctx context.Context, id int) (*models.StockMovement, error)
+	GetByProductID(ctx context.Context, params models.StockMovementParams) (*models.PaginatedStockMovements, error)
+	Adjust(ctx context.Context, movement models.StockMovement) (*models.StockMovement, error)
+	GetLatestID(ctx context.Context) (int, error)
+	GetUpdates(ctx context.Context, afterID int, exclude []int, limit int) ([]models.StockUpdate, error)
+}
+
+// stockMovementRepository implements StockMovementRepository interface with PostgreSQL
+type stockMovementRepository struct {
+	db DBTX
+}
+
+// NewStockMovementRepository creates a new stock movement repository instance
+func NewStockMovementRepository(db DBTX) StockMovementRepository {
+	return &stockMovementRepository{db: db}
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// recordStockMovement appends a ledger row for a stock change and applies the
+// change to the stock of the store it happened at (the default store when
+// StoreID is 0). It must run in the same database transaction as the change
+// to the product's total stock.
+func recordStockMovement(ctx context.Context, e execer, movement models.StockMovement) error {
+	if movement.QuantityDelta == 0 {
+		return nil
+	}
+	_, err := e.ExecContext(ctx, `
+		WITH movement AS (
+			INSERT INTO stock_movements (product_id, store_id, quantity_delta, balance_after, reason, reason_code, reference_type, reference_id, note, created_by)
+			VALUES ($1, COALESCE(NULLIF($2, 0), (SELECT id FROM stores WHERE is_default)), $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING store_id, product_id, quantity_delta
+		)
+		INSERT INTO store_stocks (store_id, product_id, stock)
+		SELECT store_id, product_id, quantity_delta FROM movement
+		ON CONFLICT (store_id, product_id) DO UPDATE SET stock = store_stocks.stock + EXCLUDED.stock, updated_at = NOW()
+	`, movement.ProductID, movement.StoreID, movement.QuantityDelta, movement.BalanceAfter, movement.Reason, movement.ReasonCode,
+		movement.ReferenceType, movement.ReferenceID, movement.Note, movement.CreatedBy)
+	return err
+}
+
+// lockStoreStock locks and returns the stock of a product at a store (0 when
+// the store never held it). Callers lock the product row first.
+func lockStoreStock(ctx context.Context, tx DBTX, storeID, productID int) (int, error) {
+	var stock int
+	err := tx.QueryRowContext(ctx,
+		`SELECT stock FROM store_stocks WHERE store_id = $1 AND product_id = $2 FOR UPDATE`,
+		storeID, productID,
+	).Scan(&stock)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return stock, err
+}
+
+// stockMovementColumns is the standard set of columns selected for stock movement queries
+const stockMovementColumns = `
+	id, product_id, store_id, quantity_delta, balance_after, reason, reason_code,
+	reference_type, reference_id, note, created_by, created_at
+`
+
+// scanStockMovement scans a row into a StockMovement struct
+func scanStockMovement(scanner interface{ Scan(dest ...interface{}) error }) (*models.StockMovement, error) {
+	var m models.StockMovement
+	err := scanner.Scan(
+		&m.ID, &m.ProductID, &m.StoreID, &m.QuantityDelta, &m.BalanceAfter, &m.Reason, &m.ReasonCode,
+		&m.ReferenceType, &m.ReferenceID, &m.Note, &m.CreatedBy, &m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetByID returns a stock movement by its ID
+func (r *stockMovementRepository) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+stockMovementColumns+` FROM stock_movements WHERE id = $1`, id)
+	m, err := scanStockMovement(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return m, err
+}
+
+// Adjust applies a manual stock change at a store and records it in the
+// ledger in one database transaction. It fails if the product's or the
+// store's stock would drop below zero and returns nil when the product does
+// not exist.
+func (r *stockMovementRepository) Adjust(ctx context.Context, movement models.StockMovement) (*models.StockMovement, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var stock int
+	err = tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = $1 FOR UPDATE`, movement.ProductID).Scan(&stock)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if stock+movement.QuantityDelta < 0 {
+		return nil, helpers.NewValidationError(fmt.Sprintf("insufficient stock for adjustment (available: %d, adjustment: %d)", stock, movement.QuantityDelta))
+	}
+	storeStock, err := lockStoreStock(ctx, tx, movement.StoreID, movement.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if storeStock+movement.QuantityDelta < 0 {
+		return nil, helpers.NewValidationError(fmt.Sprintf("insufficient stock for adjustment at store %d (available: %d, adjustment: %d)", movement.StoreID, storeStock, movement.QuantityDelta))
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`UPDATE products SET stock = stock + $1, updated_at = NOW() WHERE id = $2 RETURNING stock`,
+		movement.QuantityDelta, movement.ProductID,
+	).Scan(&movement.BalanceAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := scanStockMovement(tx.QueryRowContext(ctx, `
+		INSERT INTO stock_movements (product_id, store_id, quantity_delta, balance_after, reason, reason_code, reference_type, note, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+stockMovementColumns,
+		movement.ProductID, movement.StoreID, movement.QuantityDelta, movement.BalanceAfter, movement.Reason, movement.ReasonCode,
+		models.StockRefAdjustment, movement.Note, movement.CreatedBy,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO store_stocks (store_id, product_id, stock) VALUES ($1, $2, $3)
+		ON CONFLICT (store_id, product_id) DO UPDATE SET stock = store_stocks.stock + EXCLUDED.stock, updated_at = NOW()
+	`, movement.StoreID, movement.ProductID, movement.QuantityDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock counted in is layered at the cost price
+	if movement.QuantityDelta > 0 {
+		if err := openCostLayer(ctx, tx, movement.ProductID, models.CostSourceAdjustment, created.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetByProductID returns the stock movements of a product, newest first
+func (r *stockMovementRepository) GetByProductID(ctx context.Context, params models.StockMovementParams) (*models.PaginatedStockMovements, error) {
+	if params.Page <= 0 {
+		params.Page = helpers.DefaultPage
+	}
+	if params.Limit <= 0 {
+		params.Limit = helpers.DefaultLimit
+	}
+
+	where := " WHERE product_id = $1"
+	args := []interface{}{params.ProductID}
+	argIdx := 2
+
+	if params.StoreID > 0 {
+		where += fmt.Sprintf(" AND store_id = $%d", argIdx)
+		args = append(args, params.StoreID)
+		argIdx++
+	}
+	if params.Reason != "" {
+		where += fmt.Sprintf(" AND reason = $%d", argIdx)
+		args = append(args, params.Reason)
+		argIdx++
+	}
+	if params.StartDate != "" {
+		where += fmt.Sprintf(" AND created_at::date >= $%d::date", argIdx)
+		args = append(args, params.StartDate)
+		argIdx++
+	}
+	if params.EndDate != "" {
+		where += fmt.Sprintf(" AND created_at::date <= $%d::date", argIdx)
+		args = append(args, params.EndDate)
+		argIdx++
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM stock_movements"+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM stock_movements
+		%s
+		ORDER BY id DESC
+		LIMIT $%d OFFSET $%d
+	`, stockMovementColumns, where, argIdx, argIdx+1)
+	args = append(args, params.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := make([]models.StockMovement, 0)
+	for rows.Next() {
+		m, err := scanStockMovement(rows)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, *m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedStockMovements{
+		Data:       movements,
+		Total:      total,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalPages: helpers.CalcTotalPages(total, params.Limit),
+	}, nil
+}
+
+// GetLatestID returns the ID of the newest movement, or 0 when the ledger is empty
+func (r *stockMovementRepository) GetLatestID(ctx context.Context) (int, error) {
+	var id int
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM stock_movements`).Scan(&id)
+	return id, err
+}
+
+// GetUpdates returns the movements after afterID, skipping the IDs in
+// exclude, oldest first with the store's current stock
+func (r *stockMovementRepository) GetUpdates(ctx context.Context, afterID int, exclude []int, limit int) ([]models.StockUpdate, error) {
+	args := []interface{}{afterID, limit}
+	query := `
+		SELECT m.id, m.product_id, m.store_id, m.quantity_delta, m.balance_after, COALESCE(ss.stock, 0), m.reason, m.created_at
+		FROM stock_movements m
+		LEFT JOIN store_stocks ss ON ss.store_id = m.store_id AND ss.product_id = m.product_id
+		WHERE m.id > $1`
+	if len(exclude) > 0 {
+		query += ` AND m.id NOT IN (VALUES ` + valuesList(len(exclude), 1, 2, "int") + `)`
+		for _, id := range exclude {
+			args = append(args, id)
+		}
+	}
+	query += ` ORDER BY m.id LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updates := make([]models.StockUpdate, 0)
+	for rows.Next() {
+		var u models.StockUpdate
+		if err := rows.Scan(&u.MovementID, &u.ProductID, &u.StoreID, &u.QuantityDelta, &u.Stock,
+			&u.StoreStock, &u.Reason, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		updates = append(updates, u)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
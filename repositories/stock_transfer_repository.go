@@ -0,0 +1,309 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+)
+
+// StockTransferRepository defines the interface for stock transfer data access
+type StockTransferRepository interface {
+	GetAll(ctx context.Context, params models.StockTransferParams) ([]models.StockTransfer, error)
+	GetByID(ctx context.Context, id int) (*models.StockTransfer, error)
+	Create(ctx context.Context, transfer models.StockTransfer) (*models.StockTransfer, error)
+	Receive(ctx context.Context, id, receivedBy int) (*models.StockTransfer, error)
+	Cancel(ctx context.Context, id, cancelledBy int) (*models.StockTransfer, error)
+}
+
+// stockTransferRepository implements StockTransferRepository interface with PostgreSQL
+type stockTransferRepository struct {
+	db DBTX
+}
+
+// NewStockTransferRepository creates a new stock transfer repository instance
+func NewStockTransferRepository(db DBTX) StockTransferRepository {
+	return &stockTransferRepository{db: db}
+}
+
+// stockTransferColumns is the standard set of columns selected for stock transfer queries
+const stockTransferColumns = `
+	st.id, st.from_store_id, fs.name, st.to_store_id, ts.name, st.status, st.note,
+	(SELECT COALESCE(SUM(i.quantity), 0) FROM stock_transfer_items i WHERE i.transfer_id = st.id),
+	COALESCE(st.created_by, 0), st.received_by, st.created_at, st.updated_at, st.received_at
+`
+
+// stockTransferFrom joins a transfer with its stores
+const stockTransferFrom = `
+	FROM stock_transfers st
+	JOIN stores fs ON fs.id = st.from_store_id
+	JOIN stores ts ON ts.id = st.to_store_id
+`
+
+// scanStockTransfer scans a row into a StockTransfer struct
+func scanStockTransfer(scanner interface{ Scan(dest ...interface{}) error }) (*models.StockTransfer, error) {
+	var t models.StockTransfer
+	err := scanner.Scan(
+		&t.ID, &t.FromStoreID, &t.FromStoreName, &t.ToStoreID, &t.ToStoreName, &t.Status, &t.Note,
+		&t.TotalQuantity, &t.CreatedBy, &t.ReceivedBy, &t.CreatedAt, &t.UpdatedAt, &t.ReceivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetAll returns stock transfers, newest first, optionally filtered by status
+// and by a store on either side (items are not loaded)
+func (r *stockTransferRepository) GetAll(ctx context.Context, params models.StockTransferParams) ([]models.StockTransfer, error) {
+	where := "WHERE 1=1"
+	args := []interface{}{}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where += fmt.Sprintf(" AND st.status = $%d", len(args))
+	}
+	if params.StoreID > 0 {
+		args = append(args, params.StoreID)
+		where += fmt.Sprintf(" AND (st.from_store_id = $%d OR st.to_store_id = $%d)", len(args), len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s %s %s ORDER BY st.id DESC`, stockTransferColumns, stockTransferFrom, where,
+	), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := make([]models.StockTransfer, 0)
+	for rows.Next() {
+		t, err := scanStockTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// GetByID returns a stock transfer with its items
+func (r *stockTransferRepository) GetByID(ctx context.Context, id int) (*models.StockTransfer, error) {
+	t, err := scanStockTransfer(r.db.QueryRowContext(ctx,
+		`SELECT `+stockTransferColumns+stockTransferFrom+`WHERE st.id = $1`, id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.id, i.transfer_id, i.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), i.quantity
+		FROM stock_transfer_items i
+		LEFT JOIN products p ON p.id = i.product_id
+		WHERE i.transfer_id = $1
+		ORDER BY i.id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	t.Items = make([]models.StockTransferItem, 0)
+	for rows.Next() {
+		var item models.StockTransferItem
+		if err := rows.Scan(&item.ID, &item.TransferID, &item.ProductID, &item.ProductName, &item.SKU, &item.Quantity); err != nil {
+			return nil, err
+		}
+		t.Items = append(t.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Create dispatches a stock transfer in one database transaction: every line
+// is taken out of the source store and the product's total stock and written
+// to the ledger as transfer_out. Lines must be sorted by product ID so
+// concurrent transfers lock products in the same order.
+func (r *stockTransferRepository) Create(ctx context.Context, transfer models.StockTransfer) (*models.StockTransfer, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO stock_transfers (from_store_id, to_store_id, status, note, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0))
+		RETURNING id
+	`, transfer.FromStoreID, transfer.ToStoreID, models.StockTransferInTransit, transfer.Note, transfer.CreatedBy).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	var actor *int
+	if transfer.CreatedBy > 0 {
+		actor = &transfer.CreatedBy
+	}
+
+	for _, item := range transfer.Items {
+		var name string
+		err = tx.QueryRowContext(ctx, `SELECT name FROM products WHERE id = $1 FOR UPDATE`, item.ProductID).Scan(&name)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, helpers.NewValidationError(fmt.Sprintf("product with id %d not found", item.ProductID))
+			}
+			return nil, err
+		}
+
+		available, err := lockStoreStock(ctx, tx, transfer.FromStoreID, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if available < item.Quantity {
+			return nil, helpers.NewValidationError(fmt.Sprintf("insufficient stock for product '%s' at the source store (available: %d, requested: %d)", name, available, item.Quantity))
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO stock_transfer_items (transfer_id, product_id, quantity) VALUES ($1, $2, $3)`,
+			id, item.ProductID, item.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = moveTransferStock(ctx, tx, id, transfer.FromStoreID, item, -item.Quantity, models.StockReasonTransferOut, "", actor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Receive confirms that an in-transit transfer arrived: every line is added to
+// the destination store and the product's total stock and written to the
+// ledger as transfer_in
+func (r *stockTransferRepository) Receive(ctx context.Context, id, receivedBy int) (*models.StockTransfer, error) {
+	return r.close(ctx, id, receivedBy, models.StockTransferReceived)
+}
+
+// Cancel returns the goods of an in-transit transfer to the source store,
+// written to the ledger as transfer_in
+func (r *stockTransferRepository) Cancel(ctx context.Context, id, cancelledBy int) (*models.StockTransfer, error) {
+	return r.close(ctx, id, cancelledBy, models.StockTransferCancelled)
+}
+
+// close books the goods of an in-transit transfer into the destination store
+// (received) or back into the source store (cancelled) in one database transaction
+func (r *stockTransferRepository) close(ctx context.Context, id, userID int, status string) (*models.StockTransfer, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var current string
+	var fromStoreID, toStoreID int
+	err = tx.QueryRowContext(ctx,
+		`SELECT status, from_store_id, to_store_id FROM stock_transfers WHERE id = $1 FOR UPDATE`, id,
+	).Scan(&current, &fromStoreID, &toStoreID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, helpers.NewNotFoundError("stock transfer not found")
+		}
+		return nil, err
+	}
+	if current != models.StockTransferInTransit {
+		return nil, helpers.NewConflictError(fmt.Sprintf("stock transfer is already %s", current))
+	}
+
+	storeID, note := toStoreID, ""
+	if status == models.StockTransferCancelled {
+		storeID, note = fromStoreID, fmt.Sprintf("Transfer #%d cancelled", id)
+	}
+
+	var actor *int
+	if userID > 0 {
+		actor = &userID
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT product_id, quantity FROM stock_transfer_items WHERE transfer_id = $1 ORDER BY product_id`, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]models.StockTransferItem, 0)
+	for rows.Next() {
+		var item models.StockTransferItem
+		if err := rows.Scan(&item.ProductID, &item.Quantity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if err := moveTransferStock(ctx, tx, id, storeID, item, item.Quantity, models.StockReasonTransferIn, note, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE stock_transfers
+		SET status = $1, received_by = CASE WHEN $1 = $2 THEN NULLIF($3, 0) END,
+		    received_at = CASE WHEN $1 = $2 THEN NOW() END, updated_at = NOW()
+		WHERE id = $4
+	`, status, models.StockTransferReceived, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// moveTransferStock applies one transfer line to a product's total stock and
+// records it in the ledger against the store it leaves or enters
+func moveTransferStock(ctx context.Context, tx DBTX, transferID, storeID int, item models.StockTransferItem, delta int, reason, note string, actor *int) error {
+	var balance int
+	err := tx.QueryRowContext(ctx,
+		`UPDATE products SET stock = stock + $1, updated_at = NOW() WHERE id = $2 RETURNING stock`,
+		delta, item.ProductID,
+	).Scan(&balance)
+	if err != nil {
+		return err
+	}
+
+	return recordStockMovement(ctx, tx, models.StockMovement{
+		ProductID:     item.ProductID,
+		StoreID:       storeID,
+		QuantityDelta: delta,
+		BalanceAfter:  balance,
+		Reason:        reason,
+		ReferenceType: models.StockRefStockTransfer,
+		ReferenceID:   &transferID,
+		Note:          note,
+		CreatedBy:     actor,
+	})
+}
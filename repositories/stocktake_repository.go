@@ -0,0 +1,515 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"strings"
+)
+
+// StocktakeRepository defines the interface for count session data access
+type StocktakeRepository interface {
+	GetAll(ctx context.Context, status string, assignedTo int) ([]models.CountSession, error)
+	GetByID(ctx context.Context, id int) (*models.CountSession, error)
+	GetOpenToday(ctx context.Context, sessionType string) (*models.CountSession, error)
+	GetCountCandidates(ctx context.Context, velocityDays int) ([]models.CountCandidate, error)
+	GetActiveProductIDs(ctx context.Context, categoryID *int, productIDs []int) ([]int, error)
+	GetVarianceLines(ctx context.Context, sessionID int) ([]models.CountVarianceLine, error)
+	Create(ctx context.Context, session models.CountSession, items []models.CountSessionItem) (*models.CountSession, error)
+	RecordCount(ctx context.Context, sessionID, productID, countedQty, countedBy int) (*models.CountSessionItem, error)
+	Complete(ctx context.Context, id, completedBy int) error
+	Cancel(ctx context.Context, id int) (*models.CountSession, error)
+}
+
+// stocktakeRepository implements StocktakeRepository interface with PostgreSQL
+type stocktakeRepository struct {
+	db DBTX
+}
+
+// NewStocktakeRepository creates a new stocktake repository instance
+func NewStocktakeRepository(db DBTX) StocktakeRepository {
+	return &stocktakeRepository{db: db}
+}
+
+// countSessionColumns is the standard set of columns selected for count session queries
+const countSessionColumns = `
+	s.id, s.type, s.status, s.note, s.store_id, s.schedule_id, s.assigned_to, s.due_at,
+	COALESCE(s.created_by, 0), s.completed_by,
+	(SELECT COUNT(*) FROM count_session_items i WHERE i.session_id = s.id),
+	(SELECT COUNT(*) FROM count_session_items i WHERE i.session_id = s.id AND i.counted_qty IS NOT NULL),
+	s.created_at, s.completed_at
+`
+
+// scanCountSession scans a row into a CountSession struct
+func scanCountSession(scanner interface{ Scan(dest ...interface{}) error }) (*models.CountSession, error) {
+	var cs models.CountSession
+	err := scanner.Scan(
+		&cs.ID, &cs.Type, &cs.Status, &cs.Note, &cs.StoreID, &cs.ScheduleID, &cs.AssignedTo, &cs.DueAt,
+		&cs.CreatedBy, &cs.CompletedBy,
+		&cs.ItemCount, &cs.CountedCount, &cs.CreatedAt, &cs.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+// countSessionItemColumns is the standard set of columns selected for count session item queries
+const countSessionItemColumns = `
+	i.id, i.session_id, i.product_id, p.name, COALESCE(p.sku, ''), i.sample_weight,
+	i.expected_qty, i.counted_qty, i.counted_by, i.counted_at
+`
+
+// countExpectedQty is the system stock a count is compared against: the
+// stock at the session's store, or the product total when it has no store.
+// It expects the product as p and the session as s.
+const countExpectedQty = `
+	CASE WHEN s.store_id IS NULL THEN p.stock
+	ELSE COALESCE((SELECT ss.stock FROM store_stocks ss WHERE ss.store_id = s.store_id AND ss.product_id = p.id), 0)
+	END
+`
+
+// scanCountSessionItem scans a row into a CountSessionItem struct
+func scanCountSessionItem(scanner interface{ Scan(dest ...interface{}) error }) (*models.CountSessionItem, error) {
+	var item models.CountSessionItem
+	err := scanner.Scan(
+		&item.ID, &item.SessionID, &item.ProductID, &item.ProductName, &item.SKU, &item.SampleWeight,
+		&item.ExpectedQty, &item.CountedQty, &item.CountedBy, &item.CountedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if item.CountedQty != nil {
+		variance := *item.CountedQty - item.ExpectedQty
+		item.Variance = &variance
+	}
+	return &item, nil
+}
+
+// GetAll returns count sessions, newest first, optionally filtered by status
+// and assignee (items are not loaded)
+func (r *stocktakeRepository) GetAll(ctx context.Context, status string, assignedTo int) ([]models.CountSession, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("s.status = $%d", len(args)))
+	}
+	if assignedTo > 0 {
+		args = append(args, assignedTo)
+		conditions = append(conditions, fmt.Sprintf("s.assigned_to = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s FROM count_sessions s %s ORDER BY s.id DESC`, countSessionColumns, where,
+	), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]models.CountSession, 0)
+	for rows.Next() {
+		cs, err := scanCountSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *cs)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetByID returns a count session with its items
+func (r *stocktakeRepository) GetByID(ctx context.Context, id int) (*models.CountSession, error) {
+	return r.getWithItems(ctx, `SELECT `+countSessionColumns+` FROM count_sessions s WHERE s.id = $1`, id)
+}
+
+// GetOpenToday returns the latest open session of a type started today, or nil
+func (r *stocktakeRepository) GetOpenToday(ctx context.Context, sessionType string) (*models.CountSession, error) {
+	return r.getWithItems(ctx, `
+		SELECT `+countSessionColumns+` FROM count_sessions s
+		WHERE s.type = $1 AND s.status = 'open' AND s.created_at::date = CURRENT_DATE
+		ORDER BY s.id DESC LIMIT 1
+	`, sessionType)
+}
+
+// getWithItems loads a single count session and its items, returning nil when no row matches
+func (r *stocktakeRepository) getWithItems(ctx context.Context, query string, args ...interface{}) (*models.CountSession, error) {
+	cs, err := scanCountSession(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	items, err := r.getItems(ctx, r.db, cs.ID)
+	if err != nil {
+		return nil, err
+	}
+	cs.Items = items
+
+	return cs, nil
+}
+
+// getItems loads the items of a count session in insertion order
+func (r *stocktakeRepository) getItems(ctx context.Context, q rowsQueryer, sessionID int) ([]models.CountSessionItem, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+countSessionItemColumns+`
+		FROM count_session_items i
+		JOIN products p ON p.id = i.product_id
+		WHERE i.session_id = $1
+		ORDER BY i.id
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.CountSessionItem, 0)
+	for rows.Next() {
+		item, err := scanCountSessionItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetCountCandidates returns every active product with its price, stock
+// and units sold over the last velocityDays days (voided sales excluded)
+func (r *stocktakeRepository) GetCountCandidates(ctx context.Context, velocityDays int) ([]models.CountCandidate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.id, p.price, p.stock, COALESCE(s.sold, 0)
+		FROM products p
+		LEFT JOIN (
+			SELECT td.product_id, SUM(td.quantity) AS sold
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE t.status = 'active' AND t.created_at >= NOW() - make_interval(days => $1)
+			GROUP BY td.product_id
+		) s ON s.product_id = p.id
+		WHERE p.is_active = true
+		ORDER BY p.id
+	`, velocityDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := make([]models.CountCandidate, 0)
+	for rows.Next() {
+		var c models.CountCandidate
+		if err := rows.Scan(&c.ProductID, &c.Price, &c.Stock, &c.UnitsSold); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// GetActiveProductIDs returns the active products, optionally limited to a
+// category and/or to the given IDs, in ID order
+func (r *stocktakeRepository) GetActiveProductIDs(ctx context.Context, categoryID *int, productIDs []int) ([]int, error) {
+	conditions := []string{"is_active = true"}
+	args := []interface{}{}
+	if categoryID != nil {
+		args = append(args, *categoryID)
+		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)))
+	}
+	if len(productIDs) > 0 {
+		args = append(args, productIDs)
+		conditions = append(conditions, fmt.Sprintf("id = ANY($%d)", len(args)))
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM products WHERE `+strings.Join(conditions, " AND ")+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetVarianceLines returns the counted items of a session whose count differs
+// from the expected quantity, valued at the product's current cost price,
+// largest absolute value first
+func (r *stocktakeRepository) GetVarianceLines(ctx context.Context, sessionID int) ([]models.CountVarianceLine, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.product_id, p.name, COALESCE(p.sku, ''), i.expected_qty, i.counted_qty,
+		       i.counted_qty - i.expected_qty, p.cost_price
+		FROM count_session_items i
+		JOIN products p ON p.id = i.product_id
+		WHERE i.session_id = $1 AND i.counted_qty IS NOT NULL AND i.counted_qty <> i.expected_qty
+		ORDER BY ABS((i.counted_qty - i.expected_qty) * p.cost_price) DESC, i.id
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := make([]models.CountVarianceLine, 0)
+	for rows.Next() {
+		var line models.CountVarianceLine
+		err := rows.Scan(
+			&line.ProductID, &line.ProductName, &line.SKU, &line.ExpectedQty, &line.CountedQty,
+			&line.Variance, &line.UnitCost,
+		)
+		if err != nil {
+			return nil, err
+		}
+		line.VarianceValue = line.Variance * line.UnitCost
+		lines = append(lines, line)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Create opens a count session with the given products, snapshotting each
+// product's current stock (at the session's store, if any) as its expected
+// quantity
+func (r *stocktakeRepository) Create(ctx context.Context, session models.CountSession, items []models.CountSessionItem) (*models.CountSession, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO count_sessions (type, status, note, store_id, schedule_id, assigned_to, due_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0))
+		RETURNING id
+	`, session.Type, models.CountStatusOpen, session.Note, session.StoreID, session.ScheduleID, session.AssignedTo,
+		session.DueAt, session.CreatedBy).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO count_session_items (session_id, product_id, sample_weight, expected_qty)
+			SELECT s.id, p.id, $2, `+countExpectedQty+`
+			FROM products p, count_sessions s
+			WHERE s.id = $1 AND p.id = $3
+		`, id, item.SampleWeight, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// RecordCount stores the counted quantity of a product in an open session and
+// refreshes its expected quantity to the stock (at the session's store, if
+// any) at the time of counting. It
+// returns nil when the product is not part of an open session.
+func (r *stocktakeRepository) RecordCount(ctx context.Context, sessionID, productID, countedQty, countedBy int) (*models.CountSessionItem, error) {
+	var itemID int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE count_session_items i
+		SET counted_qty = $1, counted_by = NULLIF($2, 0), counted_at = NOW(),
+		    expected_qty = `+countExpectedQty+`
+		FROM products p, count_sessions s
+		WHERE p.id = i.product_id AND s.id = i.session_id AND s.status = 'open'
+		  AND i.session_id = $3 AND i.product_id = $4
+		RETURNING i.id
+	`, countedQty, countedBy, sessionID, productID).Scan(&itemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return scanCountSessionItem(r.db.QueryRowContext(ctx, `
+		SELECT `+countSessionItemColumns+`
+		FROM count_session_items i
+		JOIN products p ON p.id = i.product_id
+		WHERE i.id = $1
+	`, itemID))
+}
+
+// Complete closes an open session and, in the same database transaction,
+// applies every count variance to stock as a count_correction ledger entry at
+// the session's store (the default store when it has none). All items must
+// have been counted. Stock is never taken below zero.
+func (r *stocktakeRepository) Complete(ctx context.Context, id, completedBy int) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	var storeID sql.NullInt64
+	err = tx.QueryRowContext(ctx, `SELECT status, store_id FROM count_sessions WHERE id = $1 FOR UPDATE`, id).Scan(&status, &storeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return helpers.NewNotFoundError("count session not found")
+		}
+		return err
+	}
+	if status != models.CountStatusOpen {
+		return helpers.NewConflictError(fmt.Sprintf("count session is already %s", status))
+	}
+
+	var uncounted int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM count_session_items WHERE session_id = $1 AND counted_qty IS NULL`, id,
+	).Scan(&uncounted)
+	if err != nil {
+		return err
+	}
+	if uncounted > 0 {
+		return helpers.NewConflictError(fmt.Sprintf("%d items have not been counted", uncounted))
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT product_id, counted_qty - expected_qty
+		FROM count_session_items
+		WHERE session_id = $1 AND counted_qty <> expected_qty
+		ORDER BY id
+	`, id)
+	if err != nil {
+		return err
+	}
+	variances := make(map[int]int)
+	productIDs := make([]int, 0)
+	for rows.Next() {
+		var productID, variance int
+		if err := rows.Scan(&productID, &variance); err != nil {
+			rows.Close()
+			return err
+		}
+		variances[productID] = variance
+		productIDs = append(productIDs, productID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	var actor *int
+	if completedBy > 0 {
+		actor = &completedBy
+	}
+
+	for _, productID := range productIDs {
+		var stock int
+		err = tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&stock)
+		if err != nil {
+			return err
+		}
+		current := stock
+		if storeID.Valid {
+			if current, err = lockStoreStock(ctx, tx, int(storeID.Int64), productID); err != nil {
+				return err
+			}
+		}
+		counted := current + variances[productID]
+		if counted < 0 {
+			counted = 0
+		}
+		delta := counted - current
+
+		var newStock int
+		err = tx.QueryRowContext(ctx,
+			`UPDATE products SET stock = stock + $1, updated_at = NOW() WHERE id = $2 RETURNING stock`,
+			delta, productID,
+		).Scan(&newStock)
+		if err != nil {
+			return err
+		}
+		err = recordStockMovement(ctx, tx, models.StockMovement{
+			ProductID:     productID,
+			StoreID:       int(storeID.Int64),
+			QuantityDelta: delta,
+			BalanceAfter:  newStock,
+			Reason:        models.StockReasonAdjustment,
+			ReasonCode:    models.AdjustmentCountCorrection,
+			ReferenceType: models.StockRefCountSession,
+			ReferenceID:   &id,
+			CreatedBy:     actor,
+		})
+		if err != nil {
+			return err
+		}
+		if delta > 0 {
+			if err := openCostLayer(ctx, tx, productID, models.CostSourceCountSession, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE count_sessions
+		SET status = $1, completed_by = NULLIF($2, 0), completed_at = NOW()
+		WHERE id = $3
+	`, models.CountStatusCompleted, completedBy, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Cancel closes an open session without touching stock. It returns nil when
+// no open session with that ID exists.
+func (r *stocktakeRepository) Cancel(ctx context.Context, id int) (*models.CountSession, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE count_sessions SET status = $1, completed_at = NOW() WHERE id = $2 AND status = 'open'`,
+		models.CountStatusCancelled, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	return r.GetByID(ctx, id)
+}
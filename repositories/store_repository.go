@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// StoreRepository defines the interface for store location data access
+type StoreRepository interface {
+	GetAll(ctx context.Context) ([]models.Store, error)
+	GetByID(ctx context.Context, id int) (*models.Store, error)
+	GetByCode(ctx context.Context, code string) (*models.Store, error)
+	GetDefault(ctx context.Context) (*models.Store, error)
+	Create(ctx context.Context, store models.Store) (*models.Store, error)
+	Update(ctx context.Context, id int, store models.Store) (*models.Store, error)
+	GetStock(ctx context.Context, storeID int) ([]models.StoreStock, error)
+	GetSalesByStore(ctx context.Context, startDate, endDate string) ([]models.StoreSales, error)
+}
+
+// storeRepository implements StoreRepository interface with PostgreSQL
+type storeRepository struct {
+	db DBTX
+}
+
+// NewStoreRepository creates a new store repository instance
+func NewStoreRepository(db DBTX) StoreRepository {
+	return &storeRepository{db: db}
+}
+
+// storeColumns is the standard set of columns selected for store queries
+const storeColumns = `id, code, name, address, is_default, is_active, created_at, updated_at`
+
+// scanStore scans a row into a Store struct
+func scanStore(scanner interface{ Scan(dest ...interface{}) error }) (*models.Store, error) {
+	var s models.Store
+	err := scanner.Scan(&s.ID, &s.Code, &s.Name, &s.Address, &s.IsDefault, &s.IsActive, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetAll returns all stores, the default store first
+func (r *storeRepository) GetAll(ctx context.Context) ([]models.Store, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+storeColumns+` FROM stores ORDER BY is_default DESC, name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stores := make([]models.Store, 0)
+	for rows.Next() {
+		s, err := scanStore(rows)
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, *s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+// GetByID returns a store by its ID
+func (r *storeRepository) GetByID(ctx context.Context, id int) (*models.Store, error) {
+	s, err := scanStore(r.db.QueryRowContext(ctx, `SELECT `+storeColumns+` FROM stores WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetByCode returns a store by its code
+func (r *storeRepository) GetByCode(ctx context.Context, code string) (*models.Store, error) {
+	s, err := scanStore(r.db.QueryRowContext(ctx, `SELECT `+storeColumns+` FROM stores WHERE code = $1`, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetDefault returns the default store
+func (r *storeRepository) GetDefault(ctx context.Context) (*models.Store, error) {
+	s, err := scanStore(r.db.QueryRowContext(ctx, `SELECT `+storeColumns+` FROM stores WHERE is_default`))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create inserts a new store
+func (r *storeRepository) Create(ctx context.Context, store models.Store) (*models.Store, error) {
+	return scanStore(r.db.QueryRowContext(ctx, `
+		INSERT INTO stores (code, name, address, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+storeColumns,
+		store.Code, store.Name, store.Address, store.IsActive,
+	))
+}
+
+// Update modifies an existing store
+func (r *storeRepository) Update(ctx context.Context, id int, store models.Store) (*models.Store, error) {
+	s, err := scanStore(r.db.QueryRowContext(ctx, `
+		UPDATE stores
+		SET code = $1, name = $2, address = $3, is_active = $4, updated_at = $5
+		WHERE id = $6
+		RETURNING `+storeColumns,
+		store.Code, store.Name, store.Address, store.IsActive, time.Now(), id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetStock returns the stock of every product a store has held, by product name
+func (r *storeRepository) GetStock(ctx context.Context, storeID int) ([]models.StoreStock, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ss.store_id, ss.product_id, p.name, COALESCE(p.sku, ''), ss.stock, p.min_stock, ss.updated_at
+		FROM store_stocks ss
+		JOIN products p ON p.id = ss.product_id
+		WHERE ss.store_id = $1
+		ORDER BY p.name, p.id
+	`, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stock := make([]models.StoreStock, 0)
+	for rows.Next() {
+		var s models.StoreStock
+		if err := rows.Scan(&s.StoreID, &s.ProductID, &s.ProductName, &s.SKU, &s.Stock, &s.MinStock, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		stock = append(stock, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// GetSalesByStore returns the non-voided sales of every store in a date
+// range, including stores without sales, highest revenue first
+func (r *storeRepository) GetSalesByStore(ctx context.Context, startDate, endDate string) ([]models.StoreSales, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH sales AS (
+			SELECT t.store_id, SUM(t.total_amount) AS revenue, COUNT(*) AS transactions
+			FROM transactions t
+			WHERE t.status = 'active' AND t.created_at::date >= $1::date AND t.created_at::date <= $2::date
+			GROUP BY t.store_id
+		), items AS (
+			SELECT t.store_id, SUM(td.quantity) AS sold
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE t.status = 'active' AND t.created_at::date >= $1::date AND t.created_at::date <= $2::date
+			GROUP BY t.store_id
+		)
+		SELECT s.id, s.code, s.name, COALESCE(sales.revenue, 0), COALESCE(sales.transactions, 0), COALESCE(items.sold, 0)
+		FROM stores s
+		LEFT JOIN sales ON sales.store_id = s.id
+		LEFT JOIN items ON items.store_id = s.id
+		ORDER BY COALESCE(sales.revenue, 0) DESC, s.id
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := make([]models.StoreSales, 0)
+	for rows.Next() {
+		var s models.StoreSales
+		if err := rows.Scan(&s.StoreID, &s.StoreCode, &s.StoreName, &s.TotalRevenue, &s.TotalTransactions, &s.ItemsSold); err != nil {
+			return nil, err
+		}
+		sales = append(sales, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// SupplierRepository defines the interface for supplier data access
+type SupplierRepository interface {
+	GetAll(ctx context.Context) ([]models.Supplier, error)
+	GetByID(ctx context.Context, id int) (*models.Supplier, error)
+	Create(ctx context.Context, supplier models.Supplier) (*models.Supplier, error)
+	Update(ctx context.Context, id int, supplier models.Supplier) (*models.Supplier, error)
+	Delete(ctx context.Context, id int) error
+	CountConsignmentProducts(ctx context.Context, id int) (int, error)
+}
+
+// supplierRepository implements SupplierRepository interface with PostgreSQL
+type supplierRepository struct {
+	db DBTX
+}
+
+// NewSupplierRepository creates a new supplier repository instance
+func NewSupplierRepository(db DBTX) SupplierRepository {
+	return &supplierRepository{db: db}
+}
+
+// supplierColumns is the standard set of columns selected for supplier queries
+const supplierColumns = `id, name, contact_name, phone, email, address, created_at, updated_at`
+
+// scanSupplier scans a row into a Supplier struct
+func scanSupplier(scanner interface{ Scan(dest ...interface{}) error }) (*models.Supplier, error) {
+	var s models.Supplier
+	err := scanner.Scan(
+		&s.ID, &s.Name, &s.ContactName, &s.Phone, &s.Email, &s.Address, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetAll returns all suppliers ordered by name
+func (r *supplierRepository) GetAll(ctx context.Context) ([]models.Supplier, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+supplierColumns+` FROM suppliers ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]models.Supplier, 0)
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, *s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suppliers, nil
+}
+
+// GetByID returns a supplier by its ID
+func (r *supplierRepository) GetByID(ctx context.Context, id int) (*models.Supplier, error) {
+	s, err := scanSupplier(r.db.QueryRowContext(ctx, `SELECT `+supplierColumns+` FROM suppliers WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create inserts a new supplier
+func (r *supplierRepository) Create(ctx context.Context, supplier models.Supplier) (*models.Supplier, error) {
+	return scanSupplier(r.db.QueryRowContext(ctx, `
+		INSERT INTO suppliers (name, contact_name, phone, email, address)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+supplierColumns,
+		supplier.Name, supplier.ContactName, supplier.Phone, supplier.Email, supplier.Address,
+	))
+}
+
+// Update modifies an existing supplier
+func (r *supplierRepository) Update(ctx context.Context, id int, supplier models.Supplier) (*models.Supplier, error) {
+	s, err := scanSupplier(r.db.QueryRowContext(ctx, `
+		UPDATE suppliers
+		SET name = $1, contact_name = $2, phone = $3, email = $4, address = $5, updated_at = $6
+		WHERE id = $7
+		RETURNING `+supplierColumns,
+		supplier.Name, supplier.ContactName, supplier.Phone, supplier.Email, supplier.Address,
+		time.Now(), id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Delete removes a supplier by its ID
+func (r *supplierRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM suppliers WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CountConsignmentProducts returns how many consignment products a supplier owns
+func (r *supplierRepository) CountConsignmentProducts(ctx context.Context, id int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM products WHERE supplier_id = $1 AND is_consignment = true`, id,
+	).Scan(&count)
+	return count, err
+}
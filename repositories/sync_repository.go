@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// SyncRepository defines the interface for tracking replication of local
+// sales, voids and stock adjustments to an upstream instance
+type SyncRepository interface {
+	GetPending(ctx context.Context, entityType string, limit int) ([]models.SyncRecord, error)
+	Save(ctx context.Context, record models.SyncRecord) error
+	GetStatus(ctx context.Context) ([]models.SyncEntityStatus, error)
+	GetProblems(ctx context.Context, limit int) ([]models.SyncRecord, error)
+	RetryConflicts(ctx context.Context) (int, error)
+}
+
+// syncRepository implements SyncRepository interface with PostgreSQL
+type syncRepository struct {
+	db DBTX
+}
+
+// NewSyncRepository creates a new sync repository instance
+func NewSyncRepository(db DBTX) SyncRepository {
+	return &syncRepository{db: db}
+}
+
+// syncRecordColumns is the standard set of columns selected for sync record queries
+const syncRecordColumns = `id, entity_type, entity_id, status, remote_id, attempts, retry_count, message, created_at, updated_at`
+
+// scanSyncRecord scans a row into a SyncRecord struct
+func scanSyncRecord(scanner interface{ Scan(dest ...interface{}) error }) (*models.SyncRecord, error) {
+	var r models.SyncRecord
+	err := scanner.Scan(&r.ID, &r.EntityType, &r.EntityID, &r.Status, &r.RemoteID, &r.Attempts, &r.RetryCount, &r.Message, &r.CreatedAt, &r.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// syncSources selects the IDs of the local entities of each type that are
+// replicated, with the remote ID a replication of them depends on: voids are
+// replicated only for transactions that reached upstream
+var syncSources = map[string]string{
+	models.SyncEntityTransaction: `SELECT t.id, NULL::int AS remote_id FROM transactions t`,
+	models.SyncEntityVoid: `
+		SELECT t.id, s.remote_id FROM transactions t
+		JOIN sync_records s ON s.entity_type = '` + models.SyncEntityTransaction + `' AND s.entity_id = t.id
+		WHERE t.status = 'void' AND s.status = '` + models.SyncStatusSynced + `' AND s.remote_id IS NOT NULL`,
+	models.SyncEntityAdjustment: `SELECT m.id, NULL::int AS remote_id FROM stock_movements m WHERE m.reason = '` + models.StockReasonAdjustment + `'`,
+}
+
+// GetPending returns the entities of a type that have no sync record yet or
+// whose last attempt failed, fewest attempts first so a failing entity does
+// not hold back the others. The records carry the entity ID, its attempts and
+// retry count so far and, for voids, the remote ID of the transaction.
+func (r *syncRepository) GetPending(ctx context.Context, entityType string, limit int) ([]models.SyncRecord, error) {
+	source, ok := syncSources[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown sync entity type %q", entityType)
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.id, e.remote_id, COALESCE(r.attempts, 0), COALESCE(r.retry_count, 0)
+		FROM (`+source+`) e
+		LEFT JOIN sync_records r ON r.entity_type = $1 AND r.entity_id = e.id
+		WHERE r.id IS NULL OR r.status = $2
+		ORDER BY COALESCE(r.attempts, 0), e.id
+		LIMIT $3
+	`, entityType, models.SyncStatusFailed, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]models.SyncRecord, 0)
+	for rows.Next() {
+		record := models.SyncRecord{EntityType: entityType, Status: models.SyncStatusPending}
+		if err := rows.Scan(&record.EntityID, &record.RemoteID, &record.Attempts, &record.RetryCount); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Save records the outcome of replicating an entity, counting the attempt
+func (r *syncRepository) Save(ctx context.Context, record models.SyncRecord) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO sync_records (entity_type, entity_id, status, remote_id, attempts, message)
+		VALUES ($1, $2, $3, $4, 1, $5)
+		ON CONFLICT (entity_type, entity_id) DO UPDATE
+		SET status = EXCLUDED.status, remote_id = EXCLUDED.remote_id, message = EXCLUDED.message,
+		    attempts = sync_records.attempts + 1, updated_at = NOW()
+	`, record.EntityType, record.EntityID, record.Status, record.RemoteID, record.Message)
+	return err
+}
+
+// GetStatus counts the entities of each type by sync status; pending are
+// those without a record or whose last attempt failed
+func (r *syncRepository) GetStatus(ctx context.Context) ([]models.SyncEntityStatus, error) {
+	entityTypes := []string{models.SyncEntityTransaction, models.SyncEntityVoid, models.SyncEntityAdjustment}
+	statuses := make([]models.SyncEntityStatus, 0, len(entityTypes))
+	for _, entityType := range entityTypes {
+		s := models.SyncEntityStatus{EntityType: entityType}
+		err := r.db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FILTER (WHERE r.id IS NULL OR r.status = $2),
+			       COUNT(*) FILTER (WHERE r.status = $3),
+			       COUNT(*) FILTER (WHERE r.status = $4),
+			       COUNT(*) FILTER (WHERE r.status = $5),
+			       COUNT(*) FILTER (WHERE r.status = $2)
+			FROM (`+syncSources[entityType]+`) e
+			LEFT JOIN sync_records r ON r.entity_type = $1 AND r.entity_id = e.id
+		`, entityType, models.SyncStatusFailed, models.SyncStatusSynced, models.SyncStatusSkipped, models.SyncStatusConflict,
+		).Scan(&s.Pending, &s.Synced, &s.Skipped, &s.Conflict, &s.Failed)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// GetProblems returns the latest conflicts and failures
+func (r *syncRepository) GetProblems(ctx context.Context, limit int) ([]models.SyncRecord, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+syncRecordColumns+`
+		FROM sync_records
+		WHERE status IN ($1, $2)
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $3
+	`, models.SyncStatusConflict, models.SyncStatusFailed, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]models.SyncRecord, 0)
+	for rows.Next() {
+		record, err := scanSyncRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// RetryConflicts marks every conflict as failed so the next run retries it,
+// returning how many there were. The retry count is raised because upstream
+// keeps answering a repeated Idempotency-Key with its first (rejecting) response.
+func (r *syncRepository) RetryConflicts(ctx context.Context) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE sync_records SET status = $1, retry_count = retry_count + 1, updated_at = NOW() WHERE status = $2
+	`, models.SyncStatusFailed, models.SyncStatusConflict)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+)
+
+// TenantRepository defines the interface for tenant data access. Tenants are
+// the one table outside row-level security, so it runs on the default pool.
+type TenantRepository interface {
+	GetAll(ctx context.Context) ([]models.Tenant, error)
+	GetByID(ctx context.Context, id int) (*models.Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Tenant, error)
+	GetByAPIKeyHash(ctx context.Context, hash string) (*models.Tenant, error)
+	Create(ctx context.Context, tenant models.Tenant, apiKeyHash string) (*models.Tenant, error)
+	SetAPIKeyHash(ctx context.Context, id int, apiKeyHash string) error
+}
+
+// tenantRepository implements TenantRepository interface with PostgreSQL
+type tenantRepository struct {
+	db DBTX
+}
+
+// NewTenantRepository creates a new tenant repository instance
+func NewTenantRepository(db DBTX) TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// tenantColumns is the standard set of columns selected for tenant queries
+const tenantColumns = `id, slug, name, api_key_hash IS NOT NULL, created_at`
+
+// scanTenant scans a row into a Tenant struct
+func scanTenant(scanner interface{ Scan(dest ...interface{}) error }) (*models.Tenant, error) {
+	var t models.Tenant
+	if err := scanner.Scan(&t.ID, &t.Slug, &t.Name, &t.HasAPIKey, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// getOne returns the single tenant matched by query, or nil
+func (r *tenantRepository) getOne(ctx context.Context, query string, arg interface{}) (*models.Tenant, error) {
+	t, err := scanTenant(r.db.QueryRowContext(ctx, `SELECT `+tenantColumns+` FROM tenants WHERE `+query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetAll returns every tenant ordered by ID
+func (r *tenantRepository) GetAll(ctx context.Context) ([]models.Tenant, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+tenantColumns+` FROM tenants ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := make([]models.Tenant, 0)
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, *t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+// GetByID returns a tenant by its ID
+func (r *tenantRepository) GetByID(ctx context.Context, id int) (*models.Tenant, error) {
+	return r.getOne(ctx, "id = $1", id)
+}
+
+// GetBySlug returns a tenant by its slug
+func (r *tenantRepository) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	return r.getOne(ctx, "slug = $1", slug)
+}
+
+// GetByAPIKeyHash returns the tenant owning an API key (SHA-256, hex)
+func (r *tenantRepository) GetByAPIKeyHash(ctx context.Context, hash string) (*models.Tenant, error) {
+	return r.getOne(ctx, "api_key_hash = $1", hash)
+}
+
+// Create inserts a new tenant
+func (r *tenantRepository) Create(ctx context.Context, tenant models.Tenant, apiKeyHash string) (*models.Tenant, error) {
+	return scanTenant(r.db.QueryRowContext(ctx, `
+		INSERT INTO tenants (slug, name, api_key_hash)
+		VALUES ($1, $2, $3)
+		RETURNING `+tenantColumns,
+		tenant.Slug, tenant.Name, apiKeyHash,
+	))
+}
+
+// SetAPIKeyHash replaces a tenant's API key
+func (r *tenantRepository) SetAPIKeyHash(ctx context.Context, id int, apiKeyHash string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE tenants SET api_key_hash = $1 WHERE id = $2`, apiKeyHash, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
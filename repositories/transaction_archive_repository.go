@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"retail-core-api/models"
+	"time"
+)
+
+// TransactionArchiveRepository defines the interface for the index of
+// transactions moved to cold storage
+type TransactionArchiveRepository interface {
+	GetArchivableIDs(ctx context.Context, before time.Time, limit int) ([]int, error)
+	GetObjectKey(ctx context.Context, id int) (string, error)
+	MarkArchived(ctx context.Context, transaction models.Transaction, objectKey string) (bool, error)
+}
+
+// transactionArchiveRepository implements TransactionArchiveRepository interface with PostgreSQL
+type transactionArchiveRepository struct {
+	db DBTX
+}
+
+// NewTransactionArchiveRepository creates a new transaction archive repository instance
+func NewTransactionArchiveRepository(db DBTX) TransactionArchiveRepository {
+	return &transactionArchiveRepository{db: db}
+}
+
+// GetArchivableIDs returns the oldest transactions created before the given
+// time. Transactions with consignment payables are never archived: the
+// payables belong to supplier settlements and are deleted with the transaction.
+func (r *transactionArchiveRepository) GetArchivableIDs(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.id FROM transactions t
+		WHERE t.created_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM consignment_payables cp WHERE cp.transaction_id = t.id)
+		ORDER BY t.id
+		LIMIT $2
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetObjectKey returns where an archived transaction is stored, or an empty
+// string when the transaction is not archived
+func (r *transactionArchiveRepository) GetObjectKey(ctx context.Context, id int) (string, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `SELECT object_key FROM archived_transactions WHERE id = $1`, id).Scan(&key)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return key, err
+}
+
+// MarkArchived indexes an uploaded transaction and deletes it from the
+// transactions table, with its details, in a single database transaction. It
+// returns false and changes nothing when the transaction was deleted or its
+// status changed (e.g. voided) after it was uploaded.
+func (r *transactionArchiveRepository) MarkArchived(ctx context.Context, transaction models.Transaction, objectKey string) (bool, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM transactions t
+		WHERE t.id = $1 AND t.status = $2
+		  AND NOT EXISTS (SELECT 1 FROM consignment_payables cp WHERE cp.transaction_id = t.id)
+	`, transaction.ID, transaction.Status)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO archived_transactions (id, receipt_no, total_amount, status, object_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, transaction.ID, transaction.ReceiptNo, transaction.TotalAmount, transaction.Status, objectKey, transaction.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -1,84 +1,68 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"retail-core-api/helpers"
 	"retail-core-api/models"
+	"sort"
+	"strings"
 	"time"
 )
 
 // TransactionRepository defines the interface for transaction data access
 type TransactionRepository interface {
-	CreateTransaction(req models.CheckoutRequest) (*models.Transaction, error)
-	GetAllTransactions(page, limit int, startDate, endDate string) (*models.PaginatedTransactions, error)
-	GetTransactionByID(id int) (*models.Transaction, error)
-	VoidTransaction(id int) error
-	GetDashboardStats() (*models.DashboardStats, error)
-	GetDailySalesReport() (*models.SalesReport, error)
-	GetSalesReportByDateRange(startDate, endDate string) (*models.SalesReport, error)
-	GetReportSummary(startDate, endDate string) (*models.ReportSummary, error)
+	CreateTransaction(ctx context.Context, req models.CheckoutRequest, details []models.TransactionDetail) (*models.Transaction, error)
+	GetAllTransactions(ctx context.Context, params models.TransactionListParams) (*models.PaginatedTransactions, error)
+	GetTransactionByID(ctx context.Context, id int) (*models.Transaction, error)
+	VoidTransaction(ctx context.Context, id int) error
+	GetDashboardStats(ctx context.Context, storeID int) (*models.DashboardStats, error)
+	GetDailySalesReport(ctx context.Context, storeID int) (*models.SalesReport, error)
+	GetSalesReportByDateRange(ctx context.Context, filter models.ReportFilter) (*models.SalesReport, error)
+	GetReportSummary(ctx context.Context, filter models.ReportFilter) (*models.ReportSummary, error)
+	GetProductProfits(ctx context.Context, filter models.ReportFilter) ([]models.ProductProfit, error)
+	GetDailySales(ctx context.Context, filter models.ReportFilter) ([]models.DailySales, error)
+	GetCategorySales(ctx context.Context, filter models.ReportFilter) ([]models.CategorySales, error)
+	GetBestSellers(ctx context.Context, filter models.ReportFilter, limit int) ([]models.BestSeller, error)
+	GetHourlySales(ctx context.Context, filter models.ReportFilter) ([]models.HourlySales, error)
+	GetSalesTimeSeries(ctx context.Context, filter models.ReportFilter, granularity string) ([]models.SalesBucket, error)
 }
 
 // transactionRepository implements TransactionRepository interface
 type transactionRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewTransactionRepository creates a new transaction repository instance
-func NewTransactionRepository(db *sql.DB) TransactionRepository {
+func NewTransactionRepository(db DBTX) TransactionRepository {
 	return &transactionRepository{db: db}
 }
 
-// CreateTransaction processes a checkout: validates products, deducts stock,
-// creates transaction record and detail rows inside a single DB transaction.
-func (repo *transactionRepository) CreateTransaction(req models.CheckoutRequest) (*models.Transaction, error) {
-	tx, err := repo.db.Begin()
+// CreateTransaction processes a checkout: checks stock, deducts it, and
+// creates the transaction record, detail rows and applied promotions inside
+// a single DB transaction. Details arrive already priced by the service layer.
+// Stock for all lines is checked and deducted in one statement, the header
+// and details are inserted in one statement each, and consignment payables,
+// cost layers and applied promotions are booked for all lines together, so
+// the statements per checkout do not grow with the number of lines. The
+// TransactionCreated domain event is written to the outbox in the same DB
+// transaction.
+func (repo *transactionRepository) CreateTransaction(ctx context.Context, req models.CheckoutRequest, details []models.TransactionDetail) (*models.Transaction, error) {
+	tx, err := beginTx(ctx, repo.db)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	totalAmount := 0
-	details := make([]models.TransactionDetail, 0, len(req.Items))
-
-	for _, item := range req.Items {
-		var productPrice, stock int
-		var productName string
-
-		err := tx.QueryRow(
-			"SELECT name, price, stock FROM products WHERE id = $1",
-			item.ProductID,
-		).Scan(&productName, &productPrice, &stock)
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product id %d not found", item.ProductID)
-		}
-		if err != nil {
-			return nil, err
-		}
-
-		if stock < item.Quantity {
-			return nil, fmt.Errorf("insufficient stock for product '%s' (available: %d, requested: %d)",
-				productName, stock, item.Quantity)
-		}
-
-		subtotal := productPrice * item.Quantity
-		totalAmount += subtotal
-
-		_, err = tx.Exec(
-			"UPDATE products SET stock = stock - $1 WHERE id = $2",
-			item.Quantity, item.ProductID,
-		)
-		if err != nil {
-			return nil, err
-		}
+	balances, err := deductCheckoutStock(ctx, tx, req.StoreID, details)
+	if err != nil {
+		return nil, err
+	}
 
-		details = append(details, models.TransactionDetail{
-			ProductID:   item.ProductID,
-			ProductName: productName,
-			Quantity:    item.Quantity,
-			UnitPrice:   productPrice,
-			Subtotal:    subtotal,
-		})
+	totalAmount := 0
+	for _, d := range details {
+		totalAmount += d.Subtotal
 	}
 
 	// Apply discount
@@ -94,73 +78,310 @@ func (repo *transactionRepository) CreateTransaction(req models.CheckoutRequest)
 		paymentMethod = "cash"
 	}
 
-	// Insert transaction header
-	var transactionID int
+	// Insert the transaction header with the next receipt number (INV-YYYYMMDD-NNNN)
+	// and pickup queue number from today's sequences; the upsert row locks keep
+	// concurrent checkouts from receiving the same numbers
+	var transactionID, queueNo int
+	var receiptNo string
 	var createdAt time.Time
-	err = tx.QueryRow(
-		`INSERT INTO transactions (total_amount, payment_method, discount, notes, status) 
-		 VALUES ($1, $2, $3, $4, 'active') RETURNING id, created_at`,
-		finalAmount, paymentMethod, discount, req.Notes,
-	).Scan(&transactionID, &createdAt)
+	err = tx.QueryRowContext(ctx, `
+		WITH receipt AS (
+			INSERT INTO receipt_sequences (seq_date, last_value) VALUES (CURRENT_DATE, 1)
+			ON CONFLICT (tenant_id, seq_date) DO UPDATE SET last_value = receipt_sequences.last_value + 1
+			RETURNING seq_date, last_value
+		), queue AS (
+			INSERT INTO queue_sequences (seq_date, last_issued) VALUES (CURRENT_DATE, 1)
+			ON CONFLICT (tenant_id, seq_date) DO UPDATE SET last_issued = queue_sequences.last_issued + 1
+			RETURNING last_issued
+		)
+		INSERT INTO transactions (store_id, receipt_no, queue_no, total_amount, payment_method, price_level, discount, notes, cashier_id, status)
+		SELECT $1, 'INV-' || to_char(r.seq_date, 'YYYYMMDD') || '-' || lpad(r.last_value::text, GREATEST(4, length(r.last_value::text)), '0'),
+		       q.last_issued, $2, $3, $4, $5, $6, NULLIF($7, 0), 'active'
+		FROM receipt r, queue q
+		RETURNING id, receipt_no, queue_no, created_at
+	`, req.StoreID, finalAmount, paymentMethod, req.PriceLevel, discount, req.Notes, req.CashierID,
+	).Scan(&transactionID, &receiptNo, &queueNo, &createdAt)
 	if err != nil {
 		return nil, err
 	}
 
-	// Insert transaction details
-	for i := range details {
-		details[i].TransactionID = transactionID
+	// Insert all transaction details. PostgreSQL does not promise to return
+	// the rows of a multi-row INSERT in the order of its VALUES, so each line
+	// carries its position and is given its ID from the sequence beforehand;
+	// the IDs come back next to the positions they belong to
+	args := make([]interface{}, 0, 1+len(details)*6)
+	args = append(args, transactionID)
+	for i, d := range details {
+		args = append(args, i, d.ProductID, d.Quantity, d.UnitPrice, d.Discount, d.Subtotal)
+	}
+	rows, err := tx.QueryContext(ctx, `
+		WITH lines (line, product_id, quantity, unit_price, discount, subtotal) AS (
+			VALUES `+valuesList(len(details), 6, 1, "int")+`
+		), numbered AS MATERIALIZED (
+			SELECT nextval(pg_get_serial_sequence('transaction_details', 'id'))::int AS id, lines.*
+			FROM lines
+		), inserted AS (
+			INSERT INTO transaction_details (id, transaction_id, product_id, quantity, unit_price, discount, subtotal)
+			SELECT id, $1::int, product_id, quantity, unit_price, discount, subtotal FROM numbered
+			RETURNING id
+		)
+		SELECT n.line, n.id FROM numbered n JOIN inserted i ON i.id = n.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	inserted := 0
+	for rows.Next() {
+		var line, id int
+		if err := rows.Scan(&line, &id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		details[line].ID = id
+		details[line].TransactionID = transactionID
+		inserted++
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if inserted != len(details) {
+		return nil, fmt.Errorf("inserted %d of %d transaction details", inserted, len(details))
+	}
 
-		var detailID int
-		err = tx.QueryRow(
-			`INSERT INTO transaction_details (transaction_id, product_id, quantity, unit_price, subtotal) 
-			 VALUES ($1, $2, $3, $4, $5) RETURNING id`,
-			transactionID, details[i].ProductID, details[i].Quantity, details[i].UnitPrice, details[i].Subtotal,
-		).Scan(&detailID)
+	// Book consignment payables, cost of goods sold and the promotions
+	// applied to the lines, each for all lines at once
+	if err = accrueConsignmentPayables(ctx, tx, transactionID, details); err != nil {
+		return nil, err
+	}
+	if err = consumeCostLayers(ctx, tx, details); err != nil {
+		return nil, err
+	}
+	args = args[:0]
+	for _, d := range details {
+		for _, ap := range d.Promotions {
+			args = append(args, d.ID, ap.PromotionID, ap.Name, ap.Type, ap.Discount)
+		}
+	}
+	if len(args) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO transaction_detail_promotions (transaction_detail_id, promotion_id, name, type, discount)
+			VALUES `+valuesList(len(args)/5, 5, 0), args...)
 		if err != nil {
 			return nil, err
 		}
-		details[i].ID = detailID
 	}
 
-	if err := tx.Commit(); err != nil {
+	// Write the ledger rows of the sale; the store stock was already deducted
+	// with the product stock
+	args = make([]interface{}, 0, len(details)*7)
+	for i, d := range details {
+		args = append(args, d.ProductID, req.StoreID, -d.Quantity, balances[i], models.StockReasonSale, models.StockRefTransaction, transactionID)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO stock_movements (product_id, store_id, quantity_delta, balance_after, reason, reference_type, reference_id)
+		VALUES `+valuesList(len(details), 7, 0), args...)
+	if err != nil {
 		return nil, err
 	}
 
-	return &models.Transaction{
+	transaction := &models.Transaction{
 		ID:            transactionID,
+		StoreID:       req.StoreID,
+		ReceiptNo:     receiptNo,
+		QueueNo:       queueNo,
 		TotalAmount:   finalAmount,
 		PaymentMethod: paymentMethod,
+		PriceLevel:    req.PriceLevel,
 		Discount:      discount,
 		Notes:         req.Notes,
 		Status:        "active",
 		CreatedAt:     createdAt,
 		Details:       details,
-	}, nil
+	}
+
+	// Announce the sale once it commits; the ledger rows above raise
+	// StockChanged on their own
+	err = recordDomainEvent(ctx, tx, models.DomainEventTransactionCreated, models.AggregateTransaction, transactionID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// deductCheckoutStock checks and deducts the stock of every line of a sale,
+// from the products' total stock and from the store's stock, in one
+// statement. Each UPDATE only matches rows that still hold enough stock, so
+// a product missing from either RETURNING set had too little and the whole
+// DB transaction is abandoned; store stock is only touched for products
+// whose total stock sufficed. Lines of the same product are deducted
+// together; the returned balances are the product's total stock after each
+// line, for the ledger.
+func deductCheckoutStock(ctx context.Context, tx DBTX, storeID int, details []models.TransactionDetail) ([]int, error) {
+	// Deduct in product order, so concurrent checkouts lock rows in the same order
+	quantities := make(map[int]int, len(details))
+	productIDs := make([]int, 0, len(details))
+	for _, d := range details {
+		if _, ok := quantities[d.ProductID]; !ok {
+			productIDs = append(productIDs, d.ProductID)
+		}
+		quantities[d.ProductID] += d.Quantity
+	}
+	sort.Ints(productIDs)
+
+	args := []interface{}{storeID}
+	for _, id := range productIDs {
+		args = append(args, id, quantities[id])
+	}
+	rows, err := tx.QueryContext(ctx, `
+		WITH requested (product_id, quantity) AS (
+			VALUES `+valuesList(len(productIDs), 2, 1, "int")+`
+		), product_rows AS (
+			UPDATE products p SET stock = p.stock - r.quantity
+			FROM requested r
+			WHERE p.id = r.product_id AND p.stock >= r.quantity
+			RETURNING p.id, p.stock, r.quantity
+		), store_rows AS (
+			-- Reading product_rows locks the product rows before the store rows
+			UPDATE store_stocks s SET stock = s.stock - pr.quantity, updated_at = NOW()
+			FROM product_rows pr
+			WHERE s.store_id = $1 AND s.product_id = pr.id AND s.stock >= pr.quantity
+			RETURNING s.product_id
+		)
+		SELECT r.product_id, pr.stock, sr.product_id IS NOT NULL
+		FROM requested r
+		LEFT JOIN product_rows pr ON pr.id = r.product_id
+		LEFT JOIN store_rows sr ON sr.product_id = r.product_id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[int]int, len(productIDs))
+	shortAtStore := make(map[int]bool)
+	for rows.Next() {
+		var productID int
+		var stock sql.NullInt64
+		var storeOK bool
+		if err := rows.Scan(&productID, &stock, &storeOK); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if stock.Valid {
+			remaining[productID] = int(stock.Int64)
+		}
+		if !storeOK {
+			shortAtStore[productID] = true
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Report the first line that could not be served, as the line-by-line
+	// checks did
+	for _, d := range details {
+		if _, ok := remaining[d.ProductID]; !ok {
+			return nil, insufficientStock(ctx, tx, d, quantities[d.ProductID])
+		}
+	}
+	for _, d := range details {
+		if shortAtStore[d.ProductID] {
+			var storeStock int
+			err := tx.QueryRowContext(ctx, `SELECT COALESCE((SELECT stock FROM store_stocks WHERE store_id = $1 AND product_id = $2), 0)`,
+				storeID, d.ProductID).Scan(&storeStock)
+			if err != nil {
+				return nil, err
+			}
+			return nil, helpers.NewCodedError(helpers.CodeInsufficientStoreStock,
+				d.ProductName, storeStock, quantities[d.ProductID])
+		}
+	}
+
+	// Give every line the balance after it, counting back from the total
+	// stock after the sale
+	balances := make([]int, len(details))
+	for i := len(details) - 1; i >= 0; i-- {
+		balances[i] = remaining[details[i].ProductID]
+		remaining[details[i].ProductID] += details[i].Quantity
+	}
+	return balances, nil
 }
 
-// VoidTransaction marks a transaction as void and restores product stock
-func (repo *transactionRepository) VoidTransaction(id int) error {
-	tx, err := repo.db.Begin()
+// insufficientStock explains why the total stock of a product could not
+// cover a sale; the product row was left unchanged, so its stock is current
+func insufficientStock(ctx context.Context, tx DBTX, d models.TransactionDetail, quantity int) error {
+	var stock int
+	err := tx.QueryRowContext(ctx, "SELECT stock FROM products WHERE id = $1", d.ProductID).Scan(&stock)
+	if err == sql.ErrNoRows {
+		return helpers.NewCodedError(helpers.CodeProductNotFound, d.ProductID)
+	}
+	if err != nil {
+		return err
+	}
+	return helpers.NewCodedError(helpers.CodeInsufficientStock, d.ProductName, stock, quantity)
+}
+
+// valuesList returns the rows of a VALUES list of n rows of cols placeholders
+// each, numbered after offset: "($1, $2), ($3, $4)". Types, when given, cast
+// the placeholders of each column, for VALUES whose types Postgres cannot infer.
+func valuesList(n, cols, offset int, types ...string) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := 0; j < cols; j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", offset+i*cols+j+1)
+			if len(types) > 0 {
+				b.WriteString("::" + types[j%len(types)])
+			}
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// VoidTransaction marks a transaction as void and restores product stock at
+// the store it was sold from. The status changes first, guarded by the
+// current one, so of two concurrent voids only one restores the stock.
+func (repo *transactionRepository) VoidTransaction(ctx context.Context, id int) error {
+	tx, err := beginTx(ctx, repo.db)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// Check current status
-	var status string
-	err = tx.QueryRow("SELECT status FROM transactions WHERE id = $1", id).Scan(&status)
+	// Mark as void; a concurrent void waits on the row lock, then finds it void
+	var storeID int
+	err = tx.QueryRowContext(ctx,
+		"UPDATE transactions SET status = 'void' WHERE id = $1 AND status <> 'void' RETURNING store_id", id,
+	).Scan(&storeID)
 	if err == sql.ErrNoRows {
-		return fmt.Errorf("transaction id %d not found", id)
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM transactions WHERE id = $1)", id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return helpers.NewCodedError(helpers.CodeTransactionNotFound, id)
+		}
+		return helpers.NewCodedError(helpers.CodeTransactionVoided)
 	}
 	if err != nil {
 		return err
 	}
-	if status == "void" {
-		return fmt.Errorf("transaction is already voided")
-	}
 
 	// Restore stock
-	rows, err := tx.Query(
+	rows, err := tx.QueryContext(ctx,
 		"SELECT product_id, quantity FROM transaction_details WHERE transaction_id = $1", id,
 	)
 	if err != nil {
@@ -183,93 +404,131 @@ func (repo *transactionRepository) VoidTransaction(id int) error {
 	rows.Close()
 
 	for _, ri := range items {
-		_, err = tx.Exec("UPDATE products SET stock = stock + $1 WHERE id = $2", ri.quantity, ri.productID)
+		var balance int
+		err = tx.QueryRowContext(ctx, "UPDATE products SET stock = stock + $1 WHERE id = $2 RETURNING stock", ri.quantity, ri.productID).Scan(&balance)
+		if err == sql.ErrNoRows {
+			// Product was deleted since the sale; nothing to restock
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = recordStockMovement(ctx, tx, models.StockMovement{
+			ProductID:     ri.productID,
+			StoreID:       storeID,
+			QuantityDelta: ri.quantity,
+			BalanceAfter:  balance,
+			Reason:        models.StockReasonRefund,
+			ReferenceType: models.StockRefTransaction,
+			ReferenceID:   &id,
+			Note:          "transaction voided",
+		})
 		if err != nil {
 			return err
 		}
 	}
 
-	// Mark as void
-	_, err = tx.Exec("UPDATE transactions SET status = 'void' WHERE id = $1", id)
-	if err != nil {
+	// Consigned goods went back on the shelf, so nothing is owed for them
+	if err = reverseConsignmentPayables(ctx, tx, id); err != nil {
+		return err
+	}
+
+	// Returned units go back into the cost layers they were sold from
+	if err = restoreCostLayers(ctx, tx, id); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
-// GetDailySalesReport returns the sales summary for today
-func (repo *transactionRepository) GetDailySalesReport() (*models.SalesReport, error) {
+// GetDailySalesReport returns the sales summary for today at a store, or
+// across all stores when storeID is 0
+func (repo *transactionRepository) GetDailySalesReport(ctx context.Context, storeID int) (*models.SalesReport, error) {
+	today := time.Now().Format("2006-01-02")
+	return repo.GetSalesReportByDateRange(ctx, models.ReportFilter{StartDate: today, EndDate: today, StoreID: storeID})
+}
+
+// GetSalesReportByDateRange returns the sales summary for the transactions
+// matching filter
+func (repo *transactionRepository) GetSalesReportByDateRange(ctx context.Context, filter models.ReportFilter) (*models.SalesReport, error) {
 	report := &models.SalesReport{}
 
-	err := repo.db.QueryRow(`
-		SELECT COALESCE(SUM(total_amount), 0), COUNT(*)
-		FROM transactions
-		WHERE created_at::date = CURRENT_DATE AND status = 'active'
-	`).Scan(&report.TotalRevenue, &report.TotalTransactions)
+	q := &reportQuery{}
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(t.total_amount), 0), COUNT(*)
+		FROM transactions t
+		WHERE `+q.sales(filter), q.args...).Scan(&report.TotalRevenue, &report.TotalTransactions)
 	if err != nil {
 		return nil, err
 	}
 
-	var best models.BestSellingProduct
-	err = repo.db.QueryRow(`
-		SELECT p.name, COALESCE(SUM(td.quantity), 0) AS qty_sold
-		FROM transaction_details td
-		JOIN transactions t ON td.transaction_id = t.id
-		JOIN products p ON td.product_id = p.id
-		WHERE t.created_at::date = CURRENT_DATE AND t.status = 'active'
-		GROUP BY p.id, p.name
-		ORDER BY qty_sold DESC
-		LIMIT 1
-	`).Scan(&best.Name, &best.QtySold)
-	if err == sql.ErrNoRows {
-		report.BestSellingProduct = nil
-	} else if err != nil {
+	if err = repo.fillCostOfSales(ctx, report, filter); err != nil {
 		return nil, err
-	} else {
-		report.BestSellingProduct = &best
 	}
 
+	best, err := repo.bestSellingProduct(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	report.BestSellingProduct = best
+
 	return report, nil
 }
 
-// GetSalesReportByDateRange returns the sales summary for a given date range
-func (repo *transactionRepository) GetSalesReportByDateRange(startDate, endDate string) (*models.SalesReport, error) {
-	report := &models.SalesReport{}
-
-	err := repo.db.QueryRow(`
-		SELECT COALESCE(SUM(total_amount), 0), COUNT(*)
-		FROM transactions
-		WHERE created_at::date >= $1::date AND created_at::date <= $2::date AND status = 'active'
-	`, startDate, endDate).Scan(&report.TotalRevenue, &report.TotalTransactions)
+// fillCostOfSales adds the FIFO cost of goods sold and the supplier share of
+// consigned sales for the transactions matching filter, and derives gross
+// profit from them. Like revenue they cover whole transactions, so gross
+// profit stays consistent under a category filter.
+func (repo *transactionRepository) fillCostOfSales(ctx context.Context, report *models.SalesReport, filter models.ReportFilter) error {
+	q := &reportQuery{}
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE((SELECT SUM(td.cost_amount)
+			          FROM transaction_details td
+			          JOIN transactions t ON t.id = td.transaction_id
+			          WHERE `+q.sales(filter)+`), 0),
+			COALESCE((SELECT SUM(cp.amount)
+			          FROM consignment_payables cp
+			          JOIN transactions t ON t.id = cp.transaction_id
+			          WHERE cp.entry_type = `+q.arg(models.ConsignmentEntrySale)+` AND `+q.sales(filter)+`), 0)
+	`, q.args...).Scan(&report.TotalCOGS, &report.ConsignmentPayable)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
+	report.GrossProfit = report.TotalRevenue - report.TotalCOGS - report.ConsignmentPayable
+	return nil
+}
+
+// bestSellingProduct returns the product sold most by quantity in the lines
+// matching filter, or nil when nothing was sold
+func (repo *transactionRepository) bestSellingProduct(ctx context.Context, filter models.ReportFilter) (*models.BestSellingProduct, error) {
+	q := &reportQuery{}
 	var best models.BestSellingProduct
-	err = repo.db.QueryRow(`
+	err := repo.db.QueryRowContext(ctx, `
 		SELECT p.name, COALESCE(SUM(td.quantity), 0) AS qty_sold
 		FROM transaction_details td
 		JOIN transactions t ON td.transaction_id = t.id
 		JOIN products p ON td.product_id = p.id
-		WHERE t.created_at::date >= $1::date AND t.created_at::date <= $2::date AND t.status = 'active'
+		WHERE `+q.lines(filter)+`
 		GROUP BY p.id, p.name
 		ORDER BY qty_sold DESC
 		LIMIT 1
-	`, startDate, endDate).Scan(&best.Name, &best.QtySold)
+	`, q.args...).Scan(&best.Name, &best.QtySold)
 	if err == sql.ErrNoRows {
-		report.BestSellingProduct = nil
-	} else if err != nil {
+		return nil, nil
+	}
+	if err != nil {
 		return nil, err
-	} else {
-		report.BestSellingProduct = &best
 	}
-
-	return report, nil
+	return &best, nil
 }
 
-// GetAllTransactions returns a paginated list of transactions with optional date filtering
-func (repo *transactionRepository) GetAllTransactions(page, limit int, startDate, endDate string) (*models.PaginatedTransactions, error) {
+// GetAllTransactions returns a paginated list of transactions with optional
+// date range and receipt number filtering
+func (repo *transactionRepository) GetAllTransactions(ctx context.Context, params models.TransactionListParams) (*models.PaginatedTransactions, error) {
+	page, limit := params.Page, params.Limit
 	if page < 1 {
 		page = 1
 	}
@@ -278,44 +537,54 @@ func (repo *transactionRepository) GetAllTransactions(page, limit int, startDate
 	}
 	offset := (page - 1) * limit
 
-	// Build WHERE clause for date filters
+	// Build WHERE clause for filters
 	where := " WHERE 1=1"
 	args := []interface{}{}
 	argIdx := 1
 
-	if startDate != "" {
+	if params.StartDate != "" {
 		where += fmt.Sprintf(" AND t.created_at::date >= $%d::date", argIdx)
-		args = append(args, startDate)
+		args = append(args, params.StartDate)
 		argIdx++
 	}
-	if endDate != "" {
+	if params.EndDate != "" {
 		where += fmt.Sprintf(" AND t.created_at::date <= $%d::date", argIdx)
-		args = append(args, endDate)
+		args = append(args, params.EndDate)
+		argIdx++
+	}
+	if params.StoreID > 0 {
+		where += fmt.Sprintf(" AND t.store_id = $%d", argIdx)
+		args = append(args, params.StoreID)
+		argIdx++
+	}
+	if params.ReceiptNo != "" {
+		where += fmt.Sprintf(" AND t.receipt_no ILIKE $%d", argIdx)
+		args = append(args, "%"+params.ReceiptNo+"%")
 		argIdx++
 	}
 
 	// Count total
 	countQuery := "SELECT COUNT(*) FROM transactions t" + where
 	var total int
-	err := repo.db.QueryRow(countQuery, args...).Scan(&total)
+	err := repo.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	// Fetch page
 	query := fmt.Sprintf(`
-		SELECT t.id, t.total_amount, t.payment_method, t.discount, t.status,
+		SELECT t.id, t.store_id, COALESCE(t.receipt_no, ''), COALESCE(t.queue_no, 0), t.total_amount, t.payment_method, t.discount, t.status,
 		       COUNT(td.id) AS item_count, t.created_at
 		FROM transactions t
 		LEFT JOIN transaction_details td ON td.transaction_id = t.id
 		%s
-		GROUP BY t.id, t.total_amount, t.payment_method, t.discount, t.status, t.created_at
+		GROUP BY t.id, t.store_id, t.receipt_no, t.queue_no, t.total_amount, t.payment_method, t.discount, t.status, t.created_at
 		ORDER BY t.created_at DESC
 		LIMIT $%d OFFSET $%d
 	`, where, argIdx, argIdx+1)
 	args = append(args, limit, offset)
 
-	rows, err := repo.db.Query(query, args...)
+	rows, err := repo.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -324,7 +593,7 @@ func (repo *transactionRepository) GetAllTransactions(page, limit int, startDate
 	items := make([]models.TransactionListItem, 0)
 	for rows.Next() {
 		var item models.TransactionListItem
-		if err := rows.Scan(&item.ID, &item.TotalAmount, &item.PaymentMethod, &item.Discount, &item.Status, &item.ItemCount, &item.CreatedAt); err != nil {
+		if err := rows.Scan(&item.ID, &item.StoreID, &item.ReceiptNo, &item.QueueNo, &item.TotalAmount, &item.PaymentMethod, &item.Discount, &item.Status, &item.ItemCount, &item.CreatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -342,23 +611,23 @@ func (repo *transactionRepository) GetAllTransactions(page, limit int, startDate
 }
 
 // GetTransactionByID returns a single transaction with all its details
-func (repo *transactionRepository) GetTransactionByID(id int) (*models.Transaction, error) {
+func (repo *transactionRepository) GetTransactionByID(ctx context.Context, id int) (*models.Transaction, error) {
 	var t models.Transaction
-	err := repo.db.QueryRow(`
-		SELECT id, total_amount, payment_method, discount, notes, status, created_at 
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT id, store_id, COALESCE(receipt_no, ''), COALESCE(queue_no, 0), total_amount, payment_method, price_level, discount, notes, status, created_at 
 		FROM transactions WHERE id = $1
-	`, id).Scan(&t.ID, &t.TotalAmount, &t.PaymentMethod, &t.Discount, &t.Notes, &t.Status, &t.CreatedAt)
+	`, id).Scan(&t.ID, &t.StoreID, &t.ReceiptNo, &t.QueueNo, &t.TotalAmount, &t.PaymentMethod, &t.PriceLevel, &t.Discount, &t.Notes, &t.Status, &t.CreatedAt)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("transaction id %d not found", id)
+		return nil, helpers.NewCodedError(helpers.CodeTransactionNotFound, id)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := repo.db.Query(`
+	rows, err := repo.db.QueryContext(ctx, `
 		SELECT td.id, td.transaction_id, td.product_id,
 		       COALESCE(p.name, 'Deleted Product') AS product_name,
-		       td.quantity, td.unit_price, td.subtotal
+		       td.quantity, td.unit_price, COALESCE(td.discount, 0), td.subtotal
 		FROM transaction_details td
 		LEFT JOIN products p ON p.id = td.product_id
 		WHERE td.transaction_id = $1
@@ -370,56 +639,93 @@ func (repo *transactionRepository) GetTransactionByID(id int) (*models.Transacti
 	defer rows.Close()
 
 	details := make([]models.TransactionDetail, 0)
+	detailIndex := make(map[int]int)
 	for rows.Next() {
 		var d models.TransactionDetail
-		if err := rows.Scan(&d.ID, &d.TransactionID, &d.ProductID, &d.ProductName, &d.Quantity, &d.UnitPrice, &d.Subtotal); err != nil {
+		if err := rows.Scan(&d.ID, &d.TransactionID, &d.ProductID, &d.ProductName, &d.Quantity, &d.UnitPrice, &d.Discount, &d.Subtotal); err != nil {
 			return nil, err
 		}
+		d.Promotions = make([]models.AppliedPromotion, 0)
+		detailIndex[d.ID] = len(details)
 		details = append(details, d)
 	}
+	rows.Close()
+
+	// Attach applied promotions to their detail lines
+	promoRows, err := repo.db.QueryContext(ctx, `
+		SELECT tdp.transaction_detail_id, COALESCE(tdp.promotion_id, 0), tdp.name, tdp.type, tdp.discount
+		FROM transaction_detail_promotions tdp
+		JOIN transaction_details td ON td.id = tdp.transaction_detail_id
+		WHERE td.transaction_id = $1
+		ORDER BY tdp.id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer promoRows.Close()
+
+	for promoRows.Next() {
+		var detailID int
+		var ap models.AppliedPromotion
+		if err := promoRows.Scan(&detailID, &ap.PromotionID, &ap.Name, &ap.Type, &ap.Discount); err != nil {
+			return nil, err
+		}
+		if idx, ok := detailIndex[detailID]; ok {
+			details[idx].Promotions = append(details[idx].Promotions, ap)
+		}
+	}
+
 	t.Details = details
 	return &t, nil
 }
 
-// GetDashboardStats returns summary statistics for the admin dashboard
-func (repo *transactionRepository) GetDashboardStats() (*models.DashboardStats, error) {
+// GetDashboardStats returns summary statistics for the admin dashboard for a
+// store, or across all stores when storeID is 0
+func (repo *transactionRepository) GetDashboardStats(ctx context.Context, storeID int) (*models.DashboardStats, error) {
 	stats := &models.DashboardStats{}
 
-	err := repo.db.QueryRow(`
+	err := repo.db.QueryRowContext(ctx, `
 		SELECT COALESCE(SUM(total_amount), 0), COUNT(*)
 		FROM transactions
-		WHERE created_at::date = CURRENT_DATE AND status = 'active'
-	`).Scan(&stats.TotalRevenueToday, &stats.TransactionsToday)
+		WHERE created_at::date = CURRENT_DATE AND status = 'active' AND ($1 = 0 OR store_id = $1)
+	`, storeID).Scan(&stats.TotalRevenueToday, &stats.TransactionsToday)
 	if err != nil {
 		return nil, err
 	}
 
-	err = repo.db.QueryRow(`SELECT COUNT(*) FROM products`).Scan(&stats.TotalProducts)
+	err = repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM products`).Scan(&stats.TotalProducts)
 	if err != nil {
 		return nil, err
 	}
 
-	err = repo.db.QueryRow(`SELECT COUNT(*) FROM categories`).Scan(&stats.TotalCategories)
+	err = repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM categories`).Scan(&stats.TotalCategories)
 	if err != nil {
 		return nil, err
 	}
 
-	err = repo.db.QueryRow(`SELECT COUNT(*) FROM products WHERE stock < 10`).Scan(&stats.LowStockCount)
+	// At a single store a product is low when the store's own stock of it is
+	// at or below min_stock
+	err = repo.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM products p
+		LEFT JOIN store_stocks ss ON ss.product_id = p.id AND ss.store_id = $1
+		WHERE p.is_active = true
+		  AND CASE WHEN $1 = 0 THEN p.stock ELSE COALESCE(ss.stock, 0) END <= p.min_stock
+	`, storeID).Scan(&stats.LowStockCount)
 	if err != nil {
 		return nil, err
 	}
 
 	var best models.BestSellingProduct
-	err = repo.db.QueryRow(`
+	err = repo.db.QueryRowContext(ctx, `
 		SELECT p.name, COALESCE(SUM(td.quantity), 0) AS qty_sold
 		FROM transaction_details td
 		JOIN transactions t ON td.transaction_id = t.id
 		JOIN products p ON td.product_id = p.id
-		WHERE t.created_at::date = CURRENT_DATE AND t.status = 'active'
+		WHERE t.created_at::date = CURRENT_DATE AND t.status = 'active' AND ($1 = 0 OR t.store_id = $1)
 		GROUP BY p.id, p.name
 		ORDER BY qty_sold DESC
 		LIMIT 1
-	`).Scan(&best.Name, &best.QtySold)
+	`, storeID).Scan(&best.Name, &best.QtySold)
 	if err == sql.ErrNoRows {
 		stats.BestSellingToday = nil
 	} else if err != nil {
@@ -431,66 +737,39 @@ func (repo *transactionRepository) GetDashboardStats() (*models.DashboardStats,
 	return stats, nil
 }
 
-// GetReportSummary returns an aggregated report with category breakdown
-func (repo *transactionRepository) GetReportSummary(startDate, endDate string) (*models.ReportSummary, error) {
+// GetReportSummary returns an aggregated report with category breakdown for
+// the transactions matching filter
+func (repo *transactionRepository) GetReportSummary(ctx context.Context, filter models.ReportFilter) (*models.ReportSummary, error) {
 	summary := &models.ReportSummary{}
 
-	// Build date filter
-	where := " WHERE t.status = 'active'"
-	args := []interface{}{}
-	argIdx := 1
-	if startDate != "" {
-		where += fmt.Sprintf(" AND t.created_at::date >= $%d::date", argIdx)
-		args = append(args, startDate)
-		argIdx++
-	}
-	if endDate != "" {
-		where += fmt.Sprintf(" AND t.created_at::date <= $%d::date", argIdx)
-		args = append(args, endDate)
-		argIdx++
-	}
-
 	// Total revenue and transactions
-	totalQuery := "SELECT COALESCE(SUM(t.total_amount), 0), COUNT(*) FROM transactions t" + where
-	err := repo.db.QueryRow(totalQuery, args...).Scan(&summary.TotalRevenue, &summary.TotalTransactions)
+	q := &reportQuery{}
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(t.total_amount), 0), COUNT(*)
+		FROM transactions t
+		WHERE `+q.sales(filter), q.args...).Scan(&summary.TotalRevenue, &summary.TotalTransactions)
 	if err != nil {
 		return nil, err
 	}
 
 	// Best selling product
-	bestQuery := fmt.Sprintf(`
-		SELECT p.name, COALESCE(SUM(td.quantity), 0) AS qty_sold
-		FROM transaction_details td
-		JOIN transactions t ON td.transaction_id = t.id
-		JOIN products p ON td.product_id = p.id
-		%s
-		GROUP BY p.id, p.name
-		ORDER BY qty_sold DESC
-		LIMIT 1
-	`, where)
-	var best models.BestSellingProduct
-	err = repo.db.QueryRow(bestQuery, args...).Scan(&best.Name, &best.QtySold)
-	if err == sql.ErrNoRows {
-		summary.BestSellingProduct = nil
-	} else if err != nil {
+	if summary.BestSellingProduct, err = repo.bestSellingProduct(ctx, filter); err != nil {
 		return nil, err
-	} else {
-		summary.BestSellingProduct = &best
 	}
 
 	// Category breakdown
-	catQuery := fmt.Sprintf(`
+	q = &reportQuery{}
+	rows, err := repo.db.QueryContext(ctx, `
 		SELECT COALESCE(p.category_id, 0), COALESCE(c.name, 'Uncategorized'),
 		       COALESCE(SUM(td.subtotal), 0), COUNT(DISTINCT t.id)
 		FROM transaction_details td
 		JOIN transactions t ON td.transaction_id = t.id
 		JOIN products p ON td.product_id = p.id
 		LEFT JOIN categories c ON p.category_id = c.id
-		%s
+		WHERE `+q.lines(filter)+`
 		GROUP BY p.category_id, c.name
 		ORDER BY SUM(td.subtotal) DESC
-	`, where)
-	rows, err := repo.db.Query(catQuery, args...)
+	`, q.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -508,3 +787,264 @@ func (repo *transactionRepository) GetReportSummary(startDate, endDate string) (
 
 	return summary, nil
 }
+
+// GetProductProfits returns revenue and cost of goods sold per product for
+// the lines matching filter, highest gross profit first.
+// Transaction-level discounts are spread over the lines in proportion to
+// their subtotal, so revenue adds up to the transaction totals. Consigned
+// lines are costed at the supplier payable instead of COGS.
+func (repo *transactionRepository) GetProductProfits(ctx context.Context, filter models.ReportFilter) ([]models.ProductProfit, error) {
+	q := &reportQuery{}
+	rows, err := repo.db.QueryContext(ctx, `
+		WITH lines AS (
+			SELECT td.product_id, td.quantity, `+lineRevenue+` AS revenue,
+			       td.cost_amount + COALESCE(cp.amount, 0) AS cost
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			LEFT JOIN consignment_payables cp ON cp.transaction_detail_id = td.id AND cp.entry_type = `+q.arg(models.ConsignmentEntrySale)+`
+			WHERE `+q.sales(filter)+`
+		)
+		SELECT l.product_id, COALESCE(p.name, ''), p.category_id, COALESCE(c.name, ''),
+		       SUM(l.quantity), SUM(l.revenue)::int, SUM(l.cost)::int
+		FROM lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE `+q.category(filter, "l.product_id")+`
+		GROUP BY l.product_id, p.name, p.category_id, c.name
+		ORDER BY SUM(l.revenue) - SUM(l.cost) DESC, l.product_id
+	`, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profits := make([]models.ProductProfit, 0)
+	for rows.Next() {
+		var p models.ProductProfit
+		err := rows.Scan(
+			&p.ProductID, &p.ProductName, &p.CategoryID, &p.CategoryName,
+			&p.QtySold, &p.Revenue, &p.COGS,
+		)
+		if err != nil {
+			return nil, err
+		}
+		profits = append(profits, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return profits, nil
+}
+
+// GetCategorySales returns quantity sold, revenue and the number of
+// transactions per category for the lines matching filter, highest revenue
+// first. Products are grouped under their current category. Transaction-level
+// discounts are spread over the lines as in GetProductProfits, so revenue adds
+// up to the transaction totals.
+func (repo *transactionRepository) GetCategorySales(ctx context.Context, filter models.ReportFilter) ([]models.CategorySales, error) {
+	q := &reportQuery{}
+	rows, err := repo.db.QueryContext(ctx, `
+		WITH lines AS (
+			SELECT td.transaction_id, td.product_id, td.quantity, `+lineRevenue+` AS revenue
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE `+q.sales(filter)+`
+		)
+		SELECT p.category_id, COALESCE(c.name, 'Uncategorized'),
+		       SUM(l.quantity), SUM(l.revenue)::int, COUNT(DISTINCT l.transaction_id)
+		FROM lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE `+q.category(filter, "l.product_id")+`
+		GROUP BY p.category_id, c.name
+		ORDER BY SUM(l.revenue) DESC, p.category_id
+	`, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.CategorySales, 0)
+	for rows.Next() {
+		var cs models.CategorySales
+		if err := rows.Scan(&cs.CategoryID, &cs.CategoryName, &cs.QtySold, &cs.Revenue, &cs.Transactions); err != nil {
+			return nil, err
+		}
+		categories = append(categories, cs)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// GetDailySales returns revenue, transactions and items sold per day for the
+// transactions matching filter, which must have a start and end date. Days
+// without sales are included with zeros.
+func (repo *transactionRepository) GetDailySales(ctx context.Context, filter models.ReportFilter) ([]models.DailySales, error) {
+	q := &reportQuery{}
+	rows, err := repo.db.QueryContext(ctx, `
+		WITH sales AS (
+			SELECT t.created_at::date AS day, COUNT(*) AS transactions, SUM(t.total_amount) AS revenue
+			FROM transactions t
+			WHERE `+q.sales(filter)+`
+			GROUP BY 1
+		), items AS (
+			SELECT t.created_at::date AS day, SUM(td.quantity) AS items_sold
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE `+q.lines(filter)+`
+			GROUP BY 1
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'), COALESCE(s.transactions, 0),
+		       COALESCE(i.items_sold, 0), COALESCE(s.revenue, 0)
+		FROM generate_series(`+q.arg(filter.StartDate)+`::date, `+q.arg(filter.EndDate)+`::date, interval '1 day') AS d(day)
+		LEFT JOIN sales s ON s.day = d.day::date
+		LEFT JOIN items i ON i.day = d.day::date
+		ORDER BY d.day
+	`, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := make([]models.DailySales, 0)
+	for rows.Next() {
+		var d models.DailySales
+		if err := rows.Scan(&d.Date, &d.Transactions, &d.ItemsSold, &d.Revenue); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
+
+// GetSalesTimeSeries returns revenue, transactions and items sold per day,
+// week or month (granularity) for the transactions matching filter, which
+// must have a start and end date. Weeks start on Monday; the first and last
+// buckets are cut to the filter's dates, and buckets without sales are
+// included with zeros.
+func (repo *transactionRepository) GetSalesTimeSeries(ctx context.Context, filter models.ReportFilter, granularity string) ([]models.SalesBucket, error) {
+	q := &reportQuery{}
+	unit, start, end := q.arg(granularity), q.arg(filter.StartDate), q.arg(filter.EndDate)
+	rows, err := repo.db.QueryContext(ctx, `
+		WITH sales AS (
+			SELECT date_trunc(`+unit+`, t.created_at)::date AS bucket, COUNT(*) AS transactions, SUM(t.total_amount) AS revenue
+			FROM transactions t
+			WHERE `+q.sales(filter)+`
+			GROUP BY 1
+		), items AS (
+			SELECT date_trunc(`+unit+`, t.created_at)::date AS bucket, SUM(td.quantity) AS items_sold
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE `+q.lines(filter)+`
+			GROUP BY 1
+		)
+		SELECT to_char(GREATEST(b.bucket::date, `+start+`::date), 'YYYY-MM-DD'),
+		       to_char(LEAST((b.bucket + ('1 ' || `+unit+`)::interval)::date - 1, `+end+`::date), 'YYYY-MM-DD'),
+		       COALESCE(s.revenue, 0), COALESCE(s.transactions, 0), COALESCE(i.items_sold, 0)
+		FROM generate_series(date_trunc(`+unit+`, `+start+`::timestamp), `+end+`::timestamp, ('1 ' || `+unit+`)::interval) AS b(bucket)
+		LEFT JOIN sales s ON s.bucket = b.bucket::date
+		LEFT JOIN items i ON i.bucket = b.bucket::date
+		ORDER BY b.bucket
+	`, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]models.SalesBucket, 0)
+	for rows.Next() {
+		var b models.SalesBucket
+		if err := rows.Scan(&b.Start, &b.End, &b.Revenue, &b.Transactions, &b.ItemsSold); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+// GetHourlySales returns transactions and revenue per hour of the day for the
+// transactions matching filter, usually a single date. All 24 hours are
+// included, with zeros for hours without sales.
+func (repo *transactionRepository) GetHourlySales(ctx context.Context, filter models.ReportFilter) ([]models.HourlySales, error) {
+	q := &reportQuery{}
+	rows, err := repo.db.QueryContext(ctx, `
+		SELECT h.hour, COUNT(t.id), COALESCE(SUM(t.total_amount), 0)
+		FROM generate_series(0, 23) AS h(hour)
+		LEFT JOIN transactions t ON EXTRACT(HOUR FROM t.created_at) = h.hour
+		  AND `+q.sales(filter)+`
+		GROUP BY h.hour
+		ORDER BY h.hour
+	`, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := make([]models.HourlySales, 0, 24)
+	for rows.Next() {
+		var h models.HourlySales
+		if err := rows.Scan(&h.Hour, &h.Transactions, &h.Revenue); err != nil {
+			return nil, err
+		}
+		hours = append(hours, h)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// GetBestSellers returns the products sold most in the lines matching filter,
+// by quantity and then revenue. Revenue is net of transaction discounts,
+// which are spread over the lines by subtotal.
+func (repo *transactionRepository) GetBestSellers(ctx context.Context, filter models.ReportFilter, limit int) ([]models.BestSeller, error) {
+	q := &reportQuery{}
+	rows, err := repo.db.QueryContext(ctx, `
+		WITH lines AS (
+			SELECT td.product_id, td.transaction_id, td.quantity, `+lineRevenue+` AS revenue
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE `+q.sales(filter)+`
+		)
+		SELECT l.product_id, COALESCE(p.name, ''), COALESCE(c.name, ''),
+		       SUM(l.quantity), SUM(l.revenue)::int, COUNT(DISTINCT l.transaction_id)
+		FROM lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE `+q.category(filter, "l.product_id")+`
+		GROUP BY l.product_id, p.name, c.name
+		ORDER BY SUM(l.quantity) DESC, SUM(l.revenue) DESC, l.product_id
+		LIMIT `+q.arg(limit)+`
+	`, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.BestSeller, 0)
+	for rows.Next() {
+		b := models.BestSeller{Rank: len(products) + 1}
+		if err := rows.Scan(&b.ProductID, &b.ProductName, &b.CategoryName, &b.QtySold, &b.Revenue, &b.Transactions); err != nil {
+			return nil, err
+		}
+		products = append(products, b)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
@@ -0,0 +1,221 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-core-api/database/dbtest"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+)
+
+// createStore inserts the default store of the tenant a pool acts as
+func createStore(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var id int
+	if err := db.QueryRow(`INSERT INTO stores (code, name, is_default) VALUES ('T1', 'Test store', true) RETURNING id`).Scan(&id); err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	return id
+}
+
+// createStockedProduct inserts a product with stock at a store and one cost
+// layer of it at unitCost
+func createStockedProduct(t *testing.T, db *sql.DB, storeID int, slug string, price, stock, unitCost int) int {
+	t.Helper()
+	var id int
+	err := db.QueryRow(`INSERT INTO products (name, slug, price, stock) VALUES ($1, $1, $2, $3) RETURNING id`,
+		slug, price, stock).Scan(&id)
+	if err != nil {
+		t.Fatalf("creating product %s: %v", slug, err)
+	}
+	if _, err := db.Exec(`INSERT INTO store_stocks (store_id, product_id, stock) VALUES ($1, $2, $3)`, storeID, id, stock); err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost) VALUES ($1, $2, 0, $3, $3, $4)`,
+		id, models.CostSourceGoodsReceipt, stock, unitCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestCreateTransactionMapsDetailsToLines(t *testing.T) {
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+
+	storeID := createStore(t, db)
+	tea := createStockedProduct(t, db, storeID, "tea", 4000, 20, 100)
+	rice := createStockedProduct(t, db, storeID, "rice", 1000, 20, 300)
+	unitCosts := map[int]int{tea: 100, rice: 300}
+
+	var promotionID int
+	err := db.QueryRow(`INSERT INTO promotions (name, type, product_id, buy_qty, get_qty) VALUES ('Tea BOGO', $1, $2, 1, 1) RETURNING id`,
+		models.PromotionTypeBOGO, tea).Scan(&promotionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bogo := models.AppliedPromotion{PromotionID: promotionID, Name: "Tea BOGO", Type: models.PromotionTypeBOGO, Discount: 4000}
+
+	// Lines of the same product are interleaved with other products and
+	// differ in quantity, so a detail attached to the wrong line shows
+	lines := []models.TransactionDetail{
+		{ProductID: tea, Quantity: 1, UnitPrice: 4000, Subtotal: 4000},
+		{ProductID: rice, Quantity: 2, UnitPrice: 1000, Subtotal: 2000},
+		{ProductID: tea, Quantity: 3, UnitPrice: 4000, Discount: 4000, Subtotal: 8000, Promotions: []models.AppliedPromotion{bogo}},
+		{ProductID: rice, Quantity: 5, UnitPrice: 1000, Subtotal: 5000},
+		{ProductID: tea, Quantity: 2, UnitPrice: 4000, Subtotal: 8000},
+	}
+	details := make([]models.TransactionDetail, len(lines))
+	copy(details, lines)
+
+	req := models.CheckoutRequest{StoreID: storeID, PaymentMethod: "cash"}
+	transaction, err := repositories.NewTransactionRepository(db).CreateTransaction(context.Background(), req, details)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int]bool)
+	for i, want := range lines {
+		got := transaction.Details[i]
+		if got.ID == 0 || seen[got.ID] {
+			t.Fatalf("line %d: detail ID %d is missing or repeated", i, got.ID)
+		}
+		seen[got.ID] = true
+
+		var productID, quantity, discount, subtotal, costAmount int
+		err := db.QueryRow(`SELECT product_id, quantity, discount, subtotal, cost_amount FROM transaction_details WHERE id = $1 AND transaction_id = $2`,
+			got.ID, transaction.ID).Scan(&productID, &quantity, &discount, &subtotal, &costAmount)
+		if err != nil {
+			t.Fatalf("line %d: detail %d: %v", i, got.ID, err)
+		}
+		if productID != want.ProductID || quantity != want.Quantity || discount != want.Discount || subtotal != want.Subtotal {
+			t.Fatalf("line %d: detail %d holds product %d x%d (discount %d, subtotal %d), want product %d x%d (discount %d, subtotal %d)",
+				i, got.ID, productID, quantity, discount, subtotal, want.ProductID, want.Quantity, want.Discount, want.Subtotal)
+		}
+
+		wantCost := want.Quantity * unitCosts[want.ProductID]
+		if got.CostAmount != wantCost {
+			t.Fatalf("line %d: cost amount %d, want %d", i, got.CostAmount, wantCost)
+		}
+		var consumed, cost int
+		err = db.QueryRow(`SELECT COALESCE(SUM(quantity), 0), COALESCE(SUM(quantity * unit_cost), 0) FROM cost_layer_consumptions WHERE transaction_detail_id = $1`,
+			got.ID).Scan(&consumed, &cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if consumed != want.Quantity || cost != wantCost {
+			t.Fatalf("line %d: detail %d consumed %d units costing %d, want %d costing %d", i, got.ID, consumed, cost, want.Quantity, wantCost)
+		}
+
+		var promotions int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM transaction_detail_promotions WHERE transaction_detail_id = $1 AND promotion_id = $2`,
+			got.ID, promotionID).Scan(&promotions); err != nil {
+			t.Fatal(err)
+		}
+		if promotions != len(want.Promotions) {
+			t.Fatalf("line %d: detail %d has %d promotions, want %d", i, got.ID, promotions, len(want.Promotions))
+		}
+	}
+}
+
+func TestCreateTransactionCostsLinesFIFO(t *testing.T) {
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+
+	// 5 units layered at 100, 3 at 200 and 2 without a layer, costed at the
+	// cost price
+	storeID := createStore(t, db)
+	tea := createStockedProduct(t, db, storeID, "tea", 4000, 5, 100)
+	_, err := db.Exec(`
+		WITH layer AS (
+			INSERT INTO cost_layers (product_id, source_type, source_id, quantity, remaining, unit_cost) VALUES ($1, $2, 0, 3, 3, 200)
+		)
+		UPDATE products SET stock = 10, cost_price = 150 WHERE id = $1
+	`, tea, models.CostSourceGoodsReceipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stockAtStore(t, db, storeID, tea, 10)
+
+	// Each line takes from what the lines before it left
+	details := []models.TransactionDetail{
+		{ProductID: tea, Quantity: 4, UnitPrice: 4000, Subtotal: 16000},
+		{ProductID: tea, Quantity: 3, UnitPrice: 4000, Subtotal: 12000},
+		{ProductID: tea, Quantity: 3, UnitPrice: 4000, Subtotal: 12000},
+	}
+	req := models.CheckoutRequest{StoreID: storeID, PaymentMethod: "cash"}
+	transaction, err := repositories.NewTransactionRepository(db).CreateTransaction(context.Background(), req, details)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []int{4 * 100, 100 + 2*200, 200 + 2*150} {
+		got := transaction.Details[i]
+		var stored int
+		if err := db.QueryRow(`SELECT cost_amount FROM transaction_details WHERE id = $1`, got.ID).Scan(&stored); err != nil {
+			t.Fatal(err)
+		}
+		if got.CostAmount != want || stored != want {
+			t.Fatalf("line %d: cost %d, stored %d, want %d", i, got.CostAmount, stored, want)
+		}
+	}
+	if remaining, _ := openLayers(t, db, tea); remaining != 0 {
+		t.Fatalf("%d units left in the layers after selling all of them", remaining)
+	}
+}
+
+func TestVoidTransactionOnce(t *testing.T) {
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+	storeID := createStore(t, db)
+	tea := createStockedProduct(t, db, storeID, "tea", 4000, 10, 100)
+
+	details := []models.TransactionDetail{{ProductID: tea, Quantity: 3, UnitPrice: 4000, Subtotal: 12000}}
+	req := models.CheckoutRequest{StoreID: storeID, PaymentMethod: "cash"}
+	transactions := repositories.NewTransactionRepository(db)
+	sale, err := transactions.CreateTransaction(context.Background(), req, details)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Voids racing each other: one restores the stock, the others find the
+	// sale already void
+	const voids = 4
+	errs := make(chan error, voids)
+	for i := 0; i < voids; i++ {
+		go func() { errs <- transactions.VoidTransaction(context.Background(), sale.ID) }()
+	}
+	succeeded := 0
+	for i := 0; i < voids; i++ {
+		err := <-errs
+		var coded *helpers.CodedError
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.As(err, &coded) && coded.Code == helpers.CodeTransactionVoided:
+		default:
+			t.Fatalf("void: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("%d voids succeeded, want 1", succeeded)
+	}
+
+	var stock, refunds, remaining int
+	err = db.QueryRow(`
+		SELECT (SELECT stock FROM products WHERE id = $1),
+		       (SELECT COUNT(*) FROM stock_movements WHERE reference_id = $2 AND reason = $3),
+		       (SELECT SUM(remaining) FROM cost_layers WHERE product_id = $1)
+	`, tea, sale.ID, models.StockReasonRefund).Scan(&stock, &refunds, &remaining)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stock != 10 || refunds != 1 || remaining != 10 {
+		t.Fatalf("after the voids: stock %d, %d refund movements, %d units layered; want 10, 1, 10", stock, refunds, remaining)
+	}
+
+	err = transactions.VoidTransaction(context.Background(), sale.ID+1000)
+	var coded *helpers.CodedError
+	if !errors.As(err, &coded) || coded.Code != helpers.CodeTransactionNotFound {
+		t.Fatalf("voiding a missing transaction: got %v, want not found", err)
+	}
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/models"
+)
+
+// Translation entity kinds, mapped to their translation tables
+const (
+	TranslationEntityProduct  = "product"
+	TranslationEntityCategory = "category"
+)
+
+// translationTables maps an entity kind to its translation table and foreign key column
+var translationTables = map[string]struct{ table, fk string }{
+	TranslationEntityProduct:  {"product_translations", "product_id"},
+	TranslationEntityCategory: {"category_translations", "category_id"},
+}
+
+// TranslationRepository defines the interface for product/category translation data access
+type TranslationRepository interface {
+	GetAll(ctx context.Context, entity string, entityID int) ([]models.Translation, error)
+	Upsert(ctx context.Context, entity string, entityID int, translation models.Translation) (*models.Translation, error)
+	Delete(ctx context.Context, entity string, entityID int, locale string) error
+	FindBest(ctx context.Context, entity string, entityIDs []int, locales []string) (map[int]models.Translation, error)
+}
+
+// translationRepository implements TranslationRepository interface with PostgreSQL
+type translationRepository struct {
+	db DBTX
+}
+
+// NewTranslationRepository creates a new translation repository instance
+func NewTranslationRepository(db DBTX) TranslationRepository {
+	return &translationRepository{db: db}
+}
+
+// tableFor resolves the translation table for an entity kind
+func tableFor(entity string) (string, string, error) {
+	t, ok := translationTables[entity]
+	if !ok {
+		return "", "", fmt.Errorf("unknown translation entity '%s'", entity)
+	}
+	return t.table, t.fk, nil
+}
+
+// GetAll returns every translation of an entity ordered by locale
+func (r *translationRepository) GetAll(ctx context.Context, entity string, entityID int) ([]models.Translation, error) {
+	table, fk, err := tableFor(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT locale, name, description, updated_at FROM %s WHERE %s = $1 ORDER BY locale`, table, fk,
+	), entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := make([]models.Translation, 0)
+	for rows.Next() {
+		var t models.Translation
+		if err := rows.Scan(&t.Locale, &t.Name, &t.Description, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		translations = append(translations, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// Upsert creates or replaces the translation of an entity for one locale
+func (r *translationRepository) Upsert(ctx context.Context, entity string, entityID int, translation models.Translation) (*models.Translation, error) {
+	table, fk, err := tableFor(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (%[2]s, locale, name, description) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (%[2]s, locale) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, updated_at = CURRENT_TIMESTAMP
+		RETURNING locale, name, description, updated_at
+	`, table, fk)
+
+	var t models.Translation
+	err = r.db.QueryRowContext(ctx, query, entityID, translation.Locale, translation.Name, translation.Description).Scan(
+		&t.Locale, &t.Name, &t.Description, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Delete removes the translation of an entity for one locale
+func (r *translationRepository) Delete(ctx context.Context, entity string, entityID int, locale string) error {
+	table, fk, err := tableFor(entity)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND locale = $2`, table, fk), entityID, locale)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// FindBest returns, for each entity ID that has one, the translation matching
+// the earliest locale in the preference list
+func (r *translationRepository) FindBest(ctx context.Context, entity string, entityIDs []int, locales []string) (map[int]models.Translation, error) {
+	best := make(map[int]models.Translation)
+	if len(entityIDs) == 0 || len(locales) == 0 {
+		return best, nil
+	}
+
+	table, fk, err := tableFor(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT DISTINCT ON (%[2]s) %[2]s, locale, name, description, updated_at
+		FROM %[1]s
+		WHERE %[2]s = ANY($1) AND locale = ANY($2)
+		ORDER BY %[2]s, array_position($2::text[], locale)
+	`, table, fk), entityIDs, locales)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var t models.Translation
+		if err := rows.Scan(&id, &t.Locale, &t.Name, &t.Description, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		best[id] = t
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return best, nil
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+)
+
+// DBTX is satisfied by both *sql.DB and *sql.Tx. Repositories are built on
+// one, so the same repository works on the connection pool or inside the
+// DB transaction of a unit of work.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// UnitOfWork runs service logic that spans several repositories in a
+// single DB transaction
+type UnitOfWork interface {
+	// Do calls fn with a transaction to build repositories on, e.g.
+	// NewTransactionRepository(tx), committing when fn returns nil and
+	// rolling back when it returns an error or panics
+	Do(ctx context.Context, fn func(tx DBTX) error) error
+}
+
+// unitOfWork implements UnitOfWork with PostgreSQL
+type unitOfWork struct {
+	db *sql.DB
+}
+
+// NewUnitOfWork creates a new unit of work on a connection pool
+func NewUnitOfWork(db *sql.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn in a new DB transaction
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx DBTX) error) error {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepointSeq numbers the savepoints of repository methods nested in a unit of work
+var savepointSeq atomic.Uint64
+
+// repoTx is the DB transaction of a repository method that changes several
+// rows atomically. On a connection pool it is a transaction of its own;
+// inside a unit of work it is a savepoint of the unit's transaction, so a
+// failed method undoes only its own changes and the unit decides whether
+// to commit.
+type repoTx struct {
+	*sql.Tx
+	savepoint string
+	done      bool
+}
+
+// beginTx starts the DB transaction of a repository method on db
+func beginTx(ctx context.Context, db DBTX) (*repoTx, error) {
+	switch db := db.(type) {
+	case *sql.DB:
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &repoTx{Tx: tx}, nil
+	case *sql.Tx:
+		savepoint := fmt.Sprintf("repo_%d", savepointSeq.Add(1))
+		if _, err := db.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			return nil, err
+		}
+		return &repoTx{Tx: db, savepoint: savepoint}, nil
+	case *repoTx:
+		// A method called by another one nests a savepoint in its transaction
+		return beginTx(ctx, db.Tx)
+	default:
+		return nil, fmt.Errorf("cannot begin a transaction on %T", db)
+	}
+}
+
+// Commit commits the transaction, or releases the savepoint
+func (t *repoTx) Commit() error {
+	if t.savepoint == "" {
+		return t.Tx.Commit()
+	}
+	t.done = true
+	// Not bound to the request: a savepoint left open is only undone with
+	// the whole transaction
+	_, err := t.Tx.ExecContext(context.Background(), "RELEASE SAVEPOINT "+t.savepoint)
+	return err
+}
+
+// Rollback rolls back the transaction, or to the savepoint. Like
+// sql.Tx.Rollback it may be deferred, doing nothing after Commit.
+func (t *repoTx) Rollback() error {
+	if t.savepoint == "" {
+		return t.Tx.Rollback()
+	}
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	_, err := t.Tx.ExecContext(context.Background(), "ROLLBACK TO SAVEPOINT "+t.savepoint)
+	return err
+}
@@ -0,0 +1,122 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-core-api/database/dbtest"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+)
+
+// productStock returns the total and store stock of a product
+func productStock(t *testing.T, db *sql.DB, storeID, productID int) (int, int) {
+	t.Helper()
+	var stock, storeStock int
+	err := db.QueryRow(`SELECT p.stock, s.stock FROM products p JOIN store_stocks s ON s.product_id = p.id AND s.store_id = $1 WHERE p.id = $2`,
+		storeID, productID).Scan(&stock, &storeStock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stock, storeStock
+}
+
+// categoryExists reports whether the pool sees a category named name
+func categoryExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var exists bool
+	if err := db.QueryRow(`SELECT EXISTS (SELECT 1 FROM categories WHERE name = $1)`, name).Scan(&exists); err != nil {
+		t.Fatal(err)
+	}
+	return exists
+}
+
+func TestUnitOfWorkNestsRepositoryTransactions(t *testing.T) {
+	_, db := dbtest.NewTenant(t, dbtest.Open(t))
+	ctx := context.Background()
+
+	storeID := createStore(t, db)
+	tea := createStockedProduct(t, db, storeID, "tea", 4000, 10, 100)
+	rice := createStockedProduct(t, db, storeID, "rice", 1000, 1, 300)
+
+	// The stock of tea is deducted before rice turns out to be short, so
+	// the failed checkout has written rows its savepoint has to undo
+	shortCheckout := []models.TransactionDetail{
+		{ProductID: tea, Quantity: 2, UnitPrice: 4000, Subtotal: 8000},
+		{ProductID: rice, Quantity: 5, UnitPrice: 1000, Subtotal: 5000},
+	}
+	checkout := []models.TransactionDetail{
+		{ProductID: tea, Quantity: 3, UnitPrice: 4000, Subtotal: 12000},
+	}
+	req := models.CheckoutRequest{StoreID: storeID, PaymentMethod: "cash"}
+
+	t.Run("a failed method rolls back only its savepoint", func(t *testing.T) {
+		var transactionID int
+		err := repositories.NewUnitOfWork(db).Do(ctx, func(tx repositories.DBTX) error {
+			if _, err := repositories.NewCategoryRepository(tx).Create(ctx, models.Category{Name: "uow-kept"}); err != nil {
+				return err
+			}
+
+			transactions := repositories.NewTransactionRepository(tx)
+			_, err := transactions.CreateTransaction(ctx, req, append([]models.TransactionDetail(nil), shortCheckout...))
+			var coded *helpers.CodedError
+			if !errors.As(err, &coded) || coded.Code != helpers.CodeInsufficientStock {
+				t.Fatalf("checkout beyond the stock: got %v, want an insufficient stock error", err)
+			}
+
+			// The unit's transaction is still usable after the failure
+			transaction, err := transactions.CreateTransaction(ctx, req, append([]models.TransactionDetail(nil), checkout...))
+			if err != nil {
+				return err
+			}
+			transactionID = transaction.ID
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !categoryExists(t, db, "uow-kept") {
+			t.Fatal("the write before the failed method was not committed")
+		}
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE id = $1`, transactionID).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatal("the checkout after the failed method was not committed")
+		}
+		// Only the successful checkout deducted stock
+		if stock, storeStock := productStock(t, db, storeID, tea); stock != 7 || storeStock != 7 {
+			t.Fatalf("tea stock = %d (store %d), want 7", stock, storeStock)
+		}
+		if stock, storeStock := productStock(t, db, storeID, rice); stock != 1 || storeStock != 1 {
+			t.Fatalf("rice stock = %d (store %d), want 1", stock, storeStock)
+		}
+	})
+
+	t.Run("an error from the unit rolls back every method", func(t *testing.T) {
+		failed := errors.New("abandoned")
+		err := repositories.NewUnitOfWork(db).Do(ctx, func(tx repositories.DBTX) error {
+			if _, err := repositories.NewCategoryRepository(tx).Create(ctx, models.Category{Name: "uow-dropped"}); err != nil {
+				return err
+			}
+			if _, err := repositories.NewTransactionRepository(tx).CreateTransaction(ctx, req, append([]models.TransactionDetail(nil), checkout...)); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("Do returned %v, want %v", err, failed)
+		}
+
+		if categoryExists(t, db, "uow-dropped") {
+			t.Fatal("a write of the abandoned unit was committed")
+		}
+		if stock, _ := productStock(t, db, storeID, tea); stock != 7 {
+			t.Fatalf("tea stock = %d after the abandoned unit, want 7", stock)
+		}
+	})
+}
@@ -1,35 +1,36 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"retail-core-api/models"
 )
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
-	GetByID(id int) (*models.User, error)
-	GetByEmail(email string) (*models.User, error)
-	GetAll() ([]models.User, error)
-	Create(user models.User) (*models.User, error)
-	Update(id int, user models.User) (*models.User, error)
-	Delete(id int) error
+	GetByID(ctx context.Context, id int) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetAll(ctx context.Context) ([]models.User, error)
+	Create(ctx context.Context, user models.User) (*models.User, error)
+	Update(ctx context.Context, id int, user models.User) (*models.User, error)
+	Delete(ctx context.Context, id int) error
 }
 
 // userRepository implements UserRepository interface
 type userRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewUserRepository creates a new user repository instance
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db DBTX) UserRepository {
 	return &userRepository{db: db}
 }
 
 // GetByID returns a user by their ID
-func (r *userRepository) GetByID(id int) (*models.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	query := `SELECT id, name, email, password, role, is_active, created_at FROM users WHERE id = $1`
 	var user models.User
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Name, &user.Email, &user.Password,
 		&user.Role, &user.IsActive, &user.CreatedAt,
 	)
@@ -43,10 +44,10 @@ func (r *userRepository) GetByID(id int) (*models.User, error) {
 }
 
 // GetByEmail returns a user by their email
-func (r *userRepository) GetByEmail(email string) (*models.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `SELECT id, name, email, password, role, is_active, created_at FROM users WHERE email = $1`
 	var user models.User
-	err := r.db.QueryRow(query, email).Scan(
+	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID, &user.Name, &user.Email, &user.Password,
 		&user.Role, &user.IsActive, &user.CreatedAt,
 	)
@@ -60,9 +61,9 @@ func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 }
 
 // GetAll returns all users
-func (r *userRepository) GetAll() ([]models.User, error) {
+func (r *userRepository) GetAll(ctx context.Context) ([]models.User, error) {
 	query := `SELECT id, name, email, password, role, is_active, created_at FROM users ORDER BY id`
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +86,14 @@ func (r *userRepository) GetAll() ([]models.User, error) {
 }
 
 // Create adds a new user
-func (r *userRepository) Create(user models.User) (*models.User, error) {
+func (r *userRepository) Create(ctx context.Context, user models.User) (*models.User, error) {
 	query := `
 		INSERT INTO users (name, email, password, role, is_active)
 		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, name, email, role, is_active, created_at
 	`
 	var created models.User
-	err := r.db.QueryRow(query, user.Name, user.Email, user.Password, user.Role, true).Scan(
+	err := r.db.QueryRowContext(ctx, query, user.Name, user.Email, user.Password, user.Role, true).Scan(
 		&created.ID, &created.Name, &created.Email,
 		&created.Role, &created.IsActive, &created.CreatedAt,
 	)
@@ -103,14 +104,14 @@ func (r *userRepository) Create(user models.User) (*models.User, error) {
 }
 
 // Update modifies an existing user
-func (r *userRepository) Update(id int, user models.User) (*models.User, error) {
+func (r *userRepository) Update(ctx context.Context, id int, user models.User) (*models.User, error) {
 	query := `
 		UPDATE users SET name = $1, email = $2, role = $3, is_active = $4
 		WHERE id = $5
 		RETURNING id, name, email, role, is_active, created_at
 	`
 	var updated models.User
-	err := r.db.QueryRow(query, user.Name, user.Email, user.Role, user.IsActive, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, user.Name, user.Email, user.Role, user.IsActive, id).Scan(
 		&updated.ID, &updated.Name, &updated.Email,
 		&updated.Role, &updated.IsActive, &updated.CreatedAt,
 	)
@@ -124,9 +125,9 @@ func (r *userRepository) Update(id int, user models.User) (*models.User, error)
 }
 
 // Delete deactivates a user by ID
-func (r *userRepository) Delete(id int) error {
+func (r *userRepository) Delete(ctx context.Context, id int) error {
 	query := `UPDATE users SET is_active = false WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
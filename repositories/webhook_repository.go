@@ -0,0 +1,353 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"strings"
+	"time"
+)
+
+// WebhookRepository defines the interface for webhook and delivery log data access
+type WebhookRepository interface {
+	GetAll(ctx context.Context) ([]models.Webhook, error)
+	GetByID(ctx context.Context, id int) (*models.Webhook, error)
+	Create(ctx context.Context, webhook models.Webhook) (*models.Webhook, error)
+	Update(ctx context.Context, id int, webhook models.Webhook) (*models.Webhook, error)
+	Delete(ctx context.Context, id int) error
+	HasSubscribers(ctx context.Context, event string) (bool, error)
+	Enqueue(ctx context.Context, event, eventID string, payload []byte) (int, error)
+	GetDue(ctx context.Context, limit int) ([]models.WebhookTarget, error)
+	SaveAttempt(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error
+	GetDeliveries(ctx context.Context, params models.WebhookDeliveryParams) (*models.PaginatedWebhookDeliveries, error)
+	GetDelivery(ctx context.Context, webhookID, deliveryID int) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int) (*models.WebhookDelivery, error)
+}
+
+// webhookRepository implements WebhookRepository interface with PostgreSQL
+type webhookRepository struct {
+	db DBTX
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db DBTX) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// webhookColumns is the standard set of columns selected for webhook queries;
+// the secret is not among them
+const webhookColumns = `id, url, description, events, is_active, created_at, updated_at`
+
+// scanWebhook scans a row into a Webhook struct
+func scanWebhook(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Webhook, error) {
+	var w models.Webhook
+	var events string
+	err := scanner.Scan(&w.ID, &w.URL, &w.Description, &events, &w.IsActive, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	w.Events = strings.Split(events, ",")
+	return &w, nil
+}
+
+// webhookDeliveryColumns is the standard set of columns selected for delivery queries
+const webhookDeliveryColumns = `
+	d.id, d.webhook_id, d.event_id, d.event, d.payload, d.status, d.attempts,
+	d.response_status, d.error, d.next_attempt_at, d.created_at, d.delivered_at
+`
+
+// scanWebhookDelivery scans a row into a WebhookDelivery struct, followed by
+// any extra destinations selected after webhookDeliveryColumns
+func scanWebhookDelivery(scanner interface {
+	Scan(dest ...interface{}) error
+}, extra ...interface{}) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	var payload []byte
+	dest := []interface{}{
+		&d.ID, &d.WebhookID, &d.EventID, &d.Event, &payload, &d.Status, &d.Attempts,
+		&d.ResponseStatus, &d.Error, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt,
+	}
+	if err := scanner.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+// GetAll returns every webhook in ID order
+func (r *webhookRepository) GetAll(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetByID returns a webhook by its ID
+func (r *webhookRepository) GetByID(ctx context.Context, id int) (*models.Webhook, error) {
+	w, err := scanWebhook(r.db.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// Create inserts a new webhook with its signing secret
+func (r *webhookRepository) Create(ctx context.Context, webhook models.Webhook) (*models.Webhook, error) {
+	return scanWebhook(r.db.QueryRowContext(ctx, `
+		INSERT INTO webhooks (url, description, events, secret, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+webhookColumns,
+		webhook.URL, webhook.Description, strings.Join(webhook.Events, ","), webhook.Secret, webhook.IsActive,
+	))
+}
+
+// Update modifies an existing webhook; its secret is kept
+func (r *webhookRepository) Update(ctx context.Context, id int, webhook models.Webhook) (*models.Webhook, error) {
+	w, err := scanWebhook(r.db.QueryRowContext(ctx, `
+		UPDATE webhooks
+		SET url = $1, description = $2, events = $3, is_active = $4, updated_at = $5
+		WHERE id = $6
+		RETURNING `+webhookColumns,
+		webhook.URL, webhook.Description, strings.Join(webhook.Events, ","), webhook.IsActive, time.Now(), id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// Delete removes a webhook and its delivery log
+func (r *webhookRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// subscribed is the condition on webhooks that receive the event in $1
+const subscribed = `is_active = true AND $1 = ANY(string_to_array(events, ','))`
+
+// HasSubscribers reports whether an active webhook subscribes to event
+func (r *webhookRepository) HasSubscribers(ctx context.Context, event string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE `+subscribed+`)`, event).Scan(&exists)
+	return exists, err
+}
+
+// Enqueue queues an event for every active webhook subscribed to it and
+// returns how many deliveries were queued. Webhooks that already have the
+// event ID queued are skipped.
+func (r *webhookRepository) Enqueue(ctx context.Context, event, eventID string, payload []byte) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload)
+		SELECT id, $2, $1, $3::jsonb FROM webhooks WHERE `+subscribed+`
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		event, eventID, string(payload),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	queued, err := result.RowsAffected()
+	return int(queued), err
+}
+
+// GetDue returns pending deliveries of active webhooks whose next attempt is
+// due, oldest first, with the URL and secret of their webhook
+func (r *webhookRepository) GetDue(ctx context.Context, limit int) ([]models.WebhookTarget, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`, w.url, w.secret
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = $1 AND d.next_attempt_at <= NOW() AND w.is_active = true
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT $2
+	`, models.WebhookDeliveryPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	targets := make([]models.WebhookTarget, 0)
+	for rows.Next() {
+		var t models.WebhookTarget
+		d, err := scanWebhookDelivery(rows, &t.URL, &t.Secret)
+		if err != nil {
+			return nil, err
+		}
+		t.Delivery = *d
+		targets = append(targets, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// SaveAttempt records the outcome of a delivery attempt on the delivery and
+// appends the attempt to its history, in one statement
+func (r *webhookRepository) SaveAttempt(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error {
+	_, err := r.db.ExecContext(ctx, `
+		WITH updated AS (
+			UPDATE webhook_deliveries
+			SET status = $1, attempts = $2, response_status = $3, error = $4, next_attempt_at = $5, delivered_at = $6
+			WHERE id = $7
+			RETURNING id
+		)
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempt, response_status, response_body, error, duration_ms, attempted_at)
+		SELECT id, $2, $3, $8, $4, $9, $10 FROM updated
+	`, delivery.Status, delivery.Attempts, delivery.ResponseStatus, delivery.Error,
+		delivery.NextAttemptAt, delivery.DeliveredAt, delivery.ID,
+		attempt.ResponseBody, attempt.DurationMs, attempt.AttemptedAt)
+	return err
+}
+
+// GetDeliveries returns the delivery log of a webhook, newest first,
+// optionally narrowed to one status
+func (r *webhookRepository) GetDeliveries(ctx context.Context, params models.WebhookDeliveryParams) (*models.PaginatedWebhookDeliveries, error) {
+	where := "d.webhook_id = $1"
+	args := []interface{}{params.WebhookID}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where += " AND d.status = $2"
+	}
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_deliveries d WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(args)
+	args = append(args, params.Limit, (params.Page-1)*params.Limit)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE %s
+		ORDER BY d.id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, n+1, n+2), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedWebhookDeliveries{
+		Data:       deliveries,
+		Total:      total,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalPages: helpers.CalcTotalPages(total, params.Limit),
+	}, nil
+}
+
+// GetDelivery returns a delivery of a webhook with every attempt made at it,
+// oldest first. It returns nil if the webhook has no such delivery.
+func (r *webhookRepository) GetDelivery(ctx context.Context, webhookID, deliveryID int) (*models.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.id = $1 AND d.webhook_id = $2
+	`, deliveryID, webhookID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, delivery_id, attempt, response_status, response_body, error, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY id
+	`, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	d.AttemptHistory = make([]models.WebhookAttempt, 0)
+	for rows.Next() {
+		var a models.WebhookAttempt
+		if err := rows.Scan(&a.ID, &a.DeliveryID, &a.Attempt, &a.ResponseStatus, &a.ResponseBody,
+			&a.Error, &a.DurationMs, &a.AttemptedAt); err != nil {
+			return nil, err
+		}
+		d.AttemptHistory = append(d.AttemptHistory, a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Redeliver queues a delivered or failed delivery of a webhook again with a
+// fresh set of attempts. It returns nil if the delivery does not exist or is
+// still pending.
+func (r *webhookRepository) Redeliver(ctx context.Context, webhookID, deliveryID int) (*models.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, `
+		UPDATE webhook_deliveries d
+		SET status = $1, attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		WHERE d.id = $2 AND d.webhook_id = $3 AND d.status <> $1
+		RETURNING `+webhookDeliveryColumns,
+		models.WebhookDeliveryPending, deliveryID, webhookID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// message builds an encoded protobuf message field by field. Like generated
+// code, it leaves out proto3 fields holding their default value.
+type message []byte
+
+// int appends an int32 or int64 field
+func (m message) int(num protowire.Number, v int) message {
+	if v == 0 {
+		return m
+	}
+	m = protowire.AppendTag(m, num, protowire.VarintType)
+	return protowire.AppendVarint(m, uint64(int64(v)))
+}
+
+// optionalInt appends an optional int64 field, which is sent even when zero
+func (m message) optionalInt(num protowire.Number, v *int) message {
+	if v == nil {
+		return m
+	}
+	m = protowire.AppendTag(m, num, protowire.VarintType)
+	return protowire.AppendVarint(m, uint64(int64(*v)))
+}
+
+// bool appends a bool field
+func (m message) bool(num protowire.Number, v bool) message {
+	if !v {
+		return m
+	}
+	m = protowire.AppendTag(m, num, protowire.VarintType)
+	return protowire.AppendVarint(m, 1)
+}
+
+// string appends a string field
+func (m message) string(num protowire.Number, v string) message {
+	if v == "" {
+		return m
+	}
+	m = protowire.AppendTag(m, num, protowire.BytesType)
+	return protowire.AppendString(m, v)
+}
+
+// message appends an embedded message field, e.g. one element of a repeated field
+func (m message) message(num protowire.Number, v message) message {
+	m = protowire.AppendTag(m, num, protowire.BytesType)
+	return protowire.AppendBytes(m, v)
+}
+
+// time appends a google.protobuf.Timestamp field
+func (m message) time(num protowire.Number, t time.Time) message {
+	if t.IsZero() {
+		return m
+	}
+	return m.message(num, message(nil).int(1, int(t.Unix())).int(2, t.Nanosecond()))
+}
+
+// field is one decoded field of a message. Varint fields carry their value
+// in varint, length-delimited fields theirs in bytes.
+type field struct {
+	num    protowire.Number
+	typ    protowire.Type
+	varint uint64
+	bytes  []byte
+}
+
+// int returns the field as an int32 or int64 value
+func (f field) int() int {
+	return int(int64(f.varint))
+}
+
+// string returns the field as a string value
+func (f field) string() string {
+	return string(f.bytes)
+}
+
+// decode calls fn with every field of an encoded message, in order. Fields
+// fn does not know are skipped, as generated code does.
+func decode(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return Errorf(InvalidArgument, "malformed message: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		f := field{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return Errorf(InvalidArgument, "malformed message: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
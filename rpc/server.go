@@ -0,0 +1,244 @@
+// Package rpc serves the gRPC API for internal services that want lower
+// latency than JSON over REST. gRPC is spoken directly over the HTTP/2
+// server of net/http (cleartext, as services inside the network use it) and
+// the messages of retail.proto are encoded by hand, so no generated code or
+// gRPC runtime is needed. Calls go to the same service layer as the REST API.
+package rpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Code is a gRPC status code
+type Code uint32
+
+// gRPC status codes used by the API
+const (
+	OK                 Code = 0
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unauthenticated    Code = 16
+)
+
+// maxMessageSize caps a request message, as gRPC's default does
+const maxMessageSize = 4 << 20
+
+// Error is a call's failure as sent to the client: a status code and message
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Errorf creates an Error with a formatted message
+func Errorf(code Code, format string, args ...interface{}) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// handler serves one method: it decodes the request message, calls the
+// service and returns the encoded response message
+type handler func(ctx context.Context, req []byte) (message, error)
+
+// Server serves the gRPC methods of one tenant
+type Server struct {
+	jwtSecret string
+	tenantID  int
+	methods   map[string]handler
+}
+
+// NewServer creates a server that accepts tokens issued for tenantID. Add
+// services with RegisterCategoryService and the like.
+func NewServer(jwtSecret string, tenantID int) *Server {
+	return &Server{jwtSecret: jwtSecret, tenantID: tenantID, methods: make(map[string]handler)}
+}
+
+// handle serves a method at its path, e.g. "/retail.v1.ProductService/GetProduct"
+func (s *Server) handle(path string, h handler) {
+	s.methods[path] = h
+}
+
+// ServeHTTP answers a gRPC call. The status always goes in the trailers,
+// after the response message when the call succeeded.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if r.Method != http.MethodPost || (contentType != "application/grpc" && contentType != "application/grpc+proto") {
+		http.Error(w, "This port only serves gRPC", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+
+	start := time.Now()
+	resp, err := s.call(r)
+	if err == nil {
+		frame := make([]byte, 5, 5+len(resp))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(resp)))
+		_, err = w.Write(append(frame, resp...))
+	}
+
+	status := &Error{Code: OK}
+	if err != nil && !errors.As(err, &status) {
+		slog.ErrorContext(r.Context(), "grpc call failed", "method", r.URL.Path, "error", err)
+		status = &Error{Code: Internal, Message: "internal error"}
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(status.Code)))
+	if status.Message != "" {
+		w.Header().Set("Grpc-Message", encodeMessage(status.Message))
+	}
+	slog.InfoContext(r.Context(), "grpc call", "method", r.URL.Path, "code", int(status.Code), "latency", time.Since(start))
+}
+
+// call authenticates a call, reads its request message and runs its method
+func (s *Server) call(r *http.Request) (message, error) {
+	h, ok := s.methods[r.URL.Path]
+	if !ok {
+		return nil, Errorf(Unimplemented, "unknown method %s", r.URL.Path)
+	}
+	if encoding := r.Header.Get("Grpc-Encoding"); encoding != "" && encoding != "identity" {
+		return nil, Errorf(Unimplemented, "compression %q is not supported", encoding)
+	}
+
+	actor, err := s.authenticate(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.WithValue(r.Context(), actorKey{}, actor)
+	if timeout := r.Header.Get("Grpc-Timeout"); timeout != "" {
+		d, err := parseTimeout(timeout)
+		if err != nil {
+			return nil, err
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	var header [5]byte
+	if _, err := io.ReadFull(r.Body, header[:]); err != nil {
+		return nil, Errorf(InvalidArgument, "missing request message")
+	}
+	if header[0] != 0 {
+		return nil, Errorf(Unimplemented, "compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxMessageSize {
+		return nil, Errorf(ResourceExhausted, "request message of %d bytes exceeds the limit of %d", size, maxMessageSize)
+	}
+	req := make([]byte, size)
+	if _, err := io.ReadFull(r.Body, req); err != nil {
+		return nil, Errorf(InvalidArgument, "truncated request message")
+	}
+
+	resp, err := h(ctx, req)
+	if err == nil && ctx.Err() != nil {
+		return nil, Errorf(DeadlineExceeded, "deadline exceeded")
+	}
+	return resp, err
+}
+
+// actorKey is the context key of the authenticated user
+type actorKey struct{}
+
+// actorFrom returns the user making a call
+func actorFrom(ctx context.Context) models.Actor {
+	actor, _ := ctx.Value(actorKey{}).(models.Actor)
+	return actor
+}
+
+// authenticate validates the JWT in the call's authorization metadata. It
+// accepts the same tokens as the REST API.
+func (s *Server) authenticate(r *http.Request) (models.Actor, error) {
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || tokenString == "" {
+		return models.Actor{}, Errorf(Unauthenticated, "authorization metadata required: Bearer <token>")
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(s.jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return models.Actor{}, Errorf(Unauthenticated, "invalid or expired token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return models.Actor{}, Errorf(Unauthenticated, "invalid token claims")
+	}
+	if helpers.ClaimTenantID(claims) != s.tenantID {
+		return models.Actor{}, Errorf(Unauthenticated, "token belongs to another tenant")
+	}
+
+	var actor models.Actor
+	if userID, ok := claims["user_id"].(float64); ok {
+		actor.UserID = int(userID)
+	}
+	actor.Name, _ = claims["name"].(string)
+	actor.Role, _ = claims["role"].(string)
+	return actor, nil
+}
+
+// parseTimeout parses a grpc-timeout value such as "500m" (milliseconds)
+func parseTimeout(value string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}
+	if len(value) < 2 || len(value) > 9 {
+		return 0, Errorf(InvalidArgument, "invalid grpc-timeout %q", value)
+	}
+	unit, ok := units[value[len(value)-1]]
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if !ok || err != nil || n < 0 {
+		return 0, Errorf(InvalidArgument, "invalid grpc-timeout %q", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// encodeMessage percent-encodes a status message for the grpc-message trailer
+func encodeMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// NewHTTPServer returns a server for gRPC on addr over cleartext HTTP/2 (h2c),
+// which is what gRPC clients use without TLS
+func NewHTTPServer(addr string, handler http.Handler) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	return &http.Server{Addr: addr, Handler: handler, Protocols: protocols}
+}
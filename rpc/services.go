@@ -0,0 +1,258 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+)
+
+// serviceError maps a service error to a status by its sentinel, as the REST
+// handlers map it to an HTTP status (see helpers.StatusOf)
+func serviceError(err error) error {
+	var code Code
+	switch {
+	case errors.Is(err, helpers.ErrNotFound):
+		code = NotFound
+	case errors.Is(err, helpers.ErrValidation):
+		code = InvalidArgument
+	case errors.Is(err, helpers.ErrConflict):
+		code = FailedPrecondition
+	case errors.Is(err, helpers.ErrUnauthorized):
+		code = Unauthenticated
+	case errors.Is(err, helpers.ErrForbidden):
+		code = PermissionDenied
+	default:
+		return err
+	}
+	return &Error{Code: code, Message: err.Error()}
+}
+
+// decodeID decodes a request whose only field is "int64 id = 1"
+func decodeID(req []byte) (int, error) {
+	id := 0
+	err := decode(req, func(f field) error {
+		if f.num == 1 {
+			id = f.int()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if id <= 0 {
+		return 0, Errorf(InvalidArgument, "id must be greater than 0")
+	}
+	return id, nil
+}
+
+// RegisterCategoryService serves retail.v1.CategoryService
+func RegisterCategoryService(s *Server, service services.CategoryService) {
+	s.handle("/retail.v1.CategoryService/GetCategory", func(ctx context.Context, req []byte) (message, error) {
+		id, err := decodeID(req)
+		if err != nil {
+			return nil, err
+		}
+		category, err := service.GetCategoryByID(ctx, id)
+		if err != nil {
+			return nil, serviceError(err)
+		}
+		if category == nil {
+			return nil, Errorf(NotFound, "category not found")
+		}
+		return encodeCategory(*category), nil
+	})
+
+	s.handle("/retail.v1.CategoryService/ListCategories", func(ctx context.Context, req []byte) (message, error) {
+		if err := decode(req, func(field) error { return nil }); err != nil {
+			return nil, err
+		}
+		categories, err := service.GetAllCategories(ctx)
+		if err != nil {
+			return nil, serviceError(err)
+		}
+		var resp message
+		for _, category := range categories {
+			resp = resp.message(1, encodeCategory(category))
+		}
+		return resp, nil
+	})
+}
+
+// RegisterProductService serves retail.v1.ProductService
+func RegisterProductService(s *Server, service services.ProductService) {
+	s.handle("/retail.v1.ProductService/GetProduct", func(ctx context.Context, req []byte) (message, error) {
+		id, err := decodeID(req)
+		if err != nil {
+			return nil, err
+		}
+		product, err := service.GetProductByID(ctx, id)
+		if err != nil {
+			return nil, serviceError(err)
+		}
+		if product == nil {
+			return nil, Errorf(NotFound, "product not found")
+		}
+		return encodeProduct(*product), nil
+	})
+
+	s.handle("/retail.v1.ProductService/ListProducts", func(ctx context.Context, req []byte) (message, error) {
+		var params models.ProductListParams
+		err := decode(req, func(f field) error {
+			switch f.num {
+			case 1:
+				params.Search = f.string()
+			case 2:
+				id := f.int()
+				params.CategoryID = &id
+			case 3:
+				params.Page = f.int()
+			case 4:
+				params.Limit = f.int()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if params.Page <= 0 {
+			params.Page = 1
+		}
+		if params.Limit <= 0 {
+			params.Limit = 20
+		}
+
+		result, err := service.GetAllProducts(ctx, params)
+		if err != nil {
+			return nil, serviceError(err)
+		}
+		var resp message
+		for _, product := range result.Data {
+			resp = resp.message(1, encodeProduct(product))
+		}
+		return resp.int(2, result.Total).int(3, result.Page).int(4, result.Limit).int(5, result.TotalPages), nil
+	})
+}
+
+// RegisterTransactionService serves retail.v1.TransactionService
+func RegisterTransactionService(s *Server, service services.TransactionService) {
+	s.handle("/retail.v1.TransactionService/Checkout", func(ctx context.Context, req []byte) (message, error) {
+		var checkout models.CheckoutRequest
+		err := decode(req, func(f field) error {
+			switch f.num {
+			case 1:
+				var item models.CheckoutItem
+				err := decode(f.bytes, func(f field) error {
+					switch f.num {
+					case 1:
+						item.ProductID = f.int()
+					case 2:
+						item.Quantity = f.int()
+					}
+					return nil
+				})
+				checkout.Items = append(checkout.Items, item)
+				return err
+			case 2:
+				checkout.StoreID = f.int()
+			case 3:
+				checkout.PaymentMethod = f.string()
+			case 4:
+				checkout.PriceLevel = f.string()
+			case 5:
+				checkout.Discount = f.int()
+			case 6:
+				checkout.Notes = f.string()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		checkout.CashierID = actorFrom(ctx).UserID
+
+		transaction, err := service.Checkout(ctx, checkout)
+		if err != nil {
+			return nil, serviceError(err)
+		}
+		return encodeTransaction(*transaction), nil
+	})
+
+	s.handle("/retail.v1.TransactionService/GetTransaction", func(ctx context.Context, req []byte) (message, error) {
+		id, err := decodeID(req)
+		if err != nil {
+			return nil, err
+		}
+		transaction, err := service.GetTransactionByID(ctx, id)
+		if err != nil {
+			return nil, serviceError(err)
+		}
+		if transaction == nil {
+			return nil, Errorf(NotFound, "transaction not found")
+		}
+		return encodeTransaction(*transaction), nil
+	})
+}
+
+// encodeCategory encodes a retail.v1.Category
+func encodeCategory(c models.Category) message {
+	return message(nil).
+		int(1, c.ID).
+		string(2, c.Name).
+		string(3, c.Slug).
+		string(4, c.Description).
+		optionalInt(5, c.ParentID).
+		time(6, c.CreatedAt).
+		time(7, c.UpdatedAt)
+}
+
+// encodeProduct encodes a retail.v1.Product
+func encodeProduct(p models.Product) message {
+	return message(nil).
+		int(1, p.ID).
+		string(2, p.Name).
+		string(3, p.Slug).
+		int(4, p.Price).
+		int(5, p.Stock).
+		int(6, p.MinStock).
+		string(7, p.SKU).
+		string(8, p.ImageURL).
+		string(9, p.Unit).
+		bool(10, p.IsActive).
+		optionalInt(11, p.CategoryID).
+		string(12, p.CategoryName).
+		optionalInt(13, p.SupplierID).
+		bool(14, p.IsConsignment).
+		int(15, p.ConsignmentCost).
+		int(16, p.CostPrice).
+		time(17, p.CreatedAt).
+		time(18, p.UpdatedAt)
+}
+
+// encodeTransaction encodes a retail.v1.Transaction
+func encodeTransaction(t models.Transaction) message {
+	m := message(nil).
+		int(1, t.ID).
+		int(2, t.StoreID).
+		string(3, t.ReceiptNo).
+		int(4, t.QueueNo).
+		int(5, t.TotalAmount).
+		string(6, t.PaymentMethod).
+		string(7, t.PriceLevel).
+		int(8, t.Discount).
+		string(9, t.Notes).
+		string(10, t.Status).
+		time(11, t.CreatedAt)
+	for _, d := range t.Details {
+		m = m.message(12, message(nil).
+			int(1, d.ID).
+			int(2, d.ProductID).
+			string(3, d.ProductName).
+			int(4, d.Quantity).
+			int(5, d.UnitPrice).
+			int(6, d.Discount).
+			int(7, d.Subtotal))
+	}
+	return m.bool(13, t.Archived)
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"retail-core-api/chaos"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+)
+
+// ApprovalNotifier is notified when a catalog change is waiting for review
+type ApprovalNotifier interface {
+	NotifyPending(req models.ProductChangeRequest)
+}
+
+// logApprovalNotifier notifies approvers through the application log
+type logApprovalNotifier struct{}
+
+// NewLogApprovalNotifier creates a notifier that writes pending approvals to the log
+func NewLogApprovalNotifier() ApprovalNotifier {
+	return logApprovalNotifier{}
+}
+
+// NotifyPending logs a pending change request for approvers
+func (logApprovalNotifier) NotifyPending(req models.ProductChangeRequest) {
+	slog.Info("change request is waiting for approval", "component", "approval",
+		"request_id", req.ID, "action", req.Action, "product", req.Payload.Name, "requested_by", req.RequestedByName)
+}
+
+// webhookApprovalNotifier logs pending change requests and POSTs them to a webhook
+type webhookApprovalNotifier struct {
+	webhook notificationWebhook
+}
+
+// NewWebhookApprovalNotifier creates a notifier that logs pending change
+// requests and POSTs {"event":"change_request_pending","change_request":{...}}
+// to url
+func NewWebhookApprovalNotifier(url string, injector *chaos.Injector) ApprovalNotifier {
+	return &webhookApprovalNotifier{webhook: newNotificationWebhook(url, injector)}
+}
+
+// NotifyPending logs the change request and delivers it to the webhook in the
+// background, so submitting a change does not wait on the webhook
+func (n *webhookApprovalNotifier) NotifyPending(req models.ProductChangeRequest) {
+	logApprovalNotifier{}.NotifyPending(req)
+
+	go n.webhook.post(map[string]interface{}{
+		"event":          "change_request_pending",
+		"change_request": req,
+	}, "component", "approval", "request_id", req.ID)
+}
+
+// ApprovalService defines the interface for the catalog approval workflow
+type ApprovalService interface {
+	NeedsApproval(actor models.Actor) bool
+	SubmitCreate(ctx context.Context, product models.Product, actor models.Actor) (*models.ProductChangeRequest, error)
+	SubmitUpdate(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.ProductChangeRequest, error)
+	GetChangeRequests(ctx context.Context, status string) ([]models.ProductChangeRequest, error)
+	GetChangeRequestByID(ctx context.Context, id int) (*models.ProductChangeRequest, error)
+	Approve(ctx context.Context, id int, reviewer models.Actor, note string) (*models.ProductChangeRequest, error)
+	Reject(ctx context.Context, id int, reviewer models.Actor, note string) (*models.ProductChangeRequest, error)
+}
+
+// approvalService implements ApprovalService interface
+type approvalService struct {
+	repo           repositories.ApprovalRepository
+	productRepo    repositories.ProductRepository
+	productService ProductService
+	auditService   AuditService
+	notifier       ApprovalNotifier
+	enabled        bool
+}
+
+// NewApprovalService creates a new approval service instance. When enabled is
+// false every change goes live immediately.
+func NewApprovalService(repo repositories.ApprovalRepository, productRepo repositories.ProductRepository, productService ProductService, auditService AuditService, notifier ApprovalNotifier, enabled bool) ApprovalService {
+	return &approvalService{
+		repo:           repo,
+		productRepo:    productRepo,
+		productService: productService,
+		auditService:   auditService,
+		notifier:       notifier,
+		enabled:        enabled,
+	}
+}
+
+// NeedsApproval reports whether catalog changes by this user must be reviewed.
+// Owners are the approvers, so their changes always go live directly.
+func (s *approvalService) NeedsApproval(actor models.Actor) bool {
+	return s.enabled && actor.Role != "owner"
+}
+
+// SubmitCreate validates a new product and queues it for approval
+func (s *approvalService) SubmitCreate(ctx context.Context, product models.Product, actor models.Actor) (*models.ProductChangeRequest, error) {
+	if err := s.productService.ValidateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return s.submit(ctx, models.ProductChangeRequest{
+		Action:          models.ChangeActionCreate,
+		Payload:         product,
+		RequestedBy:     actor.UserID,
+		RequestedByName: actor.Name,
+	})
+}
+
+// SubmitUpdate queues a product update for approval when it changes the
+// price. It returns nil when the update does not need review and can be
+// applied directly.
+func (s *approvalService) SubmitUpdate(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.ProductChangeRequest, error) {
+	existing, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, helpers.NewNotFoundError("product not found")
+	}
+	if existing.Price == product.Price {
+		return nil, nil
+	}
+
+	if err := s.productService.ValidateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return s.submit(ctx, models.ProductChangeRequest{
+		Action:          models.ChangeActionUpdate,
+		ProductID:       &id,
+		Payload:         product,
+		RequestedBy:     actor.UserID,
+		RequestedByName: actor.Name,
+	})
+}
+
+// submit stores a change request and notifies approvers
+func (s *approvalService) submit(ctx context.Context, req models.ProductChangeRequest) (*models.ProductChangeRequest, error) {
+	created, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.NotifyPending(*created)
+	return created, nil
+}
+
+// GetChangeRequests returns change requests, optionally filtered by status
+func (s *approvalService) GetChangeRequests(ctx context.Context, status string) ([]models.ProductChangeRequest, error) {
+	switch status {
+	case "", models.ChangeStatusPending, models.ChangeStatusApproved, models.ChangeStatusRejected:
+	default:
+		return nil, helpers.NewValidationError("status must be 'pending', 'approved' or 'rejected'")
+	}
+	return s.repo.GetAll(ctx, status)
+}
+
+// GetChangeRequestByID returns a change request by its ID
+func (s *approvalService) GetChangeRequestByID(ctx context.Context, id int) (*models.ProductChangeRequest, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// pendingRequest loads a change request and checks it can still be reviewed
+func (s *approvalService) pendingRequest(ctx context.Context, id int) (*models.ProductChangeRequest, error) {
+	req, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, helpers.NewNotFoundError("change request not found")
+	}
+	if req.Status != models.ChangeStatusPending {
+		return nil, helpers.NewConflictError("change request has already been reviewed")
+	}
+	return req, nil
+}
+
+// Approve applies a pending change to the live catalog and marks it approved
+func (s *approvalService) Approve(ctx context.Context, id int, reviewer models.Actor, note string) (*models.ProductChangeRequest, error) {
+	req, err := s.pendingRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attribute the change to the requester; the approver is on the change request
+	requester := models.Actor{UserID: req.RequestedBy, Name: req.RequestedByName}
+
+	var applied, before *models.Product
+	auditAction := models.AuditActionCreate
+	switch req.Action {
+	case models.ChangeActionCreate:
+		applied, err = s.productService.CreateProduct(ctx, req.Payload, requester)
+	case models.ChangeActionUpdate:
+		auditAction = models.AuditActionUpdate
+		if before, err = s.productRepo.GetByID(ctx, *req.ProductID); err != nil {
+			return nil, err
+		}
+		applied, err = s.productService.UpdateProduct(ctx, *req.ProductID, req.Payload, requester)
+	default:
+		err = errors.New("unknown change request action")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, requester, auditAction, models.AuditEntityProduct, applied.ID, before, applied)
+
+	reviewed, err := s.repo.SetStatus(ctx, id, models.ChangeStatusApproved, reviewer.UserID, note, &applied.ID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewed == nil {
+		return nil, helpers.NewConflictError("change request has already been reviewed")
+	}
+	return reviewed, nil
+}
+
+// Reject marks a pending change request as rejected without applying it
+func (s *approvalService) Reject(ctx context.Context, id int, reviewer models.Actor, note string) (*models.ProductChangeRequest, error) {
+	if _, err := s.pendingRequest(ctx, id); err != nil {
+		return nil, err
+	}
+
+	reviewed, err := s.repo.SetStatus(ctx, id, models.ChangeStatusRejected, reviewer.UserID, note, nil)
+	if err != nil {
+		return nil, err
+	}
+	if reviewed == nil {
+		return nil, helpers.NewConflictError("change request has already been reviewed")
+	}
+	return reviewed, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+)
+
+// AuditService defines the interface for recording and querying entity changes
+type AuditService interface {
+	Record(ctx context.Context, actor models.Actor, action, entityType string, entityID int, before, after interface{})
+	GetAuditLogs(ctx context.Context, params models.AuditLogParams) (*models.PaginatedAuditLogs, error)
+}
+
+// auditService implements AuditService interface
+type auditService struct {
+	repo repositories.AuditRepository
+}
+
+// NewAuditService creates a new audit service instance
+func NewAuditService(repo repositories.AuditRepository) AuditService {
+	return &auditService{repo: repo}
+}
+
+// Record stores a change made by actor. before is nil for creations and
+// after is nil for deletions. The change itself has already been committed,
+// so failures are logged rather than returned.
+func (s *auditService) Record(ctx context.Context, actor models.Actor, action, entityType string, entityID int, before, after interface{}) {
+	entry, err := auditEntry(actor, action, entityType, entityID, before, after)
+	if err == nil {
+		err = s.repo.Create(ctx, entry)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record audit entry", "component", "audit", "action", action, "entity_type", entityType, "entity_id", entityID, "actor", actor.Name, "error", err)
+	}
+}
+
+// auditEntry builds the audit log entry of a change. Services that change
+// data in a unit of work create it in the same transaction as the change.
+func auditEntry(actor models.Actor, action, entityType string, entityID int, before, after interface{}) (models.AuditLog, error) {
+	entry := models.AuditLog{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		ActorName:  actor.Name,
+	}
+	if actor.UserID > 0 {
+		entry.ActorID = &actor.UserID
+	}
+
+	var err error
+	if entry.Before, err = marshalAuditState(before); err == nil {
+		entry.After, err = marshalAuditState(after)
+	}
+	return entry, err
+}
+
+// marshalAuditState encodes an entity snapshot, leaving nil snapshots empty
+func marshalAuditState(state interface{}) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil || string(data) == "null" {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetAuditLogs returns audit logs matching the filters
+func (s *auditService) GetAuditLogs(ctx context.Context, params models.AuditLogParams) (*models.PaginatedAuditLogs, error) {
+	switch params.Action {
+	case "", models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete:
+	default:
+		return nil, helpers.NewValidationError("action must be 'create', 'update' or 'delete'")
+	}
+	return s.repo.GetAll(ctx, params)
+}
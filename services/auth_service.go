@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/repositories"
 	"time"
@@ -12,51 +14,55 @@ import (
 
 // AuthService defines the interface for authentication business logic
 type AuthService interface {
-	Login(email, password string) (*models.LoginResponse, error)
-	Register(name, email, password, role string) (*models.User, error)
+	Login(ctx context.Context, email, password string) (*models.LoginResponse, error)
+	Register(ctx context.Context, name, email, password, role string) (*models.User, error)
 }
 
 // authService implements AuthService interface
 type authService struct {
 	userRepo  repositories.UserRepository
 	jwtSecret string
+	tenantID  int
 }
 
-// NewAuthService creates a new auth service instance
-func NewAuthService(userRepo repositories.UserRepository, jwtSecret string) AuthService {
+// NewAuthService creates a new auth service instance. Tokens carry the
+// tenant they were issued for.
+func NewAuthService(userRepo repositories.UserRepository, jwtSecret string, tenantID int) AuthService {
 	return &authService{
 		userRepo:  userRepo,
 		jwtSecret: jwtSecret,
+		tenantID:  tenantID,
 	}
 }
 
 // Login authenticates a user and returns a JWT token
-func (s *authService) Login(email, password string) (*models.LoginResponse, error) {
-	user, err := s.userRepo.GetByEmail(email)
+func (s *authService) Login(ctx context.Context, email, password string) (*models.LoginResponse, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, errors.New("failed to find user")
 	}
 	if user == nil {
-		return nil, errors.New("invalid email or password")
+		return nil, helpers.NewCodedError(helpers.CodeInvalidCredentials)
 	}
 
 	if !user.IsActive {
-		return nil, errors.New("account is deactivated")
+		return nil, helpers.NewCodedError(helpers.CodeAccountDeactivated)
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, helpers.NewCodedError(helpers.CodeInvalidCredentials)
 	}
 
 	// Generate JWT token
 	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"role":    user.Role,
-		"name":    user.Name,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
-		"iat":     time.Now().Unix(),
+		"user_id":   user.ID,
+		"email":     user.Email,
+		"role":      user.Role,
+		"name":      user.Name,
+		"tenant_id": s.tenantID,
+		"exp":       time.Now().Add(24 * time.Hour).Unix(),
+		"iat":       time.Now().Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -75,19 +81,19 @@ func (s *authService) Login(email, password string) (*models.LoginResponse, erro
 }
 
 // Register creates a new user account
-func (s *authService) Register(name, email, password, role string) (*models.User, error) {
+func (s *authService) Register(ctx context.Context, name, email, password, role string) (*models.User, error) {
 	// Check if email already exists
-	existing, err := s.userRepo.GetByEmail(email)
+	existing, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, errors.New("failed to check existing user")
 	}
 	if existing != nil {
-		return nil, errors.New("email already registered")
+		return nil, helpers.NewConflictError("email already registered")
 	}
 
 	// Validate role
 	if role != "owner" && role != "cashier" {
-		return nil, errors.New("role must be 'owner' or 'cashier'")
+		return nil, helpers.NewValidationError("role must be 'owner' or 'cashier'")
 	}
 
 	// Hash password
@@ -103,5 +109,5 @@ func (s *authService) Register(name, email, password, role string) (*models.User
 		Role:     role,
 	}
 
-	return s.userRepo.Create(user)
+	return s.userRepo.Create(ctx, user)
 }
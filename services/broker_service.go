@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"retail-core-api/broker"
+	"retail-core-api/models"
+	"time"
+)
+
+// brokerSubjects maps domain events to the last tokens of their subjects
+var brokerSubjects = map[string]string{
+	models.DomainEventTransactionCreated: "transaction.created",
+	models.DomainEventStockChanged:       "stock.changed",
+}
+
+// brokerEventHandler publishes domain events relayed from the outbox to a
+// message broker
+type brokerEventHandler struct {
+	publisher broker.Publisher
+	prefix    string
+	tenant    string
+}
+
+// NewBrokerEventHandler creates the outbox consumer that publishes a
+// tenant's domain events to "{prefix}.{tenant}.{event}", e.g.
+// "retail.default.transaction.created"
+func NewBrokerEventHandler(publisher broker.Publisher, prefix, tenant string) EventHandler {
+	return &brokerEventHandler{publisher: publisher, prefix: prefix, tenant: tenant}
+}
+
+// Consumer names the broker publisher in the outbox
+func (h *brokerEventHandler) Consumer() string {
+	return "broker"
+}
+
+// HandleEvent publishes an event as {"id","type","tenant","aggregate_type",
+// "aggregate_id","created_at","data"}. The id, which is also the message ID,
+// derives from the outbox event, so an event relayed twice is published
+// with the same id.
+func (h *brokerEventHandler) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	subject, ok := brokerSubjects[event.Type]
+	if !ok {
+		return nil
+	}
+
+	id := fmt.Sprintf("%s-%d", h.tenant, event.ID)
+	body, err := json.Marshal(map[string]interface{}{
+		"id":             id,
+		"type":           event.Type,
+		"tenant":         h.tenant,
+		"aggregate_type": event.AggregateType,
+		"aggregate_id":   event.AggregateID,
+		"created_at":     event.CreatedAt.UTC(),
+		"data":           event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return h.publisher.Publish(ctx, broker.Message{
+		Subject: h.prefix + "." + h.tenant + "." + subject,
+		ID:      id,
+		Data:    body,
+	})
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"retail-core-api/cache"
+	"retail-core-api/models"
+	"strconv"
+	"time"
+)
+
+// CatalogCache caches the product and category reads of one tenant
+// (cache-aside). Entries are keyed by a catalog version that every change
+// bumps, so one INCR invalidates every cached list and product at once and
+// entries of older versions simply expire after ttl. Cache failures are
+// logged and fall back to the database.
+type CatalogCache struct {
+	cache  cache.Cache
+	prefix string
+	ttl    time.Duration
+}
+
+// NewCatalogCache creates the catalog cache of a tenant. ttl bounds how long
+// a change made outside the catalog services (scheduled prices, say)
+// takes to show.
+func NewCatalogCache(c cache.Cache, tenantID int, ttl time.Duration) *CatalogCache {
+	return &CatalogCache{cache: c, prefix: fmt.Sprintf("retail:%d:catalog:", tenantID), ttl: ttl}
+}
+
+// version returns the current catalog version, 0 before the first change
+func (c *CatalogCache) version(ctx context.Context) (string, error) {
+	value, ok, err := c.cache.Get(ctx, c.prefix+"version")
+	if err != nil || !ok {
+		return "0", err
+	}
+	return string(value), nil
+}
+
+// load reads name into dst, reporting whether it was cached. key is where a
+// value loaded from the database should then be stored, "" when the cache is
+// unavailable.
+func (c *CatalogCache) load(ctx context.Context, name string, dst interface{}) (hit bool, key string) {
+	version, err := c.version(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "catalog cache unavailable", "component", "cache", "error", err)
+		return false, ""
+	}
+	key = c.prefix + version + ":" + name
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "catalog cache unavailable", "component", "cache", "error", err)
+		return false, ""
+	}
+	if !ok || json.Unmarshal(data, dst) != nil {
+		return false, key
+	}
+	return true, key
+}
+
+// store caches value under a key returned by load
+func (c *CatalogCache) store(ctx context.Context, key string, value interface{}) {
+	if key == "" {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = c.cache.Set(ctx, key, data, c.ttl)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to cache catalog read", "component", "cache", "key", key, "error", err)
+	}
+}
+
+// Invalidate drops every cached catalog read. It is called after a change
+// has been committed, so a read racing with it can only store its result
+// under the old version.
+func (c *CatalogCache) Invalidate(ctx context.Context) {
+	if _, err := c.cache.Incr(ctx, c.prefix+"version"); err != nil {
+		slog.WarnContext(ctx, "failed to invalidate catalog cache", "component", "cache", "error", err)
+	}
+}
+
+// Consumer names the catalog cache in the outbox
+func (c *CatalogCache) Consumer() string {
+	return "catalog_cache"
+}
+
+// HandleEvent invalidates the cache on stock changes relayed from the outbox,
+// among them those of every checkout, made through any instance. It never
+// fails, so an unavailable cache does not hold up the relay.
+func (c *CatalogCache) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	switch event.Type {
+	case models.DomainEventStockChanged, models.DomainEventTransactionCreated:
+		c.Invalidate(ctx)
+	}
+	return nil
+}
+
+// cachedProductService serves product reads from the catalog cache and
+// invalidates it on every change made through it
+type cachedProductService struct {
+	ProductService
+	cache *CatalogCache
+}
+
+// NewCachedProductService wraps a product service with the catalog cache
+func NewCachedProductService(service ProductService, cache *CatalogCache) ProductService {
+	return &cachedProductService{ProductService: service, cache: cache}
+}
+
+// GetAllProducts returns a cached page of products, loading it on a miss
+func (s *cachedProductService) GetAllProducts(ctx context.Context, params models.ProductListParams) (*models.PaginatedProducts, error) {
+	categoryID := ""
+	if params.CategoryID != nil {
+		categoryID = strconv.Itoa(*params.CategoryID)
+	}
+	name := fmt.Sprintf("products:%q:%s:%d:%d", params.Search, categoryID, params.Page, params.Limit)
+
+	var cached models.PaginatedProducts
+	hit, key := s.cache.load(ctx, name, &cached)
+	if hit {
+		return &cached, nil
+	}
+	result, err := s.ProductService.GetAllProducts(ctx, params)
+	if err == nil {
+		s.cache.store(ctx, key, result)
+	}
+	return result, err
+}
+
+// GetProductByID returns a cached product, loading it on a miss
+func (s *cachedProductService) GetProductByID(ctx context.Context, id int) (*models.Product, error) {
+	var cached models.Product
+	hit, key := s.cache.load(ctx, "product:"+strconv.Itoa(id), &cached)
+	if hit {
+		return &cached, nil
+	}
+	product, err := s.ProductService.GetProductByID(ctx, id)
+	if err == nil && product != nil {
+		s.cache.store(ctx, key, product)
+	}
+	return product, err
+}
+
+// CreateProduct creates a product and invalidates the cache
+func (s *cachedProductService) CreateProduct(ctx context.Context, product models.Product, actor models.Actor) (*models.Product, error) {
+	created, err := s.ProductService.CreateProduct(ctx, product, actor)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return created, err
+}
+
+// UpdateProduct updates a product and invalidates the cache
+func (s *cachedProductService) UpdateProduct(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.Product, error) {
+	updated, err := s.ProductService.UpdateProduct(ctx, id, product, actor)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return updated, err
+}
+
+// DeleteProduct deletes a product and invalidates the cache
+func (s *cachedProductService) DeleteProduct(ctx context.Context, id int) error {
+	err := s.ProductService.DeleteProduct(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+// AddProductRelation adds a related product and invalidates the cache, which
+// holds products with their relations
+func (s *cachedProductService) AddProductRelation(ctx context.Context, productID int, input models.ProductRelationInput) (*models.ProductRelation, error) {
+	relation, err := s.ProductService.AddProductRelation(ctx, productID, input)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return relation, err
+}
+
+// RemoveProductRelation removes a related product and invalidates the cache
+func (s *cachedProductService) RemoveProductRelation(ctx context.Context, productID, relatedProductID int, relationType string) error {
+	err := s.ProductService.RemoveProductRelation(ctx, productID, relatedProductID, relationType)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+// cachedCategoryService serves category reads from the catalog cache and
+// invalidates it on every change made through it
+type cachedCategoryService struct {
+	CategoryService
+	cache *CatalogCache
+}
+
+// NewCachedCategoryService wraps a category service with the catalog cache
+func NewCachedCategoryService(service CategoryService, cache *CatalogCache) CategoryService {
+	return &cachedCategoryService{CategoryService: service, cache: cache}
+}
+
+// GetAllCategories returns the cached categories, loading them on a miss
+func (s *cachedCategoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	var cached []models.Category
+	hit, key := s.cache.load(ctx, "categories", &cached)
+	if hit {
+		return cached, nil
+	}
+	categories, err := s.CategoryService.GetAllCategories(ctx)
+	if err == nil {
+		s.cache.store(ctx, key, categories)
+	}
+	return categories, err
+}
+
+// GetCategoryByID returns a cached category, loading it on a miss
+func (s *cachedCategoryService) GetCategoryByID(ctx context.Context, id int) (*models.Category, error) {
+	var cached models.Category
+	hit, key := s.cache.load(ctx, "category:"+strconv.Itoa(id), &cached)
+	if hit {
+		return &cached, nil
+	}
+	category, err := s.CategoryService.GetCategoryByID(ctx, id)
+	if err == nil && category != nil {
+		s.cache.store(ctx, key, category)
+	}
+	return category, err
+}
+
+// CreateCategory creates a category and invalidates the cache
+func (s *cachedCategoryService) CreateCategory(ctx context.Context, category models.Category) (*models.Category, error) {
+	created, err := s.CategoryService.CreateCategory(ctx, category)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return created, err
+}
+
+// UpdateCategory updates a category and invalidates the cache, which holds
+// products with their category's name
+func (s *cachedCategoryService) UpdateCategory(ctx context.Context, id int, category models.Category) (*models.Category, error) {
+	updated, err := s.CategoryService.UpdateCategory(ctx, id, category)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return updated, err
+}
+
+// DeleteCategory deletes a category and invalidates the cache
+func (s *cachedCategoryService) DeleteCategory(ctx context.Context, id int) error {
+	err := s.CategoryService.DeleteCategory(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
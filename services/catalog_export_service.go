@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"strconv"
+	"time"
+)
+
+// CatalogExportService defines the interface for spreadsheet exports of the catalog
+type CatalogExportService interface {
+	ValidateFormat(format string) error
+	ExportProducts(ctx context.Context, w io.Writer, format string) error
+	ExportCategories(ctx context.Context, w io.Writer, format string) error
+}
+
+// catalogExportService implements CatalogExportService interface
+type catalogExportService struct {
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+// NewCatalogExportService creates a new catalog export service instance
+func NewCatalogExportService(productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository) CatalogExportService {
+	return &catalogExportService{productRepo: productRepo, categoryRepo: categoryRepo}
+}
+
+// exportSheet receives the rows of one exported table
+type exportSheet interface {
+	start(name string, headers []string) error
+	row(cells ...interface{}) error
+	close() error
+}
+
+// ValidateFormat checks an export format before anything is written
+func (s *catalogExportService) ValidateFormat(format string) error {
+	if format != models.ExportFormatCSV && format != models.ExportFormatXLSX {
+		return helpers.NewValidationError("format must be 'csv' or 'xlsx'")
+	}
+	return nil
+}
+
+// ExportProducts writes every product with its category name. The XLSX
+// workbook has a second sheet with the categories.
+func (s *catalogExportService) ExportProducts(ctx context.Context, w io.Writer, format string) error {
+	if err := s.ValidateFormat(format); err != nil {
+		return err
+	}
+	out := newExportSheet(w, format)
+
+	if err := out.start("Products", models.ProductExportColumns); err != nil {
+		return err
+	}
+	err := s.productRepo.EachListing(ctx, func(p models.Product) error {
+		return out.row(
+			p.Name, p.SKU, p.Price, p.Stock, p.CategoryName,
+			p.ID, p.Slug, p.Unit, p.MinStock, p.CostPrice, strconv.FormatBool(p.IsActive), p.UpdatedAt.Format(time.RFC3339),
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	if format == models.ExportFormatXLSX {
+		if err := s.writeCategories(ctx, out); err != nil {
+			return err
+		}
+	}
+	return out.close()
+}
+
+// ExportCategories writes every category with the name of its parent
+func (s *catalogExportService) ExportCategories(ctx context.Context, w io.Writer, format string) error {
+	if err := s.ValidateFormat(format); err != nil {
+		return err
+	}
+	out := newExportSheet(w, format)
+	if err := s.writeCategories(ctx, out); err != nil {
+		return err
+	}
+	return out.close()
+}
+
+// writeCategories adds the categories table to an export
+func (s *catalogExportService) writeCategories(ctx context.Context, out exportSheet) error {
+	categories, err := s.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	names := make(map[int]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	if err := out.start("Categories", models.CategoryExportColumns); err != nil {
+		return err
+	}
+	for _, c := range categories {
+		var parentID interface{} = ""
+		parent := ""
+		if c.ParentID != nil {
+			parentID, parent = *c.ParentID, names[*c.ParentID]
+		}
+		if err := out.row(c.ID, c.Name, c.Slug, parentID, parent, c.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newExportSheet returns the writer for an export format
+func newExportSheet(w io.Writer, format string) exportSheet {
+	if format == models.ExportFormatXLSX {
+		return &xlsxExport{w: helpers.NewXLSXWriter(w)}
+	}
+	return &csvExport{w: csv.NewWriter(w)}
+}
+
+// csvExport writes a single table as CSV with a header row
+type csvExport struct {
+	w *csv.Writer
+	n int
+}
+
+func (e *csvExport) start(_ string, headers []string) error {
+	return e.w.Write(headers)
+}
+
+func (e *csvExport) row(cells ...interface{}) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		switch v := cell.(type) {
+		case string:
+			record[i] = v
+		case int:
+			record[i] = strconv.Itoa(v)
+		}
+	}
+	if err := e.w.Write(record); err != nil {
+		return err
+	}
+	// Flush now and then so the response streams instead of buffering
+	if e.n++; e.n%500 == 0 {
+		e.w.Flush()
+		return e.w.Error()
+	}
+	return nil
+}
+
+func (e *csvExport) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// xlsxExport writes each table as a sheet of a workbook
+type xlsxExport struct {
+	w *helpers.XLSXWriter
+}
+
+func (e *xlsxExport) start(name string, headers []string) error {
+	return e.w.Sheet(name, headers...)
+}
+
+func (e *xlsxExport) row(cells ...interface{}) error {
+	return e.w.Row(cells...)
+}
+
+func (e *xlsxExport) close() error {
+	return e.w.Close()
+}
@@ -1,18 +1,22 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/repositories"
 )
 
 // CategoryService defines the interface for category business logic
 type CategoryService interface {
-	GetAllCategories() ([]models.Category, error)
-	GetCategoryByID(id int) (*models.Category, error)
-	CreateCategory(category models.Category) (*models.Category, error)
-	UpdateCategory(id int, category models.Category) (*models.Category, error)
-	DeleteCategory(id int) error
+	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	GetCategoryByID(ctx context.Context, id int) (*models.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error)
+	CreateCategory(ctx context.Context, category models.Category) (*models.Category, error)
+	UpdateCategory(ctx context.Context, id int, category models.Category) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id int) error
+	GetCategoryTree(ctx context.Context) ([]models.CategoryTreeNode, error)
 }
 
 // categoryService implements CategoryService interface
@@ -26,45 +30,143 @@ func NewCategoryService(repo repositories.CategoryRepository) CategoryService {
 }
 
 // GetAllCategories returns all categories
-func (s *categoryService) GetAllCategories() ([]models.Category, error) {
-	return s.repo.GetAll()
+func (s *categoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	return s.repo.GetAll(ctx)
 }
 
 // GetCategoryByID returns a category by its ID
-func (s *categoryService) GetCategoryByID(id int) (*models.Category, error) {
-	return s.repo.GetByID(id)
+func (s *categoryService) GetCategoryByID(ctx context.Context, id int) (*models.Category, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetCategoryBySlug returns a category by its slug
+func (s *categoryService) GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	return s.repo.GetBySlug(ctx, slug)
 }
 
 // CreateCategory validates and creates a new category
-func (s *categoryService) CreateCategory(category models.Category) (*models.Category, error) {
-	// Business logic validation
-	if category.Name == "" {
-		return nil, errors.New("category name is required")
+func (s *categoryService) CreateCategory(ctx context.Context, category models.Category) (*models.Category, error) {
+	if err := s.validateCategory(ctx, 0, category); err != nil {
+		return nil, err
 	}
 
-	return s.repo.Create(category)
+	return s.repo.Create(ctx, category)
 }
 
 // UpdateCategory validates and updates an existing category
-func (s *categoryService) UpdateCategory(id int, category models.Category) (*models.Category, error) {
-	// Business logic validation
-	if category.Name == "" {
-		return nil, errors.New("category name is required")
+func (s *categoryService) UpdateCategory(ctx context.Context, id int, category models.Category) (*models.Category, error) {
+	if err := s.validateCategory(ctx, id, category); err != nil {
+		return nil, err
 	}
 
-	updated, err := s.repo.Update(id, category)
+	updated, err := s.repo.Update(ctx, id, category)
 	if err != nil {
 		return nil, err
 	}
 	
 	if updated == nil {
-		return nil, errors.New("category not found")
+		return nil, helpers.NewNotFoundError("category not found")
 	}
 
 	return updated, nil
 }
 
 // DeleteCategory removes a category by its ID
-func (s *categoryService) DeleteCategory(id int) error {
-	return s.repo.Delete(id)
+func (s *categoryService) DeleteCategory(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// validateCategory applies the business rules of category creation and
+// update (id is 0 for new categories), reporting every invalid field at once
+// as helpers.FieldErrors
+func (s *categoryService) validateCategory(ctx context.Context, id int, category models.Category) error {
+	var errs helpers.FieldErrors
+	if category.Name == "" {
+		errs.Add("name", "required", "name is required")
+	}
+	if err := s.validateParent(ctx, id, category.ParentID, &errs); err != nil {
+		return err
+	}
+	return errs.Err()
+}
+
+// validateParent checks that the parent category exists and that placing
+// category id under it would not create a cycle. Rule violations are added to
+// errs; the returned error is a failure to check them.
+func (s *categoryService) validateParent(ctx context.Context, id int, parentID *int, errs *helpers.FieldErrors) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		errs.Add("parent_id", "parent", "parent_id cannot be the category itself")
+		return nil
+	}
+
+	parent, err := s.repo.GetByID(ctx, *parentID)
+	if err != nil {
+		return errors.New("failed to validate parent category")
+	}
+	if parent == nil {
+		errs.Add("parent_id", "exists", "parent_id does not match a category")
+		return nil
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	descendants, err := s.repo.GetDescendantIDs(ctx, id)
+	if err != nil {
+		return errors.New("failed to validate parent category")
+	}
+	for _, d := range descendants {
+		if d == *parentID {
+			errs.Add("parent_id", "parent", "parent_id cannot be one of the category's own subcategories")
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// GetCategoryTree returns all categories nested under their parents
+func (s *categoryService) GetCategoryTree(ctx context.Context) ([]models.CategoryTreeNode, error) {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildCategoryTree(categories), nil
+}
+
+// BuildCategoryTree nests a flat category list under their parents. Categories
+// whose parent is missing from the list are treated as roots.
+func BuildCategoryTree(categories []models.Category) []models.CategoryTreeNode {
+	known := make(map[int]bool, len(categories))
+	children := make(map[int][]models.Category)
+	for _, c := range categories {
+		known[c.ID] = true
+	}
+
+	roots := make([]models.Category, 0)
+	for _, c := range categories {
+		if c.ParentID != nil && known[*c.ParentID] {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var build func(list []models.Category) []models.CategoryTreeNode
+	build = func(list []models.Category) []models.CategoryTreeNode {
+		nodes := make([]models.CategoryTreeNode, 0, len(list))
+		for _, c := range list {
+			nodes = append(nodes, models.CategoryTreeNode{
+				Category: c,
+				Children: build(children[c.ID]),
+			})
+		}
+		return nodes
+	}
+
+	return build(roots)
 }
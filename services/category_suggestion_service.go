@@ -0,0 +1,479 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// suggestionBatchSize caps how many uncategorized products one scan handles
+	suggestionBatchSize = 1000
+	// suggestionVocabularySize is how many categorized product names the
+	// heuristics learn from
+	suggestionVocabularySize = 5000
+	// heuristicConfidenceScale keeps heuristic guesses below comparable rule matches
+	heuristicConfidenceScale = 0.6
+	// DefaultSuggestionMinConfidence is the confidence below which no suggestion is queued
+	DefaultSuggestionMinConfidence = 0.3
+)
+
+// CategoryPrediction is a category proposed by a CategoryClassifier
+type CategoryPrediction struct {
+	CategoryID int     `json:"category_id"`
+	Confidence float64 `json:"confidence"`
+}
+
+// CategoryClassifier is an optional external model consulted for products no
+// keyword rule matches. It returns nil when it has no prediction.
+type CategoryClassifier interface {
+	Classify(product models.Product, categories []models.Category) (*CategoryPrediction, error)
+}
+
+// httpCategoryClassifier asks an HTTP endpoint to classify a product
+type httpCategoryClassifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPCategoryClassifier creates a classifier that POSTs
+// {"name","sku","categories":[{"id","name"}]} to url and expects
+// {"category_id","confidence"} back; a category_id of 0 means no prediction
+func NewHTTPCategoryClassifier(url string) CategoryClassifier {
+	return &httpCategoryClassifier{url: url, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// classifierCategory is a category as sent to the external classifier
+type classifierCategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Classify sends the product and the candidate categories to the classifier
+func (c *httpCategoryClassifier) Classify(product models.Product, categories []models.Category) (*CategoryPrediction, error) {
+	candidates := make([]classifierCategory, 0, len(categories))
+	for _, category := range categories {
+		candidates = append(candidates, classifierCategory{ID: category.ID, Name: category.Name})
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":       product.Name,
+		"sku":        product.SKU,
+		"categories": candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("classifier returned %s", resp.Status)
+	}
+
+	var prediction CategoryPrediction
+	if err := json.NewDecoder(resp.Body).Decode(&prediction); err != nil {
+		return nil, fmt.Errorf("invalid classifier response: %w", err)
+	}
+	if prediction.CategoryID <= 0 {
+		return nil, nil
+	}
+	return &prediction, nil
+}
+
+// CategorySuggestionService defines the interface for suggesting categories
+// for uncategorized products and reviewing the suggestions
+type CategorySuggestionService interface {
+	GetRules(ctx context.Context) ([]models.CategoryRule, error)
+	CreateRule(ctx context.Context, input models.CategoryRuleInput) (*models.CategoryRule, error)
+	DeleteRule(ctx context.Context, id int) error
+	GetSuggestions(ctx context.Context, status string) ([]models.CategorySuggestion, error)
+	GetSuggestionByID(ctx context.Context, id int) (*models.CategorySuggestion, error)
+	SuggestFor(ctx context.Context, product models.Product) (bool, error)
+	GenerateSuggestions(ctx context.Context) (*models.CategorySuggestionRun, error)
+	Accept(ctx context.Context, id int, categoryID *int, reviewer models.Actor) (*models.CategorySuggestion, error)
+	Reject(ctx context.Context, id int, reviewer models.Actor) (*models.CategorySuggestion, error)
+}
+
+// categorySuggestionService implements CategorySuggestionService interface
+type categorySuggestionService struct {
+	repo          repositories.CategorySuggestionRepository
+	categoryRepo  repositories.CategoryRepository
+	classifier    CategoryClassifier
+	minConfidence float64
+}
+
+// NewCategorySuggestionService creates a new category suggestion service
+// instance. The classifier is optional; suggestions below minConfidence are
+// dropped.
+func NewCategorySuggestionService(repo repositories.CategorySuggestionRepository, categoryRepo repositories.CategoryRepository, classifier CategoryClassifier, minConfidence float64) CategorySuggestionService {
+	if minConfidence <= 0 || minConfidence > 1 {
+		minConfidence = DefaultSuggestionMinConfidence
+	}
+	return &categorySuggestionService{
+		repo:          repo,
+		categoryRepo:  categoryRepo,
+		classifier:    classifier,
+		minConfidence: minConfidence,
+	}
+}
+
+// GetRules returns every keyword rule
+func (s *categorySuggestionService) GetRules(ctx context.Context) ([]models.CategoryRule, error) {
+	return s.repo.GetRules(ctx)
+}
+
+// CreateRule validates and stores a keyword rule
+func (s *categorySuggestionService) CreateRule(ctx context.Context, input models.CategoryRuleInput) (*models.CategoryRule, error) {
+	keyword := normalizeText(input.Keyword)
+	if keyword == "" {
+		return nil, helpers.NewValidationError("keyword must contain letters or digits")
+	}
+	category, err := s.categoryRepo.GetByID(ctx, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, helpers.NewValidationError(fmt.Sprintf("category id %d not found", input.CategoryID))
+	}
+
+	weight := input.Weight
+	if weight == 0 {
+		weight = 1
+	}
+	return s.repo.CreateRule(ctx, models.CategoryRule{CategoryID: input.CategoryID, Keyword: keyword, Weight: weight})
+}
+
+// DeleteRule removes a keyword rule
+func (s *categorySuggestionService) DeleteRule(ctx context.Context, id int) error {
+	deleted, err := s.repo.DeleteRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return helpers.NewNotFoundError("category rule not found")
+	}
+	return nil
+}
+
+// GetSuggestions returns suggestions filtered by status, pending when empty
+func (s *categorySuggestionService) GetSuggestions(ctx context.Context, status string) ([]models.CategorySuggestion, error) {
+	switch status {
+	case "":
+		status = models.SuggestionStatusPending
+	case "all":
+		status = ""
+	case models.SuggestionStatusPending, models.SuggestionStatusAccepted, models.SuggestionStatusRejected:
+	default:
+		return nil, helpers.NewValidationError("status must be pending, accepted, rejected or all")
+	}
+	return s.repo.GetAll(ctx, status)
+}
+
+// GetSuggestionByID returns a suggestion by its ID
+func (s *categorySuggestionService) GetSuggestionByID(ctx context.Context, id int) (*models.CategorySuggestion, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// SuggestFor queues a suggestion for one uncategorized product, e.g. right
+// after it was created or imported. Returns false when no category is
+// confident enough.
+func (s *categorySuggestionService) SuggestFor(ctx context.Context, product models.Product) (bool, error) {
+	if product.CategoryID != nil {
+		return false, nil
+	}
+	engine, err := s.loadEngine(ctx)
+	if err != nil {
+		return false, err
+	}
+	return s.suggest(ctx, engine, product)
+}
+
+// GenerateSuggestions scans uncategorized products that have no suggestion yet
+func (s *categorySuggestionService) GenerateSuggestions(ctx context.Context) (*models.CategorySuggestionRun, error) {
+	products, err := s.repo.GetUncategorizedProducts(ctx, suggestionBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := s.loadEngine(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.CategorySuggestionRun{Scanned: len(products)}
+	for _, product := range products {
+		queued, err := s.suggest(ctx, engine, product)
+		if err != nil {
+			return nil, err
+		}
+		if queued {
+			run.Suggested++
+		} else {
+			run.Skipped++
+		}
+	}
+	return run, nil
+}
+
+// Accept assigns the suggested category, or the reviewer's choice, to the product
+func (s *categorySuggestionService) Accept(ctx context.Context, id int, categoryID *int, reviewer models.Actor) (*models.CategorySuggestion, error) {
+	suggestion, err := s.pending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	chosen := suggestion.CategoryID
+	if categoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, *categoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			return nil, helpers.NewValidationError(fmt.Sprintf("invalid category_id %d: category not found", *categoryID))
+		}
+		chosen = category.ID
+	}
+
+	accepted, err := s.repo.Accept(ctx, id, chosen, reviewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if accepted == nil {
+		return nil, helpers.NewConflictError("suggestion has already been reviewed")
+	}
+	return accepted, nil
+}
+
+// Reject dismisses a suggestion; the product stays uncategorized
+func (s *categorySuggestionService) Reject(ctx context.Context, id int, reviewer models.Actor) (*models.CategorySuggestion, error) {
+	if _, err := s.pending(ctx, id); err != nil {
+		return nil, err
+	}
+	rejected, err := s.repo.Reject(ctx, id, reviewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if rejected == nil {
+		return nil, helpers.NewConflictError("suggestion has already been reviewed")
+	}
+	return rejected, nil
+}
+
+// pending loads a suggestion that is still waiting for review
+func (s *categorySuggestionService) pending(ctx context.Context, id int) (*models.CategorySuggestion, error) {
+	suggestion, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion == nil {
+		return nil, helpers.NewNotFoundError("suggestion not found")
+	}
+	if suggestion.Status != models.SuggestionStatusPending {
+		return nil, helpers.NewConflictError("suggestion has already been reviewed")
+	}
+	return suggestion, nil
+}
+
+// suggestionEngine holds what one scan needs to score products
+type suggestionEngine struct {
+	rules      []models.CategoryRule
+	categories []models.Category
+	// vocabulary holds, per category, the name tokens of its products
+	vocabulary map[int]map[string]bool
+}
+
+// loadEngine reads the rules, categories and categorized product names
+func (s *categorySuggestionService) loadEngine(ctx context.Context) (*suggestionEngine, error) {
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.repo.GetCategorizedNames(ctx, suggestionVocabularySize)
+	if err != nil {
+		return nil, err
+	}
+
+	vocabulary := make(map[int]map[string]bool, len(names))
+	for categoryID, productNames := range names {
+		tokens := make(map[string]bool)
+		for _, name := range productNames {
+			for _, token := range nameTokens(name) {
+				tokens[token] = true
+			}
+		}
+		vocabulary[categoryID] = tokens
+	}
+	return &suggestionEngine{rules: rules, categories: categories, vocabulary: vocabulary}, nil
+}
+
+// suggest scores a product and queues the best category when it is confident
+// enough. Keyword rules win; otherwise the external classifier and the name
+// heuristics are compared and the more confident one is used.
+func (s *categorySuggestionService) suggest(ctx context.Context, engine *suggestionEngine, product models.Product) (bool, error) {
+	if len(engine.categories) == 0 {
+		return false, nil
+	}
+
+	suggestion := engine.matchRules(product)
+	if suggestion == nil {
+		suggestion = engine.matchHeuristics(product)
+		if s.classifier != nil {
+			prediction, err := s.classifier.Classify(product, engine.categories)
+			if err != nil {
+				slog.WarnContext(ctx, "classifier failed", "component", "category-suggest", "product_id", product.ID, "error", err)
+			} else if prediction != nil && engine.hasCategory(prediction.CategoryID) &&
+				(suggestion == nil || prediction.Confidence > suggestion.Confidence) {
+				suggestion = &models.CategorySuggestion{
+					CategoryID: prediction.CategoryID,
+					Confidence: prediction.Confidence,
+					Source:     models.SuggestionSourceClassifier,
+					Reason:     "external classifier",
+				}
+			}
+		}
+	}
+	if suggestion == nil || suggestion.Confidence < s.minConfidence {
+		return false, nil
+	}
+
+	if suggestion.Confidence > 1 {
+		suggestion.Confidence = 1
+	}
+	suggestion.ProductID = product.ID
+	return s.repo.Create(ctx, *suggestion)
+}
+
+// matchRules sums the weights of the keyword rules found in the product name
+// and SKU per category. Confidence grows with the winning weight and shrinks
+// when other categories match too.
+func (e *suggestionEngine) matchRules(product models.Product) *models.CategorySuggestion {
+	text := " " + normalizeText(product.Name+" "+product.SKU) + " "
+	scores := make(map[int]int)
+	matched := make(map[int][]string)
+	for _, rule := range e.rules {
+		if strings.Contains(text, " "+rule.Keyword+" ") {
+			scores[rule.CategoryID] += rule.Weight
+			matched[rule.CategoryID] = append(matched[rule.CategoryID], rule.Keyword)
+		}
+	}
+
+	best, total := bestScore(scores)
+	if best == 0 {
+		return nil
+	}
+	return &models.CategorySuggestion{
+		CategoryID: best,
+		Confidence: float64(scores[best]) / float64(total+1),
+		Source:     models.SuggestionSourceRule,
+		Reason:     "keyword " + quoteAll(matched[best]),
+	}
+}
+
+// matchHeuristics compares the product name with category names (worth two
+// points per shared word) and with the names of products already in each
+// category (one point per shared word)
+func (e *suggestionEngine) matchHeuristics(product models.Product) *models.CategorySuggestion {
+	tokens := nameTokens(product.Name)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	scores := make(map[int]int)
+	for _, category := range e.categories {
+		categoryTokens := make(map[string]bool)
+		for _, token := range nameTokens(category.Name) {
+			categoryTokens[token] = true
+		}
+		for _, token := range tokens {
+			if categoryTokens[token] {
+				scores[category.ID] += 2
+			}
+			if e.vocabulary[category.ID][token] {
+				scores[category.ID]++
+			}
+		}
+	}
+
+	best, total := bestScore(scores)
+	if best == 0 {
+		return nil
+	}
+	return &models.CategorySuggestion{
+		CategoryID: best,
+		Confidence: heuristicConfidenceScale * float64(scores[best]) / float64(total+1),
+		Source:     models.SuggestionSourceHeuristic,
+		Reason:     "similar to category name and products",
+	}
+}
+
+// hasCategory reports whether a category ID exists
+func (e *suggestionEngine) hasCategory(id int) bool {
+	for _, category := range e.categories {
+		if category.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// bestScore returns the highest scoring category (lowest ID on ties) and the
+// sum of all scores
+func bestScore(scores map[int]int) (int, int) {
+	best, total := 0, 0
+	for categoryID, score := range scores {
+		total += score
+		if best == 0 || score > scores[best] || (score == scores[best] && categoryID < best) {
+			best = categoryID
+		}
+	}
+	return best, total
+}
+
+// normalizeText lowercases text and reduces everything but letters and
+// digits to single spaces
+func normalizeText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// nameTokens returns the distinct words of a name worth comparing: at least
+// three characters and not purely numeric (sizes and pack counts)
+func nameTokens(name string) []string {
+	seen := make(map[string]bool)
+	tokens := make([]string, 0)
+	for _, token := range strings.Fields(normalizeText(name)) {
+		if len([]rune(token)) < 3 || seen[token] || strings.IndexFunc(token, unicode.IsLetter) < 0 {
+			continue
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// quoteAll formats keywords for a suggestion reason
+func quoteAll(keywords []string) string {
+	sort.Strings(keywords)
+	quoted := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		quoted[i] = fmt.Sprintf("%q", keyword)
+	}
+	return strings.Join(quoted, ", ")
+}
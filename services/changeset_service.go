@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+)
+
+// ChangesetService defines the interface for scheduled catalog publishing
+type ChangesetService interface {
+	GetChangesets(ctx context.Context, status string) ([]models.CatalogChangeset, error)
+	GetChangesetByID(ctx context.Context, id int) (*models.CatalogChangeset, error)
+	CreateChangeset(ctx context.Context, name string, actor models.Actor) (*models.CatalogChangeset, error)
+	AddItem(ctx context.Context, changesetID int, productID *int, product models.Product) (*models.CatalogChangesetItem, error)
+	RemoveItem(ctx context.Context, changesetID, itemID int) error
+	Schedule(ctx context.Context, id int, publishAt time.Time) (*models.CatalogChangeset, error)
+	Unschedule(ctx context.Context, id int) (*models.CatalogChangeset, error)
+	Cancel(ctx context.Context, id int) (*models.CatalogChangeset, error)
+	Publish(ctx context.Context, id int, actor models.Actor) (*models.CatalogChangeset, error)
+	PublishDue(ctx context.Context) int
+}
+
+// changesetService implements ChangesetService interface
+type changesetService struct {
+	repo           repositories.ChangesetRepository
+	productRepo    repositories.ProductRepository
+	productService ProductService
+	auditService   AuditService
+	uow            repositories.UnitOfWork
+}
+
+// schedulerActor is recorded in the audit log for changesets published on schedule
+var schedulerActor = models.Actor{Name: "scheduler"}
+
+// NewChangesetService creates a new changeset service instance
+func NewChangesetService(repo repositories.ChangesetRepository, productRepo repositories.ProductRepository, productService ProductService, auditService AuditService, uow repositories.UnitOfWork) ChangesetService {
+	return &changesetService{
+		repo:           repo,
+		productRepo:    productRepo,
+		productService: productService,
+		auditService:   auditService,
+		uow:            uow,
+	}
+}
+
+// GetChangesets returns changesets, optionally filtered by status
+func (s *changesetService) GetChangesets(ctx context.Context, status string) ([]models.CatalogChangeset, error) {
+	switch status {
+	case "", models.ChangesetStatusDraft, models.ChangesetStatusScheduled,
+		models.ChangesetStatusPublished, models.ChangesetStatusCancelled:
+	default:
+		return nil, helpers.NewValidationError("status must be 'draft', 'scheduled', 'published' or 'cancelled'")
+	}
+	return s.repo.GetAll(ctx, status)
+}
+
+// GetChangesetByID returns a changeset with its items
+func (s *changesetService) GetChangesetByID(ctx context.Context, id int) (*models.CatalogChangeset, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateChangeset creates an empty draft changeset
+func (s *changesetService) CreateChangeset(ctx context.Context, name string, actor models.Actor) (*models.CatalogChangeset, error) {
+	if name == "" {
+		return nil, helpers.NewValidationError("changeset name is required")
+	}
+	return s.repo.Create(ctx, models.CatalogChangeset{Name: name, CreatedBy: actor.UserID})
+}
+
+// editableChangeset loads a changeset and checks it has not been published or cancelled
+func (s *changesetService) editableChangeset(ctx context.Context, id int) (*models.CatalogChangeset, error) {
+	cs, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		return nil, helpers.NewNotFoundError("changeset not found")
+	}
+	if cs.Status != models.ChangesetStatusDraft && cs.Status != models.ChangesetStatusScheduled {
+		return nil, helpers.NewConflictError("changeset is already " + cs.Status)
+	}
+	return cs, nil
+}
+
+// AddItem validates a draft product and adds it to a changeset. A nil
+// productID creates a new product when the changeset is published.
+func (s *changesetService) AddItem(ctx context.Context, changesetID int, productID *int, product models.Product) (*models.CatalogChangesetItem, error) {
+	if _, err := s.editableChangeset(ctx, changesetID); err != nil {
+		return nil, err
+	}
+
+	if err := s.productService.ValidateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	action := models.ChangeActionCreate
+	if productID != nil {
+		existing, err := s.productRepo.GetByID(ctx, *productID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, helpers.NewValidationError("product not found")
+		}
+		action = models.ChangeActionUpdate
+	}
+
+	return s.repo.AddItem(ctx, models.CatalogChangesetItem{
+		ChangesetID: changesetID,
+		Action:      action,
+		ProductID:   productID,
+		Payload:     product,
+	})
+}
+
+// RemoveItem removes a draft product change from a changeset
+func (s *changesetService) RemoveItem(ctx context.Context, changesetID, itemID int) error {
+	if _, err := s.editableChangeset(ctx, changesetID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteItem(ctx, changesetID, itemID); err != nil {
+		return helpers.NewNotFoundError("changeset item not found")
+	}
+	return nil
+}
+
+// Schedule sets the time at which the changeset is published automatically
+func (s *changesetService) Schedule(ctx context.Context, id int, publishAt time.Time) (*models.CatalogChangeset, error) {
+	if !publishAt.After(time.Now()) {
+		return nil, helpers.NewValidationError("publish_at must be in the future")
+	}
+	return s.transition(ctx, id, models.ChangesetStatusScheduled, &publishAt)
+}
+
+// Unschedule returns a scheduled changeset to draft
+func (s *changesetService) Unschedule(ctx context.Context, id int) (*models.CatalogChangeset, error) {
+	return s.transition(ctx, id, models.ChangesetStatusDraft, nil)
+}
+
+// Cancel discards a changeset that has not been published
+func (s *changesetService) Cancel(ctx context.Context, id int) (*models.CatalogChangeset, error) {
+	return s.transition(ctx, id, models.ChangesetStatusCancelled, nil)
+}
+
+// transition moves an editable changeset to a new status
+func (s *changesetService) transition(ctx context.Context, id int, status string, publishAt *time.Time) (*models.CatalogChangeset, error) {
+	if _, err := s.editableChangeset(ctx, id); err != nil {
+		return nil, err
+	}
+
+	cs, err := s.repo.SetStatus(ctx, id, status, publishAt)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		return nil, helpers.NewConflictError("changeset has already been published or cancelled")
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// Publish applies all changes in the changeset to the live catalog atomically
+func (s *changesetService) Publish(ctx context.Context, id int, actor models.Actor) (*models.CatalogChangeset, error) {
+	if err := s.publish(ctx, id, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// publish applies a changeset and records each product change in the audit
+// log, in one DB transaction so the catalog never changes without its trail
+func (s *changesetService) publish(ctx context.Context, id int, actor models.Actor) error {
+	return s.uow.Do(ctx, func(tx repositories.DBTX) error {
+		repo := repositories.NewChangesetRepository(tx)
+		productRepo := repositories.NewProductRepository(tx)
+		auditRepo := repositories.NewAuditRepository(tx)
+
+		cs, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if cs == nil {
+			return helpers.NewNotFoundError("changeset not found")
+		}
+
+		before := make(map[int]*models.Product)
+		for _, item := range cs.Items {
+			if item.ProductID != nil {
+				if before[item.ID], err = productRepo.GetByID(ctx, *item.ProductID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := repo.Publish(ctx, id, actor); err != nil {
+			return err
+		}
+
+		// Reload to pick up the IDs of products created by the changeset
+		published, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, item := range published.Items {
+			if item.ProductID == nil {
+				continue
+			}
+			after, err := productRepo.GetByID(ctx, *item.ProductID)
+			if err != nil {
+				return err
+			}
+			action := models.AuditActionUpdate
+			if item.Action == models.ChangeActionCreate {
+				action = models.AuditActionCreate
+			}
+			entry, err := auditEntry(actor, action, models.AuditEntityProduct, *item.ProductID, before[item.ID], after)
+			if err != nil {
+				return err
+			}
+			if err := auditRepo.Create(ctx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PublishDue publishes every scheduled changeset whose publish time has
+// passed and returns how many went live. Failures are recorded on the
+// changeset and retried on the next run.
+func (s *changesetService) PublishDue(ctx context.Context) int {
+	ids, err := s.repo.GetDueIDs(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load due changesets", "component", "changesets", "error", err)
+		return 0
+	}
+
+	published := 0
+	for _, id := range ids {
+		if err := s.publish(ctx, id, schedulerActor); err != nil {
+			slog.ErrorContext(ctx, "failed to publish changeset", "component", "changesets", "changeset_id", id, "error", err)
+			if err := s.repo.SetError(ctx, id, err.Error()); err != nil {
+				slog.ErrorContext(ctx, "failed to record changeset error", "component", "changesets", "changeset_id", id, "error", err)
+			}
+			continue
+		}
+		slog.InfoContext(ctx, "published changeset", "component", "changesets", "changeset_id", id)
+		published++
+	}
+
+	return published
+}
+
+// StartChangesetScheduler runs PublishDue in the background every interval
+func StartChangesetScheduler(service ChangesetService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.PublishDue(context.Background())
+		}
+	}()
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+)
+
+// ConsignmentService defines the interface for consignment settlement logic
+type ConsignmentService interface {
+	GetSettlementReport(ctx context.Context, startDate, endDate string, supplierID *int) (*models.ConsignmentSettlementReport, error)
+}
+
+// consignmentService implements ConsignmentService interface
+type consignmentService struct {
+	repo repositories.ConsignmentRepository
+}
+
+// NewConsignmentService creates a new consignment service instance
+func NewConsignmentService(repo repositories.ConsignmentRepository) ConsignmentService {
+	return &consignmentService{repo: repo}
+}
+
+// GetSettlementReport returns what the store owes each supplier for consigned
+// goods sold in the period. Without dates the current month is used.
+func (s *consignmentService) GetSettlementReport(ctx context.Context, startDate, endDate string, supplierID *int) (*models.ConsignmentSettlementReport, error) {
+	now := time.Now()
+	if startDate == "" {
+		startDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
+	}
+	if endDate == "" {
+		endDate = now.Format("2006-01-02")
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, helpers.NewValidationError("start_date must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, helpers.NewValidationError("end_date must be in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, helpers.NewValidationError("end_date must not be before start_date")
+	}
+
+	settlements, err := s.repo.GetSettlements(ctx, startDate, endDate, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ConsignmentSettlementReport{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Suppliers: settlements,
+	}
+	for _, settlement := range settlements {
+		report.TotalPayable += settlement.PayableAmount
+	}
+	return report, nil
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+)
+
+// ConsistencyService defines the interface for data consistency checks and
+// their repairs
+type ConsistencyService interface {
+	CheckTransactionTotals(ctx context.Context, date string) (*models.TransactionConsistencyReport, error)
+	RepairTransactionTotals(ctx context.Context, input models.TransactionRepairInput, actor models.Actor) (*models.TransactionRepairResult, error)
+	CheckStock(ctx context.Context) (*models.StockConsistencyReport, error)
+	RepairStock(ctx context.Context, input models.StockRepairInput, actor models.Actor) (*models.StockRepairResult, error)
+}
+
+// consistencyService implements ConsistencyService interface
+type consistencyService struct {
+	repo repositories.ConsistencyRepository
+	uow  repositories.UnitOfWork
+}
+
+// NewConsistencyService creates a new consistency service instance
+func NewConsistencyService(repo repositories.ConsistencyRepository, uow repositories.UnitOfWork) ConsistencyService {
+	return &consistencyService{repo: repo, uow: uow}
+}
+
+// consistencyDate validates a YYYY-MM-DD date, defaulting to today
+func consistencyDate(date string) (string, error) {
+	if date == "" {
+		return time.Now().Format("2006-01-02"), nil
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return "", helpers.NewValidationError("date must be in YYYY-MM-DD format")
+	}
+	return date, nil
+}
+
+// CheckTransactionTotals lists the transactions created on date (default
+// today) whose recorded total differs from their lines
+func (s *consistencyService) CheckTransactionTotals(ctx context.Context, date string) (*models.TransactionConsistencyReport, error) {
+	date, err := consistencyDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	checked, mismatches, err := s.repo.CheckTransactionTotals(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TransactionConsistencyReport{Date: date, Checked: checked, Mismatches: mismatches}, nil
+}
+
+// RepairTransactionTotals sets the recorded total of the approved mismatched
+// transactions of a day to the total of their lines. Each transaction is
+// checked again under a row lock first; those that now agree, belong to
+// another day or have inconsistent lines (whose subtotals cannot be trusted
+// to repair the total from) are skipped. Totals and their audit log entries
+// are written in one DB transaction.
+func (s *consistencyService) RepairTransactionTotals(ctx context.Context, input models.TransactionRepairInput, actor models.Actor) (*models.TransactionRepairResult, error) {
+	if input.Date == "" {
+		return nil, helpers.NewValidationError("date is required")
+	}
+	date, err := consistencyDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := input.TransactionIDs
+	if len(ids) == 0 {
+		_, mismatches, err := s.repo.CheckTransactionTotals(ctx, date)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range mismatches {
+			ids = append(ids, m.TransactionID)
+		}
+	}
+
+	result := &models.TransactionRepairResult{
+		Date:     date,
+		Repaired: make([]models.TransactionTotalCheck, 0),
+		Skipped:  make([]models.TransactionRepairSkip, 0),
+	}
+	err = s.uow.Do(ctx, func(tx repositories.DBTX) error {
+		repo := repositories.NewConsistencyRepository(tx)
+		auditRepo := repositories.NewAuditRepository(tx)
+
+		for _, id := range ids {
+			check, err := repo.CheckTransactionTotal(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			reason := ""
+			switch {
+			case check == nil:
+				reason = "transaction not found"
+			case check.CreatedAt.Format("2006-01-02") != date:
+				reason = "transaction is not from " + date
+			case check.LineMismatches > 0:
+				reason = "line subtotals are inconsistent; review the lines manually"
+			case check.Difference == 0:
+				reason = "total already matches its lines"
+			}
+			if reason != "" {
+				result.Skipped = append(result.Skipped, models.TransactionRepairSkip{TransactionID: id, Reason: reason})
+				continue
+			}
+
+			if err := repo.SetTransactionTotal(ctx, id, check.ExpectedTotal); err != nil {
+				return err
+			}
+			entry, err := auditEntry(actor, models.AuditActionUpdate, models.AuditEntityTransaction, id,
+				map[string]int{"total_amount": check.RecordedTotal},
+				map[string]int{"total_amount": check.ExpectedTotal},
+			)
+			if err != nil {
+				return err
+			}
+			if err := auditRepo.Create(ctx, entry); err != nil {
+				return err
+			}
+			result.Repaired = append(result.Repaired, *check)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CheckStock lists the products whose total or per-store stock drifted from
+// the stock movement ledger
+func (s *consistencyService) CheckStock(ctx context.Context) (*models.StockConsistencyReport, error) {
+	checkedAt := time.Now()
+	checked, drifts, err := s.repo.CheckStock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.StockConsistencyReport{CheckedAt: checkedAt, Checked: checked, Drifts: drifts}, nil
+}
+
+// RepairStock rebuilds the stock of the approved drifted products (every
+// drifted product when none are given) from the ledger. Each product is
+// checked again under a row lock first; products that no longer drift, have
+// no ledger entries or whose ledger comes out negative are skipped. Stock and
+// its audit log entries are written in one DB transaction.
+func (s *consistencyService) RepairStock(ctx context.Context, input models.StockRepairInput, actor models.Actor) (*models.StockRepairResult, error) {
+	ids := input.ProductIDs
+	if len(ids) == 0 {
+		_, drifts, err := s.repo.CheckStock(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range drifts {
+			ids = append(ids, d.ProductID)
+		}
+	}
+
+	result := &models.StockRepairResult{
+		Repaired: make([]models.StockDrift, 0),
+		Skipped:  make([]models.StockRepairSkip, 0),
+	}
+	err := s.uow.Do(ctx, func(tx repositories.DBTX) error {
+		repo := repositories.NewConsistencyRepository(tx)
+		auditRepo := repositories.NewAuditRepository(tx)
+
+		for _, id := range ids {
+			drift, err := repo.CheckProductStock(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			reason := ""
+			switch {
+			case drift == nil:
+				reason = "product not found"
+			case drift.Drift == 0 && len(drift.Stores) == 0:
+				reason = "stock already matches the ledger"
+			case drift.LedgerEntries == 0:
+				reason = "no ledger entries to rebuild stock from"
+			case negativeLedgerStock(*drift):
+				reason = "ledger stock is negative; review the ledger"
+			}
+			if reason != "" {
+				result.Skipped = append(result.Skipped, models.StockRepairSkip{ProductID: id, Reason: reason})
+				continue
+			}
+
+			if err := repo.SetProductStock(ctx, *drift); err != nil {
+				return err
+			}
+			before, after := map[string]interface{}{"stock": drift.Stock}, map[string]interface{}{"stock": drift.LedgerStock}
+			if len(drift.Stores) > 0 {
+				beforeStores, afterStores := make(map[int]int), make(map[int]int)
+				for _, st := range drift.Stores {
+					beforeStores[st.StoreID], afterStores[st.StoreID] = st.Stock, st.LedgerStock
+				}
+				before["store_stock"], after["store_stock"] = beforeStores, afterStores
+			}
+			entry, err := auditEntry(actor, models.AuditActionUpdate, models.AuditEntityProduct, id, before, after)
+			if err != nil {
+				return err
+			}
+			if err := auditRepo.Create(ctx, entry); err != nil {
+				return err
+			}
+			result.Repaired = append(result.Repaired, *drift)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// negativeLedgerStock reports whether the ledger puts a product's total or
+// a store's stock below zero, which no repair should write
+func negativeLedgerStock(drift models.StockDrift) bool {
+	if drift.LedgerStock < 0 {
+		return true
+	}
+	for _, st := range drift.Stores {
+		if st.LedgerStock < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// StartStockConsistencyChecker compares stock with the ledger in the
+// background every interval and logs the products that drifted. It never
+// repairs: rebuilding stock needs the owner's approval.
+func StartStockConsistencyChecker(service ConsistencyService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			report, err := service.CheckStock(context.Background())
+			if err != nil {
+				slog.Error("stock check failed", "component", "stock-check", "error", err)
+				continue
+			}
+			for _, d := range report.Drifts {
+				slog.Warn("product stock drifted from the ledger", "component", "stock-check",
+					"product_id", d.ProductID, "product", d.ProductName, "stock", d.Stock, "ledger_stock", d.LedgerStock, "stores", len(d.Stores))
+			}
+			if len(report.Drifts) > 0 {
+				slog.Warn("products drifted from the ledger", "component", "stock-check", "drifted", len(report.Drifts), "checked", report.Checked)
+			}
+		}
+	}()
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"retail-core-api/chaos"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sort"
+	"time"
+)
+
+const (
+	// abcClassAShare and abcClassBShare are the cumulative shares of
+	// consumption value covered by A and by A+B products
+	abcClassAShare = 0.80
+	abcClassBShare = 0.95
+	// abcVelocityDays is the sales window used to rank products into classes
+	abcVelocityDays = 90
+	// complianceDefaultDays is the compliance window when no dates are given
+	complianceDefaultDays = 90
+)
+
+// CycleCountNotifier is notified when a cycle count session is opened
+type CycleCountNotifier interface {
+	NotifyAssigned(session models.CountSession, assignee *models.User)
+}
+
+// logCycleCountNotifier notifies assigned staff through the application log
+type logCycleCountNotifier struct{}
+
+// NewLogCycleCountNotifier creates a notifier that writes new cycle counts to the log
+func NewLogCycleCountNotifier() CycleCountNotifier {
+	return logCycleCountNotifier{}
+}
+
+// NotifyAssigned logs a newly opened cycle count for its assignee
+func (logCycleCountNotifier) NotifyAssigned(session models.CountSession, assignee *models.User) {
+	who := "unassigned"
+	if assignee != nil {
+		who = fmt.Sprintf("%s <%s>", assignee.Name, assignee.Email)
+	}
+	due := "no due date"
+	if session.DueAt != nil {
+		due = "due " + session.DueAt.Format("2006-01-02 15:04")
+	}
+	slog.Info("count session opened", "component", "cycle-count",
+		"session_id", session.ID, "note", session.Note, "items", session.ItemCount, "due", due, "assignee", who)
+}
+
+// webhookCycleCountNotifier logs new cycle counts and POSTs them to a webhook
+type webhookCycleCountNotifier struct {
+	webhook notificationWebhook
+}
+
+// NewWebhookCycleCountNotifier creates a notifier that logs new cycle counts
+// and POSTs {"event":"count_session_opened","session":{...},"assignee":{...}}
+// to url; assignee is null when the session is not assigned
+func NewWebhookCycleCountNotifier(url string, injector *chaos.Injector) CycleCountNotifier {
+	return &webhookCycleCountNotifier{webhook: newNotificationWebhook(url, injector)}
+}
+
+// NotifyAssigned logs the session and delivers it to the webhook in the
+// background, so opening a count does not wait on the webhook
+func (n *webhookCycleCountNotifier) NotifyAssigned(session models.CountSession, assignee *models.User) {
+	logCycleCountNotifier{}.NotifyAssigned(session, assignee)
+
+	go n.webhook.post(map[string]interface{}{
+		"event":    "count_session_opened",
+		"session":  session,
+		"assignee": assignee,
+	}, "component", "cycle-count", "session_id", session.ID)
+}
+
+// CycleCountService defines the interface for the cycle counting program
+type CycleCountService interface {
+	GetSchedules(ctx context.Context) ([]models.CycleCountSchedule, error)
+	GetScheduleByID(ctx context.Context, id int) (*models.CycleCountSchedule, error)
+	CreateSchedule(ctx context.Context, input models.CycleCountScheduleInput) (*models.CycleCountSchedule, error)
+	UpdateSchedule(ctx context.Context, id int, input models.CycleCountScheduleInput) (*models.CycleCountSchedule, error)
+	DeleteSchedule(ctx context.Context, id int) error
+	RunSchedule(ctx context.Context, id int) (*models.CountSession, error)
+	OpenDue(ctx context.Context) int
+	GetCompliance(ctx context.Context, startDate, endDate string) ([]models.CycleCountCompliance, error)
+}
+
+// cycleCountService implements CycleCountService interface
+type cycleCountService struct {
+	repo          repositories.CycleCountRepository
+	stocktakeRepo repositories.StocktakeRepository
+	userRepo      repositories.UserRepository
+	notifier      CycleCountNotifier
+}
+
+// NewCycleCountService creates a new cycle count service instance
+func NewCycleCountService(repo repositories.CycleCountRepository, stocktakeRepo repositories.StocktakeRepository, userRepo repositories.UserRepository, notifier CycleCountNotifier) CycleCountService {
+	return &cycleCountService{
+		repo:          repo,
+		stocktakeRepo: stocktakeRepo,
+		userRepo:      userRepo,
+		notifier:      notifier,
+	}
+}
+
+// GetSchedules returns every cycle count schedule
+func (s *cycleCountService) GetSchedules(ctx context.Context) ([]models.CycleCountSchedule, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetScheduleByID returns a cycle count schedule by its ID
+func (s *cycleCountService) GetScheduleByID(ctx context.Context, id int) (*models.CycleCountSchedule, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// scheduleFromInput validates the input and maps it to a schedule (active by default)
+func (s *cycleCountService) scheduleFromInput(ctx context.Context, input models.CycleCountScheduleInput) (models.CycleCountSchedule, error) {
+	schedule := models.CycleCountSchedule{
+		ABCClass:   input.ABCClass,
+		Frequency:  input.Frequency,
+		AssignedTo: input.AssignedTo,
+		IsActive:   true,
+		NextRunAt:  time.Now(),
+	}
+	if input.IsActive != nil {
+		schedule.IsActive = *input.IsActive
+	}
+	if input.NextRunAt != nil {
+		schedule.NextRunAt = *input.NextRunAt
+	}
+
+	switch schedule.ABCClass {
+	case models.ABCClassA, models.ABCClassB, models.ABCClassC:
+	default:
+		return schedule, helpers.NewValidationError("abc_class must be 'A', 'B' or 'C'")
+	}
+	switch schedule.Frequency {
+	case models.CycleFrequencyWeekly, models.CycleFrequencyMonthly, models.CycleFrequencyQuarterly:
+	default:
+		return schedule, helpers.NewValidationError("frequency must be 'weekly', 'monthly' or 'quarterly'")
+	}
+
+	if schedule.AssignedTo != nil {
+		user, err := s.userRepo.GetByID(ctx, *schedule.AssignedTo)
+		if err != nil {
+			return schedule, err
+		}
+		if user == nil {
+			return schedule, helpers.NewValidationError("assigned user not found")
+		}
+		if !user.IsActive {
+			return schedule, helpers.NewValidationError("assigned user must be active")
+		}
+	}
+
+	return schedule, nil
+}
+
+// CreateSchedule validates and creates a cycle count schedule
+func (s *cycleCountService) CreateSchedule(ctx context.Context, input models.CycleCountScheduleInput) (*models.CycleCountSchedule, error) {
+	schedule, err := s.scheduleFromInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, schedule)
+}
+
+// UpdateSchedule validates and updates a cycle count schedule
+func (s *cycleCountService) UpdateSchedule(ctx context.Context, id int, input models.CycleCountScheduleInput) (*models.CycleCountSchedule, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, helpers.NewNotFoundError("cycle count schedule not found")
+	}
+
+	if input.NextRunAt == nil {
+		input.NextRunAt = &existing.NextRunAt
+	}
+	schedule, err := s.scheduleFromInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, id, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, helpers.NewNotFoundError("cycle count schedule not found")
+	}
+	return updated, nil
+}
+
+// DeleteSchedule removes a cycle count schedule
+func (s *cycleCountService) DeleteSchedule(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// RunSchedule opens a cycle count for a schedule right away, due one period
+// from now. The schedule's regular next run is not changed.
+func (s *cycleCountService) RunSchedule(ctx context.Context, id int) (*models.CountSession, error) {
+	schedule, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, helpers.NewNotFoundError("cycle count schedule not found")
+	}
+	return s.openSession(ctx, *schedule, nextCycleRun(time.Now(), schedule.Frequency))
+}
+
+// OpenDue opens a count session for every active schedule whose next run
+// has passed, advances the schedule and returns how many sessions were opened.
+// Runs missed while the server was down are skipped rather than replayed.
+func (s *cycleCountService) OpenDue(ctx context.Context) int {
+	schedules, err := s.repo.GetDue(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load due schedules", "component", "cycle-count", "error", err)
+		return 0
+	}
+
+	now := time.Now()
+	opened := 0
+	for _, schedule := range schedules {
+		next := schedule.NextRunAt
+		for !next.After(now) {
+			next = nextCycleRun(next, schedule.Frequency)
+		}
+
+		claimed, err := s.repo.Advance(ctx, schedule.ID, next)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to advance schedule", "component", "cycle-count", "schedule_id", schedule.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if _, err := s.openSession(ctx, schedule, next); err != nil {
+			slog.ErrorContext(ctx, "failed to open count session", "component", "cycle-count", "schedule_id", schedule.ID, "error", err)
+			continue
+		}
+		opened++
+	}
+
+	return opened
+}
+
+// openSession opens a cycle count session for every active product currently
+// in the schedule's ABC class and notifies the assignee
+func (s *cycleCountService) openSession(ctx context.Context, schedule models.CycleCountSchedule, dueAt time.Time) (*models.CountSession, error) {
+	candidates, err := s.stocktakeRepo.GetCountCandidates(ctx, abcVelocityDays)
+	if err != nil {
+		return nil, err
+	}
+
+	classes := classifyABC(candidates)
+	items := make([]models.CountSessionItem, 0)
+	for _, c := range candidates {
+		if classes[c.ProductID] == schedule.ABCClass {
+			items = append(items, models.CountSessionItem{ProductID: c.ProductID})
+		}
+	}
+	if len(items) == 0 {
+		return nil, helpers.NewValidationError(fmt.Sprintf("there are no active products in class %s", schedule.ABCClass))
+	}
+
+	scheduleID := schedule.ID
+	session, err := s.stocktakeRepo.Create(ctx, models.CountSession{
+		Type:       models.CountSessionCycle,
+		Note:       fmt.Sprintf("Cycle count: class %s (%s)", schedule.ABCClass, schedule.Frequency),
+		ScheduleID: &scheduleID,
+		AssignedTo: schedule.AssignedTo,
+		DueAt:      &dueAt,
+	}, items)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignee *models.User
+	if schedule.AssignedTo != nil {
+		assignee, err = s.userRepo.GetByID(ctx, *schedule.AssignedTo)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load session assignee", "component", "cycle-count", "session_id", session.ID, "error", err)
+		}
+	}
+	s.notifier.NotifyAssigned(*session, assignee)
+
+	return session, nil
+}
+
+// nextCycleRun returns the run that follows t for a frequency
+func nextCycleRun(t time.Time, frequency string) time.Time {
+	switch frequency {
+	case models.CycleFrequencyWeekly:
+		return t.AddDate(0, 0, 7)
+	case models.CycleFrequencyMonthly:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 3, 0)
+	}
+}
+
+// classifyABC ranks products by consumption value (price x units sold) and
+// assigns A to the products making up the first 80% of total value, B to the
+// next 15% and C to the rest, including products that did not sell
+func classifyABC(candidates []models.CountCandidate) map[int]string {
+	ranked := make([]models.CountCandidate, len(candidates))
+	copy(ranked, candidates)
+	value := func(c models.CountCandidate) float64 { return float64(c.Price) * float64(c.UnitsSold) }
+	sort.SliceStable(ranked, func(a, b int) bool { return value(ranked[a]) > value(ranked[b]) })
+
+	var total float64
+	for _, c := range ranked {
+		total += value(c)
+	}
+
+	classes := make(map[int]string, len(ranked))
+	var cumulative float64
+	for _, c := range ranked {
+		switch {
+		case total == 0 || value(c) == 0:
+			classes[c.ProductID] = models.ABCClassC
+		case cumulative/total < abcClassAShare:
+			classes[c.ProductID] = models.ABCClassA
+		case cumulative/total < abcClassBShare:
+			classes[c.ProductID] = models.ABCClassB
+		default:
+			classes[c.ProductID] = models.ABCClassC
+		}
+		cumulative += value(c)
+	}
+	return classes
+}
+
+// GetCompliance reports how each schedule's sessions were completed between
+// two dates (the last 90 days by default)
+func (s *cycleCountService) GetCompliance(ctx context.Context, startDate, endDate string) ([]models.CycleCountCompliance, error) {
+	if startDate == "" && endDate == "" {
+		endDate = time.Now().Format("2006-01-02")
+		startDate = time.Now().AddDate(0, 0, -complianceDefaultDays).Format("2006-01-02")
+	}
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, helpers.NewValidationError("start_date must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, helpers.NewValidationError("end_date must be in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, helpers.NewValidationError("end_date must not be before start_date")
+	}
+
+	report, err := s.repo.GetCompliance(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for i := range report {
+		c := &report[i]
+		due := c.CompletedOnTime + c.CompletedLate + c.Overdue + c.Cancelled
+		if due > 0 {
+			c.ComplianceRate = math.Round(float64(c.CompletedOnTime)/float64(due)*10000) / 100
+		}
+	}
+	return report, nil
+}
+
+// StartCycleCountScheduler runs OpenDue in the background every interval
+func StartCycleCountScheduler(service CycleCountService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.OpenDue(context.Background())
+		}
+	}()
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+)
+
+// dataQualityChecks lists the checks in report order with their descriptions
+var dataQualityChecks = []struct {
+	check       string
+	description string
+}{
+	{models.DataQualityMissingBarcode, "Products without a SKU/barcode to scan at checkout"},
+	{models.DataQualityZeroPrice, "Products with a selling price of zero"},
+	{models.DataQualityUncategorized, "Products without a category"},
+	{models.DataQualityDuplicateName, "Products sharing their name (case-insensitive) with another product"},
+	{models.DataQualityStale, "Active products neither sold nor edited within stale_days"},
+}
+
+// DataQualityService defines the interface for catalog hygiene reports
+type DataQualityService interface {
+	GetReport(ctx context.Context, staleDays int) (*models.DataQualityReport, error)
+	GetIssues(ctx context.Context, params models.DataQualityParams) (*models.PaginatedDataQualityIssues, error)
+}
+
+// dataQualityService implements DataQualityService interface
+type dataQualityService struct {
+	repo repositories.DataQualityRepository
+}
+
+// NewDataQualityService creates a new data quality service instance
+func NewDataQualityService(repo repositories.DataQualityRepository) DataQualityService {
+	return &dataQualityService{repo: repo}
+}
+
+// staleCutoff validates stale_days (default DefaultStaleDays) and returns it
+// with the matching cutoff time
+func staleCutoff(staleDays int) (int, time.Time, error) {
+	if staleDays == 0 {
+		staleDays = models.DefaultStaleDays
+	}
+	if staleDays < 1 || staleDays > 3650 {
+		return 0, time.Time{}, helpers.NewValidationError("stale_days must be between 1 and 3650")
+	}
+	return staleDays, time.Now().AddDate(0, 0, -staleDays), nil
+}
+
+// GetReport counts the products failing each check
+func (s *dataQualityService) GetReport(ctx context.Context, staleDays int) (*models.DataQualityReport, error) {
+	staleDays, cutoff, err := staleCutoff(staleDays)
+	if err != nil {
+		return nil, err
+	}
+
+	total, counts, err := s.repo.CountIssues(ctx, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.DataQualityReport{
+		TotalProducts: total,
+		StaleDays:     staleDays,
+		Checks:        make([]models.DataQualityCheck, 0, len(dataQualityChecks)),
+	}
+	for _, c := range dataQualityChecks {
+		report.Checks = append(report.Checks, models.DataQualityCheck{
+			Check:       c.check,
+			Description: c.description,
+			Count:       counts[c.check],
+			URL:         "/v1/admin/data-quality/" + c.check,
+		})
+	}
+	return report, nil
+}
+
+// GetIssues returns a page of the products failing one check
+func (s *dataQualityService) GetIssues(ctx context.Context, params models.DataQualityParams) (*models.PaginatedDataQualityIssues, error) {
+	known := false
+	for _, c := range dataQualityChecks {
+		known = known || c.check == params.Check
+	}
+	if !known {
+		return nil, helpers.NewNotFoundError("data quality check not found")
+	}
+
+	_, cutoff, err := staleCutoff(params.StaleDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetIssues(ctx, params, cutoff)
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FeatureFlagService defines the interface for feature flags: features
+// switched on or off per tenant, or per store, without a redeploy
+type FeatureFlagService interface {
+	Enabled(ctx context.Context, name string, storeID int) bool
+	GetFlags(ctx context.Context) ([]models.FeatureFlag, error)
+	SetFlag(ctx context.Context, name string, req models.SetFeatureFlagRequest) (*models.FeatureFlag, error)
+	ClearFlag(ctx context.Context, name string, storeID *int) (*models.FeatureFlag, error)
+}
+
+// featureFlagCacheTTL is how long the settings of a tenant's flags are
+// reused before they are read again. A change made through an instance
+// applies on it at once, and on the other instances within this time.
+const featureFlagCacheTTL = 5 * time.Second
+
+// featureFlagService implements FeatureFlagService interface
+type featureFlagService struct {
+	repo      repositories.FeatureFlagRepository
+	storeRepo repositories.StoreRepository
+	defaults  *FeatureFlagDefaults
+	ttl       time.Duration
+
+	// The tenant's settings, read at most once per ttl. generation counts
+	// the changes, so settings read while one was made are not kept.
+	mu         sync.Mutex
+	settings   []models.FeatureFlagSetting
+	expires    time.Time
+	generation uint64
+}
+
+// NewFeatureFlagService creates a new feature flag service instance. The
+// defaults are shared by every tenant; the settings of the tenant repo
+// belongs to are cached for a few seconds (featureFlagCacheTTL).
+func NewFeatureFlagService(repo repositories.FeatureFlagRepository, storeRepo repositories.StoreRepository, defaults *FeatureFlagDefaults) FeatureFlagService {
+	return &featureFlagService{repo: repo, storeRepo: storeRepo, defaults: defaults, ttl: featureFlagCacheTTL}
+}
+
+// FeatureFlagDefaults holds the defaults of FEATURE_FLAGS, which override
+// the built-in default of the flags they name. A configuration reload
+// replaces them while the server runs.
+type FeatureFlagDefaults struct {
+	flags atomic.Pointer[map[string]bool]
+}
+
+// NewFeatureFlagDefaults creates the defaults from flags, which must have
+// been checked with ValidateFeatureFlags
+func NewFeatureFlagDefaults(flags map[string]bool) *FeatureFlagDefaults {
+	d := &FeatureFlagDefaults{}
+	d.Set(flags)
+	return d
+}
+
+// Set replaces the defaults with flags, which must have been checked with
+// ValidateFeatureFlags
+func (d *FeatureFlagDefaults) Set(flags map[string]bool) {
+	d.flags.Store(&flags)
+}
+
+// lookup returns the default of a flag, false when FEATURE_FLAGS does not
+// name it
+func (d *FeatureFlagDefaults) lookup(name string) (enabled, ok bool) {
+	enabled, ok = (*d.flags.Load())[name]
+	return enabled, ok
+}
+
+// ValidateFeatureFlags checks that every flag in defaults is known
+func ValidateFeatureFlags(defaults map[string]bool) error {
+	for name := range defaults {
+		if _, ok := featureFlagDefinition(name); !ok {
+			names := make([]string, len(models.FeatureFlagDefinitions))
+			for i, d := range models.FeatureFlagDefinitions {
+				names[i] = d.Name
+			}
+			return fmt.Errorf("unknown feature flag %q (known: %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// featureFlagDefinition returns the definition of a flag, false when it is
+// not known
+func featureFlagDefinition(name string) (models.FeatureFlagDefinition, bool) {
+	for _, d := range models.FeatureFlagDefinitions {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return models.FeatureFlagDefinition{}, false
+}
+
+// defaultOf returns the default of a flag: FEATURE_FLAGS, else built in
+func (s *featureFlagService) defaultOf(d models.FeatureFlagDefinition) bool {
+	if enabled, ok := s.defaults.lookup(d.Name); ok {
+		return enabled
+	}
+	return d.Default
+}
+
+// Enabled reports whether a feature is on at a store: the store's override,
+// else the tenant's setting, else the default. The settings come from the
+// cache, so checkouts do not read them each time; the defaults are not
+// cached, so a reload of FEATURE_FLAGS applies at once. When the settings
+// cannot be read, the default applies, so a database hiccup does not fail
+// the request consulting the flag. Unknown flags are off.
+func (s *featureFlagService) Enabled(ctx context.Context, name string, storeID int) bool {
+	d, ok := featureFlagDefinition(name)
+	if !ok {
+		return false
+	}
+	enabled := s.defaultOf(d)
+
+	settings, err := s.cachedSettings(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read feature flag, using its default", "component", "feature-flags", "flag", name, "default", enabled, "error", err)
+		return enabled
+	}
+	// The tenant's setting of a flag comes before its store overrides
+	for _, setting := range settings {
+		if setting.Name == name && (setting.StoreID == nil || *setting.StoreID == storeID) {
+			enabled = setting.Enabled
+		}
+	}
+	return enabled
+}
+
+// cachedSettings returns the tenant's settings, read again once they are
+// older than the TTL
+func (s *featureFlagService) cachedSettings(ctx context.Context) ([]models.FeatureFlagSetting, error) {
+	s.mu.Lock()
+	if time.Now().Before(s.expires) {
+		settings := s.settings
+		s.mu.Unlock()
+		return settings, nil
+	}
+	s.mu.Unlock()
+	return s.readSettings(ctx)
+}
+
+// readSettings reads the tenant's settings and caches them, unless they
+// changed while they were being read
+func (s *featureFlagService) readSettings(ctx context.Context) ([]models.FeatureFlagSetting, error) {
+	s.mu.Lock()
+	generation := s.generation
+	s.mu.Unlock()
+
+	settings, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.generation == generation {
+		s.settings = settings
+		s.expires = time.Now().Add(s.ttl)
+	}
+	s.mu.Unlock()
+	return settings, nil
+}
+
+// invalidate drops the cached settings after a change
+func (s *featureFlagService) invalidate() {
+	s.mu.Lock()
+	s.generation++
+	s.settings = nil
+	s.expires = time.Time{}
+	s.mu.Unlock()
+}
+
+// GetFlags returns every known flag with its default, the tenant's setting
+// and the store overrides, by name
+func (s *featureFlagService) GetFlags(ctx context.Context) ([]models.FeatureFlag, error) {
+	settings, err := s.readSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]models.FeatureFlag, 0, len(models.FeatureFlagDefinitions))
+	for _, d := range models.FeatureFlagDefinitions {
+		flags = append(flags, s.flag(d, settings))
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags, nil
+}
+
+// SetFlag switches a flag on or off for the tenant, or for one store
+func (s *featureFlagService) SetFlag(ctx context.Context, name string, req models.SetFeatureFlagRequest) (*models.FeatureFlag, error) {
+	d, ok := featureFlagDefinition(name)
+	if !ok {
+		return nil, helpers.NewNotFoundError("feature flag not found")
+	}
+	if req.StoreID != nil {
+		store, err := s.storeRepo.GetByID(ctx, *req.StoreID)
+		if err != nil {
+			return nil, err
+		}
+		if store == nil {
+			return nil, helpers.NewCodedError(helpers.CodeStoreNotFound, *req.StoreID)
+		}
+	}
+
+	_, err := s.repo.Set(ctx, models.FeatureFlagSetting{Name: name, StoreID: req.StoreID, Enabled: *req.Enabled})
+	s.invalidate()
+	if err != nil {
+		return nil, err
+	}
+	return s.reload(ctx, d)
+}
+
+// ClearFlag removes the tenant's setting of a flag, so the default applies
+// again, or a store's override when storeID is set, so the tenant's applies
+func (s *featureFlagService) ClearFlag(ctx context.Context, name string, storeID *int) (*models.FeatureFlag, error) {
+	d, ok := featureFlagDefinition(name)
+	if !ok {
+		return nil, helpers.NewNotFoundError("feature flag not found")
+	}
+
+	err := s.repo.Delete(ctx, name, storeID)
+	s.invalidate()
+	if err == sql.ErrNoRows {
+		return nil, helpers.NewNotFoundError("feature flag is not set")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reload(ctx, d)
+}
+
+// reload returns a flag as it stands after a change, caching the settings
+// it reads
+func (s *featureFlagService) reload(ctx context.Context, d models.FeatureFlagDefinition) (*models.FeatureFlag, error) {
+	settings, err := s.readSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flag := s.flag(d, settings)
+	return &flag, nil
+}
+
+// flag builds the view of a flag from the settings of every flag
+func (s *featureFlagService) flag(d models.FeatureFlagDefinition, settings []models.FeatureFlagSetting) models.FeatureFlag {
+	flag := models.FeatureFlag{
+		Name:           d.Name,
+		Description:    d.Description,
+		Default:        s.defaultOf(d),
+		StoreOverrides: make([]models.FeatureFlagSetting, 0),
+	}
+	flag.Enabled = flag.Default
+	for _, setting := range settings {
+		switch {
+		case setting.Name != d.Name:
+		case setting.StoreID == nil:
+			flag.TenantSetting = &setting
+			flag.Enabled = setting.Enabled
+		default:
+			flag.StoreOverrides = append(flag.StoreOverrides, setting)
+		}
+	}
+	return flag
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+	"time"
+)
+
+// countingFlags keeps feature flag settings in memory and counts the reads
+type countingFlags struct {
+	repositories.FeatureFlagRepository
+	settings []models.FeatureFlagSetting
+	reads    int
+	err      error
+}
+
+func (r *countingFlags) GetAll(ctx context.Context) ([]models.FeatureFlagSetting, error) {
+	r.reads++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return append([]models.FeatureFlagSetting(nil), r.settings...), nil
+}
+
+func (r *countingFlags) Set(ctx context.Context, setting models.FeatureFlagSetting) (*models.FeatureFlagSetting, error) {
+	r.settings = append(r.settings, setting)
+	return &setting, nil
+}
+
+func (r *countingFlags) Delete(ctx context.Context, name string, storeID *int) error {
+	for i, s := range r.settings {
+		if s.Name == name && (s.StoreID == nil) == (storeID == nil) && (storeID == nil || *s.StoreID == *storeID) {
+			r.settings = append(r.settings[:i], r.settings[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func TestFeatureFlagsCached(t *testing.T) {
+	ctx := context.Background()
+	repo := &countingFlags{settings: []models.FeatureFlagSetting{
+		{Name: models.FeatureFlagPromotions, Enabled: false},
+		{Name: models.FeatureFlagPromotions, StoreID: intPtr(2), Enabled: true},
+	}}
+	defaults := NewFeatureFlagDefaults(nil)
+	service := NewFeatureFlagService(repo, nil, defaults)
+
+	// The store's override beats the tenant's setting
+	for i := 0; i < 3; i++ {
+		if service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+			t.Fatal("promotions are on at store 1, want the tenant's off")
+		}
+		if !service.Enabled(ctx, models.FeatureFlagPromotions, 2) {
+			t.Fatal("promotions are off at store 2, want its override on")
+		}
+	}
+	if repo.reads != 1 {
+		t.Fatalf("six checks read the settings %d times, want once", repo.reads)
+	}
+
+	// A change through the service applies at once
+	if _, err := service.ClearFlag(ctx, models.FeatureFlagPromotions, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("promotions are still off at store 1 after the tenant's setting was cleared")
+	}
+	enabled := false
+	if _, err := service.SetFlag(ctx, models.FeatureFlagPromotions, models.SetFeatureFlagRequest{Enabled: &enabled}); err != nil {
+		t.Fatal(err)
+	}
+	if service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("promotions are still on at store 1 after the tenant switched them off")
+	}
+
+	// Reloaded defaults apply at once
+	defaults.Set(map[string]bool{models.FeatureFlagPromotions: false})
+	if service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("promotions are on after FEATURE_FLAGS switched them off")
+	}
+}
+
+func TestFeatureFlagsExpire(t *testing.T) {
+	ctx := context.Background()
+	repo := &countingFlags{settings: []models.FeatureFlagSetting{{Name: models.FeatureFlagPromotions, Enabled: false}}}
+	service := NewFeatureFlagService(repo, nil, NewFeatureFlagDefaults(nil))
+	service.(*featureFlagService).ttl = 50 * time.Millisecond
+
+	if service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("promotions are on, want the tenant's off")
+	}
+
+	// A change made elsewhere, e.g. on another instance, applies once the
+	// cached settings expire
+	repo.settings = nil
+	if service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("a change made elsewhere applied before the cache expired")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if !service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("a change made elsewhere did not apply after the cache expired")
+	}
+	if repo.reads != 2 {
+		t.Fatalf("read the settings %d times, want 2", repo.reads)
+	}
+}
+
+func TestFeatureFlagsReadFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := &countingFlags{
+		settings: []models.FeatureFlagSetting{{Name: models.FeatureFlagPromotions, Enabled: false}},
+		err:      errors.New("connection refused"),
+	}
+	service := NewFeatureFlagService(repo, nil, NewFeatureFlagDefaults(nil))
+
+	// The default applies, and the failure is not cached
+	if !service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("promotions are off while the settings cannot be read, want the default")
+	}
+	repo.err = nil
+	if service.Enabled(ctx, models.FeatureFlagPromotions, 1) {
+		t.Fatal("the tenant's setting did not apply once the settings could be read")
+	}
+	if repo.reads != 2 {
+		t.Fatalf("read the settings %d times, want 2", repo.reads)
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-core-api/cache"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sync"
+	"time"
+)
+
+// healthCheckTimeout bounds each dependency check; they run in parallel, so
+// it also bounds the readiness check as a whole
+const healthCheckTimeout = 2 * time.Second
+
+// HealthService defines the interface for checking the dependencies of the
+// service
+type HealthService interface {
+	Ready(ctx context.Context) models.HealthReport
+}
+
+// healthService implements HealthService interface
+type healthService struct {
+	db            *sql.DB
+	replica       *sql.DB
+	schemaRepo    repositories.SchemaRepository
+	schemaVersion int
+	cache         cache.Cache
+}
+
+// NewHealthService creates a new health service instance. schemaVersion is
+// the latest migration this build ships. replica and cache are optional and
+// nil when not configured; the service keeps working without them, so they
+// never make it unready.
+func NewHealthService(db, replica *sql.DB, schemaRepo repositories.SchemaRepository, schemaVersion int, c cache.Cache) HealthService {
+	return &healthService{db: db, replica: replica, schemaRepo: schemaRepo, schemaVersion: schemaVersion, cache: c}
+}
+
+// healthCheck is one dependency to check. check returns a detail for the
+// report, or an error when the dependency is down.
+type healthCheck struct {
+	name     string
+	critical bool
+	check    func(ctx context.Context) (string, error)
+}
+
+// Ready checks every configured dependency: the database, its schema
+// version, the read replica and the cache. The report is down when a
+// critical dependency is down and degraded when only an optional one is.
+func (s *healthService) Ready(ctx context.Context) models.HealthReport {
+	checks := []healthCheck{
+		{name: "database", critical: true, check: func(ctx context.Context) (string, error) {
+			return "", s.db.PingContext(ctx)
+		}},
+		{name: "migrations", critical: true, check: s.checkMigrations},
+	}
+	if s.replica != nil {
+		checks = append(checks, healthCheck{name: "replica", check: func(ctx context.Context) (string, error) {
+			return "", s.replica.PingContext(ctx)
+		}})
+	}
+	if s.cache != nil {
+		checks = append(checks, healthCheck{name: "cache", check: func(ctx context.Context) (string, error) {
+			return "", s.cache.Ping(ctx)
+		}})
+	}
+
+	results := make([]models.DependencyHealth, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runHealthCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := models.HealthReport{Status: models.HealthUp, Dependencies: make(map[string]models.DependencyHealth, len(checks))}
+	for i, c := range checks {
+		report.Dependencies[c.name] = results[i]
+		if results[i].Status == models.HealthUp {
+			continue
+		}
+		if c.critical {
+			report.Status = models.HealthDown
+		} else if report.Status == models.HealthUp {
+			report.Status = models.HealthDegraded
+		}
+	}
+	return report
+}
+
+// checkMigrations checks that every migration this build ships has been
+// applied. A schema ahead of the build is fine: during a rolling deploy the
+// new instances migrate while the old ones still serve.
+func (s *healthService) checkMigrations(ctx context.Context) (string, error) {
+	migrations, err := s.schemaRepo.GetMigrations(ctx)
+	if err != nil {
+		return "", err
+	}
+	applied := 0
+	if len(migrations) > 0 {
+		applied = migrations[len(migrations)-1].Version
+	}
+	if applied < s.schemaVersion {
+		return "", fmt.Errorf("schema at version %d, this build needs %d", applied, s.schemaVersion)
+	}
+	return fmt.Sprintf("schema at version %d", applied), nil
+}
+
+// runHealthCheck runs c with a timeout and reports how it went
+func runHealthCheck(ctx context.Context, c healthCheck) models.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := c.check(ctx)
+	result := models.DependencyHealth{
+		Status:    models.HealthUp,
+		Critical:  c.critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Detail:    detail,
+	}
+	if err != nil {
+		result.Status = models.HealthDown
+		result.Error = err.Error()
+	}
+	return result
+}
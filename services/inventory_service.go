@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"math"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sort"
+)
+
+// InventoryService defines the interface for inventory business logic
+type InventoryService interface {
+	GetStockMovements(ctx context.Context, params models.StockMovementParams) (*models.PaginatedStockMovements, error)
+	AdjustStock(ctx context.Context, productID int, input models.StockAdjustmentInput, actor models.Actor) (*models.StockMovement, error)
+	GetLowStockReport(ctx context.Context, categoryID *int) ([]models.LowStockProduct, error)
+	GetReorderSuggestions(ctx context.Context, params models.ReorderSuggestionParams) (*models.ReorderSuggestionReport, error)
+	GetInventoryValuation(ctx context.Context, categoryID *int) (*models.InventoryValuation, error)
+}
+
+// Defaults and limits for reorder suggestions
+const (
+	ReorderDefaultVelocityDays = 30
+	ReorderDefaultLeadTimeDays = 7
+	ReorderDefaultCoverDays    = 14
+	ReorderMaxDays             = 365
+)
+
+// inventoryService implements InventoryService interface
+type inventoryService struct {
+	repo          repositories.StockMovementRepository
+	productRepo   repositories.ProductRepository
+	costLayerRepo repositories.CostLayerRepository
+	storeRepo     repositories.StoreRepository
+}
+
+// NewInventoryService creates a new inventory service instance
+func NewInventoryService(repo repositories.StockMovementRepository, productRepo repositories.ProductRepository, costLayerRepo repositories.CostLayerRepository, storeRepo repositories.StoreRepository) InventoryService {
+	return &inventoryService{
+		repo:          repo,
+		productRepo:   productRepo,
+		costLayerRepo: costLayerRepo,
+		storeRepo:     storeRepo,
+	}
+}
+
+// GetStockMovements returns the stock ledger of a product, newest first
+func (s *inventoryService) GetStockMovements(ctx context.Context, params models.StockMovementParams) (*models.PaginatedStockMovements, error) {
+	switch params.Reason {
+	case "", models.StockReasonInitial, models.StockReasonSale, models.StockReasonRefund,
+		models.StockReasonRestock, models.StockReasonAdjustment, models.StockReasonTransferOut, models.StockReasonTransferIn:
+	default:
+		return nil, helpers.NewValidationError("reason must be 'initial', 'sale', 'refund', 'restock', 'adjustment', 'transfer_out' or 'transfer_in'")
+	}
+
+	product, err := s.productRepo.GetByID(ctx, params.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, helpers.NewNotFoundError("product not found")
+	}
+
+	return s.repo.GetByProductID(ctx, params)
+}
+
+// AdjustStock applies a manual stock change with a reason code. Damage can
+// only remove stock and received goods can only add it; count corrections go
+// either way.
+func (s *inventoryService) AdjustStock(ctx context.Context, productID int, input models.StockAdjustmentInput, actor models.Actor) (*models.StockMovement, error) {
+	if input.Quantity == 0 {
+		return nil, helpers.NewValidationError("quantity must not be 0")
+	}
+
+	reason := models.StockReasonAdjustment
+	switch input.ReasonCode {
+	case models.AdjustmentDamage:
+		if input.Quantity > 0 {
+			return nil, helpers.NewValidationError("damage adjustments must have a negative quantity")
+		}
+	case models.AdjustmentReceivedGoods:
+		if input.Quantity < 0 {
+			return nil, helpers.NewValidationError("received_goods adjustments must have a positive quantity")
+		}
+		reason = models.StockReasonRestock
+	case models.AdjustmentCountCorrection:
+	default:
+		return nil, helpers.NewValidationError("reason_code must be 'damage', 'count_correction' or 'received_goods'")
+	}
+
+	storeID, err := resolveStore(ctx, s.storeRepo, input.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := models.StockMovement{
+		ProductID:     productID,
+		StoreID:       storeID,
+		QuantityDelta: input.Quantity,
+		Reason:        reason,
+		ReasonCode:    input.ReasonCode,
+		Note:          input.Note,
+	}
+	if actor.UserID > 0 {
+		movement.CreatedBy = &actor.UserID
+	}
+
+	created, err := s.repo.Adjust(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, helpers.NewNotFoundError("product not found")
+	}
+	return created, nil
+}
+
+// GetLowStockReport returns active products at or below their min_stock
+// threshold, optionally limited to one category
+func (s *inventoryService) GetLowStockReport(ctx context.Context, categoryID *int) ([]models.LowStockProduct, error) {
+	return s.productRepo.GetLowStock(ctx, categoryID)
+}
+
+// GetReorderSuggestions projects demand from recent sales velocity and lists
+// the products whose stock is at or below their reorder point. The reorder
+// point covers demand during the supplier lead time plus min_stock as safety
+// stock; the suggested quantity tops stock up to cover the lead time and the
+// requested days of cover on top of that safety stock.
+func (s *inventoryService) GetReorderSuggestions(ctx context.Context, params models.ReorderSuggestionParams) (*models.ReorderSuggestionReport, error) {
+	if params.VelocityDays == 0 {
+		params.VelocityDays = ReorderDefaultVelocityDays
+	}
+	if params.LeadTimeDays == 0 {
+		params.LeadTimeDays = ReorderDefaultLeadTimeDays
+	}
+	if params.CoverDays == 0 {
+		params.CoverDays = ReorderDefaultCoverDays
+	}
+	if params.VelocityDays < 1 || params.VelocityDays > ReorderMaxDays {
+		return nil, helpers.NewValidationError("days must be between 1 and 365")
+	}
+	if params.LeadTimeDays < 1 || params.LeadTimeDays > ReorderMaxDays {
+		return nil, helpers.NewValidationError("lead_time_days must be between 1 and 365")
+	}
+	if params.CoverDays < 1 || params.CoverDays > ReorderMaxDays {
+		return nil, helpers.NewValidationError("cover_days must be between 1 and 365")
+	}
+
+	products, err := s.productRepo.GetSalesVelocity(ctx, params.VelocityDays, params.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]models.ReorderSuggestion, 0)
+	for _, p := range products {
+		p.DailyVelocity = float64(p.UnitsSold) / float64(params.VelocityDays)
+		p.ReorderPoint = int(math.Ceil(p.DailyVelocity*float64(params.LeadTimeDays))) + p.MinStock
+		if p.Stock > p.ReorderPoint {
+			continue
+		}
+
+		target := int(math.Ceil(p.DailyVelocity*float64(params.LeadTimeDays+params.CoverDays))) + p.MinStock
+		p.SuggestedQty = target - p.Stock
+		if p.SuggestedQty <= 0 {
+			continue
+		}
+		p.DailyVelocity = math.Round(p.DailyVelocity*100) / 100
+		suggestions = append(suggestions, p)
+	}
+
+	// Most urgent first: furthest below the reorder point
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].ReorderPoint-suggestions[i].Stock > suggestions[j].ReorderPoint-suggestions[j].Stock
+	})
+
+	return &models.ReorderSuggestionReport{
+		VelocityDays: params.VelocityDays,
+		LeadTimeDays: params.LeadTimeDays,
+		CoverDays:    params.CoverDays,
+		Suggestions:  suggestions,
+	}, nil
+}
+
+// GetInventoryValuation values the stock the store owns using FIFO: the units
+// on hand are the most recently received ones, so each product's stock is
+// taken from its newest open cost layers. Stock not covered by a layer (for
+// example opening stock entered without a cost) is valued at the product's
+// cost price, else its last received unit cost. Consigned products are
+// excluded.
+func (s *inventoryService) GetInventoryValuation(ctx context.Context, categoryID *int) (*models.InventoryValuation, error) {
+	products, err := s.costLayerRepo.GetValuationProducts(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := s.costLayerRepo.GetOpenLayers(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Layers arrive newest first per product
+	byProduct := make(map[int][]models.CostLayer)
+	for _, l := range layers {
+		byProduct[l.ProductID] = append(byProduct[l.ProductID], l)
+	}
+
+	valuation := &models.InventoryValuation{
+		Method:   models.CostingMethodFIFO,
+		Products: products,
+	}
+	for i := range valuation.Products {
+		p := &valuation.Products[i]
+		left := p.Stock
+		for _, l := range byProduct[p.ProductID] {
+			if left == 0 {
+				break
+			}
+			take := min(left, l.Remaining)
+			p.CostedQty += take
+			p.Value += take * l.UnitCost
+			left -= take
+		}
+		p.UncostedQty = left
+		p.Value += left * p.LastCost
+		p.AverageCost = p.Value / p.Stock
+
+		valuation.TotalUnits += p.Stock
+		valuation.TotalValue += p.Value
+	}
+
+	return valuation, nil
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+)
+
+// The services below run on the in-memory category, product and transaction
+// repositories. What checkout needs besides them only exists on Postgres, so
+// these stand in for it: one default store, no price tiers, a fixed list of
+// promotions, every feature flag on, no related products and no webhooks.
+
+type memoryStores struct{ repositories.StoreRepository }
+
+func (memoryStores) GetDefault(ctx context.Context) (*models.Store, error) {
+	return &models.Store{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil
+}
+
+func (memoryStores) GetByID(ctx context.Context, id int) (*models.Store, error) {
+	if id != 1 {
+		return nil, nil
+	}
+	return &models.Store{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil
+}
+
+type noPriceTiers struct {
+	repositories.PriceTierRepository
+}
+
+func (noPriceTiers) GetByProductID(ctx context.Context, productID int) ([]models.PriceTier, error) {
+	return nil, nil
+}
+
+func (noPriceTiers) GetByProductIDs(ctx context.Context, productIDs []int) (map[int][]models.PriceTier, error) {
+	return nil, nil
+}
+
+type fixedPromotions struct {
+	repositories.PromotionRepository
+	active []models.Promotion
+}
+
+func (p fixedPromotions) GetActive(ctx context.Context) ([]models.Promotion, error) {
+	return p.active, nil
+}
+
+type flagsOn struct{ FeatureFlagService }
+
+func (flagsOn) Enabled(ctx context.Context, name string, storeID int) bool { return true }
+
+type noRelations struct {
+	repositories.ProductRelationRepository
+}
+
+func (noRelations) GetByProductID(ctx context.Context, productID int, relationType string) ([]models.ProductRelation, error) {
+	return nil, nil
+}
+
+type noEvents struct{}
+
+func (noEvents) Publish(ctx context.Context, event string, data interface{}) {}
+
+// memoryCatalog returns category and product services sharing one MemoryStore
+func memoryCatalog(store *repositories.MemoryStore) (CategoryService, ProductService) {
+	categories := repositories.NewMemoryCategoryRepository(store)
+	products := repositories.NewMemoryProductRepository(store)
+	return NewCategoryService(categories), NewProductService(products, categories, noRelations{}, nil, nil, noEvents{})
+}
+
+func TestCatalogOnMemoryRepositories(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewMemoryStore()
+	categories, products := memoryCatalog(store)
+
+	drinks, err := categories.CreateCategory(ctx, models.Category{Name: "Drinks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tea, err := categories.CreateCategory(ctx, models.Category{Name: "Tea", ParentID: &drinks.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := categories.CreateCategory(ctx, models.Category{Name: "Drinks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drinks.Slug != "drinks" || again.Slug != "drinks-2" {
+		t.Fatalf("slugs %q and %q, want drinks and drinks-2", drinks.Slug, again.Slug)
+	}
+
+	// A category cannot move under its own subcategory
+	_, err = categories.UpdateCategory(ctx, drinks.ID, models.Category{Name: "Drinks", ParentID: &tea.ID})
+	if !errors.Is(err, helpers.ErrValidation) {
+		t.Fatalf("moving a category under its subcategory: got %v, want a validation error", err)
+	}
+
+	missing := 999
+	_, err = products.CreateProduct(ctx, models.Product{Name: "Ghost", Price: 1000, CategoryID: &missing}, models.Actor{})
+	if !errors.Is(err, helpers.ErrValidation) {
+		t.Fatalf("creating a product in a missing category: got %v, want a validation error", err)
+	}
+	greenTea, err := products.CreateProduct(ctx, models.Product{Name: "Green Tea", Price: 4000, Stock: 10, CategoryID: &tea.ID}, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if greenTea.CategoryName != "Tea" {
+		t.Fatalf("category name %q, want Tea", greenTea.CategoryName)
+	}
+
+	inDrinks, err := products.GetProductsByCategoryID(ctx, drinks.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inDrinks) != 1 || inDrinks[0].ID != greenTea.ID {
+		t.Fatalf("products under Drinks and its subcategories: %v, want Green Tea", inDrinks)
+	}
+	found, err := products.GetAllProducts(ctx, models.ProductListParams{Search: "green"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Total != 1 {
+		t.Fatalf("searching for green finds %d products, want 1", found.Total)
+	}
+
+	_, err = products.UpdateProduct(ctx, missing, models.Product{Name: "Ghost", Price: 1000}, models.Actor{})
+	if !errors.Is(err, helpers.ErrNotFound) {
+		t.Fatalf("updating a missing product: got %v, want not found", err)
+	}
+
+	// Deleting a category uncategorizes its products, as ON DELETE SET NULL does
+	if err := categories.DeleteCategory(ctx, tea.ID); err != nil {
+		t.Fatal(err)
+	}
+	product, err := products.GetProductByID(ctx, greenTea.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product.CategoryID != nil {
+		t.Fatalf("the product is still in deleted category %d", *product.CategoryID)
+	}
+	if err := categories.DeleteCategory(ctx, tea.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("deleting a deleted category: got %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestCheckoutOnMemoryRepositories(t *testing.T) {
+	ctx := context.Background()
+	store := repositories.NewMemoryStore()
+	_, products := memoryCatalog(store)
+
+	tea, err := products.CreateProduct(ctx, models.Product{Name: "Tea", Price: 4000, Stock: 10, CostPrice: 2500}, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rice, err := products.CreateProduct(ctx, models.Product{Name: "Rice", Price: 12000, Stock: 3, CostPrice: 10000}, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bogo := models.Promotion{ID: 1, Name: "Tea BOGO", Type: models.PromotionTypeBOGO, ProductID: &tea.ID, BuyQty: 1, GetQty: 1}
+
+	transactions := repositories.NewMemoryTransactionRepository(store)
+	service := NewTransactionService(transactions, repositories.NewMemoryProductRepository(store),
+		fixedPromotions{active: []models.Promotion{bogo}}, noPriceTiers{}, memoryStores{}, nil, flagsOn{})
+
+	// stock returns the stock of a product as the catalog sees it
+	stock := func(id int) int {
+		t.Helper()
+		p, err := products.GetProductByID(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p.Stock
+	}
+
+	sale, err := service.Checkout(ctx, models.CheckoutRequest{Items: []models.CheckoutItem{
+		{ProductID: tea.ID, Quantity: 1},
+		{ProductID: rice.ID, Quantity: 2},
+		{ProductID: tea.ID, Quantity: 1},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The sale deducts stock from the products of the shared store
+	if got := stock(tea.ID); got != 8 {
+		t.Fatalf("tea stock %d after selling 2, want 8", got)
+	}
+	if got := stock(rice.ID); got != 1 {
+		t.Fatalf("rice stock %d after selling 2, want 1", got)
+	}
+	if sale.StoreID != 1 || len(sale.Details) != 3 {
+		t.Fatalf("sale at store %d with %d lines, want store 1 with 3", sale.StoreID, len(sale.Details))
+	}
+	// The second tea is free: buy one, get one across the two lines
+	if got, want := sale.TotalAmount, 4000+2*12000; got != want {
+		t.Fatalf("total %d, want %d", got, want)
+	}
+	if got, want := sale.Details[1].CostAmount, 2*10000; got != want {
+		t.Fatalf("rice line costs %d, want %d", got, want)
+	}
+
+	stored, err := service.GetTransactionByID(ctx, sale.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ReceiptNo != sale.ReceiptNo || stored.Details[0].ProductName != "Tea" {
+		t.Fatalf("stored transaction %+v does not match the sale", stored)
+	}
+
+	// A sale beyond the stock is refused and takes nothing
+	_, err = service.Checkout(ctx, models.CheckoutRequest{Items: []models.CheckoutItem{
+		{ProductID: tea.ID, Quantity: 1},
+		{ProductID: rice.ID, Quantity: 2},
+	}})
+	var coded *helpers.CodedError
+	if !errors.As(err, &coded) || coded.Code != helpers.CodeInsufficientStock {
+		t.Fatalf("selling more rice than in stock: got %v, want insufficient stock", err)
+	}
+	if stock(tea.ID) != 8 || stock(rice.ID) != 1 {
+		t.Fatalf("a refused sale changed stock: tea %d, rice %d", stock(tea.ID), stock(rice.ID))
+	}
+
+	// Voiding the sale puts its stock back
+	if err := service.VoidTransaction(ctx, sale.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stock(tea.ID) != 10 || stock(rice.ID) != 3 {
+		t.Fatalf("after the void: tea %d, rice %d, want 10 and 3", stock(tea.ID), stock(rice.ID))
+	}
+	if err := service.VoidTransaction(ctx, sale.ID); err == nil {
+		t.Fatal("a sale was voided twice")
+	}
+}
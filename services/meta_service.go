@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+)
+
+// MetaService defines the interface for API and data schema metadata
+type MetaService interface {
+	GetSchemaVersion(ctx context.Context) (*models.SchemaVersion, error)
+	GetMigrations(ctx context.Context) ([]models.SchemaMigration, error)
+}
+
+// metaService implements MetaService interface
+type metaService struct {
+	schemaRepo repositories.SchemaRepository
+}
+
+// NewMetaService creates a new meta service instance
+func NewMetaService(schemaRepo repositories.SchemaRepository) MetaService {
+	return &metaService{schemaRepo: schemaRepo}
+}
+
+// GetSchemaVersion returns the latest applied schema migration
+func (s *metaService) GetSchemaVersion(ctx context.Context) (*models.SchemaVersion, error) {
+	migrations, err := s.schemaRepo.GetMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, errors.New("no schema migrations have been applied")
+	}
+
+	latest := migrations[len(migrations)-1]
+	return &models.SchemaVersion{Version: latest.Version, Name: latest.Name, AppliedAt: latest.AppliedAt}, nil
+}
+
+// GetMigrations returns the applied schema migrations, oldest first
+func (s *metaService) GetMigrations(ctx context.Context) ([]models.SchemaMigration, error) {
+	return s.schemaRepo.GetMigrations(ctx)
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"retail-core-api/chaos"
+	"time"
+)
+
+// notificationWebhook is the URL a webhook notifier POSTs its events to. Unlike
+// the integrators' webhooks, deliveries are not signed, queued or retried: a
+// failure is only logged.
+type notificationWebhook struct {
+	url      string
+	client   *http.Client
+	injector *chaos.Injector
+}
+
+func newNotificationWebhook(url string, injector *chaos.Injector) notificationWebhook {
+	return notificationWebhook{url: url, client: &http.Client{Timeout: 10 * time.Second}, injector: injector}
+}
+
+// post sends event as JSON and logs, with attrs, when it was not delivered
+func (w notificationWebhook) post(event map[string]interface{}, attrs ...any) {
+	if err := w.injector.Webhook(); err != nil {
+		slog.Error("notification webhook not delivered", append(attrs, "event", event["event"], "error", err)...)
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		slog.Error("notification webhook not delivered", append(attrs, "event", event["event"], "error", err)...)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		slog.Error("notification webhook rejected", append(attrs, "event", event["event"], "status", resp.StatusCode)...)
+	}
+}
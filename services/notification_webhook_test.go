@@ -0,0 +1,75 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"retail-core-api/models"
+	"testing"
+	"time"
+)
+
+// receiveWebhook serves a webhook and passes on the bodies POSTed to it
+func receiveWebhook(t *testing.T) (string, <-chan map[string]json.RawMessage) {
+	t.Helper()
+	bodies := make(chan map[string]json.RawMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("webhook body: %v", err)
+		}
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, bodies
+}
+
+func nextWebhook(t *testing.T, bodies <-chan map[string]json.RawMessage) map[string]json.RawMessage {
+	t.Helper()
+	select {
+	case body := <-bodies:
+		return body
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not called")
+		return nil
+	}
+}
+
+func TestWebhookApprovalNotifier(t *testing.T) {
+	url, bodies := receiveWebhook(t)
+
+	NewWebhookApprovalNotifier(url, nil).NotifyPending(models.ProductChangeRequest{ID: 7, Action: "create"})
+
+	body := nextWebhook(t, bodies)
+	var req models.ProductChangeRequest
+	if err := json.Unmarshal(body["change_request"], &req); err != nil {
+		t.Fatal(err)
+	}
+	if string(body["event"]) != `"change_request_pending"` || req.ID != 7 {
+		t.Fatalf("webhook got event %s for change request %d, want change_request_pending for 7", body["event"], req.ID)
+	}
+}
+
+func TestWebhookCycleCountNotifier(t *testing.T) {
+	url, bodies := receiveWebhook(t)
+
+	session := models.CountSession{ID: 3, Note: "Cycle count: class A (weekly)"}
+	NewWebhookCycleCountNotifier(url, nil).NotifyAssigned(session, &models.User{ID: 5, Name: "Sari", Password: "hash"})
+
+	body := nextWebhook(t, bodies)
+	var got struct {
+		Event    string
+		Session  models.CountSession
+		Assignee map[string]interface{}
+	}
+	raw, _ := json.Marshal(body)
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Event != "count_session_opened" || got.Session.ID != 3 || got.Assignee["id"] != float64(5) {
+		t.Fatalf("webhook got %s", raw)
+	}
+	if _, ok := got.Assignee["password"]; ok {
+		t.Fatal("webhook got the assignee's password")
+	}
+}
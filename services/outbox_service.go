@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sync"
+	"time"
+)
+
+const (
+	// outboxBatchSize caps how many events one relay pass hands a consumer
+	outboxBatchSize = 100
+	// outboxRetention is how long published events are kept
+	outboxRetention = 7 * 24 * time.Hour
+	// outboxLease is how long a relay holds a consumer; a pass stops handing
+	// out events after outboxPassLimit, well within it
+	outboxLease     = 2 * time.Minute
+	outboxPassLimit = time.Minute
+	// outboxMaxAttempts is how often a consumer is handed an event before it
+	// is parked. Retries back off from outboxRetryMin, doubling up to
+	// outboxRetryMax, so a consumer keeps trying for about half an hour.
+	outboxMaxAttempts = 12
+	outboxRetryMin    = time.Second
+	outboxRetryMax    = 5 * time.Minute
+)
+
+// EventHandler consumes domain events relayed from the outbox. Each handler
+// is handed every event once it accepted the ones before, so a handler may
+// see an event more than once and must tolerate it. Consumer names the
+// handler in the outbox; it must stay the same across releases.
+type EventHandler interface {
+	Consumer() string
+	HandleEvent(ctx context.Context, event models.DomainEvent) error
+}
+
+// OutboxService defines the interface for relaying domain events from the
+// outbox to their consumers
+type OutboxService interface {
+	Relay(ctx context.Context) int
+	Purge(ctx context.Context) int
+}
+
+// outboxService implements OutboxService interface
+type outboxService struct {
+	repo     repositories.OutboxRepository
+	handlers []EventHandler
+
+	running sync.Mutex
+}
+
+// NewOutboxService creates a new outbox relay handing events to handlers
+func NewOutboxService(repo repositories.OutboxRepository, handlers ...EventHandler) OutboxService {
+	return &outboxService{repo: repo, handlers: handlers}
+}
+
+// Relay hands the pending events to every handler, each on its own, and
+// marks the events every handler is done with as published. It returns the
+// most events one handler accepted.
+func (s *outboxService) Relay(ctx context.Context) int {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	relayed := 0
+	consumers := make([]string, len(s.handlers))
+	for i, h := range s.handlers {
+		consumers[i] = h.Consumer()
+		relayed = max(relayed, s.relay(ctx, h))
+	}
+
+	if _, err := s.repo.MarkPublished(ctx, consumers); err != nil {
+		slog.ErrorContext(ctx, "failed to mark events published", "component", "outbox", "error", err)
+	}
+	return relayed
+}
+
+// relay hands a handler its pending events, oldest first, and returns how
+// many it accepted. No DB transaction is held while the handler runs; the
+// lease keeps relays on other servers away from the handler instead. When
+// the handler fails, the event is retried after a backoff and the pass stops,
+// so the handler is never handed events out of order. An event it keeps
+// failing is parked after outboxMaxAttempts and the handler moves on.
+func (s *outboxService) relay(ctx context.Context, h EventHandler) int {
+	consumer := h.Consumer()
+	leased, err := s.repo.Lease(ctx, consumer, outboxLease)
+	if err != nil {
+		slog.ErrorContext(ctx, "relay failed", "component", "outbox", "consumer", consumer, "error", err)
+		return 0
+	}
+	if !leased {
+		return 0
+	}
+	defer func() {
+		if err := s.repo.Release(context.WithoutCancel(ctx), consumer); err != nil {
+			slog.ErrorContext(ctx, "failed to release outbox consumer", "component", "outbox", "consumer", consumer, "error", err)
+		}
+	}()
+
+	events, err := s.repo.GetPending(ctx, consumer, outboxBatchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "relay failed", "component", "outbox", "consumer", consumer, "error", err)
+		return 0
+	}
+
+	deadline := time.Now().Add(outboxPassLimit)
+	delivered := make([]int, 0, len(events))
+	for _, event := range events {
+		if event.Deferred || time.Now().After(deadline) {
+			break
+		}
+		err := h.HandleEvent(ctx, event)
+		if err == nil {
+			delivered = append(delivered, event.ID)
+			continue
+		}
+
+		attempt := event.Attempts + 1
+		if attempt >= outboxMaxAttempts {
+			slog.ErrorContext(ctx, "event parked", "component", "outbox", "consumer", consumer, "event", event.Type, "event_id", event.ID, "attempt", attempt, "error", err)
+			if err := s.repo.Park(ctx, consumer, event.ID, err.Error()); err != nil {
+				slog.ErrorContext(ctx, "relay failed", "component", "outbox", "consumer", consumer, "error", err)
+				break
+			}
+			continue
+		}
+		retryIn := min(outboxRetryMin<<(attempt-1), outboxRetryMax)
+		slog.WarnContext(ctx, "event not relayed", "component", "outbox", "consumer", consumer, "event", event.Type, "event_id", event.ID, "attempt", attempt, "retry_in", retryIn, "error", err)
+		if err := s.repo.RecordFailure(ctx, consumer, event.ID, err.Error(), retryIn); err != nil {
+			slog.ErrorContext(ctx, "relay failed", "component", "outbox", "consumer", consumer, "error", err)
+		}
+		break
+	}
+
+	if err := s.repo.MarkDelivered(ctx, consumer, delivered); err != nil {
+		slog.ErrorContext(ctx, "relay failed", "component", "outbox", "consumer", consumer, "error", err)
+		return 0
+	}
+	return len(delivered)
+}
+
+// Purge deletes events published longer than outboxRetention ago and
+// returns how many were deleted
+func (s *outboxService) Purge(ctx context.Context) int {
+	deleted, err := s.repo.DeletePublishedBefore(ctx, time.Now().Add(-outboxRetention))
+	if err != nil {
+		slog.ErrorContext(ctx, "purge failed", "component", "outbox", "error", err)
+		return 0
+	}
+	return deleted
+}
+
+// StartOutboxRelay relays pending domain events in the background every
+// interval and purges old published events hourly
+func StartOutboxRelay(service OutboxService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		purge := time.NewTicker(time.Hour)
+		defer purge.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// Keep relaying while passes come back full, to drain a backlog
+				for service.Relay(context.Background()) >= outboxBatchSize {
+				}
+			case <-purge.C:
+				if n := service.Purge(context.Background()); n > 0 {
+					slog.Info("purged published events", "component", "outbox", "count", n)
+				}
+			}
+		}
+	}()
+}
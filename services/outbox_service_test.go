@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"testing"
+	"time"
+)
+
+// delivery is what a consumer did with an event in memoryOutbox
+type delivery struct {
+	attempts  int
+	deferred  bool
+	delivered bool
+	parked    bool
+}
+
+// memoryOutbox keeps the outbox in memory
+type memoryOutbox struct {
+	repositories.OutboxRepository
+	events     []models.DomainEvent
+	deliveries map[string]map[int]*delivery
+	published  map[int]bool
+}
+
+func newMemoryOutbox(n int) *memoryOutbox {
+	o := &memoryOutbox{deliveries: make(map[string]map[int]*delivery), published: make(map[int]bool)}
+	for id := 1; id <= n; id++ {
+		o.events = append(o.events, models.DomainEvent{ID: id, Type: models.DomainEventStockChanged})
+	}
+	return o
+}
+
+func (o *memoryOutbox) delivery(consumer string, id int) *delivery {
+	if o.deliveries[consumer] == nil {
+		o.deliveries[consumer] = make(map[int]*delivery)
+	}
+	if o.deliveries[consumer][id] == nil {
+		o.deliveries[consumer][id] = &delivery{}
+	}
+	return o.deliveries[consumer][id]
+}
+
+// retryNow makes every deferred retry due
+func (o *memoryOutbox) retryNow() {
+	for _, byID := range o.deliveries {
+		for _, d := range byID {
+			d.deferred = false
+		}
+	}
+}
+
+func (o *memoryOutbox) Lease(ctx context.Context, consumer string, d time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (o *memoryOutbox) Release(ctx context.Context, consumer string) error {
+	return nil
+}
+
+func (o *memoryOutbox) GetPending(ctx context.Context, consumer string, limit int) ([]models.DomainEvent, error) {
+	var events []models.DomainEvent
+	for _, e := range o.events {
+		d := o.delivery(consumer, e.ID)
+		if o.published[e.ID] || d.delivered || d.parked {
+			continue
+		}
+		e.Attempts, e.Deferred = d.attempts, d.deferred
+		events = append(events, e)
+		if len(events) == limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+func (o *memoryOutbox) MarkDelivered(ctx context.Context, consumer string, ids []int) error {
+	for _, id := range ids {
+		d := o.delivery(consumer, id)
+		d.attempts++
+		d.delivered = true
+	}
+	return nil
+}
+
+func (o *memoryOutbox) RecordFailure(ctx context.Context, consumer string, id int, message string, retryIn time.Duration) error {
+	d := o.delivery(consumer, id)
+	d.attempts++
+	d.deferred = true
+	return nil
+}
+
+func (o *memoryOutbox) Park(ctx context.Context, consumer string, id int, message string) error {
+	d := o.delivery(consumer, id)
+	d.attempts++
+	d.parked = true
+	return nil
+}
+
+func (o *memoryOutbox) MarkPublished(ctx context.Context, consumers []string) (int, error) {
+	n := 0
+	for _, e := range o.events {
+		done := !o.published[e.ID]
+		for _, c := range consumers {
+			d := o.delivery(c, e.ID)
+			done = done && (d.delivered || d.parked)
+		}
+		if done {
+			o.published[e.ID] = true
+			n++
+		}
+	}
+	return n, nil
+}
+
+// recordingHandler records the events it accepts and fails those in failing
+type recordingHandler struct {
+	name     string
+	failing  map[int]bool
+	accepted []int
+}
+
+func (h *recordingHandler) Consumer() string {
+	return h.name
+}
+
+func (h *recordingHandler) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	if h.failing[event.ID] {
+		return errors.New("broker unavailable")
+	}
+	h.accepted = append(h.accepted, event.ID)
+	return nil
+}
+
+func TestOutboxRelaysConsumersIndependently(t *testing.T) {
+	ctx := context.Background()
+	outbox := newMemoryOutbox(3)
+	webhooks := &recordingHandler{name: "webhooks"}
+	broker := &recordingHandler{name: "broker", failing: map[int]bool{2: true}}
+	cache := &recordingHandler{name: "catalog_cache"}
+	service := NewOutboxService(outbox, webhooks, broker, cache)
+
+	// The broker failing on event 2 holds back neither the webhooks nor the
+	// cache, which comes after it
+	service.Relay(ctx)
+	if len(webhooks.accepted) != 3 || len(cache.accepted) != 3 {
+		t.Fatalf("webhooks accepted %v and the cache %v, want every event", webhooks.accepted, cache.accepted)
+	}
+	if len(broker.accepted) != 1 {
+		t.Fatalf("the broker accepted %v, want event 1 only", broker.accepted)
+	}
+	if !outbox.published[1] || outbox.published[2] || outbox.published[3] {
+		t.Fatalf("published %v, want only event 1, which every consumer accepted", outbox.published)
+	}
+
+	// The retry waits for its backoff, and events stay in order behind it
+	service.Relay(ctx)
+	if len(broker.accepted) != 1 || outbox.delivery("broker", 2).attempts != 1 {
+		t.Fatalf("the broker was handed events before its retry was due: accepted %v", broker.accepted)
+	}
+
+	// Once the broker is back, the retry goes through and the rest follows
+	broker.failing = nil
+	outbox.retryNow()
+	service.Relay(ctx)
+	if len(broker.accepted) != 3 || broker.accepted[1] != 2 || broker.accepted[2] != 3 {
+		t.Fatalf("the broker accepted %v, want 1, 2, 3", broker.accepted)
+	}
+	if !outbox.published[2] || !outbox.published[3] {
+		t.Fatal("events every consumer accepted are not published")
+	}
+}
+
+func TestOutboxParksFailingEvents(t *testing.T) {
+	ctx := context.Background()
+	outbox := newMemoryOutbox(2)
+	broker := &recordingHandler{name: "broker", failing: map[int]bool{1: true}}
+	service := NewOutboxService(outbox, broker)
+
+	for i := 0; i < outboxMaxAttempts-1; i++ {
+		service.Relay(ctx)
+		outbox.retryNow()
+		if outbox.delivery("broker", 1).parked {
+			t.Fatalf("event parked after %d attempts, want %d", i+1, outboxMaxAttempts)
+		}
+	}
+	if len(broker.accepted) != 0 {
+		t.Fatalf("event 2 was handed out while event 1 was being retried")
+	}
+
+	// The last attempt parks the event and the broker moves on
+	service.Relay(ctx)
+	if d := outbox.delivery("broker", 1); !d.parked || d.attempts != outboxMaxAttempts {
+		t.Fatalf("event 1: parked %v after %d attempts, want parked after %d", d.parked, d.attempts, outboxMaxAttempts)
+	}
+	if len(broker.accepted) != 1 || broker.accepted[0] != 2 {
+		t.Fatalf("the broker accepted %v after parking event 1, want event 2", broker.accepted)
+	}
+	if !outbox.published[1] || !outbox.published[2] {
+		t.Fatal("parked and accepted events are not published")
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+)
+
+// PriceScheduleService defines the interface for future-dated price changes
+type PriceScheduleService interface {
+	GetScheduledPrices(ctx context.Context, productID int, status string) ([]models.ScheduledPrice, error)
+	SchedulePrice(ctx context.Context, productID int, input models.ScheduledPriceInput, actor models.Actor) (*models.ScheduledPrice, error)
+	CancelScheduledPrice(ctx context.Context, productID, id int) (*models.ScheduledPrice, error)
+	ApplyDue(ctx context.Context) int
+}
+
+// priceScheduleService implements PriceScheduleService interface
+type priceScheduleService struct {
+	repo         repositories.ScheduledPriceRepository
+	productRepo  repositories.ProductRepository
+	auditService AuditService
+}
+
+// NewPriceScheduleService creates a new price schedule service instance
+func NewPriceScheduleService(repo repositories.ScheduledPriceRepository, productRepo repositories.ProductRepository, auditService AuditService) PriceScheduleService {
+	return &priceScheduleService{
+		repo:         repo,
+		productRepo:  productRepo,
+		auditService: auditService,
+	}
+}
+
+// existingProduct returns an error when the product does not exist
+func (s *priceScheduleService) existingProduct(ctx context.Context, productID int) error {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return helpers.NewNotFoundError("product not found")
+	}
+	return nil
+}
+
+// GetScheduledPrices returns the scheduled prices of a product, optionally
+// filtered by status
+func (s *priceScheduleService) GetScheduledPrices(ctx context.Context, productID int, status string) ([]models.ScheduledPrice, error) {
+	switch status {
+	case "", models.ScheduledPriceStatusPending, models.ScheduledPriceStatusApplied, models.ScheduledPriceStatusCancelled:
+	default:
+		return nil, helpers.NewValidationError("status must be 'pending', 'applied' or 'cancelled'")
+	}
+	if err := s.existingProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByProductID(ctx, productID, status)
+}
+
+// SchedulePrice stores a price that the scheduler applies at effective_at
+func (s *priceScheduleService) SchedulePrice(ctx context.Context, productID int, input models.ScheduledPriceInput, actor models.Actor) (*models.ScheduledPrice, error) {
+	if input.Price < 0 {
+		return nil, helpers.NewValidationError("price cannot be negative")
+	}
+	if !input.EffectiveAt.After(time.Now()) {
+		return nil, helpers.NewValidationError("effective_at must be in the future")
+	}
+	if err := s.existingProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	scheduled := models.ScheduledPrice{
+		ProductID:     productID,
+		Price:         input.Price,
+		EffectiveAt:   input.EffectiveAt,
+		CreatedByName: actor.Name,
+	}
+	if actor.UserID > 0 {
+		scheduled.CreatedBy = &actor.UserID
+	}
+	return s.repo.Create(ctx, scheduled)
+}
+
+// CancelScheduledPrice withdraws a pending price change of a product
+func (s *priceScheduleService) CancelScheduledPrice(ctx context.Context, productID, id int) (*models.ScheduledPrice, error) {
+	scheduled, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if scheduled == nil || scheduled.ProductID != productID {
+		return nil, helpers.NewNotFoundError("scheduled price not found")
+	}
+
+	cancelled, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cancelled == nil {
+		return nil, helpers.NewConflictError("scheduled price has already been applied or cancelled")
+	}
+	return cancelled, nil
+}
+
+// ApplyDue applies every pending price change whose effective time has passed
+// and returns how many were applied. Failures are recorded on the scheduled
+// price and retried on the next run.
+func (s *priceScheduleService) ApplyDue(ctx context.Context) int {
+	ids, err := s.repo.GetDueIDs(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load due price changes", "component", "prices", "error", err)
+		return 0
+	}
+
+	applied := 0
+	for _, id := range ids {
+		if err := s.apply(ctx, id); err != nil {
+			slog.ErrorContext(ctx, "failed to apply scheduled price", "component", "prices", "scheduled_price_id", id, "error", err)
+			if err := s.repo.SetError(ctx, id, err.Error()); err != nil {
+				slog.ErrorContext(ctx, "failed to record scheduled price error", "component", "prices", "scheduled_price_id", id, "error", err)
+			}
+			continue
+		}
+		applied++
+	}
+
+	return applied
+}
+
+// apply applies one scheduled price and records the product update in the
+// audit log under the user who scheduled it
+func (s *priceScheduleService) apply(ctx context.Context, id int) error {
+	scheduled, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if scheduled == nil {
+		return helpers.NewNotFoundError("scheduled price not found")
+	}
+	before, err := s.productRepo.GetByID(ctx, scheduled.ProductID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Apply(ctx, id); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "applied scheduled price", "component", "prices", "scheduled_price_id", id, "product_id", scheduled.ProductID)
+
+	after, err := s.productRepo.GetByID(ctx, scheduled.ProductID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load product for the audit log", "component", "prices", "product_id", scheduled.ProductID, "error", err)
+		return nil
+	}
+	actor := models.Actor{Name: scheduled.CreatedByName}
+	if scheduled.CreatedBy != nil {
+		actor.UserID = *scheduled.CreatedBy
+	}
+	s.auditService.Record(ctx, actor, models.AuditActionUpdate, models.AuditEntityProduct, scheduled.ProductID, before, after)
+	return nil
+}
+
+// StartPriceScheduler runs ApplyDue in the background every interval
+func StartPriceScheduler(service PriceScheduleService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.ApplyDue(context.Background())
+		}
+	}()
+}
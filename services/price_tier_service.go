@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+)
+
+// PriceTierService defines the interface for price level and quantity break logic
+type PriceTierService interface {
+	GetPriceTiers(ctx context.Context, productID int) ([]models.PriceTier, error)
+	ReplacePriceTiers(ctx context.Context, productID int, tiers []models.PriceTierInput) ([]models.PriceTier, error)
+}
+
+// priceTierService implements PriceTierService interface
+type priceTierService struct {
+	repo        repositories.PriceTierRepository
+	productRepo repositories.ProductRepository
+}
+
+// NewPriceTierService creates a new price tier service instance
+func NewPriceTierService(repo repositories.PriceTierRepository, productRepo repositories.ProductRepository) PriceTierService {
+	return &priceTierService{repo: repo, productRepo: productRepo}
+}
+
+// existingProduct returns an error when the product does not exist
+func (s *priceTierService) existingProduct(ctx context.Context, productID int) error {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return helpers.NewNotFoundError("product not found")
+	}
+	return nil
+}
+
+// GetPriceTiers returns the price tiers of a product
+func (s *priceTierService) GetPriceTiers(ctx context.Context, productID int) ([]models.PriceTier, error) {
+	if err := s.existingProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByProductID(ctx, productID)
+}
+
+// ReplacePriceTiers sets the full list of price tiers of a product. Each price
+// level may have one tier per minimum quantity.
+func (s *priceTierService) ReplacePriceTiers(ctx context.Context, productID int, tiers []models.PriceTierInput) ([]models.PriceTier, error) {
+	seen := make(map[string]bool, len(tiers))
+	for _, t := range tiers {
+		if !validPriceLevel(t.PriceLevel) {
+			return nil, helpers.NewValidationError("price_level must be 'retail', 'wholesale' or 'member'")
+		}
+		if t.MinQuantity < 1 {
+			return nil, helpers.NewValidationError("min_quantity must be at least 1")
+		}
+		if t.Price < 0 {
+			return nil, helpers.NewValidationError("price cannot be negative")
+		}
+		key := fmt.Sprintf("%s/%d", t.PriceLevel, t.MinQuantity)
+		if seen[key] {
+			return nil, helpers.NewConflictError(fmt.Sprintf("%s tier for min_quantity %d must be unique", t.PriceLevel, t.MinQuantity))
+		}
+		seen[key] = true
+	}
+	if err := s.existingProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.repo.Replace(ctx, productID, tiers)
+}
+
+// validPriceLevel reports whether level is a known customer group
+func validPriceLevel(level string) bool {
+	switch level {
+	case models.PriceLevelRetail, models.PriceLevelWholesale, models.PriceLevelMember:
+		return true
+	}
+	return false
+}
+
+// tierPrice returns the unit price a customer of the given price level pays
+// for a quantity: the lowest of the base price and every tier of that level,
+// or of retail, whose minimum quantity is reached. Retail quantity breaks so
+// apply to every customer group.
+func tierPrice(basePrice int, tiers []models.PriceTier, level string, quantity int) int {
+	price := basePrice
+	for _, t := range tiers {
+		if t.PriceLevel != level && t.PriceLevel != models.PriceLevelRetail {
+			continue
+		}
+		if quantity >= t.MinQuantity && t.Price < price {
+			price = t.Price
+		}
+	}
+	return price
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxProductImportRows caps the data rows of one CSV import
+const MaxProductImportRows = 5000
+
+// ProductImportService defines the interface for bulk product imports
+type ProductImportService interface {
+	Import(ctx context.Context, r io.Reader, dryRun bool, actor models.Actor) (*models.ProductImportResult, error)
+}
+
+// productImportService implements ProductImportService interface
+type productImportService struct {
+	productRepo       repositories.ProductRepository
+	categoryRepo      repositories.CategoryRepository
+	productService    ProductService
+	auditService      AuditService
+	suggestionService CategorySuggestionService
+}
+
+// NewProductImportService creates a new product import service instance
+func NewProductImportService(productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository, productService ProductService, auditService AuditService, suggestionService CategorySuggestionService) ProductImportService {
+	return &productImportService{
+		productRepo:       productRepo,
+		categoryRepo:      categoryRepo,
+		productService:    productService,
+		auditService:      auditService,
+		suggestionService: suggestionService,
+	}
+}
+
+// importRow is a parsed CSV row; nil fields were absent or left empty
+type importRow struct {
+	line     int
+	name     string
+	sku      string
+	price    *int
+	stock    *int
+	category *string
+}
+
+// Import creates or updates products from a CSV with a header row. A row
+// whose sku matches an existing product updates its name, price, stock and
+// category, leaving empty cells unchanged; any other row creates a product.
+// Invalid rows are skipped and reported. With dryRun every row is validated
+// but nothing is written, so the result shows exactly what the import would do.
+func (s *productImportService) Import(ctx context.Context, r io.Reader, dryRun bool, actor models.Actor) (*models.ProductImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, helpers.NewValidationError("CSV file is empty")
+	}
+	if err != nil {
+		return nil, helpers.NewValidationError(fmt.Sprintf("invalid CSV: %v", err))
+	}
+	columns, err := importColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryLookup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ProductImportResult{
+		DryRun: dryRun,
+		Rows:   make([]models.ProductImportRow, 0),
+		Errors: make([]models.ProductImportError, 0),
+	}
+	seenSKUs := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, helpers.NewValidationError(fmt.Sprintf("invalid CSV: %v", err))
+		}
+		line, _ := reader.FieldPos(0)
+		if blankRecord(record) {
+			continue
+		}
+		result.TotalRows++
+		if result.TotalRows > MaxProductImportRows {
+			return nil, helpers.NewValidationError(fmt.Sprintf("CSV must have at most %d rows", MaxProductImportRows))
+		}
+
+		row, rowErr := parseImportRow(line, record, columns)
+		if rowErr == nil && row.sku != "" {
+			if first, ok := seenSKUs[row.sku]; ok {
+				rowErr = &models.ProductImportError{Row: line, Column: "sku", Message: fmt.Sprintf("sku %s already appears on row %d", row.sku, first)}
+			} else {
+				seenSKUs[row.sku] = line
+			}
+		}
+		var imported *models.ProductImportRow
+		if rowErr == nil {
+			imported, rowErr = s.applyRow(ctx, row, categories, dryRun, actor)
+		}
+		if rowErr != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+
+		if imported.Action == models.ProductImportCreate {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+		result.Rows = append(result.Rows, *imported)
+	}
+
+	return result, nil
+}
+
+// importColumns maps each known column to its index in the header
+func importColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !isImportColumn(name) {
+			// The extra columns of a product export are ignored, so an edited
+			// export can be imported again
+			if slices.Contains(models.ProductExportColumns, name) {
+				continue
+			}
+			return nil, helpers.NewValidationError(fmt.Sprintf("unknown column %q; columns must be %s", name, strings.Join(models.ProductImportColumns, ", ")))
+		}
+		if _, dup := columns[name]; dup {
+			return nil, helpers.NewValidationError(fmt.Sprintf("column %s appears twice", name))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, helpers.NewValidationError("CSV must have a name column")
+	}
+	if _, ok := columns["price"]; !ok {
+		return nil, helpers.NewValidationError("CSV must have a price column")
+	}
+	return columns, nil
+}
+
+// isImportColumn reports whether name is one of ProductImportColumns
+func isImportColumn(name string) bool {
+	return slices.Contains(models.ProductImportColumns, name)
+}
+
+// blankRecord reports whether a CSV record has no values, e.g. a trailing line
+func blankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportRow reads the cells of one record
+func parseImportRow(line int, record []string, columns map[string]int) (importRow, *models.ProductImportError) {
+	cell := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	number := func(column string) (*int, *models.ProductImportError) {
+		raw := cell(column)
+		if raw == "" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, &models.ProductImportError{Row: line, Column: column, Message: column + " must be a whole number"}
+		}
+		return &n, nil
+	}
+
+	row := importRow{line: line, name: cell("name"), sku: cell("sku")}
+	var rowErr *models.ProductImportError
+	if row.price, rowErr = number("price"); rowErr != nil {
+		return row, rowErr
+	}
+	if row.stock, rowErr = number("stock"); rowErr != nil {
+		return row, rowErr
+	}
+	if category := cell("category"); category != "" {
+		row.category = &category
+	}
+	return row, nil
+}
+
+// categoryLookup indexes the categories by lowercase name and slug
+func (s *productImportService) categoryLookup(ctx context.Context) (map[string]int, error) {
+	categories, err := s.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lookup := make(map[string]int, 2*len(categories))
+	for _, c := range categories {
+		lookup[strings.ToLower(c.Slug)] = c.ID
+	}
+	// Names win over slugs when a name happens to equal another category's slug
+	for _, c := range categories {
+		lookup[strings.ToLower(c.Name)] = c.ID
+	}
+	return lookup, nil
+}
+
+// applyRow validates a row against the catalog and, unless dryRun, writes it
+func (s *productImportService) applyRow(ctx context.Context, row importRow, categories map[string]int, dryRun bool, actor models.Actor) (*models.ProductImportRow, *models.ProductImportError) {
+	rowError := func(column, message string) *models.ProductImportError {
+		return &models.ProductImportError{Row: row.line, Column: column, Message: message}
+	}
+
+	var existing *models.Product
+	if row.sku != "" {
+		matches, err := s.productRepo.GetBySKU(ctx, row.sku)
+		if err != nil {
+			return nil, rowError("sku", "failed to look up sku: "+err.Error())
+		}
+		if len(matches) > 1 {
+			return nil, rowError("sku", fmt.Sprintf("sku %s matches %d products", row.sku, len(matches)))
+		}
+		if len(matches) == 1 {
+			existing = &matches[0]
+		}
+	}
+
+	var product models.Product
+	if existing != nil {
+		product = *existing
+	} else {
+		product = models.Product{SKU: row.sku, MinStock: models.DefaultMinStock, IsActive: true}
+		if row.price == nil {
+			return nil, rowError("price", "price is required for new products")
+		}
+	}
+	if row.name != "" {
+		product.Name = row.name
+	}
+	if row.price != nil {
+		product.Price = *row.price
+	}
+	if row.stock != nil {
+		product.Stock = *row.stock
+	}
+	if row.category != nil {
+		id, ok := categories[strings.ToLower(*row.category)]
+		if !ok {
+			return nil, rowError("category", fmt.Sprintf("category %s not found", *row.category))
+		}
+		product.CategoryID = &id
+	}
+	if err := s.productService.ValidateProduct(ctx, product); err != nil {
+		return nil, rowError("", err.Error())
+	}
+
+	imported := &models.ProductImportRow{Row: row.line, Action: models.ProductImportCreate, SKU: product.SKU, Name: product.Name}
+	if existing != nil {
+		imported.Action = models.ProductImportUpdate
+		imported.ProductID = &existing.ID
+	}
+	if dryRun {
+		return imported, nil
+	}
+
+	if existing != nil {
+		updated, err := s.productService.UpdateProduct(ctx, existing.ID, product, actor)
+		if err != nil {
+			return nil, rowError("", err.Error())
+		}
+		s.auditService.Record(ctx, actor, models.AuditActionUpdate, models.AuditEntityProduct, existing.ID, existing, updated)
+		return imported, nil
+	}
+
+	created, err := s.productService.CreateProduct(ctx, product, actor)
+	if err != nil {
+		return nil, rowError("", err.Error())
+	}
+	s.auditService.Record(ctx, actor, models.AuditActionCreate, models.AuditEntityProduct, created.ID, nil, created)
+	if _, err := s.suggestionService.SuggestFor(ctx, *created); err != nil {
+		slog.ErrorContext(ctx, "failed to suggest a category", "component", "category-suggest", "product_id", created.ID, "error", err)
+	}
+	imported.ProductID = &created.ID
+	return imported, nil
+}
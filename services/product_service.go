@@ -1,121 +1,278 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/repositories"
 )
 
 // ProductService defines the interface for product business logic
 type ProductService interface {
-	GetAllProducts(params models.ProductListParams) (*models.PaginatedProducts, error)
-	GetProductByID(id int) (*models.Product, error)
-	GetProductsByCategoryID(categoryID int) ([]models.Product, error)
-	CreateProduct(product models.Product) (*models.Product, error)
-	UpdateProduct(id int, product models.Product) (*models.Product, error)
-	DeleteProduct(id int) error
+	GetAllProducts(ctx context.Context, params models.ProductListParams) (*models.PaginatedProducts, error)
+	GetProductByID(ctx context.Context, id int) (*models.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*models.Product, error)
+	GetProductsByCategoryID(ctx context.Context, categoryID int, includeDescendants bool) ([]models.Product, error)
+	CreateProduct(ctx context.Context, product models.Product, actor models.Actor) (*models.Product, error)
+	UpdateProduct(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id int) error
+	ValidateProduct(ctx context.Context, product models.Product) error
+	GetProductRelations(ctx context.Context, productID int, relationType string) ([]models.ProductRelation, error)
+	AddProductRelation(ctx context.Context, productID int, input models.ProductRelationInput) (*models.ProductRelation, error)
+	RemoveProductRelation(ctx context.Context, productID, relatedProductID int, relationType string) error
+	GetPriceHistory(ctx context.Context, productID int) ([]models.PriceChange, error)
 }
 
 // productService implements ProductService interface
 type productService struct {
 	repo         repositories.ProductRepository
 	categoryRepo repositories.CategoryRepository
+	relationRepo repositories.ProductRelationRepository
+	supplierRepo repositories.SupplierRepository
+	priceRepo    repositories.PriceChangeRepository
+	events       EventPublisher
 }
 
 // NewProductService creates a new product service instance
-func NewProductService(repo repositories.ProductRepository, categoryRepo repositories.CategoryRepository) ProductService {
+func NewProductService(repo repositories.ProductRepository, categoryRepo repositories.CategoryRepository, relationRepo repositories.ProductRelationRepository, supplierRepo repositories.SupplierRepository, priceRepo repositories.PriceChangeRepository, events EventPublisher) ProductService {
 	return &productService{
 		repo:         repo,
 		categoryRepo: categoryRepo,
+		relationRepo: relationRepo,
+		supplierRepo: supplierRepo,
+		priceRepo:    priceRepo,
+		events:       events,
 	}
 }
 
 // GetAllProducts returns paginated products with optional search/filter
-func (s *productService) GetAllProducts(params models.ProductListParams) (*models.PaginatedProducts, error) {
-	return s.repo.GetAll(params)
+func (s *productService) GetAllProducts(ctx context.Context, params models.ProductListParams) (*models.PaginatedProducts, error) {
+	return s.repo.GetAll(ctx, params)
 }
 
-// GetProductByID returns a product by its ID
-func (s *productService) GetProductByID(id int) (*models.Product, error) {
-	return s.repo.GetByID(id)
+// GetProductByID returns a product by its ID together with its related
+// products (substitutes, accessories, upsells)
+func (s *productService) GetProductByID(ctx context.Context, id int) (*models.Product, error) {
+	product, err := s.repo.GetByID(ctx, id)
+	return s.withRelations(ctx, product, err)
 }
 
-// CreateProduct validates and creates a new product
-func (s *productService) CreateProduct(product models.Product) (*models.Product, error) {
-	// Business logic validation
-	if product.Name == "" {
-		return nil, errors.New("product name is required")
+// GetProductBySlug returns a product by its slug together with its related products
+func (s *productService) GetProductBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	product, err := s.repo.GetBySlug(ctx, slug)
+	return s.withRelations(ctx, product, err)
+}
+
+// withRelations attaches related products to a product lookup result
+func (s *productService) withRelations(ctx context.Context, product *models.Product, err error) (*models.Product, error) {
+	if err != nil || product == nil {
+		return product, err
 	}
 
-	if product.Price < 0 {
-		return nil, errors.New("product price cannot be negative")
+	relations, err := s.relationRepo.GetByProductID(ctx, product.ID, "")
+	if err != nil {
+		return nil, err
 	}
+	product.Relations = relations
 
+	return product, nil
+}
+
+// ValidateProduct applies the business rules shared by product creation and
+// update. It reports every invalid field at once as helpers.FieldErrors.
+func (s *productService) ValidateProduct(ctx context.Context, product models.Product) error {
+	var errs helpers.FieldErrors
+	if product.Name == "" {
+		errs.Add("name", "required", "name is required")
+	}
+	if product.Price < 0 {
+		errs.Add("price", "min", "price must be at least 0")
+	}
 	if product.Stock < 0 {
-		return nil, errors.New("product stock cannot be negative")
+		errs.Add("stock", "min", "stock must be at least 0")
+	}
+	if product.MinStock < 0 {
+		errs.Add("min_stock", "min", "min_stock must be at least 0")
+	}
+	if product.CostPrice < 0 {
+		errs.Add("cost_price", "min", "cost_price must be at least 0")
 	}
 
 	// Validate category exists if category_id is provided
 	if product.CategoryID != nil {
-		category, err := s.categoryRepo.GetByID(*product.CategoryID)
+		category, err := s.categoryRepo.GetByID(ctx, *product.CategoryID)
 		if err != nil {
-			return nil, errors.New("failed to validate category")
+			return errors.New("failed to validate category")
 		}
 		if category == nil {
-			return nil, errors.New("category not found")
+			errs.Add("category_id", "exists", "category_id does not match a category")
 		}
 	}
 
-	return s.repo.Create(product)
-}
-
-// UpdateProduct validates and updates an existing product
-func (s *productService) UpdateProduct(id int, product models.Product) (*models.Product, error) {
-	// Business logic validation
-	if product.Name == "" {
-		return nil, errors.New("product name is required")
+	if product.SupplierID != nil {
+		supplier, err := s.supplierRepo.GetByID(ctx, *product.SupplierID)
+		if err != nil {
+			return errors.New("failed to validate supplier")
+		}
+		if supplier == nil {
+			errs.Add("supplier_id", "exists", "supplier_id does not match a supplier")
+		}
 	}
 
-	if product.Price < 0 {
-		return nil, errors.New("product price cannot be negative")
+	if product.IsConsignment {
+		if product.SupplierID == nil {
+			errs.Add("supplier_id", "required", "supplier_id is required for consignment products")
+		}
+		if product.ConsignmentCost < 0 {
+			errs.Add("consignment_cost", "min", "consignment_cost must be at least 0")
+		}
+	} else if product.ConsignmentCost != 0 {
+		errs.Add("consignment_cost", "consignment", "consignment_cost is only allowed on consignment products")
 	}
 
-	if product.Stock < 0 {
-		return nil, errors.New("product stock cannot be negative")
+	return errs.Err()
+}
+
+// CreateProduct validates and creates a new product. actor is recorded as the
+// author of its opening price.
+func (s *productService) CreateProduct(ctx context.Context, product models.Product, actor models.Actor) (*models.Product, error) {
+	if err := s.ValidateProduct(ctx, product); err != nil {
+		return nil, err
 	}
 
-	// Validate category exists if category_id is provided
-	if product.CategoryID != nil {
-		category, err := s.categoryRepo.GetByID(*product.CategoryID)
-		if err != nil {
-			return nil, errors.New("failed to validate category")
-		}
-		if category == nil {
-			return nil, errors.New("category not found")
-		}
+	return s.repo.Create(ctx, product, actor)
+}
+
+// UpdateProduct validates and updates an existing product. A price change is
+// recorded in the price history under actor, and product.updated is published.
+func (s *productService) UpdateProduct(ctx context.Context, id int, product models.Product, actor models.Actor) (*models.Product, error) {
+	if err := s.ValidateProduct(ctx, product); err != nil {
+		return nil, err
 	}
 
-	updated, err := s.repo.Update(id, product)
+	updated, err := s.repo.Update(ctx, id, product, actor)
 	if err != nil {
 		return nil, err
 	}
 
 	if updated == nil {
-		return nil, errors.New("product not found")
+		return nil, helpers.NewNotFoundError("product not found")
 	}
 
+	go s.events.Publish(context.WithoutCancel(ctx), models.WebhookEventProductUpdated, *updated)
 	return updated, nil
 }
 
 // DeleteProduct removes a product by its ID
-func (s *productService) DeleteProduct(id int) error {
-	return s.repo.Delete(id)
+func (s *productService) DeleteProduct(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
 }
 
-// GetProductsByCategoryID returns all products belonging to a category
-func (s *productService) GetProductsByCategoryID(categoryID int) ([]models.Product, error) {
+// GetProductsByCategoryID returns all products belonging to a category,
+// optionally including products in all of its subcategories
+func (s *productService) GetProductsByCategoryID(ctx context.Context, categoryID int, includeDescendants bool) ([]models.Product, error) {
 	if categoryID <= 0 {
-		return nil, errors.New("invalid category ID")
+		return nil, helpers.NewValidationError("invalid category ID")
+	}
+	if !includeDescendants {
+		return s.repo.GetByCategoryID(ctx, categoryID)
+	}
+
+	ids, err := s.categoryRepo.GetDescendantIDs(ctx, categoryID)
+	if err != nil {
+		return nil, err
 	}
-	return s.repo.GetByCategoryID(categoryID)
+	return s.repo.GetByCategoryIDs(ctx, ids)
+}
+
+// isValidRelationType reports whether t is a supported product relation type
+func isValidRelationType(t string) bool {
+	switch t {
+	case models.RelationTypeSubstitute, models.RelationTypeAccessory, models.RelationTypeUpsell:
+		return true
+	}
+	return false
+}
+
+// GetProductRelations returns the related products of a product, optionally filtered by type
+func (s *productService) GetProductRelations(ctx context.Context, productID int, relationType string) ([]models.ProductRelation, error) {
+	if relationType != "" && !isValidRelationType(relationType) {
+		return nil, helpers.NewValidationError("relation type must be 'substitute', 'accessory' or 'upsell'")
+	}
+
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, helpers.NewNotFoundError("product not found")
+	}
+
+	return s.relationRepo.GetByProductID(ctx, productID, relationType)
+}
+
+// AddProductRelation validates both products and links them with the given relation type
+func (s *productService) AddProductRelation(ctx context.Context, productID int, input models.ProductRelationInput) (*models.ProductRelation, error) {
+	if !isValidRelationType(input.Type) {
+		return nil, helpers.NewValidationError("relation type must be 'substitute', 'accessory' or 'upsell'")
+	}
+	if input.RelatedProductID == productID {
+		return nil, helpers.NewValidationError("a product cannot be related to itself")
+	}
+
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, helpers.NewNotFoundError("product not found")
+	}
+
+	related, err := s.repo.GetByID(ctx, input.RelatedProductID)
+	if err != nil {
+		return nil, err
+	}
+	if related == nil {
+		return nil, helpers.NewValidationError("related product not found")
+	}
+
+	return s.relationRepo.Create(ctx, productID, input.RelatedProductID, input.Type)
+}
+
+// RemoveProductRelation unlinks two products for the given relation type
+func (s *productService) RemoveProductRelation(ctx context.Context, productID, relatedProductID int, relationType string) error {
+	if !isValidRelationType(relationType) {
+		return helpers.NewValidationError("relation type must be 'substitute', 'accessory' or 'upsell'")
+	}
+	return s.relationRepo.Delete(ctx, productID, relatedProductID, relationType)
+}
+
+// ApplyMargin sets the margin percentage of a product from its unit cost: the
+// consignment cost for consigned products, cost_price otherwise. A product
+// without a known cost or price is left without a margin.
+func ApplyMargin(product *models.Product) {
+	cost := product.CostPrice
+	if product.IsConsignment {
+		cost = product.ConsignmentCost
+	}
+	if cost <= 0 || product.Price <= 0 {
+		product.MarginPercent = nil
+		return
+	}
+	margin := marginPercent(product.Price-cost, product.Price)
+	product.MarginPercent = &margin
+}
+
+// GetPriceHistory returns every price a product has had, newest first
+func (s *productService) GetPriceHistory(ctx context.Context, productID int) ([]models.PriceChange, error) {
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, helpers.NewNotFoundError("product not found")
+	}
+
+	return s.priceRepo.GetByProductID(ctx, productID)
 }
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+)
+
+// PromotionService defines the interface for promotion business logic
+type PromotionService interface {
+	GetAllPromotions(ctx context.Context) ([]models.Promotion, error)
+	GetPromotionByID(ctx context.Context, id int) (*models.Promotion, error)
+	CreatePromotion(ctx context.Context, promotion models.Promotion) (*models.Promotion, error)
+	UpdatePromotion(ctx context.Context, id int, promotion models.Promotion) (*models.Promotion, error)
+	DeletePromotion(ctx context.Context, id int) error
+	GetPerformanceReport(ctx context.Context, startDate, endDate string, storeID int) (*models.PromotionPerformanceReport, error)
+}
+
+// promotionService implements PromotionService interface
+type promotionService struct {
+	repo         repositories.PromotionRepository
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+// NewPromotionService creates a new promotion service instance
+func NewPromotionService(repo repositories.PromotionRepository, productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository) PromotionService {
+	return &promotionService{
+		repo:         repo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// GetAllPromotions returns all promotions
+func (s *promotionService) GetAllPromotions(ctx context.Context) ([]models.Promotion, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetPromotionByID returns a promotion by its ID
+func (s *promotionService) GetPromotionByID(ctx context.Context, id int) (*models.Promotion, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreatePromotion validates and creates a new promotion
+func (s *promotionService) CreatePromotion(ctx context.Context, promotion models.Promotion) (*models.Promotion, error) {
+	if err := s.validate(ctx, &promotion); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, promotion)
+}
+
+// UpdatePromotion validates and updates an existing promotion
+func (s *promotionService) UpdatePromotion(ctx context.Context, id int, promotion models.Promotion) (*models.Promotion, error) {
+	if err := s.validate(ctx, &promotion); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, id, promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, helpers.NewNotFoundError("promotion not found")
+	}
+
+	return updated, nil
+}
+
+// DeletePromotion removes a promotion by its ID
+func (s *promotionService) DeletePromotion(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// GetPerformanceReport returns how every promotion performed over its
+// validity window, optionally clipped to a date range and one store
+func (s *promotionService) GetPerformanceReport(ctx context.Context, startDate, endDate string, storeID int) (*models.PromotionPerformanceReport, error) {
+	var start, end time.Time
+	var err error
+	if startDate != "" {
+		if start, err = time.Parse("2006-01-02", startDate); err != nil {
+			return nil, helpers.NewValidationError("start_date must be in YYYY-MM-DD format")
+		}
+	}
+	if endDate != "" {
+		if end, err = time.Parse("2006-01-02", endDate); err != nil {
+			return nil, helpers.NewValidationError("end_date must be in YYYY-MM-DD format")
+		}
+	}
+	if startDate != "" && endDate != "" && end.Before(start) {
+		return nil, helpers.NewValidationError("end_date must not be before start_date")
+	}
+
+	promotions, err := s.repo.GetPerformance(ctx, startDate, endDate, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PromotionPerformanceReport{StartDate: startDate, EndDate: endDate, StoreID: storeID, Promotions: promotions}
+	for _, p := range promotions {
+		report.TotalUsage += p.UsageCount
+		report.TotalDiscountGiven += p.DiscountGiven
+		report.TotalAttachedRevenue += p.AttachedRevenue
+	}
+	return report, nil
+}
+
+// validate checks the rule parameters required by each promotion type and
+// fills in defaults (BOGO defaults to buy 1 get 1).
+func (s *promotionService) validate(ctx context.Context, promotion *models.Promotion) error {
+	if promotion.Name == "" {
+		return helpers.NewValidationError("promotion name is required")
+	}
+
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && promotion.EndsAt.Before(*promotion.StartsAt) {
+		return helpers.NewValidationError("ends_at must be after starts_at")
+	}
+
+	switch promotion.Type {
+	case models.PromotionTypeBOGO:
+		if promotion.ProductID == nil {
+			return helpers.NewValidationError("product_id is required for bogo promotions")
+		}
+		if promotion.BuyQty <= 0 {
+			promotion.BuyQty = 1
+		}
+		if promotion.GetQty <= 0 {
+			promotion.GetQty = 1
+		}
+		promotion.CategoryID = nil
+	case models.PromotionTypeBundlePrice:
+		if promotion.ProductID == nil {
+			return helpers.NewValidationError("product_id is required for bundle_price promotions")
+		}
+		if promotion.BundleQty < 2 {
+			return helpers.NewValidationError("bundle_qty must be at least 2")
+		}
+		if promotion.BundlePrice <= 0 {
+			return helpers.NewValidationError("bundle_price must be greater than 0")
+		}
+		promotion.CategoryID = nil
+	case models.PromotionTypeCategoryDiscount:
+		if promotion.CategoryID == nil {
+			return helpers.NewValidationError("category_id is required for category_discount promotions")
+		}
+		if promotion.DiscountPercent <= 0 || promotion.DiscountPercent > 100 {
+			return helpers.NewValidationError("discount_percent must be between 1 and 100")
+		}
+		promotion.ProductID = nil
+	default:
+		return helpers.NewValidationError("promotion type must be 'bogo', 'bundle_price' or 'category_discount'")
+	}
+
+	if promotion.ProductID != nil {
+		product, err := s.productRepo.GetByID(ctx, *promotion.ProductID)
+		if err != nil {
+			return errors.New("failed to validate product")
+		}
+		if product == nil {
+			return helpers.NewValidationError("product not found")
+		}
+	}
+
+	if promotion.CategoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, *promotion.CategoryID)
+		if err != nil {
+			return errors.New("failed to validate category")
+		}
+		if category == nil {
+			return helpers.NewValidationError("category not found")
+		}
+	}
+
+	return nil
+}
+
+// applyPromotions evaluates the active promotion rules against priced checkout
+// lines. Product-level rules (BOGO, bundle price) run first and count every
+// unit of a product in the cart, however it is split across lines; category
+// discounts are then taken off whatever remains of each line, so a line can
+// never go below zero. Discount, Subtotal and Promotions are set on each detail.
+func applyPromotions(details []models.TransactionDetail, promotions []models.Promotion) {
+	remaining := make([]int, len(details))
+	for i := range details {
+		remaining[i] = details[i].UnitPrice * details[i].Quantity
+		details[i].Promotions = make([]models.AppliedPromotion, 0)
+	}
+
+	apply := func(i int, p models.Promotion, discount int) {
+		if discount > remaining[i] {
+			discount = remaining[i]
+		}
+		if discount <= 0 {
+			return
+		}
+		remaining[i] -= discount
+		details[i].Promotions = append(details[i].Promotions, models.AppliedPromotion{
+			PromotionID: p.ID,
+			Name:        p.Name,
+			Type:        p.Type,
+			Discount:    discount,
+		})
+	}
+
+	// The lines of each product, in the order the products first appear
+	productIDs := make([]int, 0)
+	lines := make(map[int][]int)
+	for i, d := range details {
+		if _, ok := lines[d.ProductID]; !ok {
+			productIDs = append(productIDs, d.ProductID)
+		}
+		lines[d.ProductID] = append(lines[d.ProductID], i)
+	}
+
+	for _, productID := range productIDs {
+		idx := lines[productID]
+		quantity := 0
+		for _, i := range idx {
+			quantity += details[i].Quantity
+		}
+
+		for _, p := range promotions {
+			if p.ProductID == nil || *p.ProductID != productID {
+				continue
+			}
+			switch p.Type {
+			case models.PromotionTypeBOGO:
+				group := p.BuyQty + p.GetQty
+				if group <= 0 {
+					continue
+				}
+				// The free units are taken from the lines in order
+				free := (quantity / group) * p.GetQty
+				for _, i := range idx {
+					units := min(free, details[i].Quantity)
+					free -= units
+					apply(i, p, units*details[i].UnitPrice)
+				}
+			case models.PromotionTypeBundlePrice:
+				if p.BundleQty <= 0 {
+					continue
+				}
+				// The bundled units are taken from the lines in order and
+				// the bundle discount is shared by their value
+				bundles := quantity / p.BundleQty
+				units := bundles * p.BundleQty
+				values := make([]int, len(idx))
+				value := 0
+				for k, i := range idx {
+					taken := min(units, details[i].Quantity)
+					units -= taken
+					values[k] = taken * details[i].UnitPrice
+					value += values[k]
+				}
+				discount := value - bundles*p.BundlePrice
+				if discount <= 0 {
+					continue
+				}
+				shared, cumulative := 0, 0
+				for k, i := range idx {
+					cumulative += values[k]
+					share := discount*cumulative/value - shared
+					shared += share
+					apply(i, p, share)
+				}
+			}
+		}
+	}
+
+	for i := range details {
+		d := &details[i]
+		for _, p := range promotions {
+			if p.Type != models.PromotionTypeCategoryDiscount {
+				continue
+			}
+			if p.CategoryID == nil || d.CategoryID == nil || *p.CategoryID != *d.CategoryID {
+				continue
+			}
+			apply(i, p, remaining[i]*p.DiscountPercent/100)
+		}
+
+		gross := d.UnitPrice * d.Quantity
+		d.Discount = gross - remaining[i]
+		d.Subtotal = remaining[i]
+	}
+}
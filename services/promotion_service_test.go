@@ -0,0 +1,97 @@
+package services
+
+import (
+	"retail-core-api/models"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+// discounts returns the discount of each line
+func discounts(details []models.TransactionDetail) []int {
+	out := make([]int, len(details))
+	for i, d := range details {
+		out[i] = d.Discount
+	}
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestApplyPromotions(t *testing.T) {
+	bogo := models.Promotion{ID: 1, Name: "Buy 1 get 1", Type: models.PromotionTypeBOGO, ProductID: intPtr(3), BuyQty: 1, GetQty: 1}
+	bundle := models.Promotion{ID: 2, Name: "3 for 10000", Type: models.PromotionTypeBundlePrice, ProductID: intPtr(3), BundleQty: 3, BundlePrice: 10000}
+	category := models.Promotion{ID: 3, Name: "Beverages 10%", Type: models.PromotionTypeCategoryDiscount, CategoryID: intPtr(2), DiscountPercent: 10}
+
+	line := func(productID, quantity, unitPrice int) models.TransactionDetail {
+		return models.TransactionDetail{ProductID: productID, Quantity: quantity, UnitPrice: unitPrice, CategoryID: intPtr(2)}
+	}
+
+	tests := []struct {
+		name       string
+		details    []models.TransactionDetail
+		promotions []models.Promotion
+		want       []int
+	}{
+		{
+			name:       "bogo on one line",
+			details:    []models.TransactionDetail{line(3, 2, 4000)},
+			promotions: []models.Promotion{bogo},
+			want:       []int{4000},
+		},
+		{
+			name:       "bogo across two lines of the same product",
+			details:    []models.TransactionDetail{line(3, 1, 4000), line(3, 1, 4000)},
+			promotions: []models.Promotion{bogo},
+			want:       []int{4000, 0},
+		},
+		{
+			name:       "bogo ignores other products",
+			details:    []models.TransactionDetail{line(3, 1, 4000), line(4, 1, 4000)},
+			promotions: []models.Promotion{bogo},
+			want:       []int{0, 0},
+		},
+		{
+			name:       "bundle across three lines shared by value",
+			details:    []models.TransactionDetail{line(3, 1, 4000), line(4, 5, 1000), line(3, 2, 4000)},
+			promotions: []models.Promotion{bundle},
+			want:       []int{666, 0, 1334},
+		},
+		{
+			name:       "bundle leftover units pay full price",
+			details:    []models.TransactionDetail{line(3, 2, 4000), line(3, 2, 4000)},
+			promotions: []models.Promotion{bundle},
+			want:       []int{1333, 667},
+		},
+		{
+			name:       "category discount on what remains",
+			details:    []models.TransactionDetail{line(3, 1, 4000), line(3, 1, 4000)},
+			promotions: []models.Promotion{bogo, category},
+			want:       []int{4000, 400},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyPromotions(tt.details, tt.promotions)
+			if got := discounts(tt.details); !equalInts(got, tt.want) {
+				t.Fatalf("discounts = %v, want %v", got, tt.want)
+			}
+			for _, d := range tt.details {
+				if d.Subtotal != d.UnitPrice*d.Quantity-d.Discount || d.Subtotal < 0 {
+					t.Fatalf("line %+v: subtotal does not match its discount", d)
+				}
+			}
+		})
+	}
+}
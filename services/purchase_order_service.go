@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+)
+
+// PurchaseOrderService defines the interface for purchase order business logic
+type PurchaseOrderService interface {
+	GetPurchaseOrders(ctx context.Context, status string, supplierID int) ([]models.PurchaseOrder, error)
+	GetPurchaseOrderByID(ctx context.Context, id int) (*models.PurchaseOrder, error)
+	CreatePurchaseOrder(ctx context.Context, input models.PurchaseOrderInput, actor models.Actor) (*models.PurchaseOrder, error)
+	ReceiveGoods(ctx context.Context, id int, input models.ReceiveInput, actor models.Actor) (*models.GoodsReceipt, error)
+	CancelPurchaseOrder(ctx context.Context, id int) (*models.PurchaseOrder, error)
+}
+
+// purchaseOrderService implements PurchaseOrderService interface
+type purchaseOrderService struct {
+	repo         repositories.PurchaseOrderRepository
+	supplierRepo repositories.SupplierRepository
+	productRepo  repositories.ProductRepository
+}
+
+// NewPurchaseOrderService creates a new purchase order service instance
+func NewPurchaseOrderService(repo repositories.PurchaseOrderRepository, supplierRepo repositories.SupplierRepository, productRepo repositories.ProductRepository) PurchaseOrderService {
+	return &purchaseOrderService{
+		repo:         repo,
+		supplierRepo: supplierRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// GetPurchaseOrders returns purchase orders, optionally filtered by status and supplier
+func (s *purchaseOrderService) GetPurchaseOrders(ctx context.Context, status string, supplierID int) ([]models.PurchaseOrder, error) {
+	switch status {
+	case "", models.PurchaseOrderOpen, models.PurchaseOrderPartiallyReceived,
+		models.PurchaseOrderReceived, models.PurchaseOrderCancelled:
+	default:
+		return nil, helpers.NewValidationError("status must be 'open', 'partially_received', 'received' or 'cancelled'")
+	}
+	return s.repo.GetAll(ctx, status, supplierID)
+}
+
+// GetPurchaseOrderByID returns a purchase order with its items and receipts
+func (s *purchaseOrderService) GetPurchaseOrderByID(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreatePurchaseOrder validates the supplier and products and opens a purchase order
+func (s *purchaseOrderService) CreatePurchaseOrder(ctx context.Context, input models.PurchaseOrderInput, actor models.Actor) (*models.PurchaseOrder, error) {
+	supplier, err := s.supplierRepo.GetByID(ctx, input.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil {
+		return nil, helpers.NewValidationError("supplier not found")
+	}
+
+	seen := make(map[int]bool)
+	items := make([]models.PurchaseOrderItem, 0, len(input.Items))
+	for _, line := range input.Items {
+		if seen[line.ProductID] {
+			return nil, helpers.NewValidationError(fmt.Sprintf("product %d must only appear once", line.ProductID))
+		}
+		seen[line.ProductID] = true
+
+		product, err := s.productRepo.GetByID(ctx, line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, helpers.NewValidationError(fmt.Sprintf("product %d not found", line.ProductID))
+		}
+
+		items = append(items, models.PurchaseOrderItem{
+			ProductID:       line.ProductID,
+			QuantityOrdered: line.Quantity,
+			UnitCost:        line.UnitCost,
+		})
+	}
+
+	return s.repo.Create(ctx, models.PurchaseOrder{
+		SupplierID: input.SupplierID,
+		Note:       input.Note,
+		CreatedBy:  actor.UserID,
+	}, items)
+}
+
+// ReceiveGoods books a full or partial delivery against a purchase order.
+// Lines without a unit cost are received at the cost on the order.
+func (s *purchaseOrderService) ReceiveGoods(ctx context.Context, id int, input models.ReceiveInput, actor models.Actor) (*models.GoodsReceipt, error) {
+	order, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, helpers.NewNotFoundError("purchase order not found")
+	}
+
+	orderedCost := make(map[int]int, len(order.Items))
+	for _, item := range order.Items {
+		orderedCost[item.ProductID] = item.UnitCost
+	}
+
+	seen := make(map[int]bool)
+	lines := make([]models.GoodsReceiptLine, 0, len(input.Items))
+	for _, line := range input.Items {
+		if seen[line.ProductID] {
+			return nil, helpers.NewValidationError(fmt.Sprintf("product %d must only appear once", line.ProductID))
+		}
+		seen[line.ProductID] = true
+
+		cost, ok := orderedCost[line.ProductID]
+		if !ok {
+			return nil, helpers.NewValidationError(fmt.Sprintf("product %d is not part of this purchase order", line.ProductID))
+		}
+		if line.UnitCost != nil {
+			cost = *line.UnitCost
+		}
+
+		lines = append(lines, models.GoodsReceiptLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  cost,
+		})
+	}
+
+	return s.repo.Receive(ctx, id, models.GoodsReceipt{
+		Note:       input.Note,
+		ReceivedBy: actor.UserID,
+		Lines:      lines,
+	})
+}
+
+// CancelPurchaseOrder cancels an open or partially received purchase order
+func (s *purchaseOrderService) CancelPurchaseOrder(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	cancelled, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cancelled == nil {
+		order, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if order == nil {
+			return nil, helpers.NewNotFoundError("purchase order not found")
+		}
+		return nil, helpers.NewConflictError(fmt.Sprintf("purchase order is already %s", order.Status))
+	}
+	return cancelled, nil
+}
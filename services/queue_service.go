@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sync"
+)
+
+// QueueService defines the interface for the pickup queue and its display feed
+type QueueService interface {
+	GetStatus(ctx context.Context) (*models.QueueStatus, error)
+	CallNext(ctx context.Context) (*models.QueueStatus, error)
+	Call(ctx context.Context, queueNo int) (*models.QueueStatus, error)
+	Subscribe() (<-chan models.QueueStatus, func())
+}
+
+// queueService implements QueueService interface. Display subscribers are
+// kept in memory, so updates reach displays connected to this instance.
+type queueService struct {
+	repo repositories.QueueRepository
+
+	mu          sync.Mutex
+	subscribers map[chan models.QueueStatus]struct{}
+}
+
+// NewQueueService creates a new queue service instance
+func NewQueueService(repo repositories.QueueRepository) QueueService {
+	return &queueService{
+		repo:        repo,
+		subscribers: make(map[chan models.QueueStatus]struct{}),
+	}
+}
+
+// GetStatus returns today's queue status
+func (s *queueService) GetStatus(ctx context.Context) (*models.QueueStatus, error) {
+	return s.repo.GetStatus(ctx)
+}
+
+// CallNext calls the next waiting queue number and notifies displays
+func (s *queueService) CallNext(ctx context.Context) (*models.QueueStatus, error) {
+	status, err := s.repo.CallNext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, helpers.NewConflictError("no queue numbers are waiting")
+	}
+	s.publish(*status)
+	return status, nil
+}
+
+// Call announces a specific queue number issued today and notifies displays
+func (s *queueService) Call(ctx context.Context, queueNo int) (*models.QueueStatus, error) {
+	if queueNo <= 0 {
+		return nil, helpers.NewValidationError("queue_no must be greater than 0")
+	}
+	status, err := s.repo.Call(ctx, queueNo)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, helpers.NewValidationError("queue number has not been issued today")
+	}
+	s.publish(*status)
+	return status, nil
+}
+
+// Subscribe registers a display for "now serving" updates. The returned
+// function must be called to unsubscribe when the display disconnects.
+func (s *queueService) Subscribe() (<-chan models.QueueStatus, func()) {
+	ch := make(chan models.QueueStatus, 4)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}
+}
+
+// publish sends a status update to every display, skipping displays that
+// are not keeping up rather than blocking the caller
+func (s *queueService) publish(status models.QueueStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- status:
+		default:
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// receiptShareTTL is how long a shared receipt link stays valid
+const receiptShareTTL = 24 * time.Hour
+
+// ReceiptService defines the interface for customer-facing receipt logic
+type ReceiptService interface {
+	CreateShareLink(ctx context.Context, transactionID int) (*models.ReceiptShareLink, error)
+	GetSharedReceipt(ctx context.Context, token string) (*models.Receipt, error)
+	GetReceipt(ctx context.Context, transactionID int) (*models.Receipt, error)
+}
+
+// receiptService implements ReceiptService interface
+type receiptService struct {
+	transactionRepo repositories.TransactionRepository
+	archive         TransactionArchiveService
+	signingKey      []byte
+	tenantID        int
+	baseURL         string
+	storeName       string
+	taxRate         float64
+}
+
+// NewReceiptService creates a new receipt service instance. Share tokens are
+// signed with a key derived from the JWT secret so they can never be used as
+// API access tokens, and name the tenant so the public link reaches it.
+func NewReceiptService(transactionRepo repositories.TransactionRepository, archive TransactionArchiveService, jwtSecret string, tenantID int, baseURL, storeName string, taxRate float64) ReceiptService {
+	return &receiptService{
+		transactionRepo: transactionRepo,
+		archive:         archive,
+		signingKey:      []byte("receipt-share:" + jwtSecret),
+		tenantID:        tenantID,
+		baseURL:         baseURL,
+		storeName:       storeName,
+		taxRate:         taxRate,
+	}
+}
+
+// CreateShareLink issues a signed, expiring public URL for a transaction receipt
+func (s *receiptService) CreateShareLink(ctx context.Context, transactionID int) (*models.ReceiptShareLink, error) {
+	if transactionID <= 0 {
+		return nil, helpers.NewValidationError("invalid transaction ID")
+	}
+
+	transaction, err := findTransaction(ctx, s.transactionRepo, s.archive, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(receiptShareTTL)
+	claims := jwt.MapClaims{
+		"transaction_id": transaction.ID,
+		"tenant_id":      s.tenantID,
+		"exp":            expiresAt.Unix(),
+		"iat":            time.Now().Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	if err != nil {
+		return nil, errors.New("failed to generate share token")
+	}
+
+	return &models.ReceiptShareLink{
+		TransactionID: transaction.ID,
+		ReceiptNo:     transaction.ReceiptNo,
+		Token:         token,
+		URL:           fmt.Sprintf("%s/receipts/%s", s.baseURL, token),
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// GetSharedReceipt validates a share token and returns the receipt it points to
+func (s *receiptService) GetSharedReceipt(ctx context.Context, token string) (*models.Receipt, error) {
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return s.signingKey, nil
+	})
+	if err != nil || !parsed.Valid {
+		return nil, helpers.NewNotFoundError("receipt link is invalid or expired")
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, helpers.NewNotFoundError("receipt link is invalid or expired")
+	}
+	transactionID, ok := claims["transaction_id"].(float64)
+	if !ok || helpers.ClaimTenantID(claims) != s.tenantID {
+		return nil, helpers.NewNotFoundError("receipt link is invalid or expired")
+	}
+
+	transaction, err := findTransaction(ctx, s.transactionRepo, s.archive, int(transactionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildReceipt(transaction), nil
+}
+
+// GetReceipt returns the printable receipt for a transaction
+func (s *receiptService) GetReceipt(ctx context.Context, transactionID int) (*models.Receipt, error) {
+	if transactionID <= 0 {
+		return nil, helpers.NewValidationError("invalid transaction ID")
+	}
+
+	transaction, err := findTransaction(ctx, s.transactionRepo, s.archive, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildReceipt(transaction), nil
+}
+
+// buildReceipt wraps a transaction with the values a receipt template needs
+func (s *receiptService) buildReceipt(transaction *models.Transaction) *models.Receipt {
+	subtotal := 0
+	for _, d := range transaction.Details {
+		subtotal += d.Subtotal
+	}
+
+	// Prices are tax-inclusive, so the tax is the share of the total above the net amount
+	tax := 0
+	if s.taxRate > 0 {
+		tax = int(math.Round(float64(transaction.TotalAmount) * s.taxRate / (100 + s.taxRate)))
+	}
+
+	return &models.Receipt{
+		StoreName:   s.storeName,
+		Transaction: *transaction,
+		Subtotal:    subtotal,
+		TaxRate:     s.taxRate,
+		Tax:         tax,
+	}
+}
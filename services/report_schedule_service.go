@@ -0,0 +1,622 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"path"
+	"retail-core-api/chaos"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"retail-core-api/storage"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reportUploadTimeout bounds rendering and uploading one report file
+const reportUploadTimeout = 5 * time.Minute
+
+// scheduledReports lists the reports that can be scheduled and whether they
+// cover a date period and can be scoped to one store
+var scheduledReports = map[string]struct{ periodic, storeScoped bool }{
+	models.ScheduledReportSalesSummary:       {periodic: true, storeScoped: true},
+	models.ScheduledReportProfit:             {periodic: true, storeScoped: true},
+	models.ScheduledReportStoreSales:         {periodic: true},
+	models.ScheduledReportConsignment:        {periodic: true},
+	models.ScheduledReportLowStock:           {},
+	models.ScheduledReportInventoryValuation: {},
+}
+
+// ReportFailureNotifier is notified when a scheduled report run fails
+type ReportFailureNotifier interface {
+	NotifyFailed(schedule models.ReportSchedule, run models.ReportRun)
+}
+
+// logReportNotifier reports failed runs through the application log
+type logReportNotifier struct{}
+
+// NewLogReportNotifier creates a notifier that writes failed report runs to the log
+func NewLogReportNotifier() ReportFailureNotifier {
+	return logReportNotifier{}
+}
+
+// NotifyFailed logs a failed report run
+func (logReportNotifier) NotifyFailed(schedule models.ReportSchedule, run models.ReportRun) {
+	slog.Warn("report run failed", "component", "report-schedule",
+		"run_id", run.ID, "schedule", schedule.Name, "report", schedule.Report, "target", schedule.Target, "error", run.Error)
+}
+
+// webhookReportNotifier logs failed runs and POSTs them to a webhook
+type webhookReportNotifier struct {
+	webhook notificationWebhook
+}
+
+// NewWebhookReportNotifier creates a notifier that logs failed report runs
+// and POSTs {"event":"report_run_failed","schedule":{...},"run":{...}} to url
+func NewWebhookReportNotifier(url string, injector *chaos.Injector) ReportFailureNotifier {
+	return &webhookReportNotifier{webhook: newNotificationWebhook(url, injector)}
+}
+
+// NotifyFailed logs the failed run and delivers it to the webhook
+func (n *webhookReportNotifier) NotifyFailed(schedule models.ReportSchedule, run models.ReportRun) {
+	logReportNotifier{}.NotifyFailed(schedule, run)
+
+	n.webhook.post(map[string]interface{}{
+		"event":    "report_run_failed",
+		"schedule": schedule,
+		"run":      run,
+	}, "component", "report-schedule", "run_id", run.ID)
+}
+
+// ReportScheduleService defines the interface for scheduled report business logic
+type ReportScheduleService interface {
+	GetSchedules(ctx context.Context) ([]models.ReportSchedule, error)
+	GetScheduleByID(ctx context.Context, id int) (*models.ReportSchedule, error)
+	CreateSchedule(ctx context.Context, input models.ReportScheduleInput, actor models.Actor) (*models.ReportSchedule, error)
+	UpdateSchedule(ctx context.Context, id int, input models.ReportScheduleInput) (*models.ReportSchedule, error)
+	DeleteSchedule(ctx context.Context, id int) error
+	RunSchedule(ctx context.Context, id int) (*models.ReportRun, error)
+	GetRuns(ctx context.Context, id, page, limit int) (*models.PaginatedReportRuns, error)
+	RunDue(ctx context.Context) int
+}
+
+// reportScheduleService implements ReportScheduleService interface
+type reportScheduleService struct {
+	repo               repositories.ReportScheduleRepository
+	storeRepo          repositories.StoreRepository
+	transactionService TransactionService
+	inventoryService   InventoryService
+	storeService       StoreService
+	consignmentService ConsignmentService
+	targets            map[string]storage.Store
+	notifier           ReportFailureNotifier
+}
+
+// NewReportScheduleService creates a new report schedule service instance.
+// targets maps the configured upload targets (local, s3, sftp) to their store.
+func NewReportScheduleService(
+	repo repositories.ReportScheduleRepository,
+	storeRepo repositories.StoreRepository,
+	transactionService TransactionService,
+	inventoryService InventoryService,
+	storeService StoreService,
+	consignmentService ConsignmentService,
+	targets map[string]storage.Store,
+	notifier ReportFailureNotifier,
+) ReportScheduleService {
+	return &reportScheduleService{
+		repo:               repo,
+		storeRepo:          storeRepo,
+		transactionService: transactionService,
+		inventoryService:   inventoryService,
+		storeService:       storeService,
+		consignmentService: consignmentService,
+		targets:            targets,
+		notifier:           notifier,
+	}
+}
+
+// GetSchedules returns every report schedule
+func (s *reportScheduleService) GetSchedules(ctx context.Context) ([]models.ReportSchedule, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetScheduleByID returns a report schedule by its ID
+func (s *reportScheduleService) GetScheduleByID(ctx context.Context, id int) (*models.ReportSchedule, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// scheduleFromInput validates the input and computes the next run
+func (s *reportScheduleService) scheduleFromInput(ctx context.Context, input models.ReportScheduleInput) (models.ReportSchedule, error) {
+	schedule := models.ReportSchedule{
+		Name:       strings.TrimSpace(input.Name),
+		Report:     input.Report,
+		Format:     input.Format,
+		Period:     input.Period,
+		StoreID:    input.StoreID,
+		Cron:       strings.TrimSpace(input.Cron),
+		Timezone:   strings.TrimSpace(input.Timezone),
+		Target:     input.Target,
+		PathPrefix: strings.Trim(strings.TrimSpace(input.PathPrefix), "/"),
+		IsActive:   true,
+	}
+	if input.IsActive != nil {
+		schedule.IsActive = *input.IsActive
+	}
+	if schedule.Name == "" {
+		return schedule, helpers.NewValidationError("name must not be empty")
+	}
+
+	kind, ok := scheduledReports[schedule.Report]
+	if !ok {
+		return schedule, helpers.NewValidationError("report must be one of sales_summary, profit, store_sales, consignment, low_stock, inventory_valuation")
+	}
+	if schedule.Format != models.ReportFormatCSV && schedule.Format != models.ReportFormatPDF {
+		return schedule, helpers.NewValidationError("format must be 'csv' or 'pdf'")
+	}
+	if kind.periodic {
+		if schedule.Period == "" {
+			schedule.Period = models.ReportPeriodYesterday
+		}
+		switch schedule.Period {
+		case models.ReportPeriodYesterday, models.ReportPeriodToday, models.ReportPeriodLast7Days,
+			models.ReportPeriodLastMonth, models.ReportPeriodMonthToDate:
+		default:
+			return schedule, helpers.NewValidationError("period must be one of yesterday, today, last_7_days, last_month, month_to_date")
+		}
+	} else {
+		schedule.Period = ""
+	}
+
+	if schedule.StoreID != nil {
+		if !kind.storeScoped {
+			return schedule, helpers.NewValidationError(fmt.Sprintf("store_id must be empty for %s reports", schedule.Report))
+		}
+		storeID, err := resolveStore(ctx, s.storeRepo, *schedule.StoreID)
+		if err != nil {
+			return schedule, err
+		}
+		schedule.StoreID = &storeID
+	}
+
+	if s.targets[schedule.Target] == nil {
+		return schedule, helpers.NewValidationError(fmt.Sprintf("target %s is not configured", schedule.Target))
+	}
+	if strings.Contains(schedule.PathPrefix, "..") {
+		return schedule, helpers.NewValidationError("invalid path_prefix")
+	}
+
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return schedule, helpers.NewValidationError(fmt.Sprintf("invalid timezone %q", schedule.Timezone))
+	}
+	cron, err := helpers.ParseCron(schedule.Cron)
+	if err != nil {
+		return schedule, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	next := cron.Next(time.Now().In(loc))
+	if next.IsZero() {
+		return schedule, helpers.NewValidationError("invalid cron expression: it never matches")
+	}
+	schedule.NextRunAt = &next
+
+	return schedule, nil
+}
+
+// CreateSchedule creates a report schedule
+func (s *reportScheduleService) CreateSchedule(ctx context.Context, input models.ReportScheduleInput, actor models.Actor) (*models.ReportSchedule, error) {
+	schedule, err := s.scheduleFromInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	schedule.CreatedBy = actor.UserID
+	return s.repo.Create(ctx, schedule)
+}
+
+// UpdateSchedule replaces a report schedule; the next run is recomputed from now
+func (s *reportScheduleService) UpdateSchedule(ctx context.Context, id int, input models.ReportScheduleInput) (*models.ReportSchedule, error) {
+	schedule, err := s.scheduleFromInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, id, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, helpers.NewNotFoundError("report schedule not found")
+	}
+	return updated, nil
+}
+
+// DeleteSchedule deletes a report schedule and its run history
+func (s *reportScheduleService) DeleteSchedule(ctx context.Context, id int) error {
+	err := s.repo.Delete(ctx, id)
+	if err == sql.ErrNoRows {
+		return helpers.NewNotFoundError("report schedule not found")
+	}
+	return err
+}
+
+// RunSchedule renders and uploads a schedule's report now, without moving its
+// next scheduled run. A failed run is returned with its error recorded.
+func (s *reportScheduleService) RunSchedule(ctx context.Context, id int) (*models.ReportRun, error) {
+	schedule, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, helpers.NewNotFoundError("report schedule not found")
+	}
+	return s.run(ctx, *schedule, models.ReportTriggerManual, time.Now())
+}
+
+// GetRuns returns the run history of a schedule, newest first
+func (s *reportScheduleService) GetRuns(ctx context.Context, id, page, limit int) (*models.PaginatedReportRuns, error) {
+	schedule, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, helpers.NewNotFoundError("report schedule not found")
+	}
+	return s.repo.GetRuns(ctx, id, page, limit)
+}
+
+// RunDue runs every active schedule whose next run has passed, advances the
+// schedule and returns how many runs succeeded. Runs missed while the server
+// was down are skipped rather than replayed.
+func (s *reportScheduleService) RunDue(ctx context.Context) int {
+	schedules, err := s.repo.GetDue(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load due schedules", "component", "report-schedule", "error", err)
+		return 0
+	}
+
+	succeeded := 0
+	for _, schedule := range schedules {
+		scheduledAt := time.Now()
+		if schedule.NextRunAt != nil {
+			scheduledAt = *schedule.NextRunAt
+		}
+
+		var next *time.Time
+		loc, locErr := time.LoadLocation(schedule.Timezone)
+		cron, cronErr := helpers.ParseCron(schedule.Cron)
+		if locErr == nil && cronErr == nil {
+			if t := cron.Next(time.Now().In(loc)); !t.IsZero() {
+				next = &t
+			}
+			scheduledAt = scheduledAt.In(loc)
+		}
+
+		claimed, err := s.repo.Advance(ctx, schedule.ID, next)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to advance schedule", "component", "report-schedule", "schedule_id", schedule.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		run, err := s.run(ctx, schedule, models.ReportTriggerSchedule, scheduledAt)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to record run", "component", "report-schedule", "schedule_id", schedule.ID, "error", err)
+			continue
+		}
+		if run.Status == models.ReportRunSucceeded {
+			succeeded++
+		}
+	}
+
+	return succeeded
+}
+
+// run renders a schedule's report for the period ending at the given time,
+// uploads it and records the outcome in the run history
+func (s *reportScheduleService) run(ctx context.Context, schedule models.ReportSchedule, trigger string, at time.Time) (*models.ReportRun, error) {
+	if loc, err := time.LoadLocation(schedule.Timezone); err == nil {
+		at = at.In(loc)
+	}
+	start, end := "", ""
+	if scheduledReports[schedule.Report].periodic {
+		start, end = reportPeriod(schedule.Period, at)
+	}
+
+	run, err := s.repo.CreateRun(ctx, models.ReportRun{
+		ScheduleID:  schedule.ID,
+		Trigger:     trigger,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, size, err := s.renderAndUpload(ctx, schedule, start, end, at)
+	run.Status = models.ReportRunSucceeded
+	run.ObjectKey = key
+	run.SizeBytes = size
+	if err != nil {
+		run.Status = models.ReportRunFailed
+		run.Error = err.Error()
+	}
+
+	finished, finishErr := s.repo.FinishRun(ctx, *run)
+	if finishErr != nil {
+		slog.ErrorContext(ctx, "failed to record run outcome", "component", "report-schedule", "run_id", run.ID, "error", finishErr)
+		finished = run
+	}
+	if finished.Status == models.ReportRunFailed {
+		s.notifier.NotifyFailed(schedule, *finished)
+	}
+	return finished, nil
+}
+
+// renderAndUpload builds the report file and uploads it to the schedule's target
+func (s *reportScheduleService) renderAndUpload(ctx context.Context, schedule models.ReportSchedule, start, end string, at time.Time) (string, int, error) {
+	store := s.targets[schedule.Target]
+	if store == nil {
+		return "", 0, fmt.Errorf("target %s is not configured", schedule.Target)
+	}
+
+	table, err := s.buildReport(ctx, schedule, start, end)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var data []byte
+	if schedule.Format == models.ReportFormatPDF {
+		data = table.pdf(at)
+	} else {
+		if data, err = table.csv(); err != nil {
+			return "", 0, err
+		}
+	}
+
+	name := schedule.Report
+	if start != "" {
+		name += "_" + start + "_" + end
+	}
+	key := path.Join(schedule.PathPrefix, fmt.Sprintf("%s_%s.%s", name, at.Format("20060102T1504"), schedule.Format))
+
+	ctx, cancel := context.WithTimeout(ctx, reportUploadTimeout)
+	defer cancel()
+	if err := store.Put(ctx, key, data); err != nil {
+		return key, 0, fmt.Errorf("upload to %s failed: %w", schedule.Target, err)
+	}
+	return key, len(data), nil
+}
+
+// reportPeriod returns the first and last day covered by a period for a run at t
+func reportPeriod(period string, t time.Time) (string, string) {
+	const layout = "2006-01-02"
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case models.ReportPeriodToday:
+		return today.Format(layout), today.Format(layout)
+	case models.ReportPeriodLast7Days:
+		return today.AddDate(0, 0, -7).Format(layout), today.AddDate(0, 0, -1).Format(layout)
+	case models.ReportPeriodLastMonth:
+		first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return first.AddDate(0, -1, 0).Format(layout), first.AddDate(0, 0, -1).Format(layout)
+	case models.ReportPeriodMonthToDate:
+		first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return first.Format(layout), today.Format(layout)
+	}
+	yesterday := today.AddDate(0, 0, -1)
+	return yesterday.Format(layout), yesterday.Format(layout)
+}
+
+// reportTable is a rendered report: a title, summary lines and a table whose
+// columns are named like the JSON fields of the matching endpoint
+type reportTable struct {
+	title   string
+	summary []string
+	headers []string
+	rows    [][]string
+}
+
+// buildReport loads a schedule's report for a period and flattens it into a table
+func (s *reportScheduleService) buildReport(ctx context.Context, schedule models.ReportSchedule, start, end string) (*reportTable, error) {
+	filter := models.ReportFilter{StartDate: start, EndDate: end}
+	if schedule.StoreID != nil {
+		filter.StoreID = *schedule.StoreID
+	}
+	itoa := strconv.Itoa
+	period := fmt.Sprintf("Period: %s to %s", start, end)
+
+	switch schedule.Report {
+	case models.ScheduledReportSalesSummary:
+		report, err := s.transactionService.GetReportSummary(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		table := &reportTable{
+			title:   "Sales summary",
+			summary: []string{period, "Total revenue: " + itoa(report.TotalRevenue), "Transactions: " + itoa(report.TotalTransactions)},
+			headers: []string{"category_id", "category_name", "revenue", "transactions"},
+		}
+		if report.BestSellingProduct != nil {
+			table.summary = append(table.summary, fmt.Sprintf("Best seller: %s (%d sold)", report.BestSellingProduct.Name, report.BestSellingProduct.QtySold))
+		}
+		for _, c := range report.CategoryBreakdown {
+			table.rows = append(table.rows, []string{itoa(c.CategoryID), c.CategoryName, itoa(c.Revenue), itoa(c.Transactions)})
+		}
+		return table, nil
+
+	case models.ScheduledReportProfit:
+		report, err := s.transactionService.GetProfitReport(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		table := &reportTable{
+			title: "Profit report",
+			summary: []string{
+				period, "Revenue: " + itoa(report.Revenue), "COGS: " + itoa(report.COGS),
+				"Gross profit: " + itoa(report.GrossProfit), fmt.Sprintf("Margin: %.2f%%", report.MarginPercent),
+			},
+			headers: []string{"product_id", "product_name", "category_name", "qty_sold", "revenue", "cogs", "gross_profit", "margin_percent"},
+		}
+		for _, p := range report.Products {
+			table.rows = append(table.rows, []string{
+				itoa(p.ProductID), p.ProductName, p.CategoryName, itoa(p.QtySold), itoa(p.Revenue), itoa(p.COGS),
+				itoa(p.GrossProfit), strconv.FormatFloat(p.MarginPercent, 'f', 2, 64),
+			})
+		}
+		return table, nil
+
+	case models.ScheduledReportStoreSales:
+		report, err := s.storeService.GetSalesReport(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+		table := &reportTable{
+			title:   "Sales by store",
+			summary: []string{period, "Total revenue: " + itoa(report.TotalRevenue), "Transactions: " + itoa(report.TotalTransactions)},
+			headers: []string{"store_id", "store_code", "store_name", "total_revenue", "total_transactions", "items_sold"},
+		}
+		for _, st := range report.Stores {
+			table.rows = append(table.rows, []string{
+				itoa(st.StoreID), st.StoreCode, st.StoreName, itoa(st.TotalRevenue), itoa(st.TotalTransactions), itoa(st.ItemsSold),
+			})
+		}
+		return table, nil
+
+	case models.ScheduledReportConsignment:
+		report, err := s.consignmentService.GetSettlementReport(ctx, start, end, nil)
+		if err != nil {
+			return nil, err
+		}
+		table := &reportTable{
+			title:   "Consignment settlement",
+			summary: []string{period, "Total payable: " + itoa(report.TotalPayable)},
+			headers: []string{"supplier_id", "supplier_name", "units_sold", "sales_amount", "payable_amount", "store_margin"},
+		}
+		for _, c := range report.Suppliers {
+			table.rows = append(table.rows, []string{
+				itoa(c.SupplierID), c.SupplierName, itoa(c.UnitsSold), itoa(c.SalesAmount), itoa(c.PayableAmount), itoa(c.StoreMargin),
+			})
+		}
+		return table, nil
+
+	case models.ScheduledReportLowStock:
+		products, err := s.inventoryService.GetLowStockReport(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		table := &reportTable{
+			title:   "Low stock",
+			summary: []string{fmt.Sprintf("Products below minimum stock: %d", len(products))},
+			headers: []string{"product_id", "name", "sku", "category_name", "stock", "min_stock", "shortfall"},
+		}
+		for _, p := range products {
+			table.rows = append(table.rows, []string{
+				itoa(p.ProductID), p.Name, p.SKU, p.CategoryName, itoa(p.Stock), itoa(p.MinStock), itoa(p.Shortfall),
+			})
+		}
+		return table, nil
+
+	case models.ScheduledReportInventoryValuation:
+		report, err := s.inventoryService.GetInventoryValuation(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		table := &reportTable{
+			title:   "Inventory valuation (" + report.Method + ")",
+			summary: []string{"Total units: " + itoa(report.TotalUnits), "Total value: " + itoa(report.TotalValue)},
+			headers: []string{"product_id", "name", "sku", "category_name", "stock", "costed_qty", "uncosted_qty", "average_cost", "value"},
+		}
+		for _, p := range report.Products {
+			table.rows = append(table.rows, []string{
+				itoa(p.ProductID), p.Name, p.SKU, p.CategoryName, itoa(p.Stock), itoa(p.CostedQty),
+				itoa(p.UncostedQty), itoa(p.AverageCost), itoa(p.Value),
+			})
+		}
+		return table, nil
+	}
+
+	return nil, fmt.Errorf("unknown report %q", schedule.Report)
+}
+
+// csv renders the table as CSV with a header row; the title and summary are
+// left out so BI tools can ingest the file directly
+func (t *reportTable) csv() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(t.headers); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(t.rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pdf renders the title, summary and a fixed-width table as a PDF page
+func (t *reportTable) pdf(generatedAt time.Time) []byte {
+	widths := make([]int, len(t.headers))
+	numeric := make([]bool, len(t.headers))
+	for i, h := range t.headers {
+		widths[i] = len(h)
+		numeric[i] = len(t.rows) > 0
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+			if _, err := strconv.ParseFloat(cell, 64); err != nil {
+				numeric[i] = false
+			}
+		}
+	}
+
+	formatRow := func(cells []string, alignNumbers bool) string {
+		parts := make([]string, len(cells))
+		for i, cell := range cells {
+			if alignNumbers && numeric[i] {
+				parts[i] = fmt.Sprintf("%*s", widths[i], cell)
+			} else {
+				parts[i] = fmt.Sprintf("%-*s", widths[i], cell)
+			}
+		}
+		return strings.TrimRight(strings.Join(parts, "  "), " ")
+	}
+
+	lines := []string{t.title, "Generated: " + generatedAt.Format("2006-01-02 15:04 MST"), ""}
+	lines = append(lines, t.summary...)
+	lines = append(lines, "", formatRow(t.headers, false))
+	rule := make([]string, len(widths))
+	for i, w := range widths {
+		rule[i] = strings.Repeat("-", w)
+	}
+	lines = append(lines, strings.Join(rule, "  "))
+	for _, row := range t.rows {
+		lines = append(lines, formatRow(row, true))
+	}
+
+	columns := 0
+	for _, line := range lines {
+		columns = max(columns, len([]rune(line)))
+	}
+	return helpers.WideTextPDF(lines, columns)
+}
+
+// StartReportScheduler runs RunDue in the background every interval
+func StartReportScheduler(service ReportScheduleService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.RunDue(context.Background())
+		}
+	}()
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sync"
+	"time"
+)
+
+const (
+	// stockStreamBatchSize caps how many movements one poll reads
+	stockStreamBatchSize = 500
+	// stockStreamLookback is how long movements below the newest one are
+	// looked for again, since a movement's ID is taken before its transaction
+	// commits and a slower transaction can commit a lower ID later
+	stockStreamLookback = 10 * time.Second
+	// stockStreamBuffer is how many updates a subscriber may fall behind by
+	// before it is disconnected
+	stockStreamBuffer = 256
+	// StockStreamReplayLimit caps how many missed updates are replayed to a
+	// reconnecting subscriber
+	StockStreamReplayLimit = 1000
+)
+
+// StockStreamService defines the interface for streaming stock changes
+type StockStreamService interface {
+	Subscribe() (<-chan models.StockUpdate, func())
+	Since(ctx context.Context, lastID int) ([]models.StockUpdate, error)
+	Poll(ctx context.Context)
+}
+
+// stockStreamService implements StockStreamService interface. Changes are
+// read from the stock ledger, so subscribers see changes made through any
+// instance; subscribers themselves are kept in memory.
+type stockStreamService struct {
+	repo repositories.StockMovementRepository
+
+	mu          sync.Mutex
+	subscribers map[chan models.StockUpdate]struct{}
+
+	// floor is the ID every movement at or below has been published; -1
+	// until the first poll with subscribers
+	floor int
+	// sent holds the IDs above floor already published, with when they were
+	// first seen
+	sent map[int]time.Time
+}
+
+// NewStockStreamService creates a new stock stream service instance
+func NewStockStreamService(repo repositories.StockMovementRepository) StockStreamService {
+	return &stockStreamService{
+		repo:        repo,
+		subscribers: make(map[chan models.StockUpdate]struct{}),
+		floor:       -1,
+		sent:        make(map[int]time.Time),
+	}
+}
+
+// Subscribe registers a subscriber for stock changes. The returned function
+// must be called to unsubscribe when the subscriber disconnects. The channel
+// is closed when the subscriber falls too far behind; it should reconnect
+// and catch up with Since.
+func (s *stockStreamService) Subscribe() (<-chan models.StockUpdate, func()) {
+	ch := make(chan models.StockUpdate, stockStreamBuffer)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}
+}
+
+// Since returns up to StockStreamReplayLimit changes after the movement with
+// ID lastID, oldest first
+func (s *stockStreamService) Since(ctx context.Context, lastID int) ([]models.StockUpdate, error) {
+	return s.repo.GetUpdates(ctx, lastID, nil, StockStreamReplayLimit)
+}
+
+// Poll reads the changes committed since the last poll and publishes them.
+// Nothing is read while there are no subscribers.
+func (s *stockStreamService) Poll(ctx context.Context) {
+	s.mu.Lock()
+	idle := len(s.subscribers) == 0
+	if idle {
+		s.floor = -1
+		clear(s.sent)
+	}
+	floor := s.floor
+	exclude := make([]int, 0, len(s.sent))
+	for id := range s.sent {
+		exclude = append(exclude, id)
+	}
+	s.mu.Unlock()
+	if idle {
+		return
+	}
+
+	if floor < 0 {
+		latest, err := s.repo.GetLatestID(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "poll failed", "component", "stock-stream", "error", err)
+			return
+		}
+		s.mu.Lock()
+		s.floor = latest
+		s.mu.Unlock()
+		return
+	}
+
+	updates, err := s.repo.GetUpdates(ctx, floor, exclude, stockStreamBatchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "poll failed", "component", "stock-stream", "error", err)
+		return
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range updates {
+		s.sent[u.MovementID] = now
+		s.publish(u)
+	}
+	for id, seen := range s.sent {
+		if now.Sub(seen) > stockStreamLookback {
+			if id > s.floor {
+				s.floor = id
+			}
+			delete(s.sent, id)
+		}
+	}
+	for id := range s.sent {
+		if id <= s.floor {
+			delete(s.sent, id)
+		}
+	}
+}
+
+// publish sends an update to every subscriber, disconnecting those whose
+// buffer is full. s.mu must be held.
+func (s *stockStreamService) publish(update models.StockUpdate) {
+	for ch := range s.subscribers {
+		select {
+		case ch <- update:
+		default:
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// StartStockStream polls the stock ledger for changes in the background
+// every interval
+func StartStockStream(service StockStreamService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.Poll(context.Background())
+		}
+	}()
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sort"
+	"strings"
+)
+
+// StockTransferService defines the interface for moving stock between stores
+type StockTransferService interface {
+	GetTransfers(ctx context.Context, params models.StockTransferParams) ([]models.StockTransfer, error)
+	GetTransferByID(ctx context.Context, id int) (*models.StockTransfer, error)
+	CreateTransfer(ctx context.Context, input models.StockTransferInput, actor models.Actor) (*models.StockTransfer, error)
+	ReceiveTransfer(ctx context.Context, id int, actor models.Actor) (*models.StockTransfer, error)
+	CancelTransfer(ctx context.Context, id int, actor models.Actor) (*models.StockTransfer, error)
+}
+
+// stockTransferService implements StockTransferService interface
+type stockTransferService struct {
+	repo      repositories.StockTransferRepository
+	storeRepo repositories.StoreRepository
+}
+
+// NewStockTransferService creates a new stock transfer service instance
+func NewStockTransferService(repo repositories.StockTransferRepository, storeRepo repositories.StoreRepository) StockTransferService {
+	return &stockTransferService{repo: repo, storeRepo: storeRepo}
+}
+
+// GetTransfers returns stock transfers filtered by status and store
+func (s *stockTransferService) GetTransfers(ctx context.Context, params models.StockTransferParams) ([]models.StockTransfer, error) {
+	switch params.Status {
+	case "", models.StockTransferInTransit, models.StockTransferReceived, models.StockTransferCancelled:
+	default:
+		return nil, helpers.NewValidationError("status must be 'in_transit', 'received' or 'cancelled'")
+	}
+	return s.repo.GetAll(ctx, params)
+}
+
+// GetTransferByID returns a stock transfer with its items
+func (s *stockTransferService) GetTransferByID(ctx context.Context, id int) (*models.StockTransfer, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateTransfer validates the stores and lines and dispatches the transfer.
+// Lines of the same product are merged.
+func (s *stockTransferService) CreateTransfer(ctx context.Context, input models.StockTransferInput, actor models.Actor) (*models.StockTransfer, error) {
+	if input.FromStoreID == input.ToStoreID {
+		return nil, helpers.NewValidationError("from_store_id and to_store_id must be different stores")
+	}
+	for _, storeID := range []int{input.FromStoreID, input.ToStoreID} {
+		if _, err := resolveStore(ctx, s.storeRepo, storeID); err != nil {
+			return nil, err
+		}
+	}
+
+	quantities := make(map[int]int)
+	for _, item := range input.Items {
+		if item.ProductID <= 0 {
+			return nil, helpers.NewValidationError(fmt.Sprintf("invalid product_id %d", item.ProductID))
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+	items := make([]models.StockTransferItem, 0, len(quantities))
+	for productID, quantity := range quantities {
+		items = append(items, models.StockTransferItem{ProductID: productID, Quantity: quantity})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+
+	return s.repo.Create(ctx, models.StockTransfer{
+		FromStoreID: input.FromStoreID,
+		ToStoreID:   input.ToStoreID,
+		Note:        strings.TrimSpace(input.Note),
+		CreatedBy:   actor.UserID,
+		Items:       items,
+	})
+}
+
+// ReceiveTransfer confirms that an in-transit transfer arrived at the destination store
+func (s *stockTransferService) ReceiveTransfer(ctx context.Context, id int, actor models.Actor) (*models.StockTransfer, error) {
+	return s.repo.Receive(ctx, id, actor.UserID)
+}
+
+// CancelTransfer returns an in-transit transfer to the source store
+func (s *stockTransferService) CancelTransfer(ctx context.Context, id int, actor models.Actor) (*models.StockTransfer, error) {
+	return s.repo.Cancel(ctx, id, actor.UserID)
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"sort"
+	"strings"
+)
+
+const (
+	// SpotCheckDefaultSize is the number of products drawn when no size is given
+	SpotCheckDefaultSize = 20
+	// SpotCheckMaxSize caps the spot-check sample size
+	SpotCheckMaxSize = 100
+	// spotCheckVelocityDays is the sales window used to weight products by velocity
+	spotCheckVelocityDays = 30
+)
+
+// StocktakeService defines the interface for stocktake (count session) business logic
+type StocktakeService interface {
+	GetSessions(ctx context.Context, status string, assignedTo int) ([]models.CountSession, error)
+	GetSessionByID(ctx context.Context, id int) (*models.CountSession, error)
+	GetSpotCheckSample(ctx context.Context, size int, actor models.Actor) (*models.CountSession, error)
+	CreateStocktake(ctx context.Context, input models.StocktakeInput, actor models.Actor) (*models.CountSession, error)
+	GetVarianceReport(ctx context.Context, id int) (*models.CountVarianceReport, error)
+	RecordCount(ctx context.Context, sessionID, productID, countedQty int, actor models.Actor) (*models.CountSessionItem, error)
+	CompleteSession(ctx context.Context, id int, actor models.Actor) (*models.CountSession, error)
+	CancelSession(ctx context.Context, id int) (*models.CountSession, error)
+}
+
+// stocktakeService implements StocktakeService interface
+type stocktakeService struct {
+	repo      repositories.StocktakeRepository
+	storeRepo repositories.StoreRepository
+}
+
+// NewStocktakeService creates a new stocktake service instance
+func NewStocktakeService(repo repositories.StocktakeRepository, storeRepo repositories.StoreRepository) StocktakeService {
+	return &stocktakeService{repo: repo, storeRepo: storeRepo}
+}
+
+// GetSessions returns count sessions, optionally filtered by status and assignee
+func (s *stocktakeService) GetSessions(ctx context.Context, status string, assignedTo int) ([]models.CountSession, error) {
+	switch status {
+	case "", models.CountStatusOpen, models.CountStatusCompleted, models.CountStatusCancelled:
+	default:
+		return nil, helpers.NewValidationError("status must be 'open', 'completed' or 'cancelled'")
+	}
+	return s.repo.GetAll(ctx, status, assignedTo)
+}
+
+// GetSessionByID returns a count session with its items
+func (s *stocktakeService) GetSessionByID(ctx context.Context, id int) (*models.CountSession, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetSpotCheckSample returns today's open spot-check session, or draws a new
+// weighted random sample of active products and opens a session for it
+func (s *stocktakeService) GetSpotCheckSample(ctx context.Context, size int, actor models.Actor) (*models.CountSession, error) {
+	if size < 1 || size > SpotCheckMaxSize {
+		return nil, helpers.NewValidationError(fmt.Sprintf("size must be between 1 and %d", SpotCheckMaxSize))
+	}
+
+	existing, err := s.repo.GetOpenToday(ctx, models.CountSessionSpotCheck)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	candidates, err := s.repo.GetCountCandidates(ctx, spotCheckVelocityDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, helpers.NewValidationError("there are no active products to sample")
+	}
+
+	weights := spotCheckWeights(candidates)
+	picked := weightedSample(weights, size)
+	items := make([]models.CountSessionItem, 0, len(picked))
+	for _, i := range picked {
+		items = append(items, models.CountSessionItem{
+			ProductID:    candidates[i].ProductID,
+			SampleWeight: math.Round(weights[i]*1000) / 1000,
+		})
+	}
+
+	return s.repo.Create(ctx, models.CountSession{
+		Type:      models.CountSessionSpotCheck,
+		Note:      fmt.Sprintf("Spot check sample of %d products", len(items)),
+		CreatedBy: actor.UserID,
+	}, items)
+}
+
+// spotCheckWeights weights each candidate by an equal base share plus its
+// share of total stock value and of units sold, so expensive and fast-moving
+// products are drawn more often while slow, cheap ones still get counted.
+// The weights of all candidates sum to at most 3.
+func spotCheckWeights(candidates []models.CountCandidate) []float64 {
+	var totalValue, totalSold float64
+	for _, c := range candidates {
+		if c.Stock > 0 {
+			totalValue += float64(c.Price) * float64(c.Stock)
+		}
+		totalSold += float64(c.UnitsSold)
+	}
+
+	base := 1 / float64(len(candidates))
+	weights := make([]float64, len(candidates))
+	for i, c := range candidates {
+		weights[i] = base
+		if totalValue > 0 && c.Stock > 0 {
+			weights[i] += float64(c.Price) * float64(c.Stock) / totalValue
+		}
+		if totalSold > 0 {
+			weights[i] += float64(c.UnitsSold) / totalSold
+		}
+	}
+	return weights
+}
+
+// weightedSample draws up to size indexes without replacement, each with
+// probability proportional to its weight (Efraimidis-Spirakis: keep the
+// largest u^(1/w) keys)
+func weightedSample(weights []float64, size int) []int {
+	keys := make([]float64, len(weights))
+	indexes := make([]int, len(weights))
+	for i, w := range weights {
+		keys[i] = math.Pow(rand.Float64(), 1/w)
+		indexes[i] = i
+	}
+
+	sort.Slice(indexes, func(a, b int) bool { return keys[indexes[a]] > keys[indexes[b]] })
+	if size < len(indexes) {
+		indexes = indexes[:size]
+	}
+	return indexes
+}
+
+// CreateStocktake opens a stocktake session at a store for every active
+// product, optionally narrowed to a category and/or a product list. A store
+// can only have one open stocktake at a time.
+func (s *stocktakeService) CreateStocktake(ctx context.Context, input models.StocktakeInput, actor models.Actor) (*models.CountSession, error) {
+	storeID, err := resolveStore(ctx, s.storeRepo, input.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]int, 0, len(input.ProductIDs))
+	seen := make(map[int]bool, len(input.ProductIDs))
+	for _, id := range input.ProductIDs {
+		if id <= 0 {
+			return nil, helpers.NewValidationError(fmt.Sprintf("invalid product_id %d", id))
+		}
+		if !seen[id] {
+			seen[id] = true
+			productIDs = append(productIDs, id)
+		}
+	}
+
+	open, err := s.repo.GetAll(ctx, models.CountStatusOpen, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range open {
+		if session.Type == models.CountSessionStocktake && session.StoreID != nil && *session.StoreID == storeID {
+			return nil, helpers.NewConflictError(fmt.Sprintf("stocktake session %d is already open for this store", session.ID))
+		}
+	}
+
+	ids, err := s.repo.GetActiveProductIDs(ctx, input.CategoryID, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(productIDs) > 0 && len(ids) < len(productIDs) {
+		found := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			found[id] = true
+		}
+		for _, id := range productIDs {
+			if !found[id] {
+				return nil, helpers.NewValidationError(fmt.Sprintf("invalid product_id %d: product not found, inactive or outside the category", id))
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, helpers.NewValidationError("there are no active products to count")
+	}
+
+	items := make([]models.CountSessionItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, models.CountSessionItem{ProductID: id})
+	}
+
+	note := strings.TrimSpace(input.Note)
+	if note == "" {
+		note = fmt.Sprintf("Stocktake of %d products", len(items))
+	}
+	return s.repo.Create(ctx, models.CountSession{
+		Type:      models.CountSessionStocktake,
+		Note:      note,
+		StoreID:   &storeID,
+		CreatedBy: actor.UserID,
+	}, items)
+}
+
+// GetVarianceReport summarizes the counted variances of a session at cost.
+// For open sessions it previews what completing the session would post.
+func (s *stocktakeService) GetVarianceReport(ctx context.Context, id int) (*models.CountVarianceReport, error) {
+	session, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, helpers.NewNotFoundError("count session not found")
+	}
+
+	lines, err := s.repo.GetVarianceLines(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CountVarianceReport{
+		SessionID:     session.ID,
+		Type:          session.Type,
+		Status:        session.Status,
+		StoreID:       session.StoreID,
+		ItemCount:     session.ItemCount,
+		CountedCount:  session.CountedCount,
+		VarianceCount: len(lines),
+		MatchedCount:  session.CountedCount - len(lines),
+		CompletedAt:   session.CompletedAt,
+		Lines:         lines,
+	}
+	for _, line := range lines {
+		if line.Variance < 0 {
+			report.ShrinkageQty -= line.Variance
+			report.ShrinkageValue -= line.VarianceValue
+		} else {
+			report.OverageQty += line.Variance
+			report.OverageValue += line.VarianceValue
+		}
+		report.NetVarianceValue += line.VarianceValue
+	}
+	if report.CountedCount > 0 {
+		report.AccuracyPercent = math.Round(float64(report.MatchedCount)/float64(report.CountedCount)*1000) / 10
+	}
+	return report, nil
+}
+
+// RecordCount stores the counted quantity of a product in an open session
+func (s *stocktakeService) RecordCount(ctx context.Context, sessionID, productID, countedQty int, actor models.Actor) (*models.CountSessionItem, error) {
+	if countedQty < 0 {
+		return nil, helpers.NewValidationError("counted_qty must not be negative")
+	}
+
+	session, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, helpers.NewNotFoundError("count session not found")
+	}
+	if session.Status != models.CountStatusOpen {
+		return nil, helpers.NewConflictError("count session is already " + session.Status)
+	}
+
+	item, err := s.repo.RecordCount(ctx, sessionID, productID, countedQty, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, helpers.NewNotFoundError("product not found in count session")
+	}
+	return item, nil
+}
+
+// CompleteSession closes a fully counted session and posts its variances to stock
+func (s *stocktakeService) CompleteSession(ctx context.Context, id int, actor models.Actor) (*models.CountSession, error) {
+	if err := s.repo.Complete(ctx, id, actor.UserID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// CancelSession closes an open session without adjusting stock
+func (s *stocktakeService) CancelSession(ctx context.Context, id int) (*models.CountSession, error) {
+	session, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil {
+		return session, nil
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, helpers.NewNotFoundError("count session not found")
+	}
+	return nil, helpers.NewConflictError("count session is already " + existing.Status)
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"strings"
+	"time"
+)
+
+// StoreService defines the interface for store location business logic
+type StoreService interface {
+	GetAllStores(ctx context.Context) ([]models.Store, error)
+	GetStoreByID(ctx context.Context, id int) (*models.Store, error)
+	CreateStore(ctx context.Context, input models.StoreInput) (*models.Store, error)
+	UpdateStore(ctx context.Context, id int, input models.StoreInput) (*models.Store, error)
+	GetStoreStock(ctx context.Context, id int) ([]models.StoreStock, error)
+	GetSalesReport(ctx context.Context, startDate, endDate string) (*models.StoreSalesReport, error)
+}
+
+// storeService implements StoreService interface
+type storeService struct {
+	repo repositories.StoreRepository
+}
+
+// NewStoreService creates a new store service instance
+func NewStoreService(repo repositories.StoreRepository) StoreService {
+	return &storeService{repo: repo}
+}
+
+// resolveStore returns the store a sale or stock change happens at: the given
+// store, which must exist and be active, or the default store when storeID is 0
+func resolveStore(ctx context.Context, repo repositories.StoreRepository, storeID int) (int, error) {
+	if storeID == 0 {
+		store, err := repo.GetDefault(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if store == nil {
+			return 0, helpers.NewCodedError(helpers.CodeDefaultStoreNotFound)
+		}
+		return store.ID, nil
+	}
+
+	store, err := repo.GetByID(ctx, storeID)
+	if err != nil {
+		return 0, err
+	}
+	if store == nil {
+		return 0, helpers.NewCodedError(helpers.CodeStoreNotFound, storeID)
+	}
+	if !store.IsActive {
+		return 0, helpers.NewCodedError(helpers.CodeStoreInactive, storeID)
+	}
+	return store.ID, nil
+}
+
+// GetAllStores returns all stores
+func (s *storeService) GetAllStores(ctx context.Context) ([]models.Store, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetStoreByID returns a store by its ID
+func (s *storeService) GetStoreByID(ctx context.Context, id int) (*models.Store, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateStore validates and creates a new store with a unique code
+func (s *storeService) CreateStore(ctx context.Context, input models.StoreInput) (*models.Store, error) {
+	store, err := s.validate(ctx, 0, input)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, store)
+}
+
+// UpdateStore validates and updates an existing store. The default store
+// cannot be deactivated because sales and stock changes fall back to it.
+func (s *storeService) UpdateStore(ctx context.Context, id int, input models.StoreInput) (*models.Store, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, helpers.NewNotFoundError("store not found")
+	}
+
+	store, err := s.validate(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsDefault && !store.IsActive {
+		return nil, helpers.NewValidationError("the default store cannot be deactivated")
+	}
+	return s.repo.Update(ctx, id, store)
+}
+
+// validate normalizes a store input and checks that its code is not taken
+// by another store
+func (s *storeService) validate(ctx context.Context, id int, input models.StoreInput) (models.Store, error) {
+	store := models.Store{
+		Code:     strings.ToUpper(strings.TrimSpace(input.Code)),
+		Name:     strings.TrimSpace(input.Name),
+		Address:  strings.TrimSpace(input.Address),
+		IsActive: input.IsActive == nil || *input.IsActive,
+	}
+	if store.Code == "" || store.Name == "" {
+		return store, helpers.NewValidationError("store code and name are required")
+	}
+
+	existing, err := s.repo.GetByCode(ctx, store.Code)
+	if err != nil {
+		return store, err
+	}
+	if existing != nil && existing.ID != id {
+		return store, helpers.NewConflictError(fmt.Sprintf("store code %s is already in use", store.Code))
+	}
+	return store, nil
+}
+
+// GetStoreStock returns the stock held at a store
+func (s *storeService) GetStoreStock(ctx context.Context, id int) ([]models.StoreStock, error) {
+	store, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, helpers.NewNotFoundError("store not found")
+	}
+	return s.repo.GetStock(ctx, id)
+}
+
+// GetSalesReport returns consolidated sales across every store with a
+// breakdown per store
+func (s *storeService) GetSalesReport(ctx context.Context, startDate, endDate string) (*models.StoreSalesReport, error) {
+	if startDate == "" || endDate == "" {
+		return nil, helpers.NewValidationError("start_date and end_date are required")
+	}
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, helpers.NewValidationError("start_date must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, helpers.NewValidationError("end_date must be in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, helpers.NewValidationError("end_date must not be before start_date")
+	}
+
+	stores, err := s.repo.GetSalesByStore(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.StoreSalesReport{StartDate: startDate, EndDate: endDate, Stores: stores}
+	for _, st := range stores {
+		report.TotalRevenue += st.TotalRevenue
+		report.TotalTransactions += st.TotalTransactions
+	}
+	return report, nil
+}
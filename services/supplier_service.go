@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"strings"
+)
+
+// SupplierService defines the interface for supplier business logic
+type SupplierService interface {
+	GetAllSuppliers(ctx context.Context) ([]models.Supplier, error)
+	GetSupplierByID(ctx context.Context, id int) (*models.Supplier, error)
+	CreateSupplier(ctx context.Context, supplier models.Supplier) (*models.Supplier, error)
+	UpdateSupplier(ctx context.Context, id int, supplier models.Supplier) (*models.Supplier, error)
+	DeleteSupplier(ctx context.Context, id int) error
+}
+
+// supplierService implements SupplierService interface
+type supplierService struct {
+	repo repositories.SupplierRepository
+}
+
+// NewSupplierService creates a new supplier service instance
+func NewSupplierService(repo repositories.SupplierRepository) SupplierService {
+	return &supplierService{repo: repo}
+}
+
+// GetAllSuppliers returns all suppliers
+func (s *supplierService) GetAllSuppliers(ctx context.Context) ([]models.Supplier, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetSupplierByID returns a supplier by its ID
+func (s *supplierService) GetSupplierByID(ctx context.Context, id int) (*models.Supplier, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateSupplier validates and creates a new supplier
+func (s *supplierService) CreateSupplier(ctx context.Context, supplier models.Supplier) (*models.Supplier, error) {
+	if strings.TrimSpace(supplier.Name) == "" {
+		return nil, helpers.NewValidationError("supplier name is required")
+	}
+	return s.repo.Create(ctx, supplier)
+}
+
+// UpdateSupplier validates and updates an existing supplier
+func (s *supplierService) UpdateSupplier(ctx context.Context, id int, supplier models.Supplier) (*models.Supplier, error) {
+	if strings.TrimSpace(supplier.Name) == "" {
+		return nil, helpers.NewValidationError("supplier name is required")
+	}
+
+	updated, err := s.repo.Update(ctx, id, supplier)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, helpers.NewNotFoundError("supplier not found")
+	}
+	return updated, nil
+}
+
+// DeleteSupplier removes a supplier. Suppliers that still own consignment
+// products cannot be removed, otherwise their future sales would accrue nothing.
+func (s *supplierService) DeleteSupplier(ctx context.Context, id int) error {
+	count, err := s.repo.CountConsignmentProducts(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return helpers.NewConflictError("supplier still owns consignment products")
+	}
+	return s.repo.Delete(ctx, id)
+}
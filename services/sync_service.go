@@ -0,0 +1,423 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"retail-core-api/client"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// syncBatchSize caps how many entities of each type one run replicates
+	syncBatchSize = 200
+	// syncRunTimeout bounds a whole replication run
+	syncRunTimeout = 5 * time.Minute
+	// syncProblemsShown is how many conflicts and failures the status lists
+	syncProblemsShown = 20
+)
+
+// SyncConfig locates the upstream instance local data is replicated to
+type SyncConfig struct {
+	UpstreamURL     string
+	APIKey          string
+	Email           string
+	Password        string
+	UpstreamStoreID int
+	EdgeID          string
+}
+
+// SyncService defines the interface for replicating local sales, voids and
+// stock adjustments to an upstream instance
+type SyncService interface {
+	Enabled() bool
+	GetStatus(ctx context.Context) (*models.SyncStatus, error)
+	Run() (*models.SyncRun, error)
+	RetryConflicts(ctx context.Context) (int, error)
+}
+
+// syncService implements SyncService interface on top of the API client
+type syncService struct {
+	repo              repositories.SyncRepository
+	transactionRepo   repositories.TransactionRepository
+	productRepo       repositories.ProductRepository
+	stockMovementRepo repositories.StockMovementRepository
+	cfg               SyncConfig
+	upstream          *client.Client
+
+	running sync.Mutex
+	mu      sync.Mutex
+	lastRun *models.SyncRun
+}
+
+// NewSyncService creates a new sync service instance. Replication is
+// disabled when no upstream URL is configured.
+func NewSyncService(repo repositories.SyncRepository, transactionRepo repositories.TransactionRepository, productRepo repositories.ProductRepository, stockMovementRepo repositories.StockMovementRepository, cfg SyncConfig) SyncService {
+	s := &syncService{
+		repo:              repo,
+		transactionRepo:   transactionRepo,
+		productRepo:       productRepo,
+		stockMovementRepo: stockMovementRepo,
+		cfg:               cfg,
+	}
+	if cfg.UpstreamURL != "" {
+		opts := []client.Option{client.WithRetries(2, time.Second), client.WithUserAgent("retail-core-sync/" + cfg.EdgeID)}
+		if cfg.APIKey != "" {
+			opts = append(opts, client.WithAPIKey(cfg.APIKey))
+		}
+		s.upstream = client.New(cfg.UpstreamURL, opts...)
+	}
+	return s
+}
+
+// errUpstreamUnavailable ends a run early: the remaining entities would fail
+// the same way
+var errUpstreamUnavailable = errors.New("upstream unavailable")
+
+// Enabled reports whether an upstream instance is configured
+func (s *syncService) Enabled() bool {
+	return s.upstream != nil
+}
+
+// GetStatus returns the configuration, the latest run, the counts per entity
+// type and the latest conflicts and failures
+func (s *syncService) GetStatus(ctx context.Context) (*models.SyncStatus, error) {
+	entities, err := s.repo.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := s.repo.GetProblems(ctx, syncProblemsShown)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	lastRun := s.lastRun
+	s.mu.Unlock()
+
+	return &models.SyncStatus{
+		Enabled:         s.Enabled(),
+		Upstream:        s.cfg.UpstreamURL,
+		EdgeID:          s.cfg.EdgeID,
+		UpstreamStoreID: s.cfg.UpstreamStoreID,
+		LastRun:         lastRun,
+		Entities:        entities,
+		Problems:        problems,
+	}, nil
+}
+
+// RetryConflicts queues every conflict for the next run, e.g. after the
+// missing product was created upstream
+func (s *syncService) RetryConflicts(ctx context.Context) (int, error) {
+	return s.repo.RetryConflicts(ctx)
+}
+
+// Run replicates pending sales, then voids, then stock adjustments. Sales
+// are replayed through the upstream checkout, so upstream applies its own
+// prices, promotions and stock rules. Conflict rules:
+//   - products are matched by SKU; a line whose product has no SKU or whose
+//     SKU does not exist upstream makes the sale a conflict
+//   - a sale upstream rejects (e.g. insufficient stock there) is a conflict;
+//     it is retried after POST /v1/sync/retry
+//   - when upstream prices the sale differently the upstream total stands and
+//     the difference is noted on the record
+//   - a sale voided before it reached upstream is skipped, and a void of a
+//     sale upstream already voided counts as synced
+//
+// Network and server errors mark the entity failed and end the run; failed
+// entities are retried on the next run with the same Idempotency-Key, so a
+// sale whose response was lost is not booked twice.
+func (s *syncService) Run() (*models.SyncRun, error) {
+	if s.upstream == nil {
+		return nil, helpers.NewValidationError("sync is not configured: set SYNC_UPSTREAM_URL")
+	}
+	if !s.running.TryLock() {
+		return nil, helpers.NewConflictError("a sync run is already in progress")
+	}
+	defer s.running.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), syncRunTimeout)
+	defer cancel()
+
+	run := &models.SyncRun{StartedAt: time.Now()}
+	if err := s.replicate(ctx, run); err != nil {
+		run.Error = err.Error()
+	}
+	run.FinishedAt = time.Now()
+
+	s.mu.Lock()
+	s.lastRun = run
+	s.mu.Unlock()
+	return run, nil
+}
+
+// replicate runs the three passes of Run
+func (s *syncService) replicate(ctx context.Context, run *models.SyncRun) error {
+	if s.cfg.Email != "" && s.upstream.Token() == "" {
+		if err := s.upstream.Login(ctx, s.cfg.Email, s.cfg.Password); err != nil {
+			return fmt.Errorf("upstream login failed: %v", err)
+		}
+	}
+	catalog, err := s.upstreamCatalog(ctx)
+	if err != nil {
+		return s.unavailable(fmt.Errorf("failed to load upstream catalog: %w", err))
+	}
+
+	passes := []struct {
+		entityType string
+		push       func(ctx context.Context, record models.SyncRecord, catalog map[string]int) (models.SyncRecord, error)
+	}{
+		{models.SyncEntityTransaction, s.pushTransaction},
+		{models.SyncEntityVoid, s.pushVoid},
+		{models.SyncEntityAdjustment, s.pushAdjustment},
+	}
+	for _, pass := range passes {
+		pending, err := s.repo.GetPending(ctx, pass.entityType, syncBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, record := range pending {
+			result, err := pass.push(ctx, record, catalog)
+			if err != nil {
+				result = record
+				result.Status = models.SyncStatusFailed
+				result.Message = err.Error()
+			}
+			if saveErr := s.repo.Save(ctx, result); saveErr != nil {
+				return saveErr
+			}
+			switch result.Status {
+			case models.SyncStatusSynced:
+				run.Synced++
+			case models.SyncStatusSkipped:
+				run.Skipped++
+			case models.SyncStatusConflict:
+				run.Conflicts++
+			default:
+				run.Failed++
+			}
+			if errors.Is(err, errUpstreamUnavailable) {
+				return err
+			}
+		}
+	}
+
+	if run.Synced+run.Conflicts+run.Failed > 0 {
+		slog.InfoContext(ctx, "sync run finished", "component", "sync", "synced", run.Synced, "skipped", run.Skipped, "conflicts", run.Conflicts, "failed", run.Failed)
+	}
+	return nil
+}
+
+// pushTransaction replays a local sale through the upstream checkout
+func (s *syncService) pushTransaction(ctx context.Context, record models.SyncRecord, catalog map[string]int) (models.SyncRecord, error) {
+	t, err := s.transactionRepo.GetTransactionByID(ctx, record.EntityID)
+	if err != nil {
+		return record, err
+	}
+	if t.Status == "void" {
+		return s.outcome(record, models.SyncStatusSkipped, nil, "voided before it was synced"), nil
+	}
+
+	req := models.CheckoutRequest{
+		StoreID:       s.cfg.UpstreamStoreID,
+		PaymentMethod: t.PaymentMethod,
+		PriceLevel:    t.PriceLevel,
+		Discount:      t.Discount,
+		Notes:         strings.TrimSpace(fmt.Sprintf("%s [edge %s, receipt %s]", t.Notes, s.cfg.EdgeID, t.ReceiptNo)),
+	}
+	for _, d := range t.Details {
+		remoteID, msg, err := s.remoteProduct(ctx, d.ProductID, catalog)
+		if err != nil {
+			return record, err
+		}
+		if msg != "" {
+			return s.outcome(record, models.SyncStatusConflict, nil, msg), nil
+		}
+		req.Items = append(req.Items, models.CheckoutItem{ProductID: remoteID, Quantity: d.Quantity})
+	}
+
+	remote, err := s.upstream.Checkout(ctx, req, s.idempotencyKey(record))
+	if err != nil {
+		return s.failure(record, err)
+	}
+	msg := ""
+	if remote.TotalAmount != t.TotalAmount {
+		msg = fmt.Sprintf("upstream total %d differs from local total %d; upstream prices apply", remote.TotalAmount, t.TotalAmount)
+	}
+	return s.outcome(record, models.SyncStatusSynced, &remote.ID, msg), nil
+}
+
+// pushVoid voids the upstream copy of a sale voided locally
+func (s *syncService) pushVoid(ctx context.Context, record models.SyncRecord, _ map[string]int) (models.SyncRecord, error) {
+	err := s.upstream.VoidTransaction(ctx, *record.RemoteID, s.idempotencyKey(record))
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) && (apiErr.Code == helpers.CodeTransactionVoided || strings.Contains(apiErr.Message, "already voided")) {
+		return s.outcome(record, models.SyncStatusSynced, record.RemoteID, "already voided upstream"), nil
+	}
+	if err != nil {
+		return s.failure(record, err)
+	}
+	return s.outcome(record, models.SyncStatusSynced, record.RemoteID, ""), nil
+}
+
+// pushAdjustment applies a local manual stock adjustment upstream with the
+// same quantity and reason code
+func (s *syncService) pushAdjustment(ctx context.Context, record models.SyncRecord, catalog map[string]int) (models.SyncRecord, error) {
+	m, err := s.stockMovementRepo.GetByID(ctx, record.EntityID)
+	if err != nil {
+		return record, err
+	}
+	if m == nil {
+		return s.outcome(record, models.SyncStatusSkipped, nil, "stock movement no longer exists"), nil
+	}
+	remoteID, msg, err := s.remoteProduct(ctx, m.ProductID, catalog)
+	if err != nil {
+		return record, err
+	}
+	if msg != "" {
+		return s.outcome(record, models.SyncStatusConflict, nil, msg), nil
+	}
+
+	input := models.StockAdjustmentInput{
+		Quantity:   m.QuantityDelta,
+		ReasonCode: m.ReasonCode,
+		Note:       strings.TrimSpace(fmt.Sprintf("%s [edge %s, movement %d]", m.Note, s.cfg.EdgeID, m.ID)),
+		StoreID:    s.cfg.UpstreamStoreID,
+	}
+	remote, err := s.upstream.AdjustStock(ctx, remoteID, input, s.idempotencyKey(record))
+	if err != nil {
+		return s.failure(record, err)
+	}
+	return s.outcome(record, models.SyncStatusSynced, &remote.ID, ""), nil
+}
+
+// remoteProduct maps a local product to its upstream ID by SKU. A non-empty
+// message explains why it cannot be mapped.
+func (s *syncService) remoteProduct(ctx context.Context, productID int, catalog map[string]int) (int, string, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return 0, "", err
+	}
+	if product == nil {
+		return 0, fmt.Sprintf("product #%d no longer exists locally", productID), nil
+	}
+	if product.SKU == "" {
+		return 0, fmt.Sprintf("product '%s' has no SKU to match upstream", product.Name), nil
+	}
+	remoteID, ok := catalog[product.SKU]
+	if !ok {
+		return 0, fmt.Sprintf("product '%s' (sku %s) does not exist upstream", product.Name, product.SKU), nil
+	}
+	return remoteID, "", nil
+}
+
+// upstreamCatalog maps every upstream SKU to its product ID, read from the
+// upstream product export
+func (s *syncService) upstreamCatalog(ctx context.Context) (map[string]int, error) {
+	data, err := s.upstream.ExportProducts(ctx, models.ExportFormatCSV)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff")))
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid product export: %v", err)
+	}
+	skuCol, idCol := -1, -1
+	for i, name := range header {
+		switch name {
+		case "sku":
+			skuCol = i
+		case "id":
+			idCol = i
+		}
+	}
+	if skuCol < 0 || idCol < 0 {
+		return nil, errors.New("invalid product export: missing id or sku column")
+	}
+
+	catalog := make(map[string]int)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid product export: %v", err)
+		}
+		if row[skuCol] == "" {
+			continue
+		}
+		id, err := strconv.Atoi(row[idCol])
+		if err != nil {
+			return nil, fmt.Errorf("invalid product export: bad id %q", row[idCol])
+		}
+		catalog[row[skuCol]] = id
+	}
+	return catalog, nil
+}
+
+// idempotencyKey identifies one replication of an entity. It stays the same
+// across failed attempts and changes when a conflict is retried.
+func (s *syncService) idempotencyKey(record models.SyncRecord) client.RequestOption {
+	return client.WithIdempotencyKey(fmt.Sprintf("sync:%s:%s:%d:%d", s.cfg.EdgeID, record.EntityType, record.EntityID, record.RetryCount))
+}
+
+// outcome returns record with a new status, remote ID and message
+func (s *syncService) outcome(record models.SyncRecord, status string, remoteID *int, message string) models.SyncRecord {
+	record.Status = status
+	record.RemoteID = remoteID
+	record.Message = message
+	return record
+}
+
+// failure classifies an upstream error: requests upstream rejects are
+// conflicts; authentication, server and network errors fail the entity and
+// end the run
+func (s *syncService) failure(record models.SyncRecord, err error) (models.SyncRecord, error) {
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized:
+			// Log in again on the next run
+			s.upstream.SetToken("")
+		case apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusForbidden &&
+			apiErr.StatusCode != http.StatusConflict && apiErr.StatusCode != http.StatusTooManyRequests:
+			return s.outcome(record, models.SyncStatusConflict, record.RemoteID, apiErr.Error()), nil
+		}
+	}
+	return record, s.unavailable(err)
+}
+
+// unavailable wraps err so the run stops after recording it
+func (s *syncService) unavailable(err error) error {
+	return fmt.Errorf("%w: %v", errUpstreamUnavailable, err)
+}
+
+// StartSyncAgent runs Run in the background every interval
+func StartSyncAgent(service SyncService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			run, err := service.Run()
+			if err != nil {
+				slog.Error("sync run failed", "component", "sync", "error", err)
+				continue
+			}
+			if run.Error != "" {
+				slog.Warn("sync run stopped", "component", "sync", "error", run.Error)
+			}
+		}
+	}()
+}
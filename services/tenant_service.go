@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tenantSlugPattern is lowercase words of letters and digits joined by dashes
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// TenantProvisioner prepares a newly created tenant to serve requests: it
+// seeds the default store and the owner account (whose password is already
+// hashed) and starts the tenant's app and background jobs
+type TenantProvisioner func(tenant models.Tenant, owner models.User) error
+
+// TenantService defines the interface for tenant management business logic
+type TenantService interface {
+	GetTenants(ctx context.Context) ([]models.Tenant, error)
+	CreateTenant(ctx context.Context, input models.TenantInput) (*models.TenantAPIKey, error)
+	RotateAPIKey(ctx context.Context, id int) (*models.TenantAPIKey, error)
+	ResolveAPIKey(ctx context.Context, apiKey string) (*models.Tenant, error)
+}
+
+// tenantService implements TenantService interface
+type tenantService struct {
+	repo      repositories.TenantRepository
+	provision TenantProvisioner
+}
+
+// NewTenantService creates a new tenant service instance
+func NewTenantService(repo repositories.TenantRepository, provision TenantProvisioner) TenantService {
+	return &tenantService{repo: repo, provision: provision}
+}
+
+// GetTenants returns every tenant
+func (s *tenantService) GetTenants(ctx context.Context) ([]models.Tenant, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// CreateTenant creates a tenant with a new API key and provisions it
+func (s *tenantService) CreateTenant(ctx context.Context, input models.TenantInput) (*models.TenantAPIKey, error) {
+	slug := strings.TrimSpace(input.Slug)
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, helpers.NewValidationError("slug must be lowercase letters, digits and dashes")
+	}
+	existing, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, helpers.NewConflictError(fmt.Sprintf("tenant %s already exists", slug))
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.OwnerPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.New("failed to hash password")
+	}
+	apiKey, keyHash, err := newTenantAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.repo.Create(ctx, models.Tenant{Slug: slug, Name: strings.TrimSpace(input.Name)}, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	owner := models.User{Name: input.OwnerName, Email: input.OwnerEmail, Password: string(hash), Role: "owner"}
+	if err := s.provision(*tenant, owner); err != nil {
+		return nil, fmt.Errorf("tenant %d created but not provisioned: %w", tenant.ID, err)
+	}
+
+	return &models.TenantAPIKey{Tenant: *tenant, APIKey: apiKey}, nil
+}
+
+// RotateAPIKey issues a new API key for a tenant; the old key stops working
+// immediately
+func (s *tenantService) RotateAPIKey(ctx context.Context, id int) (*models.TenantAPIKey, error) {
+	tenant, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, helpers.NewNotFoundError("tenant not found")
+	}
+
+	apiKey, keyHash, err := newTenantAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetAPIKeyHash(ctx, id, keyHash); err != nil {
+		return nil, err
+	}
+	tenant.HasAPIKey = true
+
+	return &models.TenantAPIKey{Tenant: *tenant, APIKey: apiKey}, nil
+}
+
+// ResolveAPIKey returns the tenant owning an API key, or nil when the key is
+// unknown
+func (s *tenantService) ResolveAPIKey(ctx context.Context, apiKey string) (*models.Tenant, error) {
+	return s.repo.GetByAPIKeyHash(ctx, hashTenantAPIKey(apiKey))
+}
+
+// newTenantAPIKey generates an API key and the hash stored for it
+func newTenantAPIKey() (string, string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", errors.New("failed to generate API key")
+	}
+	key := "rk_" + hex.EncodeToString(b)
+	return key, hashTenantAPIKey(key), nil
+}
+
+// hashTenantAPIKey returns the SHA-256 of an API key, hex encoded. Keys are
+// random, so a fast unsalted hash is enough to keep them out of the database.
+func hashTenantAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
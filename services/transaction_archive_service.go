@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"retail-core-api/storage"
+	"time"
+)
+
+const (
+	// archiveBatchSize caps how many transactions one archiver run moves
+	archiveBatchSize = 500
+	// archiveTimeout bounds a single cold storage upload or download
+	archiveTimeout = 30 * time.Second
+)
+
+// TransactionArchiveService defines the interface for moving old transactions
+// to cold storage and reading them back
+type TransactionArchiveService interface {
+	GetArchived(ctx context.Context, id int) (*models.Transaction, error)
+	ArchiveDue(ctx context.Context) int
+}
+
+// transactionArchiveService implements TransactionArchiveService interface
+type transactionArchiveService struct {
+	repo            repositories.TransactionArchiveRepository
+	transactionRepo repositories.TransactionRepository
+	store           storage.Store
+	retention       time.Duration
+}
+
+// NewTransactionArchiveService creates a new transaction archive service
+// instance. Transactions older than retention are archived; a zero retention
+// disables archiving but archived transactions stay retrievable.
+func NewTransactionArchiveService(repo repositories.TransactionArchiveRepository, transactionRepo repositories.TransactionRepository, store storage.Store, retention time.Duration) TransactionArchiveService {
+	return &transactionArchiveService{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		store:           store,
+		retention:       retention,
+	}
+}
+
+// archiveKey returns the object key of an archived transaction, grouped by
+// the month it was created
+func archiveKey(transaction *models.Transaction) string {
+	return fmt.Sprintf("transactions/%s/%d.json", transaction.CreatedAt.Format("2006/01"), transaction.ID)
+}
+
+// GetArchived downloads an archived transaction from cold storage. It returns
+// nil when the transaction was never archived.
+func (s *transactionArchiveService) GetArchived(ctx context.Context, id int) (*models.Transaction, error) {
+	key, err := s.repo.GetObjectKey(ctx, id)
+	if err != nil || key == "" {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+	data, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve archived transaction id %d: %w", id, err)
+	}
+
+	var transaction models.Transaction
+	if err := json.Unmarshal(data, &transaction); err != nil {
+		return nil, fmt.Errorf("archived transaction id %d is corrupt: %w", id, err)
+	}
+	transaction.Archived = true
+	return &transaction, nil
+}
+
+// findTransaction looks a transaction up in the database and falls back to
+// cold storage when it has been archived
+func findTransaction(ctx context.Context, transactionRepo repositories.TransactionRepository, archiveService TransactionArchiveService, id int) (*models.Transaction, error) {
+	transaction, err := transactionRepo.GetTransactionByID(ctx, id)
+	if err == nil || !helpers.IsNotFound(err) {
+		return transaction, err
+	}
+
+	archived, archiveErr := archiveService.GetArchived(ctx, id)
+	if archiveErr != nil {
+		return nil, archiveErr
+	}
+	if archived == nil {
+		return nil, err
+	}
+	return archived, nil
+}
+
+// ArchiveDue moves transactions past the retention period to cold storage and
+// returns how many were archived. Each transaction is uploaded before it is
+// deleted, so a failure leaves it in the database to be retried on the next run.
+func (s *transactionArchiveService) ArchiveDue(ctx context.Context) int {
+	if s.retention <= 0 {
+		return 0
+	}
+
+	ids, err := s.repo.GetArchivableIDs(ctx, time.Now().Add(-s.retention), archiveBatchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load archivable transactions", "component", "archive", "error", err)
+		return 0
+	}
+
+	archived := 0
+	for _, id := range ids {
+		ok, err := s.archive(ctx, id)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to archive transaction", "component", "archive", "transaction_id", id, "error", err)
+			continue
+		}
+		if ok {
+			archived++
+		}
+	}
+
+	if archived > 0 {
+		slog.InfoContext(ctx, "moved transactions to cold storage", "component", "archive", "count", archived)
+	}
+	return archived
+}
+
+// archive uploads one transaction and removes it from the database
+func (s *transactionArchiveService) archive(ctx context.Context, id int) (bool, error) {
+	transaction, err := s.transactionRepo.GetTransactionByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(transaction)
+	if err != nil {
+		return false, err
+	}
+
+	key := archiveKey(transaction)
+	ctx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+	if err := s.store.Put(ctx, key, data); err != nil {
+		return false, err
+	}
+
+	return s.repo.MarkArchived(ctx, *transaction, key)
+}
+
+// StartTransactionArchiver runs ArchiveDue in the background every interval
+func StartTransactionArchiver(service TransactionArchiveService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.ArchiveDue(context.Background())
+		}
+	}()
+}
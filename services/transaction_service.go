@@ -1,94 +1,476 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/repositories"
+	"slices"
+	"sort"
+	"time"
 )
 
 // TransactionService defines the interface for transaction business logic
 type TransactionService interface {
-	Checkout(req models.CheckoutRequest) (*models.Transaction, error)
-	GetAllTransactions(page, limit int, startDate, endDate string) (*models.PaginatedTransactions, error)
-	GetTransactionByID(id int) (*models.Transaction, error)
-	VoidTransaction(id int) error
-	GetDashboardStats() (*models.DashboardStats, error)
-	GetDailySalesReport() (*models.SalesReport, error)
-	GetSalesReportByDateRange(startDate, endDate string) (*models.SalesReport, error)
-	GetReportSummary(startDate, endDate string) (*models.ReportSummary, error)
+	Checkout(ctx context.Context, req models.CheckoutRequest) (*models.Transaction, error)
+	GetAllTransactions(ctx context.Context, params models.TransactionListParams) (*models.PaginatedTransactions, error)
+	GetTransactionByID(ctx context.Context, id int) (*models.Transaction, error)
+	VoidTransaction(ctx context.Context, id int) error
+	GetDashboardStats(ctx context.Context, storeID int) (*models.DashboardStats, error)
+	GetDailySalesReport(ctx context.Context, storeID int) (*models.SalesReport, error)
+	GetSalesReportByDateRange(ctx context.Context, filter models.ReportFilter) (*models.SalesReport, error)
+	GetReportSummary(ctx context.Context, filter models.ReportFilter) (*models.ReportSummary, error)
+	GetProfitReport(ctx context.Context, filter models.ReportFilter) (*models.ProfitReport, error)
+	GetCategorySalesReport(ctx context.Context, filter models.ReportFilter) (*models.CategorySalesReport, error)
+	GetBestSellersReport(ctx context.Context, filter models.ReportFilter, limit int) (*models.BestSellersReport, error)
+	GetHourlySalesReport(ctx context.Context, date string, filter models.ReportFilter) (*models.HourlySalesReport, error)
+	GetSalesTimeSeries(ctx context.Context, filter models.ReportFilter, granularity string) ([]models.SalesBucket, error)
+	ExportSalesReport(ctx context.Context, w io.Writer, filter models.ReportFilter) error
 }
 
 // transactionService implements TransactionService interface
 type transactionService struct {
-	repo repositories.TransactionRepository
+	repo          repositories.TransactionRepository
+	productRepo   repositories.ProductRepository
+	promotionRepo repositories.PromotionRepository
+	priceTierRepo repositories.PriceTierRepository
+	storeRepo     repositories.StoreRepository
+	archive       TransactionArchiveService
+	flags         FeatureFlagService
 }
 
 // NewTransactionService creates a new transaction service instance
-func NewTransactionService(repo repositories.TransactionRepository) TransactionService {
-	return &transactionService{repo: repo}
+func NewTransactionService(repo repositories.TransactionRepository, productRepo repositories.ProductRepository, promotionRepo repositories.PromotionRepository, priceTierRepo repositories.PriceTierRepository, storeRepo repositories.StoreRepository, archive TransactionArchiveService, flags FeatureFlagService) TransactionService {
+	return &transactionService{
+		repo:          repo,
+		productRepo:   productRepo,
+		promotionRepo: promotionRepo,
+		priceTierRepo: priceTierRepo,
+		storeRepo:     storeRepo,
+		archive:       archive,
+		flags:         flags,
+	}
 }
 
-// Checkout validates the checkout request and delegates to the repository
-func (s *transactionService) Checkout(req models.CheckoutRequest) (*models.Transaction, error) {
+// Checkout validates the checkout request, prices each line at the customer's
+// price level and quantity, applies active promotion rules unless the
+// promotions flag is off at the store, and delegates persistence to the
+// repository. Stock is taken from the store the sale happens at. The sale
+// publishes transaction.created, and stock.low for the products it took
+// below their minimum stock.
+func (s *transactionService) Checkout(ctx context.Context, req models.CheckoutRequest) (*models.Transaction, error) {
 	if len(req.Items) == 0 {
-		return nil, errors.New("checkout items cannot be empty")
+		return nil, helpers.NewCodedError(helpers.CodeCheckoutEmpty)
+	}
+	if req.PriceLevel == "" {
+		req.PriceLevel = models.PriceLevelRetail
+	}
+	if !validPriceLevel(req.PriceLevel) {
+		return nil, helpers.NewCodedError(helpers.CodeInvalidPriceLevel)
+	}
+	storeID, err := resolveStore(ctx, s.storeRepo, req.StoreID)
+	if err != nil {
+		return nil, err
 	}
+	req.StoreID = storeID
 
 	for _, item := range req.Items {
 		if item.ProductID <= 0 {
-			return nil, errors.New("invalid product ID")
+			return nil, helpers.NewCodedError(helpers.CodeInvalidProductID)
 		}
 		if item.Quantity <= 0 {
-			return nil, errors.New("quantity must be greater than 0")
+			return nil, helpers.NewCodedError(helpers.CodeInvalidQuantity)
+		}
+	}
+
+	// Quantity breaks apply to the total bought of a product, even when it is
+	// scanned on several lines
+	quantities := make(map[int]int, len(req.Items))
+	productIDs := make([]int, 0, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := quantities[item.ProductID]; !ok {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	// The products and price tiers of all lines are read at once
+	found, err := s.productRepo.GetByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[int]models.Product, len(found))
+	for _, p := range found {
+		products[p.ID] = p
+	}
+	for _, id := range productIDs {
+		if _, ok := products[id]; !ok {
+			return nil, helpers.NewCodedError(helpers.CodeProductNotFound, id)
 		}
 	}
+	tiers, err := s.priceTierRepo.GetByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]models.TransactionDetail, 0, len(req.Items))
+	for _, item := range req.Items {
+		product := products[item.ProductID]
+		unitPrice := tierPrice(product.Price, tiers[product.ID], req.PriceLevel, quantities[product.ID])
 
-	return s.repo.CreateTransaction(req)
+		details = append(details, models.TransactionDetail{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   unitPrice,
+			Subtotal:    unitPrice * item.Quantity,
+			CategoryID:  product.CategoryID,
+		})
+	}
+
+	if s.flags.Enabled(ctx, models.FeatureFlagPromotions, req.StoreID) {
+		promotions, err := s.promotionRepo.GetActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		applyPromotions(details, promotions)
+	}
+
+	return s.repo.CreateTransaction(ctx, req, details)
 }
 
 // VoidTransaction voids a transaction and restores stock
-func (s *transactionService) VoidTransaction(id int) error {
+func (s *transactionService) VoidTransaction(ctx context.Context, id int) error {
 	if id <= 0 {
-		return errors.New("invalid transaction ID")
+		return helpers.NewValidationError("invalid transaction ID")
 	}
-	return s.repo.VoidTransaction(id)
+	return s.repo.VoidTransaction(ctx, id)
 }
 
-// GetDailySalesReport returns the sales summary for today
-func (s *transactionService) GetDailySalesReport() (*models.SalesReport, error) {
-	return s.repo.GetDailySalesReport()
+// GetDailySalesReport returns the sales summary for today at a store, or
+// across all stores when storeID is 0
+func (s *transactionService) GetDailySalesReport(ctx context.Context, storeID int) (*models.SalesReport, error) {
+	return s.repo.GetDailySalesReport(ctx, storeID)
 }
 
-// GetSalesReportByDateRange returns the sales summary for a given date range
-func (s *transactionService) GetSalesReportByDateRange(startDate, endDate string) (*models.SalesReport, error) {
-	if startDate == "" || endDate == "" {
-		return nil, errors.New("start_date and end_date are required")
+// GetSalesReportByDateRange returns the sales summary for the date range and
+// other criteria of filter
+func (s *transactionService) GetSalesReportByDateRange(ctx context.Context, filter models.ReportFilter) (*models.SalesReport, error) {
+	if filter.StartDate == "" || filter.EndDate == "" {
+		return nil, helpers.NewValidationError("start_date and end_date are required")
+	}
+	return s.repo.GetSalesReportByDateRange(ctx, filter)
+}
+
+// GetReportSummary returns an aggregated report with category breakdown for
+// the date range and other criteria of filter
+func (s *transactionService) GetReportSummary(ctx context.Context, filter models.ReportFilter) (*models.ReportSummary, error) {
+	if filter.StartDate == "" || filter.EndDate == "" {
+		return nil, helpers.NewValidationError("start_date and end_date are required")
 	}
-	return s.repo.GetSalesReportByDateRange(startDate, endDate)
+	return s.repo.GetReportSummary(ctx, filter)
 }
 
-// GetReportSummary returns an aggregated report with category breakdown
-func (s *transactionService) GetReportSummary(startDate, endDate string) (*models.ReportSummary, error) {
+// validateReportRange checks that a report period has two YYYY-MM-DD dates
+// in order
+func validateReportRange(startDate, endDate string) error {
 	if startDate == "" || endDate == "" {
-		return nil, errors.New("start_date and end_date are required")
+		return helpers.NewValidationError("start_date and end_date are required")
+	}
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return helpers.NewValidationError("start_date must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return helpers.NewValidationError("end_date must be in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return helpers.NewValidationError("end_date must not be before start_date")
+	}
+	return nil
+}
+
+// GetProfitReport returns revenue, COGS and gross margin per product, per
+// category and in total for the date range and other criteria of filter
+func (s *transactionService) GetProfitReport(ctx context.Context, filter models.ReportFilter) (*models.ProfitReport, error) {
+	if err := validateReportRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, err
+	}
+
+	products, err := s.repo.GetProductProfits(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ProfitReport{
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+		Categories: make([]models.CategoryProfit, 0),
+		Products:   products,
+	}
+	categoryIndex := make(map[int]int)
+	for i := range report.Products {
+		p := &report.Products[i]
+		p.GrossProfit = p.Revenue - p.COGS
+		p.MarginPercent = marginPercent(p.GrossProfit, p.Revenue)
+
+		report.Revenue += p.Revenue
+		report.COGS += p.COGS
+
+		// Uncategorized products are grouped under key 0
+		key := 0
+		if p.CategoryID != nil {
+			key = *p.CategoryID
+		}
+		idx, ok := categoryIndex[key]
+		if !ok {
+			idx = len(report.Categories)
+			categoryIndex[key] = idx
+			report.Categories = append(report.Categories, models.CategoryProfit{
+				CategoryID:   p.CategoryID,
+				CategoryName: p.CategoryName,
+			})
+		}
+		c := &report.Categories[idx]
+		c.QtySold += p.QtySold
+		c.Revenue += p.Revenue
+		c.COGS += p.COGS
+	}
+
+	for i := range report.Categories {
+		c := &report.Categories[i]
+		c.GrossProfit = c.Revenue - c.COGS
+		c.MarginPercent = marginPercent(c.GrossProfit, c.Revenue)
+	}
+	sort.SliceStable(report.Categories, func(i, j int) bool {
+		return report.Categories[i].GrossProfit > report.Categories[j].GrossProfit
+	})
+
+	report.GrossProfit = report.Revenue - report.COGS
+	report.MarginPercent = marginPercent(report.GrossProfit, report.Revenue)
+	return report, nil
+}
+
+// GetCategorySalesReport returns quantity, revenue and revenue share per
+// category for the date range and other criteria of filter. Transactions
+// counts distinct sales, so a sale spanning two categories counts once in the
+// total.
+func (s *transactionService) GetCategorySalesReport(ctx context.Context, filter models.ReportFilter) (*models.CategorySalesReport, error) {
+	if err := validateReportRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.repo.GetCategorySales(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.repo.GetSalesReportByDateRange(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CategorySalesReport{
+		StartDate:    filter.StartDate,
+		EndDate:      filter.EndDate,
+		Transactions: totals.TotalTransactions,
+		Categories:   categories,
 	}
-	return s.repo.GetReportSummary(startDate, endDate)
+	for _, c := range categories {
+		report.QtySold += c.QtySold
+		report.Revenue += c.Revenue
+	}
+	for i := range report.Categories {
+		c := &report.Categories[i]
+		c.SharePercent = marginPercent(c.Revenue, report.Revenue)
+	}
+	return report, nil
+}
+
+// Best sellers report limits
+const (
+	defaultBestSellersLimit = 10
+	maxBestSellersLimit     = 100
+)
+
+// GetBestSellersReport returns the top limit products by quantity sold for
+// the date range and other criteria of filter. limit 0 uses the default.
+func (s *transactionService) GetBestSellersReport(ctx context.Context, filter models.ReportFilter, limit int) (*models.BestSellersReport, error) {
+	if err := validateReportRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultBestSellersLimit
+	}
+	if limit < 1 || limit > maxBestSellersLimit {
+		return nil, helpers.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", maxBestSellersLimit))
+	}
+
+	products, err := s.repo.GetBestSellers(ctx, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BestSellersReport{StartDate: filter.StartDate, EndDate: filter.EndDate, Limit: limit, Products: products}, nil
+}
+
+// GetHourlySalesReport returns transactions and revenue per hour of a day
+// (YYYY-MM-DD, default today) matching the other criteria of filter, whose
+// dates are replaced by date, with the busiest hour for staffing
+func (s *transactionService) GetHourlySalesReport(ctx context.Context, date string, filter models.ReportFilter) (*models.HourlySalesReport, error) {
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, helpers.NewValidationError("date must be in YYYY-MM-DD format")
+	}
+
+	filter.StartDate, filter.EndDate = date, date
+	hours, err := s.repo.GetHourlySales(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.HourlySalesReport{Date: date, Hours: hours}
+	peak := -1
+	for i, h := range hours {
+		report.Transactions += h.Transactions
+		report.Revenue += h.Revenue
+		if h.Transactions > 0 && (peak < 0 || h.Transactions > hours[peak].Transactions) {
+			peak = i
+		}
+	}
+	if peak >= 0 {
+		report.PeakHour = &hours[peak].Hour
+	}
+	return report, nil
 }
 
-// GetAllTransactions returns a paginated list of transactions with optional date range
-func (s *transactionService) GetAllTransactions(page, limit int, startDate, endDate string) (*models.PaginatedTransactions, error) {
-	return s.repo.GetAllTransactions(page, limit, startDate, endDate)
+// GetSalesTimeSeries returns revenue, transactions and items sold per day,
+// week or month (default day) for the date range and other criteria of
+// filter, oldest first and without gaps
+func (s *transactionService) GetSalesTimeSeries(ctx context.Context, filter models.ReportFilter, granularity string) ([]models.SalesBucket, error) {
+	if err := validateReportRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, err
+	}
+	switch granularity {
+	case "":
+		granularity = models.GranularityDay
+	case models.GranularityDay, models.GranularityWeek, models.GranularityMonth:
+	default:
+		return nil, helpers.NewValidationError("granularity must be 'day', 'week' or 'month'")
+	}
+	return s.repo.GetSalesTimeSeries(ctx, filter, granularity)
+}
+
+// marginPercent returns profit as a percentage of revenue, rounded to two decimals
+func marginPercent(profit, revenue int) float64 {
+	if revenue == 0 {
+		return 0
+	}
+	return math.Round(float64(profit)*10000/float64(revenue)) / 100
 }
 
-// GetTransactionByID returns a single transaction with its details
-func (s *transactionService) GetTransactionByID(id int) (*models.Transaction, error) {
+// GetAllTransactions returns a paginated list of transactions with optional date range and receipt number filter
+func (s *transactionService) GetAllTransactions(ctx context.Context, params models.TransactionListParams) (*models.PaginatedTransactions, error) {
+	return s.repo.GetAllTransactions(ctx, params)
+}
+
+// GetTransactionByID returns a single transaction with its details, reading
+// archived transactions back from cold storage
+func (s *transactionService) GetTransactionByID(ctx context.Context, id int) (*models.Transaction, error) {
 	if id <= 0 {
-		return nil, errors.New("invalid transaction ID")
+		return nil, helpers.NewValidationError("invalid transaction ID")
 	}
-	return s.repo.GetTransactionByID(id)
+	return findTransaction(ctx, s.repo, s.archive, id)
+}
+
+// GetDashboardStats returns summary statistics for the admin dashboard for a
+// store, or across all stores when storeID is 0
+func (s *transactionService) GetDashboardStats(ctx context.Context, storeID int) (*models.DashboardStats, error) {
+	return s.repo.GetDashboardStats(ctx, storeID)
 }
 
-// GetDashboardStats returns summary statistics for the admin dashboard
-func (s *transactionService) GetDashboardStats() (*models.DashboardStats, error) {
-	return s.repo.GetDashboardStats()
+// ExportSalesReport writes the sales report matching filter as an XLSX
+// workbook with a Summary sheet (the figures of GetSalesReportByDateRange), a
+// Products sheet (quantity and revenue per product, best sellers first) and a
+// Days sheet (every day of the period). Everything is queried before the
+// first byte is written, so an error leaves w untouched.
+func (s *transactionService) ExportSalesReport(ctx context.Context, w io.Writer, filter models.ReportFilter) error {
+	if err := validateReportRange(filter.StartDate, filter.EndDate); err != nil {
+		return err
+	}
+
+	storeName := "All stores"
+	if filter.StoreID > 0 {
+		store, err := s.storeRepo.GetByID(ctx, filter.StoreID)
+		if err != nil {
+			return err
+		}
+		if store == nil {
+			return helpers.NewNotFoundError("store not found")
+		}
+		storeName = store.Name
+	}
+	report, err := s.repo.GetSalesReportByDateRange(ctx, filter)
+	if err != nil {
+		return err
+	}
+	products, err := s.repo.GetProductProfits(ctx, filter)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(products, func(a, b models.ProductProfit) int {
+		return b.Revenue - a.Revenue
+	})
+	days, err := s.repo.GetDailySales(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	x := helpers.NewXLSXWriter(w)
+	if err := x.Sheet("Summary", "metric", "value"); err != nil {
+		return err
+	}
+	summary := [][]interface{}{
+		{"start_date", filter.StartDate},
+		{"end_date", filter.EndDate},
+		{"store", storeName},
+		{"total_revenue", report.TotalRevenue},
+		{"total_cogs", report.TotalCOGS},
+		{"consignment_payable", report.ConsignmentPayable},
+		{"gross_profit", report.GrossProfit},
+		{"total_transactions", report.TotalTransactions},
+	}
+	if report.BestSellingProduct != nil {
+		summary = append(summary,
+			[]interface{}{"best_selling_product", report.BestSellingProduct.Name},
+			[]interface{}{"best_selling_qty_sold", report.BestSellingProduct.QtySold},
+		)
+	}
+	for _, row := range summary {
+		if err := x.Row(row...); err != nil {
+			return err
+		}
+	}
+
+	if err := x.Sheet("Products", "product_id", "product_name", "category_name", "qty_sold", "revenue"); err != nil {
+		return err
+	}
+	for _, p := range products {
+		if err := x.Row(p.ProductID, p.ProductName, p.CategoryName, p.QtySold, p.Revenue); err != nil {
+			return err
+		}
+	}
+
+	if err := x.Sheet("Days", "date", "transactions", "items_sold", "revenue"); err != nil {
+		return err
+	}
+	for _, d := range days {
+		if err := x.Row(d.Date, d.Transactions, d.ItemsSold, d.Revenue); err != nil {
+			return err
+		}
+	}
+	return x.Close()
 }
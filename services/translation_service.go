@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+)
+
+// TranslationService defines the interface for product/category translation logic
+type TranslationService interface {
+	GetTranslations(ctx context.Context, entity string, entityID int) ([]models.Translation, error)
+	UpsertTranslation(ctx context.Context, entity string, entityID int, locale string, input models.TranslationInput) (*models.Translation, error)
+	DeleteTranslation(ctx context.Context, entity string, entityID int, locale string) error
+	LocalizeProducts(ctx context.Context, products []models.Product, locales []string) error
+	LocalizeCategories(ctx context.Context, categories []models.Category, locales []string) error
+}
+
+// translationService implements TranslationService interface
+type translationService struct {
+	repo         repositories.TranslationRepository
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+// NewTranslationService creates a new translation service instance
+func NewTranslationService(repo repositories.TranslationRepository, productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository) TranslationService {
+	return &translationService{
+		repo:         repo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// ensureEntity checks that the product or category being translated exists
+func (s *translationService) ensureEntity(ctx context.Context, entity string, entityID int) error {
+	switch entity {
+	case repositories.TranslationEntityProduct:
+		product, err := s.productRepo.GetByID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			return helpers.NewNotFoundError("product not found")
+		}
+	case repositories.TranslationEntityCategory:
+		category, err := s.categoryRepo.GetByID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			return helpers.NewNotFoundError("category not found")
+		}
+	default:
+		return errors.New("unknown translation entity")
+	}
+	return nil
+}
+
+// GetTranslations returns all translations of a product or category
+func (s *translationService) GetTranslations(ctx context.Context, entity string, entityID int) ([]models.Translation, error) {
+	if err := s.ensureEntity(ctx, entity, entityID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(ctx, entity, entityID)
+}
+
+// UpsertTranslation validates the locale and creates or replaces a translation
+func (s *translationService) UpsertTranslation(ctx context.Context, entity string, entityID int, locale string, input models.TranslationInput) (*models.Translation, error) {
+	normalized := helpers.NormalizeLocale(locale)
+	if normalized == "" {
+		return nil, helpers.NewValidationError("invalid locale, expected a language tag such as 'id' or 'en-us'")
+	}
+	if input.Name == "" {
+		return nil, helpers.NewValidationError("translated name is required")
+	}
+	if err := s.ensureEntity(ctx, entity, entityID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Upsert(ctx, entity, entityID, models.Translation{
+		Locale:      normalized,
+		Name:        input.Name,
+		Description: input.Description,
+	})
+}
+
+// DeleteTranslation removes the translation of a product or category for one locale
+func (s *translationService) DeleteTranslation(ctx context.Context, entity string, entityID int, locale string) error {
+	normalized := helpers.NormalizeLocale(locale)
+	if normalized == "" {
+		return helpers.NewValidationError("invalid locale, expected a language tag such as 'id' or 'en-us'")
+	}
+	return s.repo.Delete(ctx, entity, entityID, normalized)
+}
+
+// LocalizeProducts replaces product and category names in place with the best
+// available translation for the given locale preferences. Products without a
+// matching translation keep their default name.
+func (s *translationService) LocalizeProducts(ctx context.Context, products []models.Product, locales []string) error {
+	if len(products) == 0 || len(locales) == 0 {
+		return nil
+	}
+
+	productIDs := make([]int, 0, len(products))
+	categoryIDs := make([]int, 0)
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+		if p.CategoryID != nil {
+			categoryIDs = append(categoryIDs, *p.CategoryID)
+		}
+	}
+
+	productNames, err := s.repo.FindBest(ctx, repositories.TranslationEntityProduct, productIDs, locales)
+	if err != nil {
+		return err
+	}
+	categoryNames, err := s.repo.FindBest(ctx, repositories.TranslationEntityCategory, categoryIDs, locales)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		if t, ok := productNames[products[i].ID]; ok {
+			products[i].Name = t.Name
+		}
+		if products[i].CategoryID != nil {
+			if t, ok := categoryNames[*products[i].CategoryID]; ok {
+				products[i].CategoryName = t.Name
+			}
+		}
+	}
+	return nil
+}
+
+// LocalizeCategories replaces category names and descriptions in place with
+// the best available translation for the given locale preferences
+func (s *translationService) LocalizeCategories(ctx context.Context, categories []models.Category, locales []string) error {
+	if len(categories) == 0 || len(locales) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(categories))
+	for _, c := range categories {
+		ids = append(ids, c.ID)
+	}
+
+	translations, err := s.repo.FindBest(ctx, repositories.TranslationEntityCategory, ids, locales)
+	if err != nil {
+		return err
+	}
+
+	for i := range categories {
+		if t, ok := translations[categories[i].ID]; ok {
+			categories[i].Name = t.Name
+			if t.Description != "" {
+				categories[i].Description = t.Description
+			}
+		}
+	}
+	return nil
+}
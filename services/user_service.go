@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"retail-core-api/helpers"
 	"retail-core-api/models"
 	"retail-core-api/repositories"
 
@@ -10,10 +12,10 @@ import (
 
 // UserService defines the interface for user business logic
 type UserService interface {
-	GetAll() ([]models.User, error)
-	GetByID(id int) (*models.User, error)
-	Update(id int, input models.UserInput) (*models.User, error)
-	Delete(id int) error
+	GetAll(ctx context.Context) ([]models.User, error)
+	GetByID(ctx context.Context, id int) (*models.User, error)
+	Update(ctx context.Context, id int, input models.UserInput) (*models.User, error)
+	Delete(ctx context.Context, id int) error
 }
 
 // userService implements UserService interface
@@ -27,18 +29,18 @@ func NewUserService(userRepo repositories.UserRepository) UserService {
 }
 
 // GetAll returns all users
-func (s *userService) GetAll() ([]models.User, error) {
-	return s.userRepo.GetAll()
+func (s *userService) GetAll(ctx context.Context) ([]models.User, error) {
+	return s.userRepo.GetAll(ctx)
 }
 
 // GetByID returns a user by ID
-func (s *userService) GetByID(id int) (*models.User, error) {
-	user, err := s.userRepo.GetByID(id)
+func (s *userService) GetByID(ctx context.Context, id int) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if user == nil {
-		return nil, errors.New("user not found")
+		return nil, helpers.NewNotFoundError("user not found")
 	}
 	// Clear password
 	user.Password = ""
@@ -46,13 +48,13 @@ func (s *userService) GetByID(id int) (*models.User, error) {
 }
 
 // Update updates a user
-func (s *userService) Update(id int, input models.UserInput) (*models.User, error) {
-	existing, err := s.userRepo.GetByID(id)
+func (s *userService) Update(ctx context.Context, id int, input models.UserInput) (*models.User, error) {
+	existing, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if existing == nil {
-		return nil, errors.New("user not found")
+		return nil, helpers.NewNotFoundError("user not found")
 	}
 
 	// If password is provided, hash it
@@ -66,7 +68,7 @@ func (s *userService) Update(id int, input models.UserInput) (*models.User, erro
 
 	// Validate role if provided
 	if input.Role != "" && input.Role != "owner" && input.Role != "cashier" {
-		return nil, errors.New("role must be 'owner' or 'cashier'")
+		return nil, helpers.NewValidationError("role must be 'owner' or 'cashier'")
 	}
 
 	user := models.User{
@@ -76,17 +78,17 @@ func (s *userService) Update(id int, input models.UserInput) (*models.User, erro
 		Role:     input.Role,
 	}
 
-	return s.userRepo.Update(id, user)
+	return s.userRepo.Update(ctx, id, user)
 }
 
 // Delete soft-deletes a user
-func (s *userService) Delete(id int) error {
-	existing, err := s.userRepo.GetByID(id)
+func (s *userService) Delete(ctx context.Context, id int) error {
+	existing, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 	if existing == nil {
-		return errors.New("user not found")
+		return helpers.NewNotFoundError("user not found")
 	}
-	return s.userRepo.Delete(id)
+	return s.userRepo.Delete(ctx, id)
 }
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"retail-core-api/chaos"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/repositories"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// webhookBatchSize caps how many deliveries one dispatch sends
+	webhookBatchSize = 100
+	// DefaultWebhookMaxAttempts is how often a delivery is tried before it
+	// fails, unless configured otherwise
+	DefaultWebhookMaxAttempts = 8
+	// webhookRetryBase is the wait after the first failed attempt; it doubles
+	// with every further attempt up to webhookRetryMax
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = time.Hour
+	// webhookTimeout bounds one delivery attempt
+	webhookTimeout = 10 * time.Second
+	// webhookResponseLimit is how much of a response body is kept per attempt
+	webhookResponseLimit = 512
+)
+
+// webhookEvents are the events webhooks can subscribe to
+var webhookEvents = []string{models.WebhookEventProductUpdated, models.WebhookEventTransactionCreated, models.WebhookEventStockLow}
+
+// EventPublisher is notified of the events integrators can subscribe to
+// that are not relayed from the outbox. Publishing never fails the
+// operation that raised the event.
+type EventPublisher interface {
+	Publish(ctx context.Context, event string, data interface{})
+}
+
+// WebhookService defines the interface for outgoing webhooks: their
+// registration, the events queued for them and their delivery. Sales and
+// stock changes reach it as domain events relayed from the outbox.
+type WebhookService interface {
+	EventPublisher
+	EventHandler
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, id int) (*models.Webhook, error)
+	CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, id int, input models.WebhookInput) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	GetDeliveries(ctx context.Context, params models.WebhookDeliveryParams) (*models.PaginatedWebhookDeliveries, error)
+	GetDelivery(ctx context.Context, id, deliveryID int) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, id, deliveryID int) (*models.WebhookDelivery, error)
+	DeliverDue(ctx context.Context) int
+}
+
+// webhookService implements WebhookService interface
+type webhookService struct {
+	repo        repositories.WebhookRepository
+	productRepo repositories.ProductRepository
+	client      *http.Client
+	injector    *chaos.Injector
+	maxAttempts int
+
+	running sync.Mutex
+}
+
+// NewWebhookService creates a new webhook service instance. A delivery fails
+// after maxAttempts attempts; 0 means DefaultWebhookMaxAttempts.
+func NewWebhookService(repo repositories.WebhookRepository, productRepo repositories.ProductRepository, injector *chaos.Injector, maxAttempts int) WebhookService {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultWebhookMaxAttempts
+	}
+	return &webhookService{
+		repo:        repo,
+		productRepo: productRepo,
+		client:      &http.Client{Timeout: webhookTimeout},
+		injector:    injector,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// GetWebhooks returns every webhook
+func (s *webhookService) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetWebhookByID returns a webhook by its ID
+func (s *webhookService) GetWebhookByID(ctx context.Context, id int) (*models.Webhook, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// webhookFromInput validates the input and builds the webhook it describes
+func webhookFromInput(input models.WebhookInput) (models.Webhook, error) {
+	webhook := models.Webhook{
+		URL:         strings.TrimSpace(input.URL),
+		Description: strings.TrimSpace(input.Description),
+		IsActive:    input.IsActive == nil || *input.IsActive,
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return webhook, helpers.NewValidationError("url must be an absolute http or https URL")
+	}
+
+	seen := make(map[string]bool)
+	for _, event := range input.Events {
+		event = strings.TrimSpace(event)
+		if !validWebhookEvent(event) {
+			return webhook, helpers.NewValidationError(fmt.Sprintf("events must be among %s", strings.Join(webhookEvents, ", ")))
+		}
+		if !seen[event] {
+			seen[event] = true
+			webhook.Events = append(webhook.Events, event)
+		}
+	}
+	if len(webhook.Events) == 0 {
+		return webhook, helpers.NewValidationError("events must not be empty")
+	}
+	return webhook, nil
+}
+
+// validWebhookEvent reports whether webhooks can subscribe to event
+func validWebhookEvent(event string) bool {
+	for _, e := range webhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWebhook registers a webhook with a new signing secret, which is only
+// returned here
+func (s *webhookService) CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, error) {
+	webhook, err := webhookFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = "whsec_" + randomHex(16)
+
+	created, err := s.repo.Create(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+// UpdateWebhook replaces a webhook's URL, events and state; its secret is kept
+func (s *webhookService) UpdateWebhook(ctx context.Context, id int, input models.WebhookInput) (*models.Webhook, error) {
+	webhook, err := webhookFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, id, webhook)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, helpers.NewNotFoundError("webhook not found")
+	}
+	return updated, nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int) error {
+	err := s.repo.Delete(ctx, id)
+	if err == sql.ErrNoRows {
+		return helpers.NewNotFoundError("webhook not found")
+	}
+	return err
+}
+
+// GetDeliveries returns the delivery log of a webhook, newest first,
+// optionally narrowed to one status
+func (s *webhookService) GetDeliveries(ctx context.Context, params models.WebhookDeliveryParams) (*models.PaginatedWebhookDeliveries, error) {
+	switch params.Status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed:
+	default:
+		return nil, helpers.NewValidationError("status must be pending, delivered or failed")
+	}
+
+	webhook, err := s.repo.GetByID(ctx, params.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, helpers.NewNotFoundError("webhook not found")
+	}
+	return s.repo.GetDeliveries(ctx, params)
+}
+
+// GetDelivery returns a delivery of a webhook with every attempt made at it
+func (s *webhookService) GetDelivery(ctx context.Context, id, deliveryID int) (*models.WebhookDelivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, helpers.NewNotFoundError("delivery not found")
+	}
+	return delivery, nil
+}
+
+// Redeliver queues a delivered or failed delivery of a webhook again
+func (s *webhookService) Redeliver(ctx context.Context, id, deliveryID int) (*models.WebhookDelivery, error) {
+	delivery, err := s.repo.Redeliver(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, helpers.NewNotFoundError("delivery not found or already pending")
+	}
+	return delivery, nil
+}
+
+// Publish queues event for every active webhook subscribed to it
+func (s *webhookService) Publish(ctx context.Context, event string, data interface{}) {
+	if err := s.enqueue(ctx, event, "evt_"+randomHex(8), time.Now(), data); err != nil {
+		slog.ErrorContext(ctx, "webhook event not queued", "component", "webhook", "event", event, "error", err)
+	}
+}
+
+// enqueue queues event for every active webhook subscribed to it. The body
+// sent is {"id","event","created_at","data"}; id is shared by the deliveries
+// of one event, so receivers can drop duplicates.
+func (s *webhookService) enqueue(ctx context.Context, event, eventID string, createdAt time.Time, data interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"id":         eventID,
+		"event":      event,
+		"created_at": createdAt.UTC(),
+		"data":       data,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.Enqueue(ctx, event, eventID, payload)
+	return err
+}
+
+// Consumer names the webhook service in the outbox
+func (s *webhookService) Consumer() string {
+	return "webhooks"
+}
+
+// HandleEvent turns a domain event relayed from the outbox into webhook
+// events: TransactionCreated into transaction.created, and a StockChanged
+// that takes a product's stock to or below its min_stock into stock.low.
+// The webhook event id derives from the outbox event, so an event relayed
+// twice reaches receivers with the same id.
+func (s *webhookService) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	eventID := fmt.Sprintf("evt_outbox_%d", event.ID)
+	switch event.Type {
+	case models.DomainEventTransactionCreated:
+		return s.enqueue(ctx, models.WebhookEventTransactionCreated, eventID, event.CreatedAt, event.Payload)
+	case models.DomainEventStockChanged:
+		var change models.StockChanged
+		if err := json.Unmarshal(event.Payload, &change); err != nil {
+			return err
+		}
+		return s.stockChanged(ctx, eventID, event.CreatedAt, change)
+	}
+	return nil
+}
+
+// stockChanged publishes stock.low when a reduction takes a product's stock
+// to or below its min_stock. Products that were already low do not raise it
+// again.
+func (s *webhookService) stockChanged(ctx context.Context, eventID string, createdAt time.Time, change models.StockChanged) error {
+	if change.QuantityDelta >= 0 {
+		return nil
+	}
+	subscribed, err := s.repo.HasSubscribers(ctx, models.WebhookEventStockLow)
+	if err != nil || !subscribed {
+		return err
+	}
+
+	product, err := s.productRepo.GetByID(ctx, change.ProductID)
+	if err != nil || product == nil {
+		return err
+	}
+	stock := change.BalanceAfter
+	if stock > product.MinStock || stock-change.QuantityDelta <= product.MinStock {
+		return nil
+	}
+	return s.enqueue(ctx, models.WebhookEventStockLow, eventID, createdAt, models.LowStockProduct{
+		ProductID:  product.ID,
+		Name:       product.Name,
+		SKU:        product.SKU,
+		Unit:       product.Unit,
+		CategoryID: product.CategoryID,
+		Stock:      stock,
+		MinStock:   product.MinStock,
+		Shortfall:  product.MinStock - stock,
+	})
+}
+
+// DeliverDue sends the deliveries whose next attempt is due and returns how
+// many were delivered. A failed attempt is retried with exponential backoff
+// until maxAttempts, after which the delivery fails.
+func (s *webhookService) DeliverDue(ctx context.Context) int {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	targets, err := s.repo.GetDue(ctx, webhookBatchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "loading due deliveries failed", "component", "webhook", "error", err)
+		return 0
+	}
+
+	delivered := 0
+	for _, t := range targets {
+		d, a := s.attempt(t)
+		if err := s.repo.SaveAttempt(ctx, d, a); err != nil {
+			slog.ErrorContext(ctx, "recording delivery attempt failed", "component", "webhook", "delivery_id", d.ID, "error", err)
+			continue
+		}
+		switch d.Status {
+		case models.WebhookDeliveryDelivered:
+			delivered++
+		case models.WebhookDeliveryFailed:
+			slog.WarnContext(ctx, "webhook delivery failed", "component", "webhook",
+				"delivery_id", d.ID, "event", d.Event, "webhook_id", d.WebhookID, "attempts", d.Attempts, "error", d.Error)
+		}
+	}
+	return delivered
+}
+
+// attempt POSTs a delivery to its webhook and returns the delivery with the
+// outcome, and the attempt for its history. The body is signed with the
+// webhook secret: X-Webhook-Signature is "sha256=" + hex HMAC-SHA256 of
+// "{X-Webhook-Timestamp}.{body}".
+func (s *webhookService) attempt(t models.WebhookTarget) (models.WebhookDelivery, models.WebhookAttempt) {
+	d := t.Delivery
+	d.Attempts++
+	d.ResponseStatus = 0
+	d.Error = ""
+
+	started := time.Now()
+	a := models.WebhookAttempt{DeliveryID: d.ID, Attempt: d.Attempts, AttemptedAt: started}
+
+	err := s.injector.Webhook()
+	if err == nil {
+		var req *http.Request
+		req, err = http.NewRequest(http.MethodPost, t.URL, bytes.NewReader(d.Payload))
+		if err == nil {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "retail-core-webhooks")
+			req.Header.Set("X-Webhook-Event", d.Event)
+			req.Header.Set("X-Webhook-Delivery", strconv.Itoa(d.ID))
+			req.Header.Set("X-Webhook-Timestamp", timestamp)
+			req.Header.Set("X-Webhook-Signature", "sha256="+signWebhook(t.Secret, timestamp, d.Payload))
+
+			var resp *http.Response
+			resp, err = s.client.Do(req)
+			if err == nil {
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+				resp.Body.Close()
+				d.ResponseStatus = resp.StatusCode
+				// Postgres text takes neither invalid UTF-8 nor NUL bytes
+				a.ResponseBody = strings.ReplaceAll(strings.ToValidUTF8(strings.TrimSpace(string(body)), ""), "\x00", "")
+				if resp.StatusCode >= 300 {
+					err = fmt.Errorf("%s: %s", resp.Status, a.ResponseBody)
+				}
+			}
+		}
+	}
+
+	now := time.Now()
+	a.DurationMs = int(now.Sub(started).Milliseconds())
+	a.ResponseStatus = d.ResponseStatus
+	if err == nil {
+		d.Status = models.WebhookDeliveryDelivered
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+		return d, a
+	}
+
+	d.Error = err.Error()
+	a.Error = d.Error
+	if d.Attempts >= s.maxAttempts {
+		d.Status = models.WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		return d, a
+	}
+	wait := webhookRetryBase << (d.Attempts - 1)
+	if wait > webhookRetryMax {
+		wait = webhookRetryMax
+	}
+	next := now.Add(wait)
+	d.Status = models.WebhookDeliveryPending
+	d.NextAttemptAt = &next
+	return d, a
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "{timestamp}.{body}" under secret
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex returns n random bytes as hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// StartWebhookDispatcher delivers due webhook deliveries in the background
+// every interval
+func StartWebhookDispatcher(service WebhookService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			service.DeliverDue(context.Background())
+		}
+	}()
+}
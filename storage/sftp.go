@@ -0,0 +1,388 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTP packet types and flags used by SFTPStore (protocol version 3)
+const (
+	sftpInit     = 1
+	sftpVersion  = 2
+	sftpOpen     = 3
+	sftpClose    = 4
+	sftpRead     = 5
+	sftpWrite    = 6
+	sftpRemove   = 13
+	sftpMkdir    = 14
+	sftpRename   = 18
+	sftpStatus   = 101
+	sftpHandle   = 102
+	sftpData     = 103
+	sftpFlagRead = 0x01
+	// write, create and truncate
+	sftpFlagWrite = 0x02 | 0x08 | 0x10
+
+	sftpStatusOK     = 0
+	sftpStatusEOF    = 1
+	sftpStatusNoFile = 2
+
+	sftpChunkSize = 32 * 1024
+)
+
+// SFTPConfig holds the connection settings of an SFTP server
+type SFTPConfig struct {
+	Addr       string // host:port; port 22 when omitted
+	User       string
+	Password   string
+	PrivateKey string // PEM private key, used instead of the password when set
+	HostKey    string // server public key in authorized_keys format, e.g. "ssh-ed25519 AAAA..."
+	Dir        string // remote directory objects are written below
+}
+
+// SFTPStore keeps objects as files below a directory of an SFTP server. It
+// connects per operation, which suits infrequent uploads such as scheduled
+// reports.
+type SFTPStore struct {
+	cfg       SFTPConfig
+	sshConfig *ssh.ClientConfig
+}
+
+// NewSFTPStore creates a store for the configured server. The server's host
+// key is required so uploads cannot be intercepted.
+func NewSFTPStore(cfg SFTPConfig) (*SFTPStore, error) {
+	if cfg.Addr == "" || cfg.User == "" {
+		return nil, errors.New("storage: SFTP address and user are required")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Addr); err != nil {
+		cfg.Addr = net.JoinHostPort(cfg.Addr, "22")
+	}
+	if cfg.HostKey == "" {
+		return nil, errors.New("storage: SFTP host key is required")
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.HostKey))
+	if err != nil {
+		return nil, fmt.Errorf("storage: invalid SFTP host key: %w", err)
+	}
+
+	var auth ssh.AuthMethod
+	switch {
+	case cfg.PrivateKey != "":
+		signer, err := ssh.ParsePrivateKey([]byte(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("storage: invalid SFTP private key: %w", err)
+		}
+		auth = ssh.PublicKeys(signer)
+	case cfg.Password != "":
+		auth = ssh.Password(cfg.Password)
+	default:
+		return nil, errors.New("storage: SFTP password or private key is required")
+	}
+
+	return &SFTPStore{
+		cfg: cfg,
+		sshConfig: &ssh.ClientConfig{
+			User:            cfg.User,
+			Auth:            []ssh.AuthMethod{auth},
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         30 * time.Second,
+		},
+	}, nil
+}
+
+// path maps a key to a file below the remote directory
+func (s *SFTPStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", errors.New("storage: invalid key")
+	}
+	return path.Join(s.cfg.Dir, clean), nil
+}
+
+// Put uploads an object, replacing any existing one. The file is written
+// next to its destination and renamed, so readers never see a partial object.
+func (s *SFTPStore) Put(ctx context.Context, key string, data []byte) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	return s.session(ctx, func(c *sftpConn) error {
+		c.mkdirAll(path.Dir(target))
+
+		tmp := target + ".tmp"
+		handle, err := c.open(tmp, sftpFlagWrite)
+		if err != nil {
+			return err
+		}
+		for offset := 0; offset < len(data); offset += sftpChunkSize {
+			end := min(offset+sftpChunkSize, len(data))
+			if err := c.write(handle, uint64(offset), data[offset:end]); err != nil {
+				c.close(handle)
+				return err
+			}
+		}
+		if err := c.close(handle); err != nil {
+			return err
+		}
+
+		// SFTP v3 renames fail when the target exists
+		c.call(sftpRemove, sftpString(target))
+		_, err = c.expectStatus(c.call(sftpRename, sftpString(tmp), sftpString(target)))
+		return err
+	})
+}
+
+// Get downloads an object
+func (s *SFTPStore) Get(ctx context.Context, key string) ([]byte, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err = s.session(ctx, func(c *sftpConn) error {
+		handle, err := c.open(target, sftpFlagRead)
+		if err != nil {
+			return err
+		}
+		defer c.close(handle)
+
+		for {
+			typ, payload, err := c.call(sftpRead, sftpString(handle), sftpUint64(uint64(len(data))), sftpUint32(sftpChunkSize))
+			if err != nil {
+				return err
+			}
+			if typ == sftpStatus {
+				if code, _ := sftpStatusCode(payload); code == sftpStatusEOF {
+					return nil
+				}
+				return sftpStatusError(payload)
+			}
+			if typ != sftpData {
+				return fmt.Errorf("storage: unexpected SFTP packet %d", typ)
+			}
+			chunk, _ := sftpReadString(payload)
+			data = append(data, chunk...)
+		}
+	})
+	return data, err
+}
+
+// session connects, starts the sftp subsystem and runs fn
+func (s *SFTPStore) session(ctx context.Context, fn func(*sftpConn) error) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("storage: SFTP connect: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Minute)
+	}
+	conn.SetDeadline(deadline)
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, s.cfg.Addr, s.sshConfig)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("storage: SFTP handshake: %w", err)
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	w, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		return fmt.Errorf("storage: SFTP subsystem: %w", err)
+	}
+
+	c := &sftpConn{w: w, r: r}
+	if err := c.send(sftpInit, sftpUint32(3)); err != nil {
+		return err
+	}
+	if typ, _, err := c.recv(); err != nil {
+		return err
+	} else if typ != sftpVersion {
+		return fmt.Errorf("storage: unexpected SFTP packet %d during init", typ)
+	}
+	return fn(c)
+}
+
+// sftpConn exchanges SFTP packets over an ssh session, one request at a time
+type sftpConn struct {
+	w      io.Writer
+	r      io.Reader
+	nextID uint32
+}
+
+// send writes one packet
+func (c *sftpConn) send(typ byte, fields ...[]byte) error {
+	length := 1
+	for _, f := range fields {
+		length += len(f)
+	}
+	packet := make([]byte, 0, 4+length)
+	packet = binary.BigEndian.AppendUint32(packet, uint32(length))
+	packet = append(packet, typ)
+	for _, f := range fields {
+		packet = append(packet, f...)
+	}
+	_, err := c.w.Write(packet)
+	return err
+}
+
+// recv reads one packet
+func (c *sftpConn) recv() (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if length < 1 || length > 1<<20 {
+		return 0, nil, fmt.Errorf("storage: invalid SFTP packet length %d", length)
+	}
+	payload := make([]byte, length-1)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[4], payload, nil
+}
+
+// call sends a request with a fresh id and returns the response payload
+// after the id
+func (c *sftpConn) call(typ byte, fields ...[]byte) (byte, []byte, error) {
+	c.nextID++
+	id := c.nextID
+	if err := c.send(typ, append([][]byte{sftpUint32(id)}, fields...)...); err != nil {
+		return 0, nil, err
+	}
+	respType, payload, err := c.recv()
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(payload) < 4 || binary.BigEndian.Uint32(payload) != id {
+		return 0, nil, errors.New("storage: SFTP response id mismatch")
+	}
+	return respType, payload[4:], nil
+}
+
+// expectStatus turns a STATUS response into nil or an error
+func (c *sftpConn) expectStatus(typ byte, payload []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if typ != sftpStatus {
+		return nil, fmt.Errorf("storage: unexpected SFTP packet %d", typ)
+	}
+	if code, _ := sftpStatusCode(payload); code != sftpStatusOK {
+		return nil, sftpStatusError(payload)
+	}
+	return payload, nil
+}
+
+// open opens a remote file and returns its handle
+func (c *sftpConn) open(name string, flags uint32) (string, error) {
+	typ, payload, err := c.call(sftpOpen, sftpString(name), sftpUint32(flags), sftpUint32(0))
+	if err != nil {
+		return "", err
+	}
+	if typ == sftpStatus {
+		if code, _ := sftpStatusCode(payload); code == sftpStatusNoFile && flags == sftpFlagRead {
+			return "", ErrNotFound
+		}
+		return "", sftpStatusError(payload)
+	}
+	if typ != sftpHandle {
+		return "", fmt.Errorf("storage: unexpected SFTP packet %d", typ)
+	}
+	handle, _ := sftpReadString(payload)
+	return handle, nil
+}
+
+// write writes data at offset of an open file
+func (c *sftpConn) write(handle string, offset uint64, data []byte) error {
+	_, err := c.expectStatus(c.call(sftpWrite, sftpString(handle), sftpUint64(offset), sftpString(string(data))))
+	return err
+}
+
+// close closes an open file
+func (c *sftpConn) close(handle string) error {
+	_, err := c.expectStatus(c.call(sftpClose, sftpString(handle)))
+	return err
+}
+
+// mkdirAll creates every directory of dir, ignoring those that already exist
+func (c *sftpConn) mkdirAll(dir string) {
+	current := ""
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		if current == "" && !strings.HasPrefix(dir, "/") {
+			current = part
+		} else {
+			current += "/" + part
+		}
+		c.call(sftpMkdir, sftpString(current), sftpUint32(0))
+	}
+}
+
+func sftpUint32(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
+
+func sftpUint64(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+func sftpString(s string) []byte {
+	return append(sftpUint32(uint32(len(s))), s...)
+}
+
+// sftpReadString reads a length-prefixed string and returns the rest
+func sftpReadString(b []byte) (string, []byte) {
+	if len(b) < 4 {
+		return "", nil
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint32(len(b)-4) < n {
+		return "", nil
+	}
+	return string(b[4 : 4+n]), b[4+n:]
+}
+
+// sftpStatusCode reads the code of a STATUS payload
+func sftpStatusCode(payload []byte) (uint32, []byte) {
+	if len(payload) < 4 {
+		return 0, nil
+	}
+	return binary.BigEndian.Uint32(payload), payload[4:]
+}
+
+// sftpStatusError reports a failed STATUS response with the server's message
+func sftpStatusError(payload []byte) error {
+	code, rest := sftpStatusCode(payload)
+	message, _ := sftpReadString(rest)
+	return fmt.Errorf("storage: SFTP error %d: %s", code, message)
+}
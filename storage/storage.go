@@ -0,0 +1,96 @@
+// Package storage provides object storage for cold data such as archived
+// transactions and for scheduled report files: a local directory for
+// development, any S3-compatible bucket (AWS S3, MinIO, Cloudflare R2,
+// Supabase Storage) or an SFTP server in production.
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("storage: object not found")
+
+// Store reads and writes whole objects by key. Keys are slash-separated
+// paths such as "transactions/2024/01/42.json".
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// FileStore keeps objects as files below a directory
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a store rooted at dir. The directory is created on
+// the first write.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// path maps a key to a file below the store directory
+func (s *FileStore) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", errors.New("storage: invalid key")
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+// Put writes an object, replacing any existing one. The file is written
+// next to its destination and renamed, so readers never see a partial object.
+func (s *FileStore) Put(_ context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get reads an object
+func (s *FileStore) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// prefixedStore keeps the objects of a Store below a key prefix
+type prefixedStore struct {
+	store  Store
+	prefix string
+}
+
+// WithPrefix returns a Store that keeps its objects below prefix in store,
+// e.g. to give each tenant its own folder of a shared bucket
+func WithPrefix(store Store, prefix string) Store {
+	return &prefixedStore{store: store, prefix: strings.Trim(prefix, "/") + "/"}
+}
+
+// Put writes an object below the prefix
+func (s *prefixedStore) Put(ctx context.Context, key string, data []byte) error {
+	return s.store.Put(ctx, s.prefix+key, data)
+}
+
+// Get reads an object below the prefix
+func (s *prefixedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	return s.store.Get(ctx, s.prefix+key)
+}
@@ -0,0 +1,50 @@
+// Package telemetry exports OpenTelemetry traces over OTLP/HTTP, so a slow
+// request (a checkout, say) can be broken down into its handler, its queries,
+// its commit and the serialization of its response. HTTP requests are traced
+// by the otelgin middleware, database calls by the otelsql driver wrapper (see
+// database.openDB) and response serialization by helpers.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Setup installs the global tracer provider, batching spans to the OTLP/HTTP
+// collector at endpoint (e.g. http://otel-collector:4318) under serviceName.
+// Incoming W3C trace context is honored, so the spans join the caller's
+// trace. When endpoint is empty tracing stays off and the instrumentation
+// records nothing. The returned function flushes buffered spans and stops the
+// exporter.
+func Setup(ctx context.Context, endpoint, serviceName string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// As with OTEL_EXPORTER_OTLP_ENDPOINT in other SDKs, endpoint is the
+	// collector's base URL and traces go to /v1/traces below it
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"))
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, errors.Join(err, exporter.Shutdown(ctx))
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,120 @@
+// Package tenancy routes the requests of a multi-tenant deployment to the app
+// of the tenant they belong to. Every tenant runs its own copy of the API on a
+// connection pool bound to it (see database.OpenTenantDB), so once a request
+// reaches a tenant's app, row-level security keeps it inside that tenant.
+package tenancy
+
+import (
+	"log/slog"
+	"net/http"
+	"retail-core-api/helpers"
+	"retail-core-api/models"
+	"retail-core-api/services"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Router resolves the tenant of each request and hands it to that tenant's app:
+//
+//   - a JWT (Authorization header or token cookie) or a shared receipt link
+//     names its tenant; tokens from before multi-tenancy belong to the
+//     default tenant
+//   - otherwise the X-API-Key header, or the api_key query parameter for
+//     clients such as EventSource that cannot set headers, names it
+//   - requests with neither are served by the default tenant
+//
+// A token and an API key naming different tenants are rejected. Paths below
+// /platform go to the platform handler instead.
+type Router struct {
+	tenants  services.TenantService
+	platform http.Handler
+
+	mu   sync.RWMutex
+	apps map[int]http.Handler
+}
+
+// NewRouter creates a router; tenant apps are added with Mount
+func NewRouter(tenants services.TenantService, platform http.Handler) *Router {
+	return &Router{tenants: tenants, platform: platform, apps: make(map[int]http.Handler)}
+}
+
+// Mount serves a tenant's requests with app
+func (r *Router) Mount(tenantID int, app http.Handler) {
+	r.mu.Lock()
+	r.apps[tenantID] = app
+	r.mu.Unlock()
+}
+
+// ServeHTTP dispatches a request to its tenant's app
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/platform" || strings.HasPrefix(req.URL.Path, "/platform/") {
+		r.platform.ServeHTTP(w, req)
+		return
+	}
+
+	tenantID := models.DefaultTenantID
+	keyTenant := 0
+	if key := apiKey(req); key != "" {
+		tenant, err := r.tenants.ResolveAPIKey(req.Context(), key)
+		if err != nil {
+			slog.ErrorContext(req.Context(), "failed to resolve API key", "component", "tenancy", "error", err)
+			helpers.WriteError(w, req, http.StatusInternalServerError, "Failed to resolve tenant")
+			return
+		}
+		if tenant == nil {
+			helpers.WriteError(w, req, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		keyTenant = tenant.ID
+		tenantID = tenant.ID
+	}
+	if tokenTenant, ok := requestTokenTenant(req); ok {
+		if keyTenant != 0 && tokenTenant != keyTenant {
+			helpers.WriteError(w, req, http.StatusUnauthorized, "Token belongs to another tenant")
+			return
+		}
+		tenantID = tokenTenant
+	}
+
+	r.mu.RLock()
+	app := r.apps[tenantID]
+	r.mu.RUnlock()
+	if app == nil {
+		helpers.WriteError(w, req, http.StatusUnauthorized, "Unknown tenant")
+		return
+	}
+	app.ServeHTTP(w, req)
+}
+
+// apiKey returns the tenant API key sent with a request
+func apiKey(req *http.Request) string {
+	if key := req.Header.Get(models.APIKeyHeader); key != "" {
+		return key
+	}
+	return req.URL.Query().Get("api_key")
+}
+
+// requestTokenTenant returns the tenant named by the request's JWT or shared
+// receipt token. The signature is not checked here: the tenant's app verifies
+// the token and rejects it unless it was issued for that tenant.
+func requestTokenTenant(req *http.Request) (int, bool) {
+	token := ""
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	} else if strings.HasPrefix(req.URL.Path, "/receipts/") {
+		token = strings.TrimPrefix(req.URL.Path, "/receipts/")
+	} else if cookie, err := req.Cookie("token"); err == nil {
+		token = cookie.Value
+	}
+	if token == "" {
+		return 0, false
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return 0, false
+	}
+	return helpers.ClaimTenantID(claims), true
+}
@@ -0,0 +1,85 @@
+// Package tlsserver lets the API serve HTTPS itself rather than behind a
+// terminating proxy, for stores that run it on a LAN box the tablets reach
+// directly. The certificate comes from files (a company CA, mkcert, or one
+// renewed by an external tool) or from Let's Encrypt through autocert, for
+// boxes with a public domain name.
+package tlsserver
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Certificate is a certificate and key read from files. Reload reads them
+// again, so a renewed certificate is served without a restart.
+type Certificate struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Pointer[tls.Certificate]
+}
+
+// LoadCertificate reads the PEM certificate (chain) in certFile and its key
+// in keyFile
+func LoadCertificate(certFile, keyFile string) (*Certificate, error) {
+	c := &Certificate{certFile: certFile, keyFile: keyFile}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Reload reads the certificate and key again. New connections get the new
+// certificate; when the files cannot be read, the current one stays.
+func (c *Certificate) Reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.cert.Store(&cert)
+	return nil
+}
+
+// TLSConfig returns the server configuration serving the latest certificate
+func (c *Certificate) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.cert.Load(), nil
+		},
+	}
+}
+
+// Autocert returns a manager that obtains and renews certificates for
+// domains from Let's Encrypt, keeping them in cacheDir so a restart does not
+// request new ones. Let's Encrypt must reach the server on port 443 (through
+// the manager's TLSConfig) or port 80 (through its HTTPHandler) to validate
+// a domain; email, when set, gets notices about expiring certificates.
+func Autocert(domains []string, email, cacheDir string) *autocert.Manager {
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Cache:      autocert.DirCache(cacheDir),
+		Email:      email,
+	}
+}
+
+// Redirect returns a handler that sends plain HTTP requests to the same
+// URL over HTTPS on httpsPort
+func Redirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := (&url.URL{Host: r.Host}).Hostname()
+		switch {
+		case httpsPort != "443":
+			host = net.JoinHostPort(host, httpsPort)
+		case strings.Contains(host, ":"):
+			host = "[" + host + "]"
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}